@@ -0,0 +1,13 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// fileInode is unsupported on platforms without inode numbers
+func fileInode(info os.FileInfo) (uint64, error) {
+	return 0, fmt.Errorf("inode checks are not supported on this platform")
+}
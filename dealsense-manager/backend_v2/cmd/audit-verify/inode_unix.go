@@ -0,0 +1,18 @@
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileInode returns the inode number backing the file
+func fileInode(info os.FileInfo) (uint64, error) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, fmt.Errorf("unable to read inode for %s", info.Name())
+	}
+	return uint64(stat.Ino), nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/config"
+)
+
+// verifyState is the checkpoint recorded after each successful verification
+type verifyState struct {
+	Inode      uint64 `json:"inode"`
+	Size       int64  `json:"size"`
+	PrefixHash string `json:"prefix_hash"`
+	Entries    int    `json:"entries"`
+}
+
+func main() {
+	logPath := flag.String("log", "", "path to the LLM audit log (defaults to AUDIT_LOG_PATH)")
+	statePath := flag.String("state", "", "path to the verification checkpoint (defaults to <log>.verify.json)")
+	flag.Parse()
+
+	if *logPath == "" {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			fail("failed to load configuration: %v", err)
+		}
+		*logPath = cfg.LLM.AuditLogPath
+	}
+	if *logPath == "" {
+		fail("no audit log path given; pass -log or set AUDIT_LOG_PATH")
+	}
+	if *statePath == "" {
+		*statePath = *logPath + ".verify.json"
+	}
+
+	current, err := inspectLog(*logPath)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	previous, err := loadState(*statePath)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if previous != nil {
+		if current.Inode != previous.Inode {
+			fail("audit log was replaced: inode changed from %d to %d", previous.Inode, current.Inode)
+		}
+		if current.Size < previous.Size {
+			fail("audit log was truncated: size shrank from %d to %d bytes", previous.Size, current.Size)
+		}
+		prefixHash, err := hashPrefix(*logPath, previous.Size)
+		if err != nil {
+			fail("%v", err)
+		}
+		if prefixHash != previous.PrefixHash {
+			fail("audit log was modified: first %d bytes no longer match the last checkpoint", previous.Size)
+		}
+	}
+
+	if err := saveState(*statePath, current); err != nil {
+		fail("%v", err)
+	}
+
+	if previous == nil {
+		fmt.Printf("✅ Audit log %s verified (%d entries); checkpoint created at %s\n", *logPath, current.Entries, *statePath)
+	} else {
+		fmt.Printf("✅ Audit log %s is append-only (%d entries, %d new since last check)\n", *logPath, current.Entries, current.Entries-previous.Entries)
+	}
+}
+
+// inspectLog validates every entry in the log and returns its current state
+func inspectLog(path string) (*verifyState, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	inode, err := fileInode(info)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	reader := bufio.NewReader(io.TeeReader(io.LimitReader(file, info.Size()), hasher))
+	entries := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry llm.AuditEntry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+				return nil, fmt.Errorf("audit log entry %d is not valid JSON: %w", entries+1, jsonErr)
+			}
+			entries++
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+	}
+
+	return &verifyState{
+		Inode:      inode,
+		Size:       info.Size(),
+		PrefixHash: hex.EncodeToString(hasher.Sum(nil)),
+		Entries:    entries,
+	}, nil
+}
+
+// hashPrefix returns the SHA-256 hash of the first size bytes of the file
+func hashPrefix(path string, size int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.CopyN(hasher, file, size); err != nil {
+		return "", fmt.Errorf("failed to hash audit log: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// loadState reads the previous checkpoint, returning nil if none exists yet
+func loadState(path string) (*verifyState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var state verifyState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	return &state, nil
+}
+
+// saveState writes the checkpoint for the next verification run
+func saveState(path string, state *verifyState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "❌ "+format+"\n", args...)
+	os.Exit(1)
+}
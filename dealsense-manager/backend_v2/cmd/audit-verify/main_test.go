@@ -0,0 +1,84 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// appendLog appends lines to the audit log at path
+func appendLog(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for _, line := range lines {
+		if _, err := file.WriteString(line + "\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInspectLogAndPrefixHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendLog(t, path, `{"prompt_id": "a"}`, "", `{"prompt_id": "b"}`)
+
+	first, err := inspectLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Entries != 2 || first.Inode == 0 {
+		t.Errorf("state = %+v, want 2 entries and the file's inode", first)
+	}
+
+	// Appending keeps the hash of the checked prefix
+	appendLog(t, path, `{"prompt_id": "c"}`)
+	second, err := inspectLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Entries != 3 || second.Inode != first.Inode || second.Size <= first.Size {
+		t.Errorf("state after append = %+v", second)
+	}
+	if prefix, err := hashPrefix(path, first.Size); err != nil || prefix != first.PrefixHash {
+		t.Errorf("hashPrefix() = %q, %v, want %q", prefix, err, first.PrefixHash)
+	}
+
+	// Rewriting an earlier entry changes the prefix hash
+	raw, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(raw), `"a"`, `"x"`, 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if prefix, _ := hashPrefix(path, first.Size); prefix == first.PrefixHash {
+		t.Error("modified log has the same prefix hash")
+	}
+}
+
+func TestInspectLogRejectsInvalidEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendLog(t, path, `{"prompt_id": "a"}`, "not json")
+
+	if _, err := inspectLog(path); err == nil || !strings.Contains(err.Error(), "entry 2") {
+		t.Errorf("inspectLog() error = %v, want entry 2 reported", err)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log.verify.json")
+	if state, err := loadState(path); state != nil || err != nil {
+		t.Fatalf("loadState() without a checkpoint = %+v, %v", state, err)
+	}
+
+	want := &verifyState{Inode: 42, Size: 100, PrefixHash: "abc", Entries: 3}
+	if err := saveState(path, want); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := loadState(path); err != nil || *got != *want {
+		t.Errorf("loadState() = %+v, %v, want %+v", got, err, want)
+	}
+}
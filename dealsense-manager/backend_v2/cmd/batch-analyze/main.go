@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/batch"
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/models"
+)
+
+func main() {
+	input := flag.String("input", "data/analysis/*.json", "glob of saved meeting analysis files to re-analyze")
+	output := flag.String("output", "", "directory for re-analyzed files (defaults to alongside each input file)")
+	concurrency := flag.Int("concurrency", 4, "number of files analyzed in parallel")
+	provider := flag.String("provider", "google", "LLM provider")
+	model := flag.String("model", "gemini-2.5-flash-lite", "LLM model")
+	promptFile := flag.String("prompt", "", "file containing custom analysis instructions")
+	keyQuotes := flag.Bool("key-quotes", false, "also extract verbatim key quotes")
+	flag.Parse()
+
+	// Load configuration for API keys and LLM settings
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logrus.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := config.SetupLogging(&cfg.Logging); err != nil {
+		logrus.Fatalf("Failed to setup logging: %v", err)
+	}
+	llm.SetDefaultGoogleFallbacks(cfg.LLM.GeminiFallbackModels)
+	llm.SetDefaultGoogleCacheTTL(cfg.LLM.GeminiCacheTTL)
+	llm.SetDefaultGroundingDomains(cfg.LLM.GroundingBlacklistDomains, cfg.LLM.GroundingWhitelistDomains)
+
+	agentConfig := models.AgentConfig{
+		Name:             "Batch Analysis",
+		LLMProvider:      models.LLMProvider(*provider),
+		LLMModel:         *model,
+		ConversationMode: models.ConversationModeAnalyst,
+		EnableKeyQuotes:  *keyQuotes,
+	}
+	if *promptFile != "" {
+		prompt, err := os.ReadFile(*promptFile)
+		if err != nil {
+			logrus.Fatalf("Failed to read prompt file: %v", err)
+		}
+		customPrompt := string(prompt)
+		agentConfig.CustomPrompt = &customPrompt
+	}
+
+	// Stop handing out new files on interrupt; files already in progress finish
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	analyzer := batch.NewBatchAnalyzer(*output)
+	report := analyzer.RunBatch(ctx, *input, agentConfig, *concurrency)
+
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		logrus.Fatalf("Failed to encode batch report: %v", err)
+	}
+	fmt.Println(string(encoded))
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
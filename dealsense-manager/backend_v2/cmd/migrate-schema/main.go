@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"joinly-manager/internal/client"
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/storage"
+)
+
+func main() {
+	dir := flag.String("dir", "data/analysis", "directory containing saved analysis files")
+	dryRun := flag.Bool("dry-run", false, "report files that need migrating without rewriting them")
+	strict := flag.Bool("strict", false, "abort when a file fails schema validation instead of warning")
+	flag.Parse()
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Invalid directory %s: %v\n", *dir, err)
+		os.Exit(1)
+	}
+
+	migrated, failed := 0, 0
+	for _, file := range files {
+		changed, err := migrateFile(file, *dryRun, *strict)
+		var validationErr *client.SchemaValidationError
+		if errors.As(err, &validationErr) && *strict {
+			fmt.Fprintf(os.Stderr, "❌ %s failed schema validation:\n", file)
+			for _, violation := range validationErr.Violations {
+				fmt.Fprintf(os.Stderr, "   - %s\n", violation)
+			}
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", file, err)
+			failed++
+			continue
+		}
+		if changed {
+			fmt.Printf("🔄 %s migrated to v%d\n", file, migration.CurrentSchemaVersion)
+			migrated++
+		}
+	}
+
+	fmt.Printf("✅ %d files checked, %d migrated, %d failed\n", len(files), migrated, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// migrateFile upgrades a single file in place, returning whether it needed migrating. Files failing schema
+// validation are reported as a *client.SchemaValidationError when strict, and migrated with a warning otherwise.
+func migrateFile(path string, dryRun, strict bool) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("failed to parse file: %w", err)
+	}
+	migrated, err := migration.Migrate(data)
+	if err != nil {
+		return false, err
+	}
+	if err := client.ValidateAnalysisData(migrated); err != nil {
+		if strict {
+			return false, err
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  %s: %v\n", path, err)
+	}
+
+	if migration.SchemaVersion(doc) == migration.CurrentSchemaVersion {
+		return false, nil
+	}
+
+	analysis, err := migration.MigrateAnalysisData[client.AnalysisData](data)
+	if err != nil {
+		return false, err
+	}
+	if dryRun {
+		return true, nil
+	}
+
+	// Encode through AnalysisData so the file matches the format written by the analyst agent
+	output, err := json.MarshalIndent(analysis, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to encode migrated data: %w", err)
+	}
+
+	// Write to a temporary file first so an interrupted run never leaves a truncated file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, output, 0644); err != nil {
+		return false, fmt.Errorf("failed to write migrated file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return false, fmt.Errorf("failed to replace file: %w", err)
+	}
+	// Keep the stored checksum current, or retention would treat the migrated file as tampered with
+	if err := storage.WriteChecksum(path, output); err != nil {
+		return false, err
+	}
+	return true, nil
+}
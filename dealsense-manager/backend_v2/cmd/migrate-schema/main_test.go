@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"joinly-manager/internal/client"
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/storage"
+)
+
+// legacyAnalysis is a v0 analysis file with a null transcript and a numeric topic start time
+const legacyAnalysis = `{"meeting_id": "m1", "start_time": "2024-05-01T10:00:00Z", "last_updated": "2024-05-01T10:00:00Z",
+	"transcript": null, "topics": [{"topic": "Budget", "start_time": 30}]}`
+
+// writeAnalysis writes content to a file in a temporary directory and returns its path
+func writeAnalysis(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "analysis.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMigrateFileUpgradesLegacyFile(t *testing.T) {
+	path := writeAnalysis(t, legacyAnalysis)
+
+	changed, err := migrateFile(path, false, true)
+	if err != nil || !changed {
+		t.Fatalf("migrateFile() = %v, %v, want the file migrated", changed, err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if migration.SchemaVersion(doc) != migration.CurrentSchemaVersion {
+		t.Errorf("schema version = %d, want %d", migration.SchemaVersion(doc), migration.CurrentSchemaVersion)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+	if _, err := os.Stat(storage.ChecksumPath(path)); err != nil || storage.VerifyChecksum(path) != nil {
+		t.Errorf("checksum of the migrated file not stored: %v", err)
+	}
+
+	// A migrated file is left alone on the next run
+	if changed, err := migrateFile(path, false, true); err != nil || changed {
+		t.Errorf("second migrateFile() = %v, %v, want no change", changed, err)
+	}
+}
+
+func TestMigrateFileDryRun(t *testing.T) {
+	path := writeAnalysis(t, legacyAnalysis)
+
+	if changed, err := migrateFile(path, true, false); err != nil || !changed {
+		t.Fatalf("migrateFile() = %v, %v, want the file reported", changed, err)
+	}
+	if raw, _ := os.ReadFile(path); string(raw) != legacyAnalysis {
+		t.Error("dry run rewrote the file")
+	}
+}
+
+func TestMigrateFileSchemaViolations(t *testing.T) {
+	invalid := `{"start_time": "2024-05-01T10:00:00Z", "last_updated": "2024-05-01T10:00:00Z", "transcript": []}`
+
+	path := writeAnalysis(t, invalid)
+	var validationErr *client.SchemaValidationError
+	if _, err := migrateFile(path, false, true); !errors.As(err, &validationErr) {
+		t.Errorf("strict migrateFile() error = %v, want a *client.SchemaValidationError", err)
+	}
+	if raw, _ := os.ReadFile(path); string(raw) != invalid {
+		t.Error("strict run rewrote an invalid file")
+	}
+
+	// Without strict, invalid files are migrated with a warning
+	if changed, err := migrateFile(path, false, false); err != nil || !changed {
+		t.Errorf("migrateFile() = %v, %v, want the file migrated", changed, err)
+	}
+}
+
+func TestMigrateFileRejectsInvalidJSON(t *testing.T) {
+	if _, err := migrateFile(writeAnalysis(t, "{not json"), false, false); err == nil {
+		t.Error("migrateFile() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/models"
+	"joinly-manager/internal/webhook"
+)
+
+// statusInterval limits how often the agent status is fetched for the progress line
+const statusInterval = time.Second
+
+// replayClient talks to the manager's REST API
+type replayClient struct {
+	baseURL string
+	secret  string
+	http    *http.Client
+}
+
+func main() {
+	srtFile := flag.String("srt-file", "", "SRT file of the meeting to replay")
+	agentConfigFile := flag.String("agent-config", "", "JSON agent configuration used to create the analyst agent")
+	agentID := flag.String("agent-id", "", "existing analyst agent to replay into instead of creating one")
+	speed := flag.Float64("speed", 1.0, "replay speed relative to the meeting, e.g. 2.0 for twice realtime")
+	apiBaseURL := flag.String("api-base-url", "http://localhost:8001", "base URL of the manager API")
+	apiSecret := flag.String("api-secret", os.Getenv("API_SECRET"), "API secret sent as a bearer token")
+	webhookSecret := flag.String("webhook-secret", "", "webhook secret of the --agent-id agent, which the API doesn't return")
+	flag.Parse()
+
+	if *srtFile == "" || (*agentConfigFile == "" && *agentID == "") {
+		fmt.Fprintln(os.Stderr, "usage: replay-meeting --srt-file meeting.srt (--agent-config agent.json | --agent-id ID [--webhook-secret SECRET]) [--speed 2.0] [--api-base-url URL]")
+		os.Exit(2)
+	}
+	if *speed <= 0 {
+		logrus.Fatal("--speed must be positive")
+	}
+
+	file, err := os.Open(*srtFile)
+	if err != nil {
+		logrus.Fatalf("Failed to open SRT file: %v", err)
+	}
+	subtitles, err := parseSRT(file)
+	file.Close()
+	if err != nil {
+		logrus.Fatalf("Failed to parse SRT file: %v", err)
+	}
+	if len(subtitles) == 0 {
+		logrus.Fatal("SRT file has no subtitles")
+	}
+
+	api := &replayClient{
+		baseURL: strings.TrimRight(*apiBaseURL, "/"),
+		secret:  *apiSecret,
+		http:    &http.Client{Timeout: 10 * time.Second},
+	}
+
+	var agentConfig models.AgentConfig
+	if *agentID == "" {
+		raw, err := os.ReadFile(*agentConfigFile)
+		if err != nil {
+			logrus.Fatalf("Failed to read agent config: %v", err)
+		}
+		if err := json.Unmarshal(raw, &agentConfig); err != nil {
+			logrus.Fatalf("Invalid agent config: %v", err)
+		}
+		agentConfig.ConversationMode = models.ConversationModeAnalyst
+
+		agent, err := api.createAgent(agentConfig)
+		if err != nil {
+			logrus.Fatalf("Failed to create agent: %v", err)
+		}
+		*agentID = agent.ID
+		if err := api.do(http.MethodPost, "/agents/"+agent.ID+"/start", nil, nil, nil); err != nil {
+			logrus.Fatalf("Failed to start agent: %v", err)
+		}
+		logrus.Infof("Created analyst agent %s", agent.ID)
+	} else {
+		agent, err := api.getAgent(*agentID)
+		if err != nil {
+			logrus.Fatalf("Failed to get agent: %v", err)
+		}
+		agentConfig = agent.Config
+		agentConfig.WebhookSecret = *webhookSecret
+	}
+
+	// Stop replaying on interrupt
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	sent, err := replay(ctx, api, *agentID, agentConfig.WebhookSecret, subtitles, *speed)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		logrus.Fatalf("Replay stopped after %d of %d utterances: %v", sent, len(subtitles), err)
+	}
+	logrus.Infof("Replayed %d utterances into agent %s", sent, *agentID)
+}
+
+// replay posts each subtitle to the agent at its time in the meeting, scaled by speed, returning how many
+// were sent
+func replay(ctx context.Context, api *replayClient, agentID, webhookSecret string, subtitles []subtitle, speed float64) (int, error) {
+	started := time.Now()
+	total := subtitles[len(subtitles)-1].End
+	status := "unknown"
+	var statusFetched time.Time
+
+	for i, sub := range subtitles {
+		wait := time.Duration(float64(sub.Start)/speed) - time.Since(started)
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return i, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		// Utterances are timestamped with their time in the meeting, as if it had started when the replay did
+		segment := map[string]interface{}{
+			"speaker":   sub.Speaker,
+			"text":      sub.Text,
+			"timestamp": float64(started.Add(sub.Start).Unix()),
+		}
+		if err := api.postUtterance(agentID, webhookSecret, segment); err != nil {
+			return i, err
+		}
+
+		if time.Since(statusFetched) >= statusInterval || i == len(subtitles)-1 {
+			if agent, err := api.getAgent(agentID); err == nil {
+				status = string(agent.Status)
+			}
+			statusFetched = time.Now()
+		}
+		fmt.Fprintf(os.Stderr, "\r%s / %s  utterance %d/%d  agent %s   ",
+			formatClock(sub.Start), formatClock(total), i+1, len(subtitles), status)
+	}
+	return len(subtitles), nil
+}
+
+// formatClock formats a meeting offset as H:MM:SS
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// createAgent creates an agent from config
+func (c *replayClient) createAgent(config models.AgentConfig) (*models.Agent, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var agent models.Agent
+	if err := c.do(http.MethodPost, "/agents", body, nil, &agent); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// getAgent fetches an agent and its status
+func (c *replayClient) getAgent(agentID string) (*models.Agent, error) {
+	var agent models.Agent
+	if err := c.do(http.MethodGet, "/agents/"+agentID, nil, nil, &agent); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// postUtterance posts one transcript segment, signing it when the agent has a webhook secret
+func (c *replayClient) postUtterance(agentID, webhookSecret string, segment map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"segments": []map[string]interface{}{segment}})
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{}
+	if webhookSecret != "" {
+		headers[webhook.SignatureHeader] = webhook.Sign(webhookSecret, body)
+	}
+	return c.do(http.MethodPost, "/agents/"+agentID+"/transcript", body, headers, nil)
+}
+
+// do sends a request to the API, decoding a JSON response into out when it is not nil
+func (c *replayClient) do(method, path string, body []byte, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequest(method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		req.Header.Set("Authorization", "Bearer "+c.secret)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed with status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/webhook"
+)
+
+// replayServer is a mock API recording when each utterance arrived, relative to the replay start
+type replayServer struct {
+	*httptest.Server
+	started time.Time
+
+	mu       sync.Mutex
+	arrivals []time.Duration
+	texts    []string
+}
+
+func newReplayServer(t *testing.T) *replayServer {
+	t.Helper()
+	s := &replayServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer api-secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/agents/agent-1":
+			w.Write([]byte(`{"id": "agent-1", "status": "running"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/agents/agent-1/transcript":
+			body, _ := io.ReadAll(r.Body)
+			if err := webhook.VerifyWebhookSignature("hook-secret", body, r.Header.Get(webhook.SignatureHeader)); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			var payload struct {
+				Segments []struct {
+					Text string `json:"text"`
+				} `json:"segments"`
+			}
+			json.Unmarshal(body, &payload)
+			s.mu.Lock()
+			s.arrivals = append(s.arrivals, time.Since(s.started))
+			s.texts = append(s.texts, payload.Segments[0].Text)
+			s.mu.Unlock()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestReplayScalesTimingBySpeed(t *testing.T) {
+	server := newReplayServer(t)
+	api := &replayClient{baseURL: server.URL, secret: "api-secret", http: server.Client()}
+	subtitles := []subtitle{
+		{Start: 0, End: time.Second, Speaker: "Alice", Text: "First"},
+		{Start: time.Second, End: 2 * time.Second, Speaker: "Bob", Text: "Second"},
+		{Start: 2 * time.Second, End: 3 * time.Second, Speaker: "Alice", Text: "Third"},
+	}
+
+	server.started = time.Now()
+	sent, err := replay(context.Background(), api, "agent-1", "hook-secret", subtitles, 10)
+	if err != nil || sent != 3 {
+		t.Fatalf("replay() = %d, %v, want all 3 utterances sent", sent, err)
+	}
+
+	// At ten times realtime the utterances are 100ms apart
+	for i, arrival := range server.arrivals {
+		want := time.Duration(i) * 100 * time.Millisecond
+		if arrival < want || arrival > want+300*time.Millisecond {
+			t.Errorf("utterance %d arrived after %s, want about %s", i, arrival, want)
+		}
+	}
+	if len(server.texts) != 3 || server.texts[2] != "Third" {
+		t.Errorf("texts = %v", server.texts)
+	}
+}
+
+func TestReplayStopsWhenCancelled(t *testing.T) {
+	server := newReplayServer(t)
+	api := &replayClient{baseURL: server.URL, secret: "api-secret", http: server.Client()}
+	subtitles := []subtitle{
+		{Start: 0, Speaker: "Alice", Text: "First"},
+		{Start: time.Hour, Speaker: "Bob", Text: "Much later"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	server.started = time.Now()
+	sent, err := replay(ctx, api, "agent-1", "hook-secret", subtitles, 1)
+	if sent != 1 || err == nil {
+		t.Errorf("replay() = %d, %v, want 1 sent and the context error", sent, err)
+	}
+}
+
+func TestReplayReportsRejectedUtterances(t *testing.T) {
+	server := newReplayServer(t)
+	api := &replayClient{baseURL: server.URL, secret: "api-secret", http: server.Client()}
+
+	sent, err := replay(context.Background(), api, "agent-1", "wrong-secret", []subtitle{{Text: "Hello"}}, 1)
+	if sent != 0 || err == nil {
+		t.Errorf("replay() = %d, %v, want the signature rejection", sent, err)
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	if got := formatClock(time.Hour + 2*time.Minute + 3400*time.Millisecond); got != "1:02:03" {
+		t.Errorf("formatClock() = %q, want 1:02:03", got)
+	}
+}
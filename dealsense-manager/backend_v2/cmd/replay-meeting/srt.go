@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// subtitle is one SRT cue
+type subtitle struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string
+	Text    string
+}
+
+var (
+	srtTiming = regexp.MustCompile(`^(\d+):(\d{2}):(\d{2})[,.](\d{3})\s*-->\s*(\d+):(\d{2}):(\d{2})[,.](\d{3})`)
+	// srtSpeaker matches a "Name: text" cue, as produced by most transcription exports
+	srtSpeaker = regexp.MustCompile(`^([\p{L}][\p{L}0-9 .'-]{0,39}):\s+(.+)$`)
+	srtTags    = regexp.MustCompile(`</?[a-zA-Z][^>]*>|\{\\[^}]*\}`)
+)
+
+// parseSRT reads the cues of an SRT file in order. Cues without a "Name:" prefix keep the speaker of the
+// previous cue, or "Participant" at the start.
+func parseSRT(r io.Reader) ([]subtitle, error) {
+	var subtitles []subtitle
+	var current *subtitle
+	var text []string
+	speaker := "Participant"
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Text = strings.TrimSpace(srtTags.ReplaceAllString(strings.Join(text, " "), ""))
+		if match := srtSpeaker.FindStringSubmatch(current.Text); match != nil {
+			speaker, current.Text = strings.TrimSpace(match[1]), match[2]
+		}
+		current.Speaker = speaker
+		if current.Text != "" {
+			subtitles = append(subtitles, *current)
+		}
+		current, text = nil, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case line == "":
+			flush()
+		case current == nil && srtTiming.MatchString(line):
+			match := srtTiming.FindStringSubmatch(line)
+			current = &subtitle{Start: srtTimecode(match[1:5]), End: srtTimecode(match[5:9])}
+		case current == nil:
+			// Cue index before the timing line
+			if _, err := strconv.Atoi(line); err != nil {
+				return nil, fmt.Errorf("line %d: expected cue number or timing, got %q", lineNumber, line)
+			}
+		default:
+			text = append(text, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return subtitles, nil
+}
+
+// srtTimecode converts hours, minutes, seconds and milliseconds to a duration
+func srtTimecode(parts []string) time.Duration {
+	units := []time.Duration{time.Hour, time.Minute, time.Second, time.Millisecond}
+	var total time.Duration
+	for i, part := range parts {
+		value, _ := strconv.Atoi(part)
+		total += time.Duration(value) * units[i]
+	}
+	return total
+}
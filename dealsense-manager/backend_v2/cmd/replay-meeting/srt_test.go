@@ -0,0 +1,44 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSRT(t *testing.T) {
+	srt := "\ufeff1\r\n00:00:01,000 --> 00:00:03,500\r\nAlice: Let's get <i>started</i>.\r\n\r\n" +
+		"2\n00:00:04.000 --> 00:00:06.000\nStill Alice, over\ntwo lines\n\n" +
+		"3\n00:01:00,000 --> 00:01:02,000\nBob: Sounds good\n\n" +
+		"4\n00:01:03,000 --> 00:01:04,000\n{\\an8}\n"
+
+	subtitles, err := parseSRT(strings.NewReader(srt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []subtitle{
+		{Start: time.Second, End: 3500 * time.Millisecond, Speaker: "Alice", Text: "Let's get started."},
+		{Start: 4 * time.Second, End: 6 * time.Second, Speaker: "Alice", Text: "Still Alice, over two lines"},
+		{Start: time.Minute, End: time.Minute + 2*time.Second, Speaker: "Bob", Text: "Sounds good"},
+	}
+	if !reflect.DeepEqual(subtitles, want) {
+		t.Errorf("parseSRT() = %+v, want %+v", subtitles, want)
+	}
+}
+
+func TestParseSRTDefaultSpeaker(t *testing.T) {
+	subtitles, err := parseSRT(strings.NewReader("1\n00:00:00,000 --> 00:00:01,000\nHello there\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subtitles) != 1 || subtitles[0].Speaker != "Participant" {
+		t.Errorf("parseSRT() = %+v, want a Participant cue", subtitles)
+	}
+}
+
+func TestParseSRTRejectsGarbage(t *testing.T) {
+	if _, err := parseSRT(strings.NewReader("not a subtitle file\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("parseSRT() = %v, want an error naming line 1", err)
+	}
+}
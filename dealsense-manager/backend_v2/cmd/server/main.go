@@ -4,16 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"joinly-manager/internal/api"
+	"joinly-manager/internal/client/llm"
 	"joinly-manager/internal/config"
 	"joinly-manager/internal/manager"
+	"joinly-manager/internal/shutdown"
 )
 
 func main() {
@@ -23,6 +22,20 @@ func main() {
 		logrus.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Refuse to start with settings that are known to be wrong
+	invalid := false
+	for _, problem := range config.ValidateConfig(cfg) {
+		if problem.Severity == config.SeverityError {
+			logrus.Errorf("Invalid configuration: %v", problem)
+			invalid = true
+		} else {
+			logrus.Warnf("Configuration warning: %v", problem)
+		}
+	}
+	if invalid {
+		logrus.Fatal("Configuration is invalid, not starting")
+	}
+
 	// Setup logging
 	if err := config.SetupLogging(&cfg.Logging); err != nil {
 		logrus.Fatalf("Failed to setup logging: %v", err)
@@ -30,9 +43,34 @@ func main() {
 
 	logrus.Info("Starting DealSense Backend v2")
 
+	// Configure Gemini model fallbacks for quota exhaustion
+	llm.SetDefaultGoogleFallbacks(cfg.LLM.GeminiFallbackModels)
+
+	// Answer identical ungrounded Gemini prompts from cache
+	llm.SetDefaultGoogleCacheTTL(cfg.LLM.GeminiCacheTTL)
+	defer llm.SetDefaultGoogleCacheTTL(0)
+
+	// Filter grounding citations by source domain
+	llm.SetDefaultGroundingDomains(cfg.LLM.GroundingBlacklistDomains, cfg.LLM.GroundingWhitelistDomains)
+
+	// Setup LLM call audit log if configured
+	if cfg.LLM.AuditLogPath != "" {
+		auditLogger, err := llm.NewFileAuditLogger(cfg.LLM.AuditLogPath)
+		if err != nil {
+			logrus.Fatalf("Failed to open audit log: %v", err)
+		}
+		defer auditLogger.Close()
+		llm.SetDefaultAuditLogger(auditLogger)
+		logrus.Infof("LLM audit logging enabled at %s", cfg.LLM.AuditLogPath)
+	}
+
 	// Create agent manager
 	agentManager := manager.NewAgentManager(cfg)
 
+	// Analyst agents finalize their analysis before the process exits
+	shutdownManager := shutdown.NewShutdownManager(cfg.Server.ShutdownTimeout)
+	agentManager.SetShutdownManager(shutdownManager)
+
 	// Start agent manager
 	if err := agentManager.Start(); err != nil {
 		logrus.Fatalf("Failed to start agent manager: %v", err)
@@ -58,10 +96,11 @@ func main() {
 	}()
 
 	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	logrus.Info("Shutting down server...")
+	sig := shutdownManager.WaitForSignal()
+	logrus.Infof("Received %s, shutting down server...", sig)
+
+	// Let in-flight analyses complete and finalize every analyst agent
+	shutdownManager.Shutdown()
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"joinly-manager/internal/client"
+)
+
+// AnalysisDiff describes what changed between two analysis runs
+type AnalysisDiff struct {
+	AddedActionItems   []string `json:"added_action_items"`
+	RemovedActionItems []string `json:"removed_action_items"`
+	ChangedSummary     bool     `json:"changed_summary"`
+	AddedKeyPoints     []string `json:"added_key_points"`
+	RemovedKeyPoints   []string `json:"removed_key_points"`
+	SentimentChanged   bool     `json:"sentiment_changed"`
+	NewTopics          []string `json:"new_topics"`
+}
+
+// DiffAnalysis compares two analysis results and reports what changed from before to after.
+// Action items are compared by description, so a reworded item shows up as removed and added.
+func DiffAnalysis(before, after *client.AnalysisData) *AnalysisDiff {
+	if before == nil {
+		before = &client.AnalysisData{}
+	}
+	if after == nil {
+		after = &client.AnalysisData{}
+	}
+
+	beforeItems := actionItemDescriptions(before.ActionItems)
+	afterItems := actionItemDescriptions(after.ActionItems)
+
+	return &AnalysisDiff{
+		AddedActionItems:   difference(afterItems, beforeItems),
+		RemovedActionItems: difference(beforeItems, afterItems),
+		ChangedSummary:     before.Summary != after.Summary,
+		AddedKeyPoints:     difference(after.KeyPoints, before.KeyPoints),
+		RemovedKeyPoints:   difference(before.KeyPoints, after.KeyPoints),
+		SentimentChanged:   before.Sentiment != after.Sentiment,
+		NewTopics:          difference(topicNames(after.Topics), topicNames(before.Topics)),
+	}
+}
+
+// difference returns the values in a that are not present in b, preserving the order of a
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, value := range b {
+		exclude[value] = true
+	}
+
+	result := []string{}
+	seen := make(map[string]bool, len(a))
+	for _, value := range a {
+		if exclude[value] || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// actionItemDescriptions returns the descriptions of the given action items
+func actionItemDescriptions(items []client.ActionItem) []string {
+	descriptions := make([]string, 0, len(items))
+	for _, item := range items {
+		descriptions = append(descriptions, item.Description)
+	}
+	return descriptions
+}
+
+// topicNames returns the names of the given topics
+func topicNames(topics []client.TopicDiscussion) []string {
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Topic)
+	}
+	return names
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client"
+)
+
+func TestDiffAnalysis(t *testing.T) {
+	before := &client.AnalysisData{
+		Summary:     "Launch planning",
+		Sentiment:   "neutral",
+		KeyPoints:   []string{"Launch moves to Friday", "Budget approved"},
+		ActionItems: []client.ActionItem{{Description: "Send the deck to legal"}, {Description: "Book the venue"}},
+		Topics:      []client.TopicDiscussion{{Topic: "Launch"}},
+	}
+	after := &client.AnalysisData{
+		Summary:     "Launch planning and hiring",
+		Sentiment:   "positive",
+		KeyPoints:   []string{"Launch moves to Friday", "Two engineers to hire"},
+		ActionItems: []client.ActionItem{{Description: "Book the venue"}, {Description: "Post the job ads"}},
+		Topics:      []client.TopicDiscussion{{Topic: "Launch"}, {Topic: "Hiring"}},
+	}
+
+	diff := DiffAnalysis(before, after)
+	want := &AnalysisDiff{
+		AddedActionItems:   []string{"Post the job ads"},
+		RemovedActionItems: []string{"Send the deck to legal"},
+		ChangedSummary:     true,
+		AddedKeyPoints:     []string{"Two engineers to hire"},
+		RemovedKeyPoints:   []string{"Budget approved"},
+		SentimentChanged:   true,
+		NewTopics:          []string{"Hiring"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffAnalysis() = %+v, want %+v", diff, want)
+	}
+}
+
+func TestDiffAnalysisRewordedActionItem(t *testing.T) {
+	before := &client.AnalysisData{ActionItems: []client.ActionItem{{Description: "Send the deck to legal"}}}
+	after := &client.AnalysisData{ActionItems: []client.ActionItem{{Description: "Send the pitch deck to legal by Friday"}}}
+
+	diff := DiffAnalysis(before, after)
+	if !reflect.DeepEqual(diff.RemovedActionItems, []string{"Send the deck to legal"}) ||
+		!reflect.DeepEqual(diff.AddedActionItems, []string{"Send the pitch deck to legal by Friday"}) {
+		t.Errorf("added %q, removed %q, want the reworded item removed and added", diff.AddedActionItems, diff.RemovedActionItems)
+	}
+}
+
+func TestDiffAnalysisUnchanged(t *testing.T) {
+	data := &client.AnalysisData{
+		Summary:     "Launch planning",
+		KeyPoints:   []string{"Launch moves to Friday"},
+		ActionItems: []client.ActionItem{{Description: "Book the venue"}, {Description: "Book the venue"}},
+	}
+
+	diff := DiffAnalysis(data, data)
+	if len(diff.AddedActionItems) != 0 || len(diff.RemovedActionItems) != 0 || diff.ChangedSummary ||
+		len(diff.AddedKeyPoints) != 0 || len(diff.RemovedKeyPoints) != 0 || diff.SentimentChanged || len(diff.NewTopics) != 0 {
+		t.Errorf("DiffAnalysis() = %+v, want no changes", diff)
+	}
+
+	if diff := DiffAnalysis(nil, data); !reflect.DeepEqual(diff.AddedActionItems, []string{"Book the venue"}) {
+		t.Errorf("added = %q, want a duplicated item listed once", diff.AddedActionItems)
+	}
+}
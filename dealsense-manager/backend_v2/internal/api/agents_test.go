@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+func TestConcurrentCreateAndDeleteAgents(t *testing.T) {
+	router, _ := newTestRouter(t)
+
+	const workers, rounds = 5, 10
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				body := fmt.Sprintf(`{"name": "Agent %d-%d", "meeting_url": "https://meet.example.com/shared", "conversation_mode": "analyst"}`, w, i)
+				recorder := serve(router, http.MethodPost, "/agents", []byte(body), true, nil)
+				if recorder.Code != http.StatusCreated {
+					t.Errorf("create status = %d: %s", recorder.Code, recorder.Body)
+					return
+				}
+				var agent models.Agent
+				if err := json.Unmarshal(recorder.Body.Bytes(), &agent); err != nil {
+					t.Error(err)
+					return
+				}
+				if recorder := serve(router, http.MethodDelete, "/agents/"+agent.ID, nil, true, nil); recorder.Code != http.StatusOK {
+					t.Errorf("delete status = %d: %s", recorder.Code, recorder.Body)
+				}
+				// A second delete of the same agent must fail cleanly
+				if recorder := serve(router, http.MethodDelete, "/agents/"+agent.ID, nil, true, nil); recorder.Code != http.StatusNotFound {
+					t.Errorf("repeated delete status = %d, want 404", recorder.Code)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if recorder := serve(router, http.MethodGet, "/agents", nil, true, nil); recorder.Code != http.StatusOK {
+					t.Errorf("list status = %d", recorder.Code)
+				}
+				serve(router, http.MethodGet, "/meetings", nil, true, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	recorder := serve(router, http.MethodGet, "/agents", nil, true, nil)
+	var agents []models.Agent
+	if err := json.Unmarshal(recorder.Body.Bytes(), &agents); err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 0 {
+		t.Errorf("%d agents left after deleting every created agent", len(agents))
+	}
+
+	recorder = serve(router, http.MethodGet, "/meetings", nil, true, nil)
+	var meetings []models.MeetingInfo
+	if err := json.Unmarshal(recorder.Body.Bytes(), &meetings); err != nil {
+		t.Fatalf("meetings response %s: %v", recorder.Body, err)
+	}
+	if len(meetings) != 0 {
+		t.Errorf("meetings = %+v, want the shared meeting removed with its last agent", meetings)
+	}
+}
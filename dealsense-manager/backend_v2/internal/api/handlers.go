@@ -1,6 +1,11 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,19 +13,28 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"joinly-manager/internal/analysis"
+	"joinly-manager/internal/client"
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/event"
+	"joinly-manager/internal/export"
 	"joinly-manager/internal/manager"
 	"joinly-manager/internal/models"
+	"joinly-manager/internal/templates"
+	"joinly-manager/internal/webhook"
 )
 
 // Handler holds the dependencies for HTTP handlers
 type Handler struct {
-	agentManager *manager.AgentManager
+	agentManager        *manager.AgentManager
+	reportFlushInterval time.Duration // Least time between flushes of a streamed report
 }
 
 // NewHandler creates a new handler instance
-func NewHandler(agentManager *manager.AgentManager) *Handler {
+func NewHandler(agentManager *manager.AgentManager, reportFlushInterval time.Duration) *Handler {
 	return &Handler{
-		agentManager: agentManager,
+		agentManager:        agentManager,
+		reportFlushInterval: reportFlushInterval,
 	}
 }
 
@@ -38,6 +52,28 @@ func (h *Handler) ListAgents(c *gin.Context) {
 	c.JSON(http.StatusOK, agents)
 }
 
+// agentQuickView is an agent's analysis counters without the rest of its analysis
+type agentQuickView struct {
+	AgentID    string             `json:"agent_id"`
+	QuickStats *client.QuickStats `json:"quick_stats"` // nil for agents that aren't analyzing a meeting
+}
+
+// ListAgentQuickViews handles GET /agents/quick
+func (h *Handler) ListAgentQuickViews(c *gin.Context) {
+	agents := h.agentManager.ListAgents()
+
+	views := make([]agentQuickView, 0, len(agents))
+	for _, agent := range agents {
+		view := agentQuickView{AgentID: agent.ID}
+		if analyst := h.agentManager.GetAnalystAgent(agent.ID); analyst != nil {
+			view.QuickStats = analyst.GetQuickStats()
+		}
+		views = append(views, view)
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
 // CreateAgent handles POST /agents
 func (h *Handler) CreateAgent(c *gin.Context) {
 	var config models.AgentConfig
@@ -77,6 +113,12 @@ func (h *Handler) CreateAgent(c *gin.Context) {
 	}
 }
 
+// agentDetails is an agent along with live meeting metrics when it is an active analyst
+type agentDetails struct {
+	*models.Agent
+	LiveStats *client.LiveStats `json:"live_stats,omitempty"`
+}
+
 // GetAgent handles GET /agents/{agent_id}
 func (h *Handler) GetAgent(c *gin.Context) {
 	agentID := c.Param("agent_id")
@@ -87,6 +129,30 @@ func (h *Handler) GetAgent(c *gin.Context) {
 		return
 	}
 
+	details := agentDetails{Agent: agent}
+	if analyst := h.agentManager.GetAnalystAgent(agentID); analyst != nil {
+		details.LiveStats = analyst.GetLiveStats()
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// UpdateAgentConfig handles PUT /agents/{agent_id}/config
+func (h *Handler) UpdateAgentConfig(c *gin.Context) {
+	agentID := c.Param("agent_id")
+
+	var update models.AgentConfigUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	agent, err := h.agentManager.UpdateAgentConfig(agentID, update)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return
+	}
+
 	c.JSON(http.StatusOK, agent)
 }
 
@@ -200,6 +266,91 @@ func (h *Handler) ListMeetings(c *gin.Context) {
 	c.JSON(http.StatusOK, meetings)
 }
 
+// GetMeetingBriefing handles GET /meetings/briefing, writing a pre-meeting briefing with the LLM of the
+// analyst agent that will join the meeting
+func (h *Handler) GetMeetingBriefing(c *gin.Context) {
+	var req struct {
+		AgentID         string              `json:"agent_id" binding:"required"`
+		Agenda          []models.AgendaItem `json:"agenda"`
+		Participants    []string            `json:"participants"`
+		PriorMeetingIDs []string            `json:"prior_meeting_ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	analyst := h.getAnalystAgentByID(c, req.AgentID)
+	if analyst == nil {
+		return
+	}
+
+	briefing, err := analyst.GenerateBriefing(c.Request.Context(), req.Agenda, req.Participants, req.PriorMeetingIDs)
+	if err != nil {
+		if errors.Is(err, client.ErrNothingToBrief) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"briefing": briefing})
+}
+
+// ListDeadLetters handles GET /dlq
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	items := h.agentManager.DeadLetterQueue().List()
+	c.JSON(http.StatusOK, items)
+}
+
+// RetryDeadLetter handles POST /dlq/{id}/retry
+func (h *Handler) RetryDeadLetter(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.agentManager.DeadLetterQueue().Retry(id); err != nil {
+		if errors.Is(err, client.ErrDLQItemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Dead letter item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Analysis step retried successfully"})
+}
+
+// GetABTestResults handles GET /ab-test/results
+func (h *Handler) GetABTestResults(c *gin.Context) {
+	c.JSON(http.StatusOK, h.agentManager.ABTestResults())
+}
+
+// GetPromptVersion handles GET /prompts/version, returning the version of the built-in analysis prompts
+func (h *Handler) GetPromptVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, client.DefaultPromptVersion())
+}
+
+// ListMeetingTemplates handles GET /templates, returning the templates learned for each meeting type
+func (h *Handler) ListMeetingTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, h.agentManager.MeetingTemplates().Templates())
+}
+
+// GetMeetingTemplate handles GET /templates/{meeting_type}
+func (h *Handler) GetMeetingTemplate(c *gin.Context) {
+	meetingType := c.Param("meeting_type")
+
+	template, ok := h.agentManager.MeetingTemplates().Template(meetingType)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": fmt.Sprintf("No template for meeting type %q; one is learned after %d finalized meetings of the type",
+				meetingType, templates.MinMeetingsForTemplate),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
 // GetUsageStats handles GET /usage (additional endpoint for usage statistics)
 func (h *Handler) GetUsageStats(c *gin.Context) {
 	stats := h.agentManager.GetUsageStats()
@@ -215,6 +366,27 @@ func (h *Handler) GetWebSocketStats(c *gin.Context) {
 	})
 }
 
+// GetWebhookHealth handles GET /admin/webhook-health, returning the health of each Discord logging
+// webhook. The map is empty when Discord logging is disabled.
+func (h *Handler) GetWebhookHealth(c *gin.Context) {
+	healthChecker := config.DefaultWebhookHealthChecker()
+	if healthChecker == nil {
+		c.JSON(http.StatusOK, config.WebhookHealthStatus{})
+		return
+	}
+	c.JSON(http.StatusOK, healthChecker.Status())
+}
+
+// GetWebhookQueue handles GET /admin/webhook-queue, listing the failed webhook deliveries awaiting retry
+func (h *Handler) GetWebhookQueue(c *gin.Context) {
+	queue := h.agentManager.WebhookRetryQueue()
+	if queue == nil {
+		c.JSON(http.StatusOK, []client.WebhookDelivery{})
+		return
+	}
+	c.JSON(http.StatusOK, queue.List())
+}
+
 // GetAgentAnalysis handles GET /agents/{agent_id}/analysis
 func (h *Handler) GetAgentAnalysis(c *gin.Context) {
 	agentID := c.Param("agent_id")
@@ -273,3 +445,850 @@ func (h *Handler) GetAgentAnalysisFormatted(c *gin.Context) {
 	c.Header("Content-Type", "text/plain; charset=utf-8")
 	c.String(http.StatusOK, formattedAnalysis)
 }
+
+// GetAgentAnalysisReport handles GET /agents/{agent_id}/analysis/report, streaming the formatted analysis
+// with chunked transfer encoding so clients can render each section as it arrives
+func (h *Handler) GetAgentAnalysisReport(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	c.Header("Content-Type", "text/markdown; charset=utf-8")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	writer := &intervalFlusher{ResponseWriter: c.Writer, interval: h.reportFlushInterval}
+	if err := analyst.WriteFormattedAnalysis(writer); err != nil {
+		// The status is already sent, so the client only sees a truncated report
+		logrus.Warnf("Failed to stream analysis report for agent %s: %v", c.Param("agent_id"), err)
+	}
+}
+
+// intervalFlusher flushes a response at most once per interval, so a fast writer doesn't send a chunk for
+// every small section. The response is flushed when the handler returns.
+type intervalFlusher struct {
+	gin.ResponseWriter
+	interval  time.Duration
+	lastFlush time.Time
+}
+
+// Flush sends the buffered response unless the previous flush was less than the interval ago
+func (f *intervalFlusher) Flush() {
+	if now := time.Now(); now.Sub(f.lastFlush) >= f.interval {
+		f.ResponseWriter.Flush()
+		f.lastFlush = now
+	}
+}
+
+// GetAgentAnalysisHTML handles GET /agents/{agent_id}/analysis/html?theme={light|dark}
+func (h *Handler) GetAgentAnalysisHTML(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	report, err := analyst.GetAnalysisHTML(c.Query("theme"))
+	if err != nil {
+		if errors.Is(err, client.ErrInvalidTheme) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(report))
+}
+
+// GetAgentAnalysisDiff handles GET /agents/{agent_id}/analysis/diff?baseline={snapshotIndex}
+func (h *Handler) GetAgentAnalysisDiff(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	baseline, err := strconv.Atoi(c.Query("baseline"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "baseline must be a snapshot index"})
+		return
+	}
+
+	current := analyst.GetAnalysis()
+	if baseline < 0 || baseline >= len(current.Snapshots) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Snapshot not found"})
+		return
+	}
+
+	diff := analysis.DiffAnalysis(current.Snapshots[baseline].ToAnalysisData(), current)
+	c.JSON(http.StatusOK, diff)
+}
+
+// GetAgentWordCloud handles GET /agents/{agent_id}/analysis/wordcloud?format={json|svg}
+func (h *Handler) GetAgentWordCloud(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	words := analyst.GetAnalysis().WordCloudData
+	if words == nil {
+		words = []client.WordFrequency{}
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, gin.H{"words": words})
+	case "svg":
+		c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(client.RenderWordCloudSVG(words)))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or svg"})
+	}
+}
+
+// SubmitActionItemFeedback handles POST /agents/{agent_id}/analysis/action-items/{item_id}/feedback
+func (h *Handler) SubmitActionItemFeedback(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	var feedback client.Feedback
+	if err := c.ShouldBindJSON(&feedback); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := analyst.SubmitActionItemFeedback(c.Param("item_id"), feedback); err != nil {
+		switch {
+		case errors.Is(err, client.ErrInvalidVerdict):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, client.ErrActionItemNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Action item not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Feedback recorded"})
+}
+
+// MarkAttachmentReceived handles PATCH /agents/{agent_id}/analysis/attachments/{idx}
+func (h *Handler) MarkAttachmentReceived(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	index, err := strconv.Atoi(c.Param("idx"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "idx must be an integer"})
+		return
+	}
+
+	var req struct {
+		ReceivedURL string `json:"received_url"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := analyst.MarkAttachmentReceived(index, req.ReceivedURL); err != nil {
+		if errors.Is(err, client.ErrAttachmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Attachment reference not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment marked as received"})
+}
+
+// SubmitAnalysisCorrection handles POST /agents/{agent_id}/analysis/corrections
+func (h *Handler) SubmitAnalysisCorrection(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	var req struct {
+		Section        string `json:"section" binding:"required"`
+		OriginalValue  string `json:"original_value" binding:"required"`
+		CorrectedValue string `json:"corrected_value"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := analyst.SubmitCorrection(c.Request.Context(), req.Section, req.OriginalValue, req.CorrectedValue); err != nil {
+		switch {
+		case errors.Is(err, client.ErrInvalidCorrectionSection), errors.Is(err, client.ErrEmptyCorrection):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Correction recorded"})
+}
+
+// SetAgentRecording handles PUT /agents/{agent_id}/recording
+func (h *Handler) SetAgentRecording(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	var req struct {
+		RecordingURL      string `json:"recording_url" binding:"required"`
+		RecordingPlatform string `json:"recording_platform" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := analyst.SetRecording(req.RecordingURL, req.RecordingPlatform); err != nil {
+		switch {
+		case errors.Is(err, client.ErrInvalidRecordingPlatform), errors.Is(err, client.ErrInvalidRecordingURL):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Recording set"})
+}
+
+// GetAgentPrompt handles GET /agents/{agent_id}/prompts/{analysis_type}
+func (h *Handler) GetAgentPrompt(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	prompt, err := analyst.GetPromptTemplate(c.Param("analysis_type"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prompt)
+}
+
+// MergeAgentAnalysis handles POST /agents/merge, analyzing the combined transcript of two analyst
+// agents that joined the same meeting
+func (h *Handler) MergeAgentAnalysis(c *gin.Context) {
+	var req struct {
+		AgentIDs []string `json:"agent_ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.AgentIDs) != 2 || req.AgentIDs[0] == req.AgentIDs[1] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "agent_ids must contain two different agent IDs"})
+		return
+	}
+
+	first := h.getAnalystAgentByID(c, req.AgentIDs[0])
+	if first == nil {
+		return
+	}
+	second := h.getAnalystAgentByID(c, req.AgentIDs[1])
+	if second == nil {
+		return
+	}
+
+	merged, err := first.MergeAnalysis(second)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, merged)
+}
+
+// GetAgentEmailDraft handles GET /agents/{agent_id}/analysis/email-draft
+func (h *Handler) GetAgentEmailDraft(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	draft := analyst.GetFollowUpEmailDraft()
+	if draft == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No follow-up email has been drafted; it is generated when the meeting is finalized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// GetAgentAnalysisObfuscated handles GET /agents/{agent_id}/analysis/obfuscated, returning the analysis with
+// participants replaced by pseudonyms. The optional integer seed selects the name-to-pseudonym mapping.
+func (h *Handler) GetAgentAnalysisObfuscated(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	var seed int64
+	if seedStr := c.Query("seed"); seedStr != "" {
+		parsed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "seed must be an integer"})
+			return
+		}
+		seed = parsed
+	}
+
+	c.JSON(http.StatusOK, analyst.ObfuscateParticipants(seed))
+}
+
+// GetAgentSpeakerSummary handles GET /agents/{agent_id}/analysis/speakers/{speaker}/summary, summarizing
+// what one speaker said
+func (h *Handler) GetAgentSpeakerSummary(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	speaker := c.Param("speaker")
+	summary, err := analyst.GenerateSpeakerSummary(c.Request.Context(), speaker)
+	if err != nil {
+		if errors.Is(err, client.ErrSpeakerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No statements from this speaker in the transcript"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"speaker": speaker, "summary": summary})
+}
+
+// GetAgentAnalysisExport handles GET /agents/{agent_id}/analysis/export?format={markdown_diagrams|csv}, where CSV
+// exports take what to export from target={action_items|transcript}
+func (h *Handler) GetAgentAnalysisExport(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	switch c.Query("format") {
+	case "markdown_diagrams":
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(export.ExportMarkdownWithDiagrams(analyst.GetAnalysis())))
+	case "csv":
+		var writeCSV func(*client.AnalysisData, io.Writer) error
+		switch target := c.DefaultQuery("target", "action_items"); target {
+		case "action_items":
+			writeCSV = export.ExportActionItemsCSV
+		case "transcript":
+			writeCSV = export.ExportTranscriptCSV
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "target must be action_items or transcript"})
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := writeCSV(analyst.GetAnalysis(), &buf); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be markdown_diagrams or csv"})
+	}
+}
+
+// GetAgentChapters handles GET /agents/{agent_id}/analysis/chapters?format={json|youtube|vtt}, returning the
+// recording chapters derived from the discussion topics
+func (h *Handler) GetAgentChapters(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	markers := analyst.GetAnalysis().ChapterMarkers
+	if markers == nil {
+		markers = []client.ChapterMarker{}
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, gin.H{"chapters": markers})
+	case "youtube":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(client.GenerateYouTubeChapterList(markers)))
+	case "vtt":
+		c.Data(http.StatusOK, "text/vtt; charset=utf-8", []byte(client.GenerateVTTChapterFile(markers)))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json, youtube or vtt"})
+	}
+}
+
+// GetAgentConversationFlow handles GET /agents/{agent_id}/analysis/flow?format={json|mermaid}, returning
+// how the discussion moved between topics
+func (h *Handler) GetAgentConversationFlow(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	analysis := analyst.GetAnalysis()
+	flow := analysis.ConversationFlow
+	if flow == nil {
+		flow = []client.TopicTransition{}
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, gin.H{
+			"conversation_flow":    flow,
+			"flow_coherence_score": analysis.FlowCoherenceScore,
+		})
+	case "mermaid":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(client.GenerateMermaidFlowchart(flow)))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or mermaid"})
+	}
+}
+
+// GetAgentSpeakerMood handles GET /agents/{agent_id}/analysis/mood?speaker={name}&at={time}, returning the
+// speaker's mood timeline, every speaker's without a speaker, or the speaker's mood at an RFC 3339 time
+func (h *Handler) GetAgentSpeakerMood(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	speaker := c.Query("speaker")
+	if atStr := c.Query("at"); atStr != "" {
+		if speaker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "speaker is required with at"})
+			return
+		}
+		at, err := time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid at time, expected RFC 3339"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"speaker": speaker, "mood": analyst.GetSpeakerMoodAtTime(speaker, at)})
+		return
+	}
+
+	timelines := analyst.GetAnalysis().SpeakerMoodTimeline
+	if speaker == "" {
+		if timelines == nil {
+			timelines = map[string][]client.MoodPoint{}
+		}
+		c.JSON(http.StatusOK, gin.H{"speaker_mood_timeline": timelines})
+		return
+	}
+
+	timeline := timelines[speaker]
+	if timeline == nil {
+		timeline = []client.MoodPoint{}
+	}
+	c.JSON(http.StatusOK, gin.H{"speaker": speaker, "timeline": timeline})
+}
+
+// GetAgentStakeholders handles GET /agents/{agent_id}/analysis/stakeholders, returning the external
+// stakeholders referenced in the meeting
+func (h *Handler) GetAgentStakeholders(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	stakeholders := analyst.GetAnalysis().StakeholderMap
+	if stakeholders == nil {
+		stakeholders = []client.Stakeholder{}
+	}
+	c.JSON(http.StatusOK, gin.H{"stakeholders": stakeholders})
+}
+
+// GetAgentEngagementHeatmap handles GET /agents/{agent_id}/analysis/heatmap?format={json|ascii}, returning
+// each participant's engagement in each 2-minute window of the meeting
+func (h *Handler) GetAgentEngagementHeatmap(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	analysis := analyst.GetAnalysis()
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		heatmap := analysis.EngagementHeatmap
+		if heatmap == nil {
+			heatmap = []client.HeatmapRow{}
+		}
+		c.JSON(http.StatusOK, gin.H{"heatmap": heatmap})
+	case "ascii":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(analysis.RenderHeatmapASCII()))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or ascii"})
+	}
+}
+
+// GetAgentRecap handles GET /agents/{agent_id}/analysis/recap?format={executive|engineering|sales|custom}&max_words={n},
+// where custom recaps take their template from the template parameter
+func (h *Handler) GetAgentRecap(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	maxWords := 0
+	if maxWordsStr := c.Query("max_words"); maxWordsStr != "" {
+		parsed, err := strconv.Atoi(maxWordsStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_words must be an integer"})
+			return
+		}
+		maxWords = parsed
+	}
+
+	format := client.RecapFormat(c.DefaultQuery("format", string(client.RecapExecutive)))
+	recap, err := analyst.GenerateRecap(c.Request.Context(), format, maxWords, c.Query("template"))
+	if err != nil {
+		switch {
+		case errors.Is(err, client.ErrInvalidRecapFormat), errors.Is(err, client.ErrInvalidRecapTemplate), errors.Is(err, client.ErrInvalidRecapLength):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, client.ErrNoTranscript):
+			c.JSON(http.StatusNotFound, gin.H{"error": "No transcript to recap yet"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"format": format, "recap": recap})
+}
+
+// GetAgentCostEstimate handles GET /agents/{agent_id}/analysis/cost
+func (h *Handler) GetAgentCostEstimate(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	estimate := analyst.GetCostEstimate()
+	if estimate == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No cost estimate yet; it is calculated when the meeting is finalized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, estimate)
+}
+
+// GetAgentInsightsCard handles GET /agents/{agent_id}/analysis/card
+func (h *Handler) GetAgentInsightsCard(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	card := analyst.GetInsightsCard()
+	if card == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No insights card yet; it is built when the meeting is finalized"})
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+// GetAgentLiveStats handles GET /agents/{agent_id}/live
+func (h *Handler) GetAgentLiveStats(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, analyst.GetLiveStats())
+}
+
+// GetAgentTranscript handles GET /agents/{agent_id}/transcript. Entries are selected by time with the
+// RFC 3339 from and to parameters, or paged with offset and limit otherwise.
+func (h *Handler) GetAgentTranscript(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	fromStr, toStr := c.Query("from"), c.Query("to")
+	if fromStr != "" || toStr != "" {
+		from, to := time.Time{}, time.Now()
+		var err error
+		if fromStr != "" {
+			if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time, expected RFC 3339"})
+				return
+			}
+		}
+		if toStr != "" {
+			if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time, expected RFC 3339"})
+				return
+			}
+		}
+
+		entries := analyst.GetTranscriptSlice(from, to)
+		c.JSON(http.StatusOK, gin.H{"entries": entries, "count": len(entries)})
+		return
+	}
+
+	offset, limit := 0, 100
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	entries, total := analyst.GetTranscriptPage(offset, limit)
+	c.JSON(http.StatusOK, gin.H{"entries": entries, "count": len(entries), "total": total, "offset": offset, "limit": limit})
+}
+
+// maxWebhookPayloadBytes is the largest body accepted from external transcript sources
+const maxWebhookPayloadBytes = 10 << 20
+
+// readSignedPayload reads the body posted by an external transcript source for the agent. When the agent
+// has a webhook secret, verify must accept the body's signature under it; requests for those agents skip
+// the API secret (see requireAPISecretOrSignature). requireSecret rejects agents without a webhook secret.
+// Writes the error response and returns false otherwise.
+func (h *Handler) readSignedPayload(c *gin.Context, requireSecret bool, verify func(secret string, payload []byte) error) ([]byte, bool) {
+	// Limit the body before reading it to check the signature, so unsigned requests can't make us buffer
+	// unbounded payloads
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadBytes)
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit)})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return nil, false
+	}
+
+	agent, exists := h.agentManager.GetAgent(c.Param("agent_id"))
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return nil, false
+	}
+	if requireSecret && agent.Config.WebhookSecret == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Agent has no webhook_secret to verify the request with"})
+		return nil, false
+	}
+	if secret := agent.Config.WebhookSecret; secret != "" {
+		if err := verify(secret, payload); err != nil {
+			var signatureErr *webhook.SignatureVerificationError
+			if !errors.As(err, &signatureErr) {
+				signatureErr = &webhook.SignatureVerificationError{Reason: err.Error()}
+			}
+			c.JSON(http.StatusUnauthorized, signatureErr)
+			return nil, false
+		}
+	}
+	return payload, true
+}
+
+// PostAgentTranscript handles POST /agents/{agent_id}/transcript, adding an utterance from an external
+// transcript source. Agents with a webhook secret only accept bodies signed in the X-Webhook-Signature
+// header, and don't need the API secret.
+func (h *Handler) PostAgentTranscript(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	payload, ok := h.readSignedPayload(c, false, func(secret string, payload []byte) error {
+		return webhook.VerifyWebhookSignature(secret, payload, c.GetHeader(webhook.SignatureHeader))
+	})
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Segments []map[string]interface{} `json:"segments"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil || len(req.Segments) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Request body must contain a non-empty segments list"})
+		return
+	}
+
+	analyst.ProcessUtterance(req.Segments)
+	c.JSON(http.StatusAccepted, gin.H{"message": "Utterance received"})
+}
+
+// PostAgentWhisperTranscript handles POST /agents/{agent_id}/transcript/whisper, adding the segments of an
+// OpenAI Whisper transcription. Signed like PostAgentTranscript.
+func (h *Handler) PostAgentWhisperTranscript(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	payload, ok := h.readSignedPayload(c, false, func(secret string, payload []byte) error {
+		return webhook.VerifyWebhookSignature(secret, payload, c.GetHeader(webhook.SignatureHeader))
+	})
+	if !ok {
+		return
+	}
+
+	if err := analyst.ProcessWhisperTranscript(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Transcript received"})
+}
+
+// PostAgentZoomCaption handles POST /agents/{agent_id}/webhooks/zoom, adding a caption from Zoom's
+// closed-caption webhook. Zoom can't send the API secret, so requests are only authenticated by Zoom's
+// signature under the agent's webhook secret (the Zoom app's secret token); agents without one reject them.
+// Answers Zoom's endpoint.url_validation challenge when the webhook is registered.
+func (h *Handler) PostAgentZoomCaption(c *gin.Context) {
+	// Verify the signature before resolving the analyst, so unauthenticated requests learn nothing about the
+	// agent
+	var secret string
+	payload, ok := h.readSignedPayload(c, true, func(webhookSecret string, payload []byte) error {
+		secret = webhookSecret
+		return webhook.VerifyZoomSignature(webhookSecret, payload, c.GetHeader(webhook.ZoomTimestampHeader), c.GetHeader(webhook.ZoomSignatureHeader))
+	})
+	if !ok {
+		return
+	}
+
+	var event struct {
+		Event   string `json:"event"`
+		Payload struct {
+			PlainToken string `json:"plainToken"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(payload, &event); err == nil && event.Event == webhook.ZoomURLValidationEvent {
+		if event.Payload.PlainToken == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "URL validation request has no plainToken"})
+			return
+		}
+		c.JSON(http.StatusOK, webhook.ValidateZoomURL(secret, event.Payload.PlainToken))
+		return
+	}
+
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+	if err := analyst.ProcessZoomCaption(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Caption received"})
+}
+
+// GetAgentCapacity handles GET /agents/{agent_id}/capacity
+func (h *Handler) GetAgentCapacity(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, analyst.GetCapacityEstimate())
+}
+
+// FinalizeAgentAnalysis handles POST /agents/{agent_id}/finalize
+func (h *Handler) FinalizeAgentAnalysis(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	data, err := analyst.Finalize(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, client.ErrAlreadyFinalized) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Analysis already finalized"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// StreamAgentTranscript handles GET /agents/{agent_id}/stream as server-sent events
+func (h *Handler) StreamAgentTranscript(c *gin.Context) {
+	analyst := h.getAnalystAgent(c)
+	if analyst == nil {
+		return
+	}
+
+	sub, err := analyst.SubscribeTranscript()
+	if err != nil {
+		if errors.Is(err, event.ErrTooManySubscribers) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many transcript streams for this agent"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer sub.Close()
+
+	// The stream lives for the whole meeting, so lift the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logrus.Debugf("Failed to clear write deadline for transcript stream: %v", err)
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case update, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			c.SSEvent("transcript", update)
+			return true
+		}
+	})
+}
+
+// getAnalystAgent resolves the analyst agent for the request, writing an error response if unavailable
+func (h *Handler) getAnalystAgent(c *gin.Context) *client.AnalystAgent {
+	return h.getAnalystAgentByID(c, c.Param("agent_id"))
+}
+
+// getAnalystAgentByID returns the analyst agent with the given ID, writing the error response when
+// there isn't one
+func (h *Handler) getAnalystAgentByID(c *gin.Context, agentID string) *client.AnalystAgent {
+	agent, exists := h.agentManager.GetAgent(agentID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return nil
+	}
+
+	if agent.Config.ConversationMode != models.ConversationModeAnalyst {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Agent is not in analyst mode"})
+		return nil
+	}
+
+	analyst := h.agentManager.GetAnalystAgent(agentID)
+	if analyst == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Analyst agent not found"})
+		return nil
+	}
+
+	return analyst
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/manager"
+	"joinly-manager/internal/models"
+	"joinly-manager/internal/webhook"
+)
+
+const testAPISecret = "api-secret"
+
+// newTestManager starts an agent manager with an analyst agent for each config, saving to a temporary
+// directory, and returns its config, the manager and the agents' IDs
+func newTestManager(t *testing.T, agentConfigs ...models.AgentConfig) (*config.Config, *manager.AgentManager, []string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Logging.Level = "info"
+	cfg.Server.APISecret = testAPISecret
+	cfg.Joinly.DefaultURL = "http://127.0.0.1:1/mcp/"
+	agentManager := manager.NewAgentManager(cfg)
+	if err := agentManager.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { agentManager.Stop() })
+
+	var agentIDs []string
+	for _, agentConfig := range agentConfigs {
+		agentConfig.ConversationMode = models.ConversationModeAnalyst
+		agent, err := agentManager.CreateAgent(agentConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := agentManager.StartAgent(agent.ID); err != nil {
+			t.Fatal(err)
+		}
+		agentIDs = append(agentIDs, agent.ID)
+	}
+	return cfg, agentManager, agentIDs
+}
+
+// newTestRouter returns the router of a manager started by newTestManager and the agents' IDs
+func newTestRouter(t *testing.T, agentConfigs ...models.AgentConfig) (*gin.Engine, []string) {
+	t.Helper()
+	cfg, agentManager, agentIDs := newTestManager(t, agentConfigs...)
+	return SetupRouter(cfg, agentManager), agentIDs
+}
+
+// serve sends a request to router, with the API secret when authorized
+func serve(router *gin.Engine, method, path string, body []byte, authorized bool, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if authorized {
+		req.Header.Set("Authorization", "Bearer "+testAPISecret)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestPostAgentTranscriptAuthentication(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Signed", MeetingURL: "https://meet.example.com/a", WebhookSecret: "hook-secret"},
+		models.AgentConfig{Name: "Unsigned", MeetingURL: "https://meet.example.com/b"})
+	signed, unsigned := "/agents/"+agentIDs[0]+"/transcript", "/agents/"+agentIDs[1]+"/transcript"
+	body := []byte(`{"segments": [{"speaker": "Alice", "text": "Let's review the launch plan today"}]}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		authorized bool
+		signature  string
+		want       int
+	}{
+		{"signed without API secret", signed, false, webhook.Sign("hook-secret", body), http.StatusAccepted},
+		{"wrong signature", signed, true, webhook.Sign("other-secret", body), http.StatusUnauthorized},
+		{"missing signature", signed, true, "", http.StatusUnauthorized},
+		{"unsigned agent with API secret", unsigned, true, "", http.StatusAccepted},
+		{"unsigned agent without API secret", unsigned, false, "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.signature != "" {
+				headers[webhook.SignatureHeader] = tt.signature
+			}
+			recorder := serve(router, http.MethodPost, tt.path, body, tt.authorized, headers)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestPostAgentTranscriptSignatureErrorBody(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Signed", MeetingURL: "https://meet.example.com/a", WebhookSecret: "hook-secret"})
+
+	recorder := serve(router, http.MethodPost, "/agents/"+agentIDs[0]+"/transcript", []byte(`{"segments": []}`), false,
+		map[string]string{webhook.SignatureHeader: "sha256=00"})
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", recorder.Code)
+	}
+	var body struct {
+		Error  string `json:"error"`
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Type != "SignatureVerificationError" || body.Reason != "signature does not match payload" || body.Error == "" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestPostAgentTranscriptLimitsBodySize(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Signed", MeetingURL: "https://meet.example.com/a", WebhookSecret: "hook-secret"})
+
+	body := []byte(`{"segments": [{"speaker": "Alice", "text": "` + strings.Repeat("a", maxWebhookPayloadBytes) + `"}]}`)
+	recorder := serve(router, http.MethodPost, "/agents/"+agentIDs[0]+"/transcript", body, false,
+		map[string]string{webhook.SignatureHeader: webhook.Sign("hook-secret", body)})
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", recorder.Code)
+	}
+}
+
+func TestGetAgentHidesWebhookSecret(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Signed", MeetingURL: "https://meet.example.com/a", WebhookSecret: "hook-secret"})
+
+	for _, path := range []string{"/agents", "/agents/" + agentIDs[0]} {
+		recorder := serve(router, http.MethodGet, path, nil, true, nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want 200", path, recorder.Code)
+		}
+		if body := recorder.Body.String(); strings.Contains(body, "hook-secret") || strings.Contains(body, "webhook_secret") {
+			t.Errorf("GET %s returned the webhook secret: %s", path, body)
+		}
+	}
+}
+
+// zoomHeaders returns the headers Zoom signs body with under secret
+func zoomHeaders(secret string, body []byte) map[string]string {
+	return zoomHeadersAt(secret, time.Now(), body)
+}
+
+// zoomHeadersAt returns the headers Zoom signs body with under secret when sending it at sent
+func zoomHeadersAt(secret string, sent time.Time, body []byte) map[string]string {
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + string(body)))
+	return map[string]string{
+		webhook.ZoomTimestampHeader: timestamp,
+		webhook.ZoomSignatureHeader: "v0=" + hex.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+func TestPostAgentZoomCaptionAuthentication(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Zoom", MeetingURL: "https://zoom.example.com/a", WebhookSecret: "zoom-token"},
+		models.AgentConfig{Name: "No secret", MeetingURL: "https://zoom.example.com/b"})
+	zoom, noSecret := "/agents/"+agentIDs[0]+"/webhooks/zoom", "/agents/"+agentIDs[1]+"/webhooks/zoom"
+	body := []byte(`{"object": {"participant": {"user_name": "Alice"}, "closed_caption_id": "1", "text": "Hello everyone, thanks for joining"}}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		authorized bool
+		headers    map[string]string
+		want       int
+	}{
+		{"signed by Zoom", zoom, false, zoomHeaders("zoom-token", body), http.StatusAccepted},
+		{"wrong signature", zoom, true, zoomHeaders("other-token", body), http.StatusUnauthorized},
+		{"API secret without signature", zoom, true, nil, http.StatusUnauthorized},
+		{"stale signature", zoom, false, zoomHeadersAt("zoom-token", time.Now().Add(-time.Hour), body), http.StatusUnauthorized},
+		{"agent without webhook secret", noSecret, true, zoomHeaders("zoom-token", body), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, http.MethodPost, tt.path, body, tt.authorized, tt.headers)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestPostAgentZoomCaptionVerifiesBeforeResolvingAnalyst(t *testing.T) {
+	cfg, agentManager, _ := newTestManager(t)
+	router := SetupRouter(cfg, agentManager)
+	// Not started, so the agent has no analyst yet
+	agent, err := agentManager.CreateAgent(models.AgentConfig{
+		Name: "Zoom", MeetingURL: "https://zoom.example.com/a", WebhookSecret: "zoom-token", ConversationMode: models.ConversationModeAnalyst,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"object": {"closed_caption_id": "1", "text": "Hello"}}`)
+	if recorder := serve(router, http.MethodPost, "/agents/"+agent.ID+"/webhooks/zoom", body, false, nil); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned status = %d, want 401 before the analyst is looked up", recorder.Code)
+	}
+	if recorder := serve(router, http.MethodPost, "/agents/"+agent.ID+"/webhooks/zoom", body, false, zoomHeaders("zoom-token", body)); recorder.Code != http.StatusNotFound {
+		t.Errorf("signed status = %d, want 404 for the missing analyst", recorder.Code)
+	}
+}
+
+func TestPostAgentZoomCaptionURLValidation(t *testing.T) {
+	router, agentIDs := newTestRouter(t,
+		models.AgentConfig{Name: "Zoom", MeetingURL: "https://zoom.example.com/a", WebhookSecret: "zoom-token"})
+
+	body := []byte(`{"event": "endpoint.url_validation", "payload": {"plainToken": "qgg8vlvZRS6UYooatFL8Aw"}}`)
+	recorder := serve(router, http.MethodPost, "/agents/"+agentIDs[0]+"/webhooks/zoom", body, false, zoomHeaders("zoom-token", body))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+
+	var validation webhook.ZoomURLValidation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &validation); err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, []byte("zoom-token"))
+	mac.Write([]byte("qgg8vlvZRS6UYooatFL8Aw"))
+	if validation.PlainToken != "qgg8vlvZRS6UYooatFL8Aw" || validation.EncryptedToken != hex.EncodeToString(mac.Sum(nil)) {
+		t.Errorf("validation = %+v", validation)
+	}
+}
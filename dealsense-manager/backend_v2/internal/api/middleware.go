@@ -0,0 +1,45 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"joinly-manager/internal/manager"
+)
+
+// requireAPISecret rejects requests without an "Authorization: Bearer <secret>" header matching the
+// configured secret. Authentication is disabled when secret is empty.
+func requireAPISecret(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if secret == "" {
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireAPISecretOrSignature authenticates the endpoints external transcript sources post to. Agents with
+// a webhook secret are authenticated by the payload's signature under that secret, which the handler
+// verifies, so a transcription service is only given the agent's webhook secret rather than the API secret.
+// Requests for other agents need the API secret.
+func requireAPISecretOrSignature(secret string, agentManager *manager.AgentManager) gin.HandlerFunc {
+	requireSecret := requireAPISecret(secret)
+	return func(c *gin.Context) {
+		if agent, exists := agentManager.GetAgent(c.Param("agent_id")); exists && agent.Config.WebhookSecret != "" {
+			c.Next()
+			return
+		}
+		requireSecret(c)
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireAPISecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		want   int
+	}{
+		{"matching secret", "s3cret", "Bearer s3cret", http.StatusOK},
+		{"wrong secret", "s3cret", "Bearer other", http.StatusUnauthorized},
+		{"missing bearer prefix", "s3cret", "s3cret", http.StatusUnauthorized},
+		{"no header", "s3cret", "", http.StatusUnauthorized},
+		{"authentication disabled", "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", requireAPISecret(tt.secret), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 
 	"joinly-manager/internal/config"
 	"joinly-manager/internal/manager"
@@ -35,35 +36,98 @@ func SetupRouter(cfg *config.Config, agentManager *manager.AgentManager) *gin.En
 	}))
 
 	// Create handler
-	handler := NewHandler(agentManager)
+	handler := NewHandler(agentManager, cfg.Server.ReportFlushInterval)
+
+	if cfg.Server.APISecret == "" {
+		logrus.Warn("API_SECRET is not set, REST API endpoints are unauthenticated")
+	}
+	auth := requireAPISecret(cfg.Server.APISecret)
 
 	// Health check
 	router.GET("/", handler.HealthCheck)
 
 	// Agent routes
-	agents := router.Group("/agents")
+	agents := router.Group("/agents", auth)
 	{
 		agents.GET("", handler.ListAgents)
 		agents.POST("", handler.CreateAgent)
+		agents.POST("/merge", handler.MergeAgentAnalysis)
+		agents.GET("/quick", handler.ListAgentQuickViews)
 		agents.GET("/:agent_id", handler.GetAgent)
 		agents.DELETE("/:agent_id", handler.DeleteAgent)
+		agents.PUT("/:agent_id/config", handler.UpdateAgentConfig)
 		agents.POST("/:agent_id/start", handler.StartAgent)
 		agents.POST("/:agent_id/stop", handler.StopAgent)
 		agents.POST("/:agent_id/join-meeting", handler.JoinMeeting)
 		agents.GET("/:agent_id/logs", handler.GetAgentLogs)
 		agents.GET("/:agent_id/analysis", handler.GetAgentAnalysis)
 		agents.GET("/:agent_id/analysis/formatted", handler.GetAgentAnalysisFormatted)
+		agents.GET("/:agent_id/analysis/report", handler.GetAgentAnalysisReport)
+		agents.GET("/:agent_id/analysis/html", handler.GetAgentAnalysisHTML)
+		agents.GET("/:agent_id/analysis/diff", handler.GetAgentAnalysisDiff)
+		agents.GET("/:agent_id/analysis/wordcloud", handler.GetAgentWordCloud)
+		agents.GET("/:agent_id/analysis/email-draft", handler.GetAgentEmailDraft)
+		agents.GET("/:agent_id/analysis/cost", handler.GetAgentCostEstimate)
+		agents.GET("/:agent_id/analysis/card", handler.GetAgentInsightsCard)
+		agents.GET("/:agent_id/analysis/obfuscated", handler.GetAgentAnalysisObfuscated)
+		agents.GET("/:agent_id/analysis/speakers/:speaker/summary", handler.GetAgentSpeakerSummary)
+		agents.GET("/:agent_id/analysis/recap", handler.GetAgentRecap)
+		agents.GET("/:agent_id/analysis/export", handler.GetAgentAnalysisExport)
+		agents.GET("/:agent_id/analysis/chapters", handler.GetAgentChapters)
+		agents.GET("/:agent_id/analysis/flow", handler.GetAgentConversationFlow)
+		agents.GET("/:agent_id/analysis/mood", handler.GetAgentSpeakerMood)
+		agents.GET("/:agent_id/analysis/stakeholders", handler.GetAgentStakeholders)
+		agents.GET("/:agent_id/analysis/heatmap", handler.GetAgentEngagementHeatmap)
+		agents.POST("/:agent_id/analysis/action-items/:item_id/feedback", handler.SubmitActionItemFeedback)
+		agents.PATCH("/:agent_id/analysis/attachments/:idx", handler.MarkAttachmentReceived)
+		agents.POST("/:agent_id/analysis/corrections", handler.SubmitAnalysisCorrection)
+		agents.GET("/:agent_id/transcript", handler.GetAgentTranscript)
+		agents.PUT("/:agent_id/recording", handler.SetAgentRecording)
+		agents.GET("/:agent_id/prompts/:analysis_type", handler.GetAgentPrompt)
+		agents.GET("/:agent_id/live", handler.GetAgentLiveStats)
+		agents.GET("/:agent_id/capacity", handler.GetAgentCapacity)
+		agents.GET("/:agent_id/stream", handler.StreamAgentTranscript)
+		agents.POST("/:agent_id/finalize", handler.FinalizeAgentAnalysis)
 	}
 
+	// Transcript sources, authenticated by the agent's webhook signature when it has a webhook secret
+	webhookAuth := requireAPISecretOrSignature(cfg.Server.APISecret, agentManager)
+	router.POST("/agents/:agent_id/transcript", webhookAuth, handler.PostAgentTranscript)
+	router.POST("/agents/:agent_id/transcript/whisper", webhookAuth, handler.PostAgentWhisperTranscript)
+
+	// Zoom can't send the API secret; the handler verifies Zoom's signature instead
+	router.POST("/agents/:agent_id/webhooks/zoom", handler.PostAgentZoomCaption)
+
 	// WebSocket routes
 	router.GET("/ws/agents/:agent_id", handler.WebSocketAgent)
 	router.GET("/ws/session", handler.WebSocketSession)
 
 	// Meeting routes
-	router.GET("/meetings", handler.ListMeetings)
+	router.GET("/meetings", auth, handler.ListMeetings)
+	router.GET("/meetings/briefing", auth, handler.GetMeetingBriefing)
+
+	// Dead letter queue routes for failed analysis steps
+	router.GET("/dlq", auth, handler.ListDeadLetters)
+	router.POST("/dlq/:id/retry", auth, handler.RetryDeadLetter)
+
+	// Aggregate results of action item prompt A/B tests
+	router.GET("/ab-test/results", auth, handler.GetABTestResults)
+
+	// Version of the built-in analysis prompts
+	router.GET("/prompts/version", auth, handler.GetPromptVersion)
+
+	// Meeting templates learned from finalized analyses of each meeting type
+	router.GET("/templates", auth, handler.ListMeetingTemplates)
+	router.GET("/templates/:meeting_type", auth, handler.GetMeetingTemplate)
+
+	// Health of the Discord logging webhooks
+	router.GET("/admin/webhook-health", auth, handler.GetWebhookHealth)
+
+	// Failed webhook deliveries awaiting retry
+	router.GET("/admin/webhook-queue", auth, handler.GetWebhookQueue)
 
 	// Additional utility routes
-	router.GET("/usage", handler.GetUsageStats)
+	router.GET("/usage", auth, handler.GetUsageStats)
 	router.GET("/ws/stats", handler.GetWebSocketStats)
 
 	return router
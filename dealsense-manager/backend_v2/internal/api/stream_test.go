@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"joinly-manager/internal/client"
+	"joinly-manager/internal/models"
+)
+
+func TestStreamAgentTranscript(t *testing.T) {
+	cfg, agentManager, agentIDs := newTestManager(t, models.AgentConfig{Name: "Analyst", MeetingURL: "https://meet.example.com/a"})
+	server := httptest.NewServer(SetupRouter(cfg, agentManager))
+	defer server.Close()
+	analyst := agentManager.GetAnalystAgent(agentIDs[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The stream's headers are only sent with the first event, so keep adding utterances until it arrives
+	posted := make(chan struct{})
+	go func() {
+		segments := []map[string]interface{}{{"speaker": "Alice", "text": "Let's review the launch plan today"}}
+		for {
+			analyst.ProcessUtterance(segments)
+			select {
+			case <-posted:
+				return
+			case <-ctx.Done():
+				return
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}()
+	defer close(posted)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/agents/"+agentIDs[0]+"/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testAPISecret)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var eventName string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event:"); ok {
+			eventName = name
+			continue
+		}
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue
+		}
+		if eventName != "transcript" {
+			t.Fatalf("event = %q, want transcript", eventName)
+		}
+		var update client.TranscriptUpdate
+		if err := json.Unmarshal([]byte(data), &update); err != nil {
+			t.Fatal(err)
+		}
+		if update.Entry.Speaker != "Alice" || update.Entry.Text != "Let's review the launch plan today" {
+			t.Errorf("update = %+v", update)
+		}
+		return
+	}
+	t.Fatalf("stream ended without a transcript event: %v", scanner.Err())
+}
+
+func TestStreamAgentTranscriptUnknownAgent(t *testing.T) {
+	router, _ := newTestRouter(t)
+
+	if recorder := serve(router, http.MethodGet, "/agents/agent_missing/stream", nil, true, nil); recorder.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", recorder.Code)
+	}
+}
+
+func TestGetAgentAnalysisReportIsChunked(t *testing.T) {
+	router, agentIDs := newTestRouter(t, models.AgentConfig{Name: "Analyst", MeetingURL: "https://meet.example.com/a"})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/agents/"+agentIDs[0]+"/analysis/report", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testAPISecret)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Errorf("transfer encoding = %q, want the report streamed in chunks", resp.TransferEncoding)
+	}
+	if resp.Header.Get("Content-Type") != "text/markdown; charset=utf-8" {
+		t.Errorf("content type = %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+// countingFlusher counts the flushes reaching the response
+type countingFlusher struct {
+	gin.ResponseWriter
+	flushes int
+}
+
+func (w *countingFlusher) Flush() {
+	w.flushes++
+}
+
+func TestIntervalFlusherLimitsFlushes(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	counter := &countingFlusher{ResponseWriter: c.Writer}
+
+	throttled := &intervalFlusher{ResponseWriter: counter, interval: time.Hour}
+	for i := 0; i < 5; i++ {
+		throttled.Flush()
+	}
+	if counter.flushes != 1 {
+		t.Errorf("flushes = %d, want only the first within the interval", counter.flushes)
+	}
+
+	counter.flushes = 0
+	unthrottled := &intervalFlusher{ResponseWriter: counter}
+	for i := 0; i < 5; i++ {
+		unthrottled.Flush()
+	}
+	if counter.flushes != 5 {
+		t.Errorf("flushes = %d, want every flush without an interval", counter.flushes)
+	}
+}
@@ -0,0 +1,139 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client"
+	"joinly-manager/internal/models"
+)
+
+// replaySuffix marks files written by a batch run so they are not picked up again
+const replaySuffix = ".replay.json"
+
+// BatchError records a file that could not be analyzed
+type BatchError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// BatchReport aggregates the results of a batch run
+type BatchReport struct {
+	Processed        int           `json:"processed"`
+	Failed           int           `json:"failed"`
+	TotalActionItems int           `json:"total_action_items"`
+	TotalDuration    time.Duration `json:"total_duration"` // Wall-clock time of the whole batch
+	Errors           []BatchError  `json:"errors"`
+}
+
+// BatchAnalyzer re-runs analysis over saved meeting files
+type BatchAnalyzer struct {
+	// OutputDir is where re-analyzed files are written; when empty they are written next to
+	// each input file with a .replay.json suffix
+	OutputDir string
+}
+
+// NewBatchAnalyzer creates a batch analyzer writing results to outputDir
+func NewBatchAnalyzer(outputDir string) *BatchAnalyzer {
+	return &BatchAnalyzer{OutputDir: outputDir}
+}
+
+// RunBatch analyzes every file matching inputGlob using up to concurrency workers. A failure in one
+// file is recorded in the report and does not stop the others.
+func (b *BatchAnalyzer) RunBatch(ctx context.Context, inputGlob string, config models.AgentConfig, concurrency int) *BatchReport {
+	startTime := time.Now()
+	report := &BatchReport{Errors: []BatchError{}}
+
+	files, err := filepath.Glob(inputGlob)
+	if err != nil {
+		report.Errors = append(report.Errors, BatchError{File: inputGlob, Error: err.Error()})
+		report.TotalDuration = time.Since(startTime)
+		return report
+	}
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	if b.OutputDir != "" {
+		if err := os.MkdirAll(b.OutputDir, 0755); err != nil {
+			report.Errors = append(report.Errors, BatchError{File: b.OutputDir, Error: err.Error()})
+			report.TotalDuration = time.Since(startTime)
+			return report
+		}
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				actionItems, err := b.processFile(ctx, file, config)
+
+				mu.Lock()
+				if err != nil {
+					report.Failed++
+					report.Errors = append(report.Errors, BatchError{File: file, Error: err.Error()})
+					logrus.Warnf("Batch analysis failed for %s: %v", file, err)
+				} else {
+					report.Processed++
+					report.TotalActionItems += actionItems
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, replaySuffix) {
+			continue
+		}
+		select {
+		case jobs <- file:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.TotalDuration = time.Since(startTime)
+	return report
+}
+
+// processFile re-analyzes a single file and returns the number of action items found
+func (b *BatchAnalyzer) processFile(ctx context.Context, file string, config models.AgentConfig) (int, error) {
+	replay, err := client.NewReplayAnalystAgent(file, b.outputPath(file), config)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := replay.Run(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("analysis failed: %w", err)
+	}
+
+	return len(data.ActionItems), nil
+}
+
+// outputPath returns where the re-analyzed version of file is written
+func (b *BatchAnalyzer) outputPath(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) + replaySuffix
+	if b.OutputDir != "" {
+		return filepath.Join(b.OutputDir, name)
+	}
+	return filepath.Join(filepath.Dir(file), name)
+}
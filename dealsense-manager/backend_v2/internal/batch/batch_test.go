@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+// writeMeetings writes n analysis files to dir, plus the output of an earlier batch run
+func writeMeetings(t *testing.T, dir string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		doc := fmt.Sprintf(`{"schema_version": 1, "meeting_id": "m%d", "start_time": "2024-05-01T10:00:00Z", "last_updated": "2024-05-01T10:30:00Z", "transcript": []}`, i)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("meeting_%d.json", i)), []byte(doc), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meeting_0"+replaySuffix), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunBatchRecordsEveryFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeMeetings(t, dir, 6)
+	if err := os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte(`{not json`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a supported LLM provider every meeting fails, which must not stop the other workers
+	config := models.AgentConfig{LLMProvider: "unsupported"}
+	report := NewBatchAnalyzer(filepath.Join(dir, "out")).RunBatch(context.Background(), filepath.Join(dir, "*.json"), config, 3)
+
+	if report.Processed != 0 || report.Failed != 7 || len(report.Errors) != 7 {
+		t.Fatalf("report = %+v, want all 7 files failed and the earlier batch output skipped", report)
+	}
+	seen := make(map[string]bool)
+	for _, batchErr := range report.Errors {
+		if strings.HasSuffix(batchErr.File, replaySuffix) {
+			t.Errorf("earlier batch output %s was analyzed", batchErr.File)
+		}
+		if seen[batchErr.File] {
+			t.Errorf("%s was analyzed twice", batchErr.File)
+		}
+		seen[batchErr.File] = true
+		if filepath.Base(batchErr.File) == "corrupt.json" && !strings.Contains(batchErr.Error, "failed to load") {
+			t.Errorf("corrupt file error = %q", batchErr.Error)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, "out")); err != nil || !info.IsDir() {
+		t.Errorf("output directory not created: %v", err)
+	}
+}
+
+func TestRunBatchStopsWhenCancelled(t *testing.T) {
+	dir := t.TempDir()
+	writeMeetings(t, dir, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := NewBatchAnalyzer("").RunBatch(ctx, filepath.Join(dir, "*.json"), models.AgentConfig{}, 1)
+	if report.Processed+report.Failed > 1 {
+		t.Errorf("report = %+v, want at most the file already handed to a worker analyzed", report)
+	}
+}
+
+func TestOutputPath(t *testing.T) {
+	if got := NewBatchAnalyzer("").outputPath("data/meeting_1.json"); got != filepath.Join("data", "meeting_1"+replaySuffix) {
+		t.Errorf("outputPath() next to the input = %q", got)
+	}
+	if got := NewBatchAnalyzer("out").outputPath("data/meeting_1.json"); got != filepath.Join("out", "meeting_1"+replaySuffix) {
+		t.Errorf("outputPath() in the output directory = %q", got)
+	}
+}
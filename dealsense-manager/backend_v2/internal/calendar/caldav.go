@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Todo is a task stored on a CalDAV server as a VTODO
+type Todo struct {
+	UID         string // Stable identifier; writing a todo with an existing UID replaces it
+	Summary     string
+	Description string
+	Due         time.Time // Date the task is due; only the date is used
+	Priority    int       // 1 (highest) to 9 (lowest), 0 when undefined
+	Organizer   string    // Name or email address of the person responsible
+}
+
+// CalDAVClient writes todos to a CalDAV calendar collection (RFC 4791) using basic auth
+type CalDAVClient struct {
+	collectionURL string
+	username      string
+	password      string
+	httpClient    *http.Client
+}
+
+// NewCalDAVClient creates a client for the calendar collection at collectionURL
+func NewCalDAVClient(collectionURL, username, password string) *CalDAVClient {
+	return &CalDAVClient{
+		collectionURL: strings.TrimSuffix(collectionURL, "/") + "/",
+		username:      username,
+		password:      password,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// PutTodo creates or replaces the todo as the calendar object resource {UID}.ics
+func (c *CalDAVClient) PutTodo(ctx context.Context, todo Todo) error {
+	resourceURL := c.collectionURL + url.PathEscape(todo.UID) + ".ics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, resourceURL, strings.NewReader(encodeTodo(todo, time.Now())))
+	if err != nil {
+		return fmt.Errorf("failed to create CalDAV request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write todo %s: %w", todo.UID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("CalDAV server returned status %d for todo %s: %s", resp.StatusCode, todo.UID, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// encodeTodo renders the todo as an iCalendar object (RFC 5545)
+func encodeTodo(todo Todo, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//DealSense//Action Items//EN",
+		"BEGIN:VTODO",
+		"UID:" + escapeText(todo.UID),
+		"DTSTAMP:" + now.UTC().Format("20060102T150405Z"),
+		"SUMMARY:" + escapeText(todo.Summary),
+	}
+	if todo.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(todo.Description))
+	}
+	if !todo.Due.IsZero() {
+		lines = append(lines, "DUE;VALUE=DATE:"+todo.Due.Format("20060102"))
+	}
+	if todo.Priority > 0 {
+		lines = append(lines, fmt.Sprintf("PRIORITY:%d", todo.Priority))
+	}
+	if todo.Organizer != "" {
+		lines = append(lines, organizerProperty(todo.Organizer))
+	}
+	lines = append(lines, "STATUS:NEEDS-ACTION", "END:VTODO", "END:VCALENDAR")
+
+	var ics strings.Builder
+	for _, line := range lines {
+		ics.WriteString(foldLine(line))
+		ics.WriteString("\r\n")
+	}
+	return ics.String()
+}
+
+// organizerProperty returns the ORGANIZER property for a name or email address. ORGANIZER must be a
+// URI, so names without an address use a urn naming the person.
+func organizerProperty(organizer string) string {
+	if strings.Contains(organizer, "@") && !strings.ContainsAny(organizer, " \t") {
+		return "ORGANIZER:mailto:" + organizer
+	}
+	name := strings.ReplaceAll(organizer, `"`, "'")
+	return fmt.Sprintf(`ORGANIZER;CN="%s":urn:dealsense:participant:%s`, name, url.PathEscape(organizer))
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// foldLine splits a content line into lines of at most 75 octets, continuing each with a space and
+// never splitting a UTF-8 character
+func foldLine(line string) string {
+	const limit = 75
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeTodo(t *testing.T) {
+	todo := Todo{
+		UID:       "m1-a1",
+		Summary:   "Send pricing; then follow up, quickly",
+		Due:       time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		Priority:  1,
+		Organizer: "Bob Smith",
+	}
+	ics := encodeTodo(todo, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"BEGIN:VTODO\r\n",
+		"UID:m1-a1\r\n",
+		"DTSTAMP:20240501T100000Z\r\n",
+		`SUMMARY:Send pricing\; then follow up\, quickly` + "\r\n",
+		"DUE;VALUE=DATE:20240503\r\n",
+		"PRIORITY:1\r\n",
+		`ORGANIZER;CN="Bob Smith":urn:dealsense:participant:Bob%20Smith` + "\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("todo is missing %q:\n%s", want, ics)
+		}
+	}
+	if strings.Contains(ics, "DESCRIPTION") {
+		t.Error("empty description was encoded")
+	}
+}
+
+func TestOrganizerEmail(t *testing.T) {
+	if got := organizerProperty("bob@example.com"); got != "ORGANIZER:mailto:bob@example.com" {
+		t.Errorf("organizerProperty() = %q", got)
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldLine(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line is %d octets: %q", len(part), part)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolding gives %q, want %q", unfolded, line)
+	}
+}
+
+func TestPutTodo(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(raw)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	todo := Todo{UID: "m1-a1", Summary: "Send pricing"}
+	if err := NewCalDAVClient(server.URL+"/calendars/alice/tasks", "alice", "secret").PutTodo(context.Background(), todo); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || path != "/calendars/alice/tasks/m1-a1.ics" {
+		t.Errorf("request = %s %s, want PUT /calendars/alice/tasks/m1-a1.ics", method, path)
+	}
+	if !strings.Contains(body, "SUMMARY:Send pricing") {
+		t.Errorf("body is missing the summary:\n%s", body)
+	}
+
+	err := NewCalDAVClient(server.URL, "alice", "wrong").PutTodo(context.Background(), todo)
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("PutTodo() with bad credentials = %v, want a status 401 error", err)
+	}
+}
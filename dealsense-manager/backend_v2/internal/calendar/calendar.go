@@ -0,0 +1,149 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// googleCalendarAPIURL is the Google Calendar v3 API base URL
+const googleCalendarAPIURL = "https://www.googleapis.com/calendar/v3"
+
+// EventDraft describes a meeting to put on a calendar for review
+type EventDraft struct {
+	Summary     string
+	Description string
+	Start       time.Time
+	Duration    time.Duration
+	Attendees   []string // Email addresses; other values are ignored
+}
+
+// EventCreator creates calendar events
+type EventCreator interface {
+	// CreateEventDraft creates an unconfirmed event without notifying attendees and returns its link
+	CreateEventDraft(draft EventDraft) (string, error)
+}
+
+// GoogleCalendar creates events through the Google Calendar v3 API
+type GoogleCalendar struct {
+	tokens     TokenProvider
+	calendarID string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewGoogleCalendar creates a Google Calendar client for calendarID, authenticating with tokens from tokens
+func NewGoogleCalendar(tokens TokenProvider, calendarID string) *GoogleCalendar {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	return &GoogleCalendar{
+		tokens:     tokens,
+		calendarID: calendarID,
+		apiURL:     googleCalendarAPIURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// googleEventTime is the start or end of a Google Calendar event
+type googleEventTime struct {
+	DateTime string `json:"dateTime"`
+}
+
+// googleAttendee is an invited attendee of a Google Calendar event
+type googleAttendee struct {
+	Email string `json:"email"`
+}
+
+// googleEvent is the body of a Google Calendar event insert request
+type googleEvent struct {
+	Summary     string           `json:"summary"`
+	Description string           `json:"description,omitempty"`
+	Start       googleEventTime  `json:"start"`
+	End         googleEventTime  `json:"end"`
+	Attendees   []googleAttendee `json:"attendees,omitempty"`
+	Status      string           `json:"status"`
+}
+
+// CreateEventDraft inserts the event as tentative and without sending invitations, so it can be reviewed
+// before it is shared
+func (g *GoogleCalendar) CreateEventDraft(draft EventDraft) (string, error) {
+	event := googleEvent{
+		Summary:     draft.Summary,
+		Description: draft.Description,
+		Start:       googleEventTime{DateTime: draft.Start.Format(time.RFC3339)},
+		End:         googleEventTime{DateTime: draft.Start.Add(draft.Duration).Format(time.RFC3339)},
+		Status:      "tentative",
+	}
+	for _, attendee := range draft.Attendees {
+		if strings.Contains(attendee, "@") {
+			event.Attendees = append(event.Attendees, googleAttendee{Email: attendee})
+		}
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal calendar event: %w", err)
+	}
+
+	ctx := context.Background()
+	token, err := g.tokens.GetToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	status, body, err := g.insertEvent(ctx, token, payload)
+	if err != nil {
+		return "", err
+	}
+
+	// The token may have been revoked or expired early, so refresh it and retry once
+	if refresher, ok := g.tokens.(TokenRefresher); ok && status == http.StatusUnauthorized {
+		if token, err = refresher.RefreshToken(ctx); err != nil {
+			return "", err
+		}
+		if status, body, err = g.insertEvent(ctx, token, payload); err != nil {
+			return "", err
+		}
+	}
+
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("Google Calendar request failed with status %d: %s", status, string(body))
+	}
+
+	var created struct {
+		HTMLLink string `json:"htmlLink"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("failed to parse calendar response: %w", err)
+	}
+	return created.HTMLLink, nil
+}
+
+// insertEvent posts the event to the calendar, returning the response status and body
+func (g *GoogleCalendar) insertEvent(ctx context.Context, token *oauth2.Token, payload []byte) (int, []byte, error) {
+	endpoint := fmt.Sprintf("%s/calendars/%s/events?sendUpdates=none", g.apiURL, url.PathEscape(g.calendarID))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create calendar request: %w", err)
+	}
+	token.SetAuthHeader(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create calendar event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, body, nil
+}
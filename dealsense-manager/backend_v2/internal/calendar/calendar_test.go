@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// googleCalendarServer is a mock Calendar API accepting events authorized with token and recording them
+func googleCalendarServer(t *testing.T, token string, events *[]googleEvent) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/calendars/team@example.com/events" || r.URL.Query().Get("sendUpdates") != "none" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, `{"error": "invalid credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		var event googleEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*events = append(*events, event)
+		w.Write([]byte(`{"htmlLink": "https://calendar.google.com/event?eid=1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGoogleCalendarCreateEventDraft(t *testing.T) {
+	var events []googleEvent
+	server := googleCalendarServer(t, "secret", &events)
+	cal := NewGoogleCalendar(NewStaticTokenProvider("secret"), "team@example.com")
+	cal.apiURL = server.URL
+
+	link, err := cal.CreateEventDraft(EventDraft{
+		Summary:   "Follow-up meeting",
+		Start:     time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		Duration:  45 * time.Minute,
+		Attendees: []string{"bob@example.com", "Alice"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != "https://calendar.google.com/event?eid=1" {
+		t.Errorf("link = %q", link)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("%d events created, want 1", len(events))
+	}
+	event := events[0]
+	if event.Status != "tentative" || event.Start.DateTime != "2024-05-02T10:00:00Z" || event.End.DateTime != "2024-05-02T10:45:00Z" {
+		t.Errorf("event = %+v", event)
+	}
+	// Attendees without an email address are left off
+	if len(event.Attendees) != 1 || event.Attendees[0].Email != "bob@example.com" {
+		t.Errorf("attendees = %+v", event.Attendees)
+	}
+}
+
+func TestGoogleCalendarRejectedToken(t *testing.T) {
+	var events []googleEvent
+	server := googleCalendarServer(t, "secret", &events)
+	cal := NewGoogleCalendar(NewStaticTokenProvider("expired"), "team@example.com")
+	cal.apiURL = server.URL
+
+	// A static token can't be refreshed, so the error is returned
+	if _, err := cal.CreateEventDraft(EventDraft{Summary: "Follow-up meeting"}); err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("CreateEventDraft() error = %v, want a status 401 error", err)
+	}
+}
+
+func TestNewGoogleCalendarDefaultsToPrimary(t *testing.T) {
+	if cal := NewGoogleCalendar(NewStaticTokenProvider("secret"), ""); cal.calendarID != "primary" {
+		t.Errorf("calendarID = %q, want primary", cal.calendarID)
+	}
+}
@@ -0,0 +1,148 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// calendarEventsScope is the OAuth scope needed to create calendar events
+const calendarEventsScope = "https://www.googleapis.com/auth/calendar.events"
+
+// TokenProvider supplies OAuth tokens for the Google Calendar API
+type TokenProvider interface {
+	GetToken(ctx context.Context) (*oauth2.Token, error)
+}
+
+// TokenRefresher is a TokenProvider that can replace a token the API has rejected
+type TokenRefresher interface {
+	TokenProvider
+	RefreshToken(ctx context.Context) (*oauth2.Token, error)
+}
+
+// StaticTokenProvider always returns the same access token
+type StaticTokenProvider struct {
+	token *oauth2.Token
+}
+
+// NewStaticTokenProvider creates a token provider for a fixed access token
+func NewStaticTokenProvider(accessToken string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: &oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"}}
+}
+
+// GetToken returns the access token
+func (p *StaticTokenProvider) GetToken(ctx context.Context) (*oauth2.Token, error) {
+	return p.token, nil
+}
+
+// FileTokenProvider keeps an OAuth token in a JSON file, refreshing it with the client credentials when
+// it expires and writing the refreshed token back to the file
+type FileTokenProvider struct {
+	config    *oauth2.Config
+	tokenPath string
+	mu        sync.Mutex
+	token     *oauth2.Token
+}
+
+// NewFileTokenProvider creates a token provider from a Google OAuth client credentials file and a token
+// file holding at least a refresh token
+func NewFileTokenProvider(credentialsPath, tokenPath string) (*FileTokenProvider, error) {
+	credentials, err := os.ReadFile(credentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar credentials: %w", err)
+	}
+
+	config, err := google.ConfigFromJSON(credentials, calendarEventsScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar credentials: %w", err)
+	}
+
+	return &FileTokenProvider{config: config, tokenPath: tokenPath}, nil
+}
+
+// GetToken returns the current token, refreshing it first if it has expired
+func (p *FileTokenProvider) GetToken(ctx context.Context) (*oauth2.Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	if p.token.Valid() {
+		return p.token, nil
+	}
+	return p.refresh(ctx)
+}
+
+// RefreshToken exchanges the refresh token for a new access token even if the current one has not expired
+func (p *FileTokenProvider) RefreshToken(ctx context.Context) (*oauth2.Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p.refresh(ctx)
+}
+
+// load reads the token file the first time a token is needed (caller must hold mu)
+func (p *FileTokenProvider) load() error {
+	if p.token != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.tokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read calendar token: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return fmt.Errorf("failed to parse calendar token: %w", err)
+	}
+	p.token = &token
+	return nil
+}
+
+// refresh obtains a new access token and saves it (caller must hold mu)
+func (p *FileTokenProvider) refresh(ctx context.Context) (*oauth2.Token, error) {
+	if p.token.RefreshToken == "" {
+		return nil, fmt.Errorf("calendar token has no refresh token")
+	}
+
+	// Drop the access token so the token source always goes to the token endpoint
+	expired := &oauth2.Token{RefreshToken: p.token.RefreshToken}
+	token, err := p.config.TokenSource(ctx, expired).Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh calendar token: %w", err)
+	}
+
+	p.token = token
+	if err := p.save(); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// save writes the token to the token file atomically (caller must hold mu)
+func (p *FileTokenProvider) save() error {
+	data, err := json.MarshalIndent(p.token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal calendar token: %w", err)
+	}
+
+	tmpPath := p.tokenPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write calendar token: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Clean(p.tokenPath)); err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return nil
+}
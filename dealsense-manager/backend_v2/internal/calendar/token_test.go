@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newFileTokenProvider writes client credentials using a mock token endpoint issuing numbered access
+// tokens, and the given token file, and returns a provider for them with the refresh count
+func newFileTokenProvider(t *testing.T, token *oauth2.Token) (*FileTokenProvider, string, *atomic.Int32) {
+	t.Helper()
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "refresh-me" {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		n := refreshes.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "access-%d", "token_type": "Bearer", "expires_in": 3600}`, n)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	credentialsPath := filepath.Join(dir, "credentials.json")
+	credentials := fmt.Sprintf(`{"installed": {"client_id": "id", "client_secret": "secret", "auth_uri": "%s/auth", "token_uri": "%s/token", "redirect_uris": ["http://localhost"]}}`, server.URL, server.URL)
+	if err := os.WriteFile(credentialsPath, []byte(credentials), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tokenPath := filepath.Join(dir, "token.json")
+	if token != nil {
+		data, _ := json.Marshal(token)
+		if err := os.WriteFile(tokenPath, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider, err := NewFileTokenProvider(credentialsPath, tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider, tokenPath, &refreshes
+}
+
+func TestFileTokenProviderUsesValidToken(t *testing.T) {
+	provider, _, refreshes := newFileTokenProvider(t, &oauth2.Token{
+		AccessToken:  "current",
+		RefreshToken: "refresh-me",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+
+	token, err := provider.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "current" || refreshes.Load() != 0 {
+		t.Errorf("token %q after %d refreshes, want the current token", token.AccessToken, refreshes.Load())
+	}
+}
+
+func TestFileTokenProviderRefreshesAndSaves(t *testing.T) {
+	provider, tokenPath, refreshes := newFileTokenProvider(t, &oauth2.Token{
+		AccessToken:  "stale",
+		RefreshToken: "refresh-me",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+
+	token, err := provider.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-1" {
+		t.Errorf("token = %q, want the refreshed token", token.AccessToken)
+	}
+
+	var saved oauth2.Token
+	data, _ := os.ReadFile(tokenPath)
+	if err := json.Unmarshal(data, &saved); err != nil || saved.AccessToken != "access-1" || saved.RefreshToken != "refresh-me" {
+		t.Errorf("saved token = %+v, %v", saved, err)
+	}
+
+	// RefreshToken replaces a token that is still valid
+	if token, err = provider.RefreshToken(context.Background()); err != nil || token.AccessToken != "access-2" || refreshes.Load() != 2 {
+		t.Errorf("RefreshToken() = %v, %v after %d refreshes", token, err, refreshes.Load())
+	}
+}
+
+func TestFileTokenProviderErrors(t *testing.T) {
+	provider, _, _ := newFileTokenProvider(t, nil)
+	if _, err := provider.GetToken(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to read calendar token") {
+		t.Errorf("GetToken() without a token file = %v", err)
+	}
+
+	provider, _, _ = newFileTokenProvider(t, &oauth2.Token{AccessToken: "stale", Expiry: time.Now().Add(-time.Minute)})
+	if _, err := provider.GetToken(context.Background()); err == nil || !strings.Contains(err.Error(), "no refresh token") {
+		t.Errorf("GetToken() without a refresh token = %v", err)
+	}
+
+	if _, err := NewFileTokenProvider(filepath.Join(t.TempDir(), "missing.json"), "token.json"); err == nil {
+		t.Error("NewFileTokenProvider() accepted missing credentials")
+	}
+}
+
+func TestGoogleCalendarRetriesWithRefreshedToken(t *testing.T) {
+	provider, _, refreshes := newFileTokenProvider(t, &oauth2.Token{
+		AccessToken:  "revoked",
+		RefreshToken: "refresh-me",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+	var events []googleEvent
+	server := googleCalendarServer(t, "access-1", &events)
+	cal := NewGoogleCalendar(provider, "team@example.com")
+	cal.apiURL = server.URL
+
+	if _, err := cal.CreateEventDraft(EventDraft{Summary: "Follow-up meeting"}); err != nil {
+		t.Fatal(err)
+	}
+	if refreshes.Load() != 1 || len(events) != 1 {
+		t.Errorf("%d refreshes, %d events, want the event created after one refresh", refreshes.Load(), len(events))
+	}
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Prompt variants an A/B test chooses between
+const (
+	ABVariantA = "A"
+	ABVariantB = "B"
+)
+
+// abTestSignificanceSamples is how many samples are collected between significance tests
+const abTestSignificanceSamples = 100
+
+// abTestSignificanceLevel is the p-value below which a difference between variants is significant
+const abTestSignificanceLevel = 0.05
+
+// ABTestMetrics describes one action item extraction run with an A/B test variant
+type ABTestMetrics struct {
+	ActionItemCount   int   `json:"action_item_count"`
+	PromptLengthChars int   `json:"prompt_length_chars"`
+	LLMLatencyMs      int64 `json:"llm_latency_ms"` // Near zero when the response came from a batch job
+}
+
+// ABTestVariantResult aggregates the samples collected for one variant
+type ABTestVariantResult struct {
+	Samples               int     `json:"samples"`
+	MeanActionItems       float64 `json:"mean_action_items"`
+	MeanPromptLengthChars float64 `json:"mean_prompt_length_chars"`
+	MeanLLMLatencyMs      float64 `json:"mean_llm_latency_ms"`
+}
+
+// ABTestResults is the aggregate report across every agent running an A/B test
+type ABTestResults struct {
+	Samples     int                            `json:"samples"`
+	Variants    map[string]ABTestVariantResult `json:"variants"`
+	ChiSquare   float64                        `json:"chi_square,omitempty"`
+	PValue      float64                        `json:"p_value,omitempty"`
+	Significant bool                           `json:"significant"`
+	Evaluated   bool                           `json:"evaluated"` // Whether enough samples were collected to test significance
+}
+
+// abTestTotals sums the metrics recorded for one variant
+type abTestTotals struct {
+	samples     int
+	actionItems int
+	promptChars int
+	latencyMs   int64
+}
+
+// ABTestRecorder collects A/B test samples from every analyst agent
+type ABTestRecorder struct {
+	mu     sync.Mutex
+	totals map[string]*abTestTotals
+}
+
+// NewABTestRecorder creates an empty A/B test recorder
+func NewABTestRecorder() *ABTestRecorder {
+	return &ABTestRecorder{totals: map[string]*abTestTotals{
+		ABVariantA: {},
+		ABVariantB: {},
+	}}
+}
+
+// Record adds a sample for variant, logging the significance of the difference between variants every
+// 100 samples
+func (r *ABTestRecorder) Record(variant string, metrics ABTestMetrics) {
+	r.mu.Lock()
+	totals, ok := r.totals[variant]
+	if !ok {
+		r.mu.Unlock()
+		return
+	}
+	totals.samples++
+	totals.actionItems += metrics.ActionItemCount
+	totals.promptChars += metrics.PromptLengthChars
+	totals.latencyMs += metrics.LLMLatencyMs
+	samples := r.totals[ABVariantA].samples + r.totals[ABVariantB].samples
+	r.mu.Unlock()
+
+	if samples%abTestSignificanceSamples != 0 {
+		return
+	}
+	results := r.Results()
+	if !results.Evaluated {
+		return
+	}
+	logrus.Infof("A/B test after %d samples: mean action items A=%.2f B=%.2f, chi-square=%.3f, p=%.4f (significant: %t)",
+		results.Samples, results.Variants[ABVariantA].MeanActionItems, results.Variants[ABVariantB].MeanActionItems,
+		results.ChiSquare, results.PValue, results.Significant)
+}
+
+// Results aggregates the samples collected so far. Once 100 samples are in, a chi-square test checks
+// whether the action items found per run differ between the variants.
+func (r *ABTestRecorder) Results() ABTestResults {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := ABTestResults{Variants: make(map[string]ABTestVariantResult, len(r.totals))}
+	for variant, totals := range r.totals {
+		result := ABTestVariantResult{Samples: totals.samples}
+		if totals.samples > 0 {
+			n := float64(totals.samples)
+			result.MeanActionItems = float64(totals.actionItems) / n
+			result.MeanPromptLengthChars = float64(totals.promptChars) / n
+			result.MeanLLMLatencyMs = float64(totals.latencyMs) / n
+		}
+		results.Variants[variant] = result
+		results.Samples += totals.samples
+	}
+
+	if results.Samples < abTestSignificanceSamples {
+		return results
+	}
+	chiSquare, ok := actionItemChiSquare(r.totals[ABVariantA], r.totals[ABVariantB])
+	if !ok {
+		return results
+	}
+	results.Evaluated = true
+	results.ChiSquare = chiSquare
+	results.PValue = math.Erfc(math.Sqrt(chiSquare / 2)) // chi-square survival function with one degree of freedom
+	results.Significant = results.PValue < abTestSignificanceLevel
+	return results
+}
+
+// actionItemChiSquare is the goodness-of-fit statistic for the action items found by each variant,
+// against the split expected if both variants found the same number per run
+func actionItemChiSquare(a, b *abTestTotals) (float64, bool) {
+	runs := a.samples + b.samples
+	items := a.actionItems + b.actionItems
+	if a.samples == 0 || b.samples == 0 || items == 0 {
+		return 0, false
+	}
+
+	chiSquare := 0.0
+	for _, totals := range []*abTestTotals{a, b} {
+		expected := float64(items) * float64(totals.samples) / float64(runs)
+		diff := float64(totals.actionItems) - expected
+		chiSquare += diff * diff / expected
+	}
+	return chiSquare, true
+}
+
+// SetABTestRecorder sets the recorder A/B test samples are reported to
+func (a *AnalystAgent) SetABTestRecorder(recorder *ABTestRecorder) {
+	a.abTests = recorder
+}
+
+// selectABTestVariant picks the action item prompt variant for the next analysis run, or none when the
+// agent isn't running an A/B test
+func (a *AnalystAgent) selectABTestVariant() {
+	a.abVariant = ""
+	test := a.config.ABTest
+	if test == nil || !test.Enabled {
+		return
+	}
+
+	split := test.SplitRatio
+	if split <= 0 || split > 1 {
+		split = 0.5
+	}
+	a.abVariant = ABVariantB
+	if rand.Float64() < split {
+		a.abVariant = ABVariantA
+	}
+}
+
+// abTestPrompt renders the selected variant's prompt template. A variant that fails to render drops
+// the run from the test, falling back to the regular prompt.
+func (a *AnalystAgent) abTestPrompt(transcript string) (string, bool) {
+	if a.abVariant == "" {
+		return "", false
+	}
+
+	text := a.config.ABTest.VariantA
+	if a.abVariant == ABVariantB {
+		text = a.config.ABTest.VariantB
+	}
+
+	data := PromptTemplateData{Transcript: transcript}
+	if a.config.CustomPrompt != nil {
+		data.CustomInstructions = *a.config.CustomPrompt
+	}
+
+	var buf bytes.Buffer
+	tmpl, err := template.New("variant_" + a.abVariant).Option("missingkey=error").Parse(text)
+	if err == nil {
+		err = tmpl.Execute(&buf, data)
+	}
+	if err != nil {
+		logrus.Warnf("Agent %s: A/B test variant %s failed, using built-in prompt: %v", a.agentID, a.abVariant, err)
+		a.abVariant = ""
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// recordABTestSample stores the metrics of an action item extraction run made with an A/B test variant
+func (a *AnalystAgent) recordABTestSample(actionItems, promptLength int, latency time.Duration) {
+	if a.abVariant == "" {
+		return
+	}
+
+	metrics := ABTestMetrics{
+		ActionItemCount:   actionItems,
+		PromptLengthChars: promptLength,
+		LLMLatencyMs:      latency.Milliseconds(),
+	}
+
+	a.dataMutex.Lock()
+	a.data.ABTestVariant = a.abVariant
+	a.data.ABTestMetrics = &metrics
+	a.dataMutex.Unlock()
+
+	if a.abTests != nil {
+		a.abTests.Record(a.abVariant, metrics)
+	}
+	logrus.Debugf("Agent %s: A/B test variant %s found %d action items (%d prompt chars, %dms)",
+		a.agentID, a.abVariant, actionItems, promptLength, metrics.LLMLatencyMs)
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/models"
+)
+
+func TestABTestRecorderResults(t *testing.T) {
+	recorder := NewABTestRecorder()
+	recorder.Record("C", ABTestMetrics{ActionItemCount: 10}) // Unknown variants are ignored
+	for i := 0; i < 49; i++ {
+		recorder.Record(ABVariantA, ABTestMetrics{ActionItemCount: 2, PromptLengthChars: 1000, LLMLatencyMs: 200})
+		recorder.Record(ABVariantB, ABTestMetrics{ActionItemCount: 1, PromptLengthChars: 500, LLMLatencyMs: 100})
+	}
+
+	results := recorder.Results()
+	if results.Samples != 98 || results.Evaluated {
+		t.Fatalf("results = %+v, want 98 samples and no significance test yet", results)
+	}
+	if a := results.Variants[ABVariantA]; a.MeanActionItems != 2 || a.MeanPromptLengthChars != 1000 || a.MeanLLMLatencyMs != 200 {
+		t.Errorf("variant A = %+v", a)
+	}
+
+	recorder.Record(ABVariantA, ABTestMetrics{ActionItemCount: 2})
+	recorder.Record(ABVariantB, ABTestMetrics{ActionItemCount: 1})
+	results = recorder.Results()
+	// 100 items against 50, where 75 each were expected
+	if !results.Evaluated || !approx(results.ChiSquare, 50.0/3) {
+		t.Fatalf("results = %+v, want chi-square 16.67", results)
+	}
+	if !results.Significant || results.PValue > 0.001 {
+		t.Errorf("p-value %.5f, significant %t, want a significant difference", results.PValue, results.Significant)
+	}
+}
+
+func TestABTestRecorderNoDifference(t *testing.T) {
+	recorder := NewABTestRecorder()
+	for i := 0; i < 50; i++ {
+		recorder.Record(ABVariantA, ABTestMetrics{ActionItemCount: 1})
+		recorder.Record(ABVariantB, ABTestMetrics{ActionItemCount: 1})
+	}
+	results := recorder.Results()
+	if !results.Evaluated || results.ChiSquare != 0 || results.PValue != 1 || results.Significant {
+		t.Errorf("results = %+v, want an insignificant difference", results)
+	}
+}
+
+func TestActionItemChiSquareNeedsBothVariants(t *testing.T) {
+	if _, ok := actionItemChiSquare(&abTestTotals{samples: 100, actionItems: 50}, &abTestTotals{}); ok {
+		t.Error("chi-square computed without samples for variant B")
+	}
+	if _, ok := actionItemChiSquare(&abTestTotals{samples: 50}, &abTestTotals{samples: 50}); ok {
+		t.Error("chi-square computed without any action items")
+	}
+}
+
+func TestSelectABTestVariant(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.selectABTestVariant()
+	if analyst.abVariant != "" {
+		t.Errorf("variant %q selected without an A/B test", analyst.abVariant)
+	}
+
+	analyst.config.ABTest = &models.ABTestConfig{Enabled: true, SplitRatio: 1}
+	analyst.selectABTestVariant()
+	if analyst.abVariant != ABVariantA {
+		t.Errorf("variant %q selected with every run going to A", analyst.abVariant)
+	}
+
+	// An out of range split falls back to an even split
+	analyst.config.ABTest.SplitRatio = 3
+	seen := map[string]bool{}
+	for i := 0; i < 200 && len(seen) < 2; i++ {
+		analyst.selectABTestVariant()
+		seen[analyst.abVariant] = true
+	}
+	if !seen[ABVariantA] || !seen[ABVariantB] {
+		t.Errorf("variants selected = %v, want both", seen)
+	}
+}
+
+func TestABTestPrompt(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if _, ok := analyst.abTestPrompt("transcript"); ok {
+		t.Error("variant prompt used without a selected variant")
+	}
+
+	instructions := "Focus on owners"
+	analyst.config.CustomPrompt = &instructions
+	analyst.config.ABTest = &models.ABTestConfig{
+		Enabled:  true,
+		VariantA: "A: {{.CustomInstructions}} / {{.Transcript}}",
+		VariantB: "B: {{.Missing}}",
+	}
+
+	analyst.abVariant = ABVariantA
+	if prompt, ok := analyst.abTestPrompt("Alice: hi"); !ok || prompt != "A: Focus on owners / Alice: hi" {
+		t.Errorf("abTestPrompt() = %q, %v", prompt, ok)
+	}
+
+	// A broken variant drops the run from the test
+	analyst.abVariant = ABVariantB
+	if _, ok := analyst.abTestPrompt("Alice: hi"); ok || analyst.abVariant != "" {
+		t.Errorf("broken variant used, variant = %q", analyst.abVariant)
+	}
+}
+
+func TestRecordABTestSample(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	recorder := NewABTestRecorder()
+	analyst.SetABTestRecorder(recorder)
+
+	analyst.recordABTestSample(3, 100, time.Second)
+	if analyst.data.ABTestMetrics != nil || recorder.Results().Samples != 0 {
+		t.Fatal("sample recorded without a selected variant")
+	}
+
+	analyst.abVariant = ABVariantB
+	analyst.recordABTestSample(3, 100, 1500*time.Millisecond)
+	if analyst.data.ABTestVariant != ABVariantB || *analyst.data.ABTestMetrics != (ABTestMetrics{ActionItemCount: 3, PromptLengthChars: 100, LLMLatencyMs: 1500}) {
+		t.Errorf("variant %q, metrics %+v", analyst.data.ABTestVariant, analyst.data.ABTestMetrics)
+	}
+	if b := recorder.Results().Variants[ABVariantB]; b.Samples != 1 || math.Abs(b.MeanActionItems-3) > 1e-9 {
+		t.Errorf("recorder variant B = %+v", b)
+	}
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/knowledge"
+)
+
+const (
+	// maxAcronymsPerRun is the most new acronyms sent to the LLM in one analysis run
+	maxAcronymsPerRun = 30
+	// acronymContexts is the number of statements quoted to the LLM for each acronym
+	acronymContexts = 2
+)
+
+// acronymPattern matches runs of 2 to 5 capital letters, capturing the acronym of plurals such as "KPIs"
+var acronymPattern = regexp.MustCompile(`\b([A-Z]{2,5})s?\b`)
+
+// nonAcronyms are ordinary words that show up in capitals when shouted or transcribed in capitals
+var nonAcronyms = map[string]bool{
+	"OK": true, "OKAY": true, "YES": true, "NO": true, "HI": true, "HEY": true, "AM": true, "PM": true,
+	"UH": true, "UM": true, "HMM": true, "OH": true, "WOW": true, "LOL": true,
+}
+
+// extractAcronyms returns the acronyms in the transcript in the order they first appear. Statements
+// mostly written in capitals are skipped, since their capitalized words aren't acronyms.
+func extractAcronyms(transcript []TranscriptEntry) []string {
+	seen := make(map[string]bool)
+	var acronyms []string
+	for _, entry := range transcript {
+		if mostlyUppercase(entry.Text) {
+			continue
+		}
+		for _, match := range acronymPattern.FindAllStringSubmatch(entry.Text, -1) {
+			if acronym := match[1]; !seen[acronym] && !nonAcronyms[acronym] {
+				seen[acronym] = true
+				acronyms = append(acronyms, acronym)
+			}
+		}
+	}
+	return acronyms
+}
+
+// mostlyUppercase reports whether more than three quarters of the letters of a text with at least three
+// words are capitals
+func mostlyUppercase(text string) bool {
+	if len(strings.Fields(text)) < 3 {
+		return false
+	}
+	var letters, upper int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters > 0 && upper*4 > letters*3
+}
+
+// buildAcronymGlossary expands the acronyms used in the meeting. Acronyms are looked up in the knowledge
+// base first, then expanded by the LLM from the statements they were used in, and finally searched for
+// when the LLM can't tell from context. Expansions found by the LLM are added to the knowledge base for
+// future meetings when it can learn terms.
+func (a *AnalystAgent) buildAcronymGlossary(ctx context.Context) error {
+	transcript := a.getFullTranscript()
+
+	a.dataMutex.RLock()
+	glossary := make(map[string]string, len(a.data.AcronymGlossary))
+	for acronym, expansion := range a.data.AcronymGlossary {
+		glossary[acronym] = expansion
+	}
+	a.dataMutex.RUnlock()
+
+	var unresolved []string
+	for _, acronym := range extractAcronyms(transcript) {
+		if _, ok := glossary[acronym]; ok {
+			continue
+		}
+		if a.knowledge != nil {
+			if explanation, ok := a.knowledge.Lookup(acronym); ok {
+				glossary[acronym] = explanation
+				continue
+			}
+		}
+		unresolved = append(unresolved, acronym)
+	}
+	if len(unresolved) > maxAcronymsPerRun {
+		unresolved = unresolved[:maxAcronymsPerRun]
+	}
+
+	var err error
+	if len(unresolved) > 0 {
+		logrus.Infof("Agent %s: Expanding %d acronyms", a.agentID, len(unresolved))
+
+		var expanded map[string]string
+		if expanded, err = a.expandAcronyms(ctx, unresolved, transcript); err == nil {
+			if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+				var missing []string
+				for _, acronym := range unresolved {
+					if expanded[acronym] == "" {
+						missing = append(missing, acronym)
+					}
+				}
+				if len(missing) > 0 {
+					searched, searchErr := a.searchAcronyms(ctx, groundingProvider, missing, transcript)
+					if searchErr != nil {
+						logrus.Warnf("Agent %s: Failed to search for acronyms: %v", a.agentID, searchErr)
+					}
+					for acronym, expansion := range searched {
+						expanded[acronym] = expansion
+					}
+				}
+			}
+			a.learnTerms(expanded)
+			for acronym, expansion := range expanded {
+				glossary[acronym] = expansion
+			}
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.AcronymGlossary = glossary
+	a.dataMutex.Unlock()
+	return err
+}
+
+// expandAcronyms asks the LLM to expand each acronym from the statements it was used in, returning only
+// the acronyms it could expand
+func (a *AnalystAgent) expandAcronyms(ctx context.Context, acronyms []string, transcript []TranscriptEntry) (map[string]string, error) {
+	prompt := a.languagePrefix() + fmt.Sprintf(`Expand each of these acronyms as they are meant in this meeting, using the statements they were used in. Give the expansion followed by a short explanation when the expansion alone is unclear, e.g. "SLA": "Service Level Agreement". Leave the expansion empty when the statements don't make the meaning clear; don't guess.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "acronyms": {"ABC": "Expansion", "XYZ": ""}
+}
+`+"`"+``, acronymContextLines(acronyms, transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return a.parseAcronymExpansions(ctx, response, acronyms)
+}
+
+// searchAcronyms asks a grounded model to expand acronyms the meeting context didn't explain
+func (a *AnalystAgent) searchAcronyms(ctx context.Context, provider llm.GroundingCapableProvider, acronyms []string, transcript []TranscriptEntry) (map[string]string, error) {
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to find what each of these acronyms most likely means given the statements from a business meeting they were used in. Leave the expansion empty when no meaning fits the statements.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "acronyms": {"ABC": "Expansion", "XYZ": ""}
+}
+`+"`"+``, acronymContextLines(acronyms, transcript))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("empty acronym search response")
+	}
+	return a.parseAcronymExpansions(ctx, response.Text, acronyms)
+}
+
+// parseAcronymExpansions reads the expansions of the requested acronyms from an LLM response, dropping
+// empty expansions and acronyms that weren't asked about
+func (a *AnalystAgent) parseAcronymExpansions(ctx context.Context, response string, acronyms []string) (map[string]string, error) {
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil, fmt.Errorf("no JSON in acronym response")
+	}
+
+	var result struct {
+		Acronyms map[string]string `json:"acronyms"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse acronym JSON: %w", err)
+	}
+
+	expanded := make(map[string]string)
+	for _, acronym := range acronyms {
+		if expansion := strings.TrimSpace(result.Acronyms[acronym]); expansion != "" {
+			expanded[acronym] = expansion
+		}
+	}
+	return expanded, nil
+}
+
+// acronymContextLines lists each acronym with the first statements it was used in
+func acronymContextLines(acronyms []string, transcript []TranscriptEntry) string {
+	var lines strings.Builder
+	for _, acronym := range acronyms {
+		pattern := regexp.MustCompile(`\b` + acronym + `s?\b`)
+		lines.WriteString("- " + acronym + "\n")
+		quoted := 0
+		for _, entry := range transcript {
+			if pattern.MatchString(entry.Text) {
+				fmt.Fprintf(&lines, "  %s: %q\n", entry.Speaker, entry.Text)
+				if quoted++; quoted == acronymContexts {
+					break
+				}
+			}
+		}
+	}
+	return lines.String()
+}
+
+// learnTerms adds explained terms such as expanded acronyms to the knowledge base when it can learn terms
+func (a *AnalystAgent) learnTerms(explanations map[string]string) {
+	writer, ok := a.knowledge.(knowledge.Writer)
+	if !ok || len(explanations) == 0 {
+		return
+	}
+
+	terms := make([]string, 0, len(explanations))
+	for term := range explanations {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	for _, term := range terms {
+		if err := writer.Add(term, explanations[term]); err != nil {
+			logrus.Warnf("Agent %s: Failed to add %s to the knowledge base: %v", a.agentID, term, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// learningGlossary is a knowledge base that learns the terms added to it
+type learningGlossary map[string]string
+
+func (g learningGlossary) Lookup(term string) (string, bool) {
+	explanation, ok := g[term]
+	return explanation, ok
+}
+
+func (g learningGlossary) Add(term, explanation string) error {
+	g[term] = explanation
+	return nil
+}
+
+func TestExtractAcronyms(t *testing.T) {
+	transcript := []TranscriptEntry{
+		entryAt(0, "Alice", "I think the AI team should own the KPIs before the QBR"),
+		entryAt(10, "Bob", "OK, and the SLA? The API ships in Q3 with an SDK, ARR permitting"),
+		entryAt(20, "Carol", "YES THIS IS FINE BY ME"),
+		entryAt(30, "Dana", "Our LONGACRONYM stays out, as does the AI mention again"),
+	}
+
+	// "I" is too short and "OK" is an ordinary word; plurals give their acronym, and shouting isn't acronyms
+	want := []string{"AI", "KPI", "QBR", "SLA", "API", "SDK", "ARR"}
+	if got := extractAcronyms(transcript); !reflect.DeepEqual(got, want) {
+		t.Errorf("extractAcronyms() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildAcronymGlossary(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	kb := learningGlossary{"ARR": "Annual recurring revenue"}
+	analyst.SetKnowledgeBase(kb)
+	mock := llm.NewMockLLMProvider("```json\n" + `{"acronyms": {"AI": "Artificial intelligence", "KPI": "", "XYZ": "Not asked about"}}` + "\n```")
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "The AI team owns the KPI and ARR target")
+
+	if err := analyst.buildAcronymGlossary(context.Background()); err != nil {
+		t.Fatalf("buildAcronymGlossary() error = %v", err)
+	}
+
+	// ARR comes from the knowledge base, and the LLM couldn't expand KPI from context
+	want := map[string]string{"AI": "Artificial intelligence", "ARR": "Annual recurring revenue"}
+	if got := analyst.GetAnalysis().AcronymGlossary; !reflect.DeepEqual(got, want) {
+		t.Errorf("AcronymGlossary = %v, want %v", got, want)
+	}
+	if kb["AI"] != "Artificial intelligence" {
+		t.Errorf("knowledge base = %v, want AI learned", kb)
+	}
+}
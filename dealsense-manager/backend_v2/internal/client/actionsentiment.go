@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// actionItemContextRadius is the number of statements either side of the one raising an action item that
+// are scored for its sentiment
+const actionItemContextRadius = 1
+
+// actionItemOrigin is where an action item was raised in the transcript and the mood of that discussion
+type actionItemOrigin struct {
+	Item            int     `json:"item"`
+	Statement       int     `json:"statement"`
+	Sentiment       string  `json:"sentiment"`
+	Score           float64 `json:"score"`
+	RaisedInContext string  `json:"raised_in_context"`
+}
+
+// classifyActionItemSentiment records the sentiment of the discussion each new action item was raised in,
+// and a short description of that discussion. The LLM places items in the transcript and rates the
+// discussion; in cost saving mode, or for items it doesn't place, the statement is found by word overlap
+// and scored with the word-list heuristic. Items that can't be placed in the transcript are neutral.
+func (a *AnalystAgent) classifyActionItemSentiment(ctx context.Context, transcript []TranscriptEntry) error {
+	a.dataMutex.RLock()
+	var pending []ActionItem
+	for _, item := range a.data.ActionItems {
+		if item.Sentiment == "" {
+			pending = append(pending, item)
+		}
+	}
+	a.dataMutex.RUnlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var origins map[int]actionItemOrigin
+	var locateErr error
+	if !a.config.CostSavingMode {
+		origins, locateErr = a.locateActionItemsWithLLM(ctx, transcript, pending)
+	}
+
+	classified := make(map[string]actionItemOrigin, len(pending))
+	for i, item := range pending {
+		origin, ok := origins[i]
+		if !ok {
+			origin = actionItemOrigin{Statement: locateActionItemStatement(transcript, item.Description)}
+		}
+		if origin.Sentiment == "" {
+			origin.Sentiment, origin.Score = "neutral", 0
+			if origin.Statement >= 0 {
+				from := max(origin.Statement-actionItemContextRadius, 0)
+				to := min(origin.Statement+actionItemContextRadius+1, len(transcript))
+				origin.Sentiment, origin.Score = heuristicSentiment(transcript[from:to])
+			}
+		}
+		classified[item.ID] = origin
+	}
+
+	a.dataMutex.Lock()
+	for i := range a.data.ActionItems {
+		item := &a.data.ActionItems[i]
+		if origin, ok := classified[item.ID]; ok && item.Sentiment == "" {
+			item.Sentiment = origin.Sentiment
+			item.SentimentScore = origin.Score
+			item.RaisedInContext = origin.RaisedInContext
+		}
+	}
+	a.dataMutex.Unlock()
+
+	return locateErr
+}
+
+// locateActionItemsWithLLM asks the LLM which statement raised each item and how the discussion around it
+// felt, returning the results by item index
+func (a *AnalystAgent) locateActionItemsWithLLM(ctx context.Context, transcript []TranscriptEntry, items []ActionItem) (map[int]actionItemOrigin, error) {
+	var list strings.Builder
+	for i, item := range items {
+		list.WriteString(fmt.Sprintf("[%d] %s\n", i, item.Description))
+	}
+	var result struct {
+		Items []actionItemOrigin `json:"items"`
+	}
+	request := indexedStatementsRequest{
+		task:     `For each action item below, find the transcript statement where it was raised and rate the sentiment of the discussion around that statement.`,
+		sections: []indexedSection{{title: "Action items", text: list.String()}, transcriptSection(transcript)},
+		indexed:  "action item and each statement",
+		format: `{
+  "items": [
+    {
+      "item": 0,
+      "statement": 12,
+      "sentiment": "positive/negative/neutral",
+      "score": -0.4,
+      "raised_in_context": "One or two sentences on what was being discussed when the item came up"
+    }
+  ]
+}`,
+		notes:  "\n\nUse a statement of -1 when an item was not raised in this part of the transcript. The score ranges from -1 (very negative) to 1 (very positive).",
+		result: "action item sentiment",
+	}
+	ok, err := a.askAboutStatements(ctx, request, &result)
+	if err != nil {
+		logrus.Warnf("Failed to classify action item sentiment: %v", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	origins := make(map[int]actionItemOrigin, len(result.Items))
+	for _, origin := range result.Items {
+		if _, ok := statementAt(transcript, origin.Statement); !ok || origin.Item < 0 || origin.Item >= len(items) {
+			continue
+		}
+		origin.Score = math.Max(-1, math.Min(1, origin.Score))
+		switch origin.Sentiment = strings.ToLower(strings.TrimSpace(origin.Sentiment)); origin.Sentiment {
+		case "positive", "negative", "neutral":
+		default:
+			origin.Sentiment = sentimentLabel(origin.Score)
+		}
+		origin.RaisedInContext = strings.TrimSpace(origin.RaisedInContext)
+		origins[origin.Item] = origin
+	}
+	return origins, nil
+}
+
+// locateActionItemStatement returns the index of the statement sharing the most significant words with
+// the description, or -1 when none shares at least two
+func locateActionItemStatement(transcript []TranscriptEntry, description string) int {
+	words := significantWords(description)
+	best, bestShared := -1, 1
+	for i, entry := range transcript {
+		shared := 0
+		for word := range significantWords(entry.Text) {
+			if words[word] {
+				shared++
+			}
+		}
+		if shared > bestShared {
+			best, bestShared = i, shared
+		}
+	}
+	return best
+}
+
+// significantWords returns the lowercased words of text that aren't stopwords
+func significantWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'')
+	}) {
+		if len(word) > 2 && !defaultStopwords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
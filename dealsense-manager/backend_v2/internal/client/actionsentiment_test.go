@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// sentimentTranscript has a tense discussion of a blocked migration followed by an upbeat launch update
+func sentimentTranscript() []TranscriptEntry {
+	return []TranscriptEntry{
+		entryAt(0, "Alice", "Good morning everyone"),
+		entryAt(10, "Bob", "The deployment failed again and the database migration is blocked"),
+		entryAt(20, "Carol", "Unfortunately that is a big problem"),
+		entryAt(30, "Alice", "Great progress on the marketing launch, thanks team"),
+	}
+}
+
+func TestLocateActionItemStatement(t *testing.T) {
+	transcript := sentimentTranscript()
+	if got := locateActionItemStatement(transcript, "Fix the database migration blocker"); got != 1 {
+		t.Errorf("locateActionItemStatement() = %d, want 1", got)
+	}
+	// A single shared word isn't enough
+	if got := locateActionItemStatement(transcript, "Order the team lunch"); got != -1 {
+		t.Errorf("locateActionItemStatement() = %d, want -1", got)
+	}
+}
+
+func TestClassifyActionItemSentimentHeuristic(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.CostSavingMode = true
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	analyst.data.ActionItems = []ActionItem{
+		{ID: "a1", Description: "Fix the database migration blocker"},
+		{ID: "a2", Description: "Order the team lunch"},
+		{ID: "a3", Description: "Already classified", Sentiment: "positive", SentimentScore: 0.5},
+	}
+
+	if err := analyst.classifyActionItemSentiment(context.Background(), sentimentTranscript()); err != nil {
+		t.Fatal(err)
+	}
+
+	items := analyst.data.ActionItems
+	// Statements 0-2 hold one positive and four negative words
+	if items[0].Sentiment != "negative" || !approx(items[0].SentimentScore, -0.6) {
+		t.Errorf("migration item = %s (%.2f), want negative (-0.60)", items[0].Sentiment, items[0].SentimentScore)
+	}
+	if items[1].Sentiment != "neutral" || items[1].SentimentScore != 0 {
+		t.Errorf("unplaced item = %s (%.2f), want neutral", items[1].Sentiment, items[1].SentimentScore)
+	}
+	if items[2].Sentiment != "positive" || items[2].SentimentScore != 0.5 {
+		t.Errorf("classified item changed to %s (%.2f)", items[2].Sentiment, items[2].SentimentScore)
+	}
+}
+
+func TestClassifyActionItemSentimentWithLLM(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n" + `{"items": [
+		{"item": 0, "statement": 3, "sentiment": "Upbeat", "score": 1.7, "raised_in_context": " Celebrating the launch "},
+		{"item": 1, "statement": 99, "sentiment": "positive", "score": 0.9}
+	]}` + "\n```")
+	analyst.llmProvider = mock
+	analyst.data.ActionItems = []ActionItem{
+		{ID: "a1", Description: "Plan the launch party"},
+		{ID: "a2", Description: "Fix the database migration blocker"},
+	}
+
+	if err := analyst.classifyActionItemSentiment(context.Background(), sentimentTranscript()); err != nil {
+		t.Fatal(err)
+	}
+	if prompt := mock.Prompts()[0]; !strings.Contains(prompt, "[1] Fix the database migration blocker") {
+		t.Errorf("prompt doesn't index the action items:\n%s", prompt)
+	}
+
+	items := analyst.data.ActionItems
+	// Unknown labels fall back to the clamped score
+	if items[0].Sentiment != "positive" || items[0].SentimentScore != 1 || items[0].RaisedInContext != "Celebrating the launch" {
+		t.Errorf("launch item = %+v", items[0])
+	}
+	// An out of range statement falls back to the heuristic
+	if items[1].Sentiment != "negative" || items[1].RaisedInContext != "" {
+		t.Errorf("migration item = %+v, want the heuristic result", items[1])
+	}
+}
+
+func TestClassifyActionItemSentimentLLMErrorFallsBack(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	analyst.data.ActionItems = []ActionItem{{ID: "a1", Description: "Fix the database migration blocker"}}
+
+	if err := analyst.classifyActionItemSentiment(context.Background(), sentimentTranscript()); err == nil {
+		t.Error("classifyActionItemSentiment() error = nil, want the LLM error")
+	}
+	if item := analyst.data.ActionItems[0]; item.Sentiment != "negative" {
+		t.Errorf("Sentiment = %q, want the heuristic result despite the error", item.Sentiment)
+	}
+}
@@ -1,38 +1,243 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"joinly-manager/internal/calendar"
 	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/event"
+	"joinly-manager/internal/i18n"
+	"joinly-manager/internal/knowledge"
+	"joinly-manager/internal/mailer"
+	"joinly-manager/internal/marketdata"
+	"joinly-manager/internal/migration"
 	"joinly-manager/internal/models"
+	"joinly-manager/internal/shutdown"
+	"joinly-manager/internal/storage"
 )
 
 // AnalysisData represents the comprehensive analysis data for a meeting
 type AnalysisData struct {
-	MeetingID         string            `json:"meeting_id"`
-	MeetingURL        string            `json:"meeting_url"`
-	StartTime         time.Time         `json:"start_time"`
-	LastUpdated       time.Time         `json:"last_updated"`
-	Transcript        []TranscriptEntry `json:"transcript"`
-	Summary           string            `json:"summary"`
-	GroundedSummary   *GroundedContent  `json:"grounded_summary,omitempty"`
-	KeyPoints         []string          `json:"key_points"`
-	GroundedKeyPoints *GroundedContent  `json:"grounded_key_points,omitempty"`
-	ActionItems       []ActionItem      `json:"action_items"`
-	Topics            []TopicDiscussion `json:"topics"`
-	Participants      []string          `json:"participants"`
-	DurationMinutes   float64           `json:"duration_minutes"`
-	WordCount         int               `json:"word_count"`
-	Sentiment         string            `json:"sentiment"`
-	Keywords          []string          `json:"keywords"`
+	SchemaVersion           int                           `json:"schema_version"`
+	MeetingID               string                        `json:"meeting_id"`
+	TenantID                string                        `json:"tenant_id,omitempty"`
+	MeetingURL              string                        `json:"meeting_url"`
+	RecordingURL            string                        `json:"recording_url,omitempty"`
+	RecordingPlatform       string                        `json:"recording_platform,omitempty"` // zoom, teams, meet, custom
+	StartTime               time.Time                     `json:"start_time"`
+	LastUpdated             time.Time                     `json:"last_updated"`
+	Transcript              []TranscriptEntry             `json:"transcript"`
+	Summary                 string                        `json:"summary"`
+	GroundedSummary         *GroundedContent              `json:"grounded_summary,omitempty"`
+	KeyPoints               []string                      `json:"key_points"`
+	GroundedKeyPoints       *GroundedContent              `json:"grounded_key_points,omitempty"`
+	ActionItems             []ActionItem                  `json:"action_items"`
+	Topics                  []TopicDiscussion             `json:"topics"`
+	ChapterMarkers          []ChapterMarker               `json:"chapter_markers,omitempty"` // Recording chapters derived from the topics
+	Participants            []string                      `json:"participants"`
+	DurationMinutes         float64                       `json:"duration_minutes"`
+	WordCount               int                           `json:"word_count"`
+	Sentiment               string                        `json:"sentiment"`
+	SentimentTimeline       []SentimentPoint              `json:"sentiment_timeline,omitempty"`
+	Keywords                []string                      `json:"keywords"`
+	WordCloudData           []WordFrequency               `json:"word_cloud_data,omitempty"`
+	KeyQuotes               []Quote                       `json:"key_quotes,omitempty"`
+	ConflictingStatements   []StatementConflict           `json:"conflicting_statements,omitempty"`
+	CompetitiveIntelligence *CompIntelReport              `json:"competitive_intelligence,omitempty"`
+	Requirements            []Requirement                 `json:"requirements,omitempty"`
+	UnansweredQuestions     []UnansweredQuestion          `json:"unanswered_questions,omitempty"`
+	KeyMetrics              []MetricMention               `json:"key_metrics,omitempty"`
+	BookRecommendations     []BookRecommendation          `json:"book_recommendations,omitempty"`
+	ComplianceFlags         []ComplianceFlag              `json:"compliance_flags,omitempty"`
+	NPSProxy                *NPSData                      `json:"nps_proxy,omitempty"`
+	ProductFeedback         []FeedbackItem                `json:"product_feedback,omitempty"`
+	SpeakerPersonas         map[string]SpeakerPersona     `json:"speaker_personas,omitempty"`
+	AcronymGlossary         map[string]string             `json:"acronym_glossary,omitempty"`  // Acronyms used in the meeting and their expansions
+	Corrections             []Correction                  `json:"corrections,omitempty"`       // Reviewer fixes shown to the LLM in later analyses
+	QualityChecks           map[string]QualityCheckResult `json:"quality_checks,omitempty"`    // Latest quality check of each analysis type's LLM response
+	AnalysisMetadata        map[string]StepMetadata       `json:"analysis_metadata,omitempty"` // How each analysis step's latest result was produced, keyed by step
+	ABTestVariant           string                        `json:"ab_test_variant,omitempty"`   // Action item prompt variant used by the latest analysis
+	ABTestMetrics           *ABTestMetrics                `json:"ab_test_metrics,omitempty"`
+	FollowUpSuggestion      *FollowUpSuggestion           `json:"follow_up_suggestion,omitempty"`  // Set when the meeting is finalized
+	FollowUpEmailDraft      *EmailDraft                   `json:"follow_up_email_draft,omitempty"` // Set when the meeting is finalized
+	NoisySegmentsDropped    int                           `json:"noisy_segments_dropped"`
+	TimeoutCount            int                           `json:"timeout_count"`      // Analysis steps that exceeded their timeout
+	PrunedEntryCount        int                           `json:"pruned_entry_count"` // Entries moved to the overflow file by the retention policy
+	CrosstalkEvents         []CrosstalkEvent              `json:"crosstalk_events,omitempty"`
+	SpeakerEngagementMap    map[string]EngagementStats    `json:"speaker_engagement_map,omitempty"`
+	CrosstalkRate           float64                       `json:"crosstalk_rate"`              // Crosstalk events per minute
+	DetectedLanguage        string                        `json:"detected_language,omitempty"` // ISO 639-1 code detected from the transcript
+	ResponseLanguage        string                        `json:"response_language,omitempty"` // Language the analysis is written in
+	Snapshots               []AnalysisSnapshot            `json:"snapshots,omitempty"`
+	MeetingScore            *MeetingBenchmark             `json:"meeting_score,omitempty"` // Set when the meeting is finalized
+	CostEstimate            *MeetingCostEstimate          `json:"cost_estimate,omitempty"` // Set when the meeting is finalized
+
+	// Past meetings of the tenant that discussed similar topics, most similar first, set when the meeting is finalized
+	SimilarMeetings []MeetingReference `json:"similar_meetings,omitempty"`
+
+	// How quickly each participant responds to others, from 0 to 100 (100 = always the fastest responder)
+	AttentionScores map[string]float64 `json:"attention_scores,omitempty"`
+
+	// Who responds to whom, and the participant involved in the most of these interactions
+	InteractionPatterns []InteractionEdge `json:"interaction_patterns,omitempty"`
+	CentralitySpeaker   string            `json:"centrality_speaker,omitempty"`
+
+	// Vocabulary richness and complexity of each participant, recomputed after each analysis
+	LanguageMetrics map[string]SpeakerLanguageMetrics `json:"language_metrics,omitempty"`
+
+	// Terms introduced in the meeting that aren't ordinary English words, such as project names and jargon
+	GlossaryOfNewTerms map[string]GlossaryEntry `json:"glossary_of_new_terms,omitempty"`
+
+	// How the discussion moved between consecutive topics, and the percentage of those moves that were natural
+	ConversationFlow   []TopicTransition `json:"conversation_flow,omitempty"`
+	FlowCoherenceScore float64           `json:"flow_coherence_score,omitempty"`
+
+	// How hard the summary, key points and transcript are to read, keyed by section, recomputed after each analysis
+	ReadabilityScores map[string]ReadabilityScore `json:"readability_scores,omitempty"`
+
+	// Technical debt mentioned in the meeting, accumulated across analysis runs
+	TechnicalDebtMentions []TechDebtItem `json:"technical_debt_mentions,omitempty"`
+
+	// Targets, KPIs and OKRs discussed in the meeting, updated as they are mentioned again
+	SuccessMetricsTracked []SuccessMetric `json:"success_metrics_tracked,omitempty"`
+
+	// Mood of each participant over the meeting, ordered by time and keyed by speaker
+	SpeakerMoodTimeline map[string][]MoodPoint `json:"speaker_mood_timeline,omitempty"`
+
+	// Whether each participant is internal, external or unknown, from the internal speakers and domains
+	SpeakerClassification map[string]string `json:"speaker_classification,omitempty"`
+
+	// Whether each of the agent's objectives was achieved, keyed by objective name, and the fraction achieved
+	ObjectiveCompletionStatus map[string]ObjectiveStatus `json:"objective_completion_status,omitempty"`
+	ObjectivesAchievementRate float64                    `json:"objectives_achievement_rate,omitempty"`
+
+	// Vocabulary, pacing and utterance length statistics of the transcript, recomputed after each analysis
+	TranscriptStatistics *TranscriptStats `json:"transcript_statistics,omitempty"`
+
+	// Red flags about the meeting such as running long or one participant dominating, set when it is finalized
+	HealthIndicators *MeetingHealthIndicators `json:"health_indicators,omitempty"`
+
+	// Budget categories whose budgeted and actual amounts were compared, updated as they are discussed again
+	BudgetDiscussions []BudgetItem `json:"budget_discussions,omitempty"`
+
+	// External organizations and people referenced in the meeting, with their relationship and mentions
+	StakeholderMap []Stakeholder `json:"stakeholder_map,omitempty"`
+
+	// Questions asked in the meeting with their answers, leaving out rhetorical questions
+	QuestionsAndAnswers []QAPair `json:"questions_and_answers,omitempty"`
+
+	// All-time records of the tenant's meetings this meeting broke, set when it is finalized
+	RecordBreakers []RecordEvent `json:"record_breakers,omitempty"`
+
+	// Three-sentence distillation of the summary for executives, with a bullet for each sentence
+	ExecutiveSummary        string   `json:"executive_summary,omitempty"`
+	ExecutiveSummaryBullets []string `json:"executive_summary_bullets,omitempty"`
+
+	// One sentence on the decisions, short enough for a push notification, and a Twitter/X-style post
+	TerseSummary string `json:"terse_summary,omitempty"` // At most 140 characters
+	TweetSummary string `json:"tweet_summary,omitempty"` // At most 280 characters
+
+	// Narrative of the meeting's emotional journey, written from its sentiment, moods and crosstalk when it
+	// is finalized
+	EmotionalArcNarrative string `json:"emotional_arc_narrative,omitempty"`
+
+	// Social conversation opening the meeting before its agenda, set when it is finalized. The quality is
+	// none when the meeting started straight with business and empty when it wasn't scored.
+	IcebreakerDetected        bool    `json:"icebreaker_detected"`
+	IcebreakerDurationSeconds float64 `json:"icebreaker_duration_seconds"`
+	IcebreakerQuality         string  `json:"icebreaker_quality,omitempty"` // none, brief, thorough
+
+	// Moments participants spoke unlike they usually do, such as sudden formality or long pauses before
+	// responding, which can indicate stress or evasion
+	SpeechPatternAnomalies []PatternAnomaly `json:"speech_pattern_anomalies,omitempty"`
+
+	// Links participants shared in the meeting, with the titles of their pages
+	ImportantLinks []LinkMention `json:"important_links,omitempty"`
+
+	// Objections prospects raised in a sales call and how they were handled
+	Objections []SalesObjection `json:"objections,omitempty"`
+
+	// Each participant's share of their utterances in each 2-minute window of the meeting
+	EngagementHeatmap []HeatmapRow `json:"engagement_heatmap,omitempty"`
+
+	// Customer-side people who took part in or were mentioned in the meeting, set when it is finalized
+	KeyContacts []ContactInfo `json:"key_contacts,omitempty"`
+
+	// Files and documents participants shared, promised or referred to, marked received through the API
+	AttachmentRefs []AttachmentReference `json:"attachment_refs,omitempty"`
+
+	// Discriminatory, harassing, confidentiality-breaching or inappropriate statements, for HR review
+	EthicsFlags []EthicsFlag `json:"ethics_flags,omitempty"`
+
+	// What the participants' time in the meeting cost against the outcomes it produced, set when it is
+	// finalized with ENABLE_ROI_REPORT
+	ROIReport *MeetingROI `json:"roi_report,omitempty"`
+
+	// The meeting's most important outcomes in one structure for API consumers, set when it is finalized
+	InsightsCard *MeetingInsightsCard `json:"insights_card,omitempty"`
+
+	// Counters for dashboards listing many meetings, refreshed after each utterance and analysis
+	QuickStats *QuickStats `json:"quick_stats,omitempty"`
+
+	// Output of registered plugins keyed by plugin name
+	PluginResults map[string]json.RawMessage `json:"plugin_results,omitempty"`
+
+	// Category of the meeting from the agent's meeting type options, set when the meeting is finalized
+	MeetingType           string  `json:"meeting_type,omitempty"`
+	MeetingTypeConfidence float64 `json:"meeting_type_confidence,omitempty"` // 0 to 1
+
+	// Version of the summary, key point, action item, topic and sentiment prompts the analysis was produced with
+	PromptVersion string            `json:"prompt_version,omitempty"`
+	PromptHashes  map[string]string `json:"prompt_hashes,omitempty"`
+
+	// Heuristic reliability of each analysis result, from 0 to 1
+	SummaryConfidence     float64 `json:"summary_confidence"`
+	KeyPointsConfidence   float64 `json:"key_points_confidence"`
+	ActionItemsConfidence float64 `json:"action_items_confidence"`
+	TopicsConfidence      float64 `json:"topics_confidence"`
+	SentimentConfidence   float64 `json:"sentiment_confidence"`
+}
+
+// AnalysisSnapshot captures the analysis results produced by a single analysis run
+type AnalysisSnapshot struct {
+	TakenAt     time.Time         `json:"taken_at"`
+	Summary     string            `json:"summary"`
+	KeyPoints   []string          `json:"key_points"`
+	ActionItems []ActionItem      `json:"action_items"`
+	Topics      []TopicDiscussion `json:"topics"`
+	Sentiment   string            `json:"sentiment"`
+	Keywords    []string          `json:"keywords"`
+}
+
+// maxAnalysisSnapshots bounds the number of historical snapshots kept per meeting
+const maxAnalysisSnapshots = 20
+
+// ToAnalysisData converts the snapshot into an AnalysisData holding only the analysis results
+func (s AnalysisSnapshot) ToAnalysisData() *AnalysisData {
+	return &AnalysisData{
+		LastUpdated: s.TakenAt,
+		Summary:     s.Summary,
+		KeyPoints:   s.KeyPoints,
+		ActionItems: s.ActionItems,
+		Topics:      s.Topics,
+		Sentiment:   s.Sentiment,
+		Keywords:    s.Keywords,
+	}
 }
 
 // TranscriptEntry represents a single transcript entry
@@ -41,6 +246,8 @@ type TranscriptEntry struct {
 	Speaker   string    `json:"speaker"`
 	Text      string    `json:"text"`
 	IsAgent   bool      `json:"is_agent"`
+
+	DeepLinkURL string `json:"deep_link_url,omitempty"` // Link to this moment in the recording, set by EnrichTranscriptWithDeepLinks
 }
 
 // GroundedContent represents content with grounding information for the analyzer
@@ -55,19 +262,68 @@ type ActionItem struct {
 	ID          string    `json:"id"`
 	Description string    `json:"description"`
 	Assignee    string    `json:"assignee,omitempty"`
-	Priority    string    `json:"priority"`       // high, medium, low
-	Type        string    `json:"type,omitempty"` // task, research, investigation, follow-up, decision
-	Status      string    `json:"status"`         // pending, in_progress, completed
+	Priority    string    `json:"priority"`           // high, medium, low
+	Type        string    `json:"type,omitempty"`     // task, research, investigation, follow-up, decision
+	Status      string    `json:"status"`             // pending, in_progress, completed
+	DueDate     string    `json:"due_date,omitempty"` // YYYY-MM-DD, stated in the meeting or inferred
 	CreatedAt   time.Time `json:"created_at"`
+
+	UserFeedback  *Feedback `json:"user_feedback,omitempty"`
+	MergedFromIDs []string  `json:"merged_from_ids,omitempty"` // IDs of earlier near-duplicate versions of this item
+	DependsOn     []string  `json:"depends_on,omitempty"`      // IDs of action items that must be completed first
+
+	// Mood of the discussion the item was raised in
+	Sentiment       string  `json:"sentiment,omitempty"`       // positive, negative, neutral
+	SentimentScore  float64 `json:"sentiment_score,omitempty"` // -1 (negative) to 1 (positive)
+	RaisedInContext string  `json:"raised_in_context,omitempty"`
+
+	BlockingAttachment *int `json:"blocking_attachment,omitempty"` // Index in AttachmentRefs of the blocking attachment the item is to share
 }
 
 // TopicDiscussion represents a discussion topic identified in the meeting
 type TopicDiscussion struct {
-	Topic        string   `json:"topic"`
-	StartTime    string   `json:"start_time"` // Changed to string to handle "HH:MM" format
-	Duration     float64  `json:"duration_minutes"`
-	Summary      string   `json:"summary"`
-	Participants []string `json:"participants"`
+	Topic        string            `json:"topic"`
+	StartTime    string            `json:"start_time"` // Changed to string to handle "HH:MM" format
+	Duration     float64           `json:"duration_minutes"`
+	Summary      string            `json:"summary"`
+	Participants []string          `json:"participants"`
+	SubTopics    []TopicDiscussion `json:"sub_topics,omitempty"` // Sub-discussions, at most maxTopicDepth levels deep
+}
+
+// maxTopicDepth is the number of topic levels kept, counting top-level topics as the first
+const maxTopicDepth = 2
+
+// FlattenTopics returns the topics and their sub-topics as a depth-first list, without nested sub-topics
+func FlattenTopics(topics []TopicDiscussion) []TopicDiscussion {
+	var flat []TopicDiscussion
+	for _, topic := range topics {
+		subTopics := topic.SubTopics
+		topic.SubTopics = nil
+		flat = append(flat, topic)
+		flat = append(flat, FlattenTopics(subTopics)...)
+	}
+	return flat
+}
+
+// copyTopics deep-copies topics, truncating sub-topics beyond the given depth
+func copyTopics(topics []TopicDiscussion, depth int) []TopicDiscussion {
+	if topics == nil {
+		return nil
+	}
+
+	copied := make([]TopicDiscussion, len(topics))
+	for i, topic := range topics {
+		copied[i] = topic
+		if topic.Participants != nil {
+			copied[i].Participants = append([]string{}, topic.Participants...)
+		}
+		if depth > 1 {
+			copied[i].SubTopics = copyTopics(topic.SubTopics, depth-1)
+		} else {
+			copied[i].SubTopics = nil
+		}
+	}
+	return copied
 }
 
 // AnalystAgent handles meeting analysis and maintains comprehensive meeting notes
@@ -82,12 +338,154 @@ type AnalystAgent struct {
 	lastAnalysis            time.Time
 	analysisMutex           sync.Mutex
 	currentAnalysisSnapshot []TranscriptEntry // Snapshot used during analysis to ensure consistency
+	dlq                     *DeadLetterQueue  // Failed steps are queued here for retry
+	speechDetector          *SpeechActivityDetector
+	languageDetector        *LanguageDetector
+	analysisInProgress      atomic.Bool
+	analysisStepLabel       atomic.Value // Name of the step currently running, for live stats
+	mailer                  mailer.Mailer
+	marketData              marketdata.MarketDataProvider
+	window                  TranscriptWindow         // Transcript window used by the running analysis
+	finalized               bool                     // Set once the meeting has been finalized (guarded by dataMutex)
+	appendsSinceRetention   int                      // Transcript appends since the retention policy was last applied
+	pendingOverflow         []TranscriptEntry        // Pruned transcript entries not yet written to the overflow file (guarded by dataMutex)
+	overflowMutex           sync.Mutex               // Serializes overflow file writes so pruned entries stay in order
+	transcriptBus           *event.Bus               // Streams new transcript entries to live subscribers
+	feedbackEvents          []actionItemFeedback     // Action item feedback collected since the last prompt refinement
+	onConfigChanged         func(models.AgentConfig) // Called when the agent updates its own configuration
+	onFinalized             func(*AnalysisData)      // Called with the analysis once the meeting is finalized
+	pendingConfig           *models.AgentConfig      // Config update applied at the start of the next analysis run
+	capacity                *CapacityMonitor         // Predicts when analyses can no longer keep up with the meeting
+	calendar                calendar.EventCreator    // Drafts recommended follow-up meetings, nil when disabled
+	plugins                 []Plugin                 // Custom post-processing steps run after each analysis
+	pluginMutex             sync.RWMutex
+	promptTemplates         map[string]*promptFile // Prompt template files keyed by analysis type, read-only after construction
+	defaultPrompts          map[string]string      // Built-in prompts seen so far keyed by analysis type
+	promptMutex             sync.RWMutex
+	batchedResponses        map[string]string // Responses to batched prompts for the running analysis, keyed by prompt
+	batchMutex              sync.Mutex
+	normalizer              TranscriptNormalizer // Cleans up utterance text, nil to keep it as recognized (guarded by dataMutex)
+	backend                 storage.Backend      // Saves the analysis file, nil to write it directly
+	writer                  analysisWriter       // Saves analysis snapshots in order, outside dataMutex
+	abTests                 *ABTestRecorder      // Collects A/B test samples across agents, nil when not shared
+	abVariant               string               // Action item prompt variant used by the running analysis, "" outside an A/B test
+	lastZoomCaption         *zoomCaption         // Latest Zoom caption added, refined by later parts with the same ID (guarded by dataMutex)
+	zoomMutex               sync.Mutex           // Serializes Zoom captions so a refinement can't race the entry it refines
+	teamsTokens             TokenRefresher       // Replaces expired Teams access tokens, nil when Teams isn't configured
+	kafkaTLS                bool                 // Connect to Kafka brokers over TLS
+	defaultHourlyRate       float64              // Hourly rate assumed for participants without one when estimating the meeting's cost
+
+	knowledge knowledge.KnowledgeBase // Explains internal terms mentioned in the transcript, nil to leave them unexplained
+
+	speakerSummaryCache map[string]speakerSummaryCacheEntry // Per-speaker summaries keyed by lowercased name (guarded by speakerSummaryMutex)
+	speakerSummaryMutex sync.Mutex
+	recapCache          []recapCacheEntry // Most recently generated recaps, newest last (guarded by recapMutex)
+	recapMutex          sync.Mutex
+
+	archives ArchiveStore // Uploads the analysis when the meeting is finalized, nil when archiving is unavailable
+
+	trigger *AdaptiveTrigger // Tracks the utterance rate to pace analyses when the adaptive trigger is enabled (guarded by dataMutex)
+
+	systemPromptPrefix string // Operator instructions placed before every prompt, from SYSTEM_PROMPT_PREFIX
+
+	productFeedbackWebhook    string // URL extracted product feedback is posted to, "" when not exported
+	lastProductFeedbackExport []byte // Payload of the last successful post (guarded by productFeedbackMutex)
+	productFeedbackMutex      sync.Mutex
+	salesObjectionWebhook     string // URL each new sales objection is posted to, "" when not posted
+	crmWebhook                string // URL the key contacts are posted to when the meeting is finalized, "" when not posted
+
+	keywordAlerts     keywordAlertState // Compiled alert patterns and when each last fired (guarded by keywordAlertMutex)
+	keywordAlertMutex sync.Mutex
+
+	webhookQueue *WebhookRetryQueue // Retries failed webhook deliveries across restarts, nil to drop them
+
+	externalNLP ExternalNLPProvider // Produces the core analysis results instead of the LLM, nil to use the LLM
+
+	rejectedTerms map[string]bool // Lowercased new term candidates the LLM didn't define, so they aren't sent again (guarded by analysisMutex)
+
+	windowResults map[string]*AnalysisData // Results of complete transcript windows keyed by windowKey, reset when the config changes (guarded by analysisMutex)
+
+	quality *QualityScorer // Rejects low-quality LLM responses before they are stored
+
+	skipSteps map[string]bool // Steps whose replayed results are kept because their prompt is unchanged (replay mode)
+
+	costEstimator  *llm.PromptCostEstimator // Projects each analysis's LLM cost against the daily budget, nil to skip estimates
+	hardBudgetStop bool                     // Analyses that would exceed the daily budget are not run
+
+	windowAgent bool // Analyzes one window of a windowed analysis, leaving steps over the merged results to the parent
+
+	speechBaselines map[string]*speechBaseline // Each speaker's usual speech that anomalies are judged against (guarded by dataMutex)
+
+	stepProviders map[string]llm.LLMProvider // LLM providers of the core steps configured with their own, keyed by step
+
+	hrNotificationEmail string // Address high-severity ethics flags are emailed to, "" to only alert Discord
+
+	roiReport bool // Report the meeting's return on the time invested when it is finalized
+}
+
+// TranscriptWindow controls how much of the transcript each analysis step sees
+type TranscriptWindow int
+
+const (
+	// WindowRecent limits each analysis step to its own number of recent entries
+	WindowRecent TranscriptWindow = iota
+	// WindowAll gives every analysis step the complete transcript
+	WindowAll
+)
+
+// ErrAlreadyFinalized is returned when finalizing an analysis that has already been finalized
+var ErrAlreadyFinalized = errors.New("analysis already finalized")
+
+// AnalysisDataDir is the directory analysis files are saved in
+const AnalysisDataDir = "data/analysis"
+
+// saveTimeout bounds saving the analysis, including publishing it when a storage backend does
+const saveTimeout = 10 * time.Second
+
+// DefaultStepTimeout is the timeout for analysis steps without one configured
+const DefaultStepTimeout = 30 * time.Second
+
+// ErrStepTimeout is returned when an analysis step exceeds its timeout
+type ErrStepTimeout struct {
+	StepName string
+	Duration time.Duration
+}
+
+func (e *ErrStepTimeout) Error() string {
+	return fmt.Sprintf("analysis step %s timed out after %s", e.StepName, e.Duration)
+}
+
+// Unwrap allows errors.Is(err, context.DeadlineExceeded)
+func (e *ErrStepTimeout) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// LiveStats holds real-time metrics for a meeting while analysis is ongoing
+type LiveStats struct {
+	TranscriptEntryCount   int      `json:"transcript_entry_count"`
+	WordCount              int      `json:"word_count"`
+	ActiveParticipants     []string `json:"active_participants"`
+	LastSpeaker            string   `json:"last_speaker"`
+	MinutesSinceLastUpdate float64  `json:"minutes_since_last_update"`
+	AnalysisInProgress     bool     `json:"analysis_in_progress"`
+	CurrentStep            string   `json:"current_step"`
+	TokensUsedToday        int64    `json:"tokens_used_today"`
+}
+
+// activeParticipantWindow is how recently a participant must have spoken to count as active
+const activeParticipantWindow = 5 * time.Minute
+
+// analysisStep is a single named stage of an analysis run
+type analysisStep struct {
+	name        string
+	description string
+	run         func(ctx context.Context) error
 }
 
 // NewAnalystAgent creates a new analyst agent
 func NewAnalystAgent(agentID string, config models.AgentConfig, llmClient *JoinlyClient) *AnalystAgent {
 	// Create data directory if it doesn't exist
-	dataDir := "data/analysis"
+	dataDir := AnalysisDataDir
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		logrus.Errorf("Failed to create analysis data directory: %v", err)
 	}
@@ -102,24 +500,22 @@ func NewAnalystAgent(agentID string, config models.AgentConfig, llmClient *Joinl
 		llmProvider = nil
 	}
 
-	analyst := &AnalystAgent{
-		agentID:     agentID,
-		config:      config,
-		filePath:    filePath,
-		llmClient:   llmClient,
-		llmProvider: llmProvider,
-		data: &AnalysisData{
-			MeetingID:    agentID,
-			MeetingURL:   config.MeetingURL,
-			StartTime:    time.Now(),
-			LastUpdated:  time.Now(),
-			Transcript:   []TranscriptEntry{},
-			KeyPoints:    []string{},
-			ActionItems:  []ActionItem{},
-			Topics:       []TopicDiscussion{},
-			Participants: []string{},
-		},
-	}
+	analyst := newAnalystAgent(agentID, config, llmProvider, newStepProviders(agentID, config.StepProviders), &AnalysisData{
+		SchemaVersion: migration.CurrentSchemaVersion,
+		MeetingID:     agentID,
+		TenantID:      config.TenantID,
+		MeetingURL:    config.MeetingURL,
+		StartTime:     time.Now(),
+		LastUpdated:   time.Now(),
+		Transcript:    []TranscriptEntry{},
+		KeyPoints:     []string{},
+		ActionItems:   []ActionItem{},
+		Topics:        []TopicDiscussion{},
+		Participants:  []string{},
+	})
+	analyst.filePath = filePath
+	analyst.llmClient = llmClient
+	analyst.loadPromptTemplateDir()
 
 	// Load existing analysis if file exists
 	if err := analyst.loadAnalysis(); err != nil {
@@ -129,36 +525,91 @@ func NewAnalystAgent(agentID string, config models.AgentConfig, llmClient *Joinl
 	return analyst
 }
 
+// newAnalystAgent creates an analyst of data with the state every analyst needs, whether it follows a
+// meeting, replays a saved one or analyzes a merged or windowed transcript. Callers set where it saves
+// and its prompts.
+func newAnalystAgent(agentID string, config models.AgentConfig, llmProvider llm.LLMProvider, stepProviders map[string]llm.LLMProvider, data *AnalysisData) *AnalystAgent {
+	analyst := &AnalystAgent{
+		agentID:           agentID,
+		config:            config,
+		data:              data,
+		llmProvider:       llmProvider,
+		stepProviders:     stepProviders,
+		speechDetector:    NewSpeechActivityDetector(),
+		languageDetector:  NewLanguageDetector(),
+		transcriptBus:     event.NewBus(maxTranscriptStreams, transcriptStreamBuffer),
+		capacity:          NewCapacityMonitor(agentID),
+		trigger:           NewAdaptiveTrigger(agentID),
+		quality:           NewQualityScorer(),
+		normalizer:        newTranscriptNormalizer(config.Normalizer),
+		defaultHourlyRate: DefaultHourlyRateUSD,
+	}
+	analyst.setAuditContext("")
+
+	if config.EnableMarketDataEnrichment {
+		analyst.SetMarketDataProvider(marketdata.NewCachedProvider(marketdata.NewYahooFinanceProvider(), marketQuoteTTL))
+	}
+	return analyst
+}
+
+// newDerivedAgent creates an analyst of data derived from this agent's transcript, sharing its LLM
+// providers, prompts, glossary, market data, system prompt prefix, hourly rate and speech filter
+func (a *AnalystAgent) newDerivedAgent(agentID string, config models.AgentConfig, data *AnalysisData) *AnalystAgent {
+	derived := newAnalystAgent(agentID, config, a.llmProvider, a.stepProviders, data)
+	derived.promptTemplates = a.promptTemplates
+	derived.marketData = a.marketData
+	derived.defaultHourlyRate = a.defaultHourlyRate
+	derived.SetKnowledgeBase(a.knowledge)
+	derived.SetSystemPromptPrefix(a.systemPromptPrefix)
+
+	a.dataMutex.RLock()
+	derived.speechDetector = a.speechDetector
+	a.dataMutex.RUnlock()
+	return derived
+}
+
 // ProcessUtterance processes a new utterance and updates the analysis
 func (a *AnalystAgent) ProcessUtterance(segments []map[string]interface{}) {
 	if len(segments) == 0 {
 		return
 	}
 
+	// Entries pruned by the retention policy are written to the overflow file once dataMutex is released
+	defer a.flushOverflow()
+
 	a.dataMutex.Lock()
 	defer a.dataMutex.Unlock()
 
-	// Extract transcript text and speaker
-	var fullText strings.Builder
+	// Skip silence, background noise and low-confidence segments
+	if !a.speechDetector.IsValidUtterance(segments) {
+		a.data.NoisySegmentsDropped++
+		return
+	}
+
+	// Extract transcript text and speaker, keeping only the text of segments with speech so placeholders
+	// and low-confidence segments don't reach the transcript alongside valid ones
 	speaker := "Participant"
 	timestamp := time.Now()
 
-	for i, segment := range segments {
+	for _, segment := range segments {
 		if speakerVal, ok := segment["speaker"].(string); ok && speakerVal != "" {
 			speaker = speakerVal
 		}
-		if text, ok := segment["text"].(string); ok && text != "" {
-			if i > 0 {
-				fullText.WriteString(" ")
-			}
-			fullText.WriteString(text)
-		}
 		if ts, ok := segment["timestamp"].(float64); ok {
 			timestamp = time.Unix(int64(ts), 0)
 		}
 	}
 
-	transcriptText := fullText.String()
+	var texts []string
+	for _, segment := range a.speechDetector.SpeechSegments(segments) {
+		text, _ := segment["text"].(string)
+		texts = append(texts, strings.TrimSpace(text))
+	}
+
+	transcriptText := strings.Join(texts, " ")
+	if a.normalizer != nil {
+		transcriptText = a.normalizer.Normalize(transcriptText)
+	}
 	if transcriptText == "" {
 		return
 	}
@@ -171,23 +622,57 @@ func (a *AnalystAgent) ProcessUtterance(segments []map[string]interface{}) {
 		IsAgent:   false,
 	}
 	a.data.Transcript = append(a.data.Transcript, entry)
+	a.capacity.RecordUtterance(time.Now())
+	a.trigger.RecordUtterance(time.Now())
+	a.checkKeywordAlerts(entry)
+	a.recordLinkMentions(entry)
+
+	// Periodically move old entries out of memory
+	a.appendsSinceRetention++
+	if a.appendsSinceRetention >= retentionCheckInterval {
+		a.appendsSinceRetention = 0
+		a.applyRetentionPolicy()
+	}
+
+	if a.finalized {
+		logrus.Warnf("Agent %s: received utterance after analysis was finalized; recording without re-analysis", a.agentID)
+	}
 
 	// Update participants list
 	a.updateParticipants(speaker)
+	a.updateSpeakerClassification()
 
 	// Update metadata
 	a.data.LastUpdated = time.Now()
 	a.data.WordCount += len(strings.Fields(transcriptText))
 	a.data.DurationMinutes = time.Since(a.data.StartTime).Minutes()
 
-	// Save updated analysis
-	if err := a.saveAnalysis(); err != nil {
-		logrus.Errorf("Failed to save analysis for agent %s: %v", a.agentID, err)
-	}
+	a.transcriptBus.Publish(TranscriptUpdate{Entry: entry, WordCount: a.data.WordCount})
+
+	// Track participants speaking over each other
+	a.detectCrosstalk()
+	a.updateCrosstalkRate()
+
+	a.updateEngagement()
+	a.updateInteractionPatterns()
+	a.detectSpeechPatternAnomalies()
+	a.updateQuickStats()
+
+	// Save updated analysis in the background, so storage doesn't hold up the lock
+	a.queueSave()
 
-	// Trigger analysis update if enough time has passed (every 5 minutes or significant new content)
-	if time.Since(a.lastAnalysis) > 5*time.Minute || len(a.data.Transcript)%20 == 0 {
-		go a.updateAnalysis()
+	// Trigger analysis update if enough time has passed (every 5 minutes or significant new content,
+	// adjusted to the utterance rate with the adaptive trigger)
+	if a.finalized {
+		return
+	}
+	interval, utterances := defaultAnalysisInterval, defaultAnalysisUtterances
+	if a.config.AdaptiveTrigger {
+		interval, utterances = a.trigger.Thresholds(a.config)
+	}
+	if time.Since(a.lastAnalysis) > interval || len(a.data.Transcript)%utterances == 0 {
+		a.capacity.AnalysisQueued(a.config.CapacityWarnThreshold)
+		go a.runQueuedAnalysis()
 	}
 }
 
@@ -201,11 +686,173 @@ func (a *AnalystAgent) updateParticipants(speaker string) {
 	a.data.Participants = append(a.data.Participants, speaker)
 }
 
-// updateAnalysis performs comprehensive analysis using LLM
-func (a *AnalystAgent) updateAnalysis() {
+// Finalize runs one last analysis over the complete transcript when the meeting ends, then emails
+// the digest to the configured recipients. Later utterances are still recorded but not analyzed.
+func (a *AnalystAgent) Finalize(ctx context.Context) (*AnalysisData, error) {
+	a.dataMutex.Lock()
+	if a.finalized {
+		a.dataMutex.Unlock()
+		return nil, ErrAlreadyFinalized
+	}
+	a.finalized = true
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Finalizing analysis for agent %s", a.agentID)
+
+	// Failed steps are queued for retry, so the digest still goes out with whatever was produced
+	if err := a.runAnalysis(WindowAll); err != nil {
+		logrus.Warnf("Final analysis for agent %s was incomplete: %v", a.agentID, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if a.config.EnableFollowUpSuggestion {
+		followUpCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("follow_up"))
+		if err := a.suggestFollowUp(followUpCtx); err != nil {
+			logrus.Errorf("Failed to suggest follow-up meeting for agent %s: %v", a.agentID, err)
+		}
+		cancel()
+	}
+
+	if a.config.EnableFollowUpEmailDraft {
+		emailCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("email_draft"))
+		if err := a.draftFollowUpEmail(emailCtx); err != nil {
+			logrus.Errorf("Failed to draft follow-up email for agent %s: %v", a.agentID, err)
+		}
+		cancel()
+	}
+
+	if a.config.EnableEmotionalArc {
+		arcCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("emotional_arc"))
+		if err := a.generateEmotionalArc(arcCtx); err != nil {
+			logrus.Errorf("Failed to write emotional arc narrative for agent %s: %v", a.agentID, err)
+		}
+		cancel()
+	}
+
+	if a.config.EnableKeyContacts {
+		contactsCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("key_contacts"))
+		if err := a.extractKeyContacts(contactsCtx); err != nil {
+			logrus.Errorf("Failed to extract key contacts for agent %s: %v", a.agentID, err)
+		} else if err := a.exportKeyContacts(); err != nil {
+			logrus.Errorf("Failed to export key contacts for agent %s: %v", a.agentID, err)
+		}
+		cancel()
+	}
+
+	classifyCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("meeting_type"))
+	a.classifyMeetingType(classifyCtx)
+	cancel()
+
+	a.updateMeetingScore()
+	a.updateCostEstimate()
+	if a.roiReport {
+		roiCtx, cancel := context.WithTimeout(ctx, a.stepTimeout("roi_report"))
+		if err := a.updateROIReport(roiCtx); err != nil {
+			logrus.Errorf("Failed to compare meeting with async alternatives for agent %s: %v", a.agentID, err)
+		}
+		cancel()
+	}
+	if a.config.EnableIcebreakerDetection {
+		a.updateIcebreaker()
+	}
+	a.updateHealthIndicators()
+	a.updateInsightsCard()
+	if err := a.updateSimilarMeetings(); err != nil {
+		logrus.Errorf("Failed to find similar meetings for agent %s: %v", a.agentID, err)
+	}
+	if err := a.updateMeetingRecords(); err != nil {
+		logrus.Errorf("Failed to update meeting records for agent %s: %v", a.agentID, err)
+	}
+	if err := a.saveAnalysis(); err != nil {
+		logrus.Errorf("Failed to save meeting score and cost for agent %s: %v", a.agentID, err)
+	}
+
+	if err := a.sendDigest(); err != nil {
+		logrus.Errorf("Failed to send meeting digest for agent %s: %v", a.agentID, err)
+	}
+
+	if policy := a.config.Archival; policy != nil && policy.ArchiveOnFinalize {
+		if err := a.archiveAnalysis(ctx, a.GetAnalysis()); err != nil {
+			logrus.Errorf("Failed to archive analysis for agent %s: %v", a.agentID, err)
+		}
+	}
+
+	data := a.GetAnalysis()
+	fields := logrus.Fields{
+		"agent_id":                a.agentID,
+		"meeting_id":              data.MeetingID,
+		"meeting_type":            data.MeetingType,
+		"meeting_type_confidence": fmt.Sprintf("%.2f", data.MeetingTypeConfidence),
+	}
+	if data.ExecutiveSummary != "" {
+		fields["summary"] = data.ExecutiveSummary
+	}
+	if data.TweetSummary != "" {
+		fields[config.DiscordContentField] = data.TweetSummary
+	}
+	if data.ROIReport != nil {
+		fields["roi_classification"] = data.ROIReport.ROIClassification
+		fields["cost_per_outcome_usd"] = fmt.Sprintf("%.2f", data.ROIReport.CostPerOutcomeUSD)
+	}
+	if len(data.ObjectiveCompletionStatus) > 0 {
+		achieved := 0
+		for _, status := range data.ObjectiveCompletionStatus {
+			if status.Achieved {
+				achieved++
+			}
+		}
+		fields["objectives_achieved"] = fmt.Sprintf("%d/%d (%.0f%%)", achieved, len(data.ObjectiveCompletionStatus), data.ObjectivesAchievementRate*100)
+	}
+	logrus.WithFields(fields).Infof("✅ Finalized analysis for agent %s", a.agentID)
+
+	if a.onFinalized != nil {
+		a.onFinalized(data)
+	}
+
+	return data, nil
+}
+
+// RegisterShutdown finalizes the analysis when the process shuts down
+func (a *AnalystAgent) RegisterShutdown(sm *shutdown.ShutdownManager) {
+	sm.Register(a.agentID, func(ctx context.Context) error {
+		if _, err := a.Finalize(ctx); err != nil && !errors.Is(err, ErrAlreadyFinalized) {
+			return err
+		}
+		return nil
+	})
+}
+
+// IsFinalized reports whether the analysis has been finalized
+func (a *AnalystAgent) IsFinalized() bool {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+	return a.finalized
+}
+
+// updateAnalysis performs comprehensive analysis using LLM, returning an error if any step failed
+func (a *AnalystAgent) updateAnalysis() error {
+	return a.runAnalysis(WindowRecent)
+}
+
+// runAnalysis runs every analysis step using the given transcript window
+func (a *AnalystAgent) runAnalysis(window TranscriptWindow) error {
 	a.analysisMutex.Lock()
 	defer a.analysisMutex.Unlock()
 
+	a.applyPendingConfig()
+
+	a.analysisInProgress.Store(true)
+	defer a.finishAnalysis()
+
+	a.window = window
+	defer func() { a.window = WindowRecent }()
+
+	a.selectABTestVariant()
+	defer func() { a.abVariant = "" }()
+
 	a.lastAnalysis = time.Now()
 
 	// Take a snapshot of the transcript with proper locking to ensure consistency
@@ -215,46 +862,42 @@ func (a *AnalystAgent) updateAnalysis() {
 	a.dataMutex.RUnlock()
 
 	if len(transcriptSnapshot) == 0 {
-		return
+		return nil
 	}
+	transcriptStats := ComputeTranscriptStatistics(transcriptSnapshot)
 
-	logrus.Infof("Updating analysis for agent %s with %d total transcript entries", a.agentID, len(transcriptSnapshot))
-
-	// Store the snapshot temporarily for use by analysis functions
-	// We'll modify the analysis functions to use this snapshot instead of calling getRecentTranscript
-	a.currentAnalysisSnapshot = transcriptSnapshot
-
-	// Generate summary
-	if err := a.generateSummary(); err != nil {
-		logrus.Errorf("Failed to generate summary for agent %s: %v", a.agentID, err)
-	}
+	started := time.Now()
+	defer func() { a.capacity.RecordAnalysisDuration(time.Since(started)) }()
 
-	// Extract key points
-	if err := a.extractKeyPoints(); err != nil {
-		logrus.Errorf("Failed to extract key points for agent %s: %v", a.agentID, err)
-	}
+	logrus.Infof("Updating analysis for agent %s with %d total transcript entries", a.agentID, len(transcriptSnapshot))
 
-	// Identify action items
-	if err := a.identifyActionItems(); err != nil {
-		logrus.Errorf("Failed to identify action items for agent %s: %v", a.agentID, err)
-	}
+	a.updateLanguage(transcriptSnapshot)
 
-	// Extract topics
-	if err := a.extractTopics(); err != nil {
-		logrus.Errorf("Failed to extract topics for agent %s: %v", a.agentID, err)
+	if err := a.checkAnalysisBudget(transcriptSnapshot); err != nil {
+		logrus.Warnf("Agent %s: Skipping analysis: %v", a.agentID, err)
+		return err
 	}
 
-	// Analyze sentiment and extract keywords
-	if err := a.analyzeSentimentAndKeywords(); err != nil {
-		logrus.Errorf("Failed to analyze sentiment for agent %s: %v", a.agentID, err)
+	var failedSteps []string
+	if windows := a.analysisWindows(transcriptSnapshot); len(windows) > 1 {
+		failedSteps = a.runWindowedSteps(windows)
+	} else {
+		failedSteps = a.runSteps(transcriptSnapshot)
 	}
 
-	// Clear the snapshot
-	a.currentAnalysisSnapshot = nil
+	a.runPlugins()
 
 	// Save the updated analysis
 	a.dataMutex.Lock()
 	a.data.LastUpdated = time.Now()
+	a.data.ChapterMarkers = deriveChapterMarkers(a.data.Topics, a.data.StartTime, a.data.DurationMinutes)
+	a.data.LanguageMetrics = computeLanguageMetrics(transcriptSnapshot)
+	a.data.EngagementHeatmap = computeEngagementHeatmap(transcriptSnapshot)
+	a.data.ReadabilityScores = computeReadabilityScores(a.data.Summary, a.data.KeyPoints, transcriptSnapshot)
+	a.data.TranscriptStatistics = transcriptStats
+	a.recordPromptVersion()
+	a.recordSnapshot()
+	a.updateQuickStats()
 	a.dataMutex.Unlock()
 
 	if err := a.saveAnalysis(); err != nil {
@@ -262,117 +905,417 @@ func (a *AnalystAgent) updateAnalysis() {
 	}
 
 	logrus.Infof("Analysis updated for agent %s", a.agentID)
-}
 
-// generateSummary creates a comprehensive meeting summary
-func (a *AnalystAgent) generateSummary() error {
-	// Get recent transcript (last 50 entries or all if less)
-	transcript := a.getRecentTranscript(50)
-	if len(transcript) == 0 {
-		return nil
+	if len(failedSteps) > 0 {
+		return fmt.Errorf("analysis steps failed: %s", strings.Join(failedSteps, ", "))
 	}
+	return nil
+}
 
-	logrus.Infof("Agent %s: Generating summary with %d transcript entries", a.agentID, len(transcript))
+// runSteps runs every analysis step against the transcript snapshot, queuing failures for retry, and
+// returns the names of the steps that failed
+func (a *AnalystAgent) runSteps(transcriptSnapshot []TranscriptEntry) []string {
+	// Store the snapshot temporarily for use by analysis functions
+	// We'll modify the analysis functions to use this snapshot instead of calling getRecentTranscript
+	a.currentAnalysisSnapshot = transcriptSnapshot
 
-	// Use custom prompt if provided, otherwise use default
-	prompt := a.buildAnalysisPrompt("summary",
-		`Analyze this meeting transcript and provide a comprehensive summary. You MUST use the google_search tool to validate and verify any factual claims, statistics, figures, technical details, company information, or specific statements that can be fact-checked.
+	a.prefetchBatchResponses()
+	stepsStarted := time.Now()
 
-		Focus on:
-		- Main topics discussed
-		- Key decisions made  
-		- Important information shared
-		- Overall meeting progress and outcomes
-		- Validation of any claims, facts, or figures mentioned
+	// Run each analysis step, queuing failures for retry
+	var failedSteps []string
+	for _, step := range a.analysisSteps() {
+		if a.skipSteps[step.name] {
+			logrus.Infof("Agent %s: Skipping %s, its prompt is unchanged since the replayed analysis", a.agentID, step.name)
+			continue
+		}
+		a.analysisStepLabel.Store(step.name)
+		a.setAuditContext(step.name)
+		if err := a.runStep(step); err != nil {
+			logrus.Errorf("Failed to %s for agent %s: %v", step.description, a.agentID, err)
+			a.deadLetter(step.name, transcriptSnapshot, err)
+			failedSteps = append(failedSteps, step.name)
+		}
+	}
 
-		IMPORTANT: For any factual statements, statistics, company data, technical specifications, or verifiable claims mentioned in the meeting:
-		1. Use google_search to verify the accuracy
-		2. Cross-reference multiple sources when possible
-		3. Note if information cannot be verified or appears outdated
-		4. Include relevant context from your search results
+	// Clear the snapshot
+	a.currentAnalysisSnapshot = nil
 
-		Transcript:
-		%s
+	unused := a.clearBatchedResponses()
+	logrus.Infof("Agent %s: Analysis steps took %dms", a.agentID, time.Since(stepsStarted).Milliseconds())
+	if unused > 0 {
+		logrus.Warnf("Agent %s: %d batched responses were not used by their analysis steps", a.agentID, unused)
+	}
+	return failedSteps
+}
 
-		Provide your response in the following JSON format within a code block:
-		`+"`"+`json
-		{
-			"summary": "Your comprehensive summary here with validated facts and verified information",
-			"key_themes": ["theme1", "theme2", "theme3"]
+// RetryStep re-runs a single analysis step against the transcript snapshot it originally failed on
+func (a *AnalystAgent) RetryStep(stepName string, transcript []TranscriptEntry) error {
+	var retry *analysisStep
+	for _, step := range a.analysisSteps() {
+		if step.name == stepName {
+			retry = &step
+			break
 		}
-		`+"`"+``,
-		a.formatTranscriptForLLM(transcript))
+	}
+	if retry == nil {
+		return fmt.Errorf("unknown analysis step: %s", stepName)
+	}
 
-	// Try grounded call first if provider supports it
-	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
-		logrus.Infof("Agent %s: Using grounded call for summary generation", a.agentID)
+	a.analysisMutex.Lock()
+	defer a.analysisMutex.Unlock()
 
-		groundedResponse, err := groundingProvider.CallWithGrounding(prompt)
-		if err != nil {
-			logrus.Warnf("Grounded call failed for summary, falling back to regular call: %v", err)
-			return err
-		}
+	a.applyPendingConfig()
 
-		return a.processSummaryWithGrounding(groundedResponse)
-	}
+	a.analysisInProgress.Store(true)
+	defer a.finishAnalysis()
 
-	// Fallback to regular LLM call
-	response, err := a.callLLM(prompt)
+	a.analysisStepLabel.Store(stepName)
+	a.currentAnalysisSnapshot = transcript
+	a.setAuditContext(stepName)
+	err := a.runStep(*retry)
+	a.currentAnalysisSnapshot = nil
 	if err != nil {
-		logrus.Warnf("Failed to generate summary: %v", err)
 		return err
 	}
 
-	if response != "" {
-		// Try to parse JSON from response
-		if jsonData := a.extractJSONFromResponse(response); jsonData != "" {
-			var result struct {
-				Summary   string   `json:"summary"`
-				KeyThemes []string `json:"key_themes"`
-			}
-			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
-				logrus.Warnf("Failed to parse summary JSON: %v", err)
-				return err
-			}
-			a.data.Summary = result.Summary
-			logrus.Infof("Agent %s: Successfully generated summary (%d characters)",
-				a.agentID, len(result.Summary))
-		}
-	}
+	a.dataMutex.Lock()
+	a.data.LastUpdated = time.Now()
+	a.updateQuickStats()
+	a.dataMutex.Unlock()
 
+	if err := a.saveAnalysis(); err != nil {
+		logrus.Errorf("Failed to save analysis after retry for agent %s: %v", a.agentID, err)
+	}
 	return nil
 }
 
-// extractKeyPoints identifies the most important points from the transcript
-func (a *AnalystAgent) extractKeyPoints() error {
-	transcript := a.getRecentTranscript(30)
-	if len(transcript) == 0 {
-		return nil
+// updateLanguage detects the meeting language and picks the language analysis responses are written in
+func (a *AnalystAgent) updateLanguage(transcript []TranscriptEntry) {
+	detected := a.languageDetector.Detect(transcript)
+
+	response := detected
+	if a.config.ForceResponseLanguage != "" {
+		response = a.config.ForceResponseLanguage
 	}
 
-	logrus.Infof("Agent %s: Extracting key points with %d transcript entries", a.agentID, len(transcript))
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
 
-	// Use custom prompt if provided, otherwise use default
-	prompt := a.buildAnalysisPrompt("key_points",
-		`Extract the key points from this meeting transcript. Focus on:
-- Important decisions or agreements
-- Critical information shared
-- Action-oriented statements
-- Questions that need answers
-- Commitments made
+	if detected != "" && detected != a.data.DetectedLanguage {
+		logrus.Infof("Agent %s: detected meeting language %s", a.agentID, detected)
+	}
+	if detected != "" {
+		a.data.DetectedLanguage = detected
+	}
+	if response != "" {
+		a.data.ResponseLanguage = response
+	}
+}
 
-Provide the most important takeaways from the discussion.
+// finishAnalysis clears the in-progress state reported by GetLiveStats
+func (a *AnalystAgent) finishAnalysis() {
+	a.analysisStepLabel.Store("")
+	a.analysisInProgress.Store(false)
+}
 
-Transcript:
-%s
+// analysisSteps returns the analysis stages in the order they run
+func (a *AnalystAgent) analysisSteps() []analysisStep {
+	steps := []analysisStep{
+		{name: "summary", description: "generate summary", run: a.generateSummary},
+		{name: "key_points", description: "extract key points", run: a.extractKeyPoints},
+		{name: "action_items", description: "identify action items", run: a.identifyActionItems},
+		{name: "topics", description: "extract topics", run: a.extractTopics},
+		{name: "sentiment_keywords", description: "analyze sentiment", run: a.analyzeSentimentAndKeywords},
+	}
+	if a.externalNLP != nil {
+		steps = []analysisStep{{name: "external_nlp", description: "analyze with the external NLP service", run: a.analyzeWithExternalNLP}}
+	}
+	if !a.windowAgent {
+		steps = append(steps, a.executiveSummaryStep(), a.terseSummariesStep())
+	}
+	if len(a.config.Objectives) > 0 {
+		steps = append(steps, a.objectivesStep())
+	}
+	if a.config.EnableKeyQuotes {
+		steps = append(steps, analysisStep{name: "key_quotes", description: "extract key quotes", run: a.extractKeyQuotes})
+	}
+	if a.config.EnableConflictDetection {
+		steps = append(steps, analysisStep{name: "conflicts", description: "detect conflicting statements", run: a.detectConflicts})
+	}
+	if a.config.EnableCompetitiveIntel {
+		steps = append(steps, analysisStep{name: "competitive_intel", description: "extract competitive intelligence", run: a.extractCompetitiveIntel})
+	}
+	if a.config.EnableRequirementExtraction {
+		steps = append(steps, analysisStep{name: "requirements", description: "extract requirements", run: a.extractRequirements})
+	}
+	if a.config.EnableMetricExtraction {
+		steps = append(steps, analysisStep{name: "metrics", description: "extract metrics", run: a.extractMetrics})
+	}
+	if a.config.EnableLearningResources {
+		steps = append(steps, analysisStep{name: "learning_resources", description: "identify learning resources", run: a.identifyLearningResources})
+	}
+	if a.config.EnableComplianceDetection {
+		steps = append(steps, analysisStep{name: "compliance", description: "detect compliance issues", run: a.detectComplianceIssues})
+	}
+	if a.config.EnableEthicsDetection && !a.windowAgent {
+		steps = append(steps, a.ethicsStep())
+	}
+	if a.config.EnableNPSProxy {
+		steps = append(steps, analysisStep{name: "nps_proxy", description: "estimate NPS proxy", run: a.computeNPSProxy})
+	}
+	if a.config.EnableProductFeedback {
+		steps = append(steps, analysisStep{name: "product_feedback", description: "extract product feedback", run: a.extractProductFeedback})
+	}
+	if a.config.EnablePersonaInference {
+		steps = append(steps, analysisStep{name: "speaker_personas", description: "infer speaker personas", run: a.inferSpeakerPersonas})
+	}
+	if a.config.EnableAcronymGlossary {
+		steps = append(steps, analysisStep{name: "acronym_glossary", description: "expand acronyms", run: a.buildAcronymGlossary})
+	}
+	if a.config.EnableNewTermsGlossary {
+		steps = append(steps, analysisStep{name: "new_terms", description: "define new terms", run: a.buildGlossary})
+	}
+	if a.config.EnableTechDebtExtraction {
+		steps = append(steps, analysisStep{name: "tech_debt", description: "extract technical debt", run: a.extractTechnicalDebt})
+	}
+	if a.config.EnableMetricsTracking {
+		steps = append(steps, analysisStep{name: "success_metrics", description: "track success metrics", run: a.extractSuccessMetrics})
+	}
+	if a.config.EnableSpeakerMoodTimeline {
+		steps = append(steps, analysisStep{name: "speaker_mood", description: "judge speaker moods", run: a.updateSpeakerMoodTimeline})
+	}
+	if a.config.EnableBudgetExtraction {
+		steps = append(steps, analysisStep{name: "budget", description: "extract budget discussions", run: a.extractBudgetDiscussions})
+	}
+	if a.config.EnableStakeholderMap {
+		steps = append(steps, analysisStep{name: "stakeholders", description: "map external stakeholders", run: a.extractStakeholders})
+	}
+	if a.config.EnableQAExtraction {
+		steps = append(steps, analysisStep{name: "qa_pairs", description: "extract questions and answers", run: a.extractQAPairs})
+	}
+	if a.config.MeetingType == salesCallMeetingType {
+		steps = append(steps, analysisStep{name: "sales_objections", description: "extract sales objections", run: a.extractSalesObjections})
+	}
+	if a.config.EnableAttachmentTracking && !a.windowAgent {
+		steps = append(steps, a.attachmentsStep())
+	}
+	if a.config.EnableConversationFlow {
+		steps = append(steps, a.conversationFlowStep())
+	}
+	return steps
+}
 
-Provide your response in the following JSON format within a code block:
-`+"`"+`json
-{
-  "key_points": ["point1", "point2", "point3"]
+// UpdateConfig replaces the agent's configuration from the next analysis run without waiting for a running one
+func (a *AnalystAgent) UpdateConfig(config models.AgentConfig) {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	a.pendingConfig = &config
+}
+
+// applyPendingConfig switches to the configuration set by UpdateConfig, if any (caller must hold analysisMutex)
+func (a *AnalystAgent) applyPendingConfig() {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	if a.pendingConfig == nil {
+		return
+	}
+	a.config = *a.pendingConfig
+	a.pendingConfig = nil
+	a.normalizer = newTranscriptNormalizer(a.config.Normalizer)
+	a.windowResults = nil
+	a.updateSpeakerClassification()
+
+	if a.config.EnableMarketDataEnrichment && a.marketData == nil {
+		a.marketData = marketdata.NewCachedProvider(marketdata.NewYahooFinanceProvider(), marketQuoteTTL)
+	}
+}
+
+// runStep runs an analysis step with its configured timeout, converting a timeout into ErrStepTimeout
+func (a *AnalystAgent) runStep(step analysisStep) error {
+	timeout := a.stepTimeout(step.name)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := step.run(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		a.dataMutex.Lock()
+		a.data.TimeoutCount++
+		a.dataMutex.Unlock()
+		return &ErrStepTimeout{StepName: step.name, Duration: timeout}
+	}
+	return err
+}
+
+// stepTimeout returns the configured timeout for an analysis step, or DefaultStepTimeout
+func (a *AnalystAgent) stepTimeout(stepName string) time.Duration {
+	if timeout, ok := a.config.StepTimeouts[stepName]; ok && timeout > 0 {
+		return timeout
+	}
+	return DefaultStepTimeout
+}
+
+// SetDeadLetterQueue sets the queue that failed analysis steps are pushed to for later retry
+func (a *AnalystAgent) SetDeadLetterQueue(dlq *DeadLetterQueue) {
+	a.dlq = dlq
+}
+
+// deadLetter pushes a failed step to the dead letter queue, if one is configured
+func (a *AnalystAgent) deadLetter(stepName string, transcript []TranscriptEntry, err error) {
+	if a.dlq == nil {
+		return
+	}
+
+	item := a.dlq.Push(FailedAnalysisStep{
+		AgentID:            a.agentID,
+		StepName:           stepName,
+		TranscriptSnapshot: transcript,
+		Error:              err.Error(),
+	})
+	logrus.Warnf("Queued %s step for agent %s for retry (%s)", stepName, a.agentID, item.ID)
+}
+
+// setAuditContext labels subsequent LLM calls of the agent's provider, and of the step's own provider if
+// it has one, with the current analysis step for audit logging
+func (a *AnalystAgent) setAuditContext(analysisType string) {
+	for _, provider := range []llm.LLMProvider{a.llmProvider, a.stepProviders[analysisType]} {
+		if auditable, ok := provider.(llm.AuditableProvider); ok {
+			auditable.SetAuditContext(a.agentID, analysisType)
+		}
+	}
+}
+
+// recordSnapshot appends the current analysis results to the snapshot history (caller must hold dataMutex)
+func (a *AnalystAgent) recordSnapshot() {
+	snapshot := AnalysisSnapshot{
+		TakenAt:     a.data.LastUpdated,
+		Summary:     a.data.Summary,
+		KeyPoints:   append([]string(nil), a.data.KeyPoints...),
+		ActionItems: append([]ActionItem(nil), a.data.ActionItems...),
+		Topics:      copyTopics(a.data.Topics, maxTopicDepth),
+		Sentiment:   a.data.Sentiment,
+		Keywords:    append([]string(nil), a.data.Keywords...),
+	}
+
+	a.data.Snapshots = append(a.data.Snapshots, snapshot)
+
+	// Keep only the most recent snapshots to bound the analysis file size
+	if len(a.data.Snapshots) > maxAnalysisSnapshots {
+		a.data.Snapshots = a.data.Snapshots[len(a.data.Snapshots)-maxAnalysisSnapshots:]
+	}
+}
+
+// generateSummary creates a comprehensive meeting summary
+func (a *AnalystAgent) generateSummary(ctx context.Context) error {
+	prompt, transcript := a.summaryPrompt()
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Generating summary with %d transcript entries", a.agentID, len(transcript))
+
+	// Try grounded call first if provider supports it
+	if groundingProvider, ok := a.stepProvider("summary").(llm.GroundingCapableProvider); ok {
+		logrus.Infof("Agent %s: Using grounded call for summary generation", a.agentID)
+
+		groundedPrompt := a.withGroundingQueries(ctx, "summary", transcript, prompt)
+		groundedResponse, err := a.callLLMWithGrounding(ctx, groundingProvider, groundedPrompt)
+		if err != nil {
+			logrus.Warnf("Grounded call failed for summary, falling back to regular call: %v", err)
+			return err
+		}
+
+		if err := a.processSummaryWithGrounding(ctx, groundedResponse); err != nil {
+			return err
+		}
+
+		a.recordConfidence("summary", len(transcript), true, false)
+		a.enrichSummaryWithMarketData(transcript)
+		return nil
+	}
+
+	// Fallback to regular LLM call
+	response, err := a.callStepLLM(ctx, "summary", prompt)
+	if err != nil {
+		logrus.Warnf("Failed to generate summary: %v", err)
+		return err
+	}
+	if err := a.checkResponseQuality("summary", response); err != nil {
+		return err
+	}
+
+	if response != "" {
+		// Try to parse JSON from response
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
+			var result struct {
+				Summary   string   `json:"summary"`
+				KeyThemes []string `json:"key_themes"`
+			}
+			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+				logrus.Warnf("Failed to parse summary JSON: %v", err)
+				return err
+			}
+			a.data.Summary = result.Summary
+			a.recordConfidence("summary", len(transcript), false, false)
+			logrus.Infof("Agent %s: Successfully generated summary (%d characters)",
+				a.agentID, len(result.Summary))
+		}
+	}
+
+	return nil
 }
-`+"`"+``,
+
+// summaryPromptTemplate is the built-in summary prompt
+const summaryPromptTemplate = `Analyze this meeting transcript and provide a comprehensive summary. You MUST use the google_search tool to validate and verify any factual claims, statistics, figures, technical details, company information, or specific statements that can be fact-checked.
+
+		Focus on:
+		- Main topics discussed
+		- Key decisions made  
+		- Important information shared
+		- Overall meeting progress and outcomes
+		- Validation of any claims, facts, or figures mentioned
+
+		IMPORTANT: For any factual statements, statistics, company data, technical specifications, or verifiable claims mentioned in the meeting:
+		1. Use google_search to verify the accuracy
+		2. Cross-reference multiple sources when possible
+		3. Note if information cannot be verified or appears outdated
+		4. Include relevant context from your search results
+
+		Transcript:
+		%s
+
+		Provide your response in the following JSON format within a code block:
+		` + "`" + `json
+		{
+			"summary": "Your comprehensive summary here with validated facts and verified information",
+			"key_themes": ["theme1", "theme2", "theme3"]
+		}
+		` + "`" + ``
+
+// summaryPrompt builds the summary prompt from the last 50 transcript entries
+func (a *AnalystAgent) summaryPrompt() (string, []TranscriptEntry) {
+	transcript := a.getRecentTranscript(50)
+	if len(transcript) == 0 {
+		return "", nil
+	}
+
+	// Use custom prompt if provided, otherwise use default
+	prompt := a.buildAnalysisPrompt("summary",
+		summaryPromptTemplate,
 		a.formatTranscriptForLLM(transcript))
+	return prompt, transcript
+}
+
+// extractKeyPoints identifies the most important points from the transcript
+func (a *AnalystAgent) extractKeyPoints(ctx context.Context) error {
+	prompt, transcript := a.keyPointsPrompt()
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting key points with %d transcript entries", a.agentID, len(transcript))
 
 	// Log the transcript being sent
 	formattedTranscript := a.formatTranscriptForLLM(transcript)
@@ -380,26 +1323,36 @@ Provide your response in the following JSON format within a code block:
 		a.agentID, len(formattedTranscript))
 
 	// Try grounded call first if provider supports it
-	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+	fellBack := false
+	if groundingProvider, ok := a.stepProvider("key_points").(llm.GroundingCapableProvider); ok {
 		logrus.Infof("Agent %s: Using grounded call for key points extraction", a.agentID)
-		groundedResponse, err := groundingProvider.CallWithGrounding(prompt)
+		groundedPrompt := a.withGroundingQueries(ctx, "key_points", transcript, prompt)
+		groundedResponse, err := a.callLLMWithGrounding(ctx, groundingProvider, groundedPrompt)
 		if err != nil {
 			logrus.Warnf("Grounded call failed for key points, falling back to regular call: %v", err)
+			fellBack = true
 		} else {
-			return a.processKeyPointsWithGrounding(groundedResponse)
+			if err := a.processKeyPointsWithGrounding(ctx, groundedResponse); err != nil {
+				return err
+			}
+			a.recordConfidence("key_points", len(transcript), true, false)
+			return nil
 		}
 	}
 
 	// Fallback to regular LLM call
-	response, err := a.callLLM(prompt)
+	response, err := a.callStepLLM(ctx, "key_points", prompt)
 	if err != nil {
 		logrus.Warnf("Failed to extract key points: %v", err)
 		return err
 	}
+	if err := a.checkResponseQuality("key_points", response); err != nil {
+		return err
+	}
 
 	if response != "" {
 		// Try to parse JSON from response
-		if jsonData := a.extractJSONFromResponse(response); jsonData != "" {
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
 			var result struct {
 				KeyPoints []string `json:"key_points"`
 			}
@@ -409,6 +1362,7 @@ Provide your response in the following JSON format within a code block:
 			}
 
 			a.data.KeyPoints = result.KeyPoints
+			a.recordConfidence("key_points", len(transcript), false, fellBack)
 			logrus.Infof("Agent %s: Successfully extracted %d key points",
 				a.agentID, len(result.KeyPoints))
 		}
@@ -416,18 +1370,100 @@ Provide your response in the following JSON format within a code block:
 	return nil
 }
 
+// keyPointsPromptTemplate is the built-in key points prompt
+const keyPointsPromptTemplate = `Extract the key points from this meeting transcript. Focus on:
+- Important decisions or agreements
+- Critical information shared
+- Action-oriented statements
+- Questions that need answers
+- Commitments made
+
+Provide the most important takeaways from the discussion.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+` + "`" + `json
+{
+  "key_points": ["point1", "point2", "point3"]
+}
+` + "`" + ``
+
+// keyPointsPrompt builds the key points prompt from the last 30 transcript entries
+func (a *AnalystAgent) keyPointsPrompt() (string, []TranscriptEntry) {
+	transcript := a.getRecentTranscript(30)
+	if len(transcript) == 0 {
+		return "", nil
+	}
+
+	// Use custom prompt if provided, otherwise use default
+	prompt := a.buildAnalysisPrompt("key_points",
+		keyPointsPromptTemplate,
+		a.formatTranscriptForLLM(transcript))
+	return prompt, transcript
+}
+
 // identifyActionItems finds actionable items in the transcript
-func (a *AnalystAgent) identifyActionItems() error {
-	transcript := a.getRecentTranscript(40)
+func (a *AnalystAgent) identifyActionItems(ctx context.Context) error {
+	prompt, transcript := a.actionItemsPrompt()
 	if len(transcript) == 0 {
 		return nil
 	}
 
 	logrus.Infof("Agent %s: Identifying action items with %d transcript entries", a.agentID, len(transcript))
 
-	// Use custom prompt if provided, otherwise use default
-	prompt := a.buildAnalysisPrompt("action_items",
-		`Identify action items from this meeting transcript. Be VERY AGGRESSIVE in finding actionables - look beyond explicit tasks to identify research opportunities, follow-ups, and valuable investigations.
+	// Log the transcript being sent
+	formattedTranscript := a.formatTranscriptForLLM(transcript)
+	logrus.Debugf("Agent %s: Sending %d characters of transcript to LLM for action items",
+		a.agentID, len(formattedTranscript))
+
+	called := time.Now()
+	response, err := a.callStepLLM(ctx, "action_items", prompt)
+	if err != nil {
+		logrus.Warnf("Failed to identify action items: %v", err)
+		return err
+	}
+	latency := time.Since(called)
+	if err := a.checkResponseQuality("action_items", response); err != nil {
+		return err
+	}
+
+	// Debug: log the response for analysis
+	previewLen := 200
+	if len(response) < previewLen {
+		previewLen = len(response)
+	}
+	logrus.Debugf("Action items LLM response length: %d, preview: %s", len(response), response[:previewLen])
+
+	if response != "" {
+		// Try to parse JSON from response
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
+			var result struct {
+				ActionItems []ActionItem `json:"action_items"`
+			}
+			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+				logrus.Warnf("Failed to parse action items JSON: %v", err)
+				return err
+			}
+
+			a.dataMutex.Lock()
+			a.data.ActionItems = deduplicateActionItems(a.data.ActionItems, mergeActionItems(a.data.ActionItems, result.ActionItems))
+			a.dataMutex.Unlock()
+			if err := a.classifyActionItemSentiment(ctx, transcript); err != nil {
+				logrus.Warnf("Agent %s: Action item sentiment falls back to the heuristic: %v", a.agentID, err)
+			}
+			a.recordConfidence("action_items", len(transcript), false, false)
+			a.recordABTestSample(len(result.ActionItems), len(prompt), latency)
+			logrus.Infof("Agent %s: Successfully identified %d action items",
+				a.agentID, len(result.ActionItems))
+		}
+	}
+	return nil
+}
+
+// actionItemsPromptTemplate is the built-in action items prompt, with {meeting_date} replaced by the meeting date
+const actionItemsPromptTemplate = `Identify action items from this meeting transcript. Be VERY AGGRESSIVE in finding actionables - look beyond explicit tasks to identify research opportunities, follow-ups, and valuable investigations.
 
 Look for:
 - Explicit tasks that need to be completed
@@ -465,77 +1501,89 @@ Transcript:
 %s
 
 Provide your response in the following JSON format within a code block:
-`+"`"+`json
+` + "`" + `json
 {
   "action_items": [
     {
       "description": "Task description - be specific about what needs to be researched/done/investigated",
       "assignee": "Person name (optional) or use 'Reviewer'",
       "priority": "high (try to not use high until very necessary) / medium / low",
-      "type": "task/research/investigation/follow-up/decision"
+      "type": "task/research/investigation/follow-up/decision",
+      "due_date": "YYYY-MM-DD, resolving relative dates against the meeting date {meeting_date}"
     }
   ]
 }
-`+"`"+``,
+` + "`" + ``
+
+// actionItemsPrompt builds the action items prompt from the last 40 transcript entries
+func (a *AnalystAgent) actionItemsPrompt() (string, []TranscriptEntry) {
+	transcript := a.getRecentTranscript(40)
+	if len(transcript) == 0 {
+		return "", nil
+	}
+
+	a.dataMutex.RLock()
+	meetingDate := a.data.StartTime.Format("2006-01-02")
+	a.dataMutex.RUnlock()
+
+	// Use custom prompt if provided, otherwise use default
+	prompt := a.buildAnalysisPrompt("action_items",
+		strings.ReplaceAll(actionItemsPromptTemplate, "{meeting_date}", meetingDate),
 		a.formatTranscriptForLLM(transcript))
 
-	// Log the transcript being sent
-	formattedTranscript := a.formatTranscriptForLLM(transcript)
-	logrus.Debugf("Agent %s: Sending %d characters of transcript to LLM for action items",
-		a.agentID, len(formattedTranscript))
+	if variantPrompt, ok := a.abTestPrompt(a.formatTranscriptForLLM(transcript)); ok {
+		prompt = variantPrompt
+	}
+	return prompt, transcript
+}
+
+// extractTopics identifies main discussion topics
+func (a *AnalystAgent) extractTopics(ctx context.Context) error {
+	prompt, transcript := a.topicsPrompt()
+	if len(transcript) == 0 {
+		return nil
+	}
 
-	response, err := a.callLLM(prompt)
+	response, err := a.callStepLLM(ctx, "topics", prompt)
 	if err != nil {
-		logrus.Warnf("Failed to identify action items: %v", err)
+		logrus.Warnf("Failed to extract topics: %v", err)
 		return err
 	}
-
-	// Debug: log the response for analysis
-	previewLen := 200
-	if len(response) < previewLen {
-		previewLen = len(response)
+	if err := a.checkResponseQuality("topics", response); err != nil {
+		return err
 	}
-	logrus.Debugf("Action items LLM response length: %d, preview: %s", len(response), response[:previewLen])
 
 	if response != "" {
 		// Try to parse JSON from response
-		if jsonData := a.extractJSONFromResponse(response); jsonData != "" {
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
 			var result struct {
-				ActionItems []ActionItem `json:"action_items"`
+				Topics []TopicDiscussion `json:"topics"`
 			}
 			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
-				logrus.Warnf("Failed to parse action items JSON: %v", err)
-				return err
+				logrus.Warnf("Failed to parse topics JSON: %v", err)
+				// Don't return error, just log and continue
+				return nil
 			}
-
-			a.data.ActionItems = result.ActionItems
-			logrus.Infof("Agent %s: Successfully identified %d action items",
-				a.agentID, len(result.ActionItems))
+			a.data.Topics = copyTopics(result.Topics, maxTopicDepth)
+			a.recordConfidence("topics", len(transcript), false, false)
 		}
 	}
 	return nil
 }
 
-// extractTopics identifies main discussion topics
-func (a *AnalystAgent) extractTopics() error {
-	transcript := a.getRecentTranscript(50)
-	if len(transcript) == 0 {
-		return nil
-	}
-
-	// Use custom prompt if provided, otherwise use default
-	prompt := a.buildAnalysisPrompt("topics",
-		`Analyze this meeting transcript and identify the main discussion topics. For each topic, provide:
+// topicsPromptTemplate is the built-in topics prompt
+const topicsPromptTemplate = `Analyze this meeting transcript and identify the main discussion topics. For each topic, provide:
 - Topic name/title
 - Brief summary of what was discussed
 - Key participants involved
 - Approximate start time and duration
+- Any distinct sub-discussions within the topic
 
 Transcript:
 %s
 
 Provide your response in the following JSON format within a code block:
-`+"`"+`json
+` + "`" + `json
 {
   "topics": [
     {
@@ -543,71 +1591,53 @@ Provide your response in the following JSON format within a code block:
       "summary": "Brief summary of discussion",
       "participants": ["Speaker1", "Speaker2"],
       "start_time": "HH:MM",
-      "duration_minutes": 15
+      "duration_minutes": 15,
+      "sub_topics": [
+        {
+          "topic": "Sub-topic name",
+          "summary": "Brief summary of the sub-discussion",
+          "participants": ["Speaker1"],
+          "start_time": "HH:MM",
+          "duration_minutes": 5
+        }
+      ]
     }
   ]
 }
-`+"`"+``,
-		a.formatTranscriptForLLM(transcript))
+` + "`" + ``
 
-	response, err := a.callLLM(prompt)
-	if err != nil {
-		logrus.Warnf("Failed to extract topics: %v", err)
-		return err
+// topicsPrompt builds the topics prompt from the last 50 transcript entries
+func (a *AnalystAgent) topicsPrompt() (string, []TranscriptEntry) {
+	transcript := a.getRecentTranscript(50)
+	if len(transcript) == 0 {
+		return "", nil
 	}
 
-	if response != "" {
-		// Try to parse JSON from response
-		if jsonData := a.extractJSONFromResponse(response); jsonData != "" {
-			var result struct {
-				Topics []TopicDiscussion `json:"topics"`
-			}
-			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
-				logrus.Warnf("Failed to parse topics JSON: %v", err)
-				// Don't return error, just log and continue
-				return nil
-			}
-			a.data.Topics = result.Topics
-		}
-	}
-	return nil
+	// Use custom prompt if provided, otherwise use default
+	prompt := a.buildAnalysisPrompt("topics",
+		topicsPromptTemplate,
+		a.formatTranscriptForLLM(transcript))
+	return prompt, transcript
 }
 
 // analyzeSentimentAndKeywords performs sentiment analysis and keyword extraction
-func (a *AnalystAgent) analyzeSentimentAndKeywords() error {
-	transcript := a.getRecentTranscript(20)
+func (a *AnalystAgent) analyzeSentimentAndKeywords(ctx context.Context) error {
+	prompt, transcript := a.sentimentKeywordsPrompt()
 	if len(transcript) == 0 {
 		return nil
 	}
 
-	// Use custom prompt if provided, otherwise use default
-	prompt := a.buildAnalysisPrompt("sentiment_keywords",
-		`Analyze the sentiment and extract keywords from this meeting transcript.
-
-Determine the overall sentiment of the discussion and identify the most important keywords and phrases.
-
-Transcript:
-%s
-
-Provide your response in the following JSON format within a code block:
-`+"`"+`json
-{
-  "sentiment": "positive/negative/neutral/mixed",
-  "keywords": ["keyword1", "keyword2", "keyword3"],
-  "confidence": 0.85
-}
-`+"`"+``,
-		a.formatTranscriptForLLM(transcript))
-
-	response, err := a.callLLM(prompt)
+	response, err := a.callStepLLM(ctx, "sentiment_keywords", prompt)
 	if err != nil {
 		logrus.Warnf("Failed to perform sentiment analysis: %v", err)
 		return err
 	}
 
-	if response != "" {
+	// The timeline and word cloud don't depend on the response, so they are updated even when it is rejected
+	qualityErr := a.checkResponseQuality("sentiment_keywords", response)
+	if response != "" && qualityErr == nil {
 		// Try to parse JSON from response
-		if jsonData := a.extractJSONFromResponse(response); jsonData != "" {
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
 			var analysis struct {
 				Sentiment  string   `json:"sentiment"`
 				Keywords   []string `json:"keywords"`
@@ -619,52 +1649,113 @@ Provide your response in the following JSON format within a code block:
 			}
 			a.data.Sentiment = analysis.Sentiment
 			a.data.Keywords = analysis.Keywords
+			a.recordConfidence("sentiment_keywords", len(transcript), false, false)
 		}
 	}
-	return nil
+
+	// Score the sentiment of each window across the whole meeting
+	fullTranscript := a.getFullTranscript()
+	a.updateSentimentTimeline(ctx, fullTranscript)
+
+	a.data.WordCloudData = computeWordFrequencies(fullTranscript, a.stopwords())
+	return qualityErr
+}
+
+// sentimentKeywordsPromptTemplate is the built-in sentiment and keywords prompt
+const sentimentKeywordsPromptTemplate = `Analyze the sentiment and extract keywords from this meeting transcript.
+
+Determine the overall sentiment of the discussion and identify the most important keywords and phrases.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+` + "`" + `json
+{
+  "sentiment": "positive/negative/neutral/mixed",
+  "keywords": ["keyword1", "keyword2", "keyword3"],
+  "confidence": 0.85
+}
+` + "`" + ``
+
+// sentimentKeywordsPrompt builds the sentiment and keywords prompt from the last 20 transcript entries
+func (a *AnalystAgent) sentimentKeywordsPrompt() (string, []TranscriptEntry) {
+	transcript := a.getRecentTranscript(20)
+	if len(transcript) == 0 {
+		return "", nil
+	}
+
+	// Use custom prompt if provided, otherwise use default
+	prompt := a.buildAnalysisPrompt("sentiment_keywords",
+		sentimentKeywordsPromptTemplate,
+		a.formatTranscriptForLLM(transcript))
+	return prompt, transcript
 }
 
 // Helper methods
 
-// callLLM calls the LLM with a simple prompt
-func (a *AnalystAgent) callLLM(prompt string) (string, error) {
-	if a.llmProvider == nil || !a.llmProvider.IsAvailable() {
-		return "", fmt.Errorf("LLM provider not available")
-	}
-
-	return a.llmProvider.Call(prompt)
+// callLLM calls the agent's LLM with a simple prompt, giving up when ctx is done
+func (a *AnalystAgent) callLLM(ctx context.Context, prompt string) (string, error) {
+	return a.callStepLLM(ctx, "", prompt)
 }
 
-// extractJSONFromResponse extracts JSON content from ```json blocks
-func (a *AnalystAgent) extractJSONFromResponse(response string) string {
-	// Look for ```json ... ``` blocks
-	startMarker := "```json"
-	endMarker := "```"
+// callLLMWithGrounding makes a grounded LLM call, giving up when ctx is done, and filters the cited
+// sources by the configured domain lists
+func (a *AnalystAgent) callLLMWithGrounding(ctx context.Context, provider llm.GroundingCapableProvider, prompt string) (*llm.GroundedResponse, error) {
+	prompt = a.withSystemPrefix(prompt)
+	response, err := callWithContext(ctx, func() (*llm.GroundedResponse, error) {
+		return provider.CallWithGrounding(prompt)
+	})
+	if err != nil || response == nil {
+		return response, err
+	}
 
-	startIdx := strings.Index(response, startMarker)
-	if startIdx == -1 {
-		return ""
+	// Agent blacklists add to the default one, while an agent whitelist replaces the default
+	blacklist, whitelist := llm.DefaultGroundingDomains()
+	blacklist = append(append([]string{}, blacklist...), a.config.GroundingSourceBlacklist...)
+	if len(a.config.GroundingSourceWhitelist) > 0 {
+		whitelist = a.config.GroundingSourceWhitelist
 	}
 
-	// Move past the start marker
-	startIdx += len(startMarker)
+	filtered := *response
+	filtered.GroundingMetadata = llm.FilterGroundingMetadata(response.GroundingMetadata, blacklist, whitelist)
+	return &filtered, nil
+}
 
-	// Find the end marker
-	endIdx := strings.Index(response[startIdx:], endMarker)
-	if endIdx == -1 {
-		return ""
+// callWithContext runs call and returns its result, or ctx's error if ctx is done first. Providers don't
+// accept a context, so an abandoned call runs to completion in the background and its result is discarded.
+func callWithContext[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
 	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value, err}
+	}()
 
-	// Extract the JSON content
-	jsonContent := strings.TrimSpace(response[startIdx : startIdx+endIdx])
-	return jsonContent
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
 }
 
-// getRecentTranscript returns the last N transcript entries
+// getRecentTranscript returns the last N transcript entries, or all of them when using WindowAll
 func (a *AnalystAgent) getRecentTranscript(count int) []TranscriptEntry {
 	// If we're in the middle of analysis, use the snapshot to ensure consistency
 	if a.currentAnalysisSnapshot != nil {
 		total := len(a.currentAnalysisSnapshot)
+		if a.window == WindowAll {
+			count = total
+		}
 		if total == 0 {
 			logrus.Debugf("Agent %s: No transcript entries available in snapshot", a.agentID)
 			return []TranscriptEntry{}
@@ -710,6 +1801,20 @@ func (a *AnalystAgent) getRecentTranscript(count int) []TranscriptEntry {
 	return result
 }
 
+// getFullTranscript returns every transcript entry, using the analysis snapshot while an analysis is running
+func (a *AnalystAgent) getFullTranscript() []TranscriptEntry {
+	if a.currentAnalysisSnapshot != nil {
+		return a.currentAnalysisSnapshot
+	}
+
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	result := make([]TranscriptEntry, len(a.data.Transcript))
+	copy(result, a.data.Transcript)
+	return result
+}
+
 // formatTranscriptForLLM formats transcript entries for LLM consumption
 func (a *AnalystAgent) formatTranscriptForLLM(entries []TranscriptEntry) string {
 	var result strings.Builder
@@ -724,13 +1829,57 @@ func (a *AnalystAgent) formatTranscriptForLLM(entries []TranscriptEntry) string
 
 // buildAnalysisPrompt builds a secure prompt for analysis using custom instructions
 func (a *AnalystAgent) buildAnalysisPrompt(analysisType, defaultPrompt, transcript string) string {
-	// Check if custom prompt is set - if so, use custom prompt-driven prompts
-	if a.config.CustomPrompt != nil && *a.config.CustomPrompt != "" {
-		return a.buildSecurePromptFromInstructions(analysisType, *a.config.CustomPrompt, transcript)
+	var prompt string
+	a.rememberDefaultPrompt(analysisType, defaultPrompt)
+
+	// A template file takes precedence, receiving any custom prompt as CustomInstructions
+	if templated, ok := a.renderPromptTemplate(analysisType, transcript); ok {
+		prompt = templated
+	} else if a.config.CustomPrompt != nil && *a.config.CustomPrompt != "" {
+		prompt = a.buildSecurePromptFromInstructions(analysisType, *a.config.CustomPrompt, transcript)
+	} else {
+		// Use default prompt if no custom instructions
+		prompt = fmt.Sprintf(defaultPrompt, transcript)
+	}
+
+	// The system prefix comes first so custom prompts and templates can't override it
+	return a.systemPrefix() + a.buildAgendaContextPrompt(a.config.Agenda) + a.glossaryPrefix(transcript) +
+		a.correctionsPrefix(analysisType) + a.languagePrefix() + prompt
+}
+
+// buildAgendaContextPrompt returns a preamble describing the planned agenda so analysis focuses on
+// coverage and deviations, or "" when there is no agenda
+func (a *AnalystAgent) buildAgendaContextPrompt(agenda []models.AgendaItem) string {
+	if len(agenda) == 0 {
+		return ""
+	}
+
+	items := make([]string, len(agenda))
+	for i, item := range agenda {
+		items[i] = fmt.Sprintf("%d. %s", i+1, item.Title)
+		if item.DurationMinutes > 0 {
+			items[i] += fmt.Sprintf(" (%d min)", item.DurationMinutes)
+		}
+	}
+
+	return fmt.Sprintf("This meeting was scheduled to cover: %s. Pay special attention to whether each agenda item "+
+		"was covered and to any significant deviations.\n\n", strings.Join(items, ", "))
+}
+
+// languagePrefix returns the instruction to answer in the response language, or "" for English meetings
+func (a *AnalystAgent) languagePrefix() string {
+	a.dataMutex.RLock()
+	detected, response := a.data.DetectedLanguage, a.data.ResponseLanguage
+	a.dataMutex.RUnlock()
+
+	if detected == "" {
+		detected = response
+	}
+	if response == "" || (strings.EqualFold(response, "en") && strings.EqualFold(detected, "en")) {
+		return ""
 	}
 
-	// Use default prompt if no custom instructions
-	return fmt.Sprintf(defaultPrompt, transcript)
+	return fmt.Sprintf("Respond in %s. The following meeting transcript is in %s.\n\n", languageName(response), languageName(detected))
 }
 
 // buildSecurePromptFromInstructions creates task-specific prompts based on custom instructions
@@ -799,6 +1948,7 @@ For each topic, provide:
 - Brief summary of what was discussed
 - Key participants involved
 - Approximate start time and duration
+- Any distinct sub-discussions within the topic
 
 Transcript:
 %s`, taskPrompt, transcript)
@@ -821,7 +1971,8 @@ Transcript:
 	return basePrompt
 }
 
-// buildDirectPrompt creates a prompt by directly inserting client instructions (fallback)
+// buildDirectPrompt creates a prompt by directly inserting client instructions (fallback). The prompt is
+// built inside buildAnalysisPrompt, which puts the system prefix before it.
 func (a *AnalystAgent) buildDirectPrompt(analysisType, clientInstructions, transcript string) string {
 	// Basic validation for harmful content
 	if !a.isSafeInstruction(clientInstructions) {
@@ -873,6 +2024,7 @@ For each topic, provide:
 - Brief summary of what was discussed
 - Key participants involved
 - Approximate start time and duration
+- Any distinct sub-discussions within the topic
 
 Transcript:
 %s`, clientInstructions, transcript)
@@ -923,7 +2075,7 @@ Generate specific instructions for how this agent should approach %s in meetings
 Keep the response focused and professional, as these instructions will be used directly in LLM prompts.`, customInstructions, taskDescription)
 
 	// Use the same LLM provider as configured for the agent
-	response, err := a.llmProvider.Call(prompt)
+	response, err := a.llmProvider.Call(a.withSystemPrefix(prompt))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate task prompt: %w", err)
 	}
@@ -950,6 +2102,7 @@ func (a *AnalystAgent) isSafeInstruction(instructions string) bool {
 		"import ", "require(", "exec(", "system(",
 		"rm ", "del ", "format ", "drop table",
 		"alter table", "truncate table",
+		"ignore previous instructions", "ignore all previous", "disregard previous", "ignore the above",
 	}
 
 	instructionsLower := strings.ToLower(instructions)
@@ -1040,6 +2193,7 @@ Transcript:
 - Brief summary of what was discussed
 - Key participants involved
 - Approximate start time and duration
+- Any distinct sub-discussions within the topic
 
 Transcript:
 %s`, transcript)
@@ -1060,13 +2214,16 @@ Transcript:
 // File operations
 
 // processSummaryWithGrounding processes a grounded response for summary generation
-func (a *AnalystAgent) processSummaryWithGrounding(groundedResponse *llm.GroundedResponse) error {
+func (a *AnalystAgent) processSummaryWithGrounding(ctx context.Context, groundedResponse *llm.GroundedResponse) error {
 	if groundedResponse == nil {
 		return fmt.Errorf("grounded response is nil")
 	}
+	if err := a.checkResponseQuality("summary", groundedResponse.Text); err != nil {
+		return err
+	}
 
 	// Try to parse JSON from response
-	if jsonData := a.extractJSONFromResponse(groundedResponse.Text); jsonData != "" {
+	if jsonData := a.extractJSONFromResponse(ctx, groundedResponse.Text); jsonData != "" {
 		var result struct {
 			Summary   string   `json:"summary"`
 			KeyThemes []string `json:"key_themes"`
@@ -1092,184 +2249,596 @@ func (a *AnalystAgent) processSummaryWithGrounding(groundedResponse *llm.Grounde
 			groundedContent.TextWithCitations = result.Summary
 		}
 
-		a.data.GroundedSummary = groundedContent
+		a.data.GroundedSummary = groundedContent
+
+		logrus.Infof("Agent %s: Successfully generated grounded summary (%d characters, %d grounding chunks)",
+			a.agentID, len(result.Summary), len(groundedResponse.GroundingMetadata.GroundingChunks))
+	}
+	return nil
+}
+
+// processKeyPointsWithGrounding processes a grounded response for key points extraction
+func (a *AnalystAgent) processKeyPointsWithGrounding(ctx context.Context, groundedResponse *llm.GroundedResponse) error {
+	if groundedResponse == nil {
+		return fmt.Errorf("grounded response is nil")
+	}
+	if err := a.checkResponseQuality("key_points", groundedResponse.Text); err != nil {
+		return err
+	}
+
+	// Try to parse JSON from response
+	if jsonData := a.extractJSONFromResponse(ctx, groundedResponse.Text); jsonData != "" {
+		var result struct {
+			KeyPoints []string `json:"key_points"`
+		}
+		if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+			logrus.Warnf("Failed to parse grounded key points JSON: %v", err)
+			return err
+		}
+
+		// Store regular key points
+		a.data.KeyPoints = result.KeyPoints
+
+		// Create grounded content with citations
+		keyPointsText := strings.Join(result.KeyPoints, "\n• ")
+		if keyPointsText != "" {
+			keyPointsText = "• " + keyPointsText
+		}
+
+		groundedContent := &GroundedContent{
+			Text:              keyPointsText,
+			GroundingMetadata: groundedResponse.GroundingMetadata,
+		}
+
+		// Add citations to the text
+		if groundedResponse.GroundingMetadata != nil {
+			groundedContent.TextWithCitations = a.addCitations(keyPointsText, groundedResponse.GroundingMetadata)
+		} else {
+			groundedContent.TextWithCitations = keyPointsText
+		}
+
+		a.data.GroundedKeyPoints = groundedContent
+
+		logrus.Infof("Agent %s: Successfully generated grounded key points (%d points, %d grounding chunks)",
+			a.agentID, len(result.KeyPoints), len(groundedResponse.GroundingMetadata.GroundingChunks))
+	}
+	return nil
+}
+
+// addCitations adds citation links to text based on grounding metadata
+func (a *AnalystAgent) addCitations(text string, groundingMetadata *llm.GroundingMetadata) string {
+	if groundingMetadata == nil || len(groundingMetadata.GroundingSupports) == 0 {
+		return text
+	}
+
+	result := text
+
+	// Sort supports by end_index in descending order to avoid shifting issues when inserting
+	supports := make([]llm.GroundingSupport, len(groundingMetadata.GroundingSupports))
+	copy(supports, groundingMetadata.GroundingSupports)
+
+	// Simple bubble sort for descending order by EndIndex
+	for i := 0; i < len(supports); i++ {
+		for j := 0; j < len(supports)-1-i; j++ {
+			if supports[j].Segment.EndIndex < supports[j+1].Segment.EndIndex {
+				supports[j], supports[j+1] = supports[j+1], supports[j]
+			}
+		}
+	}
+
+	for _, support := range supports {
+		endIndex := support.Segment.EndIndex
+		if len(support.GroundingChunkIndices) > 0 && endIndex <= len(result) {
+			// Create citation string like [1](link1), [2](link2)
+			var citationLinks []string
+			for _, i := range support.GroundingChunkIndices {
+				if i < len(groundingMetadata.GroundingChunks) {
+					uri := groundingMetadata.GroundingChunks[i].Web.URI
+					title := groundingMetadata.GroundingChunks[i].Web.Title
+					citationLinks = append(citationLinks, fmt.Sprintf("[%d](%s)", i+1, uri))
+					logrus.Debugf("Agent %s: Adding citation [%d] %s -> %s", a.agentID, i+1, title, uri)
+				}
+			}
+
+			if len(citationLinks) > 0 {
+				citationString := " " + strings.Join(citationLinks, ", ")
+				result = result[:endIndex] + citationString + result[endIndex:]
+			}
+		}
+	}
+
+	return result
+}
+
+// File operations
+
+// saveAnalysis saves the analysis data to file. The data is written to a temporary file that is then
+// renamed over the analysis file, so an interrupted save never leaves a partially written file.
+// SetStorageBackend sets the backend analysis is saved with; the analysis file is written directly when unset
+func (a *AnalystAgent) SetStorageBackend(backend storage.Backend) {
+	a.backend = backend
+}
+
+// loadAnalysis loads analysis data from file
+func (a *AnalystAgent) loadAnalysis() error {
+	if _, err := os.Stat(a.filePath); os.IsNotExist(err) {
+		return nil // File doesn't exist, will create new
+	}
+
+	data, err := os.ReadFile(a.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read analysis file: %w", err)
+	}
+
+	migrated, err := migration.Migrate(data)
+	if err != nil {
+		return err
+	}
+	if err := ValidateAnalysisData(migrated); err != nil {
+		return err
+	}
+
+	var analysis AnalysisData
+	if err := json.Unmarshal(migrated, &analysis); err != nil {
+		return fmt.Errorf("failed to parse analysis data: %w", err)
+	}
+
+	a.data = &analysis
+	return nil
+}
+
+// GetAnalysis returns a copy of the current analysis data
+func (a *AnalystAgent) GetAnalysis() *AnalysisData {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	// Create a deep copy
+	dataCopy := *a.data
+	dataCopy.Transcript = make([]TranscriptEntry, len(a.data.Transcript))
+	copy(dataCopy.Transcript, a.data.Transcript)
+
+	dataCopy.KeyPoints = make([]string, len(a.data.KeyPoints))
+	copy(dataCopy.KeyPoints, a.data.KeyPoints)
+
+	dataCopy.ActionItems = make([]ActionItem, len(a.data.ActionItems))
+	copy(dataCopy.ActionItems, a.data.ActionItems)
+
+	dataCopy.Topics = copyTopics(a.data.Topics, maxTopicDepth)
+	if dataCopy.Topics == nil {
+		dataCopy.Topics = []TopicDiscussion{}
+	}
+
+	dataCopy.Participants = make([]string, len(a.data.Participants))
+	copy(dataCopy.Participants, a.data.Participants)
+
+	dataCopy.Keywords = make([]string, len(a.data.Keywords))
+	copy(dataCopy.Keywords, a.data.Keywords)
+
+	if a.data.CrosstalkEvents != nil {
+		dataCopy.CrosstalkEvents = make([]CrosstalkEvent, len(a.data.CrosstalkEvents))
+		for i, event := range a.data.CrosstalkEvents {
+			dataCopy.CrosstalkEvents[i] = event
+			dataCopy.CrosstalkEvents[i].Speakers = append([]string{}, event.Speakers...)
+		}
+	}
+
+	if a.data.SpeakerEngagementMap != nil {
+		dataCopy.SpeakerEngagementMap = make(map[string]EngagementStats, len(a.data.SpeakerEngagementMap))
+		for speaker, stats := range a.data.SpeakerEngagementMap {
+			stats.AttentionSamples = append([]float64(nil), stats.AttentionSamples...)
+			dataCopy.SpeakerEngagementMap[speaker] = stats
+		}
+	}
+
+	if a.data.AttentionScores != nil {
+		dataCopy.AttentionScores = make(map[string]float64, len(a.data.AttentionScores))
+		for speaker, score := range a.data.AttentionScores {
+			dataCopy.AttentionScores[speaker] = score
+		}
+	}
+
+	if a.data.GlossaryOfNewTerms != nil {
+		dataCopy.GlossaryOfNewTerms = make(map[string]GlossaryEntry, len(a.data.GlossaryOfNewTerms))
+		for term, entry := range a.data.GlossaryOfNewTerms {
+			dataCopy.GlossaryOfNewTerms[term] = entry
+		}
+	}
+
+	if a.data.ConversationFlow != nil {
+		dataCopy.ConversationFlow = make([]TopicTransition, len(a.data.ConversationFlow))
+		copy(dataCopy.ConversationFlow, a.data.ConversationFlow)
+	}
+
+	if a.data.TechnicalDebtMentions != nil {
+		dataCopy.TechnicalDebtMentions = make([]TechDebtItem, len(a.data.TechnicalDebtMentions))
+		copy(dataCopy.TechnicalDebtMentions, a.data.TechnicalDebtMentions)
+	}
+
+	if a.data.SuccessMetricsTracked != nil {
+		dataCopy.SuccessMetricsTracked = make([]SuccessMetric, len(a.data.SuccessMetricsTracked))
+		copy(dataCopy.SuccessMetricsTracked, a.data.SuccessMetricsTracked)
+	}
+
+	if a.data.BudgetDiscussions != nil {
+		dataCopy.BudgetDiscussions = make([]BudgetItem, len(a.data.BudgetDiscussions))
+		copy(dataCopy.BudgetDiscussions, a.data.BudgetDiscussions)
+	}
+
+	if a.data.SpeechPatternAnomalies != nil {
+		dataCopy.SpeechPatternAnomalies = make([]PatternAnomaly, len(a.data.SpeechPatternAnomalies))
+		copy(dataCopy.SpeechPatternAnomalies, a.data.SpeechPatternAnomalies)
+	}
+
+	if a.data.Objections != nil {
+		dataCopy.Objections = make([]SalesObjection, len(a.data.Objections))
+		copy(dataCopy.Objections, a.data.Objections)
+	}
+
+	if a.data.ImportantLinks != nil {
+		dataCopy.ImportantLinks = make([]LinkMention, len(a.data.ImportantLinks))
+		copy(dataCopy.ImportantLinks, a.data.ImportantLinks)
+	}
+
+	if a.data.AttachmentRefs != nil {
+		dataCopy.AttachmentRefs = make([]AttachmentReference, len(a.data.AttachmentRefs))
+		copy(dataCopy.AttachmentRefs, a.data.AttachmentRefs)
+	}
+
+	if a.data.RecordBreakers != nil {
+		dataCopy.RecordBreakers = make([]RecordEvent, len(a.data.RecordBreakers))
+		copy(dataCopy.RecordBreakers, a.data.RecordBreakers)
+	}
+
+	if a.data.ExecutiveSummaryBullets != nil {
+		dataCopy.ExecutiveSummaryBullets = make([]string, len(a.data.ExecutiveSummaryBullets))
+		copy(dataCopy.ExecutiveSummaryBullets, a.data.ExecutiveSummaryBullets)
+	}
+
+	if a.data.QuestionsAndAnswers != nil {
+		dataCopy.QuestionsAndAnswers = make([]QAPair, len(a.data.QuestionsAndAnswers))
+		copy(dataCopy.QuestionsAndAnswers, a.data.QuestionsAndAnswers)
+	}
+
+	if a.data.StakeholderMap != nil {
+		dataCopy.StakeholderMap = make([]Stakeholder, len(a.data.StakeholderMap))
+		for i, stakeholder := range a.data.StakeholderMap {
+			stakeholder.Context = append([]string(nil), stakeholder.Context...)
+			stakeholder.ActionItemsAssigned = append([]string(nil), stakeholder.ActionItemsAssigned...)
+			dataCopy.StakeholderMap[i] = stakeholder
+		}
+	}
+
+	if a.data.KeyContacts != nil {
+		dataCopy.KeyContacts = make([]ContactInfo, len(a.data.KeyContacts))
+		copy(dataCopy.KeyContacts, a.data.KeyContacts)
+	}
+
+	if a.data.EngagementHeatmap != nil {
+		dataCopy.EngagementHeatmap = make([]HeatmapRow, len(a.data.EngagementHeatmap))
+		for i, row := range a.data.EngagementHeatmap {
+			dataCopy.EngagementHeatmap[i] = HeatmapRow{Speaker: row.Speaker, Cells: append([]HeatmapCell(nil), row.Cells...)}
+		}
+	}
+
+	if a.data.SpeakerMoodTimeline != nil {
+		dataCopy.SpeakerMoodTimeline = make(map[string][]MoodPoint, len(a.data.SpeakerMoodTimeline))
+		for speaker, points := range a.data.SpeakerMoodTimeline {
+			dataCopy.SpeakerMoodTimeline[speaker] = append([]MoodPoint(nil), points...)
+		}
+	}
+
+	if a.data.SpeakerClassification != nil {
+		dataCopy.SpeakerClassification = make(map[string]string, len(a.data.SpeakerClassification))
+		for speaker, class := range a.data.SpeakerClassification {
+			dataCopy.SpeakerClassification[speaker] = class
+		}
+	}
+
+	if a.data.ObjectiveCompletionStatus != nil {
+		dataCopy.ObjectiveCompletionStatus = make(map[string]ObjectiveStatus, len(a.data.ObjectiveCompletionStatus))
+		for objective, status := range a.data.ObjectiveCompletionStatus {
+			dataCopy.ObjectiveCompletionStatus[objective] = status
+		}
+	}
+
+	if a.data.ReadabilityScores != nil {
+		dataCopy.ReadabilityScores = make(map[string]ReadabilityScore, len(a.data.ReadabilityScores))
+		for section, score := range a.data.ReadabilityScores {
+			dataCopy.ReadabilityScores[section] = score
+		}
+	}
+
+	if a.data.QuickStats != nil {
+		quickStats := *a.data.QuickStats
+		dataCopy.QuickStats = &quickStats
+	}
+
+	if a.data.TranscriptStatistics != nil {
+		transcriptStats := *a.data.TranscriptStatistics
+		dataCopy.TranscriptStatistics = &transcriptStats
+	}
+
+	if a.data.HealthIndicators != nil {
+		health := *a.data.HealthIndicators
+		dataCopy.HealthIndicators = &health
+	}
+
+	if a.data.ROIReport != nil {
+		roi := *a.data.ROIReport
+		dataCopy.ROIReport = &roi
+	}
+
+	dataCopy.InsightsCard = a.data.InsightsCard.clone()
+
+	if a.data.InteractionPatterns != nil {
+		dataCopy.InteractionPatterns = make([]InteractionEdge, len(a.data.InteractionPatterns))
+		copy(dataCopy.InteractionPatterns, a.data.InteractionPatterns)
+	}
+
+	if a.data.LanguageMetrics != nil {
+		dataCopy.LanguageMetrics = make(map[string]SpeakerLanguageMetrics, len(a.data.LanguageMetrics))
+		for speaker, metrics := range a.data.LanguageMetrics {
+			dataCopy.LanguageMetrics[speaker] = metrics
+		}
+	}
+
+	if a.data.SentimentTimeline != nil {
+		dataCopy.SentimentTimeline = make([]SentimentPoint, len(a.data.SentimentTimeline))
+		copy(dataCopy.SentimentTimeline, a.data.SentimentTimeline)
+	}
 
-		logrus.Infof("Agent %s: Successfully generated grounded summary (%d characters, %d grounding chunks)",
-			a.agentID, len(result.Summary), len(groundedResponse.GroundingMetadata.GroundingChunks))
+	if a.data.WordCloudData != nil {
+		dataCopy.WordCloudData = make([]WordFrequency, len(a.data.WordCloudData))
+		copy(dataCopy.WordCloudData, a.data.WordCloudData)
 	}
-	return nil
-}
 
-// processKeyPointsWithGrounding processes a grounded response for key points extraction
-func (a *AnalystAgent) processKeyPointsWithGrounding(groundedResponse *llm.GroundedResponse) error {
-	if groundedResponse == nil {
-		return fmt.Errorf("grounded response is nil")
+	if a.data.KeyQuotes != nil {
+		dataCopy.KeyQuotes = make([]Quote, len(a.data.KeyQuotes))
+		copy(dataCopy.KeyQuotes, a.data.KeyQuotes)
 	}
 
-	// Try to parse JSON from response
-	if jsonData := a.extractJSONFromResponse(groundedResponse.Text); jsonData != "" {
-		var result struct {
-			KeyPoints []string `json:"key_points"`
-		}
-		if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
-			logrus.Warnf("Failed to parse grounded key points JSON: %v", err)
-			return err
+	if a.data.Requirements != nil {
+		dataCopy.Requirements = make([]Requirement, len(a.data.Requirements))
+		for i, requirement := range a.data.Requirements {
+			dataCopy.Requirements[i] = requirement
+			dataCopy.Requirements[i].AcceptanceCriteria = append([]string(nil), requirement.AcceptanceCriteria...)
 		}
+	}
 
-		// Store regular key points
-		a.data.KeyPoints = result.KeyPoints
+	if a.data.ABTestMetrics != nil {
+		metrics := *a.data.ABTestMetrics
+		dataCopy.ABTestMetrics = &metrics
+	}
 
-		// Create grounded content with citations
-		keyPointsText := strings.Join(result.KeyPoints, "\n• ")
-		if keyPointsText != "" {
-			keyPointsText = "• " + keyPointsText
-		}
+	if a.data.BookRecommendations != nil {
+		dataCopy.BookRecommendations = make([]BookRecommendation, len(a.data.BookRecommendations))
+		copy(dataCopy.BookRecommendations, a.data.BookRecommendations)
+	}
 
-		groundedContent := &GroundedContent{
-			Text:              keyPointsText,
-			GroundingMetadata: groundedResponse.GroundingMetadata,
-		}
+	if a.data.KeyMetrics != nil {
+		dataCopy.KeyMetrics = make([]MetricMention, len(a.data.KeyMetrics))
+		copy(dataCopy.KeyMetrics, a.data.KeyMetrics)
+	}
 
-		// Add citations to the text
-		if groundedResponse.GroundingMetadata != nil {
-			groundedContent.TextWithCitations = a.addCitations(keyPointsText, groundedResponse.GroundingMetadata)
-		} else {
-			groundedContent.TextWithCitations = keyPointsText
-		}
+	dataCopy.CostEstimate = a.data.CostEstimate.clone()
 
-		a.data.GroundedKeyPoints = groundedContent
+	if a.data.ComplianceFlags != nil {
+		dataCopy.ComplianceFlags = make([]ComplianceFlag, len(a.data.ComplianceFlags))
+		copy(dataCopy.ComplianceFlags, a.data.ComplianceFlags)
+	}
 
-		logrus.Infof("Agent %s: Successfully generated grounded key points (%d points, %d grounding chunks)",
-			a.agentID, len(result.KeyPoints), len(groundedResponse.GroundingMetadata.GroundingChunks))
+	if a.data.EthicsFlags != nil {
+		dataCopy.EthicsFlags = make([]EthicsFlag, len(a.data.EthicsFlags))
+		copy(dataCopy.EthicsFlags, a.data.EthicsFlags)
 	}
-	return nil
-}
 
-// addCitations adds citation links to text based on grounding metadata
-func (a *AnalystAgent) addCitations(text string, groundingMetadata *llm.GroundingMetadata) string {
-	if groundingMetadata == nil || len(groundingMetadata.GroundingSupports) == 0 {
-		return text
+	dataCopy.NPSProxy = a.data.NPSProxy.clone()
+
+	if a.data.ProductFeedback != nil {
+		dataCopy.ProductFeedback = make([]FeedbackItem, len(a.data.ProductFeedback))
+		copy(dataCopy.ProductFeedback, a.data.ProductFeedback)
 	}
 
-	result := text
+	if a.data.ChapterMarkers != nil {
+		dataCopy.ChapterMarkers = make([]ChapterMarker, len(a.data.ChapterMarkers))
+		copy(dataCopy.ChapterMarkers, a.data.ChapterMarkers)
+	}
 
-	// Sort supports by end_index in descending order to avoid shifting issues when inserting
-	supports := make([]llm.GroundingSupport, len(groundingMetadata.GroundingSupports))
-	copy(supports, groundingMetadata.GroundingSupports)
+	if a.data.SimilarMeetings != nil {
+		dataCopy.SimilarMeetings = make([]MeetingReference, len(a.data.SimilarMeetings))
+		for i, reference := range a.data.SimilarMeetings {
+			dataCopy.SimilarMeetings[i] = reference
+			dataCopy.SimilarMeetings[i].CommonTopics = append([]string{}, reference.CommonTopics...)
+		}
+	}
 
-	// Simple bubble sort for descending order by EndIndex
-	for i := 0; i < len(supports); i++ {
-		for j := 0; j < len(supports)-1-i; j++ {
-			if supports[j].Segment.EndIndex < supports[j+1].Segment.EndIndex {
-				supports[j], supports[j+1] = supports[j+1], supports[j]
-			}
+	if a.data.SpeakerPersonas != nil {
+		dataCopy.SpeakerPersonas = make(map[string]SpeakerPersona, len(a.data.SpeakerPersonas))
+		for speaker, persona := range a.data.SpeakerPersonas {
+			dataCopy.SpeakerPersonas[speaker] = persona.clone()
 		}
 	}
 
-	for _, support := range supports {
-		endIndex := support.Segment.EndIndex
-		if len(support.GroundingChunkIndices) > 0 && endIndex <= len(result) {
-			// Create citation string like [1](link1), [2](link2)
-			var citationLinks []string
-			for _, i := range support.GroundingChunkIndices {
-				if i < len(groundingMetadata.GroundingChunks) {
-					uri := groundingMetadata.GroundingChunks[i].Web.URI
-					title := groundingMetadata.GroundingChunks[i].Web.Title
-					citationLinks = append(citationLinks, fmt.Sprintf("[%d](%s)", i+1, uri))
-					logrus.Debugf("Agent %s: Adding citation [%d] %s -> %s", a.agentID, i+1, title, uri)
-				}
-			}
+	if a.data.Corrections != nil {
+		dataCopy.Corrections = make([]Correction, len(a.data.Corrections))
+		copy(dataCopy.Corrections, a.data.Corrections)
+	}
 
-			if len(citationLinks) > 0 {
-				citationString := " " + strings.Join(citationLinks, ", ")
-				result = result[:endIndex] + citationString + result[endIndex:]
-			}
+	if a.data.AcronymGlossary != nil {
+		dataCopy.AcronymGlossary = make(map[string]string, len(a.data.AcronymGlossary))
+		for acronym, expansion := range a.data.AcronymGlossary {
+			dataCopy.AcronymGlossary[acronym] = expansion
 		}
 	}
 
-	return result
-}
+	if a.data.PromptHashes != nil {
+		dataCopy.PromptHashes = make(map[string]string, len(a.data.PromptHashes))
+		for analysisType, hash := range a.data.PromptHashes {
+			dataCopy.PromptHashes[analysisType] = hash
+		}
+	}
 
-// File operations
+	if a.data.QualityChecks != nil {
+		dataCopy.QualityChecks = make(map[string]QualityCheckResult, len(a.data.QualityChecks))
+		for analysisType, result := range a.data.QualityChecks {
+			dataCopy.QualityChecks[analysisType] = result
+		}
+	}
 
-// saveAnalysis saves the analysis data to file
-func (a *AnalystAgent) saveAnalysis() error {
-	data, err := json.MarshalIndent(a.data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal analysis data: %w", err)
+	if a.data.AnalysisMetadata != nil {
+		dataCopy.AnalysisMetadata = make(map[string]StepMetadata, len(a.data.AnalysisMetadata))
+		for step, metadata := range a.data.AnalysisMetadata {
+			dataCopy.AnalysisMetadata[step] = metadata
+		}
 	}
 
-	return os.WriteFile(a.filePath, data, 0644)
-}
+	if a.data.UnansweredQuestions != nil {
+		dataCopy.UnansweredQuestions = make([]UnansweredQuestion, len(a.data.UnansweredQuestions))
+		copy(dataCopy.UnansweredQuestions, a.data.UnansweredQuestions)
+	}
 
-// loadAnalysis loads analysis data from file
-func (a *AnalystAgent) loadAnalysis() error {
-	if _, err := os.Stat(a.filePath); os.IsNotExist(err) {
-		return nil // File doesn't exist, will create new
+	if a.data.ConflictingStatements != nil {
+		dataCopy.ConflictingStatements = make([]StatementConflict, len(a.data.ConflictingStatements))
+		copy(dataCopy.ConflictingStatements, a.data.ConflictingStatements)
 	}
 
-	data, err := os.ReadFile(a.filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read analysis file: %w", err)
+	if a.data.PluginResults != nil {
+		dataCopy.PluginResults = make(map[string]json.RawMessage, len(a.data.PluginResults))
+		for name, result := range a.data.PluginResults {
+			dataCopy.PluginResults[name] = append(json.RawMessage{}, result...)
+		}
 	}
 
-	return json.Unmarshal(data, a.data)
+	dataCopy.Snapshots = make([]AnalysisSnapshot, len(a.data.Snapshots))
+	copy(dataCopy.Snapshots, a.data.Snapshots)
+
+	return &dataCopy
 }
 
-// GetAnalysis returns a copy of the current analysis data
-func (a *AnalystAgent) GetAnalysis() *AnalysisData {
+// GetLiveStats returns real-time metrics without waiting for a running analysis to complete
+func (a *AnalystAgent) GetLiveStats() *LiveStats {
 	a.dataMutex.RLock()
 	defer a.dataMutex.RUnlock()
 
-	// Create a deep copy
-	dataCopy := *a.data
-	dataCopy.Transcript = make([]TranscriptEntry, len(a.data.Transcript))
-	copy(dataCopy.Transcript, a.data.Transcript)
+	stats := &LiveStats{
+		TranscriptEntryCount: len(a.data.Transcript),
+		WordCount:            a.data.WordCount,
+		ActiveParticipants:   []string{},
+		AnalysisInProgress:   a.analysisInProgress.Load(),
+	}
 
-	dataCopy.KeyPoints = make([]string, len(a.data.KeyPoints))
-	copy(dataCopy.KeyPoints, a.data.KeyPoints)
+	if step, ok := a.analysisStepLabel.Load().(string); ok {
+		stats.CurrentStep = step
+	}
 
-	dataCopy.ActionItems = make([]ActionItem, len(a.data.ActionItems))
-	copy(dataCopy.ActionItems, a.data.ActionItems)
+	if reporter, ok := a.llmProvider.(llm.TokenUsageReporter); ok {
+		stats.TokensUsedToday = reporter.TokensUsedToday()
+	}
 
-	dataCopy.Topics = make([]TopicDiscussion, len(a.data.Topics))
-	copy(dataCopy.Topics, a.data.Topics)
+	// Time since the last completed analysis run, or since the meeting started if none has completed
+	lastUpdate := a.data.StartTime
+	if len(a.data.Snapshots) > 0 {
+		lastUpdate = a.data.Snapshots[len(a.data.Snapshots)-1].TakenAt
+	}
+	stats.MinutesSinceLastUpdate = time.Since(lastUpdate).Minutes()
 
-	dataCopy.Participants = make([]string, len(a.data.Participants))
-	copy(dataCopy.Participants, a.data.Participants)
+	if len(a.data.Transcript) > 0 {
+		stats.LastSpeaker = a.data.Transcript[len(a.data.Transcript)-1].Speaker
+	}
 
-	dataCopy.Keywords = make([]string, len(a.data.Keywords))
-	copy(dataCopy.Keywords, a.data.Keywords)
+	// Walk the transcript backwards collecting speakers within the active window
+	cutoff := time.Now().Add(-activeParticipantWindow)
+	seen := make(map[string]bool)
+	for i := len(a.data.Transcript) - 1; i >= 0; i-- {
+		entry := a.data.Transcript[i]
+		if entry.Timestamp.Before(cutoff) {
+			break
+		}
+		if !seen[entry.Speaker] {
+			seen[entry.Speaker] = true
+			stats.ActiveParticipants = append(stats.ActiveParticipants, entry.Speaker)
+		}
+	}
 
-	return &dataCopy
+	return stats
 }
 
 // GetFormattedAnalysis returns the analysis in a nicely formatted text format
 func (a *AnalystAgent) GetFormattedAnalysis() string {
+	var result strings.Builder
+	a.WriteFormattedAnalysis(&result)
+	return result.String()
+}
+
+// WriteFormattedAnalysis writes the analysis formatted as by GetFormattedAnalysis to w one section at a
+// time, flushing after each section when w is an http.Flusher so clients can render the report while the
+// rest is written. Returns the first write error, after which nothing more is written.
+func (a *AnalystAgent) WriteFormattedAnalysis(w io.Writer) error {
 	data := a.GetAnalysis()
+	locale := a.config.ReportLocale
+	if locale == "" {
+		locale = data.DetectedLanguage
+	}
 
+	// Sections are rendered into result and sent when the next one starts
 	var result strings.Builder
+	var writeErr error
+	sendSection := func() {
+		if writeErr == nil && result.Len() > 0 {
+			_, writeErr = io.WriteString(w, result.String())
+			if flusher, ok := w.(http.Flusher); ok && writeErr == nil {
+				flusher.Flush()
+			}
+		}
+		result.Reset()
+	}
+	startSection := func(key string) {
+		sendSection()
+		result.WriteString(fmt.Sprintf("## %s\n\n", i18n.Translate(locale, key)))
+	}
 
-	result.WriteString("# Meeting Analysis Report\n\n")
+	result.WriteString(fmt.Sprintf("# %s\n\n", i18n.Translate(locale, "report_title")))
 	result.WriteString(fmt.Sprintf("**Meeting URL:** %s\n", data.MeetingURL))
+	if data.RecordingURL != "" {
+		result.WriteString(fmt.Sprintf("**Recording:** %s\n", data.RecordingURL))
+	}
 	result.WriteString(fmt.Sprintf("**Start Time:** %s\n", data.StartTime.Format("2006-01-02 15:04:05")))
 	result.WriteString(fmt.Sprintf("**Last Updated:** %s\n", data.LastUpdated.Format("2006-01-02 15:04:05")))
 	result.WriteString(fmt.Sprintf("**Duration:** %.1f minutes\n", data.DurationMinutes))
 	result.WriteString(fmt.Sprintf("**Participants:** %s\n", strings.Join(data.Participants, ", ")))
 	result.WriteString(fmt.Sprintf("**Total Words:** %d\n", data.WordCount))
+	if confidence := formatConfidence(data); confidence != "" {
+		result.WriteString(fmt.Sprintf("**Analysis Confidence:** %s\n", confidence))
+	}
 	if data.Sentiment != "" {
 		result.WriteString(fmt.Sprintf("**Overall Sentiment:** %s\n", data.Sentiment))
 	}
+	if data.MeetingType != "" {
+		result.WriteString(fmt.Sprintf("**Meeting Type:** %s (confidence %.0f%%)\n", data.MeetingType, data.MeetingTypeConfidence*100))
+	}
+	if len(data.SentimentTimeline) > 0 {
+		result.WriteString(fmt.Sprintf("**Sentiment Timeline:** %s (%d-minute windows)\n",
+			sentimentSparkline(data.SentimentTimeline), int(sentimentWindow.Minutes())))
+	}
 	result.WriteString("\n")
 
+	if data.ExecutiveSummary != "" {
+		startSection("executive_summary")
+		result.WriteString(data.ExecutiveSummary + "\n\n")
+		for _, bullet := range data.ExecutiveSummaryBullets {
+			result.WriteString(fmt.Sprintf("- %s\n", bullet))
+		}
+		result.WriteString("\n")
+	}
+
 	if data.Summary != "" {
-		result.WriteString("## Summary\n\n")
+		startSection("summary")
 		result.WriteString(data.Summary)
 		result.WriteString("\n\n")
 	}
 
 	if len(data.KeyPoints) > 0 {
-		result.WriteString("## Key Points\n\n")
+		startSection("key_points")
 		for i, point := range data.KeyPoints {
 			result.WriteString(fmt.Sprintf("%d. %s\n", i+1, point))
 		}
@@ -1277,7 +2846,7 @@ func (a *AnalystAgent) GetFormattedAnalysis() string {
 	}
 
 	if len(data.ActionItems) > 0 {
-		result.WriteString("## Action Items\n\n")
+		startSection("action_items")
 		for _, item := range data.ActionItems {
 			result.WriteString(fmt.Sprintf("- **%s** (%s priority)", item.Description, item.Priority))
 			if item.Type != "" {
@@ -1292,30 +2861,322 @@ func (a *AnalystAgent) GetFormattedAnalysis() string {
 	}
 
 	if len(data.Topics) > 0 {
-		result.WriteString("## Discussion Topics\n\n")
+		startSection("topics")
 		for _, topic := range data.Topics {
 			result.WriteString(fmt.Sprintf("### %s\n", topic.Topic))
 			result.WriteString(fmt.Sprintf("**Duration:** %.1f minutes\n", topic.Duration))
 			result.WriteString(fmt.Sprintf("**Participants:** %s\n", strings.Join(topic.Participants, ", ")))
-			result.WriteString(fmt.Sprintf("**Summary:** %s\n\n", topic.Summary))
+			result.WriteString(fmt.Sprintf("**Summary:** %s\n", topic.Summary))
+			for _, subTopic := range topic.SubTopics {
+				result.WriteString(fmt.Sprintf("  - **%s** (%.1f minutes): %s\n", subTopic.Topic, subTopic.Duration, subTopic.Summary))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	if len(data.KeyQuotes) > 0 {
+		startSection("key_quotes")
+		for _, quote := range data.KeyQuotes {
+			result.WriteString(fmt.Sprintf("- \"%s\" — **%s** (%s, %s)\n",
+				quote.Text, quote.Speaker, quote.Category, quote.Timestamp.Format("15:04:05")))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.Requirements) > 0 {
+		startSection("requirements")
+		for _, requirement := range data.Requirements {
+			result.WriteString(fmt.Sprintf("- **%s** (%s, %s priority)", requirement.Description, requirement.Type, requirement.Priority))
+			if requirement.RequestedBy != "" {
+				result.WriteString(fmt.Sprintf(" — requested by %s", requirement.RequestedBy))
+			}
+			if requirement.Ambiguous {
+				result.WriteString(" ⚠️ needs clarification")
+			}
+			result.WriteString("\n")
+			for _, criterion := range requirement.AcceptanceCriteria {
+				result.WriteString(fmt.Sprintf("  - %s\n", criterion))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.KeyMetrics) > 0 {
+		startSection("key_metrics")
+		result.WriteString(data.MetricsSummary())
+		result.WriteString("\n")
+	}
+
+	if len(data.BudgetDiscussions) > 0 {
+		startSection("budget_vs_actual")
+		result.WriteString(data.BudgetSummaryTable())
+		result.WriteString("\n")
+	}
+
+	if len(data.SuccessMetricsTracked) > 0 {
+		startSection("success_metrics")
+		for _, metric := range data.SuccessMetricsTracked {
+			result.WriteString(fmt.Sprintf("- **%s** (%s)", metric.MetricName, strings.ReplaceAll(metric.Status, "_", " ")))
+			if metric.CurrentValue != "" {
+				result.WriteString(fmt.Sprintf(": %s", metric.CurrentValue))
+			}
+			if metric.TargetValue != "" {
+				result.WriteString(fmt.Sprintf(", target %s", metric.TargetValue))
+			}
+			if metric.Deadline != "" {
+				result.WriteString(fmt.Sprintf(" by %s", metric.Deadline))
+			}
+			if metric.Owner != "" {
+				result.WriteString(fmt.Sprintf(" — %s", metric.Owner))
+			}
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.BookRecommendations) > 0 {
+		startSection("recommended_reading")
+		for _, book := range data.BookRecommendations {
+			result.WriteString(fmt.Sprintf("- [%s](%s)", book.Title, book.AmazonSearchURL))
+			if book.Author != "" {
+				result.WriteString(fmt.Sprintf(" by %s", book.Author))
+			}
+			result.WriteString(fmt.Sprintf(": %s\n", book.Relevance))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.Objections) > 0 {
+		startSection("sales_objections")
+		for _, objection := range data.Objections {
+			result.WriteString(fmt.Sprintf("- **%s** (%s, %s): %s\n", objection.ObjectionType, objection.RaisedBy, objection.Outcome, objection.ObjectionText))
+			if objection.Handled {
+				result.WriteString(fmt.Sprintf("  - %s: %s\n", objection.HandledBy, objection.HandlingResponse))
+			}
+			if objection.CompetitorClaimCheck != "" {
+				result.WriteString(fmt.Sprintf("  - %s\n", objection.CompetitorClaimCheck))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.AttachmentRefs) > 0 {
+		startSection("attachments")
+		for _, ref := range data.AttachmentRefs {
+			result.WriteString(fmt.Sprintf("- %s, mentioned by %s at %s", ref.Description, ref.MentionedBy, ref.Timestamp.Format("15:04")))
+			if ref.FileName != "" {
+				result.WriteString(fmt.Sprintf(" (`%s`)", ref.FileName))
+			}
+			switch {
+			case ref.Received && ref.ReceivedURL != "":
+				result.WriteString(fmt.Sprintf(", [received](%s)", ref.ReceivedURL))
+			case ref.Received:
+				result.WriteString(", received")
+			case ref.Blocking:
+				result.WriteString(fmt.Sprintf(", **blocking**: %s", ref.BlockedWork))
+			}
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.ImportantLinks) > 0 {
+		startSection("shared_links")
+		for _, link := range data.ImportantLinks {
+			title := link.Title
+			if title == "" {
+				title = link.URL
+			}
+			result.WriteString(fmt.Sprintf("- [%s](%s), shared by %s at %s\n", title, link.URL, link.MentionedBy, link.Timestamp.Format("15:04")))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.ComplianceFlags) > 0 {
+		startSection("compliance_flags")
+		for _, flag := range data.ComplianceFlags {
+			result.WriteString(fmt.Sprintf("- **%s** (%s severity): %s — %s, %s", flag.Category, flag.Severity,
+				flag.Description, flag.Speaker, flag.Timestamp.Format("15:04:05")))
+			if flag.RegulationReference != "" {
+				result.WriteString(fmt.Sprintf(" (%s)", flag.RegulationReference))
+			}
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.EthicsFlags) > 0 {
+		startSection("ethics_flags")
+		for _, flag := range data.EthicsFlags {
+			result.WriteString(fmt.Sprintf("- **%s** (%s severity): \"%s\" — %s, %s", flag.Category, flag.Severity,
+				flag.Quote, flag.Speaker, flag.Timestamp.Format("15:04:05")))
+			if flag.ActionRecommended != "" {
+				result.WriteString(fmt.Sprintf(". %s", flag.ActionRecommended))
+			}
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.TechnicalDebtMentions) > 0 {
+		startSection("technical_debt")
+		for _, item := range data.TechnicalDebtMentions {
+			result.WriteString(fmt.Sprintf("- **%s** (%s severity): %s — %s, %s", item.Component, item.Severity,
+				item.Description, item.MentionedBy, item.Timestamp.Format("15:04:05")))
+			if item.ProposedFix != "" {
+				result.WriteString(fmt.Sprintf(" (proposed fix: %s)", item.ProposedFix))
+			}
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
+	}
+
+	if data.NPSProxy != nil {
+		startSection("nps_proxy")
+		result.WriteString(fmt.Sprintf("**Estimated score:** %.0f (confidence %.0f%%)\n", data.NPSProxy.EstimatedScore, data.NPSProxy.Confidence*100))
+		for _, group := range []struct {
+			label   string
+			phrases []string
+		}{
+			{"Promoter", data.NPSProxy.PromoterPhrases},
+			{"Passive", data.NPSProxy.PassivePhrases},
+			{"Detractor", data.NPSProxy.DetractorPhrases},
+		} {
+			for _, phrase := range group.phrases {
+				result.WriteString(fmt.Sprintf("- %s: \"%s\"\n", group.label, phrase))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.ProductFeedback) > 0 {
+		startSection("product_feedback")
+		for _, item := range data.ProductFeedback {
+			result.WriteString(fmt.Sprintf("- **%s** (%s priority", strings.ReplaceAll(item.Type, "_", " "), item.Priority))
+			if item.ProductArea != "" {
+				result.WriteString(", " + item.ProductArea)
+			}
+			result.WriteString(fmt.Sprintf("): %s — %s: \"%s\"\n", item.Description, item.CustomerSpeaker, item.Verbatim))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.SpeakerPersonas) > 0 {
+		startSection("speaker_personas")
+		speakers := make([]string, 0, len(data.SpeakerPersonas))
+		for speaker := range data.SpeakerPersonas {
+			speakers = append(speakers, speaker)
+		}
+		sort.Strings(speakers)
+		for _, speaker := range speakers {
+			persona := data.SpeakerPersonas[speaker]
+			result.WriteString(fmt.Sprintf("- **%s**: %s, %s style, %s technical depth (confidence %.0f%%)\n", speaker,
+				persona.InferredRole, persona.CommunicationStyle, persona.TechnicalDepth, persona.Confidence*100))
+			for _, quote := range persona.SampleQuotes {
+				result.WriteString(fmt.Sprintf("  - \"%s\"\n", quote))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.AcronymGlossary) > 0 {
+		startSection("acronym_glossary")
+		acronyms := make([]string, 0, len(data.AcronymGlossary))
+		for acronym := range data.AcronymGlossary {
+			acronyms = append(acronyms, acronym)
+		}
+		sort.Strings(acronyms)
+		for _, acronym := range acronyms {
+			result.WriteString(fmt.Sprintf("- **%s**: %s\n", acronym, data.AcronymGlossary[acronym]))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.QuestionsAndAnswers) > 0 {
+		startSection("questions_and_answers")
+		for _, pair := range data.QuestionsAndAnswers {
+			result.WriteString(fmt.Sprintf("- **%s** — %s, %s\n", pair.Question, pair.QuestionedBy, pair.QuestionTimestamp.Format("15:04:05")))
+			if pair.Answered {
+				result.WriteString(fmt.Sprintf("  - %s — %s (%s answer)\n", pair.Answer, pair.AnsweredBy, pair.AnswerQuality))
+			} else {
+				result.WriteString("  - Unanswered\n")
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.UnansweredQuestions) > 0 {
+		startSection("unanswered_questions")
+		for _, question := range data.UnansweredQuestions {
+			result.WriteString(fmt.Sprintf("- %s\n", question.Question))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.ConflictingStatements) > 0 {
+		startSection("conflicting_statements")
+		for _, conflict := range data.ConflictingStatements {
+			if conflict.Statement1 >= len(data.Transcript) || conflict.Statement2 >= len(data.Transcript) {
+				continue
+			}
+			first, second := data.Transcript[conflict.Statement1], data.Transcript[conflict.Statement2]
+			result.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", conflict.ConflictType, conflict.Severity, conflict.Explanation))
+			result.WriteString(fmt.Sprintf("  - [%s] %s: %s\n", first.Timestamp.Format("15:04:05"), first.Speaker, first.Text))
+			result.WriteString(fmt.Sprintf("  - [%s] %s: %s\n", second.Timestamp.Format("15:04:05"), second.Speaker, second.Text))
+		}
+		result.WriteString("\n")
+	}
+
+	if intel := data.CompetitiveIntelligence; intel != nil && (len(intel.CompetitorsMentioned) > 0 ||
+		len(intel.PricingDiscussed) > 0 || len(intel.FeatureComparisons) > 0) {
+		startSection("competitive_intelligence")
+		for _, mention := range intel.CompetitorsMentioned {
+			result.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", mention.Name, mention.Sentiment, mention.Context))
+		}
+		for _, pricing := range intel.PricingDiscussed {
+			result.WriteString(fmt.Sprintf("- Pricing: %s\n", pricing))
+		}
+		for _, comparison := range intel.FeatureComparisons {
+			result.WriteString(fmt.Sprintf("- %s vs %s: %s\n", comparison.Feature, comparison.Competitor, comparison.Comparison))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(data.CrosstalkEvents) > 0 {
+		startSection("crosstalk")
+		result.WriteString(fmt.Sprintf("%d events (%.2f per minute)\n\n", len(data.CrosstalkEvents), data.CrosstalkRate))
+		for _, event := range data.CrosstalkEvents {
+			result.WriteString(fmt.Sprintf("- %s–%s: %s\n",
+				event.StartTime.Format("15:04:05"), event.EndTime.Format("15:04:05"), strings.Join(event.Speakers, ", ")))
 		}
+		result.WriteString("\n")
+	}
+
+	if data.EmotionalArcNarrative != "" {
+		startSection("meeting_narrative")
+		result.WriteString(data.EmotionalArcNarrative + "\n\n")
 	}
 
 	if len(data.Keywords) > 0 {
-		result.WriteString("## Keywords\n\n")
+		startSection("keywords")
 		result.WriteString(strings.Join(data.Keywords, ", "))
 		result.WriteString("\n\n")
 	}
 
 	if len(data.Transcript) > 0 {
-		result.WriteString("## Full Transcript\n\n")
-		for _, entry := range data.Transcript {
-			result.WriteString(fmt.Sprintf("[%s] **%s:** %s\n\n",
-				entry.Timestamp.Format("15:04:05"),
+		startSection("full_transcript")
+		for _, entry := range data.EnrichTranscriptWithDeepLinks() {
+			timestamp := fmt.Sprintf("[%s]", entry.Timestamp.Format("15:04:05"))
+			if entry.DeepLinkURL != "" {
+				timestamp = fmt.Sprintf("[%s](%s)", entry.Timestamp.Format("15:04:05"), entry.DeepLinkURL)
+			}
+			result.WriteString(fmt.Sprintf("%s **%s:** %s\n\n",
+				timestamp,
 				entry.Speaker,
 				entry.Text))
 		}
 	}
 
-	return result.String()
+	sendSection()
+	return writeErr
 }
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+func TestDerivedAgentsShareParentSettings(t *testing.T) {
+	parent := newTestAnalyst(t)
+	parent.config.Normalizer = &models.NormalizerConfig{Enabled: true, RemoveFillers: true}
+	parent.llmProvider = llm.NewMockLLMProvider()
+	parent.SetKnowledgeBase(glossary{"ARR": "Annual recurring revenue"})
+	parent.SetDefaultHourlyRate(120)
+	parent.SetSystemPromptPrefix("Answer in British English.")
+	parent.SetSpeechFilter(0.7, []string{`^\[music\]$`})
+
+	window := parent.newWindowAgent(indexedTranscript("Alice", "Bob"))
+	derived := parent.newDerivedAgent("merged", parent.config, &AnalysisData{})
+	for name, agent := range map[string]*AnalystAgent{"window": window, "derived": derived} {
+		if agent.llmProvider != parent.llmProvider {
+			t.Errorf("%s agent doesn't use the parent's LLM provider", name)
+		}
+		if agent.knowledge == nil {
+			t.Errorf("%s agent has no knowledge base", name)
+		}
+		if agent.normalizer == nil {
+			t.Errorf("%s agent has no transcript normalizer", name)
+		}
+		if agent.defaultHourlyRate != 120 {
+			t.Errorf("%s agent hourly rate = %v, want 120", name, agent.defaultHourlyRate)
+		}
+		if agent.systemPromptPrefix != "Answer in British English." {
+			t.Errorf("%s agent system prompt prefix = %q", name, agent.systemPromptPrefix)
+		}
+		if agent.speechDetector.MinConfidence != 0.7 {
+			t.Errorf("%s agent speech confidence = %v, want 0.7", name, agent.speechDetector.MinConfidence)
+		}
+		if agent.transcriptBus == nil || agent.capacity == nil || agent.trigger == nil || agent.quality == nil {
+			t.Errorf("%s agent is missing per-agent state", name)
+		}
+	}
+
+	if !window.windowAgent {
+		t.Error("window agent not marked as one")
+	}
+	if _, ok := window.backend.(discardBackend); !ok {
+		t.Error("window agent saves its analysis")
+	}
+}
+
+func TestTopicDiscussionRoundTrip(t *testing.T) {
+	topics := []TopicDiscussion{{
+		Topic:        "Launch",
+		StartTime:    "10:00",
+		Duration:     12,
+		Summary:      "Launch planning",
+		Participants: []string{"Alice", "Bob"},
+		SubTopics: []TopicDiscussion{
+			{Topic: "Pricing", StartTime: "10:02", Duration: 5, Participants: []string{"Alice"}},
+			{Topic: "Legal review", StartTime: "10:07", Duration: 4, Participants: []string{"Bob"}},
+		},
+	}}
+
+	data, err := json.Marshal(topics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []TopicDiscussion
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, topics) {
+		t.Errorf("decoded = %+v, want %+v", decoded, topics)
+	}
+
+	var flat []string
+	for _, topic := range FlattenTopics(decoded) {
+		flat = append(flat, topic.Topic)
+	}
+	if !reflect.DeepEqual(flat, []string{"Launch", "Pricing", "Legal review"}) {
+		t.Errorf("flattened = %q", flat)
+	}
+}
+
+func TestCopyTopicsTruncatesDepth(t *testing.T) {
+	topics := []TopicDiscussion{{Topic: "A", SubTopics: []TopicDiscussion{{Topic: "B", SubTopics: []TopicDiscussion{{Topic: "C"}}}}}}
+
+	copied := copyTopics(topics, maxTopicDepth)
+	if len(copied[0].SubTopics) != 1 || copied[0].SubTopics[0].SubTopics != nil {
+		t.Errorf("copied = %+v, want sub-topics past %d levels dropped", copied, maxTopicDepth)
+	}
+	copied[0].SubTopics[0].Topic = "changed"
+	if topics[0].SubTopics[0].Topic != "B" {
+		t.Error("copy shares sub-topics with the original")
+	}
+}
+
+func TestAgendaPreambleInPrompts(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.Agenda = []models.AgendaItem{{Title: "Budget review", DurationMinutes: 20}, {Title: "Roadmap planning"}}
+	mock := llm.NewMockLLMProvider(keyPointsResponse)
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "Let's start with the budget")
+
+	if err := analyst.extractKeyPoints(context.Background()); err != nil {
+		t.Fatalf("extractKeyPoints() error = %v", err)
+	}
+	want := "This meeting was scheduled to cover: 1. Budget review (20 min), 2. Roadmap planning. Pay special " +
+		"attention to whether each agenda item was covered and to any significant deviations.\n\n"
+	if prompt := mock.Prompts()[0]; !strings.HasPrefix(prompt, want) {
+		t.Errorf("prompt doesn't start with the agenda preamble:\n%s", prompt)
+	}
+
+	// Without an agenda there is no preamble
+	if got := analyst.buildAgendaContextPrompt(nil); got != "" {
+		t.Errorf("buildAgendaContextPrompt(nil) = %q", got)
+	}
+}
+
+func TestLiveStatsDuringAnalysis(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	mock := llm.NewMockLLMProvider()
+	mock.Respond = func(string) (string, error) {
+		once.Do(func() { close(started) })
+		<-release
+		return "", errors.New("offline")
+	}
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "Let's review the launch plan")
+	say(analyst, 10, "Bob", "The checklist is nearly done")
+
+	done := make(chan struct{})
+	go func() {
+		analyst.updateAnalysis()
+		close(done)
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("analysis did not call the LLM")
+	}
+
+	// The analysis is blocked in its first LLM call
+	stats := analyst.GetLiveStats()
+	if !stats.AnalysisInProgress || stats.CurrentStep == "" {
+		t.Errorf("stats during analysis = %+v, want in progress with a step", stats)
+	}
+	if stats.TranscriptEntryCount != 2 || stats.LastSpeaker != "Bob" {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("analysis did not finish")
+	}
+	if stats := analyst.GetLiveStats(); stats.AnalysisInProgress || stats.CurrentStep != "" {
+		t.Errorf("stats after analysis = %+v, want idle", stats)
+	}
+}
+
+func TestStepTimeout(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.StepTimeouts = map[string]time.Duration{"summary": 5 * time.Second, "topics": 0}
+
+	if got := analyst.stepTimeout("summary"); got != 5*time.Second {
+		t.Errorf("stepTimeout(summary) = %v, want the configured 5s", got)
+	}
+	// Unset and non-positive timeouts use the default
+	for _, step := range []string{"topics", "key_points"} {
+		if got := analyst.stepTimeout(step); got != DefaultStepTimeout {
+			t.Errorf("stepTimeout(%s) = %v, want %v", step, got, DefaultStepTimeout)
+		}
+	}
+}
+
+func TestRunStepTimesOut(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.StepTimeouts = map[string]time.Duration{"slow": 10 * time.Millisecond}
+
+	slow := analysisStep{name: "slow", run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	err := analyst.runStep(slow)
+	var timeoutErr *ErrStepTimeout
+	if !errors.As(err, &timeoutErr) || timeoutErr.StepName != "slow" || timeoutErr.Duration != 10*time.Millisecond {
+		t.Fatalf("runStep() error = %v, want ErrStepTimeout for slow", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("ErrStepTimeout doesn't unwrap to context.DeadlineExceeded")
+	}
+
+	// Other failures are returned as they are and aren't counted as timeouts
+	failed := errors.New("bad response")
+	if err := analyst.runStep(analysisStep{name: "fast", run: func(context.Context) error { return failed }}); err != failed {
+		t.Errorf("runStep() error = %v, want %v", err, failed)
+	}
+	if count := analyst.GetAnalysis().TimeoutCount; count != 1 {
+		t.Errorf("TimeoutCount = %d, want 1", count)
+	}
+}
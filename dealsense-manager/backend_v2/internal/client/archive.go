@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/storage"
+)
+
+const (
+	archiveAttempts    = 3
+	archiveBaseBackoff = time.Second
+)
+
+// ArchiveStore opens the storage backend for an archive destination URL
+type ArchiveStore interface {
+	Backend(destination string) (storage.Backend, error)
+}
+
+// SetArchiveStore sets the store finalized analyses are archived to
+func (a *AnalystAgent) SetArchiveStore(store ArchiveStore) {
+	a.archives = store
+}
+
+// archiveAnalysis uploads the analysis to the destination of the agent's archival policy, gzipped when
+// the format is json_gz. Objects are named after the analysis file, under the tenant ID when there is
+// one. Failed uploads are retried with exponential backoff.
+func (a *AnalystAgent) archiveAnalysis(ctx context.Context, data *AnalysisData) error {
+	policy := a.config.Archival
+	if policy == nil || policy.ArchiveDestination == "" {
+		return fmt.Errorf("no archive destination is configured")
+	}
+	if a.archives == nil {
+		return fmt.Errorf("no archive store is set")
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis data: %w", err)
+	}
+
+	name := filepath.Base(a.filePath)
+	if data.TenantID != "" {
+		name = data.TenantID + "/" + name
+	}
+	switch policy.ArchiveFormat {
+	case "", "json":
+	case "json_gz":
+		if payload, err = gzipBytes(payload); err != nil {
+			return fmt.Errorf("failed to compress analysis data: %w", err)
+		}
+		name += ".gz"
+	default:
+		return fmt.Errorf("unsupported archive format %q: must be json or json_gz", policy.ArchiveFormat)
+	}
+
+	backend, err := a.archives.Backend(policy.ArchiveDestination)
+	if err != nil {
+		return err
+	}
+
+	doc := storage.Document{Path: name, TenantID: data.TenantID, MeetingID: data.MeetingID, Data: payload}
+	err = backend.Save(ctx, doc)
+	for attempt := 1; err != nil && attempt < archiveAttempts && ctx.Err() == nil; attempt++ {
+		logrus.Warnf("Agent %s: Archive upload failed, retrying: %v", a.agentID, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(archiveBaseBackoff << (attempt - 1)):
+			err = backend.Save(ctx, doc)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to archive analysis: %w", err)
+	}
+
+	logrus.Infof("Agent %s: Archived analysis to %s/%s", a.agentID, strings.TrimSuffix(policy.ArchiveDestination, "/"), name)
+	return nil
+}
+
+// gzipBytes compresses data with gzip
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/models"
+	"joinly-manager/internal/storage"
+)
+
+// fakeArchiveStore hands out a backend recording saved documents, failing the first failures saves
+type fakeArchiveStore struct {
+	destination string
+	docs        []storage.Document
+	failures    int
+}
+
+func (s *fakeArchiveStore) Backend(destination string) (storage.Backend, error) {
+	s.destination = destination
+	return s, nil
+}
+
+func (s *fakeArchiveStore) Save(ctx context.Context, doc storage.Document) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("upload failed")
+	}
+	s.docs = append(s.docs, doc)
+	return nil
+}
+
+func TestArchiveAnalysisGzipsUnderTenant(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	store := &fakeArchiveStore{}
+	analyst.SetArchiveStore(store)
+	analyst.config.Archival = &models.ArchivalPolicy{ArchiveDestination: "s3://archive/meetings", ArchiveFormat: "json_gz"}
+	data := &AnalysisData{MeetingID: "m1", TenantID: "acme", Summary: "Rollout planned"}
+
+	if err := analyst.archiveAnalysis(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+	if store.destination != "s3://archive/meetings" || len(store.docs) != 1 {
+		t.Fatalf("destination %q, %d documents saved", store.destination, len(store.docs))
+	}
+
+	doc := store.docs[0]
+	if !strings.HasPrefix(doc.Path, "acme/") || !strings.HasSuffix(doc.Path, ".json.gz") || doc.MeetingID != "m1" {
+		t.Errorf("document = %s (tenant %q, meeting %q)", doc.Path, doc.TenantID, doc.MeetingID)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(doc.Data))
+	if err != nil {
+		t.Fatalf("archive is not gzipped: %v", err)
+	}
+	raw, _ := io.ReadAll(reader)
+	var archived AnalysisData
+	if err := json.Unmarshal(raw, &archived); err != nil || archived.Summary != "Rollout planned" {
+		t.Errorf("archived analysis = %+v, %v", archived, err)
+	}
+}
+
+func TestArchiveAnalysisRetries(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	store := &fakeArchiveStore{failures: 1}
+	analyst.SetArchiveStore(store)
+	analyst.config.Archival = &models.ArchivalPolicy{ArchiveDestination: "gs://archive"}
+
+	if err := analyst.archiveAnalysis(context.Background(), &AnalysisData{MeetingID: "m1"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.docs) != 1 || strings.Contains(store.docs[0].Path, "/") || !json.Valid(store.docs[0].Data) {
+		t.Errorf("documents = %+v, want one plain JSON document", store.docs)
+	}
+}
+
+func TestArchiveAnalysisStopsRetryingWhenCanceled(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.SetArchiveStore(&fakeArchiveStore{failures: archiveAttempts})
+	analyst.config.Archival = &models.ArchivalPolicy{ArchiveDestination: "gs://archive"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := analyst.archiveAnalysis(ctx, &AnalysisData{}); err == nil || !strings.Contains(err.Error(), "upload failed") {
+		t.Errorf("archiveAnalysis() error = %v, want the upload error", err)
+	}
+}
+
+func TestArchiveAnalysisConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *models.ArchivalPolicy
+		store  ArchiveStore
+		want   string
+	}{
+		{"no destination", &models.ArchivalPolicy{}, &fakeArchiveStore{}, "no archive destination"},
+		{"no store", &models.ArchivalPolicy{ArchiveDestination: "s3://archive"}, nil, "no archive store"},
+		{"bad format", &models.ArchivalPolicy{ArchiveDestination: "s3://archive", ArchiveFormat: "xml"}, &fakeArchiveStore{}, "unsupported archive format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyst := newTestAnalyst(t)
+			analyst.archives = tt.store
+			analyst.config.Archival = tt.policy
+			if err := analyst.archiveAnalysis(context.Background(), &AnalysisData{}); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("archiveAnalysis() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// attachmentsTranscript is the number of recent transcript entries attachment references are extracted from
+const attachmentsTranscript = 100
+
+// ErrAttachmentNotFound is returned when an attachment reference index is out of range
+var ErrAttachmentNotFound = errors.New("attachment reference not found")
+
+// AttachmentReference is a file or document a participant shared, promised to send or referred to in the
+// meeting, such as "I'll send the deck" or "look at page 5 of the proposal"
+type AttachmentReference struct {
+	Description string    `json:"description"`
+	MentionedBy string    `json:"mentioned_by"`
+	Timestamp   time.Time `json:"timestamp"`
+	FileName    string    `json:"file_name,omitempty"` // Set when the file was named explicitly
+	Received    bool      `json:"received"`            // Set through the API once the file has been received
+	ReceivedURL string    `json:"received_url,omitempty"`
+
+	// Whether someone can't proceed until they get the file, and what they are waiting to do. Blocking
+	// references get an action item to share the file, completed when the file is received.
+	Blocking    bool   `json:"blocking"`
+	BlockedWork string `json:"blocked_work,omitempty"`
+}
+
+// attachmentsStep is the analysis step extracting attachment references, run over the whole meeting's
+// analysis rather than per window
+func (a *AnalystAgent) attachmentsStep() analysisStep {
+	return analysisStep{name: "attachments", description: "extract attachment references", run: a.extractAttachmentRefs}
+}
+
+// extractAttachmentRefs finds the files and documents participants shared, promised or referred to.
+// References accumulate across analysis runs, so their indexes stay stable for the API, and one extracted
+// again keeps its received state. Blocking references are linked to an action item to share the file.
+func (a *AnalystAgent) extractAttachmentRefs(ctx context.Context) error {
+	transcript := a.getRecentTranscript(attachmentsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting attachment references from %d transcript entries", a.agentID, len(transcript))
+
+	var result struct {
+		Attachments []struct {
+			Statement   int    `json:"statement"`
+			Description string `json:"description"`
+			FileName    string `json:"file_name"`
+			Blocking    bool   `json:"blocking"`
+			BlockedWork string `json:"blocked_work"`
+			SharedBy    string `json:"shared_by"`
+		} `json:"attachments"`
+	}
+	request := indexedStatementsRequest{
+		task: `Identify every file or document participants shared, promised to send, asked for or referred to in this meeting: decks, proposals, contracts, spreadsheets, reports, recordings and the like. Look for document-sharing language such as "I'll send the deck", "can you share the spreadsheet", "look at page 5 of the proposal" or "it's in the attached contract". Leave out links, which are tracked separately.
+
+For each document, give:
+- The index of the statement referring to it
+- A short description of the document
+- Its file name, only when one was said explicitly
+- Whether it is blocking: someone said they can't proceed until they get it, as in "I can't start the review until I get the deck"
+- For blocking documents, what is waiting on it, and who is expected to share it`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "attachments": [
+    {
+      "statement": 7,
+      "description": "Q3 pricing deck",
+      "file_name": "q3-pricing.pdf",
+      "blocking": true,
+      "blocked_work": "Review the pricing with finance",
+      "shared_by": "Alice"
+    }
+  ]
+}`,
+		result: "attachment references",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return err
+	}
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	added := 0
+	for _, candidate := range result.Attachments {
+		mentioned, ok := statementAt(transcript, candidate.Statement)
+		if !ok {
+			continue
+		}
+		ref := AttachmentReference{
+			Description: strings.TrimSpace(candidate.Description),
+			MentionedBy: mentioned.Speaker,
+			Timestamp:   mentioned.Timestamp,
+			FileName:    strings.TrimSpace(candidate.FileName),
+			Blocking:    candidate.Blocking,
+			BlockedWork: strings.TrimSpace(candidate.BlockedWork),
+		}
+		if ref.Description == "" {
+			ref.Description = ref.FileName
+		}
+		if ref.Description == "" {
+			continue
+		}
+
+		index := findAttachmentRef(a.data.AttachmentRefs, ref)
+		if index < 0 {
+			a.data.AttachmentRefs = append(a.data.AttachmentRefs, ref)
+			index = len(a.data.AttachmentRefs) - 1
+			added++
+		} else {
+			ref.Received = a.data.AttachmentRefs[index].Received
+			ref.ReceivedURL = a.data.AttachmentRefs[index].ReceivedURL
+			a.data.AttachmentRefs[index] = ref
+		}
+		if ref.Blocking {
+			a.linkBlockingAttachment(index, strings.TrimSpace(candidate.SharedBy))
+		}
+	}
+
+	logrus.Infof("Agent %s: Tracking %d attachment references, %d new", a.agentID, len(a.data.AttachmentRefs), added)
+	return nil
+}
+
+// findAttachmentRef returns the index of the reference made by the same speaker at the same time, or -1
+func findAttachmentRef(refs []AttachmentReference, ref AttachmentReference) int {
+	for i, existing := range refs {
+		if existing.MentionedBy == ref.MentionedBy && existing.Timestamp.Equal(ref.Timestamp) {
+			return i
+		}
+	}
+	return -1
+}
+
+// blockingAttachmentItem returns the action item to share the attachment at index, or nil when it has none
+// (caller must hold dataMutex)
+func (a *AnalystAgent) blockingAttachmentItem(index int) *ActionItem {
+	for i := range a.data.ActionItems {
+		if attachment := a.data.ActionItems[i].BlockingAttachment; attachment != nil && *attachment == index {
+			return &a.data.ActionItems[i]
+		}
+	}
+	return nil
+}
+
+// linkBlockingAttachment adds a high-priority action item to share the blocking attachment at index,
+// assigned to whoever is expected to share it, unless the attachment already has one. Caller must hold
+// dataMutex.
+func (a *AnalystAgent) linkBlockingAttachment(index int, sharedBy string) {
+	if a.blockingAttachmentItem(index) != nil {
+		return
+	}
+
+	ref := a.data.AttachmentRefs[index]
+	name := ref.Description
+	if ref.FileName != "" {
+		name = ref.FileName
+	}
+	description := fmt.Sprintf("Share %s", name)
+	if ref.BlockedWork != "" {
+		description = fmt.Sprintf("Share %s (blocking: %s)", name, ref.BlockedWork)
+	}
+
+	status := "pending"
+	if ref.Received {
+		status = "completed"
+	}
+	item := ActionItem{
+		Description:        description,
+		Assignee:           sharedBy,
+		Priority:           "high",
+		Type:               "follow-up",
+		Status:             status,
+		BlockingAttachment: &index,
+	}
+	a.data.ActionItems = deduplicateActionItems(a.data.ActionItems, mergeActionItems(a.data.ActionItems, []ActionItem{item}))
+}
+
+// MarkAttachmentReceived marks the attachment reference at index as received, storing the URL it can be
+// found at, and completes the action item to share it
+func (a *AnalystAgent) MarkAttachmentReceived(index int, receivedURL string) error {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	if index < 0 || index >= len(a.data.AttachmentRefs) {
+		return ErrAttachmentNotFound
+	}
+
+	ref := &a.data.AttachmentRefs[index]
+	ref.Received = true
+	ref.ReceivedURL = strings.TrimSpace(receivedURL)
+	if item := a.blockingAttachmentItem(index); item != nil {
+		item.Status = "completed"
+	}
+	logrus.Infof("Agent %s: Attachment %d (%s) received", a.agentID, index, ref.Description)
+
+	a.queueSave()
+	return nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const attachmentsResponse = "```json\n" + `{"attachments": [
+	{"statement": 0, "description": "Q3 pricing deck", "file_name": "q3-pricing.pdf", "blocking": true, "blocked_work": "Review the pricing with finance", "shared_by": "Alice"},
+	{"statement": 1, "description": "Security questionnaire", "blocking": false}
+]}` + "\n```"
+
+func TestBlockingAttachmentGetsActionItem(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider(attachmentsResponse, attachmentsResponse)
+	say(analyst, 0, "Bob", "I can't review pricing until I get the deck")
+	say(analyst, 10, "Alice", "The security questionnaire is attached too")
+
+	ctx := context.Background()
+	if err := analyst.extractAttachmentRefs(ctx); err != nil {
+		t.Fatalf("extractAttachmentRefs() error = %v", err)
+	}
+
+	analysis := analyst.GetAnalysis()
+	if len(analysis.AttachmentRefs) != 2 || !analysis.AttachmentRefs[0].Blocking || analysis.AttachmentRefs[1].Blocking {
+		t.Fatalf("AttachmentRefs = %+v", analysis.AttachmentRefs)
+	}
+	if len(analysis.ActionItems) != 1 {
+		t.Fatalf("ActionItems = %+v, want one for the blocking deck", analysis.ActionItems)
+	}
+	item := analysis.ActionItems[0]
+	if item.Description != "Share q3-pricing.pdf (blocking: Review the pricing with finance)" || item.Assignee != "Alice" ||
+		item.Priority != "high" || item.Status != "pending" || item.BlockingAttachment == nil || *item.BlockingAttachment != 0 {
+		t.Errorf("action item = %+v", item)
+	}
+
+	if err := analyst.MarkAttachmentReceived(0, " https://files.example.com/q3-pricing.pdf "); err != nil {
+		t.Fatalf("MarkAttachmentReceived() error = %v", err)
+	}
+	// Extracting again keeps the received state and doesn't add a second action item
+	if err := analyst.extractAttachmentRefs(ctx); err != nil {
+		t.Fatalf("second extractAttachmentRefs() error = %v", err)
+	}
+
+	analysis = analyst.GetAnalysis()
+	if ref := analysis.AttachmentRefs[0]; !ref.Received || ref.ReceivedURL != "https://files.example.com/q3-pricing.pdf" {
+		t.Errorf("received ref = %+v", ref)
+	}
+	if len(analysis.ActionItems) != 1 || analysis.ActionItems[0].Status != "completed" {
+		t.Errorf("ActionItems = %+v, want the share item completed", analysis.ActionItems)
+	}
+}
+
+func TestMarkAttachmentReceivedOutOfRange(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if err := analyst.MarkAttachmentReceived(0, ""); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("MarkAttachmentReceived() error = %v, want ErrAttachmentNotFound", err)
+	}
+}
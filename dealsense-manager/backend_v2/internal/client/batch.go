@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// batchablePrompt builds the prompt of an analysis step that makes a single ungrounded LLM call
+type batchablePrompt struct {
+	step  string
+	build func() (string, []TranscriptEntry)
+}
+
+// prefetchBatchResponses sends the prompts of the core analysis steps as one batch when the agent and
+// provider support it, so each step's LLM call is answered without a request of its own. Steps whose
+// prompt isn't in the batch, or whose batched request failed, call the LLM as usual.
+func (a *AnalystAgent) prefetchBatchResponses() {
+	if !a.config.EnableBatchCalls {
+		return
+	}
+	provider, ok := a.llmProvider.(llm.BatchCallingProvider)
+	if !ok || !provider.IsAvailable() {
+		return
+	}
+	// Custom prompts are regenerated on every call, so a step would never find its batched prompt
+	if a.config.CustomPrompt != nil && *a.config.CustomPrompt != "" {
+		return
+	}
+
+	candidates := []batchablePrompt{
+		{step: "action_items", build: a.actionItemsPrompt},
+		{step: "topics", build: a.topicsPrompt},
+		{step: "sentiment_keywords", build: a.sentimentKeywordsPrompt},
+	}
+	// Summary and key points use grounded calls when the provider supports them, which can't be batched
+	if _, grounded := a.llmProvider.(llm.GroundingCapableProvider); !grounded {
+		candidates = append([]batchablePrompt{
+			{step: "summary", build: a.summaryPrompt},
+			{step: "key_points", build: a.keyPointsPrompt},
+		}, candidates...)
+	}
+
+	var requests []llm.PromptRequest
+	var timeout time.Duration
+	for _, candidate := range candidates {
+		// Steps with a provider of their own call it individually
+		if _, ok := a.stepProviders[candidate.step]; ok {
+			continue
+		}
+		if prompt, transcript := candidate.build(); len(transcript) > 0 {
+			requests = append(requests, llm.PromptRequest{Prompt: prompt})
+			timeout += a.stepTimeout(candidate.step)
+		}
+	}
+	if len(requests) < 2 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	started := time.Now()
+	responses, err := callWithContext(ctx, func() ([]llm.PromptResponse, error) {
+		return provider.CallBatch(requests)
+	})
+	if err != nil {
+		logrus.Warnf("Agent %s: Batch call failed after %dms, running analysis steps individually: %v",
+			a.agentID, time.Since(started).Milliseconds(), err)
+		return
+	}
+
+	batched := make(map[string]string, len(responses))
+	for i, response := range responses {
+		if i < len(requests) && response.Err == nil {
+			batched[requests[i].Prompt] = response.Text
+		}
+	}
+
+	a.batchMutex.Lock()
+	a.batchedResponses = batched
+	a.batchMutex.Unlock()
+
+	logrus.Infof("Agent %s: Batched %d of %d analysis prompts in %dms",
+		a.agentID, len(batched), len(requests), time.Since(started).Milliseconds())
+}
+
+// takeBatchedResponse returns and forgets the batched response to prompt, if there is one
+func (a *AnalystAgent) takeBatchedResponse(prompt string) (string, bool) {
+	a.batchMutex.Lock()
+	defer a.batchMutex.Unlock()
+
+	response, ok := a.batchedResponses[prompt]
+	if ok {
+		delete(a.batchedResponses, prompt)
+	}
+	return response, ok
+}
+
+// clearBatchedResponses drops batched responses no step used, returning how many there were
+func (a *AnalystAgent) clearBatchedResponses() int {
+	a.batchMutex.Lock()
+	defer a.batchMutex.Unlock()
+
+	unused := len(a.batchedResponses)
+	a.batchedResponses = nil
+	return unused
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// batchingMock is a mock provider that answers batches with respond, recording the requests
+type batchingMock struct {
+	*llm.MockLLMProvider
+	requests []llm.PromptRequest
+	respond  func(requests []llm.PromptRequest) ([]llm.PromptResponse, error)
+}
+
+func (m *batchingMock) CallBatch(requests []llm.PromptRequest) ([]llm.PromptResponse, error) {
+	m.requests = requests
+	return m.respond(requests)
+}
+
+// newBatchingAnalyst creates an analyst with batch calls enabled and a short transcript
+func newBatchingAnalyst(t *testing.T, respond func([]llm.PromptRequest) ([]llm.PromptResponse, error)) (*AnalystAgent, *batchingMock) {
+	t.Helper()
+	analyst := newTestAnalyst(t)
+	provider := &batchingMock{MockLLMProvider: llm.NewMockLLMProvider(), respond: respond}
+	analyst.llmProvider = provider
+	analyst.config.EnableBatchCalls = true
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+	say(analyst, 30, "Bob", "I will email the customer today.")
+	return analyst, provider
+}
+
+func TestPrefetchBatchResponses(t *testing.T) {
+	analyst, provider := newBatchingAnalyst(t, func(requests []llm.PromptRequest) ([]llm.PromptResponse, error) {
+		responses := make([]llm.PromptResponse, len(requests))
+		for i := range responses {
+			responses[i].Text = "response"
+		}
+		responses[2].Err = errors.New("request failed")
+		return responses, nil
+	})
+
+	analyst.prefetchBatchResponses()
+
+	// Summary, key points, action items, topics and sentiment are batched
+	if len(provider.requests) != 5 {
+		t.Fatalf("batched %d prompts, want 5", len(provider.requests))
+	}
+	summary, _ := analyst.summaryPrompt()
+	if response, ok := analyst.takeBatchedResponse(summary); !ok || response != "response" {
+		t.Errorf("takeBatchedResponse(summary) = %q, %v", response, ok)
+	}
+	if _, ok := analyst.takeBatchedResponse(summary); ok {
+		t.Error("batched response returned twice")
+	}
+	// The failed action items request is left for the step to make itself
+	actionItems, _ := analyst.actionItemsPrompt()
+	if _, ok := analyst.takeBatchedResponse(actionItems); ok {
+		t.Error("failed batch request returned a response")
+	}
+	if unused := analyst.clearBatchedResponses(); unused != 3 {
+		t.Errorf("clearBatchedResponses() = %d, want 3", unused)
+	}
+}
+
+func TestPrefetchBatchResponsesSkipsStepProviders(t *testing.T) {
+	analyst, provider := newBatchingAnalyst(t, func(requests []llm.PromptRequest) ([]llm.PromptResponse, error) {
+		return make([]llm.PromptResponse, len(requests)), nil
+	})
+	analyst.stepProviders = map[string]llm.LLMProvider{"summary": llm.NewMockLLMProvider()}
+
+	analyst.prefetchBatchResponses()
+
+	summary, _ := analyst.summaryPrompt()
+	for _, request := range provider.requests {
+		if request.Prompt == summary {
+			t.Error("summary batched despite having its own provider")
+		}
+	}
+	if len(provider.requests) != 4 {
+		t.Errorf("batched %d prompts, want 4", len(provider.requests))
+	}
+}
+
+func TestPrefetchBatchResponsesFallsBackOnError(t *testing.T) {
+	analyst, provider := newBatchingAnalyst(t, func([]llm.PromptRequest) ([]llm.PromptResponse, error) {
+		return nil, errors.New("batch unavailable")
+	})
+
+	analyst.prefetchBatchResponses()
+	if len(provider.requests) == 0 {
+		t.Fatal("no batch sent")
+	}
+	if unused := analyst.clearBatchedResponses(); unused != 0 {
+		t.Errorf("%d responses stored after a failed batch", unused)
+	}
+}
+
+func TestPrefetchBatchResponsesDisabled(t *testing.T) {
+	analyst, provider := newBatchingAnalyst(t, func([]llm.PromptRequest) ([]llm.PromptResponse, error) {
+		t.Error("batch sent")
+		return nil, nil
+	})
+	analyst.config.EnableBatchCalls = false
+	analyst.prefetchBatchResponses()
+
+	analyst.config.EnableBatchCalls = true
+	instructions := "Focus on owners"
+	analyst.config.CustomPrompt = &instructions
+	analyst.prefetchBatchResponses()
+
+	if provider.requests != nil {
+		t.Errorf("batched %d prompts", len(provider.requests))
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/migration"
+)
+
+const (
+	// benchmarkHistory is how far back past meetings are compared against
+	benchmarkHistory = 90 * 24 * time.Hour
+	// benchmarkCacheTTL is how long loaded historical meeting statistics are reused
+	benchmarkCacheTTL = time.Hour
+)
+
+// MeetingBenchmark compares a meeting with the tenant's meetings from the last 90 days. The VsAvg fields
+// are the difference from the historical average, so positive values are above average.
+type MeetingBenchmark struct {
+	PercentileRank         float64 `json:"percentile_rank"`            // 0-100, share of past meetings with a lower action item density
+	ActionItemDensityVsAvg float64 `json:"action_item_density_vs_avg"` // Action items per hour
+	SentimentVsAvg         float64 `json:"sentiment_vs_avg"`           // Sentiment score from -1 to 1
+	DurationVsAvg          float64 `json:"duration_vs_avg"`            // Minutes
+	MeetingsCompared       int     `json:"meetings_compared"`
+}
+
+// meetingStats are the statistics a meeting is benchmarked on
+type meetingStats struct {
+	key               string // Identifies the meeting across copies of its analysis file
+	actionItemDensity float64
+	sentiment         float64
+	durationMinutes   float64
+}
+
+// tenantHistory is the cached statistics of a tenant's past meetings
+type tenantHistory struct {
+	meetings []meetingStats
+	loadedAt time.Time
+}
+
+// BenchmarkCache holds historical meeting statistics per tenant, reloading them once they are older than ttl
+type BenchmarkCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	tenants map[string]*tenantHistory
+}
+
+// NewBenchmarkCache creates a cache that reloads each tenant's history after ttl
+func NewBenchmarkCache(ttl time.Duration) *BenchmarkCache {
+	return &BenchmarkCache{
+		ttl:     ttl,
+		tenants: make(map[string]*tenantHistory),
+	}
+}
+
+// defaultBenchmarkCache is shared by all analyst agents in the process
+var defaultBenchmarkCache = NewBenchmarkCache(benchmarkCacheTTL)
+
+// history returns the tenant's meeting statistics from the analysis files in dir, loading them if the
+// cached copy is missing or stale
+func (c *BenchmarkCache) history(dir, tenantID string) []meetingStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := dir + "|" + tenantID
+	if cached, ok := c.tenants[key]; ok && time.Since(cached.loadedAt) < c.ttl {
+		return cached.meetings
+	}
+
+	meetings := loadMeetingStats(dir, tenantID, time.Now().Add(-benchmarkHistory))
+	c.tenants[key] = &tenantHistory{meetings: meetings, loadedAt: time.Now()}
+	return meetings
+}
+
+// Invalidate drops all cached history so the next benchmark reloads it
+func (c *BenchmarkCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tenants = make(map[string]*tenantHistory)
+}
+
+// loadMeetingStats reads the statistics of the tenant's meetings in dir that started after since
+func loadMeetingStats(dir, tenantID string, since time.Time) []meetingStats {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		logrus.Warnf("Failed to list analysis files for benchmarking: %v", err)
+		return nil
+	}
+
+	var meetings []meetingStats
+	seen := make(map[string]bool)
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			logrus.Debugf("Skipping %s for benchmarking: %v", file, err)
+			continue
+		}
+		data, err := migration.MigrateAnalysisData[AnalysisData](raw)
+		if err != nil {
+			logrus.Debugf("Skipping %s for benchmarking: %v", file, err)
+			continue
+		}
+
+		if data.TenantID != tenantID || data.StartTime.Before(since) || data.DurationMinutes <= 0 {
+			continue
+		}
+
+		// Batch re-analysis writes extra copies of a meeting, only count it once
+		stats := statsFor(data)
+		if seen[stats.key] {
+			continue
+		}
+		seen[stats.key] = true
+		meetings = append(meetings, stats)
+	}
+	return meetings
+}
+
+// statsFor computes the benchmark statistics of a meeting
+func statsFor(data *AnalysisData) meetingStats {
+	stats := meetingStats{
+		key:             data.MeetingID + "|" + data.StartTime.UTC().Format(time.RFC3339Nano),
+		durationMinutes: data.DurationMinutes,
+	}
+	if data.DurationMinutes > 0 {
+		stats.actionItemDensity = float64(len(data.ActionItems)) / (data.DurationMinutes / 60)
+	}
+
+	if len(data.SentimentTimeline) > 0 {
+		var total float64
+		for _, point := range data.SentimentTimeline {
+			total += point.Score
+		}
+		stats.sentiment = total / float64(len(data.SentimentTimeline))
+	} else {
+		stats.sentiment = sentimentLabelScore(data.Sentiment)
+	}
+	return stats
+}
+
+// sentimentLabelScore maps an overall sentiment label onto the -1 to 1 score scale
+func sentimentLabelScore(label string) float64 {
+	switch strings.ToLower(label) {
+	case "positive":
+		return 0.5
+	case "negative":
+		return -0.5
+	default:
+		return 0
+	}
+}
+
+// percentileRank returns the percentage of values below value, counting ties as half
+func percentileRank(value float64, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var below, equal int
+	for _, v := range values {
+		switch {
+		case v < value:
+			below++
+		case v == value:
+			equal++
+		}
+	}
+	return (float64(below) + float64(equal)/2) / float64(len(values)) * 100
+}
+
+// benchmarkMeeting compares the meeting with history, or returns nil when there is nothing to compare with
+func benchmarkMeeting(current meetingStats, history []meetingStats) *MeetingBenchmark {
+	var densities []float64
+	var density, sentiment, duration float64
+	for _, meeting := range history {
+		if meeting.key == current.key {
+			continue
+		}
+		densities = append(densities, meeting.actionItemDensity)
+		density += meeting.actionItemDensity
+		sentiment += meeting.sentiment
+		duration += meeting.durationMinutes
+	}
+	if len(densities) == 0 {
+		return nil
+	}
+
+	count := float64(len(densities))
+	return &MeetingBenchmark{
+		PercentileRank:         percentileRank(current.actionItemDensity, densities),
+		ActionItemDensityVsAvg: current.actionItemDensity - density/count,
+		SentimentVsAvg:         current.sentiment - sentiment/count,
+		DurationVsAvg:          current.durationMinutes - duration/count,
+		MeetingsCompared:       len(densities),
+	}
+}
+
+// updateMeetingScore benchmarks the meeting against the tenant's past meetings
+func (a *AnalystAgent) updateMeetingScore() {
+	history := defaultBenchmarkCache.history(filepath.Dir(a.filePath), a.config.TenantID)
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	a.data.MeetingScore = benchmarkMeeting(statsFor(a.data), history)
+}
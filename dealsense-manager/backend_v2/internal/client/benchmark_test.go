@@ -0,0 +1,94 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentileRank(t *testing.T) {
+	values := []float64{1, 2, 2, 4}
+	cases := map[float64]float64{0: 0, 2: 50, 3: 75, 5: 100}
+	for value, want := range cases {
+		if got := percentileRank(value, values); got != want {
+			t.Errorf("percentileRank(%v) = %v, want %v", value, got, want)
+		}
+	}
+	if got := percentileRank(1, nil); got != 0 {
+		t.Errorf("percentileRank() without history = %v, want 0", got)
+	}
+}
+
+func TestBenchmarkMeeting(t *testing.T) {
+	current := meetingStats{key: "current", actionItemDensity: 6, sentiment: 0.5, durationMinutes: 30}
+	history := []meetingStats{
+		current, // The meeting's own saved analysis isn't compared with
+		{key: "a", actionItemDensity: 2, sentiment: 0, durationMinutes: 60},
+		{key: "b", actionItemDensity: 4, sentiment: -0.2, durationMinutes: 40},
+	}
+
+	benchmark := benchmarkMeeting(current, history)
+	if benchmark == nil {
+		t.Fatal("benchmarkMeeting() = nil")
+	}
+	want := MeetingBenchmark{PercentileRank: 100, ActionItemDensityVsAvg: 3, SentimentVsAvg: 0.6, DurationVsAvg: -20, MeetingsCompared: 2}
+	if benchmark.PercentileRank != want.PercentileRank || !approx(benchmark.ActionItemDensityVsAvg, want.ActionItemDensityVsAvg) ||
+		!approx(benchmark.SentimentVsAvg, want.SentimentVsAvg) || !approx(benchmark.DurationVsAvg, want.DurationVsAvg) ||
+		benchmark.MeetingsCompared != want.MeetingsCompared {
+		t.Errorf("benchmarkMeeting() = %+v, want %+v", *benchmark, want)
+	}
+
+	if got := benchmarkMeeting(current, []meetingStats{current}); got != nil {
+		t.Errorf("benchmarkMeeting() without other meetings = %+v, want nil", got)
+	}
+}
+
+func TestStatsFor(t *testing.T) {
+	data := &AnalysisData{
+		MeetingID:       "m1",
+		DurationMinutes: 30,
+		ActionItems:     []ActionItem{{Description: "One"}, {Description: "Two"}},
+		Sentiment:       "negative",
+	}
+	stats := statsFor(data)
+	if stats.actionItemDensity != 4 || stats.sentiment != -0.5 {
+		t.Errorf("statsFor() = %+v, want 4 items an hour and the label's score", stats)
+	}
+
+	// The sentiment timeline is preferred over the overall label
+	data.SentimentTimeline = []SentimentPoint{{Score: 0.2}, {Score: 0.6}}
+	if stats := statsFor(data); !approx(stats.sentiment, 0.4) {
+		t.Errorf("sentiment = %v, want the timeline average", stats.sentiment)
+	}
+}
+
+func TestLoadMeetingStats(t *testing.T) {
+	newTestAnalyst(t)
+	now := time.Now()
+	writePriorMeeting(t, "recent.json", AnalysisData{MeetingID: "recent", TenantID: "acme", StartTime: now.Add(-24 * time.Hour), DurationMinutes: 30})
+	writePriorMeeting(t, "recent-batch.json", AnalysisData{MeetingID: "recent", TenantID: "acme", StartTime: now.Add(-24 * time.Hour), DurationMinutes: 30})
+	writePriorMeeting(t, "old.json", AnalysisData{MeetingID: "old", TenantID: "acme", StartTime: now.Add(-100 * 24 * time.Hour), DurationMinutes: 30})
+	writePriorMeeting(t, "other.json", AnalysisData{MeetingID: "other", TenantID: "globex", StartTime: now, DurationMinutes: 30})
+	writePriorMeeting(t, "empty.json", AnalysisData{MeetingID: "empty", TenantID: "acme", StartTime: now})
+
+	stats := loadMeetingStats(AnalysisDataDir, "acme", now.Add(-benchmarkHistory))
+	if len(stats) != 1 || stats[0].durationMinutes != 30 {
+		t.Errorf("loadMeetingStats() = %+v, want the recent meeting once", stats)
+	}
+}
+
+func TestBenchmarkCacheReloadsWhenInvalidated(t *testing.T) {
+	newTestAnalyst(t)
+	cache := NewBenchmarkCache(time.Hour)
+	if history := cache.history(AnalysisDataDir, "acme"); len(history) != 0 {
+		t.Fatalf("history = %+v, want none", history)
+	}
+
+	writePriorMeeting(t, "new.json", AnalysisData{MeetingID: "new", TenantID: "acme", StartTime: time.Now(), DurationMinutes: 15})
+	if history := cache.history(AnalysisDataDir, "acme"); len(history) != 0 {
+		t.Errorf("history = %+v, want the cached empty history", history)
+	}
+	cache.Invalidate()
+	if history := cache.history(AnalysisDataDir, "acme"); len(history) != 1 {
+		t.Errorf("history = %+v, want the new meeting after invalidating", history)
+	}
+}
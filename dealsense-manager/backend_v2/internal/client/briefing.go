@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/models"
+)
+
+const (
+	// briefingCacheTTL is how long a generated briefing is reused for the same agenda, participants and
+	// prior meetings
+	briefingCacheTTL = 30 * time.Minute
+	// briefingTimeout bounds generating a briefing
+	briefingTimeout = 2 * time.Minute
+	// maxBriefingTopics is the number of topics of each prior meeting given to the LLM
+	maxBriefingTopics = 8
+)
+
+// ErrNothingToBrief is returned when a briefing is requested without an agenda, participants or prior
+// meetings that could be loaded
+var ErrNothingToBrief = errors.New("no agenda, participants or prior meetings to brief on")
+
+// cachedBriefing is a generated briefing and when it was generated
+type cachedBriefing struct {
+	text        string
+	generatedAt time.Time
+}
+
+// briefingCache holds generated briefings keyed by their request, shared by all agents
+var briefingCache = struct {
+	sync.Mutex
+	briefings map[string]cachedBriefing
+}{briefings: make(map[string]cachedBriefing)}
+
+// GenerateBriefing writes a pre-meeting briefing in markdown for an upcoming meeting with the given agenda
+// and participants, from what was discussed in the prior meetings: their open action items, their recent
+// topics relevant to the agenda, and suggested talking points. Prior meetings are loaded from the analysis
+// files, and only those of the agent's tenant are used; unknown IDs are skipped. Briefings are cached for
+// 30 minutes.
+func (a *AnalystAgent) GenerateBriefing(ctx context.Context, agenda []models.AgendaItem, participants []string, priorMeetingIDs []string) (string, error) {
+	a.dataMutex.RLock()
+	tenantID := a.data.TenantID
+	a.dataMutex.RUnlock()
+
+	key := briefingKey(a.agentID, tenantID, agenda, participants, priorMeetingIDs)
+	briefingCache.Lock()
+	cached, ok := briefingCache.briefings[key]
+	briefingCache.Unlock()
+	if ok && time.Since(cached.generatedAt) < briefingCacheTTL {
+		return cached.text, nil
+	}
+
+	priorMeetings := loadPriorMeetings(AnalysisDataDir, tenantID, priorMeetingIDs)
+	if len(agenda) == 0 && len(participants) == 0 && len(priorMeetings) == 0 {
+		return "", ErrNothingToBrief
+	}
+
+	logrus.Infof("Agent %s: Generating a pre-meeting briefing from %d of %d prior meetings",
+		a.agentID, len(priorMeetings), len(priorMeetingIDs))
+
+	ctx, cancel := context.WithTimeout(ctx, briefingTimeout)
+	defer cancel()
+
+	briefing, err := a.callLLM(ctx, a.briefingPrompt(agenda, participants, priorMeetings))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate briefing: %w", err)
+	}
+	briefing = strings.TrimSpace(briefing)
+
+	briefingCache.Lock()
+	for cachedKey, entry := range briefingCache.briefings {
+		if time.Since(entry.generatedAt) >= briefingCacheTTL {
+			delete(briefingCache.briefings, cachedKey)
+		}
+	}
+	briefingCache.briefings[key] = cachedBriefing{text: briefing, generatedAt: time.Now()}
+	briefingCache.Unlock()
+
+	return briefing, nil
+}
+
+// briefingPrompt asks for a briefing on the agenda and participants, with the summary, open action items
+// and topics of each prior meeting as context
+func (a *AnalystAgent) briefingPrompt(agenda []models.AgendaItem, participants []string, priorMeetings []*AnalysisData) string {
+	var history strings.Builder
+	for _, meeting := range priorMeetings {
+		history.WriteString(fmt.Sprintf("Meeting %s on %s:\n", meeting.MeetingID, meeting.StartTime.Format("2006-01-02")))
+		if summary := meeting.ExecutiveSummary; summary != "" {
+			history.WriteString(fmt.Sprintf("Summary: %s\n", summary))
+		} else if meeting.Summary != "" {
+			history.WriteString(fmt.Sprintf("Summary: %s\n", meeting.Summary))
+		}
+
+		var open []string
+		for _, item := range meeting.ActionItems {
+			if item.Status == "completed" {
+				continue
+			}
+			line := "- " + item.Description
+			if item.Assignee != "" {
+				line += fmt.Sprintf(" (%s)", item.Assignee)
+			}
+			if item.DueDate != "" {
+				line += fmt.Sprintf(", due %s", item.DueDate)
+			}
+			open = append(open, line)
+		}
+		if len(open) > 0 {
+			history.WriteString("Open action items:\n" + strings.Join(open, "\n") + "\n")
+		}
+
+		var topics []string
+		for i, topic := range meeting.Topics {
+			if i == maxBriefingTopics {
+				break
+			}
+			topics = append(topics, fmt.Sprintf("- %s: %s", topic.Topic, topic.Summary))
+		}
+		if len(topics) > 0 {
+			history.WriteString("Topics:\n" + strings.Join(topics, "\n") + "\n")
+		}
+		history.WriteString("\n")
+	}
+	if history.Len() == 0 {
+		history.WriteString("No prior meetings are known.\n")
+	}
+
+	items := make([]string, len(agenda))
+	for i, item := range agenda {
+		items[i] = fmt.Sprintf("%d. %s", i+1, item.Title)
+		if item.DurationMinutes > 0 {
+			items[i] += fmt.Sprintf(" (%d min)", item.DurationMinutes)
+		}
+	}
+	agendaText := strings.Join(items, "\n")
+	if agendaText == "" {
+		agendaText = "No agenda was set."
+	}
+	participantsText := strings.Join(participants, ", ")
+	if participantsText == "" {
+		participantsText = "Not known."
+	}
+
+	return a.languagePrefix() + fmt.Sprintf(`Write a pre-meeting briefing for the participants of an upcoming meeting, based on what was discussed in their prior meetings.
+
+Cover, in markdown with a heading for each:
+1. Open action items: the items from prior meetings that aren't completed, with who owns them, highlighting those of the upcoming meeting's participants
+2. Recent relevant topics: what prior meetings discussed about the agenda items, and what was decided
+3. Suggested talking points: questions to settle and follow-ups to raise in the upcoming meeting
+
+Only use the information below and don't invent past discussions. Keep the briefing short enough to read in two minutes.
+
+Agenda of the upcoming meeting:
+%s
+
+Participants: %s
+
+Prior meetings:
+%s`, agendaText, participantsText, history.String())
+}
+
+// loadPriorMeetings reads the analyses of the tenant's meetings with the given IDs from the analysis files
+// in dir, in the order of the IDs. Meetings with several analysis files, such as batch re-analyses, use the
+// most recently updated one.
+func loadPriorMeetings(dir, tenantID string, meetingIDs []string) []*AnalysisData {
+	if len(meetingIDs) == 0 {
+		return nil
+	}
+
+	wanted := make(map[string]bool, len(meetingIDs))
+	for _, id := range meetingIDs {
+		wanted[id] = true
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		logrus.Warnf("Failed to list analysis files for briefing: %v", err)
+		return nil
+	}
+
+	latest := make(map[string]*AnalysisData)
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			logrus.Debugf("Skipping %s for briefing: %v", file, err)
+			continue
+		}
+		data, err := migration.MigrateAnalysisData[AnalysisData](raw)
+		if err != nil {
+			logrus.Debugf("Skipping %s for briefing: %v", file, err)
+			continue
+		}
+		if !wanted[data.MeetingID] || data.TenantID != tenantID {
+			continue
+		}
+		if previous, ok := latest[data.MeetingID]; !ok || data.LastUpdated.After(previous.LastUpdated) {
+			latest[data.MeetingID] = data
+		}
+	}
+
+	meetings := make([]*AnalysisData, 0, len(latest))
+	seen := make(map[string]bool)
+	for _, id := range meetingIDs {
+		if data, ok := latest[id]; ok && !seen[id] {
+			seen[id] = true
+			meetings = append(meetings, data)
+		}
+	}
+	return meetings
+}
+
+// briefingKey identifies a briefing request for caching, participants and prior meetings compared
+// regardless of their order
+func briefingKey(agentID, tenantID string, agenda []models.AgendaItem, participants, priorMeetingIDs []string) string {
+	sortedParticipants := append([]string(nil), participants...)
+	sort.Strings(sortedParticipants)
+	sortedIDs := append([]string(nil), priorMeetingIDs...)
+	sort.Strings(sortedIDs)
+
+	raw, _ := json.Marshal(struct {
+		AgentID         string              `json:"agent_id"`
+		TenantID        string              `json:"tenant_id"`
+		Agenda          []models.AgendaItem `json:"agenda"`
+		Participants    []string            `json:"participants"`
+		PriorMeetingIDs []string            `json:"prior_meeting_ids"`
+	}{agentID, tenantID, agenda, sortedParticipants, sortedIDs})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+func TestGenerateBriefingUsesPriorMeetings(t *testing.T) {
+	// Briefings are cached across agents, so start without any left by an earlier run
+	briefingCache.Lock()
+	clear(briefingCache.briefings)
+	briefingCache.Unlock()
+
+	analyst := newTestAnalyst(t)
+	writePriorMeeting(t, "kickoff-old.json", AnalysisData{
+		MeetingID: "kickoff", StartTime: testMeetingStart, LastUpdated: testMeetingStart,
+		Summary: "Outdated summary",
+	})
+	writePriorMeeting(t, "kickoff-new.json", AnalysisData{
+		MeetingID: "kickoff", StartTime: testMeetingStart, LastUpdated: testMeetingStart.Add(time.Hour),
+		Summary: "Agreed on the migration plan",
+		ActionItems: []ActionItem{
+			{Description: "Draft the rollout checklist", Assignee: "Alice", DueDate: "2024-05-10", Status: "pending"},
+			{Description: "Book the venue", Status: "completed"},
+		},
+		Topics: []TopicDiscussion{{Topic: "Data migration", Summary: "Move tenants in batches"}},
+	})
+	writePriorMeeting(t, "other-tenant.json", AnalysisData{MeetingID: "secret", TenantID: "acme", Summary: "Other tenant's plans"})
+
+	mock := llm.NewMockLLMProvider("## Open action items\n- Draft the rollout checklist (Alice)")
+	analyst.llmProvider = mock
+	agenda := []models.AgendaItem{{Title: "Migration status", DurationMinutes: 15}}
+
+	briefing, err := analyst.GenerateBriefing(context.Background(), agenda, []string{"Alice", "Bob"}, []string{"kickoff", "secret", "unknown"})
+	if err != nil {
+		t.Fatalf("GenerateBriefing() error = %v", err)
+	}
+	if !strings.HasPrefix(briefing, "## Open action items") {
+		t.Errorf("briefing = %q", briefing)
+	}
+
+	prompt := mock.Prompts()[0]
+	for _, want := range []string{
+		"1. Migration status (15 min)",
+		"Participants: Alice, Bob",
+		"Summary: Agreed on the migration plan",
+		"- Draft the rollout checklist (Alice), due 2024-05-10",
+		"- Data migration: Move tenants in batches",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Outdated summary", "Book the venue", "Other tenant's plans"} {
+		if strings.Contains(prompt, unwanted) {
+			t.Errorf("prompt contains %q", unwanted)
+		}
+	}
+
+	// The same request in another order is served from the cache, without another LLM call
+	if cached, err := analyst.GenerateBriefing(context.Background(), agenda, []string{"Bob", "Alice"}, []string{"unknown", "kickoff", "secret"}); err != nil || cached != briefing {
+		t.Errorf("cached GenerateBriefing() = %q, %v", cached, err)
+	}
+	if len(mock.Prompts()) != 1 {
+		t.Errorf("LLM called %d times, want once", len(mock.Prompts()))
+	}
+}
+
+func TestGenerateBriefingNeedsSomethingToBrief(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	if _, err := analyst.GenerateBriefing(context.Background(), nil, nil, []string{"unknown"}); !errors.Is(err, ErrNothingToBrief) {
+		t.Errorf("GenerateBriefing() error = %v, want ErrNothingToBrief", err)
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// SetCostEstimator sets the estimator that projects each analysis's LLM cost against the daily budget.
+// With hardStop, analyses that would exceed the budget are not run and their steps are queued for retry.
+func (a *AnalystAgent) SetCostEstimator(estimator *llm.PromptCostEstimator, hardStop bool) {
+	a.costEstimator = estimator
+	a.hardBudgetStop = hardStop
+}
+
+// checkAnalysisBudget estimates what the core analysis prompts over the transcript snapshot will cost and
+// logs the total. It returns an error, after queuing every step for retry, when the analysis would exceed
+// the daily budget and the budget is a hard stop; otherwise the estimate is counted towards today's spend.
+func (a *AnalystAgent) checkAnalysisBudget(transcriptSnapshot []TranscriptEntry) error {
+	if a.costEstimator == nil {
+		return nil
+	}
+
+	a.currentAnalysisSnapshot = transcriptSnapshot
+	prompts := []func() (string, []TranscriptEntry){
+		a.summaryPrompt, a.keyPointsPrompt, a.actionItemsPrompt, a.topicsPrompt, a.sentimentKeywordsPrompt,
+	}
+	var total llm.CostEstimate
+	for _, build := range prompts {
+		prompt, _ := build()
+		estimate, err := a.costEstimator.EstimateCallCost(a.withSystemPrefix(prompt), a.config.LLMModel)
+		if err != nil {
+			a.currentAnalysisSnapshot = nil
+			logrus.Debugf("Agent %s: Skipping the analysis cost estimate: %v", a.agentID, err)
+			return nil
+		}
+		total.InputTokens += estimate.InputTokens
+		total.EstimatedOutputTokens += estimate.EstimatedOutputTokens
+		total.EstimatedCostUSD += estimate.EstimatedCostUSD
+	}
+	a.currentAnalysisSnapshot = nil
+	total.WillExceedDailyBudget = a.costEstimator.WouldExceedDailyBudget(total.EstimatedCostUSD)
+
+	logrus.WithFields(logrus.Fields{
+		"agent_id":                 a.agentID,
+		"input_tokens":             total.InputTokens,
+		"estimated_output_tokens":  total.EstimatedOutputTokens,
+		"estimated_cost_usd":       total.EstimatedCostUSD,
+		"will_exceed_daily_budget": total.WillExceedDailyBudget,
+	}).Infof("Agent %s: Estimated analysis cost $%.4f", a.agentID, total.EstimatedCostUSD)
+
+	if total.WillExceedDailyBudget && a.hardBudgetStop {
+		err := fmt.Errorf("analysis would exceed the daily LLM budget (estimated $%.4f, $%.4f spent today)",
+			total.EstimatedCostUSD, a.costEstimator.SpentToday())
+		for _, step := range a.analysisSteps() {
+			a.deadLetter(step.name, transcriptSnapshot, err)
+		}
+		return err
+	}
+
+	a.costEstimator.RecordSpend(total.EstimatedCostUSD)
+	return nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// millionTokens counts every prompt as a million tokens
+func millionTokens(model, prompt string) (int, error) {
+	return 1_000_000, nil
+}
+
+func TestAnalysisBudgetHardStop(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.LLMModel = "gemini-2.5-flash"
+	queue := NewDeadLetterQueue(DefaultDLQCapacity, DefaultMaxDLQRetries)
+	analyst.SetDeadLetterQueue(queue)
+	// Each of the five prompts costs $1.55, far over a $1 budget
+	analyst.SetCostEstimator(llm.NewPromptCostEstimator(1, 0.5).WithTokenCounter(millionTokens), true)
+	snapshot := []TranscriptEntry{entryAt(0, "Alice", "Let's review the rollout plan.")}
+
+	err := analyst.checkAnalysisBudget(snapshot)
+	if err == nil || !strings.Contains(err.Error(), "daily LLM budget") {
+		t.Fatalf("checkAnalysisBudget() = %v, want a budget error", err)
+	}
+	if queued := queue.List(); len(queued) != len(analyst.analysisSteps()) {
+		t.Errorf("queued %d steps for retry, want every analysis step", len(queued))
+	}
+	if spent := analyst.costEstimator.SpentToday(); spent != 0 {
+		t.Errorf("spent $%.2f on an analysis that didn't run", spent)
+	}
+	if analyst.currentAnalysisSnapshot != nil {
+		t.Error("snapshot left set after estimating")
+	}
+}
+
+func TestAnalysisBudgetSoftLimitRecordsSpend(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.LLMModel = "gemini-2.5-flash"
+	analyst.SetCostEstimator(llm.NewPromptCostEstimator(1, 0.5).WithTokenCounter(millionTokens), false)
+
+	if err := analyst.checkAnalysisBudget([]TranscriptEntry{entryAt(0, "Alice", "Let's review the rollout plan.")}); err != nil {
+		t.Fatalf("checkAnalysisBudget() = %v, want analyses over a soft budget to run", err)
+	}
+	if spent := analyst.costEstimator.SpentToday(); !approx(spent, 7.75) {
+		t.Errorf("spent $%.2f, want the five prompts' $7.75 recorded", spent)
+	}
+}
+
+func TestAnalysisBudgetWithoutPricing(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.LLMModel = "unpriced-model"
+	analyst.SetCostEstimator(llm.NewPromptCostEstimator(0.01, 0.5).WithTokenCounter(millionTokens), true)
+
+	// Models without pricing can't be estimated, so the analysis runs
+	if err := analyst.checkAnalysisBudget([]TranscriptEntry{entryAt(0, "Alice", "Let's review the rollout plan.")}); err != nil {
+		t.Errorf("checkAnalysisBudget() = %v, want nil without pricing", err)
+	}
+}
@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	// budgetTranscript is the number of recent transcript entries budget discussions are extracted from
+	budgetTranscript = 50
+	// budgetOnTrackTolerance is the variance, in percent of the budget, within which spending is on track
+	budgetOnTrackTolerance = 5.0
+	// budgetLargeFigure is the amount from which budget and actual figures are checked with a web search
+	budgetLargeFigure = 1e6
+)
+
+// Status of actual spending against its budget
+const (
+	BudgetOver    = "over"
+	BudgetUnder   = "under"
+	BudgetOnTrack = "on_track"
+)
+
+// BudgetItem is a budget category whose budgeted and actual amounts were compared in the meeting
+type BudgetItem struct {
+	Category     string  `json:"category"`
+	BudgetAmount float64 `json:"budget_amount"`
+	ActualAmount float64 `json:"actual_amount"`
+	Variance     float64 `json:"variance"`     // Actual minus budget; positive when over budget
+	VariancePct  float64 `json:"variance_pct"` // Variance in percent of the budget
+	Status       string  `json:"status"`       // over, under, on_track
+	Currency     string  `json:"currency,omitempty"`
+	MentionedBy  string  `json:"mentioned_by,omitempty"`
+	Verified     bool    `json:"verified"` // Large figures were confirmed by a web search
+}
+
+// budgetVariance returns how far actual spending is from its budget, in the currency and in percent of the
+// budget, and whether it is over, under or on track. Spending within budgetOnTrackTolerance percent of the
+// budget is on track; without a budget, any spending is over.
+func budgetVariance(budget, actual float64) (variance, variancePct float64, status string) {
+	variance = actual - budget
+	if budget != 0 {
+		variancePct = variance / math.Abs(budget) * 100
+	}
+
+	switch {
+	case budget == 0 && variance > 0:
+		status = BudgetOver
+	case budget == 0 || math.Abs(variancePct) <= budgetOnTrackTolerance:
+		status = BudgetOnTrack
+	case variance > 0:
+		status = BudgetOver
+	default:
+		status = BudgetUnder
+	}
+	return variance, variancePct, status
+}
+
+// extractBudgetDiscussions finds the budget categories whose planned and actual amounts were compared in
+// the recent transcript, computing each one's variance. A category discussed again replaces its earlier
+// figures. Figures of at least budgetLargeFigure are checked with a web search when the LLM supports
+// grounding.
+func (a *AnalystAgent) extractBudgetDiscussions(ctx context.Context) error {
+	transcript := a.getRecentTranscript(budgetTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting budget discussions from %d transcript entries", a.agentID, len(transcript))
+
+	text := a.formatTranscriptForLLM(transcript)
+	prompt := a.glossaryPrefix(text) + a.languagePrefix() + fmt.Sprintf(`Find every budget category in this meeting where a budgeted or planned amount was compared with the actual or forecast amount, such as "marketing came in at $540K against a $500K budget" or "we've only spent 60%% of the travel budget".
+
+Listen for financial figures and currency amounts, budget comparison language such as "budget", "plan", "forecast", "actuals", "spend", "run rate" and "year to date", and variance language such as "over budget", "under budget", "overspent", "underspent", "ahead of plan", "behind plan" and "variance".
+
+For each category, give:
+- The budget category, such as a department, project or cost type
+- The budgeted and the actual amount, copied exactly as said including currency symbols and scale words (for example "$500K", "1.2 million", "€80,000"); work out an amount stated only relative to the other, such as "10%% over a $200K budget"
+- The ISO currency code, if known
+- The participant who stated the figures
+
+Only report categories where both amounts were stated or can be worked out.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "budget_items": [
+    {
+      "category": "Marketing",
+      "budget_amount": "$500K",
+      "actual_amount": "$540K",
+      "currency": "USD",
+      "mentioned_by": "Participant name"
+    }
+  ]
+}
+`+"`"+``, text)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		BudgetItems []struct {
+			Category     string `json:"category"`
+			BudgetAmount string `json:"budget_amount"`
+			ActualAmount string `json:"actual_amount"`
+			Currency     string `json:"currency"`
+			MentionedBy  string `json:"mentioned_by"`
+		} `json:"budget_items"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse budget discussions JSON: %w", err)
+	}
+
+	var found []BudgetItem
+	for _, raw := range result.BudgetItems {
+		category := strings.TrimSpace(raw.Category)
+		budget, budgetUnit, budgetOK := parseMetricValue(raw.BudgetAmount)
+		actual, actualUnit, actualOK := parseMetricValue(raw.ActualAmount)
+		if category == "" || !budgetOK || !actualOK || budgetUnit == "%" || actualUnit == "%" {
+			continue
+		}
+
+		item := BudgetItem{
+			Category:     category,
+			BudgetAmount: budget,
+			ActualAmount: actual,
+			Currency:     strings.ToUpper(strings.TrimSpace(raw.Currency)),
+			MentionedBy:  strings.TrimSpace(raw.MentionedBy),
+		}
+		if item.Currency == "" {
+			item.Currency = budgetCurrency(budgetUnit, actualUnit)
+		}
+		item.Variance, item.VariancePct, item.Status = budgetVariance(budget, actual)
+		found = append(found, item)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		if err := a.verifyBudgetFigures(ctx, groundingProvider, found); err != nil {
+			logrus.Warnf("Agent %s: Failed to verify budget figures: %v", a.agentID, err)
+		}
+	}
+
+	a.dataMutex.Lock()
+	for _, item := range found {
+		mergeBudgetItem(&a.data.BudgetDiscussions, item)
+	}
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Extracted %d budget discussions", a.agentID, len(found))
+	return nil
+}
+
+// mergeBudgetItem adds a budget category to the discussed ones, or replaces the figures of the one with the
+// same category
+func mergeBudgetItem(discussed *[]BudgetItem, item BudgetItem) {
+	for i, existing := range *discussed {
+		if strings.EqualFold(existing.Category, item.Category) {
+			if item.MentionedBy == "" {
+				item.MentionedBy = existing.MentionedBy
+			}
+			(*discussed)[i] = item
+			return
+		}
+	}
+	*discussed = append(*discussed, item)
+}
+
+// budgetCurrency returns the ISO code of the currency symbol either amount was stated with, or "" when
+// neither had one
+func budgetCurrency(units ...string) string {
+	for _, unit := range units {
+		switch unit {
+		case "$":
+			return "USD"
+		case "€":
+			return "EUR"
+		case "£":
+			return "GBP"
+		case "¥":
+			return "JPY"
+		}
+	}
+	return ""
+}
+
+// verifyBudgetFigures asks a grounded model which budget categories with a figure of at least
+// budgetLargeFigure are confirmed by search results, setting Verified on those. Internal budgets that
+// can't be found publicly stay unverified.
+func (a *AnalystAgent) verifyBudgetFigures(ctx context.Context, provider llm.GroundingCapableProvider, items []BudgetItem) error {
+	var lines []string
+	numbers := make(map[int]int)
+	for i, item := range items {
+		if math.Abs(item.BudgetAmount) < budgetLargeFigure && math.Abs(item.ActualAmount) < budgetLargeFigure {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s: budget %s, actual %s %s", len(lines)+1, item.Category,
+			formatMetricNumber(item.BudgetAmount), formatMetricNumber(item.ActualAmount), item.Currency))
+		numbers[len(lines)] = i
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to check each of these budget figures mentioned in a meeting. A figure is verified only when search results confirm it; budgets of private or internal matters that can't be found are not verified.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "verified": [1, 3]
+}
+`+"`"+``, strings.Join(lines, "\n"))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil || response.GroundingMetadata == nil || len(response.GroundingMetadata.GroundingChunks) == 0 {
+		return fmt.Errorf("no search results to verify budget figures against")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in budget verification response")
+	}
+
+	var result struct {
+		Verified []int `json:"verified"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse budget verification JSON: %w", err)
+	}
+	for _, number := range result.Verified {
+		if i, ok := numbers[number]; ok {
+			items[i].Verified = true
+		}
+	}
+	return nil
+}
+
+// BudgetSummaryTable formats the budget discussions as a markdown table, marking spending over budget red,
+// under budget yellow and on track green, or returns "" when there are none
+func (d *AnalysisData) BudgetSummaryTable() string {
+	if len(d.BudgetDiscussions) == 0 {
+		return ""
+	}
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	var table strings.Builder
+	table.WriteString("| | Category | Budget | Actual | Variance | Mentioned by |\n|---|---|---|---|---|---|\n")
+	for _, item := range d.BudgetDiscussions {
+		indicator := "🟢"
+		switch item.Status {
+		case BudgetOver:
+			indicator = "🔴"
+		case BudgetUnder:
+			indicator = "🟡"
+		}
+
+		variance := formatMetricNumber(item.Variance)
+		if item.Variance > 0 {
+			variance = "+" + variance
+		}
+		if item.BudgetAmount != 0 {
+			variance += fmt.Sprintf(" (%+.1f%%)", item.VariancePct)
+		}
+
+		amount := func(value float64) string {
+			formatted := formatMetricNumber(value)
+			if item.Currency != "" {
+				formatted += " " + item.Currency
+			}
+			if item.Verified && math.Abs(value) >= budgetLargeFigure {
+				formatted += " ✓"
+			}
+			return formatted
+		}
+		table.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", indicator, cell.Replace(item.Category),
+			amount(item.BudgetAmount), amount(item.ActualAmount), variance, cell.Replace(item.MentionedBy)))
+	}
+	return table.String()
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestBudgetVariance(t *testing.T) {
+	cases := []struct {
+		budget, actual float64
+		variance, pct  float64
+		status         string
+	}{
+		{500000, 540000, 40000, 8, BudgetOver},
+		{200000, 150000, -50000, -25, BudgetUnder},
+		{100000, 104000, 4000, 4, BudgetOnTrack},
+		{100000, 95000, -5000, -5, BudgetOnTrack},
+		{0, 1000, 1000, 0, BudgetOver},
+		{0, 0, 0, 0, BudgetOnTrack},
+	}
+	for _, c := range cases {
+		variance, pct, status := budgetVariance(c.budget, c.actual)
+		if !approx(variance, c.variance) || !approx(pct, c.pct) || status != c.status {
+			t.Errorf("budgetVariance(%v, %v) = %v, %v, %q, want %v, %v, %q",
+				c.budget, c.actual, variance, pct, status, c.variance, c.pct, c.status)
+		}
+	}
+}
+
+func TestExtractBudgetDiscussions(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"budget_items": [
+		{"category": "Marketing", "budget_amount": "$500K", "actual_amount": "$540K", "mentioned_by": "Alice"},
+		{"category": "Travel", "budget_amount": "$50K", "actual_amount": "60%", "mentioned_by": "Bob"}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "Marketing came in at 540K against a 500K budget")
+
+	if err := analyst.extractBudgetDiscussions(context.Background()); err != nil {
+		t.Fatalf("extractBudgetDiscussions() error = %v", err)
+	}
+	// Percentages can't be compared with amounts, so Travel is dropped
+	items := analyst.GetAnalysis().BudgetDiscussions
+	if len(items) != 1 {
+		t.Fatalf("BudgetDiscussions = %+v, want Marketing only", items)
+	}
+	item := items[0]
+	if item.Category != "Marketing" || item.BudgetAmount != 500000 || item.ActualAmount != 540000 ||
+		item.Currency != "USD" || item.Status != BudgetOver || item.MentionedBy != "Alice" {
+		t.Errorf("budget item = %+v", item)
+	}
+}
+
+func TestMergeBudgetItemReplacesCategory(t *testing.T) {
+	discussed := []BudgetItem{{Category: "Marketing", ActualAmount: 540000, MentionedBy: "Alice"}}
+	mergeBudgetItem(&discussed, BudgetItem{Category: "marketing", ActualAmount: 560000})
+	mergeBudgetItem(&discussed, BudgetItem{Category: "Travel", ActualAmount: 30000})
+
+	if len(discussed) != 2 || discussed[0].ActualAmount != 560000 || discussed[0].MentionedBy != "Alice" {
+		t.Errorf("discussed = %+v", discussed)
+	}
+}
+
+func TestBudgetSummaryTable(t *testing.T) {
+	data := &AnalysisData{BudgetDiscussions: []BudgetItem{
+		{Category: "Marketing", Status: BudgetOver},
+		{Category: "Travel", Status: BudgetUnder},
+		{Category: "Tooling", Status: BudgetOnTrack},
+	}}
+	table := data.BudgetSummaryTable()
+	for _, row := range []string{"| 🔴 | Marketing |", "| 🟡 | Travel |", "| 🟢 | Tooling |"} {
+		if !strings.Contains(table, row) {
+			t.Errorf("table is missing %q:\n%s", row, table)
+		}
+	}
+	if got := (&AnalysisData{}).BudgetSummaryTable(); got != "" {
+		t.Errorf("BudgetSummaryTable() without discussions = %q, want empty", got)
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/calendar"
+)
+
+// ExportToCalDAV writes every action item with a due date to the CalDAV calendar collection at
+// serverURL as a todo. Todos are keyed by meeting and action item, so exporting again updates them.
+func (a *AnalystAgent) ExportToCalDAV(ctx context.Context, serverURL, username, password string) error {
+	a.dataMutex.RLock()
+	meetingID := a.data.MeetingID
+	items := append([]ActionItem{}, a.data.ActionItems...)
+	a.dataMutex.RUnlock()
+
+	caldav := calendar.NewCalDAVClient(serverURL, username, password)
+	exported := 0
+	for _, item := range items {
+		todo, ok := actionItemTodo(meetingID, item)
+		if !ok {
+			continue
+		}
+		if err := caldav.PutTodo(ctx, todo); err != nil {
+			return fmt.Errorf("exported %d action items before failing: %w", exported, err)
+		}
+		exported++
+	}
+
+	logrus.Infof("Agent %s: Exported %d of %d action items to CalDAV", a.agentID, exported, len(items))
+	return nil
+}
+
+// actionItemTodo converts an action item to a todo, returning false when it has no valid due date
+func actionItemTodo(meetingID string, item ActionItem) (calendar.Todo, bool) {
+	due, err := time.Parse("2006-01-02", strings.TrimSpace(item.DueDate))
+	if err != nil {
+		return calendar.Todo{}, false
+	}
+
+	return calendar.Todo{
+		UID:       meetingID + "-" + item.ID,
+		Summary:   item.Description,
+		Due:       due,
+		Priority:  caldavPriority(item.Priority),
+		Organizer: item.Assignee,
+	}, true
+}
+
+// caldavPriority maps an action item priority to the iCalendar PRIORITY scale
+func caldavPriority(priority string) int {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "high":
+		return 1
+	case "medium":
+		return 5
+	case "low":
+		return 9
+	default:
+		return 0
+	}
+}
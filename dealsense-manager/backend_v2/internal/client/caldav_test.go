@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExportToCalDAV(t *testing.T) {
+	var mu sync.Mutex
+	todos := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		todos[r.URL.Path] = string(raw)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	analyst := newTestAnalyst(t)
+	analyst.dataMutex.Lock()
+	analyst.data.MeetingID = "m1"
+	analyst.data.ActionItems = []ActionItem{
+		{ID: "a1", Description: "Send pricing", Priority: "high", DueDate: "2024-05-03", Assignee: "bob@example.com"},
+		{ID: "a2", Description: "No due date", Priority: "low"},
+		{ID: "a3", Description: "Unparseable due date", DueDate: "next week"},
+	}
+	analyst.dataMutex.Unlock()
+
+	if err := analyst.ExportToCalDAV(context.Background(), server.URL+"/tasks/", "alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(todos) != 1 {
+		t.Fatalf("exported %d todos, want only the one with a due date", len(todos))
+	}
+	todo, ok := todos["/tasks/m1-a1.ics"]
+	if !ok {
+		t.Fatalf("todo not written as m1-a1.ics: %v", todos)
+	}
+	for _, want := range []string{"SUMMARY:Send pricing", "DUE;VALUE=DATE:20240503", "PRIORITY:1", "ORGANIZER:mailto:bob@example.com"} {
+		if !strings.Contains(todo, want) {
+			t.Errorf("todo is missing %q:\n%s", want, todo)
+		}
+	}
+}
+
+func TestCalDAVPriority(t *testing.T) {
+	for priority, want := range map[string]int{"High": 1, "medium": 5, "low": 9, "": 0} {
+		if got := caldavPriority(priority); got != want {
+			t.Errorf("caldavPriority(%q) = %d, want %d", priority, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCapacityWarnThreshold is the estimated analysis backlog, in minutes, that triggers a warning
+	DefaultCapacityWarnThreshold = 5.0
+	// analysisDurationAlpha weights the newest analysis duration in the moving average
+	analysisDurationAlpha = 0.2
+	// capacityWarnInterval limits how often a capacity warning is logged per agent
+	capacityWarnInterval = 5 * time.Minute
+	// utteranceRateWindow is the period utterances per minute are measured over
+	utteranceRateWindow = time.Minute
+)
+
+// CapacityEstimate is a snapshot of an analyst's analysis load
+type CapacityEstimate struct {
+	AvgAnalysisDurationSeconds float64 `json:"avg_analysis_duration_seconds"`
+	UtterancesPerMinute        float64 `json:"utterances_per_minute"`
+	PendingAnalyses            int     `json:"pending_analyses"`
+	EstimatedBacklogMinutes    float64 `json:"estimated_backlog_minutes"`
+	WarnThresholdMinutes       float64 `json:"warn_threshold_minutes"`
+	Overloaded                 bool    `json:"overloaded"`
+}
+
+// CapacityMonitor tracks how long analyses take and how many are waiting, to predict when an analyst
+// can no longer keep up with the meeting
+type CapacityMonitor struct {
+	mu                         sync.Mutex
+	agentID                    string
+	avgAnalysisDurationSeconds float64 // Exponential moving average of analysis run time
+	pendingAnalyses            int
+	utterances                 []time.Time // Utterance arrival times within utteranceRateWindow
+	lastWarning                time.Time
+}
+
+// NewCapacityMonitor creates a capacity monitor for the given agent
+func NewCapacityMonitor(agentID string) *CapacityMonitor {
+	return &CapacityMonitor{agentID: agentID}
+}
+
+// RecordUtterance counts an utterance towards the utterance rate
+func (m *CapacityMonitor) RecordUtterance(at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.utterances = append(m.utterances, at)
+	m.trimUtterances(at)
+}
+
+// AnalysisQueued records an analysis waiting to run, warning when the backlog exceeds threshold minutes
+func (m *CapacityMonitor) AnalysisQueued(threshold float64) {
+	m.mu.Lock()
+	m.pendingAnalyses++
+	estimate := m.estimate(threshold)
+	warn := estimate.Overloaded && time.Since(m.lastWarning) >= capacityWarnInterval
+	if warn {
+		m.lastWarning = time.Now()
+	}
+	m.mu.Unlock()
+
+	if warn {
+		// Warnings are forwarded to the Discord warn webhook when one is configured
+		logrus.WithFields(logrus.Fields{
+			"agent_id":              m.agentID,
+			"pending_analyses":      estimate.PendingAnalyses,
+			"avg_analysis_seconds":  estimate.AvgAnalysisDurationSeconds,
+			"utterances_per_minute": estimate.UtterancesPerMinute,
+			"backlog_minutes":       estimate.EstimatedBacklogMinutes,
+		}).Warnf("Agent %s: analysis capacity warning, estimated backlog of %.1f minutes", m.agentID, estimate.EstimatedBacklogMinutes)
+	}
+}
+
+// AnalysisDone records that a queued analysis has finished
+func (m *CapacityMonitor) AnalysisDone() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.pendingAnalyses > 0 {
+		m.pendingAnalyses--
+	}
+}
+
+// RecordAnalysisDuration updates the moving average of analysis run time
+func (m *CapacityMonitor) RecordAnalysisDuration(duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seconds := duration.Seconds()
+	if m.avgAnalysisDurationSeconds == 0 {
+		m.avgAnalysisDurationSeconds = seconds
+		return
+	}
+	m.avgAnalysisDurationSeconds = analysisDurationAlpha*seconds + (1-analysisDurationAlpha)*m.avgAnalysisDurationSeconds
+}
+
+// Estimate returns the current capacity estimate against threshold minutes of backlog
+func (m *CapacityMonitor) Estimate(threshold float64) CapacityEstimate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.trimUtterances(time.Now())
+	return m.estimate(threshold)
+}
+
+// estimate computes the capacity estimate (caller must hold mu)
+func (m *CapacityMonitor) estimate(threshold float64) CapacityEstimate {
+	if threshold <= 0 {
+		threshold = DefaultCapacityWarnThreshold
+	}
+
+	backlog := float64(m.pendingAnalyses) * m.avgAnalysisDurationSeconds / 60
+	return CapacityEstimate{
+		AvgAnalysisDurationSeconds: m.avgAnalysisDurationSeconds,
+		UtterancesPerMinute:        float64(len(m.utterances)) / utteranceRateWindow.Minutes(),
+		PendingAnalyses:            m.pendingAnalyses,
+		EstimatedBacklogMinutes:    backlog,
+		WarnThresholdMinutes:       threshold,
+		Overloaded:                 backlog > threshold,
+	}
+}
+
+// trimUtterances drops utterances older than the rate window (caller must hold mu)
+func (m *CapacityMonitor) trimUtterances(now time.Time) {
+	cutoff := now.Add(-utteranceRateWindow)
+	i := 0
+	for i < len(m.utterances) && m.utterances[i].Before(cutoff) {
+		i++
+	}
+	m.utterances = m.utterances[i:]
+}
+
+// runQueuedAnalysis runs an analysis triggered by new utterances, counting it as pending until it finishes
+func (a *AnalystAgent) runQueuedAnalysis() {
+	defer a.capacity.AnalysisDone()
+	a.updateAnalysis()
+}
+
+// GetCapacityEstimate returns the analyst's current analysis load estimate
+func (a *AnalystAgent) GetCapacityEstimate() CapacityEstimate {
+	return a.capacity.Estimate(a.capacityWarnThreshold())
+}
+
+// capacityWarnThreshold returns the configured backlog warning threshold in minutes
+func (a *AnalystAgent) capacityWarnThreshold() float64 {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+	return a.config.CapacityWarnThreshold
+}
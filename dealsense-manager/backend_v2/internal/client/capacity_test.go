@@ -0,0 +1,68 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCapacityMonitorEMAConverges(t *testing.T) {
+	monitor := NewCapacityMonitor("test-agent")
+
+	monitor.RecordAnalysisDuration(10 * time.Second)
+	if got := monitor.Estimate(0).AvgAnalysisDurationSeconds; got != 10 {
+		t.Fatalf("average = %v, want the first duration", got)
+	}
+	monitor.RecordAnalysisDuration(20 * time.Second)
+	if got := monitor.Estimate(0).AvgAnalysisDurationSeconds; !approx(got, 12) {
+		t.Errorf("average = %v, want 0.2 * 20 + 0.8 * 10 = 12", got)
+	}
+
+	// After a sustained change the average approaches the new duration geometrically
+	for i := 0; i < 30; i++ {
+		monitor.RecordAnalysisDuration(60 * time.Second)
+	}
+	got := monitor.Estimate(0).AvgAnalysisDurationSeconds
+	if want := 60 - 48*math.Pow(0.8, 30); !approx(got, want) {
+		t.Errorf("average = %v, want %v", got, want)
+	}
+	if 60-got > 0.1 {
+		t.Errorf("average = %v, want it within 0.1s of 60s", got)
+	}
+}
+
+func TestCapacityMonitorBacklog(t *testing.T) {
+	monitor := NewCapacityMonitor("test-agent")
+	monitor.RecordAnalysisDuration(2 * time.Minute)
+
+	for i := 0; i < 3; i++ {
+		monitor.AnalysisQueued(5)
+	}
+	estimate := monitor.Estimate(5)
+	if estimate.PendingAnalyses != 3 || estimate.EstimatedBacklogMinutes != 6 || !estimate.Overloaded {
+		t.Errorf("estimate = %+v, want 6 minutes of backlog over the threshold", estimate)
+	}
+
+	monitor.AnalysisDone()
+	if estimate := monitor.Estimate(0); estimate.Overloaded || estimate.WarnThresholdMinutes != DefaultCapacityWarnThreshold {
+		t.Errorf("estimate = %+v, want 4 minutes within the default threshold", estimate)
+	}
+	for i := 0; i < 5; i++ {
+		monitor.AnalysisDone()
+	}
+	if pending := monitor.Estimate(0).PendingAnalyses; pending != 0 {
+		t.Errorf("pending = %d, want never negative", pending)
+	}
+}
+
+func TestCapacityMonitorUtteranceRate(t *testing.T) {
+	monitor := NewCapacityMonitor("test-agent")
+	now := time.Now()
+	monitor.RecordUtterance(now.Add(-2 * time.Minute))
+	monitor.RecordUtterance(now.Add(-30 * time.Second))
+	monitor.RecordUtterance(now)
+
+	if rate := monitor.Estimate(0).UtterancesPerMinute; rate != 2 {
+		t.Errorf("rate = %v, want the utterances of the last minute", rate)
+	}
+}
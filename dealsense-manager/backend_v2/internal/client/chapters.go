@@ -0,0 +1,147 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// topicStartSlack is how far before the meeting start a topic's truncated HH:MM start time may fall and
+// still count as starting with the meeting
+const topicStartSlack = time.Minute
+
+// ChapterMarker is a chapter of the meeting recording, derived from a discussion topic
+type ChapterMarker struct {
+	StartTimeOffset time.Duration `json:"start_time_offset"` // From the start of the meeting
+	EndTimeOffset   time.Duration `json:"end_time_offset"`   // Start of the next chapter, or the end of the last topic
+	Title           string        `json:"title"`
+	Description     string        `json:"description"`
+}
+
+// deriveChapterMarkers turns topics into chapters ordered by start time. Topic start times are wall
+// clock HH:MM or HH:MM:SS times on the day the meeting started; topics without a parseable start time
+// are left out.
+func deriveChapterMarkers(topics []TopicDiscussion, meetingStart time.Time, durationMinutes float64) []ChapterMarker {
+	type chapter struct {
+		marker   ChapterMarker
+		topicEnd time.Duration
+	}
+
+	var chapters []chapter
+	for _, topic := range topics {
+		clock, ok := parseTopicClock(topic.StartTime)
+		if !ok || strings.TrimSpace(topic.Topic) == "" {
+			continue
+		}
+
+		start := time.Date(meetingStart.Year(), meetingStart.Month(), meetingStart.Day(),
+			clock.Hour(), clock.Minute(), clock.Second(), 0, meetingStart.Location())
+		offset := start.Sub(meetingStart)
+		switch {
+		case offset < 0 && offset > -topicStartSlack:
+			offset = 0
+		case offset < 0:
+			// The meeting ran past midnight
+			offset += 24 * time.Hour
+		}
+
+		chapters = append(chapters, chapter{
+			marker: ChapterMarker{
+				StartTimeOffset: offset.Truncate(time.Second),
+				Title:           strings.TrimSpace(topic.Topic),
+				Description:     strings.TrimSpace(topic.Summary),
+			},
+			topicEnd: offset + time.Duration(topic.Duration*float64(time.Minute)),
+		})
+	}
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].marker.StartTimeOffset < chapters[j].marker.StartTimeOffset
+	})
+
+	markers := make([]ChapterMarker, len(chapters))
+	for i, chapter := range chapters {
+		markers[i] = chapter.marker
+		if i+1 < len(chapters) {
+			markers[i].EndTimeOffset = chapters[i+1].marker.StartTimeOffset
+		} else {
+			meetingEnd := time.Duration(durationMinutes * float64(time.Minute))
+			markers[i].EndTimeOffset = max(chapter.topicEnd, meetingEnd).Truncate(time.Second)
+		}
+	}
+	return markers
+}
+
+// parseTopicClock parses a topic start time in HH:MM or HH:MM:SS format
+func parseTopicClock(value string) (time.Time, bool) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GenerateYouTubeChapterList formats markers as a YouTube description chapter list, one "M:SS Title"
+// line per chapter ("H:MM:SS" from an hour in). YouTube requires the first chapter at 0:00, so an
+// "Opening" chapter is added when the first marker starts later.
+func GenerateYouTubeChapterList(markers []ChapterMarker) string {
+	if len(markers) == 0 {
+		return ""
+	}
+
+	var list strings.Builder
+	if markers[0].StartTimeOffset > 0 {
+		list.WriteString("0:00 Opening\n")
+	}
+	for _, marker := range markers {
+		fmt.Fprintf(&list, "%s %s\n", youTubeTimestamp(marker.StartTimeOffset), singleLine(marker.Title))
+	}
+	return list.String()
+}
+
+// youTubeTimestamp formats an offset as M:SS, or H:MM:SS from an hour in
+func youTubeTimestamp(offset time.Duration) string {
+	seconds := int(offset.Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// GenerateVTTChapterFile formats markers as a WebVTT chapter track, with each chapter's title as its cue
+// text. Chapters without an end after their start last until the next chapter, or a minute when last.
+func GenerateVTTChapterFile(markers []ChapterMarker) string {
+	var vtt strings.Builder
+	vtt.WriteString("WEBVTT\n")
+	for i, marker := range markers {
+		end := marker.EndTimeOffset
+		if end <= marker.StartTimeOffset {
+			if i+1 < len(markers) && markers[i+1].StartTimeOffset > marker.StartTimeOffset {
+				end = markers[i+1].StartTimeOffset
+			} else {
+				end = marker.StartTimeOffset + time.Minute
+			}
+		}
+		fmt.Fprintf(&vtt, "\nchapter-%d\n%s --> %s\n%s\n", i+1, vttTimestamp(marker.StartTimeOffset), vttTimestamp(end),
+			strings.ReplaceAll(singleLine(marker.Title), "-->", "->"))
+	}
+	return vtt.String()
+}
+
+// vttTimestamp formats an offset as a WebVTT HH:MM:SS.mmm timestamp
+func vttTimestamp(offset time.Duration) string {
+	millis := offset.Milliseconds()
+	if millis < 0 {
+		millis = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", millis/3600000, millis%3600000/60000, millis%60000/1000, millis%1000)
+}
+
+// singleLine collapses whitespace, including line breaks, to single spaces
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
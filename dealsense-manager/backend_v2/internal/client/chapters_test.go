@@ -0,0 +1,70 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeriveChapterMarkers(t *testing.T) {
+	topics := []TopicDiscussion{
+		{Topic: "Pricing", StartTime: "10:05:30", Duration: 10, Summary: "New tiers"},
+		{Topic: "Welcome", StartTime: "10:00"}, // Truncated to the minute the meeting started in
+		{Topic: "Hiring", StartTime: "later"},
+		{Topic: "Wrap-up", StartTime: "10:40", Duration: 5},
+	}
+
+	markers := deriveChapterMarkers(topics, testMeetingStart.Add(30*time.Second), 30)
+	want := []ChapterMarker{
+		{StartTimeOffset: 0, EndTimeOffset: 5 * time.Minute, Title: "Welcome"},
+		{StartTimeOffset: 5 * time.Minute, EndTimeOffset: 39*time.Minute + 30*time.Second, Title: "Pricing", Description: "New tiers"},
+		{StartTimeOffset: 39*time.Minute + 30*time.Second, EndTimeOffset: 44*time.Minute + 30*time.Second, Title: "Wrap-up"},
+	}
+	if len(markers) != len(want) {
+		t.Fatalf("markers = %+v, want %+v", markers, want)
+	}
+	for i := range want {
+		if markers[i] != want[i] {
+			t.Errorf("marker %d = %+v, want %+v", i, markers[i], want[i])
+		}
+	}
+}
+
+func TestChapterTimestampFormats(t *testing.T) {
+	tests := []struct {
+		offset  time.Duration
+		youTube string
+		vtt     string
+	}{
+		{0, "0:00", "00:00:00.000"},
+		{65 * time.Second, "1:05", "00:01:05.000"},
+		{59*time.Minute + 59*time.Second, "59:59", "00:59:59.000"},
+		{time.Hour, "1:00:00", "01:00:00.000"},
+		{time.Hour + 2*time.Minute + 5*time.Second + 500*time.Millisecond, "1:02:05", "01:02:05.500"},
+		{-time.Second, "0:00", "00:00:00.000"},
+	}
+	for _, tt := range tests {
+		if got := youTubeTimestamp(tt.offset); got != tt.youTube {
+			t.Errorf("youTubeTimestamp(%s) = %q, want %q", tt.offset, got, tt.youTube)
+		}
+		if got := vttTimestamp(tt.offset); got != tt.vtt {
+			t.Errorf("vttTimestamp(%s) = %q, want %q", tt.offset, got, tt.vtt)
+		}
+	}
+}
+
+func TestGenerateChapterFiles(t *testing.T) {
+	markers := []ChapterMarker{
+		{StartTimeOffset: 90 * time.Second, Title: "Pricing\nand tiers"},
+		{StartTimeOffset: time.Hour, EndTimeOffset: time.Hour + 5*time.Minute, Title: "Q&A --> wrap-up"},
+	}
+
+	if got, want := GenerateYouTubeChapterList(markers), "0:00 Opening\n1:30 Pricing and tiers\n1:00:00 Q&A --> wrap-up\n"; got != want {
+		t.Errorf("chapter list = %q, want %q", got, want)
+	}
+
+	want := "WEBVTT\n\nchapter-1\n00:01:30.000 --> 01:00:00.000\nPricing and tiers\n" +
+		"\nchapter-2\n01:00:00.000 --> 01:05:00.000\nQ&A -> wrap-up\n"
+	if got := GenerateVTTChapterFile(markers); got != want {
+		t.Errorf("VTT = %q, want %q", got, want)
+	}
+}
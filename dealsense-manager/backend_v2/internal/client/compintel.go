@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// compIntelTranscript is the number of recent transcript entries searched for competitor references
+const compIntelTranscript = 50
+
+// CompIntelReport collects what was said about competitors during a sales meeting
+type CompIntelReport struct {
+	CompetitorsMentioned []CompetitorMention    `json:"competitors_mentioned"`
+	PricingDiscussed     []string               `json:"pricing_discussed"`
+	FeatureComparisons   []FeatureComparison    `json:"feature_comparisons"`
+	GroundingMetadata    *llm.GroundingMetadata `json:"grounding_metadata,omitempty"` // Sources used to verify competitor information
+}
+
+// CompetitorMention is a reference to a competitor and how it came up
+type CompetitorMention struct {
+	Name      string `json:"name"`
+	Context   string `json:"context"`   // What was said about the competitor
+	Sentiment string `json:"sentiment"` // positive, negative, neutral
+}
+
+// FeatureComparison is a feature compared between our product and a competitor's
+type FeatureComparison struct {
+	Feature    string `json:"feature"`
+	Competitor string `json:"competitor"`
+	Comparison string `json:"comparison"` // How the two products compare on this feature
+}
+
+// extractCompetitiveIntel looks for competitor references, pricing and feature comparisons in the
+// transcript, verifying competitor information with search grounding when the provider supports it
+func (a *AnalystAgent) extractCompetitiveIntel(ctx context.Context) error {
+	transcript := a.getRecentTranscript(compIntelTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting competitive intelligence with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("competitive_intel",
+		`Analyze this sales meeting transcript for competitive intelligence. Look specifically for:
+- References to competitors or competing products, by name
+- Competitor pricing, discounts or contract terms that were discussed
+- Comparisons between our product's features and a competitor's
+
+Only include a competitor when the transcript says something about it; skip names that are mentioned in passing with no context.
+Use google_search, when available, to verify factual claims about competitors such as their pricing or features, and note in the context when a claim appears inaccurate or outdated.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "competitors_mentioned": [
+    {"name": "Competitor name", "context": "What was said about them", "sentiment": "positive/negative/neutral"}
+  ],
+  "pricing_discussed": ["Pricing detail"],
+  "feature_comparisons": [
+    {"feature": "Feature name", "competitor": "Competitor name", "comparison": "How the products compare"}
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	var response string
+	var metadata *llm.GroundingMetadata
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		groundedResponse, err := a.callLLMWithGrounding(ctx, groundingProvider, prompt)
+		if err != nil {
+			logrus.Warnf("Grounded call failed for competitive intelligence, falling back to regular call: %v", err)
+		} else if groundedResponse != nil {
+			response, metadata = groundedResponse.Text, groundedResponse.GroundingMetadata
+		}
+	}
+	if response == "" {
+		var err error
+		if response, err = a.callLLM(ctx, prompt); err != nil {
+			logrus.Warnf("Failed to extract competitive intelligence: %v", err)
+			return err
+		}
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var report CompIntelReport
+	if err := json.Unmarshal([]byte(jsonData), &report); err != nil {
+		return fmt.Errorf("failed to parse competitive intelligence JSON: %w", err)
+	}
+	report.CompetitorsMentioned = filterCompetitorMentions(report.CompetitorsMentioned)
+	report.GroundingMetadata = metadata
+
+	a.data.CompetitiveIntelligence = &report
+	logrus.Infof("Agent %s: Found %d competitor mentions, %d pricing details and %d feature comparisons",
+		a.agentID, len(report.CompetitorsMentioned), len(report.PricingDiscussed), len(report.FeatureComparisons))
+	return nil
+}
+
+// filterCompetitorMentions drops mentions without a name or without any context, normalizing sentiment
+func filterCompetitorMentions(mentions []CompetitorMention) []CompetitorMention {
+	filtered := []CompetitorMention{}
+	for _, mention := range mentions {
+		mention.Name = strings.TrimSpace(mention.Name)
+		mention.Context = strings.TrimSpace(mention.Context)
+		if mention.Name == "" || mention.Context == "" {
+			continue
+		}
+
+		switch mention.Sentiment = strings.ToLower(strings.TrimSpace(mention.Sentiment)); mention.Sentiment {
+		case "positive", "negative", "neutral":
+		default:
+			mention.Sentiment = "neutral"
+		}
+		filtered = append(filtered, mention)
+	}
+	return filtered
+}
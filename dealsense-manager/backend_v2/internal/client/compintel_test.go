@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractCompetitiveIntelDropsMentionsWithoutContext(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{
+		"competitors_mentioned": [
+			{"name": "Globex", "context": "Quoted 20% less for the same seats", "sentiment": "Negative"},
+			{"name": "Initech", "context": "  ", "sentiment": "neutral"},
+			{"name": "Umbrella", "sentiment": "positive"},
+			{"name": "", "context": "Some other vendor", "sentiment": "neutral"},
+			{"name": "Hooli", "context": "Their reporting is nicer", "sentiment": "impressed"}
+		],
+		"pricing_discussed": ["Globex charges $40 per seat"],
+		"feature_comparisons": [{"feature": "Reporting", "competitor": "Hooli", "comparison": "Hooli has scheduled exports"}]
+	}` + "\n```")
+	say(analyst, 0, "Dana", "Globex quoted us twenty percent less, and Hooli reporting is nicer")
+
+	if err := analyst.extractCompetitiveIntel(context.Background()); err != nil {
+		t.Fatalf("extractCompetitiveIntel() error = %v", err)
+	}
+
+	report := analyst.GetAnalysis().CompetitiveIntelligence
+	if report == nil {
+		t.Fatal("CompetitiveIntelligence = nil")
+	}
+	mentions := report.CompetitorsMentioned
+	if len(mentions) != 2 || mentions[0].Name != "Globex" || mentions[1].Name != "Hooli" {
+		t.Fatalf("CompetitorsMentioned = %+v, want Globex and Hooli", mentions)
+	}
+	if mentions[0].Sentiment != "negative" || mentions[1].Sentiment != "neutral" {
+		t.Errorf("sentiments = %q, %q, want negative and neutral", mentions[0].Sentiment, mentions[1].Sentiment)
+	}
+	if len(report.PricingDiscussed) != 1 || len(report.FeatureComparisons) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// complianceTranscript is the number of recent transcript entries checked for compliance issues
+const complianceTranscript = 50
+
+// ComplianceFlag is a statement that may breach a regulation governing the meeting's industry
+type ComplianceFlag struct {
+	Category            string    `json:"category"` // HIPAA, SOX, GDPR, PCI
+	Description         string    `json:"description"`
+	Severity            string    `json:"severity"` // low, medium, high
+	Timestamp           time.Time `json:"timestamp"`
+	Speaker             string    `json:"speaker"`
+	RegulationReference string    `json:"regulation_reference,omitempty"`
+}
+
+// complianceFocus describes what the LLM looks for under each framework
+var complianceFocus = map[string]string{
+	"HIPAA": "HIPAA: protected health information (PHI) such as patient names with diagnoses, treatments or prescriptions, medical record numbers, dates of birth, insurance IDs or Social Security numbers, and PHI shared with people who should not receive it",
+	"SOX":   "SOX: pressure to alter or backdate financial records, bypassing internal controls or approvals, undisclosed material information, and destroying or withholding audit documents",
+	"GDPR":  "GDPR: processing or sharing personal data of EU residents without a lawful basis or consent, transfers outside the EU without safeguards, ignoring access or erasure requests, and keeping data longer than needed",
+	"PCI":   "PCI DSS: full card numbers, CVV codes, PINs or magnetic stripe data read aloud or stored, and card data sent over unsecured channels",
+}
+
+// complianceCategories lists the frameworks in the order they appear in prompts
+var complianceCategories = []string{"HIPAA", "SOX", "GDPR", "PCI"}
+
+// phiPattern is a regular expression matching a HIPAA identifier that is flagged without asking the LLM
+type phiPattern struct {
+	pattern     *regexp.Regexp
+	description string
+	severity    string
+}
+
+// phiPatterns match identifiers from the HIPAA Safe Harbor list that can be recognised from their format,
+// most severe first as only the first match in a statement is kept
+var phiPatterns = []phiPattern{
+	{regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), "Social Security number disclosed", "high"},
+	{regexp.MustCompile(`(?i)\b(?:MRN|medical record (?:number|no\.?|#))\s*(?:is|:)?\s*#?[A-Z]*\d[A-Z0-9-]{3,}`), "Medical record number disclosed", "high"},
+	{regexp.MustCompile(`(?i)\b(?:date of birth|DOB|born on)\b\W{0,3}(?:is\s+)?(?:\d{1,2}[/-]\d{1,2}[/-]\d{2,4}|(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+\d{1,2})`), "Date of birth disclosed", "medium"},
+	{regexp.MustCompile(`(?i)\b(?:member|policy|insurance|health plan) (?:id|number)\s*(?:is|:)?\s*#?[A-Z]*\d[A-Z0-9-]{4,}`), "Health plan member number disclosed", "medium"},
+}
+
+// phiRegulationReference is the HIPAA rule listing the identifiers matched by phiPatterns
+const phiRegulationReference = "45 CFR 164.514(b)(2)"
+
+// detectComplianceIssues flags statements that may breach the regulations selected by ComplianceFramework,
+// or all supported ones when it is empty. HIPAA identifiers are matched locally before the LLM is asked.
+// Flags accumulate across analysis runs and each new high-severity flag raises an error-level alert.
+func (a *AnalystAgent) detectComplianceIssues(ctx context.Context) error {
+	transcript := a.getRecentTranscript(complianceTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	categories := a.complianceCategories()
+	logrus.Infof("Agent %s: Checking %d transcript entries for %s compliance issues", a.agentID, len(transcript), strings.Join(categories, "/"))
+
+	var found []ComplianceFlag
+	if slices.Contains(categories, "HIPAA") {
+		found = detectPHI(transcript)
+	}
+
+	// Identifier matches are still recorded when the LLM call fails
+	flags, detectErr := a.detectComplianceWithLLM(ctx, transcript, categories)
+	found = append(found, flags...)
+
+	a.dataMutex.Lock()
+	seen := make(map[string]bool)
+	for _, flag := range a.data.ComplianceFlags {
+		seen[complianceFlagKey(flag)] = true
+	}
+	var added []ComplianceFlag
+	for _, flag := range found {
+		if key := complianceFlagKey(flag); !seen[key] {
+			seen[key] = true
+			added = append(added, flag)
+		}
+	}
+	a.data.ComplianceFlags = append(a.data.ComplianceFlags, added...)
+	meetingID := a.data.MeetingID
+	a.dataMutex.Unlock()
+
+	for _, flag := range added {
+		if flag.Severity == "high" {
+			a.alertComplianceFlag(meetingID, flag)
+		}
+	}
+	if len(added) > 0 {
+		logrus.Infof("Agent %s: Found %d new compliance flags", a.agentID, len(added))
+	}
+	return detectErr
+}
+
+// complianceCategories returns the frameworks selected by ComplianceFramework, defaulting to all of them
+func (a *AnalystAgent) complianceCategories() []string {
+	var categories []string
+	for _, name := range strings.FieldsFunc(a.config.ComplianceFramework, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if category := normalizeComplianceCategory(name); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	if len(categories) == 0 {
+		return complianceCategories
+	}
+	return categories
+}
+
+// detectComplianceWithLLM asks the LLM for compliance issues in the transcript under the given frameworks
+func (a *AnalystAgent) detectComplianceWithLLM(ctx context.Context, transcript []TranscriptEntry, categories []string) ([]ComplianceFlag, error) {
+	var focus strings.Builder
+	for _, category := range categories {
+		focus.WriteString("- " + complianceFocus[category] + "\n")
+	}
+
+	var result struct {
+		Flags []struct {
+			Statement int `json:"statement"`
+			ComplianceFlag
+		} `json:"flags"`
+	}
+	request := indexedStatementsRequest{
+		task: `Review this meeting transcript for statements that may breach the following regulations:
+` + focus.String() + `Only flag statements that disclose regulated data or describe conduct the regulation prohibits. Do not flag general discussion of compliance, or data that is clearly fictional or already de-identified.
+Rate severity as high when regulated data is disclosed or a violation is described as happening, medium when a statement risks a violation, and low for minor policy concerns.`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "flags": [
+    {
+      "statement": 12,
+      "category": "` + strings.Join(categories, "/") + `",
+      "description": "What was said and why it is a compliance concern",
+      "severity": "low/medium/high",
+      "regulation_reference": "Specific rule or article, e.g. 45 CFR 164.502"
+    }
+  ]
+}`,
+		result: "compliance flags",
+	}
+	ok, err := a.askAboutStatements(ctx, request, &result)
+	if err != nil {
+		logrus.Warnf("Failed to detect compliance issues: %v", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	allowed := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		allowed[category] = true
+	}
+
+	var flags []ComplianceFlag
+	for _, candidate := range result.Flags {
+		flag := candidate.ComplianceFlag
+		flag.Category = normalizeComplianceCategory(flag.Category)
+		flag.Description = strings.TrimSpace(flag.Description)
+		statement, ok := statementAt(transcript, candidate.Statement)
+		if !ok || !allowed[flag.Category] || flag.Description == "" {
+			continue
+		}
+		flag.Severity = normalizeComplianceSeverity(flag.Severity)
+		flag.Timestamp = statement.Timestamp
+		flag.Speaker = statement.Speaker
+		flag.RegulationReference = strings.TrimSpace(flag.RegulationReference)
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+// detectPHI flags transcript entries containing HIPAA identifiers recognisable from their format
+func detectPHI(transcript []TranscriptEntry) []ComplianceFlag {
+	var flags []ComplianceFlag
+	for _, entry := range transcript {
+		for _, phi := range phiPatterns {
+			if !phi.pattern.MatchString(entry.Text) {
+				continue
+			}
+			flags = append(flags, ComplianceFlag{
+				Category:            "HIPAA",
+				Description:         phi.description,
+				Severity:            phi.severity,
+				Timestamp:           entry.Timestamp,
+				Speaker:             entry.Speaker,
+				RegulationReference: phiRegulationReference,
+			})
+		}
+	}
+	return flags
+}
+
+// alertComplianceFlag logs a high-severity flag at error level, which the Discord hook forwards to the
+// error webhook with the flag's details as embed fields
+func (a *AnalystAgent) alertComplianceFlag(meetingID string, flag ComplianceFlag) {
+	logrus.WithFields(logrus.Fields{
+		"agent_id":   a.agentID,
+		"meeting_id": meetingID,
+		"category":   flag.Category,
+		"severity":   flag.Severity,
+		"speaker":    flag.Speaker,
+		"timestamp":  flag.Timestamp.Format(time.RFC3339),
+		"regulation": flag.RegulationReference,
+	}).Errorf("🚨 High-severity %s compliance flag: %s", flag.Category, flag.Description)
+}
+
+// complianceFlagKey identifies the statement and regulation a flag is for, so a statement is flagged once
+// per regulation however often it is analyzed
+func complianceFlagKey(flag ComplianceFlag) string {
+	return fmt.Sprintf("%s|%s|%d", flag.Category, flag.Speaker, flag.Timestamp.UnixNano())
+}
+
+// normalizeComplianceCategory maps a framework name onto HIPAA, SOX, GDPR or PCI, returning "" for others
+func normalizeComplianceCategory(category string) string {
+	switch category = strings.ToUpper(strings.TrimSpace(category)); category {
+	case "HIPAA", "SOX", "GDPR", "PCI":
+		return category
+	case "PCI DSS", "PCI-DSS", "PCIDSS":
+		return "PCI"
+	default:
+		return ""
+	}
+}
+
+// normalizeComplianceSeverity maps the LLM's severity onto the supported set, defaulting to medium
+func normalizeComplianceSeverity(severity string) string {
+	switch severity = strings.ToLower(strings.TrimSpace(severity)); severity {
+	case "low", "medium", "high":
+		return severity
+	default:
+		return "medium"
+	}
+}
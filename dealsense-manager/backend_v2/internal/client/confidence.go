@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// confidenceFullTranscript is the transcript length at which the length score is maxed out
+	confidenceFullTranscript = 200
+	// confidenceLengthWeight is the largest share of the score earned by transcript length
+	confidenceLengthWeight = 0.5
+	// confidenceGroundingBonus is added when the result was verified with a grounded call
+	confidenceGroundingBonus = 0.2
+	// confidenceSuccessBonus is added when the primary LLM call succeeded without falling back
+	confidenceSuccessBonus = 0.3
+)
+
+// analysisConfidence estimates how reliable an analysis result is from the number of transcript entries it
+// was based on, whether it was grounded and whether its primary LLM call had to fall back
+func analysisConfidence(entries int, grounded, fellBack bool) float64 {
+	score := 0.0
+	if entries > 0 {
+		// Log scale so the first few dozen entries matter far more than the last hundred
+		lengthScore := math.Log1p(float64(entries)) / math.Log1p(confidenceFullTranscript)
+		score += confidenceLengthWeight * math.Min(lengthScore, 1)
+	}
+	if grounded {
+		score += confidenceGroundingBonus
+	}
+	if !fellBack {
+		score += confidenceSuccessBonus
+	}
+	return math.Min(score, 1)
+}
+
+// recordConfidence stores the confidence of the result produced by an analysis step. The caller must not
+// hold dataMutex.
+func (a *AnalystAgent) recordConfidence(stepName string, entries int, grounded, fellBack bool) {
+	confidence := analysisConfidence(entries, grounded, fellBack)
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	switch stepName {
+	case "summary":
+		a.data.SummaryConfidence = confidence
+	case "key_points":
+		a.data.KeyPointsConfidence = confidence
+	case "action_items":
+		a.data.ActionItemsConfidence = confidence
+	case "topics":
+		a.data.TopicsConfidence = confidence
+	case "sentiment_keywords":
+		a.data.SentimentConfidence = confidence
+	}
+}
+
+// GetAnalysisConfidence returns the confidence of each analysis result, from 0 to 1
+func (a *AnalystAgent) GetAnalysisConfidence() map[string]float64 {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return map[string]float64{
+		"summary":      a.data.SummaryConfidence,
+		"key_points":   a.data.KeyPointsConfidence,
+		"action_items": a.data.ActionItemsConfidence,
+		"topics":       a.data.TopicsConfidence,
+		"sentiment":    a.data.SentimentConfidence,
+	}
+}
+
+// formatConfidence renders the confidence of each produced analysis result on one line, or "" if there are none
+func formatConfidence(data *AnalysisData) string {
+	scores := []struct {
+		label string
+		value float64
+	}{
+		{"Summary", data.SummaryConfidence},
+		{"Key Points", data.KeyPointsConfidence},
+		{"Action Items", data.ActionItemsConfidence},
+		{"Topics", data.TopicsConfidence},
+		{"Sentiment", data.SentimentConfidence},
+	}
+
+	var parts []string
+	for _, score := range scores {
+		if score.value > 0 {
+			parts = append(parts, fmt.Sprintf("%s %.0f%%", score.label, score.value*100))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
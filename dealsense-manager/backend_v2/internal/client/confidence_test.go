@@ -0,0 +1,62 @@
+package client
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnalysisConfidenceBounds(t *testing.T) {
+	if got := analysisConfidence(0, false, true); got != 0 {
+		t.Errorf("confidence without transcript or success = %v, want 0", got)
+	}
+	if got := analysisConfidence(10000, true, false); got != 1 {
+		t.Errorf("confidence with everything = %v, want capped at 1", got)
+	}
+	if got := analysisConfidence(confidenceFullTranscript, false, false); math.Abs(got-0.8) > 1e-9 {
+		t.Errorf("confidence of a full ungrounded transcript = %v, want 0.8", got)
+	}
+
+	previous := 0.0
+	for _, entries := range []int{1, 5, 20, 100, 200, 400} {
+		got := analysisConfidence(entries, false, true)
+		if got < previous || got > confidenceLengthWeight {
+			t.Errorf("confidence for %d entries = %v, want non-decreasing and at most %v", entries, got, confidenceLengthWeight)
+		}
+		previous = got
+	}
+}
+
+func TestAnalysisConfidenceGroundingBonus(t *testing.T) {
+	for _, entries := range []int{0, 10, 50} {
+		bonus := analysisConfidence(entries, true, false) - analysisConfidence(entries, false, false)
+		if math.Abs(bonus-confidenceGroundingBonus) > 1e-9 {
+			t.Errorf("grounding bonus with %d entries = %v, want %v", entries, bonus, confidenceGroundingBonus)
+		}
+	}
+}
+
+func TestRecordConfidence(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.recordConfidence("summary", 50, true, false)
+
+	confidence := analyst.GetAnalysisConfidence()
+	if math.Abs(confidence["summary"]-analysisConfidence(50, true, false)) > 1e-9 {
+		t.Errorf("confidence = %v", confidence)
+	}
+}
+
+func TestRecordConfidenceWhileReading(t *testing.T) {
+	analyst := newTestAnalyst(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			analyst.recordConfidence("sentiment_keywords", i, false, false)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		analyst.GetAnalysisConfidence()
+	}
+	<-done
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// minConflictEntries is the transcript length below which conflict detection is skipped, as short
+	// meetings produce mostly false positives
+	minConflictEntries = 50
+	// conflictWindowSize is the number of transcript entries in each window compared for contradictions
+	conflictWindowSize = 25
+	// maxConflictWindowPairs bounds the LLM calls made per analysis run so the step fits its timeout
+	maxConflictWindowPairs = 3
+)
+
+// StatementConflict is a pair of transcript entries that contradict each other
+type StatementConflict struct {
+	Statement1   int    `json:"statement1"`    // Index of the earlier statement in AnalysisData.Transcript
+	Statement2   int    `json:"statement2"`    // Index of the later statement in AnalysisData.Transcript
+	ConflictType string `json:"conflict_type"` // factual, opinion, commitment
+	Severity     string `json:"severity"`      // low, medium, high
+	Explanation  string `json:"explanation"`
+}
+
+// detectConflicts compares windows of the transcript pairwise and records statements that contradict
+// each other. Windows that include the newest entries are compared first.
+func (a *AnalystAgent) detectConflicts(ctx context.Context) error {
+	transcript := a.currentAnalysisSnapshot
+	if len(transcript) <= minConflictEntries {
+		return nil
+	}
+
+	var windows [][2]int
+	for start := 0; start < len(transcript); start += conflictWindowSize {
+		windows = append(windows, [2]int{start, min(start+conflictWindowSize, len(transcript))})
+	}
+
+	var pairs [][2][2]int
+	for later := len(windows) - 1; later > 0 && len(pairs) < maxConflictWindowPairs; later-- {
+		for earlier := later - 1; earlier >= 0 && len(pairs) < maxConflictWindowPairs; earlier-- {
+			pairs = append(pairs, [2][2]int{windows[earlier], windows[later]})
+		}
+	}
+
+	logrus.Infof("Agent %s: Detecting conflicting statements across %d window pairs", a.agentID, len(pairs))
+
+	// Conflicts found before a failure are still recorded
+	var found []StatementConflict
+	var detectErr error
+	for _, pair := range pairs {
+		conflicts, err := a.detectWindowConflicts(ctx, transcript, pair[0], pair[1])
+		if err != nil {
+			detectErr = err
+			break
+		}
+		found = append(found, conflicts...)
+	}
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	// Entries may have been pruned since the snapshot was taken, so map the snapshot indices onto the
+	// live transcript
+	offset := len(a.data.Transcript) - len(transcript)
+	seen := make(map[[2]int]bool)
+	for _, conflict := range a.data.ConflictingStatements {
+		seen[[2]int{conflict.Statement1, conflict.Statement2}] = true
+	}
+	for _, conflict := range found {
+		first, ok1 := a.liveTranscriptIndex(transcript, conflict.Statement1, offset)
+		second, ok2 := a.liveTranscriptIndex(transcript, conflict.Statement2, offset)
+		if !ok1 || !ok2 || seen[[2]int{first, second}] {
+			continue
+		}
+		seen[[2]int{first, second}] = true
+		conflict.Statement1, conflict.Statement2 = first, second
+		a.data.ConflictingStatements = append(a.data.ConflictingStatements, conflict)
+	}
+
+	return detectErr
+}
+
+// detectWindowConflicts asks the LLM for contradictions between statements in two transcript windows
+func (a *AnalystAgent) detectWindowConflicts(ctx context.Context, transcript []TranscriptEntry, earlier, later [2]int) ([]StatementConflict, error) {
+	var result struct {
+		Conflicts []StatementConflict `json:"conflicts"`
+	}
+	request := indexedStatementsRequest{
+		task: `Compare these two parts of a meeting transcript and identify logical contradictions, where a statement in the later part contradicts a statement in the earlier part.
+
+Only report genuine contradictions:
+- factual: two statements claim incompatible facts or figures
+- opinion: a speaker reverses a position they stated earlier
+- commitment: a commitment is contradicted by a later statement
+
+Do not report clarifications, corrections that are acknowledged as such, or different people simply disagreeing.`,
+		sections: []indexedSection{
+			{title: "Earlier part", text: formatIndexedTranscript(transcript, earlier)},
+			{title: "Later part", text: formatIndexedTranscript(transcript, later)},
+		},
+		format: `{
+  "conflicts": [
+    {
+      "statement1": 12,
+      "statement2": 40,
+      "conflict_type": "factual/opinion/commitment",
+      "severity": "low/medium/high",
+      "explanation": "Why the statements contradict each other"
+    }
+  ]
+}`,
+		result: "conflicts",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return nil, err
+	}
+
+	var conflicts []StatementConflict
+	for _, conflict := range result.Conflicts {
+		if conflict.Statement1 < earlier[0] || conflict.Statement1 >= earlier[1] ||
+			conflict.Statement2 < later[0] || conflict.Statement2 >= later[1] {
+			continue
+		}
+		conflict.ConflictType = normalizeConflictType(conflict.ConflictType)
+		conflict.Severity = normalizeConflictSeverity(conflict.Severity)
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+// liveTranscriptIndex maps an index into the analysis snapshot onto the live transcript, reporting false
+// when the entry is no longer there (caller must hold dataMutex)
+func (a *AnalystAgent) liveTranscriptIndex(snapshot []TranscriptEntry, index, offset int) (int, bool) {
+	entry := snapshot[index]
+	for _, candidate := range []int{index, index + offset} {
+		if candidate >= 0 && candidate < len(a.data.Transcript) &&
+			a.data.Transcript[candidate].Timestamp.Equal(entry.Timestamp) && a.data.Transcript[candidate].Text == entry.Text {
+			return candidate, true
+		}
+	}
+	return 0, false
+}
+
+// shiftConflicts drops conflicts referencing pruned transcript entries in [start, end) and re-indexes the
+// rest (caller must hold dataMutex)
+func (a *AnalystAgent) shiftConflicts(start, end int) {
+	pruned := end - start
+	kept := a.data.ConflictingStatements[:0]
+	for _, conflict := range a.data.ConflictingStatements {
+		first, ok1 := shiftIndex(conflict.Statement1, start, end, pruned)
+		second, ok2 := shiftIndex(conflict.Statement2, start, end, pruned)
+		if !ok1 || !ok2 {
+			continue
+		}
+		conflict.Statement1, conflict.Statement2 = first, second
+		kept = append(kept, conflict)
+	}
+	a.data.ConflictingStatements = kept
+}
+
+// shiftIndex returns index after removing the entries in [start, end), reporting false if it was removed
+func shiftIndex(index, start, end, pruned int) (int, bool) {
+	switch {
+	case index < start:
+		return index, true
+	case index < end:
+		return 0, false
+	default:
+		return index - pruned, true
+	}
+}
+
+// normalizeConflictType maps the LLM's conflict type onto the supported set, defaulting to factual
+func normalizeConflictType(conflictType string) string {
+	switch conflictType = strings.ToLower(strings.TrimSpace(conflictType)); conflictType {
+	case "factual", "opinion", "commitment":
+		return conflictType
+	default:
+		return "factual"
+	}
+}
+
+// normalizeConflictSeverity maps the LLM's severity onto the supported set, defaulting to medium
+func normalizeConflictSeverity(severity string) string {
+	switch severity = strings.ToLower(strings.TrimSpace(severity)); severity {
+	case "low", "medium", "high":
+		return severity
+	default:
+		return "medium"
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestDetectConflictsMapsSnapshotOntoLiveTranscript(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	var snapshot []TranscriptEntry
+	for i := 0; i < 60; i++ {
+		snapshot = append(snapshot, entryAt(i*10, "Alice", fmt.Sprintf("Statement number %d", i)))
+	}
+	// The first 5 entries were pruned while the analysis ran
+	analyst.data.Transcript = append([]TranscriptEntry{}, snapshot[5:]...)
+	analyst.currentAnalysisSnapshot = snapshot
+
+	// Windows [0,25), [25,50) and [50,60) are compared newest first
+	mock := llm.NewMockLLMProvider(
+		"```json\n"+`{"conflicts": [
+			{"statement1": 30, "statement2": 55, "conflict_type": "Opinion", "severity": "critical", "explanation": "Reversed position"},
+			{"statement1": 5, "statement2": 55, "conflict_type": "factual", "severity": "low", "explanation": "Outside the earlier window"}
+		]}`+"\n```",
+		"```json\n"+`{"conflicts": [{"statement1": 3, "statement2": 52, "conflict_type": "factual", "severity": "high", "explanation": "Pruned statement"}]}`+"\n```",
+		"```json\n"+`{"conflicts": [{"statement1": 10, "statement2": 40, "conflict_type": "commitment", "severity": "high", "explanation": "Broken commitment"}]}`+"\n```",
+	)
+	analyst.llmProvider = mock
+
+	if err := analyst.detectConflicts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.Prompts()) != maxConflictWindowPairs {
+		t.Errorf("%d LLM calls, want %d window pairs", len(mock.Prompts()), maxConflictWindowPairs)
+	}
+
+	want := []StatementConflict{
+		{Statement1: 25, Statement2: 50, ConflictType: "opinion", Severity: "medium", Explanation: "Reversed position"},
+		{Statement1: 5, Statement2: 35, ConflictType: "commitment", Severity: "high", Explanation: "Broken commitment"},
+	}
+	got := analyst.data.ConflictingStatements
+	if len(got) != len(want) {
+		t.Fatalf("conflicts = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("conflict %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDetectConflictsSkipsShortMeetings(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider()
+	analyst.llmProvider = mock
+	for i := 0; i < minConflictEntries; i++ {
+		analyst.currentAnalysisSnapshot = append(analyst.currentAnalysisSnapshot, entryAt(i, "Alice", "Statement"))
+	}
+
+	if err := analyst.detectConflicts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.Prompts()) != 0 {
+		t.Error("conflicts detected in a short meeting")
+	}
+}
+
+func TestShiftConflicts(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.ConflictingStatements = []StatementConflict{
+		{Statement1: 1, Statement2: 20},
+		{Statement1: 6, Statement2: 20}, // References a pruned entry
+		{Statement1: 12, Statement2: 30},
+	}
+
+	analyst.shiftConflicts(5, 10)
+
+	got := analyst.data.ConflictingStatements
+	if len(got) != 2 || got[0].Statement1 != 1 || got[0].Statement2 != 15 || got[1].Statement1 != 7 || got[1].Statement2 != 25 {
+		t.Errorf("conflicts = %+v, want the pruned one dropped and the rest shifted by 5", got)
+	}
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	// keyContactsTranscript is the number of recent transcript entries key contacts are extracted from
+	keyContactsTranscript = 200
+	// crmExportTimeout bounds posting the key contacts to the CRM webhook
+	crmExportTimeout = 10 * time.Second
+)
+
+// crmHTTPClient posts the key contacts of finalized meetings to the CRM webhook
+var crmHTTPClient = &http.Client{Timeout: crmExportTimeout}
+
+// Roles a contact plays in the buying decision
+const (
+	ContactDecisionMaker = "decision_maker"
+	ContactInfluencer    = "influencer"
+	ContactChampion      = "champion"
+	ContactBlocker       = "blocker"
+	ContactEndUser       = "end_user"
+)
+
+// ContactInfo is a person on the customer side who took part in or was mentioned in the meeting, for CRM
+// enrichment
+type ContactInfo struct {
+	Name         string `json:"name"`
+	Title        string `json:"title,omitempty"`
+	Company      string `json:"company,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Role         string `json:"role"` // decision_maker, influencer, champion, blocker, end_user
+	MentionCount int    `json:"mention_count"`
+
+	CompanyVerified bool `json:"company_verified"` // A web search confirmed the company exists, and its name was corrected to the official one
+	TitleVerified   bool `json:"title_verified"`   // A web search confirmed the contact holds the title at the company
+}
+
+// crmContactsEvent is posted to the CRM webhook when the meeting is finalized
+type crmContactsEvent struct {
+	AgentID   string        `json:"agent_id"`
+	MeetingID string        `json:"meeting_id"`
+	Contacts  []ContactInfo `json:"contacts"`
+}
+
+// SetCRMWebhook sets the URL the key contacts are posted to when the meeting is finalized, "" to keep them
+// in the analysis only
+func (a *AnalystAgent) SetCRMWebhook(url string) {
+	a.crmWebhook = url
+}
+
+// extractKeyContacts finds the people of the customer side who took part in or were mentioned in the
+// meeting, with the contact details they gave and their role in the buying decision. Internal speakers are
+// left out. Companies and titles are checked with a web search when the provider supports grounding.
+func (a *AnalystAgent) extractKeyContacts(ctx context.Context) error {
+	transcript := a.getRecentTranscript(keyContactsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting key contacts from %d transcript entries", a.agentID, len(transcript))
+
+	text := a.formatTranscriptForLLM(transcript)
+	prompt := a.glossaryPrefix(text) + a.languagePrefix() + fmt.Sprintf(`Identify the people (PERSON entities) on the customer side of this call, both participants and people they mentioned, for updating the CRM. Leave out the vendor side's own team.
+
+For each person, give:
+- Their full name as said in the meeting, without honorifics
+- Their job title and company, when stated or clearly implied
+- Their email address and phone number, only when said in the meeting
+- Their role in the buying decision:
+  - decision_maker: signs off on the purchase or controls the budget
+  - influencer: shapes the decision without making it
+  - champion: advocates for the purchase internally
+  - blocker: opposes or slows down the purchase
+  - end_user: would use the product day to day
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "contacts": [
+    {
+      "name": "Jane Doe",
+      "title": "VP of Engineering",
+      "company": "Acme Corp",
+      "email": "",
+      "phone": "",
+      "role": "decision_maker/influencer/champion/blocker/end_user"
+    }
+  ]
+}
+`+"`"+``, text)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		Contacts []ContactInfo `json:"contacts"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse key contacts JSON: %w", err)
+	}
+
+	a.dataMutex.RLock()
+	contacts := a.filterKeyContacts(result.Contacts)
+	a.dataMutex.RUnlock()
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		if err := a.verifyKeyContacts(ctx, groundingProvider, contacts); err != nil {
+			logrus.Warnf("Agent %s: Failed to verify key contacts: %v", a.agentID, err)
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.KeyContacts = contacts
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Extracted %d key contacts", a.agentID, len(contacts))
+	return nil
+}
+
+// filterKeyContacts drops unnamed contacts, duplicates and internal speakers, whether named among the
+// internal speakers or with an email at an internal domain, normalizes the roles and counts each
+// contact's mentions and statements in the transcript. Caller must hold dataMutex.
+func (a *AnalystAgent) filterKeyContacts(candidates []ContactInfo) []ContactInfo {
+	contacts := []ContactInfo{}
+	seen := make(map[string]bool)
+	for _, contact := range candidates {
+		contact.Name = strings.TrimSpace(contact.Name)
+		contact.Email = strings.TrimSpace(contact.Email)
+		key := strings.ToLower(contact.Name)
+		if contact.Name == "" || seen[key] || a.isInternalStakeholder(contact.Name) {
+			continue
+		}
+		if contact.Email != "" && classifySpeaker(contact.Email, nil, a.config.InternalDomains) == SpeakerInternal {
+			continue
+		}
+		seen[key] = true
+
+		contact.Title = strings.TrimSpace(contact.Title)
+		contact.Company = strings.TrimSpace(contact.Company)
+		contact.Phone = strings.TrimSpace(contact.Phone)
+		contact.Role = normalizeContactRole(contact.Role)
+		contact.MentionCount = a.countContactMentions(contact.Name)
+		contact.CompanyVerified, contact.TitleVerified = false, false
+		contacts = append(contacts, contact)
+	}
+	return contacts
+}
+
+// countContactMentions counts the statements a contact spoke and the times others said their name. Caller
+// must hold dataMutex.
+func (a *AnalystAgent) countContactMentions(name string) int {
+	mention := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`)
+	count := 0
+	for _, entry := range a.data.Transcript {
+		if strings.EqualFold(entry.Speaker, name) {
+			count++
+			continue
+		}
+		if !entry.IsAgent {
+			count += len(mention.FindAllStringIndex(entry.Text, -1))
+		}
+	}
+	return count
+}
+
+// verifyKeyContacts asks a web search to confirm the company and title of the contacts that have one,
+// correcting company names to their official form
+func (a *AnalystAgent) verifyKeyContacts(ctx context.Context, provider llm.GroundingCapableProvider, contacts []ContactInfo) error {
+	var lines []string
+	numbers := make(map[int]int)
+	for i, contact := range contacts {
+		if contact.Company == "" {
+			continue
+		}
+		line := fmt.Sprintf("%d. %s at %s", len(lines)+1, contact.Name, contact.Company)
+		if contact.Title != "" {
+			line = fmt.Sprintf("%d. %s, %s at %s", len(lines)+1, contact.Name, contact.Title, contact.Company)
+		}
+		lines = append(lines, line)
+		numbers[len(lines)] = i
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to check these contacts from a sales call. For each, say whether search results confirm the company exists, giving its official name, and whether they confirm the person holds the title there. Don't confirm anything search results don't support.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "checks": [
+    {"number": 1, "company_verified": true, "official_company_name": "Acme Corporation", "title_verified": false}
+  ]
+}
+`+"`"+``, strings.Join(lines, "\n"))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil || response.GroundingMetadata == nil || len(response.GroundingMetadata.GroundingChunks) == 0 {
+		return fmt.Errorf("no search results to verify key contacts against")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in key contact verification response")
+	}
+
+	var result struct {
+		Checks []struct {
+			Number              int    `json:"number"`
+			CompanyVerified     bool   `json:"company_verified"`
+			OfficialCompanyName string `json:"official_company_name"`
+			TitleVerified       bool   `json:"title_verified"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse key contact verification JSON: %w", err)
+	}
+	for _, check := range result.Checks {
+		i, ok := numbers[check.Number]
+		if !ok || !check.CompanyVerified {
+			continue
+		}
+		contacts[i].CompanyVerified = true
+		if name := strings.TrimSpace(check.OfficialCompanyName); name != "" {
+			contacts[i].Company = name
+		}
+		contacts[i].TitleVerified = check.TitleVerified && contacts[i].Title != ""
+	}
+	return nil
+}
+
+// exportKeyContacts posts the key contacts to the CRM webhook, queueing them for retry when the post
+// fails. Nothing is posted without a webhook or without contacts.
+func (a *AnalystAgent) exportKeyContacts() error {
+	a.dataMutex.RLock()
+	event := crmContactsEvent{AgentID: a.agentID, MeetingID: a.data.MeetingID, Contacts: a.data.KeyContacts}
+	payload, err := json.Marshal(event)
+	a.dataMutex.RUnlock()
+	if a.crmWebhook == "" || len(event.Contacts) == 0 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal key contacts: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), crmExportTimeout)
+	defer cancel()
+
+	if err := postWebhook(ctx, crmHTTPClient, a.crmWebhook, nil, payload); err != nil {
+		if !a.queueWebhookRetry("crm", a.crmWebhook, payload, err) {
+			return fmt.Errorf("failed to post key contacts: %w", err)
+		}
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Exported %d key contacts to the CRM", a.agentID, len(event.Contacts))
+	return nil
+}
+
+// normalizeContactRole maps the LLM's buying role onto the supported set, defaulting to influencer
+func normalizeContactRole(role string) string {
+	switch role = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(role), " ", "_")); role {
+	case ContactDecisionMaker, ContactInfluencer, ContactChampion, ContactBlocker, ContactEndUser:
+		return role
+	case "user", "enduser":
+		return ContactEndUser
+	default:
+		return ContactInfluencer
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractKeyContacts(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.InternalSpeakers = []string{"Alice"}
+	analyst.config.InternalDomains = []string{"vendor.io"}
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"contacts": [
+		{"name": "Jane Doe", "title": "VP of Engineering", "company": "Acme Corp", "role": "Decision Maker"},
+		{"name": "Alice", "company": "Vendor", "role": "champion"},
+		{"name": "Sam Lee", "email": "sam@vendor.io", "role": "influencer"},
+		{"name": "jane doe", "role": "blocker"},
+		{"name": "Raj Patel", "role": "user"}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "Thanks for joining Jane, and Raj too")
+	say(analyst, 10, "Jane Doe", "Happy to be here, I own the budget")
+
+	if err := analyst.extractKeyContacts(context.Background()); err != nil {
+		t.Fatalf("extractKeyContacts() error = %v", err)
+	}
+
+	// The internal speaker, the colleague at the internal domain and the duplicate are dropped
+	contacts := analyst.GetAnalysis().KeyContacts
+	if len(contacts) != 2 {
+		t.Fatalf("KeyContacts = %+v, want Jane Doe and Raj Patel", contacts)
+	}
+	if jane := contacts[0]; jane.Name != "Jane Doe" || jane.Role != ContactDecisionMaker || jane.MentionCount != 1 {
+		t.Errorf("Jane Doe = %+v", jane)
+	}
+	if raj := contacts[1]; raj.Name != "Raj Patel" || raj.Role != ContactEndUser || raj.MentionCount != 0 {
+		t.Errorf("Raj Patel = %+v", raj)
+	}
+}
+
+func TestNormalizeContactRole(t *testing.T) {
+	cases := map[string]string{
+		"Decision Maker": ContactDecisionMaker,
+		"blocker":        ContactBlocker,
+		"enduser":        ContactEndUser,
+		"sponsor":        ContactInfluencer,
+	}
+	for role, want := range cases {
+		if got := normalizeContactRole(role); got != want {
+			t.Errorf("normalizeContactRole(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
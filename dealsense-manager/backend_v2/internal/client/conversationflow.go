@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Ways the discussion can move from one topic to the next
+const (
+	TransitionNatural    = "natural"    // The next topic follows from the previous one or an agenda
+	TransitionAbrupt     = "abrupt"     // The topic changes without a link to the previous one
+	TransitionTangential = "tangential" // The discussion drifts to a side topic
+)
+
+// TopicTransition is a change of discussion topic
+type TopicTransition struct {
+	FromTopic          string    `json:"from_topic"`
+	ToTopic            string    `json:"to_topic"`
+	TransitionType     string    `json:"transition_type"` // natural, abrupt, tangential
+	AtTimestamp        time.Time `json:"at_timestamp"`
+	TriggeredBySpeaker string    `json:"triggered_by_speaker,omitempty"` // First participant speaking once the new topic started
+}
+
+// conversationFlowStep is the analysis step classifying the transitions between the analysis topics
+func (a *AnalystAgent) conversationFlowStep() analysisStep {
+	return analysisStep{name: "conversation_flow", description: "classify topic transitions", run: a.analyzeConversationFlow}
+}
+
+// analyzeConversationFlow orders the analysis topics by start time and asks the LLM to classify each change
+// of topic, storing the transitions and the share of them that were natural. Topics without a parseable
+// start time are left out, as their place in the meeting is unknown.
+func (a *AnalystAgent) analyzeConversationFlow(ctx context.Context) error {
+	a.dataMutex.RLock()
+	markers := deriveChapterMarkers(a.data.Topics, a.data.StartTime, a.data.DurationMinutes)
+	startTime := a.data.StartTime
+	transcript := make([]TranscriptEntry, len(a.data.Transcript))
+	copy(transcript, a.data.Transcript)
+	a.dataMutex.RUnlock()
+
+	var transitions []TopicTransition
+	var summaries [][2]string
+	for i := 1; i < len(markers); i++ {
+		from, to := markers[i-1], markers[i]
+		if strings.EqualFold(from.Title, to.Title) {
+			continue
+		}
+		at := startTime.Add(to.StartTimeOffset)
+		transitions = append(transitions, TopicTransition{
+			FromTopic:          from.Title,
+			ToTopic:            to.Title,
+			AtTimestamp:        at,
+			TriggeredBySpeaker: firstSpeakerAfter(transcript, at),
+		})
+		summaries = append(summaries, [2]string{from.Description, to.Description})
+	}
+
+	if len(transitions) == 0 {
+		a.dataMutex.Lock()
+		a.data.ConversationFlow = nil
+		a.data.FlowCoherenceScore = 0
+		a.dataMutex.Unlock()
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Classifying %d topic transitions", a.agentID, len(transitions))
+
+	types, err := a.classifyTopicTransitions(ctx, transitions, summaries)
+	if err != nil {
+		return err
+	}
+
+	natural := 0
+	for i := range transitions {
+		transitions[i].TransitionType = normalizeTransitionType(types[i+1])
+		if transitions[i].TransitionType == TransitionNatural {
+			natural++
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.ConversationFlow = transitions
+	a.data.FlowCoherenceScore = 100 * float64(natural) / float64(len(transitions))
+	a.dataMutex.Unlock()
+	return nil
+}
+
+// classifyTopicTransitions asks the LLM for the type of each transition, returning the types keyed by the
+// transition's 1-based number
+func (a *AnalystAgent) classifyTopicTransitions(ctx context.Context, transitions []TopicTransition, summaries [][2]string) (map[int]string, error) {
+	var list strings.Builder
+	for i, transition := range transitions {
+		list.WriteString(fmt.Sprintf("%d. From %q (%s) to %q (%s)\n", i+1,
+			transition.FromTopic, summaries[i][0], transition.ToTopic, summaries[i][1]))
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`These are the consecutive changes of topic in a meeting, each with the summaries of both topics. Classify how the discussion moved from one topic to the next:
+- natural: the new topic follows from the previous one, or the meeting moved on to its next planned item
+- abrupt: the topic changed without any link to the previous one, for example after an interruption
+- tangential: the discussion drifted to a side topic only loosely related to the previous one
+
+Transitions:
+%s
+Provide your response in the following JSON format within a code block:
+`+"```"+`json
+{
+  "transitions": [
+    {"number": 1, "transition_type": "natural/abrupt/tangential"}
+  ]
+}
+`+"```", list.String())
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[int]string, len(transitions))
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return types, nil
+	}
+
+	var result struct {
+		Transitions []struct {
+			Number         int    `json:"number"`
+			TransitionType string `json:"transition_type"`
+		} `json:"transitions"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse topic transitions JSON: %w", err)
+	}
+	for _, transition := range result.Transitions {
+		types[transition.Number] = transition.TransitionType
+	}
+	return types, nil
+}
+
+// firstSpeakerAfter returns the first participant speaking at or after a time, or "" when nobody did
+func firstSpeakerAfter(transcript []TranscriptEntry, at time.Time) string {
+	for _, entry := range transcript {
+		if !entry.IsAgent && !entry.Timestamp.Before(at) {
+			return entry.Speaker
+		}
+	}
+	return ""
+}
+
+// normalizeTransitionType maps the LLM's transition type onto the supported set, defaulting to natural
+func normalizeTransitionType(transitionType string) string {
+	switch transitionType = strings.ToLower(strings.TrimSpace(transitionType)); transitionType {
+	case TransitionNatural, TransitionAbrupt, TransitionTangential:
+		return transitionType
+	default:
+		return TransitionNatural
+	}
+}
+
+// GenerateMermaidFlowchart renders topic transitions as a Mermaid left-to-right flowchart with a node per
+// topic and an edge per transition labeled with its type: solid for natural, dotted for tangential and
+// thick for abrupt transitions
+func GenerateMermaidFlowchart(flow []TopicTransition) string {
+	var chart strings.Builder
+	chart.WriteString("graph LR\n")
+
+	nodes := make(map[string]string)
+	node := func(topic string) string {
+		if id, ok := nodes[topic]; ok {
+			return id
+		}
+		id := fmt.Sprintf("t%d", len(nodes))
+		nodes[topic] = id
+		chart.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", id, mermaidLabel(topic)))
+		return id
+	}
+
+	var edges strings.Builder
+	for _, transition := range flow {
+		from, to := node(transition.FromTopic), node(transition.ToTopic)
+		arrow := "-->"
+		switch transition.TransitionType {
+		case TransitionTangential:
+			arrow = "-.->"
+		case TransitionAbrupt:
+			arrow = "==>"
+		}
+		label := transition.TransitionType
+		if label == "" {
+			label = TransitionNatural
+		}
+		edges.WriteString(fmt.Sprintf("    %s %s|%s| %s\n", from, arrow, label, to))
+	}
+
+	chart.WriteString(edges.String())
+	return chart.String()
+}
+
+// mermaidLabel escapes a topic for use as a quoted Mermaid node label
+func mermaidLabel(topic string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ", "\r", "").Replace(strings.TrimSpace(topic))
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMermaidFlowchart(t *testing.T) {
+	chart := GenerateMermaidFlowchart([]TopicTransition{
+		{FromTopic: "Roadmap", ToTopic: "Pricing", TransitionType: TransitionNatural},
+		{FromTopic: "Pricing", ToTopic: `The "new" office`, TransitionType: TransitionTangential},
+		{FromTopic: `The "new" office`, ToTopic: "Roadmap", TransitionType: TransitionAbrupt},
+		{FromTopic: "Roadmap", ToTopic: "Pricing"},
+	})
+
+	want := `graph LR
+    t0["Roadmap"]
+    t1["Pricing"]
+    t2["The #quot;new#quot; office"]
+    t0 -->|natural| t1
+    t1 -.->|tangential| t2
+    t2 ==>|abrupt| t0
+    t0 -->|natural| t1
+`
+	if chart != want {
+		t.Errorf("chart =\n%s\nwant\n%s", chart, want)
+	}
+
+	node := regexp.MustCompile(`^    t\d+\["[^"\n]*"\]$`)
+	edge := regexp.MustCompile(`^    t\d+ (-->|-\.->|==>)\|(natural|tangential|abrupt)\| t\d+$`)
+	lines := strings.Split(strings.TrimSuffix(chart, "\n"), "\n")
+	for _, line := range lines[1:] {
+		if !node.MatchString(line) && !edge.MatchString(line) {
+			t.Errorf("invalid Mermaid line %q", line)
+		}
+	}
+}
+
+func TestGenerateMermaidFlowchartWithoutTransitions(t *testing.T) {
+	if chart := GenerateMermaidFlowchart(nil); chart != "graph LR\n" {
+		t.Errorf("chart = %q", chart)
+	}
+}
+
+func TestNormalizeTransitionType(t *testing.T) {
+	tests := map[string]string{" Abrupt ": TransitionAbrupt, "tangential": TransitionTangential, "sudden": TransitionNatural, "": TransitionNatural}
+	for transitionType, want := range tests {
+		if got := normalizeTransitionType(transitionType); got != want {
+			t.Errorf("normalizeTransitionType(%q) = %q, want %q", transitionType, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// promptCorrections is the number of most recent corrections of a section included in its prompt
+const promptCorrections = 10
+
+// correctableSections are the analysis sections reviewers can correct
+var correctableSections = map[string]bool{"summary": true, "key_points": true, "action_items": true, "topics": true}
+
+var (
+	// ErrInvalidCorrectionSection is returned when correcting a section that can't be corrected
+	ErrInvalidCorrectionSection = errors.New("section must be summary, key_points, action_items or topics")
+	// ErrEmptyCorrection is returned when a correction doesn't say what was wrong
+	ErrEmptyCorrection = errors.New("original value is required")
+)
+
+// Correction is a reviewer's fix of an analysis result, shown to the LLM in later analyses of the section
+type Correction struct {
+	Section        string    `json:"section"` // summary, key_points, action_items or topics
+	OriginalValue  string    `json:"original_value"`
+	CorrectedValue string    `json:"corrected_value,omitempty"` // Empty when the original value should not have been produced
+	SubmittedAt    time.Time `json:"submitted_at"`
+}
+
+// SubmitCorrection records that a result of section was wrong, so the next analyses of that section are
+// told about the mistake. correctedValue may be empty when the original value shouldn't have been produced.
+func (a *AnalystAgent) SubmitCorrection(ctx context.Context, section string, originalValue, correctedValue string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !correctableSections[section] {
+		return ErrInvalidCorrectionSection
+	}
+	originalValue, correctedValue = strings.TrimSpace(originalValue), strings.TrimSpace(correctedValue)
+	if originalValue == "" {
+		return ErrEmptyCorrection
+	}
+
+	a.dataMutex.Lock()
+	a.data.Corrections = append(a.data.Corrections, Correction{
+		Section:        section,
+		OriginalValue:  originalValue,
+		CorrectedValue: correctedValue,
+		SubmittedAt:    time.Now(),
+	})
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Recorded a %s correction", a.agentID, section)
+
+	if err := a.saveAnalysis(); err != nil {
+		return fmt.Errorf("failed to save correction: %w", err)
+	}
+	return nil
+}
+
+// correctionsPrefix lists the most recent corrections of an analysis type so the LLM avoids repeating
+// them, or returns "" when the type has none
+func (a *AnalystAgent) correctionsPrefix(analysisType string) string {
+	a.dataMutex.RLock()
+	var corrections []Correction
+	for _, correction := range a.data.Corrections {
+		if correction.Section == analysisType {
+			corrections = append(corrections, correction)
+		}
+	}
+	a.dataMutex.RUnlock()
+
+	if len(corrections) == 0 {
+		return ""
+	}
+	if len(corrections) > promptCorrections {
+		corrections = corrections[len(corrections)-promptCorrections:]
+	}
+
+	var prefix strings.Builder
+	prefix.WriteString("Previous analysis included the following errors that were manually corrected:\n")
+	for _, correction := range corrections {
+		if correction.CorrectedValue == "" {
+			fmt.Fprintf(&prefix, "- %q was wrong and should not be included\n", correction.OriginalValue)
+		} else {
+			fmt.Fprintf(&prefix, "- %q was wrong; the correct version is %q\n", correction.OriginalValue, correction.CorrectedValue)
+		}
+	}
+	prefix.WriteString("Do not repeat these mistakes.\n\n")
+	return prefix.String()
+}
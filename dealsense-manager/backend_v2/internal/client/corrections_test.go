@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestCorrectionAppearsInNextPrompt(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider(keyPointsResponse)
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "The launch moves to June")
+
+	ctx := context.Background()
+	if err := analyst.SubmitCorrection(ctx, "key_points", "Launch moved to May", "Launch moved to June"); err != nil {
+		t.Fatalf("SubmitCorrection() error = %v", err)
+	}
+	if err := analyst.SubmitCorrection(ctx, "summary", "The budget was cut", ""); err != nil {
+		t.Fatalf("SubmitCorrection() error = %v", err)
+	}
+	if err := analyst.extractKeyPoints(ctx); err != nil {
+		t.Fatalf("extractKeyPoints() error = %v", err)
+	}
+
+	prompt := mock.Prompts()[0]
+	want := "Previous analysis included the following errors that were manually corrected:\n" +
+		"- \"Launch moved to May\" was wrong; the correct version is \"Launch moved to June\"\n" +
+		"Do not repeat these mistakes.\n\n"
+	if !strings.Contains(prompt, want) {
+		t.Errorf("prompt is missing the correction block:\n%s", prompt)
+	}
+	// Corrections of other sections stay out of the prompt
+	if strings.Contains(prompt, "The budget was cut") {
+		t.Errorf("key points prompt includes a summary correction:\n%s", prompt)
+	}
+}
+
+func TestSubmitCorrectionValidates(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	ctx := context.Background()
+
+	if err := analyst.SubmitCorrection(ctx, "sentiment", "positive", "negative"); !errors.Is(err, ErrInvalidCorrectionSection) {
+		t.Errorf("unknown section error = %v, want ErrInvalidCorrectionSection", err)
+	}
+	if err := analyst.SubmitCorrection(ctx, "summary", "  ", "Better summary"); !errors.Is(err, ErrEmptyCorrection) {
+		t.Errorf("empty original error = %v, want ErrEmptyCorrection", err)
+	}
+	if corrections := analyst.GetAnalysis().Corrections; len(corrections) != 0 {
+		t.Errorf("Corrections = %+v, want none stored", corrections)
+	}
+}
+
+func TestCorrectionsPrefixKeepsMostRecent(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	for i := 0; i <= promptCorrections; i++ {
+		analyst.data.Corrections = append(analyst.data.Corrections, Correction{Section: "topics", OriginalValue: string(rune('A' + i))})
+	}
+
+	prefix := analyst.correctionsPrefix("topics")
+	if strings.Contains(prefix, `"A"`) || !strings.Contains(prefix, `"K" was wrong and should not be included`) {
+		t.Errorf("correctionsPrefix() = %q, want the %d most recent", prefix, promptCorrections)
+	}
+	if got := analyst.correctionsPrefix("summary"); got != "" {
+		t.Errorf("correctionsPrefix() without corrections = %q", got)
+	}
+}
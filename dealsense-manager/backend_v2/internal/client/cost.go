@@ -0,0 +1,98 @@
+package client
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultHourlyRateUSD is the hourly rate assumed for participants without a configured rate
+const DefaultHourlyRateUSD = 75.0
+
+// MeetingCostEstimate estimates what the meeting cost from the time each participant spent speaking
+type MeetingCostEstimate struct {
+	TotalCostUSD        float64           `json:"total_cost_usd"`
+	CostPerMinuteUSD    float64           `json:"cost_per_minute_usd"` // Total cost over the meeting's duration
+	ParticipantCosts    []ParticipantCost `json:"participant_costs"`
+	CostEfficiencyScore float64           `json:"cost_efficiency_score"` // Action items generated per dollar
+}
+
+// ParticipantCost is one participant's share of the meeting cost
+type ParticipantCost struct {
+	Name            string  `json:"name"`
+	HourlyRateUSD   float64 `json:"hourly_rate_usd"`
+	SpeakingMinutes float64 `json:"speaking_minutes"`
+	CostUSD         float64 `json:"cost_usd"`
+}
+
+// SetDefaultHourlyRate sets the hourly rate assumed for participants without one in ParticipantHourlyRates
+func (a *AnalystAgent) SetDefaultHourlyRate(rate float64) {
+	if rate > 0 {
+		a.defaultHourlyRate = rate
+	}
+}
+
+// updateCostEstimate estimates the meeting's cost from the finished transcript
+func (a *AnalystAgent) updateCostEstimate() {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	a.data.CostEstimate = estimateMeetingCost(a.data, a.config.ParticipantHourlyRates, a.defaultHourlyRate)
+}
+
+// GetCostEstimate returns the cost estimated when the meeting was finalized, or nil
+func (a *AnalystAgent) GetCostEstimate() *MeetingCostEstimate {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return a.data.CostEstimate.clone()
+}
+
+// estimateMeetingCost prices each participant's speaking time at their hourly rate, looked up by name
+// ignoring case, or defaultRate
+func estimateMeetingCost(data *AnalysisData, rates map[string]float64, defaultRate float64) *MeetingCostEstimate {
+	ratesByName := make(map[string]float64, len(rates))
+	for name, rate := range rates {
+		ratesByName[strings.ToLower(strings.TrimSpace(name))] = rate
+	}
+
+	spoken := SpeakingSeconds(data.Transcript)
+	speakers := make([]string, 0, len(spoken))
+	for speaker := range spoken {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	estimate := &MeetingCostEstimate{ParticipantCosts: make([]ParticipantCost, 0, len(speakers))}
+	for _, speaker := range speakers {
+		rate, ok := ratesByName[strings.ToLower(speaker)]
+		if !ok {
+			rate = defaultRate
+		}
+		cost := spoken[speaker] * rate / 3600
+		estimate.ParticipantCosts = append(estimate.ParticipantCosts, ParticipantCost{
+			Name:            speaker,
+			HourlyRateUSD:   rate,
+			SpeakingMinutes: spoken[speaker] / 60,
+			CostUSD:         cost,
+		})
+		estimate.TotalCostUSD += cost
+	}
+
+	if data.DurationMinutes > 0 {
+		estimate.CostPerMinuteUSD = estimate.TotalCostUSD / data.DurationMinutes
+	}
+	if estimate.TotalCostUSD > 0 {
+		estimate.CostEfficiencyScore = float64(len(data.ActionItems)) / estimate.TotalCostUSD
+	}
+	return estimate
+}
+
+// clone returns a deep copy of the estimate, or nil
+func (e *MeetingCostEstimate) clone() *MeetingCostEstimate {
+	if e == nil {
+		return nil
+	}
+	estimate := *e
+	estimate.ParticipantCosts = append([]ParticipantCost(nil), e.ParticipantCosts...)
+	return &estimate
+}
@@ -0,0 +1,50 @@
+package client
+
+import "testing"
+
+func TestEstimateMeetingCost(t *testing.T) {
+	data := &AnalysisData{
+		DurationMinutes: 30,
+		Transcript: []TranscriptEntry{
+			// 150 words at 2.5 words a second is a minute of speaking
+			{Speaker: "Alice", Text: wordsOf(150)},
+			{Speaker: "Bob", Text: wordsOf(300)},
+			{Speaker: "Agent", Text: wordsOf(300), IsAgent: true},
+		},
+		ActionItems: []ActionItem{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+	}
+
+	estimate := estimateMeetingCost(data, map[string]float64{" alice ": 120}, 60)
+	if len(estimate.ParticipantCosts) != 2 {
+		t.Fatalf("participants = %+v, want the agent excluded", estimate.ParticipantCosts)
+	}
+	alice, bob := estimate.ParticipantCosts[0], estimate.ParticipantCosts[1]
+	if alice.Name != "Alice" || alice.HourlyRateUSD != 120 || !approx(alice.SpeakingMinutes, 1) || !approx(alice.CostUSD, 2) {
+		t.Errorf("Alice = %+v, want a minute at the configured rate", alice)
+	}
+	if bob.HourlyRateUSD != 60 || !approx(bob.CostUSD, 2) {
+		t.Errorf("Bob = %+v, want two minutes at the default rate", bob)
+	}
+	if !approx(estimate.TotalCostUSD, 4) || !approx(estimate.CostPerMinuteUSD, 4.0/30) {
+		t.Errorf("total = %v, per minute = %v", estimate.TotalCostUSD, estimate.CostPerMinuteUSD)
+	}
+	if !approx(estimate.CostEfficiencyScore, 0.75) {
+		t.Errorf("efficiency = %v, want 3 action items over $4", estimate.CostEfficiencyScore)
+	}
+}
+
+func TestEstimateMeetingCostWithoutSpeech(t *testing.T) {
+	estimate := estimateMeetingCost(&AnalysisData{ActionItems: []ActionItem{{ID: "1"}}}, nil, DefaultHourlyRateUSD)
+	if estimate.TotalCostUSD != 0 || estimate.CostPerMinuteUSD != 0 || estimate.CostEfficiencyScore != 0 {
+		t.Errorf("estimate = %+v, want zero cost without dividing by it", estimate)
+	}
+}
+
+// wordsOf returns text of n words
+func wordsOf(n int) string {
+	words := make([]byte, 0, 2*n)
+	for i := 0; i < n; i++ {
+		words = append(words, "w "...)
+	}
+	return string(words)
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// crosstalkGap is the maximum time between entries from different speakers to count as crosstalk
+	crosstalkGap = time.Second
+	// crosstalkMaxWords is the length below which both entries must be to count as fragments
+	crosstalkMaxWords = 10
+)
+
+// CrosstalkEvent records a period where several participants spoke over each other
+type CrosstalkEvent struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Speakers  []string  `json:"speakers"`
+}
+
+// detectCrosstalk checks whether the newest transcript entry overlaps the one before it and records
+// or extends a crosstalk event (caller must hold dataMutex)
+func (a *AnalystAgent) detectCrosstalk() {
+	count := len(a.data.Transcript)
+	if count < 2 {
+		return
+	}
+
+	current := a.data.Transcript[count-1]
+	previous := a.data.Transcript[count-2]
+
+	gap := current.Timestamp.Sub(previous.Timestamp)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > crosstalkGap || current.Speaker == previous.Speaker ||
+		len(strings.Fields(current.Text)) >= crosstalkMaxWords ||
+		len(strings.Fields(previous.Text)) >= crosstalkMaxWords {
+		return
+	}
+
+	start, end := previous.Timestamp, current.Timestamp
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	// Merge with the last event if this continues the same burst of overlapping speech
+	if n := len(a.data.CrosstalkEvents); n > 0 {
+		last := &a.data.CrosstalkEvents[n-1]
+		if !start.After(last.EndTime.Add(crosstalkGap)) {
+			if end.After(last.EndTime) {
+				last.EndTime = end
+			}
+			last.Speakers = appendUnique(last.Speakers, previous.Speaker, current.Speaker)
+			return
+		}
+	}
+
+	a.data.CrosstalkEvents = append(a.data.CrosstalkEvents, CrosstalkEvent{
+		StartTime: start,
+		EndTime:   end,
+		Speakers:  []string{previous.Speaker, current.Speaker},
+	})
+}
+
+// updateCrosstalkRate recomputes crosstalk events per minute of meeting (caller must hold dataMutex)
+func (a *AnalystAgent) updateCrosstalkRate() {
+	if a.data.DurationMinutes <= 0 {
+		a.data.CrosstalkRate = 0
+		return
+	}
+	a.data.CrosstalkRate = float64(len(a.data.CrosstalkEvents)) / a.data.DurationMinutes
+}
+
+// appendUnique appends the values not already present in the slice
+func appendUnique(values []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, value := range values {
+			if value == addition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
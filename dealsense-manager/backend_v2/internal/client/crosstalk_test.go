@@ -0,0 +1,73 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCrosstalkBetweenTwoSpeakers(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "So the launch date")
+	say(analyst, 1, "Bob", "Wait, which date")
+
+	events := analyst.GetAnalysis().CrosstalkEvents
+	if len(events) != 1 {
+		t.Fatalf("events = %+v, want one", events)
+	}
+	if !reflect.DeepEqual(events[0].Speakers, []string{"Alice", "Bob"}) {
+		t.Errorf("speakers = %q", events[0].Speakers)
+	}
+	if !events[0].StartTime.Equal(testMeetingStart) || events[0].EndTime.Sub(events[0].StartTime).Seconds() != 1 {
+		t.Errorf("event = %v to %v", events[0].StartTime, events[0].EndTime)
+	}
+}
+
+func TestCrosstalkBurstWithSeveralSpeakers(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "So the launch date")
+	say(analyst, 1, "Bob", "Wait, which date")
+	say(analyst, 1, "Carol", "Friday I think")
+	say(analyst, 2, "Alice", "No, Monday")
+
+	events := analyst.GetAnalysis().CrosstalkEvents
+	if len(events) != 1 {
+		t.Fatalf("events = %+v, want the burst merged into one", events)
+	}
+	if !reflect.DeepEqual(events[0].Speakers, []string{"Alice", "Bob", "Carol"}) {
+		t.Errorf("speakers = %q, want every speaker once", events[0].Speakers)
+	}
+	if events[0].EndTime.Sub(testMeetingStart).Seconds() != 2 {
+		t.Errorf("event ends at %v, want extended to the last fragment", events[0].EndTime)
+	}
+}
+
+func TestNoCrosstalk(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	// Same speaker continuing
+	say(analyst, 0, "Alice", "So the launch date")
+	say(analyst, 1, "Alice", "is next Friday")
+	// A later reply
+	say(analyst, 10, "Bob", "Sounds good")
+	// A full sentence rather than a fragment
+	say(analyst, 11, "Carol", "I still need to confirm the venue and the catering before we announce anything")
+
+	if events := analyst.GetAnalysis().CrosstalkEvents; len(events) != 0 {
+		t.Errorf("events = %+v, want none", events)
+	}
+}
+
+func TestSeparateCrosstalkEvents(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "So the launch date")
+	say(analyst, 1, "Bob", "Wait, which date")
+	say(analyst, 30, "Carol", "Quick question")
+	say(analyst, 30, "Dave", "Go ahead")
+
+	events := analyst.GetAnalysis().CrosstalkEvents
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want two", events)
+	}
+	if !reflect.DeepEqual(events[1].Speakers, []string{"Carol", "Dave"}) {
+		t.Errorf("second event speakers = %q", events[1].Speakers)
+	}
+}
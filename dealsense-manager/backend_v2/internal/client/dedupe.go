@@ -0,0 +1,88 @@
+package client
+
+import "strings"
+
+// actionItemDuplicateRatio is the largest edit distance, relative to the longer description, at which two
+// action items are treated as the same item
+const actionItemDuplicateRatio = 0.10
+
+// deduplicateActionItems adds newly extracted action items to the existing ones. A new item whose
+// description is within 10% edit distance of an existing one replaces it, keeping the existing item's
+// status, creation time and feedback and recording its ID in MergedFromIDs; other items are appended.
+func deduplicateActionItems(existing, extracted []ActionItem) []ActionItem {
+	items := append([]ActionItem(nil), existing...)
+	for _, item := range extracted {
+		match := -1
+		for i := range items {
+			if isDuplicateActionItem(items[i].Description, item.Description) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			items = append(items, item)
+			continue
+		}
+
+		previous := items[match]
+		item.Status = previous.Status
+		item.CreatedAt = previous.CreatedAt
+		item.UserFeedback = previous.UserFeedback
+		if item.Assignee == "" {
+			item.Assignee = previous.Assignee
+		}
+		if item.DueDate == "" {
+			item.DueDate = previous.DueDate
+		}
+		if item.Sentiment == "" {
+			item.Sentiment = previous.Sentiment
+			item.SentimentScore = previous.SentimentScore
+			item.RaisedInContext = previous.RaisedInContext
+		}
+		if len(item.DependsOn) == 0 {
+			item.DependsOn = previous.DependsOn
+		}
+		if item.BlockingAttachment == nil {
+			item.BlockingAttachment = previous.BlockingAttachment
+		}
+		item.MergedFromIDs = append([]string(nil), previous.MergedFromIDs...)
+		if previous.ID != item.ID {
+			item.MergedFromIDs = append(item.MergedFromIDs, previous.ID)
+		}
+		items[match] = item
+	}
+	return items
+}
+
+// isDuplicateActionItem reports whether two descriptions differ by at most actionItemDuplicateRatio of the
+// longer one's length, ignoring case and spacing
+func isDuplicateActionItem(a, b string) bool {
+	first := []rune(strings.Join(strings.Fields(strings.ToLower(a)), " "))
+	second := []rune(strings.Join(strings.Fields(strings.ToLower(b)), " "))
+	longer := max(len(first), len(second))
+	if longer == 0 {
+		return true
+	}
+	return float64(levenshtein(first, second)) <= actionItemDuplicateRatio*float64(longer)
+}
+
+// levenshtein returns the number of single-rune insertions, deletions and substitutions turning a into b
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDeduplicateActionItems(t *testing.T) {
+	created := testMeetingStart
+	existing := []ActionItem{
+		{ID: "1", Description: "Send the pricing deck to Acme", Assignee: "Maya", Status: "in_progress", CreatedAt: created},
+		{ID: "2", Description: "Book the offsite venue", Status: "pending", CreatedAt: created},
+	}
+	extracted := []ActionItem{
+		// Exact duplicate
+		{ID: "3", Description: "Book the offsite venue", Status: "pending", CreatedAt: created.Add(time.Hour)},
+		// Near duplicate with different capitalization and a small rewording
+		{ID: "4", Description: "send the pricing deck to ACME.", Status: "pending", DueDate: "2024-05-03", CreatedAt: created.Add(time.Hour)},
+		// Genuinely distinct
+		{ID: "5", Description: "Send the security questionnaire to Acme", Status: "pending"},
+	}
+
+	items := deduplicateActionItems(existing, extracted)
+	if len(items) != 3 {
+		t.Fatalf("items = %+v, want 3", items)
+	}
+
+	deck := items[0]
+	if deck.ID != "4" || deck.Description != "send the pricing deck to ACME." || deck.DueDate != "2024-05-03" {
+		t.Errorf("merged item = %+v, want the newer version", deck)
+	}
+	if deck.Status != "in_progress" || deck.Assignee != "Maya" || !deck.CreatedAt.Equal(created) {
+		t.Errorf("merged item = %+v, want the existing status, assignee and creation time kept", deck)
+	}
+	if !reflect.DeepEqual(deck.MergedFromIDs, []string{"1"}) {
+		t.Errorf("merged from %q, want [1]", deck.MergedFromIDs)
+	}
+	if items[1].ID != "3" || !reflect.DeepEqual(items[1].MergedFromIDs, []string{"2"}) {
+		t.Errorf("exact duplicate = %+v, want it merged into item 2", items[1])
+	}
+	if items[2].ID != "5" || items[2].MergedFromIDs != nil {
+		t.Errorf("distinct item = %+v, want it appended", items[2])
+	}
+
+	// A later run keeps the audit trail
+	again := deduplicateActionItems(items, []ActionItem{{ID: "6", Description: "Send the pricing deck to Acme"}})
+	if !reflect.DeepEqual(again[0].MergedFromIDs, []string{"1", "4"}) {
+		t.Errorf("merged from %q, want [1 4]", again[0].MergedFromIDs)
+	}
+}
+
+func TestIsDuplicateActionItem(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Review the contract", "Review the contract", true},
+		{"Review the contract", "  REVIEW   the contract ", true},
+		{"Review the contract", "Review the contracts", true},
+		{"Review the contract", "Review the budget", false},
+		{"Call Bob", "Call Rob", false}, // One edit is more than 10% of a short description
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		if got := isDuplicateActionItem(tt.a, tt.b); got != tt.want {
+			t.Errorf("isDuplicateActionItem(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"café", "cafe", 1},
+		{"same", "same", 0},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/mailer"
+)
+
+// digestTemplate wraps the rendered analysis in a minimal HTML email layout
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Title}}</title></head>
+<body style="font-family: Arial, sans-serif; line-height: 1.5; color: #222; max-width: 720px;">
+{{range .Blocks}}{{.}}
+{{end}}</body>
+</html>
+`))
+
+// SetMailer sets the mailer used to send the post-meeting digest
+func (a *AnalystAgent) SetMailer(m mailer.Mailer) {
+	a.mailer = m
+}
+
+// sendDigest emails the formatted analysis to the configured post-meeting recipients
+func (a *AnalystAgent) sendDigest() error {
+	recipients := a.config.PostMeetingEmailRecipients
+	if a.mailer == nil || len(recipients) == 0 {
+		return nil
+	}
+
+	a.dataMutex.RLock()
+	hasTranscript := len(a.data.Transcript) > 0
+	a.dataMutex.RUnlock()
+	if !hasTranscript {
+		logrus.Infof("Skipping meeting digest for agent %s: no transcript recorded", a.agentID)
+		return nil
+	}
+
+	subject := "Meeting Summary"
+	if a.config.Name != "" {
+		subject = fmt.Sprintf("Meeting Summary - %s", a.config.Name)
+	}
+
+	// Provider-hosted templates render the analysis data themselves
+	if templateMailer, ok := a.mailer.(mailer.TemplateMailer); ok && templateMailer.UsesTemplate() {
+		if err := templateMailer.SendTemplate(recipients, subject, a.GetAnalysis()); err != nil {
+			return fmt.Errorf("failed to send meeting digest: %w", err)
+		}
+		logrus.Infof("Sent meeting digest for agent %s to %d recipients", a.agentID, len(recipients))
+		return nil
+	}
+
+	plainBody := a.GetFormattedAnalysis()
+	htmlBody, err := renderDigestHTML(plainBody)
+	if err != nil {
+		return fmt.Errorf("failed to render meeting digest: %w", err)
+	}
+
+	if err := a.mailer.SendDigest(recipients, subject, htmlBody, plainBody); err != nil {
+		return fmt.Errorf("failed to send meeting digest: %w", err)
+	}
+
+	logrus.Infof("Sent meeting digest for agent %s to %d recipients", a.agentID, len(recipients))
+	return nil
+}
+
+// renderDigestHTML converts the markdown produced by GetFormattedAnalysis into an HTML email
+func renderDigestHTML(markdown string) (string, error) {
+	var blocks []template.HTML
+	var list []string
+
+	flushList := func() {
+		if len(list) > 0 {
+			blocks = append(blocks, template.HTML("<ul>"+strings.Join(list, "")+"</ul>"))
+			list = nil
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flushList()
+		case strings.HasPrefix(trimmed, "### "):
+			flushList()
+			blocks = append(blocks, template.HTML("<h3>"+inlineMarkdownToHTML(trimmed[4:])+"</h3>"))
+		case strings.HasPrefix(trimmed, "## "):
+			flushList()
+			blocks = append(blocks, template.HTML("<h2>"+inlineMarkdownToHTML(trimmed[3:])+"</h2>"))
+		case strings.HasPrefix(trimmed, "# "):
+			flushList()
+			blocks = append(blocks, template.HTML("<h1>"+inlineMarkdownToHTML(trimmed[2:])+"</h1>"))
+		case strings.HasPrefix(trimmed, "- "):
+			list = append(list, "<li>"+inlineMarkdownToHTML(trimmed[2:])+"</li>")
+		default:
+			flushList()
+			blocks = append(blocks, template.HTML("<p>"+inlineMarkdownToHTML(trimmed)+"</p>"))
+		}
+	}
+	flushList()
+
+	var buf bytes.Buffer
+	err := digestTemplate.Execute(&buf, struct {
+		Title  string
+		Blocks []template.HTML
+	}{
+		Title:  "Meeting Analysis Report",
+		Blocks: blocks,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// inlineMarkdownToHTML escapes text and converts **bold** spans to <strong> tags
+func inlineMarkdownToHTML(text string) string {
+	parts := strings.Split(text, "**")
+	var result strings.Builder
+	for i, part := range parts {
+		escaped := markdownLinksToHTML(part)
+		// Odd-indexed parts sit between a pair of ** markers; an unmatched trailing marker is left as text
+		if i%2 == 1 && i < len(parts)-1 {
+			result.WriteString("<strong>" + escaped + "</strong>")
+		} else {
+			if i%2 == 1 {
+				result.WriteString("**")
+			}
+			result.WriteString(escaped)
+		}
+	}
+	return result.String()
+}
+
+// markdownLink matches [text](url) links to web pages
+var markdownLink = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^\s)]+)\)`)
+
+// markdownLinksToHTML escapes text, converting markdown links to anchors
+func markdownLinksToHTML(text string) string {
+	var result strings.Builder
+	last := 0
+	for _, match := range markdownLink.FindAllStringSubmatchIndex(text, -1) {
+		result.WriteString(template.HTMLEscapeString(text[last:match[0]]))
+		result.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`,
+			template.HTMLEscapeString(text[match[4]:match[5]]),
+			template.HTMLEscapeString(text[match[2]:match[3]])))
+		last = match[1]
+	}
+	result.WriteString(template.HTMLEscapeString(text[last:]))
+	return result.String()
+}
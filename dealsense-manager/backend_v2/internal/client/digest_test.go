@@ -0,0 +1,72 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+// recordingTemplateMailer records the templated emails it is asked to send
+type recordingTemplateMailer struct {
+	recordingMailer
+	templated []*AnalysisData
+}
+
+func (m *recordingTemplateMailer) UsesTemplate() bool { return true }
+
+func (m *recordingTemplateMailer) SendTemplate(to []string, subject string, data interface{}) error {
+	m.templated = append(m.templated, data.(*AnalysisData))
+	return nil
+}
+
+func TestDigestIsSentToRecipients(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mail := &recordingMailer{}
+	analyst.SetMailer(mail)
+	analyst.config.Name = "Weekly sync"
+	analyst.config.PostMeetingEmailRecipients = []string{"alice@example.com", "bob@example.com"}
+
+	// Nothing is sent for a meeting without a transcript
+	if err := analyst.sendDigest(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatal("digest sent without a transcript")
+	}
+
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+	analyst.dataMutex.Lock()
+	analyst.data.Summary = "The team reviewed the rollout."
+	analyst.dataMutex.Unlock()
+	if err := analyst.sendDigest(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mail.sent) != 1 {
+		t.Fatalf("sent %d digests, want 1", len(mail.sent))
+	}
+	sent := mail.sent[0]
+	if sent.subject != "Meeting Summary - Weekly sync" || len(sent.to) != 2 {
+		t.Errorf("digest to %v with subject %q", sent.to, sent.subject)
+	}
+	if !strings.Contains(sent.plainBody, "The team reviewed the rollout.") {
+		t.Errorf("digest body is missing the summary:\n%s", sent.plainBody)
+	}
+}
+
+func TestDigestUsesProviderTemplate(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mail := &recordingTemplateMailer{}
+	analyst.SetMailer(mail)
+	analyst.config.PostMeetingEmailRecipients = []string{"alice@example.com"}
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+
+	if err := analyst.sendDigest(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mail.templated) != 1 || len(mail.sent) != 0 {
+		t.Fatalf("sent %d templated and %d plain digests, want only the template", len(mail.templated), len(mail.sent))
+	}
+	if len(mail.templated[0].Transcript) != 1 {
+		t.Errorf("template data = %+v, want the analysis", mail.templated[0])
+	}
+}
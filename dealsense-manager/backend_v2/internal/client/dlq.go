@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultDLQCapacity bounds the number of failed steps kept in memory
+	DefaultDLQCapacity = 100
+	// DefaultMaxDLQRetries is the number of retries before a failed step is given up on
+	DefaultMaxDLQRetries = 5
+
+	dlqBaseBackoff = 30 * time.Second
+	dlqMaxBackoff  = 30 * time.Minute
+)
+
+// ErrDLQItemNotFound is returned when retrying an item that is not in the queue
+var ErrDLQItemNotFound = errors.New("dead letter item not found")
+
+// FailedAnalysisStep is an analysis step that failed and is waiting to be retried
+type FailedAnalysisStep struct {
+	ID                 string            `json:"id"`
+	AgentID            string            `json:"agent_id"`
+	StepName           string            `json:"step_name"`
+	TranscriptSnapshot []TranscriptEntry `json:"transcript_snapshot"`
+	Error              string            `json:"error"`
+	FailedAt           time.Time         `json:"failed_at"`
+	Attempts           int               `json:"attempts"`
+	NextRetryAt        time.Time         `json:"next_retry_at"`
+}
+
+// RetryFunc re-runs a failed analysis step
+type RetryFunc func(step *FailedAnalysisStep) error
+
+// DeadLetterQueue holds failed analysis steps in a bounded in-memory queue and retries them with exponential backoff
+type DeadLetterQueue struct {
+	mu         sync.Mutex
+	items      []*FailedAnalysisStep
+	capacity   int
+	maxRetries int
+	retry      RetryFunc
+}
+
+// NewDeadLetterQueue creates a dead letter queue holding at most capacity items
+func NewDeadLetterQueue(capacity, maxRetries int) *DeadLetterQueue {
+	if capacity <= 0 {
+		capacity = DefaultDLQCapacity
+	}
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxDLQRetries
+	}
+
+	return &DeadLetterQueue{
+		items:      []*FailedAnalysisStep{},
+		capacity:   capacity,
+		maxRetries: maxRetries,
+	}
+}
+
+// Push adds a failed step to the queue, dropping the oldest item when the queue is full
+func (q *DeadLetterQueue) Push(step FailedAnalysisStep) *FailedAnalysisStep {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if step.ID == "" {
+		step.ID = fmt.Sprintf("dlq_%s", uuid.New().String()[:8])
+	}
+	if step.FailedAt.IsZero() {
+		step.FailedAt = time.Now()
+	}
+	step.NextRetryAt = step.FailedAt.Add(dlqBackoff(step.Attempts))
+
+	if len(q.items) >= q.capacity {
+		dropped := q.items[0]
+		q.items = q.items[1:]
+		logrus.Warnf("Dead letter queue full, dropping %s step for agent %s", dropped.StepName, dropped.AgentID)
+	}
+
+	item := step
+	q.items = append(q.items, &item)
+	return &item
+}
+
+// List returns a copy of the pending items
+func (q *DeadLetterQueue) List() []FailedAnalysisStep {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := make([]FailedAnalysisStep, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, *item)
+	}
+	return items
+}
+
+// Retry immediately retries the item with the given ID, regardless of its backoff
+func (q *DeadLetterQueue) Retry(id string) error {
+	q.mu.Lock()
+	item := q.remove(id)
+	q.mu.Unlock()
+
+	if item == nil {
+		return fmt.Errorf("%w: %s", ErrDLQItemNotFound, id)
+	}
+
+	return q.attempt(item)
+}
+
+// Start runs the retry worker until the context is cancelled
+func (q *DeadLetterQueue) Start(ctx context.Context, interval time.Duration, retry RetryFunc) {
+	q.mu.Lock()
+	q.retry = retry
+	q.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				q.retryDue(time.Now())
+			}
+		}
+	}()
+}
+
+// retryDue retries every item whose backoff has elapsed
+func (q *DeadLetterQueue) retryDue(now time.Time) {
+	q.mu.Lock()
+	var due []*FailedAnalysisStep
+	remaining := q.items[:0]
+	for _, item := range q.items {
+		if now.Before(item.NextRetryAt) {
+			remaining = append(remaining, item)
+		} else {
+			due = append(due, item)
+		}
+	}
+	q.items = remaining
+	q.mu.Unlock()
+
+	for _, item := range due {
+		if err := q.attempt(item); err != nil {
+			logrus.Debugf("Dead letter retry of %s for agent %s failed: %v", item.StepName, item.AgentID, err)
+		}
+	}
+}
+
+// attempt runs the retry function for an item that has already been removed from the queue,
+// re-queuing it with a longer backoff if it fails and retries remain
+func (q *DeadLetterQueue) attempt(item *FailedAnalysisStep) error {
+	q.mu.Lock()
+	retry := q.retry
+	q.mu.Unlock()
+
+	if retry == nil {
+		q.requeue(item)
+		return fmt.Errorf("dead letter retry worker is not running")
+	}
+
+	err := retry(item)
+	item.Attempts++
+	if err == nil {
+		logrus.Infof("Recovered %s step for agent %s after %d dead letter retries", item.StepName, item.AgentID, item.Attempts)
+		return nil
+	}
+
+	item.Error = err.Error()
+	if item.Attempts >= q.maxRetries {
+		logrus.Errorf("Giving up on %s step for agent %s after %d dead letter retries: %v", item.StepName, item.AgentID, item.Attempts, err)
+		return err
+	}
+
+	item.NextRetryAt = time.Now().Add(dlqBackoff(item.Attempts))
+	q.requeue(item)
+	return err
+}
+
+// requeue puts an item back on the queue
+func (q *DeadLetterQueue) requeue(item *FailedAnalysisStep) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) >= q.capacity {
+		q.items = q.items[1:]
+	}
+	q.items = append(q.items, item)
+}
+
+// remove takes the item with the given ID off the queue (caller must hold mu)
+func (q *DeadLetterQueue) remove(id string) *FailedAnalysisStep {
+	for i, item := range q.items {
+		if item.ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return item
+		}
+	}
+	return nil
+}
+
+// dlqBackoff returns the delay before the next retry after the given number of attempts
+func dlqBackoff(attempts int) time.Duration {
+	backoff := dlqBaseBackoff
+	for i := 0; i < attempts && backoff < dlqMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > dlqMaxBackoff {
+		backoff = dlqMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeadLetterQueueWorkerRetriesDueItems(t *testing.T) {
+	queue := NewDeadLetterQueue(10, 3)
+	queue.Push(FailedAnalysisStep{AgentID: "agent-1", StepName: "summary", FailedAt: time.Now().Add(-time.Hour)})
+	queue.Push(FailedAnalysisStep{AgentID: "agent-1", StepName: "sentiment", FailedAt: time.Now()})
+
+	retried := make(chan string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queue.Start(ctx, 10*time.Millisecond, func(step *FailedAnalysisStep) error {
+		retried <- step.StepName
+		return nil
+	})
+
+	select {
+	case step := <-retried:
+		if step != "summary" {
+			t.Errorf("retried %s, want the step whose backoff elapsed", step)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker didn't retry the due item")
+	}
+
+	// The step that just failed is still backing off
+	time.Sleep(50 * time.Millisecond)
+	items := queue.List()
+	if len(items) != 1 || items[0].StepName != "sentiment" {
+		t.Errorf("queue = %+v, want only the step still backing off", items)
+	}
+}
+
+func TestDeadLetterQueueBacksOffAndGivesUp(t *testing.T) {
+	queue := NewDeadLetterQueue(10, 2)
+	item := queue.Push(FailedAnalysisStep{AgentID: "agent-1", StepName: "summary", FailedAt: time.Now().Add(-time.Hour)})
+	queue.retry = func(step *FailedAnalysisStep) error { return errors.New("still failing") }
+
+	queue.retryDue(time.Now())
+	items := queue.List()
+	if len(items) != 1 || items[0].Attempts != 1 || items[0].Error != "still failing" {
+		t.Fatalf("queue = %+v, want the item requeued after its first retry", items)
+	}
+	if wait := time.Until(items[0].NextRetryAt); wait < dlqBaseBackoff || wait > dlqBackoff(1) {
+		t.Errorf("next retry in %v, want about %v", wait, dlqBackoff(1))
+	}
+
+	if err := queue.Retry(item.ID); err == nil {
+		t.Error("Retry() succeeded with a failing step")
+	}
+	if items := queue.List(); len(items) != 0 {
+		t.Errorf("queue = %+v, want the item given up on after its last retry", items)
+	}
+}
+
+func TestDeadLetterQueueRetry(t *testing.T) {
+	queue := NewDeadLetterQueue(10, 3)
+	item := queue.Push(FailedAnalysisStep{AgentID: "agent-1", StepName: "summary"})
+
+	if err := queue.Retry(item.ID); err == nil {
+		t.Error("Retry() succeeded without a running worker")
+	}
+	if len(queue.List()) != 1 {
+		t.Fatal("item dropped when retried without a worker")
+	}
+
+	queue.retry = func(step *FailedAnalysisStep) error { return nil }
+	if err := queue.Retry(item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(queue.List()) != 0 {
+		t.Error("recovered item left in the queue")
+	}
+	if err := queue.Retry(item.ID); !errors.Is(err, ErrDLQItemNotFound) {
+		t.Errorf("Retry() = %v, want ErrDLQItemNotFound", err)
+	}
+}
+
+func TestDeadLetterQueueDropsOldestWhenFull(t *testing.T) {
+	queue := NewDeadLetterQueue(2, 3)
+	for _, step := range []string{"summary", "sentiment", "actions"} {
+		queue.Push(FailedAnalysisStep{AgentID: "agent-1", StepName: step})
+	}
+
+	items := queue.List()
+	if len(items) != 2 || items[0].StepName != "sentiment" || items[1].StepName != "actions" {
+		t.Errorf("queue = %+v, want the two newest steps", items)
+	}
+}
+
+func TestDLQBackoff(t *testing.T) {
+	tests := map[int]time.Duration{0: 30 * time.Second, 1: time.Minute, 3: 4 * time.Minute, 20: dlqMaxBackoff}
+	for attempts, want := range tests {
+		if got := dlqBackoff(attempts); got != want {
+			t.Errorf("dlqBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EmailDraft is a follow-up email the organizer can send after the meeting
+type EmailDraft struct {
+	Subject             string   `json:"subject"`
+	ToSuggestions       []string `json:"to_suggestions"`
+	BodyMarkdown        string   `json:"body_markdown"`      // Ends with the action items table
+	ActionItemsTable    string   `json:"action_items_table"` // Markdown table of every action item
+	NextMeetingProposal string   `json:"next_meeting_proposal,omitempty"`
+}
+
+// draftFollowUpEmail asks the LLM to write the follow-up email from the summary, decisions and action
+// items. The action items table is built from the analysis so no item is left out.
+func (a *AnalystAgent) draftFollowUpEmail(ctx context.Context) error {
+	a.dataMutex.RLock()
+	var tasks, decisions []string
+	for _, item := range a.data.ActionItems {
+		line := item.Description
+		if item.Assignee != "" {
+			line += " (" + item.Assignee + ")"
+		}
+		if item.Type == "decision" {
+			decisions = append(decisions, line)
+		} else {
+			tasks = append(tasks, line)
+		}
+	}
+	table := actionItemsTable(a.data.ActionItems)
+	summary := a.data.Summary
+	recipients := emailRecipientSuggestions(a.data.Participants, a.config.PostMeetingEmailRecipients)
+	followUp := "None planned"
+	if suggestion := a.data.FollowUpSuggestion; suggestion != nil && suggestion.Recommended {
+		followUp = fmt.Sprintf("%d minutes, %s. Agenda:\n%s", suggestion.SuggestedDurationMinutes,
+			strings.ReplaceAll(suggestion.Urgency, "_", " "), bulletList(suggestion.SuggestedAgenda))
+	}
+	a.dataMutex.RUnlock()
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Write a professional follow-up email from the meeting organizer to the participants. Thank them briefly, recap the outcome and the decisions made, and close with the next steps. Keep it concise and friendly. Do not list the action items; a table of them is appended to the email.
+
+Meeting summary:
+%s
+
+Decisions:
+%s
+
+Action items:
+%s
+
+Recommended follow-up meeting:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "subject": "Email subject line",
+  "body_markdown": "Email body in markdown, without the action items",
+  "next_meeting_proposal": "One or two sentences proposing the next meeting, or empty if none is needed"
+}
+`+"`"+``, summary, bulletList(decisions), bulletList(tasks), followUp)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in follow-up email response")
+	}
+
+	var draft EmailDraft
+	if err := json.Unmarshal([]byte(jsonData), &draft); err != nil {
+		return fmt.Errorf("failed to parse follow-up email JSON: %w", err)
+	}
+
+	draft.Subject = strings.TrimSpace(draft.Subject)
+	draft.ToSuggestions = recipients
+	draft.ActionItemsTable = table
+	draft.BodyMarkdown = strings.TrimSpace(draft.BodyMarkdown)
+	if table != "" {
+		draft.BodyMarkdown += "\n\n**Action items**\n\n" + table
+	}
+
+	a.dataMutex.Lock()
+	a.data.FollowUpEmailDraft = &draft
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Drafted follow-up email %q", a.agentID, draft.Subject)
+	return nil
+}
+
+// GetFollowUpEmailDraft returns the follow-up email drafted when the meeting was finalized, or nil
+func (a *AnalystAgent) GetFollowUpEmailDraft() *EmailDraft {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	if a.data.FollowUpEmailDraft == nil {
+		return nil
+	}
+	draft := *a.data.FollowUpEmailDraft
+	draft.ToSuggestions = append([]string{}, draft.ToSuggestions...)
+	return &draft
+}
+
+// actionItemsTable formats action items as a markdown table, or "" when there are none
+func actionItemsTable(items []ActionItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	var table strings.Builder
+	table.WriteString("| Action item | Owner | Priority |\n|---|---|---|\n")
+	for _, item := range items {
+		owner := item.Assignee
+		if owner == "" {
+			owner = "Unassigned"
+		}
+		table.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+			cell.Replace(item.Description), cell.Replace(owner), cell.Replace(item.Priority)))
+	}
+	return table.String()
+}
+
+// emailRecipientSuggestions lists the digest recipients followed by participants not already included
+func emailRecipientSuggestions(participants, digestRecipients []string) []string {
+	suggestions := []string{}
+	seen := make(map[string]bool)
+	for _, recipient := range append(append([]string{}, digestRecipients...), participants...) {
+		key := strings.ToLower(strings.TrimSpace(recipient))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		suggestions = append(suggestions, strings.TrimSpace(recipient))
+	}
+	return suggestions
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestActionItemsTable(t *testing.T) {
+	if table := actionItemsTable(nil); table != "" {
+		t.Errorf("actionItemsTable(nil) = %q, want empty", table)
+	}
+
+	table := actionItemsTable([]ActionItem{
+		{Description: "Compare A | B\nthen decide", Assignee: "Bob", Priority: "high"},
+		{Description: "Book the room", Priority: "low"},
+	})
+	want := "| Action item | Owner | Priority |\n|---|---|---|\n" +
+		"| Compare A \\| B then decide | Bob | high |\n" +
+		"| Book the room | Unassigned | low |\n"
+	if table != want {
+		t.Errorf("actionItemsTable() = %q, want %q", table, want)
+	}
+}
+
+func TestEmailRecipientSuggestions(t *testing.T) {
+	got := emailRecipientSuggestions([]string{"Alice", "bob@example.com", " ", "Carol"}, []string{" Bob@Example.com ", "lead@example.com"})
+	want := []string{"Bob@Example.com", "lead@example.com", "Alice", "Carol"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("emailRecipientSuggestions() = %v, want %v", got, want)
+	}
+	if got := emailRecipientSuggestions(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("emailRecipientSuggestions(nil, nil) = %#v, want an empty list", got)
+	}
+}
+
+func TestDraftFollowUpEmailAppendsActionItems(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n" + `{"subject": " Rollout recap ", "body_markdown": "Thanks all.\n", "next_meeting_proposal": "Meet on Monday."}` + "\n```")
+	analyst.llmProvider = mock
+	analyst.config.PostMeetingEmailRecipients = []string{"lead@example.com"}
+	analyst.data.Participants = []string{"Alice", "Bob"}
+	analyst.data.ActionItems = []ActionItem{
+		{Description: "Email the customer", Assignee: "Bob", Priority: "high"},
+		{Description: "Ship on Monday", Type: "decision"},
+	}
+	analyst.data.FollowUpSuggestion = &FollowUpSuggestion{Recommended: true, SuggestedDurationMinutes: 30, Urgency: "this_week", SuggestedAgenda: []string{"Pricing"}}
+
+	if err := analyst.draftFollowUpEmail(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := mock.Prompts()[0]
+	for _, want := range []string{"Decisions:\n- Ship on Monday", "Action items:\n- Email the customer (Bob)", "30 minutes, this week"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	draft := analyst.GetFollowUpEmailDraft()
+	if draft == nil {
+		t.Fatal("no draft stored")
+	}
+	if draft.Subject != "Rollout recap" || draft.NextMeetingProposal != "Meet on Monday." {
+		t.Errorf("draft = %+v", draft)
+	}
+	if !reflect.DeepEqual(draft.ToSuggestions, []string{"lead@example.com", "Alice", "Bob"}) {
+		t.Errorf("ToSuggestions = %v", draft.ToSuggestions)
+	}
+	if draft.BodyMarkdown != "Thanks all.\n\n**Action items**\n\n"+draft.ActionItemsTable {
+		t.Errorf("BodyMarkdown = %q", draft.BodyMarkdown)
+	}
+	if !strings.Contains(draft.ActionItemsTable, "| Ship on Monday | Unassigned |") {
+		t.Errorf("decisions missing from the table:\n%s", draft.ActionItemsTable)
+	}
+
+	// The returned draft is a copy
+	draft.ToSuggestions[0] = "changed"
+	if analyst.GetFollowUpEmailDraft().ToSuggestions[0] != "lead@example.com" {
+		t.Error("GetFollowUpEmailDraft() shares its recipients with the stored draft")
+	}
+}
+
+func TestGetFollowUpEmailDraftBeforeFinalize(t *testing.T) {
+	if draft := newTestAnalyst(t).GetFollowUpEmailDraft(); draft != nil {
+		t.Errorf("GetFollowUpEmailDraft() = %+v, want nil", draft)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// generateEmotionalArc asks the LLM to tell the story of the meeting's emotional journey from its sentiment
+// timeline, the participants' moods and the moments they talked over each other. It is only run when the
+// meeting is finalized, so the narrative covers the whole meeting and costs a single call.
+func (a *AnalystAgent) generateEmotionalArc(ctx context.Context) error {
+	a.dataMutex.RLock()
+	var sentiment, moods, crosstalk strings.Builder
+	for _, point := range a.data.SentimentTimeline {
+		sentiment.WriteString(fmt.Sprintf("- %s: %s (%.2f)\n", point.Timestamp.Format("15:04"), point.Sentiment, point.Score))
+	}
+	speakers := make([]string, 0, len(a.data.SpeakerMoodTimeline))
+	for speaker := range a.data.SpeakerMoodTimeline {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+	for _, speaker := range speakers {
+		var points []string
+		for _, point := range a.data.SpeakerMoodTimeline[speaker] {
+			points = append(points, fmt.Sprintf("%s %s", point.Timestamp.Format("15:04"), point.Mood))
+		}
+		moods.WriteString(fmt.Sprintf("- %s: %s\n", speaker, strings.Join(points, ", ")))
+	}
+	for _, event := range a.data.CrosstalkEvents {
+		crosstalk.WriteString(fmt.Sprintf("- %s–%s: %s\n", event.StartTime.Format("15:04:05"), event.EndTime.Format("15:04:05"), strings.Join(event.Speakers, ", ")))
+	}
+	a.dataMutex.RUnlock()
+
+	if sentiment.Len() == 0 && moods.Len() == 0 {
+		return nil
+	}
+
+	none := func(section strings.Builder) string {
+		if section.Len() == 0 {
+			return "None recorded\n"
+		}
+		return section.String()
+	}
+	prompt := a.languagePrefix() + fmt.Sprintf(`Write a 2-paragraph narrative describing the emotional journey of this meeting, noting key turning points, high-tension moments, and how the group's energy shifted.
+
+Base it only on the data below, referring to moments by their time. Write plain prose without headings or lists.
+
+Sentiment over the meeting (score from -1 negative to 1 positive):
+%s
+Participants' moods:
+%s
+Moments participants talked over each other:
+%s`, none(sentiment), none(moods), none(crosstalk))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	narrative := strings.TrimSpace(response)
+	if narrative == "" {
+		return fmt.Errorf("empty emotional arc narrative")
+	}
+
+	a.dataMutex.Lock()
+	a.data.EmotionalArcNarrative = narrative
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Wrote emotional arc narrative (%d characters)", a.agentID, len(narrative))
+	return nil
+}
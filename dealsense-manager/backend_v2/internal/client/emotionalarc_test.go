@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestGenerateEmotionalArc(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("  The meeting started calmly.\n\nIt ended on a high.  ")
+	analyst.llmProvider = mock
+	analyst.data.SentimentTimeline = []SentimentPoint{
+		{Timestamp: testMeetingStart, Sentiment: "neutral", Score: 0},
+		{Timestamp: testMeetingStart.Add(5 * time.Minute), Sentiment: "negative", Score: -0.5},
+	}
+	analyst.data.SpeakerMoodTimeline = map[string][]MoodPoint{
+		"Bob":   {{Timestamp: testMeetingStart.Add(3 * time.Minute), Mood: "frustrated"}},
+		"Alice": {{Timestamp: testMeetingStart.Add(3 * time.Minute), Mood: "excited"}},
+	}
+
+	if err := analyst.generateEmotionalArc(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := mock.Prompts()[0]
+	for _, want := range []string{"- 10:05: negative (-0.50)", "- Alice: 10:03 excited\n- Bob: 10:03 frustrated", "talked over each other:\nNone recorded"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if got := analyst.data.EmotionalArcNarrative; got != "The meeting started calmly.\n\nIt ended on a high." {
+		t.Errorf("EmotionalArcNarrative = %q", got)
+	}
+}
+
+func TestGenerateEmotionalArcSkipsWithoutTimelines(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider()
+	analyst.llmProvider = mock
+	// Crosstalk alone isn't enough to tell the story
+	analyst.data.CrosstalkEvents = []CrosstalkEvent{{StartTime: testMeetingStart, EndTime: testMeetingStart, Speakers: []string{"Alice", "Bob"}}}
+
+	if err := analyst.generateEmotionalArc(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.Prompts()) != 0 {
+		t.Error("narrative requested without sentiment or mood data")
+	}
+}
+
+func TestGenerateEmotionalArcRejectsEmptyNarrative(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("   ")
+	analyst.data.SentimentTimeline = []SentimentPoint{{Timestamp: testMeetingStart, Sentiment: "neutral"}}
+
+	if err := analyst.generateEmotionalArc(context.Background()); err == nil {
+		t.Error("generateEmotionalArc() error = nil, want an empty narrative error")
+	}
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// silentPeriodThreshold is how long a participant must go without speaking to count a silent period
+	silentPeriodThreshold = 30 * time.Second
+	// wordsPerSecond estimates speaking rate, as transcript entries only record when speech started
+	wordsPerSecond = 2.5
+	// maxAttentionLag is the longest response latency counted for attention; later turns likely start a
+	// new topic rather than respond
+	maxAttentionLag = 60.0
+	// maxAttentionSamples bounds the response latencies kept per participant, dropping the oldest
+	maxAttentionSamples = 500
+)
+
+// EngagementStats describes how a participant takes part in the conversation
+type EngagementStats struct {
+	ResponseLatencyAvgSeconds float64 `json:"response_latency_avg_seconds"` // Time from another speaker finishing to this speaker starting
+	MonologueLengthAvg        float64 `json:"monologue_length_avg"`         // Average words per uninterrupted turn
+	QuestionsAsked            int     `json:"questions_asked"`
+	QuestionsAnswered         int     `json:"questions_answered"`
+	SilentPeriodCount         int     `json:"silent_period_count"` // Gaps of more than 30 seconds between the speaker's turns
+
+	// Running totals the averages are computed from
+	Responses   int       `json:"responses"`
+	Turns       int       `json:"turns"`
+	TurnWords   int       `json:"turn_words"`
+	LastSpokeAt time.Time `json:"last_spoke_at"`
+
+	// Response latencies in seconds of at most a minute, the attention scores are computed from
+	AttentionSamples []float64 `json:"attention_samples,omitempty"`
+}
+
+// updateEngagement updates the speaker engagement map with the newest transcript entry (caller must
+// hold dataMutex)
+func (a *AnalystAgent) updateEngagement() {
+	count := len(a.data.Transcript)
+	if count == 0 {
+		return
+	}
+	if a.data.SpeakerEngagementMap == nil {
+		a.data.SpeakerEngagementMap = make(map[string]EngagementStats)
+	}
+
+	current := a.data.Transcript[count-1]
+	stats := a.data.SpeakerEngagementMap[current.Speaker]
+	words := len(strings.Fields(current.Text))
+
+	if !stats.LastSpokeAt.IsZero() && current.Timestamp.Sub(stats.LastSpokeAt) > silentPeriodThreshold {
+		stats.SilentPeriodCount++
+	}
+	stats.LastSpokeAt = current.Timestamp
+
+	if count > 1 && a.data.Transcript[count-2].Speaker == current.Speaker {
+		// The speaker is continuing their turn
+		stats.TurnWords += words
+	} else {
+		stats.Turns++
+		stats.TurnWords += words
+
+		if count > 1 {
+			previous := a.data.Transcript[count-2]
+			latency := responseLatency(previous, current)
+			stats.ResponseLatencyAvgSeconds = (stats.ResponseLatencyAvgSeconds*float64(stats.Responses) + latency) /
+				float64(stats.Responses+1)
+			stats.Responses++
+
+			if latency <= maxAttentionLag {
+				stats.AttentionSamples = append(stats.AttentionSamples, latency)
+				if len(stats.AttentionSamples) > maxAttentionSamples {
+					stats.AttentionSamples = stats.AttentionSamples[len(stats.AttentionSamples)-maxAttentionSamples:]
+				}
+			}
+			if strings.Contains(previous.Text, "?") {
+				stats.QuestionsAnswered++
+			}
+		}
+	}
+	stats.MonologueLengthAvg = float64(stats.TurnWords) / float64(stats.Turns)
+
+	if strings.Contains(current.Text, "?") {
+		stats.QuestionsAsked++
+	}
+
+	a.data.SpeakerEngagementMap[current.Speaker] = stats
+	a.data.AttentionScores = computeAttentionScores(a.data.SpeakerEngagementMap)
+}
+
+// computeAttentionScores scores each participant's attention from 0 to 100 by how quickly they respond to
+// other speakers. Every response latency is normalized against the fastest and slowest responses of the
+// meeting, so the fastest scores 1 and the slowest 0, and a participant's score is 100 times the average of
+// their normalized responses: 100 means they always responded fastest. Participants without responses
+// within a minute aren't scored, and everyone scores 100 when all responses were equally fast.
+func computeAttentionScores(engagement map[string]EngagementStats) map[string]float64 {
+	fastest, slowest := math.Inf(1), math.Inf(-1)
+	for _, stats := range engagement {
+		for _, lag := range stats.AttentionSamples {
+			fastest = min(fastest, lag)
+			slowest = max(slowest, lag)
+		}
+	}
+
+	scores := make(map[string]float64)
+	for speaker, stats := range engagement {
+		if len(stats.AttentionSamples) == 0 {
+			continue
+		}
+		var total float64
+		for _, lag := range stats.AttentionSamples {
+			if slowest > fastest {
+				total += (slowest - lag) / (slowest - fastest)
+			} else {
+				total++
+			}
+		}
+		scores[speaker] = 100 * total / float64(len(stats.AttentionSamples))
+	}
+	return scores
+}
+
+// responseLatency estimates the seconds between previous finishing and current starting, never negative.
+// The end of the previous entry is estimated from its length.
+func responseLatency(previous, current TranscriptEntry) float64 {
+	spoken := time.Duration(float64(len(strings.Fields(previous.Text))) / wordsPerSecond * float64(time.Second))
+	latency := current.Timestamp.Sub(previous.Timestamp.Add(spoken)).Seconds()
+	return max(latency, 0)
+}
+
+// GetEngagementReport formats the speaker engagement map as a Markdown table
+func (a *AnalystAgent) GetEngagementReport() string {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	speakers := make([]string, 0, len(a.data.SpeakerEngagementMap))
+	for speaker := range a.data.SpeakerEngagementMap {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	var result strings.Builder
+	result.WriteString("| Speaker | Avg Response Latency (s) | Avg Monologue (words) | Questions Asked | Questions Answered | Silent Periods |\n")
+	result.WriteString("|---|---|---|---|---|---|\n")
+	for _, speaker := range speakers {
+		stats := a.data.SpeakerEngagementMap[speaker]
+		result.WriteString(fmt.Sprintf("| %s | %.1f | %.1f | %d | %d | %d |\n",
+			strings.ReplaceAll(speaker, "|", "\\|"), stats.ResponseLatencyAvgSeconds, stats.MonologueLengthAvg,
+			stats.QuestionsAsked, stats.QuestionsAnswered, stats.SilentPeriodCount))
+	}
+	return result.String()
+}
+
+// SpeakingSeconds estimates how long each participant spoke from their word count, skipping the agent
+func SpeakingSeconds(transcript []TranscriptEntry) map[string]float64 {
+	seconds := make(map[string]float64)
+	for _, entry := range transcript {
+		if !entry.IsAgent {
+			seconds[entry.Speaker] += float64(len(strings.Fields(entry.Text))) / wordsPerSecond
+		}
+	}
+	return seconds
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResponseLatency(t *testing.T) {
+	// Five words take two seconds to say, so a reply three seconds after they started comes a second later
+	previous := entryAt(0, "Alice", "one two three four five")
+	if got := responseLatency(previous, entryAt(3, "Bob", "ok")); math.Abs(got-1) > 1e-9 {
+		t.Errorf("latency = %v, want 1", got)
+	}
+	if got := responseLatency(previous, entryAt(1, "Bob", "ok")); got != 0 {
+		t.Errorf("latency = %v, want interruptions counted as 0", got)
+	}
+}
+
+func TestEngagementAcrossSpeakerTransitions(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "Can we ship Friday?")
+	say(analyst, 5, "Bob", "Yes we can")
+	say(analyst, 6, "Bob", "if legal signs off")
+	say(analyst, 50, "Alice", "Great, let's do that")
+
+	engagement := analyst.GetAnalysis().SpeakerEngagementMap
+	alice, bob := engagement["Alice"], engagement["Bob"]
+
+	// Bob replied 5s after a 4-word question that took 1.6s to say
+	if math.Abs(bob.ResponseLatencyAvgSeconds-3.4) > 1e-9 || bob.Responses != 1 {
+		t.Errorf("Bob latency = %v over %d responses, want 3.4 over 1", bob.ResponseLatencyAvgSeconds, bob.Responses)
+	}
+	if bob.Turns != 1 || bob.MonologueLengthAvg != 7 || bob.QuestionsAnswered != 1 {
+		t.Errorf("Bob = %+v, want one 7-word turn answering a question", bob)
+	}
+	// Alice's reply came 44s after Bob's last entry began, which took 1.6s to say
+	if math.Abs(alice.ResponseLatencyAvgSeconds-42.4) > 1e-9 {
+		t.Errorf("Alice latency = %v, want 42.4", alice.ResponseLatencyAvgSeconds)
+	}
+	if alice.QuestionsAsked != 1 || alice.SilentPeriodCount != 1 || alice.Turns != 2 {
+		t.Errorf("Alice = %+v, want a question, a silent period and two turns", alice)
+	}
+}
+
+func TestComputeAttentionScores(t *testing.T) {
+	scores := computeAttentionScores(map[string]EngagementStats{
+		"Fast":   {AttentionSamples: []float64{1, 1}},
+		"Slow":   {AttentionSamples: []float64{11}},
+		"Mixed":  {AttentionSamples: []float64{1, 11}},
+		"Silent": {},
+	})
+
+	want := map[string]float64{"Fast": 100, "Slow": 0, "Mixed": 50}
+	for speaker, score := range want {
+		if math.Abs(scores[speaker]-score) > 1e-9 {
+			t.Errorf("%s score = %v, want %v", speaker, scores[speaker], score)
+		}
+	}
+	if _, ok := scores["Silent"]; ok {
+		t.Error("participant without responses was scored")
+	}
+
+	equal := computeAttentionScores(map[string]EngagementStats{"A": {AttentionSamples: []float64{4}}, "B": {AttentionSamples: []float64{4}}})
+	if equal["A"] != 100 || equal["B"] != 100 {
+		t.Errorf("scores = %v, want 100 for equally fast responses", equal)
+	}
+}
+
+func TestAttentionExcludesLateResponses(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "Any updates?")
+	say(analyst, 2, "Bob", "Yes, two of them")
+	say(analyst, 200, "Carol", "Sorry, I was away")
+
+	data := analyst.GetAnalysis()
+	if samples := data.SpeakerEngagementMap["Carol"].AttentionSamples; len(samples) != 0 {
+		t.Errorf("Carol's samples = %v, want a reply after more than 60 seconds excluded", samples)
+	}
+	if _, ok := data.AttentionScores["Carol"]; ok {
+		t.Error("Carol scored without a response within a minute")
+	}
+	if data.AttentionScores["Bob"] != 100 {
+		t.Errorf("Bob's score = %v, want 100 as the only scored participant", data.AttentionScores["Bob"])
+	}
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ethicsTranscript is the number of recent transcript entries checked for ethics issues
+const ethicsTranscript = 50
+
+// Categories of ethics issues
+const (
+	EthicsDiscrimination        = "discrimination"
+	EthicsHarassment            = "harassment"
+	EthicsConfidentialityBreach = "confidentiality_breach"
+	EthicsInappropriateLanguage = "inappropriate_language"
+)
+
+// EthicsFlag is a statement with discriminatory, harassing or otherwise inappropriate language, for HR review
+type EthicsFlag struct {
+	Category          string    `json:"category"` // discrimination, harassment, confidentiality_breach, inappropriate_language
+	Severity          string    `json:"severity"` // low, medium, high
+	Quote             string    `json:"quote"`    // Verbatim text of the statement
+	Speaker           string    `json:"speaker"`
+	Timestamp         time.Time `json:"timestamp"`
+	ActionRecommended string    `json:"action_recommended,omitempty"`
+}
+
+// ethicsStep is the analysis step flagging ethics issues, run over the whole meeting's analysis rather than
+// per window so each high-severity flag alerts once
+func (a *AnalystAgent) ethicsStep() analysisStep {
+	return analysisStep{name: "ethics", description: "detect ethics issues", run: a.detectEthicsIssues}
+}
+
+// SetHRNotificationEmail sets the address high-severity ethics flags are emailed to, "" to only alert the
+// Discord error webhook
+func (a *AnalystAgent) SetHRNotificationEmail(email string) {
+	a.hrNotificationEmail = email
+}
+
+// detectEthicsIssues flags discriminatory, harassing, confidentiality-breaching or inappropriate statements.
+// Quotes the LLM didn't copy verbatim are replaced with the whole statement. Flags accumulate across
+// analysis runs and each new high-severity flag raises an error-level alert, which the Discord hook sends
+// to the error webhook, and is emailed to the HR notification address right away.
+func (a *AnalystAgent) detectEthicsIssues(ctx context.Context) error {
+	transcript := a.getRecentTranscript(ethicsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Checking %d transcript entries for ethics issues", a.agentID, len(transcript))
+
+	var result struct {
+		Flags []struct {
+			Statement         int    `json:"statement"`
+			Category          string `json:"category"`
+			Severity          string `json:"severity"`
+			Quote             string `json:"quote"`
+			ActionRecommended string `json:"action_recommended"`
+		} `json:"flags"`
+	}
+	request := indexedStatementsRequest{
+		task: `Review this meeting transcript for language an HR team needs to know about:
+- discrimination: remarks demeaning or excluding people for their gender, race, ethnicity, religion, age, disability, sexual orientation or other protected characteristics
+- harassment: threats, intimidation, bullying, unwelcome sexual remarks or personal attacks on a participant or colleague
+- confidentiality_breach: sharing confidential employee information such as salaries, health or disciplinary matters, or trade secrets with people who shouldn't have them
+- inappropriate_language: profanity or slurs directed at people, or otherwise unprofessional language
+
+Only flag what was actually said. Do not flag discussion of these topics, such as reviewing the harassment policy, or mild casual profanity not directed at anyone.
+Rate severity as high for slurs, threats, sexual harassment or disclosed confidential employee information, medium for clearly inappropriate remarks, and low for borderline ones.
+
+For each flag, copy the offending words exactly as they appear in the statement, without paraphrasing or correcting them, and recommend an action for HR in one sentence.`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "flags": [
+    {
+      "statement": 12,
+      "category": "discrimination/harassment/confidentiality_breach/inappropriate_language",
+      "severity": "low/medium/high",
+      "quote": "Exact words from the statement",
+      "action_recommended": "Follow up with the speaker's manager about the remark."
+    }
+  ]
+}`,
+		result: "ethics flags",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return err
+	}
+
+	var found []EthicsFlag
+	for _, candidate := range result.Flags {
+		category := normalizeEthicsCategory(candidate.Category)
+		statement, ok := statementAt(transcript, candidate.Statement)
+		if !ok || category == "" {
+			continue
+		}
+		found = append(found, EthicsFlag{
+			Category:          category,
+			Severity:          normalizeComplianceSeverity(candidate.Severity),
+			Quote:             verbatimQuote(candidate.Quote, statement.Text),
+			Speaker:           statement.Speaker,
+			Timestamp:         statement.Timestamp,
+			ActionRecommended: strings.TrimSpace(candidate.ActionRecommended),
+		})
+	}
+
+	a.dataMutex.Lock()
+	seen := make(map[string]bool)
+	for _, flag := range a.data.EthicsFlags {
+		seen[ethicsFlagKey(flag)] = true
+	}
+	var added []EthicsFlag
+	for _, flag := range found {
+		if key := ethicsFlagKey(flag); !seen[key] {
+			seen[key] = true
+			added = append(added, flag)
+		}
+	}
+	a.data.EthicsFlags = append(a.data.EthicsFlags, added...)
+	meetingID := a.data.MeetingID
+	a.dataMutex.Unlock()
+
+	for _, flag := range added {
+		if flag.Severity == "high" {
+			a.alertEthicsFlag(meetingID, flag)
+		}
+	}
+	if len(added) > 0 {
+		logrus.Infof("Agent %s: Found %d new ethics flags", a.agentID, len(added))
+	}
+	return nil
+}
+
+// alertEthicsFlag logs a high-severity flag at error level, which the Discord hook forwards to the error
+// webhook, and emails it to the HR notification address when one is set
+func (a *AnalystAgent) alertEthicsFlag(meetingID string, flag EthicsFlag) {
+	logrus.WithFields(logrus.Fields{
+		"agent_id":   a.agentID,
+		"meeting_id": meetingID,
+		"category":   flag.Category,
+		"severity":   flag.Severity,
+		"speaker":    flag.Speaker,
+		"timestamp":  flag.Timestamp.Format(time.RFC3339),
+	}).Errorf("🚨 High-severity %s ethics flag: %q", flag.Category, flag.Quote)
+
+	if a.mailer == nil || a.hrNotificationEmail == "" {
+		return
+	}
+
+	subject := fmt.Sprintf("Ethics flag: %s in meeting %s", flag.Category, meetingID)
+	plainBody := fmt.Sprintf("A high-severity %s flag was raised in meeting %s.\n\nSpeaker: %s\nTime: %s\nQuote: \"%s\"\n",
+		flag.Category, meetingID, flag.Speaker, flag.Timestamp.Format(time.RFC3339), flag.Quote)
+	if flag.ActionRecommended != "" {
+		plainBody += fmt.Sprintf("Recommended action: %s\n", flag.ActionRecommended)
+	}
+	htmlBody, err := renderDigestHTML(plainBody)
+	if err != nil {
+		logrus.Warnf("Agent %s: Failed to render ethics flag email: %v", a.agentID, err)
+		return
+	}
+
+	if err := a.mailer.SendDigest([]string{a.hrNotificationEmail}, subject, htmlBody, plainBody); err != nil {
+		logrus.Warnf("Agent %s: Failed to email ethics flag to HR: %v", a.agentID, err)
+		return
+	}
+	logrus.Infof("Agent %s: Emailed %s ethics flag to HR", a.agentID, flag.Category)
+}
+
+// verbatimQuote returns the quote when it appears verbatim in the statement, or the whole statement when
+// the LLM paraphrased it
+func verbatimQuote(quote, statement string) string {
+	quote = strings.Trim(strings.TrimSpace(quote), `"“”`)
+	if quote != "" && strings.Contains(statement, quote) {
+		return quote
+	}
+	return statement
+}
+
+// ethicsFlagKey identifies the statement and category a flag is for, so a statement is flagged once per
+// category however often it is analyzed
+func ethicsFlagKey(flag EthicsFlag) string {
+	return fmt.Sprintf("%s|%s|%d", flag.Category, flag.Speaker, flag.Timestamp.UnixNano())
+}
+
+// normalizeEthicsCategory maps the LLM's category onto the supported set, returning "" for others
+func normalizeEthicsCategory(category string) string {
+	switch category = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(category), " ", "_")); category {
+	case EthicsDiscrimination, EthicsHarassment, EthicsConfidentialityBreach, EthicsInappropriateLanguage:
+		return category
+	case "confidentiality", "breach_of_confidentiality":
+		return EthicsConfidentialityBreach
+	case "profanity", "inappropriate":
+		return EthicsInappropriateLanguage
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestHighSeverityEthicsFlagEmailsHR(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mail := &recordingMailer{}
+	analyst.SetMailer(mail)
+	analyst.SetHRNotificationEmail("hr@example.com")
+	response := "```json\n" + `{"flags": [
+		{"statement": 0, "category": "Harassment", "severity": "high", "quote": "\"you'll regret it\"", "action_recommended": "Escalate to HR."},
+		{"statement": 1, "category": "profanity", "severity": "low", "quote": "something they never said"},
+		{"statement": 1, "category": "off_topic", "severity": "high", "quote": "damn"}
+	]}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(response, response)
+	say(analyst, 0, "Mallory", "Push back again and you'll regret it")
+	say(analyst, 10, "Bob", "That damn printer is broken again")
+
+	ctx := context.Background()
+	if err := analyst.detectEthicsIssues(ctx); err != nil {
+		t.Fatalf("detectEthicsIssues() error = %v", err)
+	}
+	// Flags found again are neither recorded nor alerted twice
+	if err := analyst.detectEthicsIssues(ctx); err != nil {
+		t.Fatalf("second detectEthicsIssues() error = %v", err)
+	}
+
+	flags := analyst.GetAnalysis().EthicsFlags
+	if len(flags) != 2 {
+		t.Fatalf("EthicsFlags = %+v, want 2", flags)
+	}
+	if flags[0].Category != EthicsHarassment || flags[0].Quote != "you'll regret it" || flags[0].Speaker != "Mallory" {
+		t.Errorf("harassment flag = %+v", flags[0])
+	}
+	// A paraphrased quote is replaced with the statement
+	if flags[1].Category != EthicsInappropriateLanguage || flags[1].Quote != "That damn printer is broken again" {
+		t.Errorf("language flag = %+v", flags[1])
+	}
+
+	if len(mail.sent) != 1 {
+		t.Fatalf("sent %d emails, want one for the high-severity flag", len(mail.sent))
+	}
+	email := mail.sent[0]
+	if len(email.to) != 1 || email.to[0] != "hr@example.com" || !strings.HasPrefix(email.subject, "Ethics flag: harassment") {
+		t.Errorf("email = %+v", email)
+	}
+	if !strings.Contains(email.plainBody, "Speaker: Mallory") || !strings.Contains(email.plainBody, "Recommended action: Escalate to HR.") {
+		t.Errorf("email body = %q", email.plainBody)
+	}
+}
+
+func TestEthicsFlagWithoutHRAddressIsNotEmailed(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mail := &recordingMailer{}
+	analyst.SetMailer(mail)
+	response := "```json\n" + `{"flags": [{"statement": 0, "category": "discrimination", "severity": "high", "quote": "too old"}]}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(response)
+	say(analyst, 0, "Mallory", "He is too old to learn this")
+
+	if err := analyst.detectEthicsIssues(context.Background()); err != nil {
+		t.Fatalf("detectEthicsIssues() error = %v", err)
+	}
+	if len(analyst.GetAnalysis().EthicsFlags) != 1 || len(mail.sent) != 0 {
+		t.Errorf("flags = %+v, emails = %+v", analyst.GetAnalysis().EthicsFlags, mail.sent)
+	}
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// executiveSummarySentences is the number of sentences, and bullets, in the executive summary
+	executiveSummarySentences = 3
+	// executiveSummaryAttempts is the number of times the LLM is asked for a summary of the right length
+	executiveSummaryAttempts = 2
+)
+
+// executiveSentenceEnd matches punctuation that may end a sentence, unlike the decimal point in "$1.2M"
+var executiveSentenceEnd = regexp.MustCompile(`[.!?…]+(?:\s+|$)`)
+
+// sentenceAbbreviations are abbreviations whose period doesn't end a sentence, lowercased without their
+// final period
+var sentenceAbbreviations = map[string]bool{
+	"dr": true, "mr": true, "mrs": true, "ms": true, "prof": true, "sr": true, "jr": true, "st": true,
+	"e.g": true, "i.e": true, "vs": true, "cf": true, "approx": true, "est": true, "no": true,
+	"inc": true, "ltd": true, "corp": true, "co": true, "dept": true, "fig": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+}
+
+// executiveSummaryStep is the analysis step distilling the summary for executives, run after the summary
+func (a *AnalystAgent) executiveSummaryStep() analysisStep {
+	return analysisStep{name: "executive_summary", description: "generate executive summary", run: a.generateExecutiveSummary}
+}
+
+// generateExecutiveSummary distills the full summary and the decisions made into a three-sentence summary
+// for executives, with a bullet for each sentence. A response with another number of sentences is sent
+// back to the LLM to rewrite, and rejected if the rewrite doesn't have three sentences either.
+func (a *AnalystAgent) generateExecutiveSummary(ctx context.Context) error {
+	a.dataMutex.RLock()
+	summary := a.data.Summary
+	var decisions []string
+	for _, item := range a.data.ActionItems {
+		if item.Type == "decision" {
+			decisions = append(decisions, "- "+item.Description)
+		}
+	}
+	a.dataMutex.RUnlock()
+
+	if summary == "" {
+		return nil
+	}
+
+	decisionList := "None recorded"
+	if len(decisions) > 0 {
+		decisionList = strings.Join(decisions, "\n")
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`In exactly 3 sentences, summarize this meeting for a C-level executive who has 30 seconds. Focus only on outcomes, decisions, and critical risks.
+
+Also give the same three points as three short bullets of a few words each.
+
+Meeting summary:
+%s
+
+Decisions:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "executive_summary": "First sentence. Second sentence. Third sentence.",
+  "bullets": ["First point", "Second point", "Third point"]
+}
+`+"`"+``, summary, decisionList)
+
+	var result executiveSummaryResponse
+	var sentences []string
+	for attempt := 1; ; attempt++ {
+		var err error
+		if result, err = a.requestExecutiveSummary(ctx, prompt); err != nil {
+			return err
+		}
+		sentences = splitExecutiveSummary(result.ExecutiveSummary)
+		if len(sentences) == executiveSummarySentences {
+			break
+		}
+		if attempt == executiveSummaryAttempts {
+			return fmt.Errorf("executive summary has %d sentences instead of %d", len(sentences), executiveSummarySentences)
+		}
+
+		logrus.Warnf("Agent %s: Executive summary has %d sentences instead of %d, asking for a rewrite",
+			a.agentID, len(sentences), executiveSummarySentences)
+		prompt += fmt.Sprintf("\n\nYour previous executive summary had %d sentences instead of exactly 3:\n%s\n\nRewrite it as exactly 3 complete sentences.",
+			len(sentences), result.ExecutiveSummary)
+	}
+	executiveSummary := strings.Join(sentences, " ")
+
+	var bullets []string
+	for _, bullet := range result.Bullets {
+		if bullet = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(bullet), "-•*")); bullet != "" && len(bullets) < executiveSummarySentences {
+			bullets = append(bullets, bullet)
+		}
+	}
+	if len(bullets) == 0 {
+		bullets = sentences
+	}
+
+	a.dataMutex.Lock()
+	a.data.ExecutiveSummary = executiveSummary
+	a.data.ExecutiveSummaryBullets = bullets
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Generated executive summary (%d characters)", a.agentID, len(executiveSummary))
+	return nil
+}
+
+// executiveSummaryResponse is the LLM's executive summary and bullets
+type executiveSummaryResponse struct {
+	ExecutiveSummary string   `json:"executive_summary"`
+	Bullets          []string `json:"bullets"`
+}
+
+// requestExecutiveSummary sends the prompt and parses the executive summary from the response
+func (a *AnalystAgent) requestExecutiveSummary(ctx context.Context, prompt string) (executiveSummaryResponse, error) {
+	var result executiveSummaryResponse
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return result, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return result, fmt.Errorf("no JSON in executive summary response")
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return result, fmt.Errorf("failed to parse executive summary JSON: %w", err)
+	}
+	return result, nil
+}
+
+// splitExecutiveSummary splits text into trimmed sentences. Periods of abbreviations such as "Dr." and
+// "e.g.", of initials, and those followed by a lowercase word don't end a sentence. Text after the last
+// sentence-ending punctuation counts as a sentence.
+func splitExecutiveSummary(text string) []string {
+	var sentences []string
+	start := 0
+	for _, end := range executiveSentenceEnd.FindAllStringIndex(text, -1) {
+		if !endsSentence(text[:end[0]], text[end[0]:end[1]], text[end[1]:]) {
+			continue
+		}
+		if sentence := strings.TrimSpace(text[start:end[1]]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end[1]
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// endsSentence reports whether the punctuation between before and after ends a sentence
+func endsSentence(before, punctuation, after string) bool {
+	after = strings.TrimSpace(after)
+	if after == "" || strings.TrimSpace(punctuation) != "." {
+		return true
+	}
+
+	// A period followed by a lowercase word or a number continues the sentence
+	if next, _ := utf8.DecodeRuneInString(after); unicode.IsLower(next) || unicode.IsDigit(next) {
+		return false
+	}
+
+	fields := strings.Fields(before)
+	if len(fields) == 0 {
+		return true
+	}
+	word := strings.ToLower(strings.TrimLeft(fields[len(fields)-1], `("'“`))
+	if sentenceAbbreviations[word] {
+		return false
+	}
+	// Initials such as the "J." of "J. Smith"
+	if runes := []rune(word); len(runes) == 1 && unicode.IsLetter(runes[0]) {
+		return false
+	}
+	return true
+}
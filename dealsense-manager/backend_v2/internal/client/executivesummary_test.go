@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestSplitExecutiveSummary(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{
+			"Revenue grew to $1.2M. The team agreed on Q3 goals. Risk: Dr. Smith leaves.",
+			[]string{"Revenue grew to $1.2M.", "The team agreed on Q3 goals.", "Risk: Dr. Smith leaves."},
+		},
+		{
+			"Costs rose in key regions, e.g. Europe and Asia. Hiring is paused! Is the launch at risk?",
+			[]string{"Costs rose in key regions, e.g. Europe and Asia.", "Hiring is paused!", "Is the launch at risk?"},
+		},
+		{
+			"J. Smith owns the migration. It ships on Oct. 3 as planned",
+			[]string{"J. Smith owns the migration.", "It ships on Oct. 3 as planned"},
+		},
+	}
+	for _, tt := range tests {
+		if got := splitExecutiveSummary(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitExecutiveSummary(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// executiveSummaryJSON returns an LLM response holding summary
+func executiveSummaryJSON(summary string) string {
+	data, _ := json.Marshal(map[string]interface{}{"executive_summary": summary, "bullets": []string{"One", "Two", "Three"}})
+	return "```json\n" + string(data) + "\n```"
+}
+
+func TestExecutiveSummaryAsksForRewrite(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Summary = "The team reviewed the launch."
+	mock := llm.NewMockLLMProvider(
+		executiveSummaryJSON("Launch is on track. Budget is approved. Hiring is paused. Legal review is pending."),
+		executiveSummaryJSON("Launch is on track. Budget is approved by Dr. Lee. Legal review is pending."),
+	)
+	analyst.llmProvider = mock
+
+	if err := analyst.generateExecutiveSummary(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := analyst.data.ExecutiveSummary, "Launch is on track. Budget is approved by Dr. Lee. Legal review is pending."; got != want {
+		t.Errorf("ExecutiveSummary = %q, want %q", got, want)
+	}
+	prompts := mock.Prompts()
+	if len(prompts) != 2 || !strings.Contains(prompts[1], "had 4 sentences") {
+		t.Errorf("rewrite prompt not sent: %q", prompts)
+	}
+}
+
+func TestExecutiveSummaryRejectsWrongLength(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Summary = "The team reviewed the launch."
+	analyst.llmProvider = llm.NewMockLLMProvider(
+		executiveSummaryJSON("Launch is on track."),
+		executiveSummaryJSON("Launch is on track. Budget is approved."),
+	)
+
+	if err := analyst.generateExecutiveSummary(context.Background()); err == nil {
+		t.Fatal("summary with the wrong number of sentences accepted")
+	}
+	if analyst.data.ExecutiveSummary != "" {
+		t.Errorf("ExecutiveSummary = %q, want none stored", analyst.data.ExecutiveSummary)
+	}
+}
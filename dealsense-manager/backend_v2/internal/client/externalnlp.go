@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// externalNLPTimeout bounds a request to the external NLP service; analysis steps usually time out sooner
+const externalNLPTimeout = 2 * time.Minute
+
+// externalNLPAnalysisTypes are the results requested from the external NLP service, replacing the core LLM steps
+var externalNLPAnalysisTypes = []string{"summary", "key_points", "action_items", "topics", "sentiment"}
+
+// NLPAnalysisResult holds the results of an external NLP service in the same shape as the AnalysisData
+// fields they are stored in. Results the service didn't produce are left empty and keep their previous value.
+type NLPAnalysisResult struct {
+	Summary     string            `json:"summary,omitempty"`
+	KeyPoints   []string          `json:"key_points,omitempty"`
+	ActionItems []ActionItem      `json:"action_items,omitempty"`
+	Topics      []TopicDiscussion `json:"topics,omitempty"`
+	Sentiment   string            `json:"sentiment,omitempty"`
+	Keywords    []string          `json:"keywords,omitempty"`
+}
+
+// ExternalNLPProvider analyzes transcripts with an NLP pipeline other than the agent's LLM, such as an
+// on-premise service
+type ExternalNLPProvider interface {
+	AnalyzeTranscript(ctx context.Context, transcript string, analysisTypes []string) (*NLPAnalysisResult, error)
+}
+
+// HTTPNLPProvider calls an external NLP service over HTTP
+type HTTPNLPProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewExternalNLPProvider creates a provider for the NLP service at baseURL, authenticating with apiKey as a
+// bearer token when it is set
+func NewExternalNLPProvider(baseURL, apiKey string) *HTTPNLPProvider {
+	return &HTTPNLPProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: externalNLPTimeout},
+	}
+}
+
+// AnalyzeTranscript posts the transcript and the requested analysis types to the service's /analyze
+// endpoint as {"transcript": "...", "analysis_types": ["summary", ...]} and reads an NLPAnalysisResult
+func (p *HTTPNLPProvider) AnalyzeTranscript(ctx context.Context, transcript string, analysisTypes []string) (*NLPAnalysisResult, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"transcript":     transcript,
+		"analysis_types": analysisTypes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NLP request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/analyze", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("NLP service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("NLP service returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var result NLPAnalysisResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse NLP service response: %w", err)
+	}
+	return &result, nil
+}
+
+// SetExternalNLPProvider sets the service the summary, key points, action items, topics and sentiment are
+// produced by instead of the LLM; nil uses the LLM
+func (a *AnalystAgent) SetExternalNLPProvider(provider ExternalNLPProvider) {
+	a.externalNLP = provider
+}
+
+// analyzeWithExternalNLP produces the core analysis results with the external NLP service, storing them
+// the way the LLM steps they replace do
+func (a *AnalystAgent) analyzeWithExternalNLP(ctx context.Context) error {
+	transcript := a.getFullTranscript()
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Analyzing %d transcript entries with the external NLP service", a.agentID, len(transcript))
+
+	result, err := a.externalNLP.AnalyzeTranscript(ctx, a.formatTranscriptForLLM(transcript), externalNLPAnalysisTypes)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return fmt.Errorf("empty NLP service response")
+	}
+
+	a.dataMutex.Lock()
+	if result.Summary != "" {
+		a.data.Summary = result.Summary
+	}
+	if result.KeyPoints != nil {
+		a.data.KeyPoints = result.KeyPoints
+	}
+	if result.ActionItems != nil {
+		a.data.ActionItems = deduplicateActionItems(a.data.ActionItems, mergeActionItems(a.data.ActionItems, result.ActionItems))
+	}
+	if result.Topics != nil {
+		a.data.Topics = copyTopics(result.Topics, maxTopicDepth)
+	}
+	if result.Sentiment != "" {
+		a.data.Sentiment = result.Sentiment
+	}
+	if result.Keywords != nil {
+		a.data.Keywords = result.Keywords
+	}
+	a.data.WordCloudData = computeWordFrequencies(transcript, a.stopwords())
+	a.dataMutex.Unlock()
+
+	for _, step := range []string{"summary", "key_points", "action_items", "topics", "sentiment_keywords"} {
+		a.recordConfidence(step, len(transcript), false, false)
+	}
+
+	logrus.Infof("Agent %s: External NLP service returned %d key points, %d action items and %d topics",
+		a.agentID, len(result.KeyPoints), len(result.ActionItems), len(result.Topics))
+	return nil
+}
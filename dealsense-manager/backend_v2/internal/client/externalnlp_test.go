@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// nlpServer is a mock NLP service answering POST /analyze with response and recording the request
+func nlpServer(t *testing.T, response string, request *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/analyze" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExternalNLPResultsAreStored(t *testing.T) {
+	var request map[string]interface{}
+	server := nlpServer(t, `{
+		"summary": "The team planned the rollout.",
+		"key_points": ["Rollout starts Monday"],
+		"action_items": [{"description": "Email the customer", "assignee": "Bob", "priority": "high", "status": "pending"}],
+		"topics": [{"topic": "Rollout", "summary": "Timeline for the rollout"}],
+		"sentiment": "positive",
+		"keywords": ["rollout"]
+	}`, &request)
+
+	analyst := newTestAnalyst(t)
+	analyst.SetExternalNLPProvider(NewExternalNLPProvider(server.URL+"/", "secret"))
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+	say(analyst, 30, "Bob", "I will email the customer today.")
+
+	if err := analyst.analyzeWithExternalNLP(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if transcript, _ := request["transcript"].(string); !strings.Contains(transcript, "rollout plan") {
+		t.Errorf("transcript %q not sent to the service", transcript)
+	}
+	if types, _ := request["analysis_types"].([]interface{}); len(types) != len(externalNLPAnalysisTypes) {
+		t.Errorf("analysis_types = %v, want %v", types, externalNLPAnalysisTypes)
+	}
+
+	data := analyst.GetAnalysis()
+	if data.Summary != "The team planned the rollout." || data.Sentiment != "positive" {
+		t.Errorf("summary %q, sentiment %q not stored", data.Summary, data.Sentiment)
+	}
+	if len(data.KeyPoints) != 1 || len(data.Topics) != 1 || data.Topics[0].Topic != "Rollout" {
+		t.Errorf("key points %v, topics %+v not stored", data.KeyPoints, data.Topics)
+	}
+	if len(data.ActionItems) != 1 || data.ActionItems[0].Assignee != "Bob" {
+		t.Errorf("action items = %+v, want Bob's email", data.ActionItems)
+	}
+	if len(data.Keywords) != 1 || len(data.WordCloudData) == 0 {
+		t.Errorf("keywords %v, word cloud %v not stored", data.Keywords, data.WordCloudData)
+	}
+}
+
+func TestExternalNLPKeepsMissingResults(t *testing.T) {
+	var request map[string]interface{}
+	server := nlpServer(t, `{"sentiment": "neutral"}`, &request)
+
+	analyst := newTestAnalyst(t)
+	analyst.SetExternalNLPProvider(NewExternalNLPProvider(server.URL, "secret"))
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+	analyst.dataMutex.Lock()
+	analyst.data.Summary = "Earlier summary"
+	analyst.dataMutex.Unlock()
+
+	if err := analyst.analyzeWithExternalNLP(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if data := analyst.GetAnalysis(); data.Summary != "Earlier summary" || data.Sentiment != "neutral" {
+		t.Errorf("summary %q, sentiment %q, want the earlier summary kept", data.Summary, data.Sentiment)
+	}
+}
+
+func TestExternalNLPServiceErrors(t *testing.T) {
+	var request map[string]interface{}
+	server := nlpServer(t, `{}`, &request)
+
+	provider := NewExternalNLPProvider(server.URL, "wrong")
+	_, err := provider.AnalyzeTranscript(context.Background(), "Alice: hello", externalNLPAnalysisTypes)
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("AnalyzeTranscript() = %v, want a status 401 error", err)
+	}
+}
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/models"
+)
+
+const (
+	// feedbackRefinementThreshold is the number of feedback events collected before refining the prompt
+	feedbackRefinementThreshold = 10
+	// refinementTranscriptEntries is how much recent transcript is sent with a refinement request
+	refinementTranscriptEntries = 40
+	// refinementTimeout bounds a prompt refinement LLM call
+	refinementTimeout = 2 * time.Minute
+)
+
+// Feedback verdicts on an action item
+const (
+	VerdictCorrect   = "correct"
+	VerdictIncorrect = "incorrect"
+	VerdictDuplicate = "duplicate"
+)
+
+var (
+	// ErrActionItemNotFound is returned when feedback refers to an unknown action item
+	ErrActionItemNotFound = errors.New("action item not found")
+	// ErrInvalidVerdict is returned when feedback has an unknown verdict
+	ErrInvalidVerdict = errors.New("verdict must be correct, incorrect or duplicate")
+)
+
+// Feedback is a user's judgement of an extracted action item
+type Feedback struct {
+	Verdict     string    `json:"verdict"`
+	Correction  string    `json:"correction,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// actionItemFeedback pairs feedback with the action item it was given on
+type actionItemFeedback struct {
+	item     ActionItem
+	feedback Feedback
+}
+
+// actionItemID derives a stable ID from the action item description so feedback survives re-analysis
+func actionItemID(description string) string {
+	sum := sha1.Sum([]byte(strings.ToLower(strings.TrimSpace(description))))
+	return "ai_" + hex.EncodeToString(sum[:6])
+}
+
+// mergeActionItems assigns IDs to newly extracted action items and carries over the status, creation time
+// and feedback of items that were already known
+func mergeActionItems(previous, extracted []ActionItem) []ActionItem {
+	known := make(map[string]ActionItem, len(previous))
+	for _, item := range previous {
+		known[item.ID] = item
+	}
+
+	now := time.Now()
+	for i := range extracted {
+		item := &extracted[i]
+		item.ID = actionItemID(item.Description)
+		if existing, ok := known[item.ID]; ok {
+			item.Status = existing.Status
+			item.CreatedAt = existing.CreatedAt
+			item.UserFeedback = existing.UserFeedback
+			continue
+		}
+		if item.Status == "" {
+			item.Status = "pending"
+		}
+		item.CreatedAt = now
+	}
+	return extracted
+}
+
+// SubmitActionItemFeedback records feedback on an action item. Once enough feedback has been collected a
+// prompt refinement job is started in the background.
+func (a *AnalystAgent) SubmitActionItemFeedback(itemID string, feedback Feedback) error {
+	switch feedback.Verdict {
+	case VerdictCorrect, VerdictIncorrect, VerdictDuplicate:
+	default:
+		return ErrInvalidVerdict
+	}
+	feedback.SubmittedAt = time.Now()
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	var item *ActionItem
+	for i := range a.data.ActionItems {
+		if a.data.ActionItems[i].ID == itemID {
+			item = &a.data.ActionItems[i]
+			break
+		}
+	}
+	if item == nil {
+		return ErrActionItemNotFound
+	}
+
+	item.UserFeedback = &feedback
+	a.feedbackEvents = append(a.feedbackEvents, actionItemFeedback{item: *item, feedback: feedback})
+	logrus.Infof("Agent %s: Recorded %s feedback on action item %s", a.agentID, feedback.Verdict, itemID)
+
+	if len(a.feedbackEvents) >= feedbackRefinementThreshold {
+		job := &PromptRefinementJob{
+			agent:      a,
+			config:     a.latestConfig(),
+			transcript: a.recentTranscriptLocked(refinementTranscriptEntries),
+			feedback:   a.feedbackEvents,
+		}
+		a.feedbackEvents = nil
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), refinementTimeout)
+			defer cancel()
+			if err := job.Run(ctx); err != nil {
+				logrus.Errorf("Agent %s: Prompt refinement failed: %v", a.agentID, err)
+			}
+		}()
+	}
+
+	a.queueSave()
+	return nil
+}
+
+// SetConfigChangedCallback sets the callback invoked when the agent changes its own configuration
+func (a *AnalystAgent) SetConfigChangedCallback(callback func(models.AgentConfig)) {
+	a.onConfigChanged = callback
+}
+
+// SetFinalizedCallback sets the callback invoked with the analysis once the meeting is finalized
+func (a *AnalystAgent) SetFinalizedCallback(callback func(*AnalysisData)) {
+	a.onFinalized = callback
+}
+
+// latestConfig returns the configuration including any update not yet applied (caller must hold dataMutex)
+func (a *AnalystAgent) latestConfig() models.AgentConfig {
+	if a.pendingConfig != nil {
+		return *a.pendingConfig
+	}
+	return a.config
+}
+
+// recentTranscriptLocked returns a copy of the last count transcript entries (caller must hold dataMutex)
+func (a *AnalystAgent) recentTranscriptLocked(count int) []TranscriptEntry {
+	start := max(len(a.data.Transcript)-count, 0)
+	return append([]TranscriptEntry{}, a.data.Transcript[start:]...)
+}
+
+// PromptRefinementJob asks the LLM to improve the agent's instructions using feedback on wrong action items
+type PromptRefinementJob struct {
+	agent      *AnalystAgent
+	config     models.AgentConfig
+	transcript []TranscriptEntry
+	feedback   []actionItemFeedback
+}
+
+// Run builds the refinement meta-prompt, stores the refined prompt as the agent's custom prompt and logs
+// what changed
+func (j *PromptRefinementJob) Run(ctx context.Context) error {
+	a := j.agent
+
+	var mistakes strings.Builder
+	for _, event := range j.feedback {
+		if event.feedback.Verdict == VerdictCorrect {
+			continue
+		}
+		mistakes.WriteString(fmt.Sprintf("- Extracted: %q (marked %s)", event.item.Description, event.feedback.Verdict))
+		if event.feedback.Correction != "" {
+			mistakes.WriteString(fmt.Sprintf("; user correction: %q", event.feedback.Correction))
+		}
+		mistakes.WriteString("\n")
+	}
+	if mistakes.Len() == 0 {
+		logrus.Infof("Agent %s: All %d feedback events confirmed action items, skipping prompt refinement", a.agentID, len(j.feedback))
+		return nil
+	}
+
+	currentPrompt := ""
+	if j.config.CustomPrompt != nil {
+		currentPrompt = *j.config.CustomPrompt
+	}
+	instructions := currentPrompt
+	if instructions == "" {
+		instructions = "(none - the default meeting analyst instructions are used)"
+	}
+
+	metaPrompt := fmt.Sprintf(`You maintain the instructions given to a meeting analyst agent. Users reviewed the action items it identified and flagged the mistakes below.
+
+Current analyst instructions:
+%s
+
+Meeting transcript the action items were identified from:
+%s
+
+Action items users flagged as wrong:
+%s
+Rewrite the analyst instructions so that future action item identification avoids these mistakes while keeping the rest of the instructions intact. Keep them under 3000 characters and respond with only the new instructions.`,
+		instructions, a.formatTranscriptForLLM(j.transcript), mistakes.String())
+
+	refined, err := a.callLLM(ctx, metaPrompt)
+	if err != nil {
+		return fmt.Errorf("failed to refine prompt: %w", err)
+	}
+	refined = strings.TrimSpace(refined)
+	if refined == "" {
+		return fmt.Errorf("refined prompt is empty")
+	}
+	if !a.isSafeInstruction(refined) {
+		return fmt.Errorf("refined prompt failed safety validation")
+	}
+
+	config := j.config
+	config.CustomPrompt = &refined
+	a.UpdateConfig(config)
+	if a.onConfigChanged != nil {
+		a.onConfigChanged(config)
+	}
+
+	logrus.Infof("Agent %s: Refined custom prompt from %d feedback events:\n%s", a.agentID, len(j.feedback), promptDiff(currentPrompt, refined))
+	return nil
+}
+
+// promptDiff lists the lines removed from and added to a prompt
+func promptDiff(before, after string) string {
+	beforeLines := strings.Split(before, "\n")
+	afterLines := strings.Split(after, "\n")
+
+	inBefore := make(map[string]bool, len(beforeLines))
+	for _, line := range beforeLines {
+		inBefore[line] = true
+	}
+	inAfter := make(map[string]bool, len(afterLines))
+	for _, line := range afterLines {
+		inAfter[line] = true
+	}
+
+	var diff []string
+	for _, line := range beforeLines {
+		if line != "" && !inAfter[line] {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for _, line := range afterLines {
+		if line != "" && !inBefore[line] {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	return strings.Join(diff, "\n")
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+// withActionItems gives the analyst count action items, returning their IDs
+func withActionItems(analyst *AnalystAgent, count int) []string {
+	analyst.dataMutex.Lock()
+	defer analyst.dataMutex.Unlock()
+
+	ids := make([]string, count)
+	for i := range ids {
+		description := fmt.Sprintf("Follow up on item %d", i)
+		ids[i] = actionItemID(description)
+		analyst.data.ActionItems = append(analyst.data.ActionItems, ActionItem{ID: ids[i], Description: description})
+	}
+	return ids
+}
+
+func TestPromptRefinementAfterTenFeedbackEvents(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("Only list tasks someone committed to doing.")
+	analyst.llmProvider = mock
+	changed := make(chan models.AgentConfig, 1)
+	analyst.SetConfigChangedCallback(func(config models.AgentConfig) { changed <- config })
+	ids := withActionItems(analyst, feedbackRefinementThreshold)
+
+	for i, id := range ids[:feedbackRefinementThreshold-1] {
+		verdict := VerdictCorrect
+		if i == 0 {
+			verdict = VerdictIncorrect
+		}
+		if err := analyst.SubmitActionItemFeedback(id, Feedback{Verdict: verdict, Correction: "Not a task"}); err != nil {
+			t.Fatalf("SubmitActionItemFeedback() error = %v", err)
+		}
+	}
+	if prompts := mock.Prompts(); len(prompts) != 0 {
+		t.Fatalf("refinement ran after %d feedback events", feedbackRefinementThreshold-1)
+	}
+
+	if err := analyst.SubmitActionItemFeedback(ids[len(ids)-1], Feedback{Verdict: VerdictDuplicate}); err != nil {
+		t.Fatalf("SubmitActionItemFeedback() error = %v", err)
+	}
+	select {
+	case config := <-changed:
+		if config.CustomPrompt == nil || *config.CustomPrompt != "Only list tasks someone committed to doing." {
+			t.Errorf("CustomPrompt = %v", config.CustomPrompt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("prompt was not refined after the tenth feedback event")
+	}
+
+	// Only the flagged items are sent as mistakes
+	prompt := mock.Prompts()[0]
+	for _, want := range []string{
+		`- Extracted: "Follow up on item 0" (marked incorrect); user correction: "Not a task"`,
+		`- Extracted: "Follow up on item 9" (marked duplicate)`,
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("refinement prompt is missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "item 1\"") {
+		t.Errorf("refinement prompt includes a confirmed item:\n%s", prompt)
+	}
+}
+
+func TestSubmitActionItemFeedbackValidates(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	ids := withActionItems(analyst, 1)
+
+	if err := analyst.SubmitActionItemFeedback(ids[0], Feedback{Verdict: "maybe"}); !errors.Is(err, ErrInvalidVerdict) {
+		t.Errorf("unknown verdict error = %v, want ErrInvalidVerdict", err)
+	}
+	if err := analyst.SubmitActionItemFeedback("ai_missing", Feedback{Verdict: VerdictCorrect}); !errors.Is(err, ErrActionItemNotFound) {
+		t.Errorf("unknown item error = %v, want ErrActionItemNotFound", err)
+	}
+	if err := analyst.SubmitActionItemFeedback(ids[0], Feedback{Verdict: VerdictCorrect}); err != nil {
+		t.Fatalf("SubmitActionItemFeedback() error = %v", err)
+	}
+	if feedback := analyst.GetAnalysis().ActionItems[0].UserFeedback; feedback == nil || feedback.Verdict != VerdictCorrect {
+		t.Errorf("UserFeedback = %+v", feedback)
+	}
+}
+
+func TestMergeActionItemsKeepsFeedback(t *testing.T) {
+	previous := mergeActionItems(nil, []ActionItem{{Description: "Send the deck"}})
+	previous[0].Status = "completed"
+	previous[0].UserFeedback = &Feedback{Verdict: VerdictCorrect}
+
+	merged := mergeActionItems(previous, []ActionItem{{Description: " send the DECK "}, {Description: "Book the room"}})
+	if merged[0].ID != previous[0].ID || merged[0].Status != "completed" || merged[0].UserFeedback == nil {
+		t.Errorf("re-extracted item = %+v", merged[0])
+	}
+	if merged[1].Status != "pending" || merged[1].ID == merged[0].ID {
+		t.Errorf("new item = %+v", merged[1])
+	}
+}
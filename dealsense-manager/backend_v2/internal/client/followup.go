@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/calendar"
+)
+
+const (
+	defaultFollowUpMinutes = 30
+	maxFollowUpMinutes     = 120
+)
+
+// FollowUpSuggestion recommends whether, when and with whom to hold a follow-up meeting
+type FollowUpSuggestion struct {
+	Recommended              bool     `json:"recommended"`
+	SuggestedAgenda          []string `json:"suggested_agenda,omitempty"`
+	SuggestedParticipants    []string `json:"suggested_participants,omitempty"`
+	SuggestedDurationMinutes int      `json:"suggested_duration_minutes,omitempty"`
+	Urgency                  string   `json:"urgency,omitempty"` // immediate, this_week, next_sprint
+	Rationale                string   `json:"rationale"`
+	CalendarEventLink        string   `json:"calendar_event_link,omitempty"` // Draft event created for the follow-up
+}
+
+// SetCalendar sets the calendar that recommended follow-up meetings are drafted on
+func (a *AnalystAgent) SetCalendar(c calendar.EventCreator) {
+	a.calendar = c
+}
+
+// suggestFollowUp asks the LLM whether the open action items, questions and decisions need a follow-up
+// meeting, drafting a calendar event for it when a calendar is configured
+func (a *AnalystAgent) suggestFollowUp(ctx context.Context) error {
+	a.dataMutex.RLock()
+	var openItems, openDecisions []string
+	for _, item := range a.data.ActionItems {
+		if item.Status == "completed" {
+			continue
+		}
+		line := item.Description
+		if item.Assignee != "" {
+			line += " (" + item.Assignee + ")"
+		}
+		if item.Type == "decision" {
+			openDecisions = append(openDecisions, line)
+		} else {
+			openItems = append(openItems, line)
+		}
+	}
+	var questions []string
+	for _, question := range a.data.UnansweredQuestions {
+		questions = append(questions, question.Question)
+	}
+	summary := a.data.Summary
+	participants := append([]string{}, a.data.Participants...)
+	a.dataMutex.RUnlock()
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Based on the outcome of this meeting, decide whether a follow-up meeting is needed. Recommend one only when open work needs the participants to meet again, not for items that can be handled asynchronously.
+
+Meeting summary:
+%s
+
+Open action items:
+%s
+
+Unresolved questions:
+%s
+
+Open decisions:
+%s
+
+Participants: %s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "recommended": true,
+  "suggested_agenda": ["Agenda item"],
+  "suggested_participants": ["Participant name"],
+  "suggested_duration_minutes": 30,
+  "urgency": "immediate/this_week/next_sprint",
+  "rationale": "Why a follow-up is or isn't needed"
+}
+`+"`"+``, summary, bulletList(openItems), bulletList(questions), bulletList(openDecisions), strings.Join(participants, ", "))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in follow-up suggestion response")
+	}
+
+	var suggestion FollowUpSuggestion
+	if err := json.Unmarshal([]byte(jsonData), &suggestion); err != nil {
+		return fmt.Errorf("failed to parse follow-up suggestion JSON: %w", err)
+	}
+	normalizeFollowUp(&suggestion)
+
+	if suggestion.Recommended && a.calendar != nil {
+		link, err := a.calendar.CreateEventDraft(followUpEventDraft(&suggestion, time.Now()))
+		if err != nil {
+			logrus.Warnf("Agent %s: Failed to draft follow-up calendar event: %v", a.agentID, err)
+		} else {
+			suggestion.CalendarEventLink = link
+			logrus.Infof("Agent %s: Drafted follow-up calendar event %s", a.agentID, link)
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.FollowUpSuggestion = &suggestion
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Follow-up recommended: %t (%s)", a.agentID, suggestion.Recommended, suggestion.Urgency)
+	return nil
+}
+
+// normalizeFollowUp fills in defaults and clears the scheduling fields when no follow-up is recommended
+func normalizeFollowUp(suggestion *FollowUpSuggestion) {
+	if !suggestion.Recommended {
+		suggestion.SuggestedAgenda = nil
+		suggestion.SuggestedParticipants = nil
+		suggestion.SuggestedDurationMinutes = 0
+		suggestion.Urgency = ""
+		return
+	}
+
+	switch suggestion.Urgency = strings.ToLower(strings.TrimSpace(suggestion.Urgency)); suggestion.Urgency {
+	case "immediate", "this_week", "next_sprint":
+	default:
+		suggestion.Urgency = "this_week"
+	}
+
+	if suggestion.SuggestedDurationMinutes <= 0 {
+		suggestion.SuggestedDurationMinutes = defaultFollowUpMinutes
+	}
+	suggestion.SuggestedDurationMinutes = min(suggestion.SuggestedDurationMinutes, maxFollowUpMinutes)
+}
+
+// followUpEventDraft builds the calendar event for a suggestion, starting on the hour after a delay
+// matching its urgency
+func followUpEventDraft(suggestion *FollowUpSuggestion, now time.Time) calendar.EventDraft {
+	delay := 3 * 24 * time.Hour
+	switch suggestion.Urgency {
+	case "immediate":
+		delay = 24 * time.Hour
+	case "next_sprint":
+		delay = 14 * 24 * time.Hour
+	}
+
+	var description strings.Builder
+	description.WriteString(suggestion.Rationale)
+	if len(suggestion.SuggestedAgenda) > 0 {
+		description.WriteString("\n\nAgenda:\n")
+		description.WriteString(bulletList(suggestion.SuggestedAgenda))
+	}
+
+	return calendar.EventDraft{
+		Summary:     "Follow-up meeting",
+		Description: description.String(),
+		Start:       now.Add(delay).Truncate(time.Hour),
+		Duration:    time.Duration(suggestion.SuggestedDurationMinutes) * time.Minute,
+		Attendees:   suggestion.SuggestedParticipants,
+	}
+}
+
+// bulletList formats items as a markdown list, or "None" when empty
+func bulletList(items []string) string {
+	if len(items) == 0 {
+		return "None"
+	}
+	return "- " + strings.Join(items, "\n- ")
+}
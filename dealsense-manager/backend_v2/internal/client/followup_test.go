@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/calendar"
+	"joinly-manager/internal/client/llm"
+)
+
+// fakeCalendar records the drafted events and returns link, or err when set
+type fakeCalendar struct {
+	drafts []calendar.EventDraft
+	link   string
+	err    error
+}
+
+func (c *fakeCalendar) CreateEventDraft(draft calendar.EventDraft) (string, error) {
+	c.drafts = append(c.drafts, draft)
+	return c.link, c.err
+}
+
+func TestNormalizeFollowUp(t *testing.T) {
+	tests := []struct {
+		name string
+		in   FollowUpSuggestion
+		want FollowUpSuggestion
+	}{
+		{
+			"not recommended clears scheduling",
+			FollowUpSuggestion{SuggestedAgenda: []string{"x"}, SuggestedParticipants: []string{"Bob"}, SuggestedDurationMinutes: 45, Urgency: "immediate", Rationale: "Done"},
+			FollowUpSuggestion{Rationale: "Done"},
+		},
+		{
+			"defaults",
+			FollowUpSuggestion{Recommended: true, Urgency: "soonish"},
+			FollowUpSuggestion{Recommended: true, Urgency: "this_week", SuggestedDurationMinutes: defaultFollowUpMinutes},
+		},
+		{
+			"capped duration and normalized urgency",
+			FollowUpSuggestion{Recommended: true, Urgency: " Next_Sprint ", SuggestedDurationMinutes: 240},
+			FollowUpSuggestion{Recommended: true, Urgency: "next_sprint", SuggestedDurationMinutes: maxFollowUpMinutes},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.in
+			normalizeFollowUp(&got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeFollowUp() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowUpEventDraft(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 42, 0, 0, time.UTC)
+	tests := []struct {
+		urgency string
+		want    time.Time
+	}{
+		{"immediate", time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)},
+		{"this_week", time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)},
+		{"next_sprint", time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		suggestion := &FollowUpSuggestion{Recommended: true, Urgency: tt.urgency, SuggestedDurationMinutes: 45}
+		if got := followUpEventDraft(suggestion, now).Start; !got.Equal(tt.want) {
+			t.Errorf("%s: Start = %s, want %s", tt.urgency, got, tt.want)
+		}
+	}
+
+	draft := followUpEventDraft(&FollowUpSuggestion{
+		Recommended:              true,
+		SuggestedAgenda:          []string{"Pricing", "Timeline"},
+		SuggestedParticipants:    []string{"bob@example.com"},
+		SuggestedDurationMinutes: 45,
+		Rationale:                "Pricing is still open.",
+	}, now)
+	if draft.Description != "Pricing is still open.\n\nAgenda:\n- Pricing\n- Timeline" {
+		t.Errorf("Description = %q", draft.Description)
+	}
+	if draft.Duration != 45*time.Minute || draft.Summary != "Follow-up meeting" || len(draft.Attendees) != 1 {
+		t.Errorf("draft = %+v", draft)
+	}
+}
+
+func TestSuggestFollowUpDraftsCalendarEvent(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n" + `{"recommended": true, "suggested_agenda": ["Pricing"], "suggested_participants": ["bob@example.com"], "urgency": "immediate", "rationale": "Pricing is still open."}` + "\n```")
+	analyst.llmProvider = mock
+	cal := &fakeCalendar{link: "https://calendar.example.com/event/1"}
+	analyst.SetCalendar(cal)
+	analyst.data.ActionItems = []ActionItem{
+		{Description: "Send the quote", Assignee: "Bob", Status: "pending"},
+		{Description: "Old task", Status: "completed"},
+	}
+
+	if err := analyst.suggestFollowUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := mock.Prompts()[0]
+	if !strings.Contains(prompt, "- Send the quote (Bob)") || strings.Contains(prompt, "Old task") {
+		t.Errorf("prompt doesn't list only the open action items:\n%s", prompt)
+	}
+	suggestion := analyst.GetAnalysis().FollowUpSuggestion
+	if suggestion == nil || suggestion.CalendarEventLink != cal.link || suggestion.SuggestedDurationMinutes != defaultFollowUpMinutes {
+		t.Fatalf("FollowUpSuggestion = %+v", suggestion)
+	}
+	if len(cal.drafts) != 1 || cal.drafts[0].Attendees[0] != "bob@example.com" {
+		t.Errorf("drafts = %+v", cal.drafts)
+	}
+}
+
+func TestSuggestFollowUpKeepsSuggestionWhenCalendarFails(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"recommended": true, "rationale": "Open items"}` + "\n```")
+	analyst.SetCalendar(&fakeCalendar{err: errors.New("calendar unavailable")})
+
+	if err := analyst.suggestFollowUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if suggestion := analyst.GetAnalysis().FollowUpSuggestion; suggestion == nil || !suggestion.Recommended || suggestion.CalendarEventLink != "" {
+		t.Errorf("FollowUpSuggestion = %+v, want it stored without a link", suggestion)
+	}
+}
+
+func TestSuggestFollowUpWithoutRecommendationSkipsCalendar(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"recommended": false, "urgency": "immediate", "rationale": "Everything is settled"}` + "\n```")
+	cal := &fakeCalendar{link: "unused"}
+	analyst.SetCalendar(cal)
+
+	if err := analyst.suggestFollowUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(cal.drafts) != 0 {
+		t.Errorf("drafted %d events, want none", len(cal.drafts))
+	}
+	if suggestion := analyst.GetAnalysis().FollowUpSuggestion; suggestion.Urgency != "" {
+		t.Errorf("Urgency = %q, want it cleared", suggestion.Urgency)
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"strings"
+
+	"joinly-manager/internal/knowledge"
+)
+
+// glossaryTerms is the number of knowledge base terms explained in each prompt
+const glossaryTerms = 5
+
+// SetKnowledgeBase sets the knowledge base used to explain internal terms in analysis prompts
+func (a *AnalystAgent) SetKnowledgeBase(kb knowledge.KnowledgeBase) {
+	a.knowledge = kb
+}
+
+// glossaryPrefix returns a glossary of the most mentioned knowledge base terms in the transcript, or "" when
+// no knowledge base is set or none of its terms are mentioned
+func (a *AnalystAgent) glossaryPrefix(transcript ...string) string {
+	if a.knowledge == nil {
+		return ""
+	}
+
+	entries := knowledge.FindTerms(a.knowledge, strings.Join(transcript, "\n"), glossaryTerms)
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var glossary strings.Builder
+	glossary.WriteString("Context glossary:\n")
+	for _, entry := range entries {
+		glossary.WriteString("- " + entry.Term + ": " + entry.Explanation + "\n")
+	}
+	glossary.WriteString("\n")
+	return glossary.String()
+}
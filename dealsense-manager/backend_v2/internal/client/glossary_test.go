@@ -0,0 +1,32 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+// glossary is a knowledge base of fixed explanations
+type glossary map[string]string
+
+func (g glossary) Lookup(term string) (string, bool) {
+	explanation, ok := g[term]
+	return explanation, ok
+}
+
+func TestGlossaryPrefixInPrompts(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if prefix := analyst.glossaryPrefix("ARR is up"); prefix != "" {
+		t.Errorf("glossaryPrefix() without a knowledge base = %q", prefix)
+	}
+
+	analyst.SetKnowledgeBase(glossary{"ARR": "Annual recurring revenue", "QBR": "Quarterly business review"})
+	prompt := analyst.buildAnalysisPrompt("summary", "Summarize:\n%s", "[10:00] Alice: ARR is up since the QBR, ARR again")
+	want := "Context glossary:\n- ARR: Annual recurring revenue\n- QBR: Quarterly business review\n\nSummarize:"
+	if !strings.Contains(prompt, want) {
+		t.Errorf("prompt = %q, want the glossary block %q", prompt, want)
+	}
+
+	if prefix := analyst.glossaryPrefix("Nothing internal here"); prefix != "" {
+		t.Errorf("glossaryPrefix() without known terms = %q", prefix)
+	}
+}
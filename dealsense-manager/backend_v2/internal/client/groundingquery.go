@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxGroundingQueries bounds the number of optimized search queries passed to a grounded call
+const maxGroundingQueries = 3
+
+// OptimizeGroundingQuery asks the LLM for a few specific search queries that would help fact-check the
+// claims made in the transcript, returning them one per line
+func (a *AnalystAgent) OptimizeGroundingQuery(analysisType string, transcript string) (string, error) {
+	return a.optimizeGroundingQuery(context.Background(), analysisType, transcript)
+}
+
+func (a *AnalystAgent) optimizeGroundingQuery(ctx context.Context, analysisType string, transcript string) (string, error) {
+	prompt := fmt.Sprintf(`Given this transcript, write 2-3 specific Google search queries that would help fact-check the claims made in the meeting. The queries will be used for the meeting's %s.
+
+Transcript:
+%s
+
+Return JSON array of query strings, for example:
+`+"`"+`json
+["query one", "query two"]
+`+"`"+``, strings.ReplaceAll(analysisType, "_", " "), transcript)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	queries, err := parseGroundingQueries(response)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(queries, "\n"), nil
+}
+
+// parseGroundingQueries extracts the JSON array of query strings from an LLM response
+func parseGroundingQueries(response string) ([]string, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("no JSON array in query optimization response")
+	}
+
+	var raw []string
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse optimized queries: %w", err)
+	}
+
+	queries := make([]string, 0, maxGroundingQueries)
+	for _, query := range raw {
+		if query = strings.TrimSpace(query); query != "" && !strings.Contains(query, "\n") {
+			queries = append(queries, query)
+		}
+		if len(queries) == maxGroundingQueries {
+			break
+		}
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("query optimization returned no queries")
+	}
+	return queries, nil
+}
+
+// withGroundingQueries appends optimized search queries to a grounded prompt when query optimization is
+// enabled. Gemini's google_search tool has no field for suggested queries (dynamicRetrievalConfig only takes
+// a numeric threshold), so the hint is given in the prompt itself. Failures leave the prompt unchanged.
+func (a *AnalystAgent) withGroundingQueries(ctx context.Context, analysisType string, transcript []TranscriptEntry, prompt string) string {
+	if !a.config.EnableQueryOptimization {
+		return prompt
+	}
+
+	queries, err := a.optimizeGroundingQuery(ctx, analysisType, a.formatTranscriptForLLM(transcript))
+	if err != nil {
+		logrus.Warnf("Agent %s: Failed to optimize grounding queries for %s: %v", a.agentID, analysisType, err)
+		return prompt
+	}
+
+	logrus.Debugf("Agent %s: Optimized grounding queries for %s: %s", a.agentID, analysisType, strings.ReplaceAll(queries, "\n", "; "))
+	return prompt + "\n\nWhen using google_search, start with these search queries:\n- " + strings.ReplaceAll(queries, "\n", "\n- ")
+}
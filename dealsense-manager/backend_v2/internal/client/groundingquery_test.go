@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestParseGroundingQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []string
+		wantErr  bool
+	}{
+		{"code block", "```json\n[\"acme revenue 2024\", \"acme headcount\"]\n```", []string{"acme revenue 2024", "acme headcount"}, false},
+		{"trimmed and capped", `Queries: [" a ", "", "b\nc", "d", "e", "f"]`, []string{"a", "d", "e"}, false},
+		{"no array", "I can't help with that", nil, true},
+		{"not strings", "[1, 2]", nil, true},
+		{"only blanks", `["  "]`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGroundingQueries(tt.response)
+			if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGroundingQueries() = %v, %v, want %v (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptimizeGroundingQuery(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider(`["acme revenue 2024", "acme headcount"]`)
+	analyst.llmProvider = mock
+
+	queries, err := analyst.OptimizeGroundingQuery("fact_check", "Alice: Acme made $5M last year")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries != "acme revenue 2024\nacme headcount" {
+		t.Errorf("OptimizeGroundingQuery() = %q", queries)
+	}
+	if prompt := mock.Prompts()[0]; !strings.Contains(prompt, "meeting's fact check") || !strings.Contains(prompt, "Acme made $5M") {
+		t.Errorf("prompt = %s", prompt)
+	}
+}
+
+func TestWithGroundingQueries(t *testing.T) {
+	transcript := []TranscriptEntry{entryAt(0, "Alice", "Acme made $5M last year")}
+
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	if got := analyst.withGroundingQueries(context.Background(), "fact_check", transcript, "Check facts"); got != "Check facts" {
+		t.Errorf("prompt changed with query optimization disabled: %q", got)
+	}
+
+	analyst.config.EnableQueryOptimization = true
+	analyst.llmProvider = llm.NewMockLLMProvider(`["acme revenue 2024", "acme headcount"]`)
+	want := "Check facts\n\nWhen using google_search, start with these search queries:\n- acme revenue 2024\n- acme headcount"
+	if got := analyst.withGroundingQueries(context.Background(), "fact_check", transcript, "Check facts"); got != want {
+		t.Errorf("withGroundingQueries() = %q, want %q", got, want)
+	}
+
+	// A failed optimization leaves the prompt unchanged
+	analyst.llmProvider = llm.NewMockLLMProvider("no queries here")
+	if got := analyst.withGroundingQueries(context.Background(), "fact_check", transcript, "Check facts"); got != "Check facts" {
+		t.Errorf("prompt changed after a failed optimization: %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"strings"
+
+	"joinly-manager/internal/models"
+)
+
+// Health thresholds used when the agent's HealthThresholds leave them unset
+const (
+	defaultMaxHealthyDurationMinutes = 60
+	defaultMaxHealthyParticipants    = 8
+	defaultDominantSpeakerShare      = 0.6
+	defaultMaxHealthyCrosstalkRate   = 0.5
+	defaultMinHealthyAttentionScore  = 40
+)
+
+// MeetingHealthIndicators are red flags about how the meeting went, computed when it is finalized
+type MeetingHealthIndicators struct {
+	TooLong              bool `json:"too_long"`
+	TooManyParticipants  bool `json:"too_many_participants"`
+	DominantSpeakerAlert bool `json:"dominant_speaker_alert"` // One participant spoke for more than the dominant share
+	NoActionItems        bool `json:"no_action_items"`
+	NoDecisions          bool `json:"no_decisions"`
+	NegativeSentiment    bool `json:"negative_sentiment"`
+	HighCrosstalkRate    bool `json:"high_crosstalk_rate"`
+	LowEngagementAlert   bool `json:"low_engagement_alert"` // Average attention score below the minimum
+	IcebreakerMissing    bool `json:"icebreaker_missing"`   // Meeting started straight with business
+}
+
+// updateHealthIndicators computes the meeting's health indicators from its analysis with the agent's
+// thresholds
+func (a *AnalystAgent) updateHealthIndicators() {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	a.data.HealthIndicators = computeHealthIndicators(a.data, a.config.HealthThresholds)
+}
+
+// computeHealthIndicators flags the analysis against the thresholds, using the defaults for those that are
+// unset. Sentiment is negative when the overall sentiment or the average of the sentiment timeline is; the
+// engagement alert needs attention scores, which are only computed once participants respond to each other.
+func computeHealthIndicators(data *AnalysisData, thresholds *models.HealthThresholds) *MeetingHealthIndicators {
+	maxDuration := float64(defaultMaxHealthyDurationMinutes)
+	maxParticipants := defaultMaxHealthyParticipants
+	dominantShare := defaultDominantSpeakerShare
+	maxCrosstalk := defaultMaxHealthyCrosstalkRate
+	minAttention := float64(defaultMinHealthyAttentionScore)
+	if thresholds != nil {
+		if thresholds.MaxDurationMinutes > 0 {
+			maxDuration = thresholds.MaxDurationMinutes
+		}
+		if thresholds.MaxParticipants > 0 {
+			maxParticipants = thresholds.MaxParticipants
+		}
+		if thresholds.DominantSpeakerShare > 0 {
+			dominantShare = thresholds.DominantSpeakerShare
+		}
+		if thresholds.MaxCrosstalkRate > 0 {
+			maxCrosstalk = thresholds.MaxCrosstalkRate
+		}
+		if thresholds.MinAttentionScore > 0 {
+			minAttention = thresholds.MinAttentionScore
+		}
+	}
+
+	health := &MeetingHealthIndicators{
+		TooLong:             data.DurationMinutes > maxDuration,
+		TooManyParticipants: len(data.Participants) > maxParticipants,
+		HighCrosstalkRate:   data.CrosstalkRate > maxCrosstalk,
+		IcebreakerMissing:   data.IcebreakerQuality == IcebreakerNone,
+		NoActionItems:       true,
+		NoDecisions:         true,
+	}
+
+	for _, item := range data.ActionItems {
+		if item.Type == "decision" {
+			health.NoDecisions = false
+		} else {
+			health.NoActionItems = false
+		}
+	}
+
+	seconds := SpeakingSeconds(data.Transcript)
+	var total, longest float64
+	for _, spoken := range seconds {
+		total += spoken
+		longest = max(longest, spoken)
+	}
+	health.DominantSpeakerAlert = total > 0 && len(seconds) > 1 && longest/total > dominantShare
+
+	if len(data.SentimentTimeline) > 0 {
+		var score float64
+		for _, point := range data.SentimentTimeline {
+			score += point.Score
+		}
+		health.NegativeSentiment = sentimentLabel(score/float64(len(data.SentimentTimeline))) == "negative"
+	}
+	if strings.Contains(strings.ToLower(data.Sentiment), "negative") {
+		health.NegativeSentiment = true
+	}
+
+	if len(data.AttentionScores) > 0 {
+		var attention float64
+		for _, score := range data.AttentionScores {
+			attention += score
+		}
+		health.LowEngagementAlert = attention/float64(len(data.AttentionScores)) < minAttention
+	}
+	return health
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+// healthyMeeting returns the analysis of a meeting raising none of the health indicators
+func healthyMeeting() *AnalysisData {
+	return &AnalysisData{
+		DurationMinutes: 30,
+		Participants:    []string{"Alice", "Bob"},
+		Transcript: []TranscriptEntry{
+			entryAt(0, "Alice", "Let's agree the launch date"),
+			entryAt(10, "Bob", "Friday works for the team"),
+		},
+		ActionItems:       []ActionItem{{Type: "task"}, {Type: "decision"}},
+		Sentiment:         "positive",
+		CrosstalkRate:     0.1,
+		AttentionScores:   map[string]float64{"Alice": 80, "Bob": 60},
+		IcebreakerQuality: IcebreakerBrief,
+	}
+}
+
+func TestHealthIndicators(t *testing.T) {
+	if health := computeHealthIndicators(healthyMeeting(), nil); *health != (MeetingHealthIndicators{}) {
+		t.Fatalf("healthy meeting indicators = %+v, want none", health)
+	}
+
+	cases := []struct {
+		name   string
+		change func(*AnalysisData)
+		want   MeetingHealthIndicators
+	}{
+		{"too long", func(d *AnalysisData) { d.DurationMinutes = 90 }, MeetingHealthIndicators{TooLong: true}},
+		{"too many participants", func(d *AnalysisData) {
+			d.Participants = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}
+		}, MeetingHealthIndicators{TooManyParticipants: true}},
+		{"dominant speaker", func(d *AnalysisData) {
+			d.Transcript = append(d.Transcript, entryAt(20, "Alice", "And then we also need to cover the launch checklist in detail"))
+		}, MeetingHealthIndicators{DominantSpeakerAlert: true}},
+		{"no action items", func(d *AnalysisData) { d.ActionItems = d.ActionItems[1:] }, MeetingHealthIndicators{NoActionItems: true}},
+		{"no decisions", func(d *AnalysisData) { d.ActionItems = d.ActionItems[:1] }, MeetingHealthIndicators{NoDecisions: true}},
+		{"negative sentiment", func(d *AnalysisData) { d.Sentiment = "Negative" }, MeetingHealthIndicators{NegativeSentiment: true}},
+		{"negative timeline", func(d *AnalysisData) {
+			d.Sentiment = ""
+			d.SentimentTimeline = []SentimentPoint{{Score: -0.8}, {Score: 0.2}}
+		}, MeetingHealthIndicators{NegativeSentiment: true}},
+		{"crosstalk", func(d *AnalysisData) { d.CrosstalkRate = 0.8 }, MeetingHealthIndicators{HighCrosstalkRate: true}},
+		{"low engagement", func(d *AnalysisData) { d.AttentionScores = map[string]float64{"Alice": 30, "Bob": 40} }, MeetingHealthIndicators{LowEngagementAlert: true}},
+		{"no icebreaker", func(d *AnalysisData) { d.IcebreakerQuality = IcebreakerNone }, MeetingHealthIndicators{IcebreakerMissing: true}},
+	}
+	for _, c := range cases {
+		data := healthyMeeting()
+		c.change(data)
+		if health := computeHealthIndicators(data, nil); *health != c.want {
+			t.Errorf("%s: indicators = %+v, want %+v", c.name, *health, c.want)
+		}
+	}
+}
+
+func TestHealthThresholds(t *testing.T) {
+	data := healthyMeeting()
+	data.DurationMinutes = 90
+	data.CrosstalkRate = 0.8
+
+	// Unset thresholds keep their defaults
+	thresholds := &models.HealthThresholds{MaxDurationMinutes: 120, MinAttentionScore: 75}
+	want := MeetingHealthIndicators{HighCrosstalkRate: true, LowEngagementAlert: true}
+	if health := computeHealthIndicators(data, thresholds); *health != want {
+		t.Errorf("indicators = %+v, want %+v", *health, want)
+	}
+}
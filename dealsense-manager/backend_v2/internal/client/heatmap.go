@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// heatmapWindow is the length of the time windows the engagement heatmap divides the meeting into
+	heatmapWindow = 2 * time.Minute
+	// heatmapLabelEvery is the number of columns between time labels in the ASCII heatmap, each label
+	// taking five columns and a space
+	heatmapLabelEvery = 6
+)
+
+// heatmapBlocks are the characters of the ASCII heatmap from least to most engaged
+var heatmapBlocks = []string{"░", "▒", "▓", "█"}
+
+// HeatmapRow is one participant's engagement across the meeting's time windows
+type HeatmapRow struct {
+	Speaker string        `json:"speaker"`
+	Cells   []HeatmapCell `json:"cells"`
+}
+
+// HeatmapCell is a participant's engagement in one time window
+type HeatmapCell struct {
+	TimeWindowStart time.Time `json:"time_window_start"`
+	Score           float64   `json:"score"` // Share of the participant's utterances spoken in the window, from 0 to 100
+}
+
+// computeEngagementHeatmap divides the meeting into 2-minute windows from the first utterance and scores
+// each participant in each window as the percentage of their utterances they spoke in it. Rows are
+// ordered by speaker and the agent's own utterances are left out.
+func computeEngagementHeatmap(transcript []TranscriptEntry) []HeatmapRow {
+	var start, end time.Time
+	counts := make(map[string]map[int]int)
+	totals := make(map[string]int)
+	for _, entry := range transcript {
+		if entry.IsAgent {
+			continue
+		}
+		if start.IsZero() || entry.Timestamp.Before(start) {
+			start = entry.Timestamp
+		}
+		if entry.Timestamp.After(end) {
+			end = entry.Timestamp
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+
+	for _, entry := range transcript {
+		if entry.IsAgent {
+			continue
+		}
+		if counts[entry.Speaker] == nil {
+			counts[entry.Speaker] = make(map[int]int)
+		}
+		counts[entry.Speaker][int(entry.Timestamp.Sub(start)/heatmapWindow)]++
+		totals[entry.Speaker]++
+	}
+
+	speakers := make([]string, 0, len(counts))
+	for speaker := range counts {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	windows := int(end.Sub(start)/heatmapWindow) + 1
+	rows := make([]HeatmapRow, 0, len(speakers))
+	for _, speaker := range speakers {
+		row := HeatmapRow{Speaker: speaker, Cells: make([]HeatmapCell, windows)}
+		for i := range row.Cells {
+			row.Cells[i] = HeatmapCell{
+				TimeWindowStart: start.Add(time.Duration(i) * heatmapWindow),
+				Score:           float64(counts[speaker][i]) / float64(totals[speaker]) * 100,
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// RenderHeatmapASCII formats the engagement heatmap as a text grid with a row per participant and a
+// column per time window, labeled with the window's start every six columns. Each cell's block shows
+// its score against the participant's most engaged window, or is blank when they didn't speak. It
+// returns "" when there is no heatmap.
+func (d *AnalysisData) RenderHeatmapASCII() string {
+	if len(d.EngagementHeatmap) == 0 {
+		return ""
+	}
+
+	labelWidth, columns := 0, 0
+	for _, row := range d.EngagementHeatmap {
+		labelWidth = max(labelWidth, len([]rune(row.Speaker)))
+		columns = max(columns, len(row.Cells))
+	}
+
+	var result strings.Builder
+	result.WriteString(strings.Repeat(" ", labelWidth+1))
+	cells := d.EngagementHeatmap[0].Cells
+	for i := 0; i < columns && i < len(cells); i += heatmapLabelEvery {
+		label := cells[i].TimeWindowStart.Format("15:04")
+		result.WriteString(fmt.Sprintf("%-*s", min(heatmapLabelEvery, columns-i), label[:min(len(label), columns-i)]))
+	}
+	result.WriteString("\n")
+
+	for _, row := range d.EngagementHeatmap {
+		result.WriteString(fmt.Sprintf("%-*s ", labelWidth, row.Speaker))
+		var peak float64
+		for _, cell := range row.Cells {
+			peak = max(peak, cell.Score)
+		}
+		for _, cell := range row.Cells {
+			result.WriteString(heatmapBlock(cell.Score, peak))
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// heatmapBlock returns the block for a score relative to the peak score of its row: blank for no
+// engagement, then ░ up to a quarter of the peak, ▒ up to half, ▓ up to three quarters and █ above
+func heatmapBlock(score, peak float64) string {
+	if score <= 0 || peak <= 0 {
+		return " "
+	}
+	ratio := score / peak
+	switch {
+	case ratio <= 0.25:
+		return heatmapBlocks[0]
+	case ratio <= 0.5:
+		return heatmapBlocks[1]
+	case ratio <= 0.75:
+		return heatmapBlocks[2]
+	default:
+		return heatmapBlocks[3]
+	}
+}
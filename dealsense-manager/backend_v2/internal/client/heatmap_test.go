@@ -0,0 +1,67 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapBlockThresholds(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{0, " "},
+		{1, "░"},
+		{25, "░"},
+		{25.1, "▒"},
+		{50, "▒"},
+		{50.1, "▓"},
+		{75, "▓"},
+		{75.1, "█"},
+		{100, "█"},
+	}
+	for _, tt := range tests {
+		if got := heatmapBlock(tt.score, 100); got != tt.want {
+			t.Errorf("heatmapBlock(%v, 100) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+	if got := heatmapBlock(10, 0); got != " " {
+		t.Errorf("heatmapBlock without a peak = %q, want blank", got)
+	}
+	// Scores are relative to the row's peak
+	if got := heatmapBlock(10, 10); got != "█" {
+		t.Errorf("heatmapBlock(10, 10) = %q, want a full block", got)
+	}
+}
+
+func TestComputeEngagementHeatmap(t *testing.T) {
+	rows := computeEngagementHeatmap([]TranscriptEntry{
+		entryAt(0, "Bob", "Morning"),
+		entryAt(30, "Alice", "Hi"),
+		entryAt(60, "Alice", "Agenda"),
+		entryAt(150, "Alice", "Pricing"),
+		{Timestamp: testMeetingStart.Add(200 * time.Second), Speaker: "Agent", Text: "Noted", IsAgent: true},
+		entryAt(300, "Alice", "Wrap-up"),
+	})
+
+	if len(rows) != 2 || rows[0].Speaker != "Alice" || rows[1].Speaker != "Bob" {
+		t.Fatalf("rows = %+v, want Alice and Bob", rows)
+	}
+	var scores []float64
+	for _, cell := range rows[0].Cells {
+		scores = append(scores, cell.Score)
+	}
+	if len(scores) != 3 || scores[0] != 50 || scores[1] != 25 || scores[2] != 25 {
+		t.Errorf("Alice's scores = %v, want [50 25 25]", scores)
+	}
+	if !rows[1].Cells[2].TimeWindowStart.Equal(testMeetingStart.Add(4*time.Minute)) || rows[1].Cells[0].Score != 100 {
+		t.Errorf("Bob's cells = %+v", rows[1].Cells)
+	}
+
+	grid := (&AnalysisData{EngagementHeatmap: rows}).RenderHeatmapASCII()
+	lines := strings.Split(grid, "\n")
+	if lines[1] != "Alice █▒▒" || lines[2] != "Bob   █  " {
+		t.Errorf("grid =\n%s", grid)
+	}
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/models"
+)
+
+// newTestAnalyst creates an analyst saving to a temporary directory that doesn't start analyses
+func newTestAnalyst(t *testing.T) *AnalystAgent {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	analyst := NewAnalystAgent("test-agent", models.AgentConfig{Name: "Test"}, nil)
+	analyst.lastAnalysis = time.Now()
+	// Saves still running after the test must not land in the next test's working directory, where an
+	// analyst created in the same second would load them
+	analyst.filePath = filepath.Join(dir, analyst.filePath)
+	t.Cleanup(func() { waitForSaves(t, analyst) })
+	return analyst
+}
+
+// waitForSaves waits until the analyst's writer has no saves left to run
+func waitForSaves(t *testing.T, analyst *AnalystAgent) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		analyst.writer.mu.Lock()
+		running := analyst.writer.running
+		analyst.writer.mu.Unlock()
+		if !running {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Error("analysis saves still running after the test")
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// testMeetingStart is the time transcripts built by tests start at
+var testMeetingStart = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+// say records an utterance spoken the given number of seconds into the meeting
+func say(analyst *AnalystAgent, seconds int, speaker, text string) {
+	analyst.ProcessUtterance([]map[string]interface{}{{
+		"speaker":   speaker,
+		"text":      text,
+		"timestamp": float64(testMeetingStart.Unix() + int64(seconds)),
+	}})
+}
+
+// entryAt returns a transcript entry spoken the given number of seconds into the meeting
+func entryAt(seconds int, speaker, text string) TranscriptEntry {
+	return TranscriptEntry{Timestamp: testMeetingStart.Add(time.Duration(seconds) * time.Second), Speaker: speaker, Text: text}
+}
+
+// approx reports whether two floats are equal up to rounding
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// keyPointsResponse is a key points response that passes the quality checks
+const keyPointsResponse = "```json\n" + `{"key_points": [
+	"The release ships on Friday after review",
+	"The launch moves from May to June",
+	"Alice owns the release checklist"
+]}` + "\n```"
+
+// sentEmail is an email a recordingMailer was asked to send
+type sentEmail struct {
+	to        []string
+	subject   string
+	plainBody string
+}
+
+// recordingMailer records the emails it is asked to send
+type recordingMailer struct {
+	sent []sentEmail
+}
+
+func (m *recordingMailer) SendDigest(to []string, subject string, htmlBody string, plainBody string) error {
+	m.sent = append(m.sent, sentEmail{to: to, subject: subject, plainBody: plainBody})
+	return nil
+}
+
+// writePriorMeeting stores an analysis file the way finalized meetings are saved
+func writePriorMeeting(t *testing.T, file string, data AnalysisData) {
+	t.Helper()
+	data.SchemaVersion = migration.CurrentSchemaVersion
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(AnalysisDataDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(AnalysisDataDir, file), raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"joinly-manager/internal/i18n"
+)
+
+// Report themes
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// ErrInvalidTheme is returned for a report theme other than light or dark
+var ErrInvalidTheme = errors.New("theme must be light or dark")
+
+// Layout of the speaking time chart
+const (
+	chartRowHeight = 28
+	chartBarWidth  = 380 // Width of the longest bar; names and labels take the rest of the 640 wide chart
+)
+
+//go:embed analysis_report.html
+var analysisReportHTML string
+
+// analysisReportTemplate is parsed once; "t" is replaced with the report locale's translations when rendering
+var analysisReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"t":             func(key string) string { return key },
+	"priorityClass": priorityClass,
+}).Parse(analysisReportHTML))
+
+// speakingTimeBar is one participant's row in the speaking time chart
+type speakingTimeBar struct {
+	Speaker string
+	Label   string
+	Y       int
+	TextY   int
+	Width   float64
+	LabelX  float64
+}
+
+// GetAnalysisHTML renders the analysis as a self-contained HTML page starting in the given theme ("light"
+// or "dark", defaulting to light). Readers can switch themes and collapse sections without JavaScript.
+func (a *AnalystAgent) GetAnalysisHTML(theme string) (string, error) {
+	switch theme {
+	case "", ThemeLight, ThemeDark:
+	default:
+		return "", ErrInvalidTheme
+	}
+
+	data := a.GetAnalysis()
+	data.Transcript = data.EnrichTranscriptWithDeepLinks()
+	locale := a.config.ReportLocale
+	if locale == "" {
+		locale = data.DetectedLanguage
+	}
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+
+	tmpl, err := analysisReportTemplate.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{"t": func(key string) string { return i18n.Translate(locale, key) }})
+
+	bars := speakingTimeBars(data.Transcript)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Data           *AnalysisData
+		Locale         string
+		Dark           bool
+		SentimentClass string
+		SpeakingTime   []speakingTimeBar
+		ChartHeight    int
+	}{
+		Data:           data,
+		Locale:         locale,
+		Dark:           theme == ThemeDark,
+		SentimentClass: sentimentClass(data.Sentiment),
+		SpeakingTime:   bars,
+		ChartHeight:    len(bars) * chartRowHeight,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render analysis report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// speakingTimeBars charts how long each participant spoke, longest first
+func speakingTimeBars(transcript []TranscriptEntry) []speakingTimeBar {
+	seconds := SpeakingSeconds(transcript)
+
+	speakers := make([]string, 0, len(seconds))
+	for speaker := range seconds {
+		speakers = append(speakers, speaker)
+	}
+	sort.Slice(speakers, func(i, j int) bool {
+		if seconds[speakers[i]] != seconds[speakers[j]] {
+			return seconds[speakers[i]] > seconds[speakers[j]]
+		}
+		return speakers[i] < speakers[j]
+	})
+	if len(speakers) == 0 || seconds[speakers[0]] == 0 {
+		return nil
+	}
+
+	bars := make([]speakingTimeBar, len(speakers))
+	for i, speaker := range speakers {
+		spoken := time.Duration(seconds[speaker] * float64(time.Second)).Round(time.Second)
+		width := float64(chartBarWidth) * seconds[speaker] / seconds[speakers[0]]
+		bars[i] = speakingTimeBar{
+			Speaker: speaker,
+			Label:   spoken.String(),
+			Y:       i*chartRowHeight + 4,
+			TextY:   i*chartRowHeight + 18,
+			Width:   width,
+			LabelX:  160 + width + 8,
+		}
+	}
+	return bars
+}
+
+// sentimentClass maps the overall sentiment to a badge color: positive is green, negative red, and
+// anything else (neutral, mixed) orange
+func sentimentClass(sentiment string) string {
+	switch sentiment = strings.ToLower(sentiment); {
+	case strings.Contains(sentiment, "negative"):
+		return "negative"
+	case strings.Contains(sentiment, "positive"):
+		return "positive"
+	default:
+		return "mixed"
+	}
+}
+
+// priorityClass maps an action item priority to its color, treating unknown priorities as medium
+func priorityClass(priority string) string {
+	switch priority = strings.ToLower(strings.TrimSpace(priority)); priority {
+	case "high", "low":
+		return priority
+	default:
+		return "medium"
+	}
+}
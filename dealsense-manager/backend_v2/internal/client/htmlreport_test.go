@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGetAnalysisHTML(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "Let's review the rollout plan before Monday.")
+	say(analyst, 30, "Bob", "Sounds good to me.")
+	analyst.dataMutex.Lock()
+	analyst.data.Summary = "Rollout <planned>"
+	analyst.data.Sentiment = "Mostly positive"
+	analyst.data.ActionItems = []ActionItem{{Description: "Email the customer", Assignee: "Bob", Priority: "urgent"}}
+	analyst.dataMutex.Unlock()
+
+	report, err := analyst.GetAnalysisHTML(ThemeDark)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<html lang="en">`,
+		`id="theme-toggle" checked`,
+		"Rollout &lt;planned&gt;",
+		"priority-medium", // Unknown priorities are shown as medium
+		">Alice<",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+
+	if light, _ := analyst.GetAnalysisHTML(""); strings.Contains(light, `id="theme-toggle" checked`) {
+		t.Error("default theme isn't light")
+	}
+	if _, err := analyst.GetAnalysisHTML("sepia"); !errors.Is(err, ErrInvalidTheme) {
+		t.Errorf("GetAnalysisHTML(sepia) error = %v, want ErrInvalidTheme", err)
+	}
+}
+
+func TestGetAnalysisHTMLTranslates(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.ReportLocale = "de"
+	report, err := analyst.GetAnalysisHTML(ThemeLight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(report, `<html lang="de">`) || !strings.Contains(report, "Analysebericht zur Besprechung") {
+		t.Error("report not rendered in German")
+	}
+}
+
+func TestSpeakingTimeBars(t *testing.T) {
+	bars := speakingTimeBars([]TranscriptEntry{
+		entryAt(0, "Bob", "one two three four five"),
+		entryAt(5, "Alice", "one two three four five six seven eight nine ten"),
+		{Speaker: "Agent", Text: "ignored words from the agent itself", IsAgent: true},
+	})
+	if len(bars) != 2 {
+		t.Fatalf("bars = %+v, want Alice and Bob", bars)
+	}
+	if bars[0].Speaker != "Alice" || bars[0].Width != chartBarWidth || bars[0].Label != "4s" {
+		t.Errorf("first bar = %+v, want Alice at full width", bars[0])
+	}
+	if bars[1].Speaker != "Bob" || bars[1].Width != chartBarWidth/2 || bars[1].Y != chartRowHeight+4 {
+		t.Errorf("second bar = %+v, want Bob at half width", bars[1])
+	}
+	if speakingTimeBars(nil) != nil {
+		t.Error("bars drawn for an empty transcript")
+	}
+}
+
+func TestSentimentClass(t *testing.T) {
+	for sentiment, want := range map[string]string{"Positive": "positive", "mostly negative": "negative", "neutral": "mixed", "": "mixed"} {
+		if got := sentimentClass(sentiment); got != want {
+			t.Errorf("sentimentClass(%q) = %q, want %q", sentiment, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// icebreakerWindow is the opening of the meeting searched for social conversation
+	icebreakerWindow = 5 * time.Minute
+	// thoroughIcebreakerSeconds is the length from which a social opener counts as thorough rather than brief
+	thoroughIcebreakerSeconds = 120
+)
+
+// How much social conversation opened the meeting
+const (
+	IcebreakerNone     = "none"
+	IcebreakerBrief    = "brief"
+	IcebreakerThorough = "thorough"
+)
+
+// defaultIcebreakerIndicators are the greetings, personal topics and laughter markers of social
+// conversation, used when the agent's IcebreakerIndicators are unset
+var defaultIcebreakerIndicators = []string{
+	"hi", "hello", "hey", "good morning", "good afternoon", "how are you", "how's it going", "nice to see",
+	"good to see", "weekend", "vacation", "holiday", "family", "kids", "weather", "trip", "birthday", "coffee",
+	"[laughter]", "[laughs]", "haha", "lol",
+}
+
+// updateIcebreaker detects the social opener of the meeting before its agenda, or topics when no agenda was
+// planned. Meetings without participant statements are left unscored, unlike meetings that started
+// straight with business, whose quality is none.
+func (a *AnalystAgent) updateIcebreaker() {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	var topics []string
+	for _, item := range a.config.Agenda {
+		topics = append(topics, item.Title)
+	}
+	if len(topics) == 0 {
+		for _, topic := range a.data.Topics {
+			topics = append(topics, topic.Topic)
+		}
+	}
+
+	indicators := a.config.IcebreakerIndicators
+	if len(indicators) == 0 {
+		indicators = defaultIcebreakerIndicators
+	}
+
+	detected, seconds, quality := detectIcebreaker(a.data.Transcript, topics, indicators)
+	a.data.IcebreakerDetected = detected
+	a.data.IcebreakerDurationSeconds = seconds
+	a.data.IcebreakerQuality = quality
+}
+
+// detectIcebreaker searches the first five minutes of the participants' statements for social conversation
+// matching the indicators as whole words, case-insensitively. The opener lasts until the first statement
+// sharing a word with the topics; without topics, until the first statement that isn't social. It returns
+// "" as the quality when there are no participant statements.
+func detectIcebreaker(transcript []TranscriptEntry, topics, indicators []string) (bool, float64, string) {
+	var statements []TranscriptEntry
+	for _, entry := range transcript {
+		if !entry.IsAgent {
+			statements = append(statements, entry)
+		}
+	}
+	if len(statements) == 0 {
+		return false, 0, ""
+	}
+
+	topicWords := make(map[string]bool)
+	for _, topic := range topics {
+		for word := range significantWords(topic) {
+			topicWords[word] = true
+		}
+	}
+	var patterns []*regexp.Regexp
+	for _, indicator := range indicators {
+		if pattern := icebreakerPattern(indicator); pattern != nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	start := statements[0].Timestamp
+	windowEnd := start.Add(icebreakerWindow)
+	end := statements[len(statements)-1].Timestamp
+	social := 0
+	for _, entry := range statements {
+		if entry.Timestamp.After(windowEnd) {
+			end = windowEnd
+			break
+		}
+		if sharesWord(significantWords(entry.Text), topicWords) {
+			end = entry.Timestamp
+			break
+		}
+		if matchesAny(entry.Text, patterns) {
+			social++
+			continue
+		}
+		if len(topicWords) == 0 {
+			end = entry.Timestamp
+			break
+		}
+	}
+
+	if social == 0 {
+		return false, 0, IcebreakerNone
+	}
+	seconds := end.Sub(start).Seconds()
+	if seconds >= thoroughIcebreakerSeconds {
+		return true, seconds, IcebreakerThorough
+	}
+	return true, seconds, IcebreakerBrief
+}
+
+// icebreakerPattern compiles an indicator into a case-insensitive pattern, bounding it by word boundaries
+// where it begins or ends with a word character so markers like "[laughter]" still match
+func icebreakerPattern(indicator string) *regexp.Regexp {
+	indicator = strings.TrimSpace(indicator)
+	if indicator == "" {
+		return nil
+	}
+	pattern := regexp.QuoteMeta(strings.ToLower(indicator))
+	if isWordByte(indicator[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWordByte(indicator[len(indicator)-1]) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + pattern)
+}
+
+// isWordByte reports whether b is an ASCII word character as matched by \w
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_'
+}
+
+// matchesAny reports whether any of the patterns matches text
+func matchesAny(text string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// sharesWord reports whether the word sets have a word in common
+func sharesWord(words, other map[string]bool) bool {
+	for word := range words {
+		if other[word] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+func TestDetectIcebreaker(t *testing.T) {
+	topics := []string{"Budget review"}
+	cases := []struct {
+		name       string
+		transcript []TranscriptEntry
+		indicators []string
+		detected   bool
+		seconds    float64
+		quality    string
+	}{
+		{"immediate business start", []TranscriptEntry{
+			entryAt(0, "Alice", "Let's start with the budget numbers"),
+			entryAt(20, "Bob", "Hi, sorry I'm late"),
+		}, defaultIcebreakerIndicators, false, 0, IcebreakerNone},
+		{"brief opener", []TranscriptEntry{
+			entryAt(0, "Alice", "Hello everyone, how was the weekend?"),
+			entryAt(15, "Bob", "Great, we went hiking [laughter]"),
+			entryAt(45, "Alice", "Okay, on to the budget"),
+		}, defaultIcebreakerIndicators, true, 45, IcebreakerBrief},
+		{"thorough opener", []TranscriptEntry{
+			entryAt(0, "Alice", "Good morning, how are you?"),
+			entryAt(60, "Bob", "Tired, the kids were up all night"),
+			entryAt(150, "Alice", "Now, the budget review"),
+		}, defaultIcebreakerIndicators, true, 150, IcebreakerThorough},
+		// Small talk between business doesn't count; "this" doesn't match "hi"
+		{"no social statements", []TranscriptEntry{
+			entryAt(0, "Alice", "Is this thing on?"),
+			entryAt(10, "Bob", "Yes, go ahead with the budget"),
+		}, defaultIcebreakerIndicators, false, 0, IcebreakerNone},
+		{"custom indicators", []TranscriptEntry{
+			entryAt(0, "Alice", "Did anyone catch the match?"),
+			entryAt(30, "Bob", "Budget first please"),
+		}, []string{"the match"}, true, 30, IcebreakerBrief},
+		{"no statements", []TranscriptEntry{
+			{Timestamp: testMeetingStart, Speaker: "Agent", Text: "Hello, I'm taking notes", IsAgent: true},
+		}, defaultIcebreakerIndicators, false, 0, ""},
+	}
+	for _, c := range cases {
+		detected, seconds, quality := detectIcebreaker(c.transcript, topics, c.indicators)
+		if detected != c.detected || seconds != c.seconds || quality != c.quality {
+			t.Errorf("%s: detectIcebreaker() = %v, %v, %q, want %v, %v, %q",
+				c.name, detected, seconds, quality, c.detected, c.seconds, c.quality)
+		}
+	}
+}
+
+func TestDetectIcebreakerWithoutTopics(t *testing.T) {
+	// Without topics, the opener ends at the first statement that isn't social
+	transcript := []TranscriptEntry{
+		entryAt(0, "Alice", "Hey, nice to see you"),
+		entryAt(20, "Bob", "Shall we look at the roadmap"),
+		entryAt(40, "Alice", "Haha, sure"),
+	}
+	detected, seconds, quality := detectIcebreaker(transcript, nil, defaultIcebreakerIndicators)
+	if !detected || seconds != 20 || quality != IcebreakerBrief {
+		t.Errorf("detectIcebreaker() = %v, %v, %q, want a 20s brief opener", detected, seconds, quality)
+	}
+}
+
+func TestUpdateIcebreakerUsesAgenda(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.Agenda = []models.AgendaItem{{Title: "Hiring plan"}}
+	say(analyst, 0, "Alice", "Morning, how was the holiday?")
+	say(analyst, 40, "Bob", "Relaxing, thanks for asking")
+	say(analyst, 90, "Alice", "Right, the hiring plan")
+
+	analyst.updateIcebreaker()
+	analysis := analyst.GetAnalysis()
+	if !analysis.IcebreakerDetected || analysis.IcebreakerDurationSeconds != 90 || analysis.IcebreakerQuality != IcebreakerBrief {
+		t.Errorf("icebreaker = %v, %v, %q", analysis.IcebreakerDetected, analysis.IcebreakerDurationSeconds, analysis.IcebreakerQuality)
+	}
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxQuickWins is the number of key points shown as quick wins on the insights card
+const maxQuickWins = 3
+
+// severityRanks orders the severities and priorities of risks and action items, higher being more urgent
+var severityRanks = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// MeetingInsightsCard is the meeting's most important outcomes in one structure, for consumers such as chat
+// bots, emails and dashboards that don't need the full analysis
+type MeetingInsightsCard struct {
+	Headline         string      `json:"headline"`                  // Single most important sentence about the meeting
+	TopActionItem    *ActionItem `json:"top_action_item,omitempty"` // Highest-priority open action item
+	TopRisk          *RiskItem   `json:"top_risk,omitempty"`        // Highest-severity risk, nil when none was found
+	MoodSummary      string      `json:"mood_summary"`              // e.g. "Started positive, ended negative"
+	QuickWins        []string    `json:"quick_wins,omitempty"`      // Up to 3 immediate takeaways
+	TotalCostUSD     float64     `json:"total_cost_usd"`
+	NextStepsSummary string      `json:"next_steps_summary"`
+}
+
+// RiskItem is a risk raised in the meeting, taken from its compliance flags, technical debt or success
+// metrics
+type RiskItem struct {
+	Description string `json:"description"`
+	Severity    string `json:"severity"` // low, medium, high
+	Source      string `json:"source"`   // compliance, technical_debt, success_metric
+}
+
+// updateInsightsCard builds the insights card from the finalized analysis
+func (a *AnalystAgent) updateInsightsCard() {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	a.data.InsightsCard = buildInsightsCard(a.data)
+}
+
+// GetInsightsCard returns the insights card built when the meeting was finalized, or nil
+func (a *AnalystAgent) GetInsightsCard() *MeetingInsightsCard {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return a.data.InsightsCard.clone()
+}
+
+// buildInsightsCard selects the card's fields from the analysis without any LLM calls. The total cost is
+// the attendance cost of the ROI report when there is one, or else the speaking-time cost estimate.
+func buildInsightsCard(data *AnalysisData) *MeetingInsightsCard {
+	card := &MeetingInsightsCard{
+		Headline:         insightsHeadline(data),
+		TopActionItem:    topActionItem(data.ActionItems),
+		TopRisk:          topRisk(data),
+		MoodSummary:      moodSummary(data),
+		NextStepsSummary: nextStepsSummary(data),
+	}
+	for _, point := range data.KeyPoints {
+		if len(card.QuickWins) == maxQuickWins {
+			break
+		}
+		if point = strings.TrimSpace(point); point != "" {
+			card.QuickWins = append(card.QuickWins, point)
+		}
+	}
+	if data.ROIReport != nil {
+		card.TotalCostUSD = data.ROIReport.EstimatedCostUSD
+	} else if data.CostEstimate != nil {
+		card.TotalCostUSD = data.CostEstimate.TotalCostUSD
+	}
+	return card
+}
+
+// insightsHeadline returns the first sentence of the executive summary, or of the tweet summary or summary
+// when there is none
+func insightsHeadline(data *AnalysisData) string {
+	for _, text := range []string{data.ExecutiveSummary, data.TweetSummary, data.Summary} {
+		if sentences := splitSentences(text); len(sentences) > 0 {
+			return sentences[0]
+		}
+	}
+	return ""
+}
+
+// topActionItem returns a copy of the open action item with the highest priority, the earliest on ties, or
+// nil when all are completed
+func topActionItem(items []ActionItem) *ActionItem {
+	var top *ActionItem
+	for i := range items {
+		if items[i].Status == "completed" {
+			continue
+		}
+		if top == nil || severityRanks[items[i].Priority] > severityRanks[top.Priority] {
+			top = &items[i]
+		}
+	}
+	if top == nil {
+		return nil
+	}
+	item := *top
+	return &item
+}
+
+// topRisk returns the risk with the highest severity among the compliance flags, technical debt and success
+// metrics that are at risk or off track, the first found on ties, or nil when there are none
+func topRisk(data *AnalysisData) *RiskItem {
+	var risks []RiskItem
+	for _, flag := range data.ComplianceFlags {
+		risks = append(risks, RiskItem{
+			Description: fmt.Sprintf("%s: %s", flag.Category, flag.Description),
+			Severity:    flag.Severity,
+			Source:      "compliance",
+		})
+	}
+	for _, debt := range data.TechnicalDebtMentions {
+		risks = append(risks, RiskItem{Description: debt.Description, Severity: debt.Severity, Source: "technical_debt"})
+	}
+	for _, metric := range data.SuccessMetricsTracked {
+		severity := ""
+		switch metric.Status {
+		case MetricOffTrack:
+			severity = "high"
+		case MetricAtRisk:
+			severity = "medium"
+		default:
+			continue
+		}
+		description := fmt.Sprintf("%s is %s", metric.MetricName, strings.ReplaceAll(metric.Status, "_", " "))
+		if metric.TargetValue != "" {
+			description += fmt.Sprintf(" (target %s)", metric.TargetValue)
+		}
+		risks = append(risks, RiskItem{Description: description, Severity: severity, Source: "success_metric"})
+	}
+
+	var top *RiskItem
+	for i := range risks {
+		if top == nil || severityRanks[risks[i].Severity] > severityRanks[top.Severity] {
+			top = &risks[i]
+		}
+	}
+	return top
+}
+
+// moodSummary returns the first sentence of the emotional arc narrative, or describes how the sentiment
+// timeline started and ended, or the overall sentiment when there is no timeline
+func moodSummary(data *AnalysisData) string {
+	if sentences := splitSentences(data.EmotionalArcNarrative); len(sentences) > 0 {
+		return sentences[0]
+	}
+	if timeline := data.SentimentTimeline; len(timeline) > 0 {
+		start, end := timeline[0].Sentiment, timeline[len(timeline)-1].Sentiment
+		if start == end {
+			return fmt.Sprintf("%s throughout", capitalize(start))
+		}
+		return fmt.Sprintf("Started %s, ended %s", start, end)
+	}
+	if data.Sentiment != "" {
+		return fmt.Sprintf("%s overall", capitalize(data.Sentiment))
+	}
+	return ""
+}
+
+// nextStepsSummary describes the open action items and the recommended follow-up meeting
+func nextStepsSummary(data *AnalysisData) string {
+	open, high := 0, 0
+	for _, item := range data.ActionItems {
+		if item.Status == "completed" {
+			continue
+		}
+		open++
+		if item.Priority == "high" {
+			high++
+		}
+	}
+
+	var summary string
+	switch {
+	case open == 0:
+		summary = "No open action items"
+	case open == 1:
+		summary = "1 open action item"
+	default:
+		summary = fmt.Sprintf("%d open action items", open)
+	}
+	if high > 0 {
+		summary += fmt.Sprintf(" (%d high priority)", high)
+	}
+	if suggestion := data.FollowUpSuggestion; suggestion != nil && suggestion.Recommended {
+		summary += "; a follow-up meeting is recommended"
+		switch suggestion.Urgency {
+		case "immediate":
+			summary += " immediately"
+		case "this_week", "next_sprint":
+			summary += " " + strings.ReplaceAll(suggestion.Urgency, "_", " ")
+		}
+	}
+	return summary + "."
+}
+
+// capitalize returns s with its first letter upper-cased
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// clone returns a deep copy of the card, or nil
+func (c *MeetingInsightsCard) clone() *MeetingInsightsCard {
+	if c == nil {
+		return nil
+	}
+	card := *c
+	if c.TopActionItem != nil {
+		item := *c.TopActionItem
+		card.TopActionItem = &item
+	}
+	if c.TopRisk != nil {
+		risk := *c.TopRisk
+		card.TopRisk = &risk
+	}
+	card.QuickWins = append([]string(nil), c.QuickWins...)
+	return &card
+}
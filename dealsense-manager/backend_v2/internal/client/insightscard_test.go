@@ -0,0 +1,69 @@
+package client
+
+import "testing"
+
+func TestBuildInsightsCard(t *testing.T) {
+	data := &AnalysisData{
+		ExecutiveSummary: "The team agreed to launch on Friday. Legal must sign off first.",
+		ActionItems: []ActionItem{
+			{ID: "1", Description: "Update the docs", Priority: "low", Status: "pending"},
+			{ID: "2", Description: "Get legal sign-off", Priority: "high", Status: "pending"},
+			{ID: "3", Description: "Book the venue", Priority: "high", Status: "completed"},
+		},
+		ComplianceFlags:       []ComplianceFlag{{Category: "GDPR", Description: "Customer emails shared", Severity: "medium"}},
+		TechnicalDebtMentions: []TechDebtItem{{Description: "Billing service has no tests", Severity: "low"}},
+		SuccessMetricsTracked: []SuccessMetric{{MetricName: "Signups", Status: MetricOffTrack, TargetValue: "1,000"}},
+		SentimentTimeline:     []SentimentPoint{{Sentiment: "positive"}, {Sentiment: "negative"}},
+		KeyPoints:             []string{"Launch Friday", " ", "Legal review", "New pricing", "Hiring freeze"},
+		CostEstimate:          &MeetingCostEstimate{TotalCostUSD: 42},
+		FollowUpSuggestion:    &FollowUpSuggestion{Recommended: true, Urgency: "this_week"},
+	}
+
+	card := buildInsightsCard(data)
+	if card.Headline != "The team agreed to launch on Friday." {
+		t.Errorf("headline = %q", card.Headline)
+	}
+	if card.TopActionItem == nil || card.TopActionItem.ID != "2" {
+		t.Errorf("top action item = %+v, want the open high-priority item", card.TopActionItem)
+	}
+	if card.TopRisk == nil || *card.TopRisk != (RiskItem{Description: "Signups is off track (target 1,000)", Severity: "high", Source: "success_metric"}) {
+		t.Errorf("top risk = %+v", card.TopRisk)
+	}
+	if card.MoodSummary != "Started positive, ended negative" {
+		t.Errorf("mood = %q", card.MoodSummary)
+	}
+	if len(card.QuickWins) != 3 || card.QuickWins[1] != "Legal review" {
+		t.Errorf("quick wins = %q, want the first three non-empty key points", card.QuickWins)
+	}
+	if card.TotalCostUSD != 42 {
+		t.Errorf("total cost = %v", card.TotalCostUSD)
+	}
+	if card.NextStepsSummary != "2 open action items (1 high priority); a follow-up meeting is recommended this week." {
+		t.Errorf("next steps = %q", card.NextStepsSummary)
+	}
+
+	card.TopActionItem.Description = "changed"
+	if data.ActionItems[1].Description != "Get legal sign-off" {
+		t.Error("card shares the top action item with the analysis")
+	}
+}
+
+func TestInsightsCardWithoutRisks(t *testing.T) {
+	card := buildInsightsCard(&AnalysisData{
+		Summary:               "A quiet sync.",
+		Sentiment:             "neutral",
+		SuccessMetricsTracked: []SuccessMetric{{MetricName: "Uptime", Status: "on_track"}},
+		ROIReport:             &MeetingROI{EstimatedCostUSD: 120},
+		CostEstimate:          &MeetingCostEstimate{TotalCostUSD: 42},
+	})
+
+	if card.TopRisk != nil {
+		t.Errorf("top risk = %+v, want nil without risks", card.TopRisk)
+	}
+	if card.TopActionItem != nil || card.NextStepsSummary != "No open action items." {
+		t.Errorf("card = %+v", card)
+	}
+	if card.Headline != "A quiet sync." || card.MoodSummary != "Neutral overall" || card.TotalCostUSD != 120 {
+		t.Errorf("card = %+v, want the summary, overall sentiment and ROI cost", card)
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import "sort"
+
+// InteractionEdge counts how often a participant spoke right after another one
+type InteractionEdge struct {
+	FromSpeaker       string  `json:"from_speaker"` // Speaker who responded
+	ToSpeaker         string  `json:"to_speaker"`   // Speaker of the immediately preceding utterance
+	Count             int     `json:"count"`
+	AverageLagSeconds float64 `json:"average_lag_seconds"` // Time from the preceding utterance ending to the response starting
+}
+
+// updateInteractionPatterns adds the newest transcript entry to the edge from its speaker to the speaker
+// before them and recomputes the central speaker. Utterances continuing the same speaker's turn aren't
+// interactions. Caller must hold dataMutex.
+func (a *AnalystAgent) updateInteractionPatterns() {
+	count := len(a.data.Transcript)
+	if count < 2 {
+		return
+	}
+	previous, current := a.data.Transcript[count-2], a.data.Transcript[count-1]
+	if previous.Speaker == current.Speaker {
+		return
+	}
+
+	lag := responseLatency(previous, current)
+	edge := a.findInteractionEdge(current.Speaker, previous.Speaker)
+	if edge == nil {
+		a.data.InteractionPatterns = append(a.data.InteractionPatterns, InteractionEdge{
+			FromSpeaker: current.Speaker,
+			ToSpeaker:   previous.Speaker,
+		})
+		edge = &a.data.InteractionPatterns[len(a.data.InteractionPatterns)-1]
+	}
+	edge.AverageLagSeconds = (edge.AverageLagSeconds*float64(edge.Count) + lag) / float64(edge.Count+1)
+	edge.Count++
+
+	a.data.CentralitySpeaker = centralSpeaker(a.data.InteractionPatterns)
+}
+
+// findInteractionEdge returns the edge between two speakers, or nil when they haven't interacted (caller
+// must hold dataMutex)
+func (a *AnalystAgent) findInteractionEdge(from, to string) *InteractionEdge {
+	for i := range a.data.InteractionPatterns {
+		if edge := &a.data.InteractionPatterns[i]; edge.FromSpeaker == from && edge.ToSpeaker == to {
+			return edge
+		}
+	}
+	return nil
+}
+
+// centralSpeaker returns the speaker involved in the most interactions, either responding or being
+// responded to, breaking ties alphabetically, or "" when there are no interactions
+func centralSpeaker(edges []InteractionEdge) string {
+	degree := make(map[string]int)
+	for _, edge := range edges {
+		degree[edge.FromSpeaker] += edge.Count
+		degree[edge.ToSpeaker] += edge.Count
+	}
+
+	speakers := make([]string, 0, len(degree))
+	for speaker := range degree {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	central := ""
+	for _, speaker := range speakers {
+		if central == "" || degree[speaker] > degree[central] {
+			central = speaker
+		}
+	}
+	return central
+}
+
+// GetInteractionGraph returns the interaction counts as an adjacency matrix, keyed by the responding
+// speaker and then by the speaker they responded to
+func (a *AnalystAgent) GetInteractionGraph() map[string]map[string]int {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	graph := make(map[string]map[string]int)
+	for _, edge := range a.data.InteractionPatterns {
+		if graph[edge.FromSpeaker] == nil {
+			graph[edge.FromSpeaker] = make(map[string]int)
+		}
+		graph[edge.FromSpeaker][edge.ToSpeaker] = edge.Count
+	}
+	return graph
+}
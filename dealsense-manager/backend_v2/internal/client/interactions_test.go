@@ -0,0 +1,54 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInteractionGraphRoundRobin(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	speakers := []string{"Alice", "Bob", "Carol"}
+	for i := 0; i < 7; i++ {
+		say(analyst, i*10, speakers[i%3], "Passing the update along")
+	}
+
+	// Two full rounds, each speaker answering the one before them as often as the others
+	want := map[string]map[string]int{
+		"Bob":   {"Alice": 2},
+		"Carol": {"Bob": 2},
+		"Alice": {"Carol": 2},
+	}
+	if got := analyst.GetInteractionGraph(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetInteractionGraph() = %v, want %v", got, want)
+	}
+}
+
+func TestInteractionPatternsSkipSameSpeaker(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "First part of my point")
+	say(analyst, 10, "Alice", "Second part of my point")
+	say(analyst, 20, "Bob", "I agree with that")
+
+	if got := analyst.GetInteractionGraph(); !reflect.DeepEqual(got, map[string]map[string]int{"Bob": {"Alice": 1}}) {
+		t.Errorf("GetInteractionGraph() = %v", got)
+	}
+}
+
+func TestCentralSpeaker(t *testing.T) {
+	edges := []InteractionEdge{
+		{FromSpeaker: "Bob", ToSpeaker: "Alice", Count: 2},
+		{FromSpeaker: "Carol", ToSpeaker: "Alice", Count: 1},
+	}
+	if got := centralSpeaker(edges); got != "Alice" {
+		t.Errorf("centralSpeaker() = %q, want Alice", got)
+	}
+
+	// Ties are broken alphabetically
+	edges = []InteractionEdge{{FromSpeaker: "Bob", ToSpeaker: "Alice", Count: 1}}
+	if got := centralSpeaker(edges); got != "Alice" {
+		t.Errorf("centralSpeaker() = %q, want Alice on a tie", got)
+	}
+	if got := centralSpeaker(nil); got != "" {
+		t.Errorf("centralSpeaker(nil) = %q, want empty", got)
+	}
+}
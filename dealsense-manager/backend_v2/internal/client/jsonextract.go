@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JSON extraction strategies, in the order they are tried
+const (
+	ExtractionCodeBlock   = "code_block"            // A ```json ... ``` block
+	ExtractionRawJSON     = "raw_json"              // A bare JSON object or array in the response
+	ExtractionWrapper     = "json_response_wrapper" // A {"json_response": ...} wrapper around the JSON
+	ExtractionLLMReformat = "llm_reformat"          // The LLM reformatted its response as JSON
+)
+
+// maxRawJSONCandidates bounds the opening braces and brackets tried as the start of bare JSON
+const maxRawJSONCandidates = 20
+
+// StepMetadata describes how an analysis step's result was produced
+type StepMetadata struct {
+	ExtractionStrategy string `json:"extraction_strategy,omitempty"` // How the JSON was found in the LLM response
+}
+
+// extractedJSON is JSON found in an LLM response and the strategy that found it
+type extractedJSON struct {
+	data     string
+	strategy string
+}
+
+// extractJSON finds the JSON in an LLM response without calling the LLM: a ```json block, or else the first
+// bare JSON object or array. A {"json_response": ...} wrapper found either way is stripped. Returns an
+// empty result when the response has no JSON.
+func extractJSON(response string) extractedJSON {
+	result := extractJSONCodeBlock(response)
+	if result.data == "" {
+		result = extractRawJSON(response)
+	}
+	if result.data == "" {
+		return result
+	}
+
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result.data), &wrapper); err == nil && len(wrapper) == 1 {
+		if inner, ok := wrapper["json_response"]; ok && len(bytes.TrimSpace(inner)) > 0 {
+			return extractedJSON{data: string(bytes.TrimSpace(inner)), strategy: ExtractionWrapper}
+		}
+	}
+	return result
+}
+
+// extractJSONCodeBlock returns the content of the first ```json block
+func extractJSONCodeBlock(response string) extractedJSON {
+	startMarker := "```json"
+	endMarker := "```"
+
+	startIdx := strings.Index(response, startMarker)
+	if startIdx == -1 {
+		return extractedJSON{}
+	}
+	startIdx += len(startMarker)
+
+	endIdx := strings.Index(response[startIdx:], endMarker)
+	if endIdx == -1 {
+		return extractedJSON{}
+	}
+
+	content := strings.TrimSpace(response[startIdx : startIdx+endIdx])
+	if content == "" {
+		return extractedJSON{}
+	}
+	return extractedJSON{data: content, strategy: ExtractionCodeBlock}
+}
+
+// extractRawJSON returns the first valid JSON object or array in the response, ignoring text around it
+func extractRawJSON(response string) extractedJSON {
+	if trimmed := strings.TrimSpace(response); json.Valid([]byte(trimmed)) && strings.ContainsAny(trimmed[:1], "{[") {
+		return extractedJSON{data: trimmed, strategy: ExtractionRawJSON}
+	}
+
+	offset := 0
+	for attempt := 0; attempt < maxRawJSONCandidates; attempt++ {
+		start := strings.IndexAny(response[offset:], "{[")
+		if start == -1 {
+			break
+		}
+		start += offset
+
+		// The decoder stops at the end of the first value, so trailing text doesn't make it invalid
+		var value json.RawMessage
+		if err := json.NewDecoder(strings.NewReader(response[start:])).Decode(&value); err == nil {
+			return extractedJSON{data: string(value), strategy: ExtractionRawJSON}
+		}
+		offset = start + 1
+	}
+	return extractedJSON{}
+}
+
+// extractJSONFromResponse returns the JSON in an LLM response, or "" when there is none. When the response
+// has no ```json block or bare JSON, the LLM is asked to reformat it as JSON. The strategy that found the
+// JSON is logged and recorded in the metadata of the running analysis step.
+func (a *AnalystAgent) extractJSONFromResponse(ctx context.Context, response string) string {
+	result := extractJSON(response)
+	if result.data == "" && strings.TrimSpace(response) != "" && a.llmProvider != nil {
+		result = a.reformatAsJSON(ctx, response)
+	}
+	if result.data == "" {
+		return ""
+	}
+
+	step, _ := a.analysisStepLabel.Load().(string)
+	if result.strategy == ExtractionCodeBlock {
+		logrus.Debugf("Agent %s: Extracted %s JSON from a code block", a.agentID, step)
+	} else {
+		logrus.Infof("Agent %s: Extracted %s JSON using the %s strategy", a.agentID, step, result.strategy)
+	}
+
+	if step != "" {
+		a.dataMutex.Lock()
+		if a.data.AnalysisMetadata == nil {
+			a.data.AnalysisMetadata = make(map[string]StepMetadata)
+		}
+		metadata := a.data.AnalysisMetadata[step]
+		metadata.ExtractionStrategy = result.strategy
+		a.data.AnalysisMetadata[step] = metadata
+		a.dataMutex.Unlock()
+	}
+	return result.data
+}
+
+// reformatAsJSON asks the LLM to rewrite a response that contains no JSON as JSON, as a last resort
+func (a *AnalystAgent) reformatAsJSON(ctx context.Context, response string) extractedJSON {
+	prompt := `The following response was supposed to be JSON but isn't. Rewrite it as the JSON it was meant to be, keeping its content unchanged and adding nothing.
+
+Response:
+` + response + `
+
+Provide only the JSON within a code block:
+` + "```json\n{}\n```"
+
+	reformatted, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Agent %s: Failed to reformat a non-JSON response as JSON: %v", a.agentID, err)
+		return extractedJSON{}
+	}
+
+	result := extractJSON(reformatted)
+	if result.data == "" {
+		logrus.Warnf("Agent %s: LLM response contained no JSON, even after reformatting", a.agentID)
+		return result
+	}
+	result.strategy = ExtractionLLMReformat
+	return result
+}
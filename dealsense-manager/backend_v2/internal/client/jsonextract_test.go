@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractJSONStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		data     string
+		strategy string
+	}{
+		{"code block", "Here you go:\n```json\n{\"a\": 1}\n```\nDone.", `{"a": 1}`, ExtractionCodeBlock},
+		{"bare object", `{"a": 1}`, `{"a": 1}`, ExtractionRawJSON},
+		{"object in prose", `Sure! {"a": [1, 2]} Let me know.`, `{"a": [1, 2]}`, ExtractionRawJSON},
+		{"array after invalid braces", `Use {placeholders} like [1, 2]`, `[1, 2]`, ExtractionRawJSON},
+		{"wrapper", `{"json_response": {"a": 1}}`, `{"a": 1}`, ExtractionWrapper},
+		{"wrapper in code block", "```json\n{\"json_response\": [1]}\n```", `[1]`, ExtractionWrapper},
+		{"wrapper with other keys", `{"json_response": 1, "b": 2}`, `{"json_response": 1, "b": 2}`, ExtractionRawJSON},
+		{"no JSON", "I couldn't find any action items.", "", ""},
+		{"empty code block", "```json\n```", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractJSON(tt.response)
+			if result.data != tt.data || result.strategy != tt.strategy {
+				t.Errorf("extractJSON() = %q via %q, want %q via %q", result.data, result.strategy, tt.data, tt.strategy)
+			}
+		})
+	}
+}
+
+func TestExtractJSONFromResponseReformats(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n{\"items\": [\"Send the deck\"]}\n```")
+	analyst.llmProvider = mock
+	analyst.analysisStepLabel.Store("action_items")
+
+	data := analyst.extractJSONFromResponse(context.Background(), "Items: Send the deck")
+	if data != `{"items": ["Send the deck"]}` {
+		t.Errorf("data = %q", data)
+	}
+	if len(mock.Prompts()) != 1 {
+		t.Errorf("%d LLM calls, want one reformat", len(mock.Prompts()))
+	}
+	if strategy := analyst.GetAnalysis().AnalysisMetadata["action_items"].ExtractionStrategy; strategy != ExtractionLLMReformat {
+		t.Errorf("recorded strategy = %q, want %q", strategy, ExtractionLLMReformat)
+	}
+}
+
+func TestExtractJSONFromResponseWithoutReformat(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("Still no JSON, sorry.")
+	analyst.llmProvider = mock
+
+	if data := analyst.extractJSONFromResponse(context.Background(), `{"a": 1}`); data != `{"a": 1}` {
+		t.Errorf("data = %q", data)
+	}
+	if len(mock.Prompts()) != 0 {
+		t.Error("LLM called for a response that already had JSON")
+	}
+	if data := analyst.extractJSONFromResponse(context.Background(), "No JSON here"); data != "" {
+		t.Errorf("data = %q, want nothing when the reformat has no JSON either", data)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// kafkaDialTimeout bounds connecting to a broker
+	kafkaDialTimeout = 10 * time.Second
+	// kafkaRetryMin and kafkaRetryMax bound the backoff between attempts to fetch or commit messages
+	kafkaRetryMin = time.Second
+	kafkaRetryMax = 30 * time.Second
+)
+
+// SetKafkaTLS sets whether the Kafka consumer connects to the brokers over TLS
+func (a *AnalystAgent) SetKafkaTLS(enabled bool) {
+	a.kafkaTLS = enabled
+}
+
+// StartKafkaConsumer reads transcript segments from a Kafka topic as a member of the consumer group
+// groupID, passing each message to ProcessUtterance. A message's value is a JSON array of segments in
+// the format ProcessUtterance accepts:
+//
+//	[{"speaker": "Alice", "text": "Let's review the roadmap", "timestamp": 1718000000.5}]
+//
+// Messages keyed by another agent's ID are skipped, so one topic can carry several meetings; messages
+// without a key are processed by every consumer. Offsets are committed once a message is processed, and
+// messages that aren't a segment array are logged and committed so they don't block the partition.
+// Partitions are reassigned between group members as they join and leave. It blocks until ctx is
+// cancelled, returning nil, or the consumer fails permanently.
+func (a *AnalystAgent) StartKafkaConsumer(ctx context.Context, brokers []string, topic, groupID string) error {
+	if len(brokers) == 0 || topic == "" || groupID == "" {
+		return fmt.Errorf("kafka brokers, topic and group ID are required")
+	}
+
+	dialer := &kafka.Dialer{Timeout: kafkaDialTimeout, DualStack: true}
+	if a.kafkaTLS {
+		dialer.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		Dialer:         dialer,
+		GroupBalancers: []kafka.GroupBalancer{kafka.RangeGroupBalancer{}, kafka.RoundRobinGroupBalancer{}},
+		StartOffset:    kafka.LastOffset, // A new group only reads segments of the meeting in progress
+		CommitInterval: 0,                // Commit each message synchronously once it has been processed
+		ErrorLogger: kafka.LoggerFunc(func(msg string, args ...interface{}) {
+			logrus.Warnf("Agent %s: Kafka: "+msg, append([]interface{}{a.agentID}, args...)...)
+		}),
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logrus.Warnf("Agent %s: Failed to close Kafka consumer: %v", a.agentID, err)
+		}
+	}()
+
+	logrus.Infof("Agent %s: Consuming transcript from Kafka topic %s as group %s", a.agentID, topic, groupID)
+
+	backoff := kafkaRetryMin
+	for {
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, kafka.ErrGroupClosed) {
+				return fmt.Errorf("kafka consumer group closed: %w", err)
+			}
+			// Fetches fail while partitions are being rebalanced; the reader rejoins the group on retry
+			logrus.Warnf("Agent %s: Failed to fetch Kafka message, retrying in %v: %v", a.agentID, backoff, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, kafkaRetryMax)
+			continue
+		}
+		backoff = kafkaRetryMin
+
+		a.processKafkaMessage(message)
+
+		if err := a.commitKafkaMessage(ctx, reader, message); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// processKafkaMessage passes the segments of a message to ProcessUtterance, unless it is keyed by another agent
+func (a *AnalystAgent) processKafkaMessage(message kafka.Message) {
+	if len(message.Key) > 0 && string(message.Key) != a.agentID {
+		return
+	}
+
+	var segments []map[string]interface{}
+	if err := json.Unmarshal(message.Value, &segments); err != nil {
+		logrus.Errorf("Agent %s: Skipping Kafka message at %s[%d]@%d that is not a segment array: %v",
+			a.agentID, message.Topic, message.Partition, message.Offset, err)
+		return
+	}
+	a.ProcessUtterance(segments)
+}
+
+// commitKafkaMessage commits the offset of a processed message, retrying with backoff. Commits for a
+// partition that was reassigned during a rebalance fail until the reader rejoins the group, after which
+// the message is redelivered to its new owner.
+func (a *AnalystAgent) commitKafkaMessage(ctx context.Context, reader *kafka.Reader, message kafka.Message) error {
+	backoff := kafkaRetryMin
+	for attempt := 1; ; attempt++ {
+		err := reader.CommitMessages(ctx, message)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || errors.Is(err, kafka.ErrGroupClosed) || backoff >= kafkaRetryMax {
+			return fmt.Errorf("failed to commit Kafka offset %d of partition %d: %w", message.Offset, message.Partition, err)
+		}
+		logrus.Warnf("Agent %s: Failed to commit Kafka offset (attempt %d), retrying in %v: %v", a.agentID, attempt, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, kafkaRetryMax)
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaSegment encodes a message value of one segment spoken the given number of seconds into the meeting
+func kafkaSegment(seconds int, speaker, text string) []byte {
+	return []byte(fmt.Sprintf(`[{"speaker": %q, "text": %q, "timestamp": %d}]`, speaker, text, testMeetingStart.Unix()+int64(seconds)))
+}
+
+func TestProcessKafkaMessage(t *testing.T) {
+	analyst := newTestAnalyst(t)
+
+	analyst.processKafkaMessage(kafka.Message{Key: []byte("test-agent"), Value: kafkaSegment(0, "Alice", "Keyed to this agent")})
+	analyst.processKafkaMessage(kafka.Message{Value: kafkaSegment(10, "Bob", "Without a key for everyone")})
+	analyst.processKafkaMessage(kafka.Message{Key: []byte("other-agent"), Value: kafkaSegment(20, "Carol", "Keyed to another agent")})
+	analyst.processKafkaMessage(kafka.Message{Value: []byte(`{"speaker": "Dave"}`)})
+
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != 2 || transcript[0].Speaker != "Alice" || transcript[1].Speaker != "Bob" {
+		t.Errorf("transcript = %+v, want the keyed and unkeyed messages only", transcript)
+	}
+}
+
+func TestStartKafkaConsumerRequiresConfig(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if err := analyst.StartKafkaConsumer(context.Background(), nil, "transcripts", "group"); err == nil {
+		t.Error("StartKafkaConsumer() without brokers = nil, want an error")
+	}
+	if err := analyst.StartKafkaConsumer(context.Background(), []string{"localhost:9092"}, "transcripts", ""); err == nil {
+		t.Error("StartKafkaConsumer() without a group = nil, want an error")
+	}
+}
+
+func TestStartKafkaConsumerStopsOnCancel(t *testing.T) {
+	analyst := newTestAnalyst(t)
+
+	// Nothing listens on the broker address, so fetches keep failing until the context is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- analyst.StartKafkaConsumer(ctx, []string{"127.0.0.1:1"}, "transcripts", "group") }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("StartKafkaConsumer() = %v, want nil once cancelled", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("consumer didn't stop when its context was cancelled")
+	}
+}
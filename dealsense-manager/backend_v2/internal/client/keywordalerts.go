@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/models"
+)
+
+const (
+	// defaultKeywordAlertCooldown is how long an alert stays quiet after firing when it has no cooldown
+	defaultKeywordAlertCooldown = time.Minute
+	// keywordAlertTimeout bounds posting an alert to Discord
+	keywordAlertTimeout = 10 * time.Second
+	// keywordAlertColor is the embed color of alerts without one, Discord's red
+	keywordAlertColor = 0xED4245
+	// maxEmbedFieldLength is the longest value Discord accepts in an embed field
+	maxEmbedFieldLength = 1024
+)
+
+// keywordAlertHTTPClient posts keyword alerts straight to their webhooks rather than through the log hook
+var keywordAlertHTTPClient = &http.Client{Timeout: keywordAlertTimeout}
+
+// keywordAlertState holds the compiled patterns and the time each alert last fired, keyed by pattern
+type keywordAlertState struct {
+	patterns  map[string]*regexp.Regexp // nil for patterns that don't compile, so they're only reported once
+	lastFired map[string]time.Time
+}
+
+// checkKeywordAlerts posts an alert for each configured pattern the entry matches, unless the alert fired
+// within its cooldown. Alerts are posted in the background so the transcript isn't held up by Discord.
+// Caller must hold dataMutex.
+func (a *AnalystAgent) checkKeywordAlerts(entry TranscriptEntry) {
+	if len(a.config.KeywordAlerts) == 0 {
+		return
+	}
+
+	a.keywordAlertMutex.Lock()
+	defer a.keywordAlertMutex.Unlock()
+
+	if a.keywordAlerts.patterns == nil {
+		a.keywordAlerts.patterns = make(map[string]*regexp.Regexp)
+		a.keywordAlerts.lastFired = make(map[string]time.Time)
+	}
+
+	now := time.Now()
+	for _, alert := range a.config.KeywordAlerts {
+		if alert.Pattern == "" || alert.WebhookURL == "" {
+			continue
+		}
+
+		pattern, compiled := a.keywordAlerts.patterns[alert.Pattern]
+		if !compiled {
+			var err error
+			if pattern, err = regexp.Compile(alert.Pattern); err != nil {
+				logrus.Warnf("Agent %s: Ignoring keyword alert with invalid pattern %q: %v", a.agentID, alert.Pattern, err)
+			}
+			a.keywordAlerts.patterns[alert.Pattern] = pattern
+		}
+		if pattern == nil || !pattern.MatchString(entry.Text) {
+			continue
+		}
+
+		cooldown := alert.Cooldown
+		if cooldown <= 0 {
+			cooldown = defaultKeywordAlertCooldown
+		}
+		if last, ok := a.keywordAlerts.lastFired[alert.Pattern]; ok && now.Sub(last) < cooldown {
+			continue
+		}
+		a.keywordAlerts.lastFired[alert.Pattern] = now
+
+		go func(alert models.KeywordAlert) {
+			if err := a.postKeywordAlert(alert, entry); err != nil {
+				logrus.Warnf("Agent %s: Failed to post keyword alert for %q: %v", a.agentID, alert.Pattern, err)
+			}
+		}(alert)
+	}
+}
+
+// postKeywordAlert posts a Discord embed quoting the matching utterance to the alert's webhook
+func (a *AnalystAgent) postKeywordAlert(alert models.KeywordAlert, entry TranscriptEntry) error {
+	title := alert.Message
+	if title == "" {
+		title = fmt.Sprintf("Keyword alert: %s", alert.Pattern)
+	}
+	color := alert.Color
+	if color == 0 {
+		color = keywordAlertColor
+	}
+	utterance := entry.Text
+	if len(utterance) > maxEmbedFieldLength {
+		utterance = utterance[:maxEmbedFieldLength-3] + "..."
+	}
+
+	message := config.DiscordMessage{
+		Embeds: []config.DiscordEmbed{{
+			Title: title,
+			Color: color,
+			Fields: []config.DiscordEmbedField{
+				{Name: "Speaker", Value: entry.Speaker, Inline: true},
+				{Name: "Timestamp", Value: entry.Timestamp.Format(time.RFC3339), Inline: true},
+				{Name: "Utterance", Value: utterance},
+			},
+			Footer:    &config.DiscordEmbedFooter{Text: "DealSense · " + a.agentID},
+			Timestamp: entry.Timestamp.Format(time.RFC3339),
+		}},
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal keyword alert: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), keywordAlertTimeout)
+	defer cancel()
+
+	if err := postWebhook(ctx, keywordAlertHTTPClient, alert.WebhookURL, nil, payload); err != nil {
+		if a.queueWebhookRetry("keyword_alert", alert.WebhookURL, payload, err) {
+			return nil
+		}
+		return fmt.Errorf("failed to post keyword alert: %w", err)
+	}
+
+	logrus.Infof("Agent %s: Posted keyword alert for %q", a.agentID, alert.Pattern)
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/config"
+	"joinly-manager/internal/models"
+)
+
+// alertServer records the Discord messages posted to it
+func alertServer(t *testing.T) (*httptest.Server, chan config.DiscordMessage) {
+	t.Helper()
+	messages := make(chan config.DiscordMessage, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var message config.DiscordMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("invalid alert: %s", body)
+		}
+		messages <- message
+	}))
+	t.Cleanup(server.Close)
+	return server, messages
+}
+
+func TestKeywordAlertFiresOncePerCooldown(t *testing.T) {
+	server, messages := alertServer(t)
+	analyst := newTestAnalyst(t)
+	analyst.config.KeywordAlerts = []models.KeywordAlert{
+		{Pattern: `(?i)\b(cancel|churn)\b`, WebhookURL: server.URL, Message: "Churn risk", Cooldown: time.Hour},
+	}
+
+	say(analyst, 0, "Dana", "We might cancel the contract")
+	say(analyst, 5, "Dana", "Churn is on the table")
+	say(analyst, 9, "Dana", "The cancellation fee is steep") // Not a whole-word match
+
+	select {
+	case message := <-messages:
+		embed := message.Embeds[0]
+		if embed.Title != "Churn risk" || embed.Color != keywordAlertColor {
+			t.Errorf("embed = %+v", embed)
+		}
+		if embed.Fields[0].Value != "Dana" || embed.Fields[2].Value != "We might cancel the contract" {
+			t.Errorf("fields = %+v, want the speaker and utterance", embed.Fields)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("alert not posted")
+	}
+	select {
+	case message := <-messages:
+		t.Errorf("second alert %+v posted within the cooldown", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestKeywordAlertFiresAgainAfterCooldown(t *testing.T) {
+	server, messages := alertServer(t)
+	analyst := newTestAnalyst(t)
+	analyst.config.KeywordAlerts = []models.KeywordAlert{{Pattern: "budget", WebhookURL: server.URL, Cooldown: time.Hour}}
+
+	say(analyst, 0, "Dana", "The budget is fixed")
+	<-messages
+	analyst.keywordAlertMutex.Lock()
+	analyst.keywordAlerts.lastFired["budget"] = time.Now().Add(-2 * time.Hour)
+	analyst.keywordAlertMutex.Unlock()
+	say(analyst, 5, "Dana", "About that budget")
+
+	select {
+	case message := <-messages:
+		if message.Embeds[0].Title != "Keyword alert: budget" {
+			t.Errorf("title = %q, want the default", message.Embeds[0].Title)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("alert not posted after the cooldown")
+	}
+}
+
+func TestKeywordAlertIgnoresInvalidPattern(t *testing.T) {
+	server, messages := alertServer(t)
+	analyst := newTestAnalyst(t)
+	analyst.config.KeywordAlerts = []models.KeywordAlert{
+		{Pattern: "(unclosed", WebhookURL: server.URL},
+		{Pattern: "renewal", WebhookURL: server.URL},
+	}
+
+	say(analyst, 0, "Dana", "(unclosed renewal")
+	select {
+	case message := <-messages:
+		if message.Embeds[0].Title != "Keyword alert: renewal" {
+			t.Errorf("title = %q, want only the valid pattern to fire", message.Embeds[0].Title)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("valid alert not posted")
+	}
+	if pattern, compiled := analyst.keywordAlerts.patterns["(unclosed"]; !compiled || pattern != nil {
+		t.Error("invalid pattern not recorded as failing to compile")
+	}
+}
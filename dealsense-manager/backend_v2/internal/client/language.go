@@ -0,0 +1,201 @@
+package client
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// languageSampleEntries is the number of entries at the start of the transcript inspected for language
+	// detection
+	languageSampleEntries = 20
+	// minDetectionWords is the number of words of Latin-script text needed to detect its language. Short
+	// replies such as "Ok, sounds good." share too many trigrams across languages to tell them apart.
+	minDetectionWords = 20
+	// minLanguageScore is the share of the text's trigrams the detected language's profile must match
+	minLanguageScore = 0.1
+	// minLanguageMargin is how many times the runner-up's score the detected language must score
+	minLanguageMargin = 1.3
+)
+
+// languageNames maps ISO 639-1 codes to the language names used in prompts
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"pt": "Portuguese",
+	"it": "Italian",
+	"nl": "Dutch",
+	"ja": "Japanese",
+	"zh": "Chinese",
+	"ko": "Korean",
+	"ru": "Russian",
+	"ar": "Arabic",
+	"hi": "Hindi",
+}
+
+// trigramProfiles holds the most characteristic character trigrams of each Latin-script language.
+// Words are padded with spaces, so " de" matches the start of a word and "ón " the end of one.
+var trigramProfiles = map[string][]string{
+	"en": {" th", "the", "he ", "ing", "ng ", " an", "and", "nd ", " to", "to ", " of", "of ", "ed ", " is", "is ", "at ", " wh", "hat", "tha", "ion", " we", " yo", "you", "ou ", "ll "},
+	"es": {" de", "de ", " la", "la ", " qu", "que", "ue ", " el", "el ", "os ", "as ", " en", "en ", " es", "ció", "ión", "ón ", " lo", "los", " co", "par", "ara", "est", " y ", "ien"},
+	"fr": {" de", "es ", " le", "le ", "de ", " la", "ent", "nt ", " et", "et ", "les", " qu", "que", "ue ", " pa", "ous", " no", "ait", "tio", "ion", " un", "eur", " ce", "est", "ons"},
+	"de": {" de", "der", "er ", "en ", "ie ", " di", "die", "ich", "ch ", " un", "und", "nd ", "ein", " ei", "sch", "den", " da", "das", "ist", " is", "cht", "gen", "ung", " zu", "nic"},
+	"pt": {" de", "de ", " qu", "que", "ue ", "os ", " a ", " o ", "as ", "ão ", "ção", " co", "com", " na", " do", "do ", "da ", " da", "ent", "nte", " pa", "par", "est", "um ", " um"},
+	"it": {" di", "di ", " ch", "che", "he ", " il", "il ", "la ", " la", "to ", " co", "on ", "re ", "ell", "lla", "zio", "ion", "one", " pe", "per", "er ", " no", "non", "ent", "tto"},
+	"nl": {" de", "de ", "en ", "van", " va", "an ", "het", " he", "et ", "een", " ee", "ij ", "ijk", "oor", " vo", "voo", "ver", "aar", " da", "dat", "cht", "ng ", " we", "wij", " zi"},
+}
+
+// LanguageDetector guesses the language of a meeting transcript without calling an external API
+type LanguageDetector struct {
+	profiles map[string]map[string]bool
+}
+
+// NewLanguageDetector creates a detector using the built-in trigram profiles
+func NewLanguageDetector() *LanguageDetector {
+	profiles := make(map[string]map[string]bool, len(trigramProfiles))
+	for lang, trigrams := range trigramProfiles {
+		profile := make(map[string]bool, len(trigrams))
+		for _, trigram := range trigrams {
+			profile[trigram] = true
+		}
+		profiles[lang] = profile
+	}
+	return &LanguageDetector{profiles: profiles}
+}
+
+// Detect returns the ISO 639-1 code of the language used in the first languageSampleEntries entries of
+// the transcript, or an empty string if there is not enough text to tell
+func (d *LanguageDetector) Detect(entries []TranscriptEntry) string {
+	var sample strings.Builder
+	for _, entry := range entries[:min(languageSampleEntries, len(entries))] {
+		sample.WriteString(entry.Text)
+		sample.WriteString(" ")
+	}
+
+	return d.DetectText(sample.String())
+}
+
+// DetectText returns the ISO 639-1 code of the language the text is written in, or an empty string when
+// unsure: Latin-script text needs at least minDetectionWords words, and the best matching language must
+// match minLanguageScore of its trigrams and beat the runner-up by minLanguageMargin
+func (d *LanguageDetector) DetectText(text string) string {
+	// Non-Latin scripts are identified by their characters alone
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+
+	if len(strings.Fields(text)) < minDetectionWords {
+		return ""
+	}
+	trigrams := extractTrigrams(text)
+	total := 0
+	for _, count := range trigrams {
+		total += count
+	}
+	if total == 0 {
+		return ""
+	}
+
+	bestLang, bestScore, runnerUpScore := "", 0, 0
+	for lang, profile := range d.profiles {
+		score := 0
+		for trigram, count := range trigrams {
+			if profile[trigram] {
+				score += count
+			}
+		}
+		// Ties go to English, then alphabetically, so results are stable across map iteration order
+		if score > bestScore || (score == bestScore && score > 0 && preferLanguage(lang, bestLang)) {
+			bestLang, bestScore, runnerUpScore = lang, score, bestScore
+		} else if score > runnerUpScore {
+			runnerUpScore = score
+		}
+	}
+
+	if float64(bestScore) < minLanguageScore*float64(total) || float64(bestScore) < minLanguageMargin*float64(runnerUpScore) {
+		return ""
+	}
+	return bestLang
+}
+
+// preferLanguage breaks scoring ties deterministically
+func preferLanguage(candidate, current string) bool {
+	if current == "en" {
+		return false
+	}
+	if candidate == "en" {
+		return true
+	}
+	return candidate < current
+}
+
+// detectScript identifies languages written in non-Latin scripts from the share of their characters
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese mixes kana with Han characters, so any meaningful amount of kana means Japanese
+	if counts["ja"] > 0 && counts["ja"]*10 >= letters {
+		return "ja"
+	}
+	if counts["han"]*2 >= letters {
+		if counts["ja"] > 0 {
+			return "ja"
+		}
+		return "zh"
+	}
+	for _, lang := range []string{"ko", "ru", "ar", "hi"} {
+		if counts[lang]*2 >= letters {
+			return lang
+		}
+	}
+	return ""
+}
+
+// extractTrigrams counts the space-padded character trigrams of each word in the text
+func extractTrigrams(text string) map[string]int {
+	trigrams := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			trigrams[string(runes[i:i+3])]++
+		}
+	}
+	return trigrams
+}
+
+// languageName returns the display name for a language code, or the value itself if unknown
+func languageName(code string) string {
+	if name, ok := languageNames[strings.ToLower(code)]; ok {
+		return name
+	}
+	return code
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectTextLanguages(t *testing.T) {
+	detector := NewLanguageDetector()
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Hi everyone, thanks for joining. Today we want to go over the launch plan and what is still missing. I talked with marketing yesterday and they need the final copy by Friday. Can you send it over when it's ready?", "en"},
+		{"french", "Bonjour à tous, merci d'être là. Aujourd'hui nous allons parler du plan de lancement et de ce qui manque encore. J'ai parlé avec le marketing hier et ils ont besoin du texte final pour vendredi. Est-ce que tu peux l'envoyer quand il est prêt?", "fr"},
+		{"spanish", "Hola a todos, gracias por venir. Hoy queremos revisar el plan de lanzamiento y lo que todavía falta. Ayer hablé con marketing y necesitan el texto final para el viernes. ¿Puedes enviarlo cuando esté listo? Creo que la presentación está casi terminada.", "es"},
+		{"german", "Hallo zusammen, danke dass ihr da seid. Heute wollen wir den Plan für den Start besprechen und was noch fehlt. Ich habe gestern mit dem Marketing gesprochen und sie brauchen den finalen Text bis Freitag. Kannst du ihn schicken, wenn er fertig ist?", "de"},
+		{"japanese", "皆さん、今日はお集まりいただきありがとうございます。", "ja"},
+		{"short english reply", "Ok, sounds good.", ""},
+		{"short french reply", "Très bien, alors on fait ça demain.", ""},
+		{"terse english", strings.Repeat("Okay so. Budget review first. Sure. Numbers look fine. Good point. Next item. ", 2), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detector.DetectText(tt.text); got != tt.want {
+				t.Errorf("DetectText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectNeedsMoreThanOpeningUtterances(t *testing.T) {
+	detector := NewLanguageDetector()
+	opening := []TranscriptEntry{{Text: "Okay so"}, {Text: "Budget review first"}, {Text: "Sure"}, {Text: "Numbers look fine"}}
+	if got := detector.Detect(opening); got != "" {
+		t.Fatalf("Detect() of the opening utterances = %q, want no language yet", got)
+	}
+
+	transcript := append(opening,
+		TranscriptEntry{Text: "Good point, let's move to the next item on the agenda which is the hiring plan for the team."},
+		TranscriptEntry{Text: "I think we should open two more roles this quarter and see how the onboarding goes with the new people."},
+	)
+	if got := detector.Detect(transcript); got != "en" {
+		t.Errorf("Detect() = %q, want en once there is enough text", got)
+	}
+}
+
+func TestDetectSamplesFirstEntries(t *testing.T) {
+	detector := NewLanguageDetector()
+	var transcript []TranscriptEntry
+	for i := 0; i < languageSampleEntries; i++ {
+		transcript = append(transcript, TranscriptEntry{Text: "Hola a todos, hoy queremos revisar el plan de lanzamiento y lo que todavía falta."})
+	}
+	for i := 0; i < 3*languageSampleEntries; i++ {
+		transcript = append(transcript, TranscriptEntry{Text: "Thanks everyone, now let's go over the launch plan and what is still missing."})
+	}
+
+	if got := detector.Detect(transcript); got != "es" {
+		t.Errorf("Detect() = %q, want es from the first %d entries only", got, languageSampleEntries)
+	}
+}
+
+func TestLanguagePrefixWithoutConfidentDetection(t *testing.T) {
+	agent := &AnalystAgent{data: &AnalysisData{}, languageDetector: NewLanguageDetector()}
+	agent.updateLanguage([]TranscriptEntry{{Text: "Ok, sounds good."}, {Text: "Très bien."}})
+
+	if prefix := agent.languagePrefix(); prefix != "" {
+		t.Errorf("languagePrefix() = %q, want none when the language is unsure", prefix)
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SpeakerLanguageMetrics describes the vocabulary richness and complexity of a participant's speech
+type SpeakerLanguageMetrics struct {
+	VocabularySize        int     `json:"vocabulary_size"`         // Unique words
+	TypeTokenRatio        float64 `json:"type_token_ratio"`        // Vocabulary size / total words
+	AverageSentenceLength float64 `json:"average_sentence_length"` // Words per sentence
+	FleschKincaidGrade    float64 `json:"flesch_kincaid_grade"`    // US school grade needed to follow the speech
+	FillerWordRate        float64 `json:"filler_word_rate"`        // Fillers per minute of estimated speaking time
+}
+
+// textStatistics counts the words, sentences and syllables of a text, and the polysyllabic words with
+// three syllables or more. A text with words but no sentence ending punctuation is one sentence, as
+// transcription often leaves it out.
+type textStatistics struct {
+	words, sentences, syllables, polysyllables int
+}
+
+// add accumulates the statistics of another text
+func (s *textStatistics) add(other textStatistics) {
+	s.words += other.words
+	s.sentences += other.sentences
+	s.syllables += other.syllables
+	s.polysyllables += other.polysyllables
+}
+
+// fleschKincaidGrade applies the Flesch-Kincaid grade level formula, returning 0 for a text without words
+func (s textStatistics) fleschKincaidGrade() float64 {
+	if s.words == 0 || s.sentences == 0 {
+		return 0
+	}
+	return 0.39*float64(s.words)/float64(s.sentences) + 11.8*float64(s.syllables)/float64(s.words) - 15.59
+}
+
+// ComputeFleschKincaid returns the Flesch-Kincaid grade level of an English text, the US school grade
+// needed to understand it, or 0 for a text without words. Syllables are estimated from vowel groups.
+func ComputeFleschKincaid(text string) float64 {
+	return measureText(text).fleschKincaidGrade()
+}
+
+// measureText counts the words, sentences and syllables of a text
+func measureText(text string) textStatistics {
+	var stats textStatistics
+	for _, word := range languageWords(text) {
+		syllables := countSyllables(word)
+		stats.words++
+		stats.syllables += syllables
+		if syllables >= 3 {
+			stats.polysyllables++
+		}
+	}
+	if stats.words == 0 {
+		return stats
+	}
+
+	// A sentence ends at punctuation following some words, so "?!" and "..." end one sentence and words
+	// after the last punctuation form a sentence of their own
+	inSentence := false
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(sentenceEndRunes, r):
+			if inSentence {
+				stats.sentences++
+			}
+			inSentence = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			inSentence = true
+		}
+	}
+	if inSentence {
+		stats.sentences++
+	}
+	return stats
+}
+
+// languageWords splits text into lowercased words, keeping apostrophes within contractions
+func languageWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if word := strings.Trim(field, "'’"); strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// countSyllables estimates the syllables of a lowercased English word as its vowel groups, not counting
+// a silent final "e", and at least one
+func countSyllables(word string) int {
+	syllables := 0
+	previousVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !previousVowel {
+			syllables++
+		}
+		previousVowel = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && syllables > 1 {
+		syllables--
+	}
+	return max(syllables, 1)
+}
+
+// countFillers counts the hesitations and discourse fillers in a text
+func countFillers(text string) int {
+	return len(hesitations.FindAllStringIndex(text, -1)) + len(discourseFillers.FindAllStringIndex(text, -1))
+}
+
+// computeLanguageMetrics computes the language metrics of each participant from their statements in the
+// transcript, leaving out agent statements. Speaking time for the filler rate is estimated from the word
+// count, as transcript entries only record when speech started.
+func computeLanguageMetrics(transcript []TranscriptEntry) map[string]SpeakerLanguageMetrics {
+	type speakerTotals struct {
+		stats      textStatistics
+		vocabulary map[string]bool
+		fillers    int
+	}
+
+	totals := make(map[string]*speakerTotals)
+	for _, entry := range transcript {
+		if entry.IsAgent {
+			continue
+		}
+		speaker := totals[entry.Speaker]
+		if speaker == nil {
+			speaker = &speakerTotals{vocabulary: make(map[string]bool)}
+			totals[entry.Speaker] = speaker
+		}
+
+		speaker.stats.add(measureText(entry.Text))
+		for _, word := range languageWords(entry.Text) {
+			speaker.vocabulary[word] = true
+		}
+		speaker.fillers += countFillers(entry.Text)
+	}
+
+	metrics := make(map[string]SpeakerLanguageMetrics, len(totals))
+	for name, speaker := range totals {
+		if speaker.stats.words == 0 {
+			continue
+		}
+		speakingMinutes := float64(speaker.stats.words) / wordsPerSecond / 60
+		metrics[name] = SpeakerLanguageMetrics{
+			VocabularySize:        len(speaker.vocabulary),
+			TypeTokenRatio:        float64(len(speaker.vocabulary)) / float64(speaker.stats.words),
+			AverageSentenceLength: float64(speaker.stats.words) / float64(speaker.stats.sentences),
+			FleschKincaidGrade:    speaker.stats.fleschKincaidGrade(),
+			FillerWordRate:        float64(speaker.fillers) / speakingMinutes,
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,51 @@
+package client
+
+import "testing"
+
+func TestComputeLanguageMetrics(t *testing.T) {
+	metrics := computeLanguageMetrics([]TranscriptEntry{
+		{Speaker: "Alice", Text: "The cat saw the dog."},
+		{Speaker: "Alice", Text: "The dog ran"},
+		{Speaker: "Bob", Text: "Um, we ship it"},
+		{Speaker: "Agent", Text: "Noted", IsAgent: true},
+	})
+
+	alice := metrics["Alice"]
+	// 8 words, 5 of them distinct, in 2 sentences of one-syllable words
+	if alice.VocabularySize != 5 || !approx(alice.TypeTokenRatio, 0.625) || !approx(alice.AverageSentenceLength, 4) {
+		t.Errorf("Alice = %+v, want 5 words of 8 in sentences of 4", alice)
+	}
+	if !approx(alice.FleschKincaidGrade, 0.39*4+11.8-15.59) || alice.FillerWordRate != 0 {
+		t.Errorf("Alice = %+v", alice)
+	}
+
+	// One filler in 4 words, which take 1.6 seconds to say
+	if bob := metrics["Bob"]; !approx(bob.FillerWordRate, 37.5) || !approx(bob.TypeTokenRatio, 1) {
+		t.Errorf("Bob = %+v, want 37.5 fillers a minute", bob)
+	}
+	if _, ok := metrics["Agent"]; ok {
+		t.Error("agent's statements measured")
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{"cat": 1, "make": 1, "table": 2, "meeting": 2, "quarterly": 3, "the": 1, "rhythm": 1}
+	for word, want := range tests {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestLanguageWords(t *testing.T) {
+	words := languageWords("We'll ship Q3's 42 builds — 'today'!")
+	want := []string{"we'll", "ship", "q3's", "builds", "today"}
+	if len(words) != len(want) {
+		t.Fatalf("languageWords() = %q, want %q", words, want)
+	}
+	for i := range want {
+		if words[i] != want[i] {
+			t.Errorf("languageWords() = %q, want %q", words, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	// learningTranscript is the number of recent transcript entries book recommendations are based on
+	learningTranscript = 50
+	// maxBookRecommendations caps how many books are recommended
+	maxBookRecommendations = 5
+)
+
+// learningThemes mark a meeting as being about learning or growth when they appear in its sentiment,
+// keywords or summary
+var learningThemes = []string{"learn", "training", "improve", "skill"}
+
+// BookRecommendation is a book suggested to close a learning gap discussed in the meeting
+type BookRecommendation struct {
+	Title           string `json:"title"`
+	Author          string `json:"author"`
+	Relevance       string `json:"relevance"`       // Why the book helps with what was discussed
+	MentionContext  string `json:"mention_context"` // The part of the discussion the recommendation responds to
+	AmazonSearchURL string `json:"amazon_search_url"`
+}
+
+// identifyLearningResources recommends books for the learning gaps discussed in the meeting. It only
+// runs once the sentiment, keywords or summary show the meeting is about learning or growth.
+func (a *AnalystAgent) identifyLearningResources(ctx context.Context) error {
+	if !a.hasLearningTheme() {
+		return nil
+	}
+
+	transcript := a.getRecentTranscript(learningTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Identifying learning resources with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("learning_resources",
+		`This meeting discussed learning, training or skill gaps. Recommend up to 5 books that would help the participants with the specific gaps they talked about.
+
+Rules:
+- Only recommend books that exist; use google_search, when available, to confirm the title and author
+- Tie each book to something that was actually said, not to the meeting's general subject
+- Prefer widely respected, practical books over niche or academic ones
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "book_recommendations": [
+    {
+      "title": "Book title",
+      "author": "Author name",
+      "relevance": "How the book helps with the gap discussed",
+      "mention_context": "What was said that this recommendation responds to"
+    }
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	var response string
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		groundedResponse, err := a.callLLMWithGrounding(ctx, groundingProvider, prompt)
+		if err != nil {
+			logrus.Warnf("Grounded call failed for learning resources, falling back to regular call: %v", err)
+		} else if groundedResponse != nil {
+			response = groundedResponse.Text
+		}
+	}
+	if response == "" {
+		var err error
+		if response, err = a.callLLM(ctx, prompt); err != nil {
+			logrus.Warnf("Failed to identify learning resources: %v", err)
+			return err
+		}
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		BookRecommendations []BookRecommendation `json:"book_recommendations"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse learning resources JSON: %w", err)
+	}
+
+	books := []BookRecommendation{}
+	for _, book := range result.BookRecommendations {
+		book.Title = strings.TrimSpace(book.Title)
+		book.Author = strings.TrimSpace(book.Author)
+		if book.Title == "" {
+			continue
+		}
+		book.AmazonSearchURL = amazonSearchURL(book.Title, book.Author)
+		books = append(books, book)
+		if len(books) == maxBookRecommendations {
+			break
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.BookRecommendations = books
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Recommended %d books", a.agentID, len(books))
+	return nil
+}
+
+// hasLearningTheme reports whether the meeting's sentiment, keywords or summary mention learning or growth
+func (a *AnalystAgent) hasLearningTheme() bool {
+	a.dataMutex.RLock()
+	text := strings.ToLower(strings.Join(append([]string{a.data.Sentiment, a.data.Summary}, a.data.Keywords...), " "))
+	a.dataMutex.RUnlock()
+
+	for _, theme := range learningThemes {
+		if strings.Contains(text, theme) {
+			return true
+		}
+	}
+	return false
+}
+
+// amazonSearchURL builds an Amazon search for a book by title and author
+func amazonSearchURL(title, author string) string {
+	query := strings.TrimSpace(title + " " + author)
+	return "https://www.amazon.com/s?" + url.Values{"k": {query}, "i": {"stripbooks"}}.Encode()
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestAmazonSearchURL(t *testing.T) {
+	cases := []struct{ title, author, want string }{
+		{"Clean Code", "Robert C. Martin", "https://www.amazon.com/s?i=stripbooks&k=Clean+Code+Robert+C.+Martin"},
+		{"Crucial Conversations: Tools & Tips", "", "https://www.amazon.com/s?i=stripbooks&k=Crucial+Conversations%3A+Tools+%26+Tips"},
+		{"Thinking, Fast and Slow", "Daniel Kahneman", "https://www.amazon.com/s?i=stripbooks&k=Thinking%2C+Fast+and+Slow+Daniel+Kahneman"},
+	}
+	for _, c := range cases {
+		if got := amazonSearchURL(c.title, c.author); got != c.want {
+			t.Errorf("amazonSearchURL(%q, %q) = %q, want %q", c.title, c.author, got, c.want)
+		}
+	}
+}
+
+func TestIdentifyLearningResources(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"book_recommendations": [
+		{"title": " Radical Candor ", "author": "Kim Scott", "relevance": "Giving feedback"},
+		{"title": "", "author": "Nobody"}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "I want to get better at giving feedback")
+	analyst.data.Keywords = []string{"feedback", "Skill development"}
+
+	if err := analyst.identifyLearningResources(context.Background()); err != nil {
+		t.Fatalf("identifyLearningResources() error = %v", err)
+	}
+	books := analyst.GetAnalysis().BookRecommendations
+	if len(books) != 1 || books[0].Title != "Radical Candor" ||
+		books[0].AmazonSearchURL != "https://www.amazon.com/s?i=stripbooks&k=Radical+Candor+Kim+Scott" {
+		t.Errorf("BookRecommendations = %+v", books)
+	}
+}
+
+func TestIdentifyLearningResourcesNeedsLearningTheme(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider()
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "Let's review the quarterly numbers")
+	analyst.data.Summary = "The team reviewed revenue."
+
+	if err := analyst.identifyLearningResources(context.Background()); err != nil {
+		t.Fatalf("identifyLearningResources() error = %v", err)
+	}
+	if len(mock.Prompts()) != 0 {
+		t.Error("LLM called for a meeting without learning themes")
+	}
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// linkTitleTimeout bounds fetching the title of a shared link
+	linkTitleTimeout = 3 * time.Second
+	// maxLinkTitleBytes is how much of a page is searched for its title
+	maxLinkTitleBytes = 64 * 1024
+)
+
+// linkPattern matches URLs with a scheme or www prefix, and bare domains of common top-level domains as
+// spoken in meetings, such as "example.com/report"
+var linkPattern = regexp.MustCompile(`(?i)\b(?:(?:https?://|www\.)[^\s<>"'\x60]+|[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|org|net|io|dev|ai|co|app|gov|edu)\b(?:/[^\s<>"'\x60]*)?)`)
+
+// linkTitlePattern matches the title element of an HTML page
+var linkTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// linkTitleHTTPClient fetches the titles of shared links
+var linkTitleHTTPClient = &http.Client{Timeout: linkTitleTimeout}
+
+// linkTitleCache holds the titles of links already fetched, shared by all agents, keyed by URL. Links whose
+// title couldn't be fetched are cached as "" so they aren't fetched again.
+var linkTitleCache = struct {
+	sync.Mutex
+	titles map[string]string
+}{titles: make(map[string]string)}
+
+// LinkMention is a URL a participant shared in the meeting
+type LinkMention struct {
+	URL         string    `json:"url"`
+	MentionedBy string    `json:"mentioned_by"`
+	Timestamp   time.Time `json:"timestamp"`
+	Context     string    `json:"context"`         // Statement the link was shared in
+	Title       string    `json:"title,omitempty"` // Title of the linked page, once fetched
+}
+
+// recordLinkMentions adds the links shared in the entry that weren't shared before, taking their titles
+// from the cache or fetching them in the background. Caller must hold dataMutex.
+func (a *AnalystAgent) recordLinkMentions(entry TranscriptEntry) {
+	for _, link := range extractLinks(entry.Text) {
+		if a.findLinkMention(link) != nil {
+			continue
+		}
+
+		title, cached := cachedLinkTitle(link)
+		a.data.ImportantLinks = append(a.data.ImportantLinks, LinkMention{
+			URL:         link,
+			MentionedBy: entry.Speaker,
+			Timestamp:   entry.Timestamp,
+			Context:     entry.Text,
+			Title:       title,
+		})
+		if !cached {
+			go a.fetchLinkTitle(link)
+		}
+	}
+}
+
+// findLinkMention returns the mention of a link, or nil when it hasn't been shared (caller must hold
+// dataMutex)
+func (a *AnalystAgent) findLinkMention(link string) *LinkMention {
+	for i := range a.data.ImportantLinks {
+		if a.data.ImportantLinks[i].URL == link {
+			return &a.data.ImportantLinks[i]
+		}
+	}
+	return nil
+}
+
+// fetchLinkTitle fetches and caches the title of a link, then sets it on the link's mention
+func (a *AnalystAgent) fetchLinkTitle(link string) {
+	ctx, cancel := context.WithTimeout(context.Background(), linkTitleTimeout)
+	defer cancel()
+
+	title, err := fetchPageTitle(ctx, linkTitleHTTPClient, link)
+	if err != nil {
+		logrus.Debugf("Agent %s: Failed to fetch title of %s: %v", a.agentID, link, err)
+	}
+
+	linkTitleCache.Lock()
+	linkTitleCache.titles[link] = title
+	linkTitleCache.Unlock()
+
+	if title == "" {
+		return
+	}
+	a.dataMutex.Lock()
+	if mention := a.findLinkMention(link); mention != nil {
+		mention.Title = title
+	}
+	a.dataMutex.Unlock()
+}
+
+// cachedLinkTitle returns the cached title of a link and whether it was fetched before
+func cachedLinkTitle(link string) (string, bool) {
+	linkTitleCache.Lock()
+	defer linkTitleCache.Unlock()
+
+	title, ok := linkTitleCache.titles[link]
+	return title, ok
+}
+
+// extractLinks returns the distinct URLs in text, in order, with https:// added to those without a scheme.
+// Trailing punctuation is dropped, and the domains of email addresses aren't links.
+func extractLinks(text string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, match := range linkPattern.FindAllStringIndex(text, -1) {
+		if match[0] > 0 && text[match[0]-1] == '@' {
+			continue
+		}
+		link := strings.TrimRight(text[match[0]:match[1]], ".,;:!?)]}")
+		if !strings.HasPrefix(strings.ToLower(link), "http://") && !strings.HasPrefix(strings.ToLower(link), "https://") {
+			link = "https://" + link
+		}
+		if parsed, err := url.Parse(link); err != nil || parsed.Host == "" {
+			continue
+		}
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// fetchPageTitle checks with a HEAD request that the link is an HTML page, then reads its title. Links to
+// local and private network hosts aren't fetched.
+func fetchPageTitle(ctx context.Context, httpClient *http.Client, link string) (string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	if isPrivateHost(parsed.Hostname()) {
+		return "", nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	// Servers that don't support HEAD are still asked for the page
+	if contentType := resp.Header.Get("Content-Type"); resp.StatusCode < 400 && contentType != "" && !strings.Contains(contentType, "html") {
+		return "", nil
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err = httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", nil
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxLinkTitleBytes))
+	if err != nil {
+		return "", err
+	}
+	match := linkTitlePattern.FindSubmatch(page)
+	if match == nil {
+		return "", nil
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " "), nil
+}
+
+// isPrivateHost reports whether host is localhost or an IP address of a loopback, private or link-local
+// network
+func isPrivateHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified())
+}
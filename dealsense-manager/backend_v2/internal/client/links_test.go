@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	text := "See https://example.com/report?id=1, www.acme.io and docs.stripe.dev/api. Mail jane@acme.com or go to example.com/report?id=1"
+	// The email domain isn't a link, and the bare repeat of the first link is a duplicate once given a scheme
+	want := []string{"https://example.com/report?id=1", "https://www.acme.io", "https://docs.stripe.dev/api"}
+	if got := extractLinks(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("extractLinks() = %v, want %v", got, want)
+	}
+	if got := extractLinks("We should hit our numbers. Okay?"); got != nil {
+		t.Errorf("extractLinks() = %v, want none", got)
+	}
+}
+
+// redirectingClient returns an HTTP client sending every request to server, whatever its host
+func redirectingClient(server *httptest.Server) *http.Client {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+	return &http.Client{Transport: transport}
+}
+
+func TestFetchPageTitle(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html><head><title>\n  Q3 Report &amp; Plan\n</title></head></html>"))
+		case "/file.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := redirectingClient(server)
+
+	title, err := fetchPageTitle(context.Background(), client, "http://example.com/page")
+	if err != nil || title != "Q3 Report & Plan" {
+		t.Errorf("fetchPageTitle() = %q, %v, want the unescaped title", title, err)
+	}
+
+	methods = nil
+	if title, err := fetchPageTitle(context.Background(), client, "http://example.com/file.pdf"); err != nil || title != "" {
+		t.Errorf("fetchPageTitle() = %q, %v, want no title for a PDF", title, err)
+	}
+	if !reflect.DeepEqual(methods, []string{"HEAD /file.pdf"}) {
+		t.Errorf("requests = %v, want only the HEAD request", methods)
+	}
+
+	if title, err := fetchPageTitle(context.Background(), client, "http://example.com/missing"); err != nil || title != "" {
+		t.Errorf("fetchPageTitle() = %q, %v, want no title for a missing page", title, err)
+	}
+}
+
+func TestFetchPageTitleSkipsPrivateHosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}))
+	defer server.Close()
+
+	for _, link := range []string{server.URL, "http://localhost:8080/admin", "http://10.0.0.5/", "http://169.254.169.254/latest"} {
+		if title, err := fetchPageTitle(context.Background(), server.Client(), link); err != nil || title != "" {
+			t.Errorf("fetchPageTitle(%s) = %q, %v", link, title, err)
+		}
+	}
+}
+
+func TestRecordLinkMentions(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	linkTitleCache.Lock()
+	linkTitleCache.titles["https://acme.io/pricing"] = "Acme Pricing"
+	linkTitleCache.Unlock()
+
+	say(analyst, 0, "Alice", "The prices are on acme.io/pricing now")
+	say(analyst, 30, "Bob", "Thanks, I saw acme.io/pricing already")
+
+	links := analyst.GetAnalysis().ImportantLinks
+	if len(links) != 1 {
+		t.Fatalf("links = %+v, want one", links)
+	}
+	if links[0].MentionedBy != "Alice" || links[0].Title != "Acme Pricing" || links[0].Context != "The prices are on acme.io/pricing now" {
+		t.Errorf("link = %+v", links[0])
+	}
+}
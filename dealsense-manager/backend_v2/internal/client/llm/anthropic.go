@@ -0,0 +1,290 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	anthropicMessagesURL = "https://api.anthropic.com/v1/messages"
+	anthropicVersion     = "2023-06-01"
+	anthropicWebSearch   = "web_search_20250305"
+)
+
+// AnthropicProvider implements the LLMProvider interface for Anthropic
+type AnthropicProvider struct {
+	model           string
+	apiCalls        int64  // Counter for API calls
+	apiURL          string // Messages API endpoint
+	webSearch       bool   // Whether the web search tool is sent with grounded calls
+	maxSearchUses   int
+	maxOutputTokens int
+}
+
+// anthropicContentBlock is a content block in an Anthropic Messages API response
+type anthropicContentBlock struct {
+	Type      string              `json:"type"`
+	Text      string              `json:"text,omitempty"`
+	Citations []anthropicCitation `json:"citations,omitempty"`
+	Name      string              `json:"name,omitempty"`
+	Input     struct {
+		Query string `json:"query"`
+	} `json:"input,omitempty"`
+	Content json.RawMessage `json:"content,omitempty"`
+}
+
+// anthropicCitation is a citation attached to a text block
+type anthropicCitation struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Title     string `json:"title"`
+	CitedText string `json:"cited_text"`
+}
+
+// anthropicSearchResult is a single result inside a web_search_tool_result block
+type anthropicSearchResult struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// anthropicResponse is the subset of the Messages API response used by the provider
+type anthropicResponse struct {
+	Content []anthropicContentBlock `json:"content"`
+	Usage   struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// NewAnthropicProvider creates a new Anthropic provider
+func NewAnthropicProvider(model string) *AnthropicProvider {
+	return &AnthropicProvider{
+		model:           model,
+		apiURL:          anthropicMessagesURL,
+		maxSearchUses:   5,
+		maxOutputTokens: 2000,
+	}
+}
+
+// NewAnthropicGroundedProvider creates an Anthropic provider with the web search tool enabled for grounded calls
+func NewAnthropicGroundedProvider(model string) *AnthropicProvider {
+	p := NewAnthropicProvider(model)
+	p.webSearch = true
+	return p
+}
+
+// GetAPICallCount returns the number of API calls made
+func (p *AnthropicProvider) GetAPICallCount() int64 {
+	return atomic.LoadInt64(&p.apiCalls)
+}
+
+// Call makes a request to the Anthropic Messages API
+func (p *AnthropicProvider) Call(prompt string) (string, error) {
+	resp, err := p.sendMessage(prompt, false)
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String(), nil
+}
+
+// CallWithGrounding makes a request with the web search tool enabled and maps the cited
+// search results into the same GroundedResponse shape returned by GoogleProvider
+func (p *AnthropicProvider) CallWithGrounding(prompt string) (*GroundedResponse, error) {
+	if !p.webSearch {
+		text, err := p.Call(prompt)
+		if err != nil {
+			return nil, err
+		}
+		return &GroundedResponse{Text: text}, nil
+	}
+
+	resp, err := p.sendMessage(prompt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAnthropicGroundedResponse(resp), nil
+}
+
+// IsAvailable checks if Anthropic API credentials are available
+func (p *AnthropicProvider) IsAvailable() bool {
+	return os.Getenv("ANTHROPIC_API_KEY") != ""
+}
+
+// sendMessage posts the prompt to the Messages API, optionally with the web search tool
+func (p *AnthropicProvider) sendMessage(prompt string, withSearch bool) (*anthropicResponse, error) {
+	promptID := generatePromptID()
+	callNumber := atomic.AddInt64(&p.apiCalls, 1)
+
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY not found")
+	}
+
+	payload := map[string]interface{}{
+		"model":      p.model,
+		"max_tokens": p.maxOutputTokens,
+		"messages": []map[string]interface{}{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if withSearch {
+		payload["tools"] = []map[string]interface{}{
+			{
+				"type":     anthropicWebSearch,
+				"name":     "web_search",
+				"max_uses": p.maxSearchUses,
+			},
+		}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", p.apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	startTime := time.Now()
+	client := &http.Client{Timeout: 120 * time.Second}
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		logrus.WithFields(logrus.Fields{
+			"prompt_id":   promptID,
+			"model":       p.model,
+			"call_number": callNumber,
+			"status_code": httpResp.StatusCode,
+			"error_body":  truncateString(string(body), 1000),
+		}).Error("❌ Anthropic API Error")
+		return nil, fmt.Errorf("API request failed with status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var resp anthropicResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"prompt_id":     promptID,
+		"model":         p.model,
+		"call_number":   callNumber,
+		"grounding":     withSearch,
+		"input_tokens":  resp.Usage.InputTokens,
+		"output_tokens": resp.Usage.OutputTokens,
+		"duration_ms":   time.Since(startTime).Milliseconds(),
+	}).Info("✅ Anthropic API Response")
+
+	return &resp, nil
+}
+
+// parseAnthropicGroundedResponse converts search tool results and text citations into grounding metadata.
+// Each cited text block becomes a GroundingSupport spanning that block's offsets in the combined text.
+func parseAnthropicGroundedResponse(resp *anthropicResponse) *GroundedResponse {
+	metadata := &GroundingMetadata{
+		WebSearchQueries:  []string{},
+		GroundingChunks:   []GroundingChunk{},
+		GroundingSupports: []GroundingSupport{},
+	}
+	chunkIndex := make(map[string]int)
+
+	addChunk := func(url, title string) int {
+		if index, ok := chunkIndex[url]; ok {
+			return index
+		}
+		var chunk GroundingChunk
+		chunk.Web.URI = url
+		chunk.Web.Title = title
+		metadata.GroundingChunks = append(metadata.GroundingChunks, chunk)
+		chunkIndex[url] = len(metadata.GroundingChunks) - 1
+		return chunkIndex[url]
+	}
+
+	var text strings.Builder
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "server_tool_use":
+			if block.Name == "web_search" && block.Input.Query != "" {
+				metadata.WebSearchQueries = append(metadata.WebSearchQueries, block.Input.Query)
+			}
+
+		case "web_search_tool_result":
+			// Content is a list of results on success, or an error object
+			var results []anthropicSearchResult
+			if err := json.Unmarshal(block.Content, &results); err != nil {
+				logrus.Debugf("Skipping web search tool result without results: %v", err)
+				continue
+			}
+			for _, result := range results {
+				if result.Type == "web_search_result" && result.URL != "" {
+					addChunk(result.URL, result.Title)
+				}
+			}
+
+		case "text":
+			start := text.Len()
+			text.WriteString(block.Text)
+
+			if len(block.Citations) == 0 {
+				continue
+			}
+
+			var support GroundingSupport
+			support.Segment.StartIndex = start
+			support.Segment.EndIndex = text.Len()
+			support.Segment.Text = block.Text
+			seen := make(map[int]bool)
+			for _, citation := range block.Citations {
+				if citation.URL == "" {
+					continue
+				}
+				index := addChunk(citation.URL, citation.Title)
+				if !seen[index] {
+					seen[index] = true
+					support.GroundingChunkIndices = append(support.GroundingChunkIndices, index)
+				}
+			}
+			if len(support.GroundingChunkIndices) > 0 {
+				metadata.GroundingSupports = append(metadata.GroundingSupports, support)
+			}
+		}
+	}
+
+	grounded := &GroundedResponse{Text: text.String()}
+	if len(metadata.GroundingChunks) > 0 || len(metadata.WebSearchQueries) > 0 {
+		grounded.GroundingMetadata = metadata
+	}
+	return grounded
+}
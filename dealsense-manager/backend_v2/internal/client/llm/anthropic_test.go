@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// anthropicServer answers Messages API requests with body and keeps the last request payload
+func anthropicServer(t *testing.T, body string) (*httptest.Server, *map[string]interface{}) {
+	t.Helper()
+	payload := &map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "anthropic-key" || r.Header.Get("anthropic-version") != anthropicVersion {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, payload)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("ANTHROPIC_API_KEY", "anthropic-key")
+	return server, payload
+}
+
+func TestAnthropicProviderCall(t *testing.T) {
+	server, payload := anthropicServer(t, `{"content":[{"type":"text","text":"Hello"},{"type":"text","text":" there"}]}`)
+	provider := NewAnthropicProvider("claude-sonnet-4")
+	provider.apiURL = server.URL
+
+	response, err := provider.Call("Hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response != "Hello there" {
+		t.Errorf("response = %q", response)
+	}
+	if (*payload)["model"] != "claude-sonnet-4" || (*payload)["tools"] != nil {
+		t.Errorf("payload = %v, want the model and no tools", *payload)
+	}
+}
+
+func TestAnthropicProviderGroundedCall(t *testing.T) {
+	server, payload := anthropicServer(t, `{"content":[
+		{"type":"server_tool_use","name":"web_search","input":{"query":"Acme revenue 2024"}},
+		{"type":"web_search_tool_result","content":[
+			{"type":"web_search_result","url":"https://acme.com/report","title":"Acme annual report"},
+			{"type":"web_search_result","url":"https://news.example.com/acme","title":"Acme news"}]},
+		{"type":"text","text":"Acme earned $4.2B.","citations":[
+			{"type":"web_search_result_location","url":"https://acme.com/report","title":"Acme annual report"},
+			{"type":"web_search_result_location","url":"https://acme.com/report","title":"Acme annual report"}]},
+		{"type":"text","text":" No other details."}]}`)
+	provider := NewAnthropicGroundedProvider("claude-sonnet-4")
+	provider.apiURL = server.URL
+
+	response, err := provider.CallWithGrounding("What did Acme earn?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Text != "Acme earned $4.2B. No other details." {
+		t.Errorf("text = %q", response.Text)
+	}
+	if (*payload)["tools"] == nil {
+		t.Error("web search tool not sent")
+	}
+
+	metadata := response.GroundingMetadata
+	if !reflect.DeepEqual(metadata.WebSearchQueries, []string{"Acme revenue 2024"}) {
+		t.Errorf("queries = %q", metadata.WebSearchQueries)
+	}
+	if len(metadata.GroundingChunks) != 2 {
+		t.Fatalf("chunks = %+v, want one per search result", metadata.GroundingChunks)
+	}
+	if len(metadata.GroundingSupports) != 1 {
+		t.Fatalf("supports = %+v, want one for the cited block", metadata.GroundingSupports)
+	}
+	support := metadata.GroundingSupports[0]
+	if support.Segment.StartIndex != 0 || support.Segment.EndIndex != len("Acme earned $4.2B.") ||
+		!reflect.DeepEqual(support.GroundingChunkIndices, []int{0}) {
+		t.Errorf("support = %+v, want the first block citing the first chunk once", support)
+	}
+}
+
+func TestAnthropicProviderGroundedCallWithoutSearch(t *testing.T) {
+	server, payload := anthropicServer(t, `{"content":[{"type":"text","text":"Ungrounded"}]}`)
+	provider := NewAnthropicProvider("claude-sonnet-4")
+	provider.apiURL = server.URL
+
+	response, err := provider.CallWithGrounding("prompt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Text != "Ungrounded" || response.GroundingMetadata != nil || (*payload)["tools"] != nil {
+		t.Errorf("response = %+v, payload = %v, want a plain call", response, *payload)
+	}
+}
+
+func TestAnthropicProviderErrorStatus(t *testing.T) {
+	server, _ := anthropicServer(t, "")
+	t.Setenv("ANTHROPIC_API_KEY", "wrong-key")
+	provider := NewAnthropicProvider("claude-sonnet-4")
+	provider.apiURL = server.URL
+
+	if _, err := provider.Call("prompt"); err == nil {
+		t.Error("Call() succeeded with a rejected key")
+	}
+}
@@ -0,0 +1,87 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a single record in the LLM call audit log. Prompts and responses
+// are stored as SHA-256 hashes so the log never contains meeting content.
+type AuditEntry struct {
+	PromptID     string    `json:"prompt_id"`
+	Timestamp    time.Time `json:"timestamp"`
+	Model        string    `json:"model"`
+	Provider     string    `json:"provider"`
+	PromptHash   string    `json:"prompt_hash"`
+	ResponseHash string    `json:"response_hash"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	AgentID      string    `json:"agent_id,omitempty"`
+	AnalysisType string    `json:"analysis_type,omitempty"`
+}
+
+// AuditLogger records LLM calls for compliance audit trails
+type AuditLogger interface {
+	LogCall(entry AuditEntry) error
+}
+
+// AuditableProvider is implemented by providers that record their calls to an AuditLogger
+type AuditableProvider interface {
+	SetAuditContext(agentID, analysisType string)
+}
+
+// FileAuditLogger appends audit entries to a file as newline-delimited JSON
+type FileAuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditLogger opens (or creates) the audit log at path in append-only mode
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &FileAuditLogger{file: file}, nil
+}
+
+// LogCall appends the entry to the audit log and flushes it to disk
+func (l *FileAuditLogger) LogCall(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return l.file.Sync()
+}
+
+// Close closes the underlying audit log file
+func (l *FileAuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// hashContent returns the hex-encoded SHA-256 hash of the given content
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,259 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultAzureOpenAIAPIVersion is used when AZURE_OPENAI_API_VERSION is unset
+const defaultAzureOpenAIAPIVersion = "2024-06-01"
+
+// ErrContentFiltered is returned when Azure's content filter rejects the prompt or the completion
+var ErrContentFiltered = errors.New("blocked by Azure OpenAI content filter")
+
+// ContentFilterError reports which content filter categories blocked a request. It matches
+// ErrContentFiltered with errors.Is.
+type ContentFilterError struct {
+	Categories []string // hate, self_harm, sexual, violence, jailbreak, ...
+}
+
+func (e *ContentFilterError) Error() string {
+	if len(e.Categories) == 0 {
+		return ErrContentFiltered.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrContentFiltered, strings.Join(e.Categories, ", "))
+}
+
+func (e *ContentFilterError) Unwrap() error {
+	return ErrContentFiltered
+}
+
+// AzureOpenAIProvider implements the LLMProvider and FunctionCallingProvider interfaces for an Azure
+// OpenAI deployment
+type AzureOpenAIProvider struct {
+	endpoint   string
+	apiKey     string
+	deployment string
+	apiVersion string
+	apiCalls   int64 // Counter for API calls
+	httpClient *http.Client
+}
+
+// azureChatResponse is the subset of the chat completions response used by the provider
+type azureChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"message"`
+		FinishReason         string                     `json:"finish_reason"`
+		ContentFilterResults map[string]json.RawMessage `json:"content_filter_results"`
+	} `json:"choices"`
+	PromptFilterResults []struct {
+		ContentFilterResults map[string]json.RawMessage `json:"content_filter_results"`
+	} `json:"prompt_filter_results"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// azureErrorResponse is the error body returned for rejected requests
+type azureErrorResponse struct {
+	Error struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		InnerError struct {
+			ContentFilterResult map[string]json.RawMessage `json:"content_filter_result"`
+		} `json:"innererror"`
+	} `json:"error"`
+}
+
+// NewAzureOpenAIProvider creates a provider configured from the AZURE_OPENAI_* environment variables.
+// A non-empty deployment overrides AZURE_OPENAI_DEPLOYMENT_NAME.
+func NewAzureOpenAIProvider(deployment string) *AzureOpenAIProvider {
+	if deployment == "" {
+		deployment = os.Getenv("AZURE_OPENAI_DEPLOYMENT_NAME")
+	}
+	apiVersion := os.Getenv("AZURE_OPENAI_API_VERSION")
+	if apiVersion == "" {
+		apiVersion = defaultAzureOpenAIAPIVersion
+	}
+
+	return &AzureOpenAIProvider{
+		endpoint:   strings.TrimSuffix(os.Getenv("AZURE_OPENAI_ENDPOINT"), "/"),
+		apiKey:     os.Getenv("AZURE_OPENAI_API_KEY"),
+		deployment: deployment,
+		apiVersion: apiVersion,
+		httpClient: &http.Client{Timeout: 120 * time.Second},
+	}
+}
+
+// GetAPICallCount returns the number of API calls made
+func (p *AzureOpenAIProvider) GetAPICallCount() int64 {
+	return atomic.LoadInt64(&p.apiCalls)
+}
+
+// IsAvailable checks if the Azure OpenAI endpoint, key and deployment are configured
+func (p *AzureOpenAIProvider) IsAvailable() bool {
+	return p.endpoint != "" && p.apiKey != "" && p.deployment != ""
+}
+
+// Call sends the prompt to the deployment's chat completions API
+func (p *AzureOpenAIProvider) Call(prompt string) (string, error) {
+	resp, err := p.chatCompletion(prompt, nil)
+	if err != nil {
+		return "", err
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// CallWithFunctions sends the prompt with functions the model may call, returning its text and any
+// function calls it made
+func (p *AzureOpenAIProvider) CallWithFunctions(prompt string, functions []FunctionDefinition) (string, []FunctionCall, error) {
+	resp, err := p.chatCompletion(prompt, functions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	message := resp.Choices[0].Message
+	calls := make([]FunctionCall, 0, len(message.ToolCalls))
+	for _, toolCall := range message.ToolCalls {
+		calls = append(calls, FunctionCall{
+			Name:      toolCall.Function.Name,
+			Arguments: json.RawMessage(toolCall.Function.Arguments),
+		})
+	}
+	return message.Content, calls, nil
+}
+
+// chatCompletion posts a single-message chat completion request, returning ErrContentFiltered when
+// the prompt or the completion was filtered
+func (p *AzureOpenAIProvider) chatCompletion(prompt string, functions []FunctionDefinition) (*azureChatResponse, error) {
+	if !p.IsAvailable() {
+		return nil, fmt.Errorf("AZURE_OPENAI_ENDPOINT, AZURE_OPENAI_API_KEY and AZURE_OPENAI_DEPLOYMENT_NAME must be set")
+	}
+
+	promptID := generatePromptID()
+	callNumber := atomic.AddInt64(&p.apiCalls, 1)
+
+	payload := map[string]interface{}{
+		"messages": []map[string]interface{}{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if len(functions) > 0 {
+		tools := make([]map[string]interface{}, len(functions))
+		for i, function := range functions {
+			tools[i] = map[string]interface{}{"type": "function", "function": function}
+		}
+		payload["tools"] = tools
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Azure addresses the deployment in the path rather than naming a model in the body
+	apiURL := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		p.endpoint, url.PathEscape(p.deployment), url.QueryEscape(p.apiVersion))
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", p.apiKey)
+
+	startTime := time.Now()
+	httpResp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp azureErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error.Code == "content_filter" {
+			logrus.WithFields(logrus.Fields{
+				"prompt_id":  promptID,
+				"deployment": p.deployment,
+			}).Warn("⚠️ Azure OpenAI prompt blocked by content filter")
+			return nil, &ContentFilterError{Categories: filteredCategories(errResp.Error.InnerError.ContentFilterResult)}
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"prompt_id":   promptID,
+			"deployment":  p.deployment,
+			"call_number": callNumber,
+			"status_code": httpResp.StatusCode,
+			"error_body":  truncateString(string(body), 1000),
+		}).Error("❌ Azure OpenAI API Error")
+		return nil, fmt.Errorf("API request failed with status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var resp azureChatResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in Azure OpenAI response")
+	}
+
+	if choice := resp.Choices[0]; choice.FinishReason == "content_filter" {
+		logrus.WithFields(logrus.Fields{
+			"prompt_id":  promptID,
+			"deployment": p.deployment,
+		}).Warn("⚠️ Azure OpenAI completion blocked by content filter")
+		return nil, &ContentFilterError{Categories: filteredCategories(choice.ContentFilterResults)}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"prompt_id":     promptID,
+		"deployment":    p.deployment,
+		"call_number":   callNumber,
+		"input_tokens":  resp.Usage.PromptTokens,
+		"output_tokens": resp.Usage.CompletionTokens,
+		"duration_ms":   time.Since(startTime).Milliseconds(),
+	}).Info("✅ Azure OpenAI API Response")
+
+	return &resp, nil
+}
+
+// filteredCategories returns the sorted names of the content filter categories marked as filtered
+func filteredCategories(results map[string]json.RawMessage) []string {
+	var categories []string
+	for category, raw := range results {
+		var result struct {
+			Filtered bool `json:"filtered"`
+			Detected bool `json:"detected"`
+		}
+		if json.Unmarshal(raw, &result) == nil && (result.Filtered || result.Detected) {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// azureServer answers chat completions with status and body, and reports the last request
+func azureServer(t *testing.T, status int, body string) (*httptest.Server, *http.Request) {
+	t.Helper()
+	received := &http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r.Clone(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("AZURE_OPENAI_ENDPOINT", server.URL+"/")
+	t.Setenv("AZURE_OPENAI_API_KEY", "azure-key")
+	t.Setenv("AZURE_OPENAI_DEPLOYMENT_NAME", "gpt-4o-prod")
+	t.Setenv("AZURE_OPENAI_API_VERSION", "")
+	return server, received
+}
+
+func TestAzureOpenAIProviderCall(t *testing.T) {
+	_, received := azureServer(t, http.StatusOK,
+		`{"choices":[{"message":{"content":"Hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":1}}`)
+
+	provider := NewAzureOpenAIProvider("")
+	response, err := provider.Call("Hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response != "Hello" {
+		t.Errorf("response = %q", response)
+	}
+	if received.URL.Path != "/openai/deployments/gpt-4o-prod/chat/completions" ||
+		received.URL.Query().Get("api-version") != defaultAzureOpenAIAPIVersion {
+		t.Errorf("request URL = %s", received.URL)
+	}
+	if received.Header.Get("api-key") != "azure-key" {
+		t.Error("API key header not sent")
+	}
+}
+
+func TestAzureOpenAIProviderFunctionCalls(t *testing.T) {
+	azureServer(t, http.StatusOK, `{"choices":[{"message":{"content":"","tool_calls":[
+		{"function":{"name":"create_task","arguments":"{\"title\":\"Send deck\"}"}}]},"finish_reason":"tool_calls"}]}`)
+
+	_, calls, err := NewAzureOpenAIProvider("").CallWithFunctions("Send the deck", []FunctionDefinition{{Name: "create_task"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || calls[0].Name != "create_task" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	var args map[string]string
+	if err := json.Unmarshal(calls[0].Arguments, &args); err != nil || args["title"] != "Send deck" {
+		t.Errorf("arguments = %s", calls[0].Arguments)
+	}
+}
+
+func TestAzureOpenAIProviderFilteredPrompt(t *testing.T) {
+	azureServer(t, http.StatusBadRequest, `{"error":{"code":"content_filter","message":"filtered","innererror":{
+		"content_filter_result":{"hate":{"filtered":false,"severity":"safe"},"violence":{"filtered":true,"severity":"high"},"jailbreak":{"filtered":true,"detected":true}}}}}`)
+
+	_, err := NewAzureOpenAIProvider("").Call("prompt")
+	if !errors.Is(err, ErrContentFiltered) {
+		t.Fatalf("Call() = %v, want ErrContentFiltered", err)
+	}
+	var filterErr *ContentFilterError
+	if !errors.As(err, &filterErr) || !reflect.DeepEqual(filterErr.Categories, []string{"jailbreak", "violence"}) {
+		t.Errorf("error = %v, want the filtered categories", err)
+	}
+}
+
+func TestAzureOpenAIProviderFilteredCompletion(t *testing.T) {
+	azureServer(t, http.StatusOK, `{"choices":[{"message":{"content":""},"finish_reason":"content_filter",
+		"content_filter_results":{"self_harm":{"filtered":true,"severity":"medium"}}}]}`)
+
+	_, err := NewAzureOpenAIProvider("").Call("prompt")
+	var filterErr *ContentFilterError
+	if !errors.As(err, &filterErr) || !reflect.DeepEqual(filterErr.Categories, []string{"self_harm"}) {
+		t.Errorf("Call() = %v, want a content filter error for self_harm", err)
+	}
+}
+
+func TestAzureOpenAIProviderRequiresConfiguration(t *testing.T) {
+	t.Setenv("AZURE_OPENAI_ENDPOINT", "")
+	provider := NewAzureOpenAIProvider("deployment")
+	if provider.IsAvailable() {
+		t.Error("provider available without an endpoint")
+	}
+	if _, err := provider.Call("prompt"); err == nil {
+		t.Error("Call() succeeded without an endpoint")
+	}
+}
@@ -0,0 +1,316 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	geminiAPIBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+	defaultBatchPollInterval = 5 * time.Second
+	defaultBatchTimeout      = 5 * time.Minute
+)
+
+// PromptRequest is one prompt in a batch, optionally offering functions the model may call
+type PromptRequest struct {
+	Prompt    string
+	Functions []FunctionDefinition
+}
+
+// PromptResponse is the result of one prompt in a batch. Err is set when that prompt failed while the
+// rest of the batch succeeded.
+type PromptResponse struct {
+	Text          string
+	FunctionCalls []FunctionCall
+	Err           error
+}
+
+// BatchCallingProvider extends LLMProvider with sending several prompts as one request
+type BatchCallingProvider interface {
+	LLMProvider
+	CallBatch(prompts []PromptRequest) ([]PromptResponse, error)
+}
+
+// geminiBatch is a batch job resource returned when creating or polling a batch
+type geminiBatch struct {
+	Name     string `json:"name"`
+	Done     bool   `json:"done"`
+	Metadata struct {
+		State  string             `json:"state"`
+		Output *geminiBatchOutput `json:"output"`
+	} `json:"metadata"`
+	Response *geminiBatchOutput `json:"response"`
+	Error    *geminiError       `json:"error"`
+}
+
+// geminiBatchOutput holds the responses of a finished batch job in request order
+type geminiBatchOutput struct {
+	InlinedResponses struct {
+		InlinedResponses []struct {
+			Response json.RawMessage `json:"response"`
+			Error    *geminiError    `json:"error"`
+			Metadata struct {
+				Key string `json:"key"`
+			} `json:"metadata"`
+		} `json:"inlinedResponses"`
+	} `json:"inlinedResponses"`
+}
+
+// geminiError is an error status returned by the Gemini API
+type geminiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// CallBatch submits the prompts as one Gemini batch job and waits for it to finish, falling back
+// through FallbackChain when the job can't be created because of rate limits. Batch jobs are
+// processed asynchronously, so the job is cancelled if it hasn't finished within the batch timeout.
+func (p *GoogleProvider) CallBatch(prompts []PromptRequest) ([]PromptResponse, error) {
+	if len(prompts) == 0 {
+		return nil, nil
+	}
+
+	chain := p.modelChain()
+	for i, model := range chain {
+		responses, err := p.callModelBatch(model, prompts)
+		if err == nil {
+			return responses, nil
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			return nil, err
+		}
+		if i+1 < len(chain) {
+			p.logFallback(model, chain[i+1], err)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAllModelsExhausted, strings.Join(chain, ", "))
+}
+
+// callModelBatch runs a batch job for the prompts using the given model
+func (p *GoogleProvider) callModelBatch(model string, prompts []PromptRequest) ([]PromptResponse, error) {
+	promptID := generatePromptID()
+	callNumber := atomic.AddInt64(&p.apiCalls, 1)
+
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("GOOGLE_API_KEY not found")
+	}
+
+	requests := make([]map[string]interface{}, len(prompts))
+	for i, prompt := range prompts {
+		request := map[string]interface{}{
+			"contents": []map[string]interface{}{
+				{"parts": []map[string]string{{"text": prompt.Prompt}}},
+			},
+			"generationConfig": map[string]interface{}{
+				"maxOutputTokens": 2000,
+				"temperature":     0.5,
+			},
+		}
+		if len(prompt.Functions) > 0 {
+			request["tools"] = []map[string]interface{}{{"functionDeclarations": prompt.Functions}}
+		}
+		requests[i] = map[string]interface{}{
+			"request":  request,
+			"metadata": map[string]string{"key": strconv.Itoa(i)},
+		}
+	}
+	payload := map[string]interface{}{
+		"batch": map[string]interface{}{
+			"display_name": "dealsense-" + promptID,
+			"input_config": map[string]interface{}{
+				"requests": map[string]interface{}{"requests": requests},
+			},
+		},
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"prompt_id":    promptID,
+		"model":        model,
+		"call_number":  callNumber,
+		"prompt_count": len(prompts),
+	}).Info("🚀 Gemini Batch Request")
+
+	startTime := time.Now()
+	var batch geminiBatch
+	if err := p.batchRequest("POST", fmt.Sprintf("%s/models/%s:batchGenerateContent", p.batchAPIURL, model), apiKey, payload, &batch); err != nil {
+		return nil, err
+	}
+
+	deadline := startTime.Add(p.batchTimeout)
+	for !batchFinished(&batch) {
+		if time.Now().After(deadline) {
+			if err := p.batchRequest("POST", fmt.Sprintf("%s/%s:cancel", p.batchAPIURL, batch.Name), apiKey, map[string]interface{}{}, nil); err != nil {
+				logrus.Warnf("Failed to cancel Gemini batch %s: %v", batch.Name, err)
+			}
+			return nil, fmt.Errorf("gemini batch %s did not finish within %s", batch.Name, p.batchTimeout)
+		}
+		time.Sleep(p.batchPollInterval)
+
+		name := batch.Name
+		batch = geminiBatch{}
+		if err := p.batchRequest("GET", fmt.Sprintf("%s/%s", p.batchAPIURL, name), apiKey, nil, &batch); err != nil {
+			return nil, err
+		}
+		if batch.Name == "" {
+			batch.Name = name
+		}
+	}
+
+	if batch.Error != nil {
+		return nil, fmt.Errorf("gemini batch %s failed: %s", batch.Name, batch.Error.Message)
+	}
+	output := batch.Response
+	if output == nil {
+		output = batch.Metadata.Output
+	}
+	if output == nil {
+		return nil, fmt.Errorf("gemini batch %s finished in state %s without responses", batch.Name, batch.Metadata.State)
+	}
+
+	responses := make([]PromptResponse, len(prompts))
+	for i := range responses {
+		responses[i].Err = fmt.Errorf("no response for batch request %d", i)
+	}
+	for position, inlined := range output.InlinedResponses.InlinedResponses {
+		index := position
+		if key, err := strconv.Atoi(inlined.Metadata.Key); err == nil {
+			index = key
+		}
+		if index < 0 || index >= len(responses) {
+			continue
+		}
+
+		if inlined.Error != nil {
+			responses[index] = PromptResponse{Err: fmt.Errorf("batch request %d failed: %s", index, inlined.Error.Message)}
+			continue
+		}
+		responses[index] = parseBatchResponse(inlined.Response)
+		if responses[index].Err == nil {
+			usage := extractTokenUsage(inlined.Response)
+			p.recordAudit(promptID, model, prompts[index].Prompt, responses[index].Text, usage)
+			p.recordTokens(usage)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"prompt_id":    promptID,
+		"model":        model,
+		"call_number":  callNumber,
+		"prompt_count": len(prompts),
+		"duration_ms":  time.Since(startTime).Milliseconds(),
+	}).Info("✅ Gemini Batch Response")
+
+	return responses, nil
+}
+
+// batchFinished reports whether the batch job has stopped running
+func batchFinished(batch *geminiBatch) bool {
+	if batch.Done {
+		return true
+	}
+	switch batch.Metadata.State {
+	case "BATCH_STATE_SUCCEEDED", "BATCH_STATE_FAILED", "BATCH_STATE_CANCELLED", "BATCH_STATE_EXPIRED":
+		return true
+	}
+	return false
+}
+
+// batchRequest sends a request to the Gemini batch API, decoding the response into result if set
+func (p *GoogleProvider) batchRequest(method, url, apiKey string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", apiKey)
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logrus.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"error_body":  truncateString(string(respBody), 1000),
+		}).Error("❌ Gemini Batch HTTP Error Response")
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("%w: batch request failed with status %d: %s", ErrRateLimited, resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("batch request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to parse batch response: %w", err)
+	}
+	return nil
+}
+
+// parseBatchResponse extracts the text and function calls of a generateContent response
+func parseBatchResponse(body json.RawMessage) PromptResponse {
+	var response struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text         string `json:"text"`
+					FunctionCall *struct {
+						Name string          `json:"name"`
+						Args json.RawMessage `json:"args"`
+					} `json:"functionCall"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return PromptResponse{Err: fmt.Errorf("failed to parse response: %w", err)}
+	}
+	if len(response.Candidates) == 0 {
+		return PromptResponse{Err: fmt.Errorf("no candidates in batch response")}
+	}
+
+	var result PromptResponse
+	var text strings.Builder
+	for _, part := range response.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+		if part.FunctionCall != nil {
+			result.FunctionCalls = append(result.FunctionCalls, FunctionCall{
+				Name:      part.FunctionCall.Name,
+				Arguments: part.FunctionCall.Args,
+			})
+		}
+	}
+	result.Text = text.String()
+	return result
+}
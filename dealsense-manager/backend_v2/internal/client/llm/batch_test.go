@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchAPI is a mock Gemini batch API answering each request with the next handler's response
+type batchAPI struct {
+	mu       sync.Mutex
+	requests []string // Method and path of each request
+	bodies   []map[string]interface{}
+	handlers []func(req *http.Request) (int, string)
+}
+
+func (b *batchAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests = append(b.requests, req.Method+" "+req.URL.Path)
+	var body map[string]interface{}
+	if req.Body != nil {
+		json.NewDecoder(req.Body).Decode(&body)
+	}
+	b.bodies = append(b.bodies, body)
+
+	status, response := http.StatusNotFound, `{}`
+	if len(b.handlers) > 0 {
+		status, response = b.handlers[0](req)
+		b.handlers = b.handlers[1:]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(response))),
+		Request:    req,
+	}, nil
+}
+
+// reply returns a handler answering status and body
+func reply(status int, body string) func(*http.Request) (int, string) {
+	return func(*http.Request) (int, string) { return status, body }
+}
+
+// newBatchProvider creates a Google provider sending batch requests to api and polling without delay
+func newBatchProvider(t *testing.T, api *batchAPI) *GoogleProvider {
+	t.Helper()
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	provider := NewGoogleProviderWithTransport("gemini-test", api)
+	provider.batchAPIURL = "https://batch.test/v1beta"
+	provider.batchPollInterval = time.Millisecond
+	return provider
+}
+
+func TestCallBatchPollsUntilDone(t *testing.T) {
+	api := &batchAPI{handlers: []func(*http.Request) (int, string){
+		reply(http.StatusOK, `{"name": "batches/1", "metadata": {"state": "BATCH_STATE_RUNNING"}}`),
+		reply(http.StatusOK, `{"metadata": {"state": "BATCH_STATE_RUNNING"}}`),
+		// Responses are matched to requests by key, not position
+		reply(http.StatusOK, `{"name": "batches/1", "done": true, "response": {"inlinedResponses": {"inlinedResponses": [
+			{"metadata": {"key": "1"}, "error": {"code": 400, "message": "bad prompt"}},
+			{"metadata": {"key": "0"}, "response": {"candidates": [{"content": {"parts": [
+				{"text": "first "}, {"text": "answer"},
+				{"functionCall": {"name": "lookup", "args": {"q": "acme"}}}
+			]}}]}}
+		]}}}`),
+	}}
+	provider := newBatchProvider(t, api)
+
+	responses, err := provider.CallBatch([]PromptRequest{
+		{Prompt: "one", Functions: []FunctionDefinition{{Name: "lookup"}}},
+		{Prompt: "two"},
+		{Prompt: "three"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /v1beta/models/gemini-test:batchGenerateContent",
+		"GET /v1beta/batches/1",
+		"GET /v1beta/batches/1",
+	}
+	if strings.Join(api.requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %v, want %v", api.requests, want)
+	}
+	created, _ := json.Marshal(api.bodies[0])
+	if !strings.Contains(string(created), `"functionDeclarations":[{"name":"lookup"`) || !strings.Contains(string(created), `"key":"2"`) {
+		t.Errorf("batch request = %s", created)
+	}
+
+	if responses[0].Err != nil || responses[0].Text != "first answer" || len(responses[0].FunctionCalls) != 1 || responses[0].FunctionCalls[0].Name != "lookup" {
+		t.Errorf("response 0 = %+v", responses[0])
+	}
+	if responses[1].Err == nil || !strings.Contains(responses[1].Err.Error(), "bad prompt") {
+		t.Errorf("response 1 error = %v, want the request's error", responses[1].Err)
+	}
+	if responses[2].Err == nil {
+		t.Error("response 2 has no error despite missing from the output")
+	}
+}
+
+func TestCallBatchFallsBackWhenRateLimited(t *testing.T) {
+	api := &batchAPI{handlers: []func(*http.Request) (int, string){
+		reply(http.StatusTooManyRequests, `{"error": {"message": "quota"}}`),
+		reply(http.StatusOK, `{"name": "batches/2", "metadata": {"state": "BATCH_STATE_SUCCEEDED", "output": {"inlinedResponses": {"inlinedResponses": [
+			{"response": {"candidates": [{"content": {"parts": [{"text": "ok"}]}}]}}
+		]}}}}`),
+	}}
+	provider := newBatchProvider(t, api).WithFallbacks("gemini-fallback")
+
+	responses, err := provider.CallBatch([]PromptRequest{{Prompt: "one"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.requests) != 2 || !strings.Contains(api.requests[1], "gemini-fallback") {
+		t.Errorf("requests = %v, want a retry with the fallback model", api.requests)
+	}
+	if responses[0].Text != "ok" {
+		t.Errorf("response = %+v", responses[0])
+	}
+
+	// Once every model is rate limited the batch fails
+	api.handlers = []func(*http.Request) (int, string){
+		reply(http.StatusTooManyRequests, `{}`),
+		reply(http.StatusTooManyRequests, `{}`),
+	}
+	if _, err := provider.CallBatch([]PromptRequest{{Prompt: "one"}}); !errors.Is(err, ErrAllModelsExhausted) {
+		t.Errorf("CallBatch() error = %v, want ErrAllModelsExhausted", err)
+	}
+}
+
+func TestCallBatchCancelsAfterTimeout(t *testing.T) {
+	api := &batchAPI{handlers: []func(*http.Request) (int, string){
+		reply(http.StatusOK, `{"name": "batches/3", "metadata": {"state": "BATCH_STATE_PENDING"}}`),
+		reply(http.StatusOK, `{}`),
+	}}
+	provider := newBatchProvider(t, api)
+	provider.batchTimeout = 0
+
+	if _, err := provider.CallBatch([]PromptRequest{{Prompt: "one"}}); err == nil || !strings.Contains(err.Error(), "did not finish") {
+		t.Errorf("CallBatch() error = %v, want a timeout", err)
+	}
+	if len(api.requests) != 2 || api.requests[1] != "POST /v1beta/batches/3:cancel" {
+		t.Errorf("requests = %v, want the batch cancelled", api.requests)
+	}
+}
+
+func TestCallBatchFailedJob(t *testing.T) {
+	api := &batchAPI{handlers: []func(*http.Request) (int, string){
+		reply(http.StatusOK, `{"name": "batches/4", "done": true, "error": {"code": 500, "message": "internal"}}`),
+	}}
+	provider := newBatchProvider(t, api)
+
+	if _, err := provider.CallBatch([]PromptRequest{{Prompt: "one"}}); err == nil || !strings.Contains(err.Error(), "internal") {
+		t.Errorf("CallBatch() error = %v, want the job's error", err)
+	}
+	if responses, err := provider.CallBatch(nil); responses != nil || err != nil {
+		t.Errorf("CallBatch(nil) = %v, %v", responses, err)
+	}
+}
+
+func TestParseBatchResponseWithoutCandidates(t *testing.T) {
+	if response := parseBatchResponse(json.RawMessage(`{"candidates": []}`)); response.Err == nil {
+		t.Error("parseBatchResponse() accepted a response without candidates")
+	}
+	if response := parseBatchResponse(json.RawMessage(`not json`)); response.Err == nil {
+		t.Error("parseBatchResponse() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ResponseCache holds ungrounded responses for a fixed time so repeated prompts don't use API quota
+type ResponseCache struct {
+	ttl     time.Duration
+	entries sync.Map // cache key -> cachedResponse
+	hits    int64
+	misses  int64
+
+	stop      chan struct{} // Closed by Close to end the sweep
+	closeOnce sync.Once
+}
+
+// cachedResponse is a response and when it was stored
+type cachedResponse struct {
+	response string
+	cachedAt time.Time
+}
+
+// NewResponseCache creates a cache whose entries expire after ttl. Expired entries are dropped when
+// read and by a sweep every ttl/2 that runs until Close is called.
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	cache := &ResponseCache{ttl: ttl, stop: make(chan struct{})}
+	go cache.sweep()
+	return cache
+}
+
+// Close stops the cache's sweep. The cache can still be read, but expired entries are only dropped when
+// read.
+func (c *ResponseCache) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+}
+
+// defaultResponseCache is the response cache shared by providers created via GetProvider, nil when
+// caching is disabled
+var defaultResponseCache *ResponseCache
+
+// SetDefaultGoogleCacheTTL enables a response cache shared by providers created via GetProvider, whose
+// entries expire after ttl, or disables it when ttl is 0. The previous shared cache is closed.
+func SetDefaultGoogleCacheTTL(ttl time.Duration) {
+	if defaultResponseCache != nil {
+		defaultResponseCache.Close()
+		defaultResponseCache = nil
+	}
+	if ttl > 0 {
+		defaultResponseCache = NewResponseCache(ttl)
+	}
+}
+
+// WithCache caches ungrounded responses for ttl in a cache of the provider's own, or disables caching when
+// ttl is 0. A cache the provider created before is closed. Grounded calls are never cached since their
+// search results go stale.
+func (p *GoogleProvider) WithCache(ttl time.Duration) *GoogleProvider {
+	var cache *ResponseCache
+	if ttl > 0 {
+		cache = NewResponseCache(ttl)
+	}
+	p.setCache(cache, true)
+	return p
+}
+
+// WithResponseCache caches ungrounded responses in a cache shared with other providers, or disables
+// caching when cache is nil. Responses are keyed by model, so providers of different models can share
+// a cache; closing it is left to its creator.
+func (p *GoogleProvider) WithResponseCache(cache *ResponseCache) *GoogleProvider {
+	p.setCache(cache, false)
+	return p
+}
+
+// setCache replaces the provider's cache, closing the previous one when the provider created it
+func (p *GoogleProvider) setCache(cache *ResponseCache, owned bool) {
+	if p.cache != nil && p.ownsCache {
+		p.cache.Close()
+	}
+	p.cache = cache
+	p.ownsCache = owned && cache != nil
+}
+
+// CacheHitCount returns the number of calls answered from the response cache
+func (p *GoogleProvider) CacheHitCount() int64 {
+	if p.cache == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&p.cache.hits)
+}
+
+// CacheMissCount returns the number of calls that had to go to the API while caching was enabled
+func (p *GoogleProvider) CacheMissCount() int64 {
+	if p.cache == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&p.cache.misses)
+}
+
+// responseCacheKey returns the cache key for a prompt sent to model
+func responseCacheKey(model, prompt string) string {
+	sum := sha256.Sum256([]byte(model + "|" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the unexpired response stored under key
+func (c *ResponseCache) get(key string) (string, bool) {
+	value, ok := c.entries.Load(key)
+	if ok {
+		entry := value.(cachedResponse)
+		if time.Since(entry.cachedAt) < c.ttl {
+			atomic.AddInt64(&c.hits, 1)
+			logrus.WithField("cache_key", key).Debug("Gemini response cache hit")
+			return entry.response, true
+		}
+		c.entries.CompareAndDelete(key, value)
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	return "", false
+}
+
+// put stores a response under key
+func (c *ResponseCache) put(key, response string) {
+	c.entries.Store(key, cachedResponse{response: response, cachedAt: time.Now()})
+}
+
+// sweep periodically removes expired entries
+func (c *ResponseCache) sweep() {
+	ticker := time.NewTicker(max(c.ttl/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+		c.entries.Range(func(key, value interface{}) bool {
+			if time.Since(value.(cachedResponse).cachedAt) >= c.ttl {
+				c.entries.CompareAndDelete(key, value)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package llm
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport answers every request with answer, rate limiting requests to the models in
+// rateLimited, and counts the requests per model
+func countingTransport(calls map[string]*int64, answer string, rateLimited ...string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		for model, count := range calls {
+			if strings.Contains(req.URL.Path, "/models/"+model+":") {
+				atomic.AddInt64(count, 1)
+			}
+		}
+		for _, model := range rateLimited {
+			if strings.Contains(req.URL.Path, "/models/"+model+":") {
+				return geminiResponse(req, http.StatusTooManyRequests, ""), nil
+			}
+		}
+		return geminiResponse(req, http.StatusOK, answer), nil
+	})
+}
+
+func TestResponseCacheAnswersRepeatedPrompts(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary int64
+	provider := NewGoogleProviderWithTransport("gemini-primary",
+		countingTransport(map[string]*int64{"gemini-primary": &primary}, "cached answer")).WithCache(time.Minute)
+	defer provider.WithCache(0)
+
+	for i := 0; i < 2; i++ {
+		response, err := provider.Call("prompt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response != "cached answer" {
+			t.Fatalf("response = %q", response)
+		}
+	}
+	if primary != 1 {
+		t.Errorf("API calls = %d, want 1", primary)
+	}
+	if provider.CacheHitCount() != 1 || provider.CacheMissCount() != 1 {
+		t.Errorf("hits, misses = %d, %d, want 1, 1", provider.CacheHitCount(), provider.CacheMissCount())
+	}
+}
+
+func TestResponseCacheSkipsFallbackAnswers(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary, fallback int64
+	transport := countingTransport(map[string]*int64{"gemini-primary": &primary, "gemini-fallback": &fallback},
+		"fallback answer", "gemini-primary")
+	provider := NewGoogleProviderWithTransport("gemini-primary", transport).
+		WithFallbacks("gemini-fallback").
+		WithCache(time.Minute)
+	defer provider.WithCache(0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.Call("prompt"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if primary != 2 || fallback != 2 {
+		t.Errorf("API calls to primary, fallback = %d, %d, want 2, 2", primary, fallback)
+	}
+	if provider.CacheHitCount() != 0 {
+		t.Errorf("cache hits = %d, want fallback answers not to be cached", provider.CacheHitCount())
+	}
+}
+
+func TestWithCacheClosesReplacedCache(t *testing.T) {
+	provider := NewGoogleProvider("gemini-primary").WithCache(time.Minute)
+	first := provider.cache
+
+	provider.WithCache(time.Minute)
+	select {
+	case <-first.stop:
+	default:
+		t.Error("replaced cache was not closed")
+	}
+
+	shared := NewResponseCache(time.Minute)
+	defer shared.Close()
+	second := provider.cache
+	provider.WithResponseCache(shared)
+	select {
+	case <-second.stop:
+	default:
+		t.Error("cache replaced by a shared one was not closed")
+	}
+
+	provider.WithResponseCache(nil)
+	select {
+	case <-shared.stop:
+		t.Error("shared cache was closed by a provider using it")
+	default:
+	}
+}
+
+func TestSetDefaultGoogleCacheTTL(t *testing.T) {
+	SetDefaultGoogleCacheTTL(time.Minute)
+	first := defaultResponseCache
+	if first == nil {
+		t.Fatal("no default cache created")
+	}
+
+	provider, err := GetProvider("google", "gemini-primary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.(*GoogleProvider).cache != first {
+		t.Error("provider doesn't use the default cache")
+	}
+
+	SetDefaultGoogleCacheTTL(0)
+	if defaultResponseCache != nil {
+		t.Error("default cache not disabled")
+	}
+	select {
+	case <-first.stop:
+	default:
+		t.Error("previous default cache was not closed")
+	}
+}
+
+func TestResponseCacheEntriesExpire(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary int64
+	provider := NewGoogleProviderWithTransport("gemini-primary",
+		countingTransport(map[string]*int64{"gemini-primary": &primary}, "answer")).WithCache(50 * time.Millisecond)
+	defer provider.WithCache(0)
+
+	if _, err := provider.Call("prompt"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := provider.Call("prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if primary != 2 {
+		t.Errorf("API calls = %d, want the expired answer requested again", primary)
+	}
+}
+
+func TestResponseCacheSkipsGroundedCalls(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary int64
+	provider := NewGoogleProviderWithTransport("gemini-primary",
+		countingTransport(map[string]*int64{"gemini-primary": &primary}, "grounded answer")).WithCache(time.Minute)
+	defer provider.WithCache(0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.CallWithGrounding("prompt"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if primary != 2 {
+		t.Errorf("API calls = %d, want grounded answers not to be cached", primary)
+	}
+	if provider.CacheHitCount() != 0 || provider.CacheMissCount() != 0 {
+		t.Errorf("hits, misses = %d, %d, want the cache unused", provider.CacheHitCount(), provider.CacheMissCount())
+	}
+}
@@ -0,0 +1,209 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultOutputTokenMultiplier estimates a response's length as this share of the prompt's tokens
+	DefaultOutputTokenMultiplier = 0.4
+
+	// approxCharsPerToken estimates token counts when the countTokens endpoint can't be used
+	approxCharsPerToken = 4
+
+	countTokensTimeout = 10 * time.Second
+)
+
+// ErrUnknownModelPricing is returned when estimating the cost of a call to a model without known pricing
+var ErrUnknownModelPricing = errors.New("no pricing for model")
+
+// ModelPricing is what a model charges per million tokens
+type ModelPricing struct {
+	InputUSDPerMillion  float64
+	OutputUSDPerMillion float64
+}
+
+// modelPricing holds the list prices of the supported models, matched by the longest prefix of the model name
+var modelPricing = map[string]ModelPricing{
+	"gemini-2.5-pro":        {InputUSDPerMillion: 1.25, OutputUSDPerMillion: 10},
+	"gemini-2.5-flash":      {InputUSDPerMillion: 0.30, OutputUSDPerMillion: 2.50},
+	"gemini-2.5-flash-lite": {InputUSDPerMillion: 0.10, OutputUSDPerMillion: 0.40},
+	"gemini-2.0-flash":      {InputUSDPerMillion: 0.10, OutputUSDPerMillion: 0.40},
+	"gemini-2.0-flash-lite": {InputUSDPerMillion: 0.075, OutputUSDPerMillion: 0.30},
+	"gemini-1.5-pro":        {InputUSDPerMillion: 1.25, OutputUSDPerMillion: 5},
+	"gemini-1.5-flash":      {InputUSDPerMillion: 0.075, OutputUSDPerMillion: 0.30},
+	"claude-3-5-haiku":      {InputUSDPerMillion: 0.80, OutputUSDPerMillion: 4},
+	"claude-3-5-sonnet":     {InputUSDPerMillion: 3, OutputUSDPerMillion: 15},
+	"claude-sonnet-4":       {InputUSDPerMillion: 3, OutputUSDPerMillion: 15},
+	"claude-opus-4":         {InputUSDPerMillion: 15, OutputUSDPerMillion: 75},
+	"gpt-4o-mini":           {InputUSDPerMillion: 0.15, OutputUSDPerMillion: 0.60},
+	"gpt-4o":                {InputUSDPerMillion: 2.50, OutputUSDPerMillion: 10},
+}
+
+// lookupModelPricing returns the pricing of the longest known model name model starts with
+func lookupModelPricing(model string) (ModelPricing, bool) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	var best string
+	for name := range modelPricing {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPricing{}, false
+	}
+	return modelPricing[best], true
+}
+
+// CostEstimate is the projected cost of sending a prompt
+type CostEstimate struct {
+	InputTokens           int     `json:"input_tokens"`
+	EstimatedOutputTokens int     `json:"estimated_output_tokens"`
+	EstimatedCostUSD      float64 `json:"estimated_cost_usd"`
+	WillExceedDailyBudget bool    `json:"will_exceed_daily_budget"` // The call would take today's spend over the daily budget
+}
+
+// TokenCounter counts the tokens a model reads from a prompt
+type TokenCounter func(model, prompt string) (int, error)
+
+// PromptCostEstimator projects what prompts will cost before they are sent, and tracks the day's
+// estimated spend against a daily budget shared by every caller
+type PromptCostEstimator struct {
+	countTokens           TokenCounter
+	outputTokenMultiplier float64
+	dailyBudgetUSD        float64 // 0 for no budget
+
+	mu         sync.Mutex
+	spentDay   string
+	spentToday float64
+}
+
+// NewPromptCostEstimator creates an estimator counting tokens with the Google AI countTokens endpoint. A
+// dailyBudgetUSD of 0 means no budget, and an outputTokenMultiplier of 0 uses DefaultOutputTokenMultiplier.
+func NewPromptCostEstimator(dailyBudgetUSD, outputTokenMultiplier float64) *PromptCostEstimator {
+	if outputTokenMultiplier <= 0 {
+		outputTokenMultiplier = DefaultOutputTokenMultiplier
+	}
+	return &PromptCostEstimator{
+		countTokens:           CountGoogleTokens,
+		outputTokenMultiplier: outputTokenMultiplier,
+		dailyBudgetUSD:        dailyBudgetUSD,
+	}
+}
+
+// WithTokenCounter replaces how prompt tokens are counted, such as with a fake in tests
+func (e *PromptCostEstimator) WithTokenCounter(counter TokenCounter) *PromptCostEstimator {
+	e.countTokens = counter
+	return e
+}
+
+// EstimateCallCost projects the cost of sending prompt to model. Input tokens are counted by the token
+// counter, falling back to four characters per token when it fails, and output tokens are estimated as
+// the output token multiplier times the input tokens.
+func (e *PromptCostEstimator) EstimateCallCost(prompt string, model string) (CostEstimate, error) {
+	pricing, ok := lookupModelPricing(model)
+	if !ok {
+		return CostEstimate{}, fmt.Errorf("%w: %s", ErrUnknownModelPricing, model)
+	}
+
+	inputTokens, err := e.countTokens(model, prompt)
+	if err != nil {
+		logrus.Debugf("Counting prompt tokens for %s failed, approximating from its length: %v", model, err)
+		inputTokens = (len(prompt) + approxCharsPerToken - 1) / approxCharsPerToken
+	}
+
+	estimate := CostEstimate{
+		InputTokens:           inputTokens,
+		EstimatedOutputTokens: int(float64(inputTokens)*e.outputTokenMultiplier + 0.5),
+	}
+	estimate.EstimatedCostUSD = (float64(estimate.InputTokens)*pricing.InputUSDPerMillion +
+		float64(estimate.EstimatedOutputTokens)*pricing.OutputUSDPerMillion) / 1e6
+	estimate.WillExceedDailyBudget = e.WouldExceedDailyBudget(estimate.EstimatedCostUSD)
+	return estimate, nil
+}
+
+// WouldExceedDailyBudget reports whether spending costUSD would take today's spend over the daily budget
+func (e *PromptCostEstimator) WouldExceedDailyBudget(costUSD float64) bool {
+	if e.dailyBudgetUSD <= 0 {
+		return false
+	}
+	return e.SpentToday()+costUSD > e.dailyBudgetUSD
+}
+
+// RecordSpend adds the estimated cost of calls that were sent to today's spend
+func (e *PromptCostEstimator) RecordSpend(costUSD float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	today := time.Now().Format("2006-01-02")
+	if e.spentDay != today {
+		e.spentDay = today
+		e.spentToday = 0
+	}
+	e.spentToday += costUSD
+}
+
+// SpentToday returns the estimated cost of the calls recorded since midnight
+func (e *PromptCostEstimator) SpentToday() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.spentDay != time.Now().Format("2006-01-02") {
+		return 0
+	}
+	return e.spentToday
+}
+
+// CountGoogleTokens counts the tokens of prompt with the Google AI countTokens endpoint
+func CountGoogleTokens(model, prompt string) (int, error) {
+	if !strings.HasPrefix(model, "gemini") {
+		return 0, fmt.Errorf("model %s is not served by the Google AI API", model)
+	}
+	apiKey := os.Getenv("GOOGLE_API_KEY")
+	if apiKey == "" {
+		return 0, fmt.Errorf("GOOGLE_API_KEY not found")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{"parts": []map[string]string{{"text": prompt}}},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/models/%s:countTokens?key=%s", geminiAPIBaseURL, model, apiKey)
+	resp, err := (&http.Client{Timeout: countTokensTimeout}).Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tokens: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("countTokens request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		TotalTokens int `json:"totalTokens"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse countTokens response: %w", err)
+	}
+	return result.TotalTokens, nil
+}
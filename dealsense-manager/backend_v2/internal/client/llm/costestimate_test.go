@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// fixedTokens counts every prompt as tokens
+func fixedTokens(tokens int) TokenCounter {
+	return func(model, prompt string) (int, error) {
+		return tokens, nil
+	}
+}
+
+func TestEstimateCallCost(t *testing.T) {
+	estimator := NewPromptCostEstimator(0, 0.5).WithTokenCounter(fixedTokens(1_000_000))
+
+	estimate, err := estimator.EstimateCallCost("prompt", "gemini-2.5-flash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.InputTokens != 1_000_000 || estimate.EstimatedOutputTokens != 500_000 {
+		t.Errorf("tokens = %d, %d", estimate.InputTokens, estimate.EstimatedOutputTokens)
+	}
+	// 1M input tokens at $0.30 and 500k output tokens at $2.50 per million
+	if math.Abs(estimate.EstimatedCostUSD-1.55) > 1e-9 {
+		t.Errorf("cost = %f, want 1.55", estimate.EstimatedCostUSD)
+	}
+	if estimate.WillExceedDailyBudget {
+		t.Error("estimate exceeds a disabled budget")
+	}
+}
+
+func TestEstimateCallCostUsesLongestModelPrefix(t *testing.T) {
+	estimator := NewPromptCostEstimator(0, 0).WithTokenCounter(fixedTokens(1_000_000))
+
+	lite, err := estimator.EstimateCallCost("prompt", "gemini-2.5-flash-lite-preview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flash, err := estimator.EstimateCallCost("prompt", "gemini-2.5-flash-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lite.EstimatedCostUSD >= flash.EstimatedCostUSD {
+		t.Errorf("flash-lite cost %f not below flash cost %f", lite.EstimatedCostUSD, flash.EstimatedCostUSD)
+	}
+	if lite.EstimatedOutputTokens != 400_000 {
+		t.Errorf("output tokens = %d, want the default multiplier", lite.EstimatedOutputTokens)
+	}
+
+	if _, err := estimator.EstimateCallCost("prompt", "unknown-model"); !errors.Is(err, ErrUnknownModelPricing) {
+		t.Errorf("EstimateCallCost() = %v, want ErrUnknownModelPricing", err)
+	}
+}
+
+func TestEstimateCallCostApproximatesWhenCountingFails(t *testing.T) {
+	estimator := NewPromptCostEstimator(0, 0).WithTokenCounter(func(model, prompt string) (int, error) {
+		return 0, errors.New("countTokens unavailable")
+	})
+
+	estimate, err := estimator.EstimateCallCost("123456789", "gpt-4o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.InputTokens != 3 {
+		t.Errorf("input tokens = %d, want 9 characters rounded up to 3 tokens", estimate.InputTokens)
+	}
+}
+
+func TestDailyBudget(t *testing.T) {
+	estimator := NewPromptCostEstimator(1, 0).WithTokenCounter(fixedTokens(1_000_000))
+
+	estimator.RecordSpend(0.5)
+	estimator.RecordSpend(0.25)
+	if math.Abs(estimator.SpentToday()-0.75) > 1e-9 {
+		t.Errorf("SpentToday() = %f, want 0.75", estimator.SpentToday())
+	}
+	if estimator.WouldExceedDailyBudget(0.25) {
+		t.Error("spending up to the budget reported as exceeding it")
+	}
+
+	estimate, err := estimator.EstimateCallCost("prompt", "gemini-2.0-flash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !estimate.WillExceedDailyBudget {
+		t.Errorf("estimate of $%f with $0.75 spent doesn't exceed the $1 budget", estimate.EstimatedCostUSD)
+	}
+}
@@ -3,17 +3,21 @@ package llm
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 )
 
 // generatePromptID creates a unique identifier for tracking Gemini calls
@@ -31,15 +35,186 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// ErrRateLimited is returned when the Google AI API rejects a request due to quota exhaustion
+var ErrRateLimited = errors.New("rate limited by Google AI API")
+
+// ErrAllModelsExhausted is returned when every model in the fallback chain is rate limited
+var ErrAllModelsExhausted = errors.New("all models in fallback chain exhausted")
+
 // GoogleProvider implements the LLMProvider interface for Google AI
 type GoogleProvider struct {
-	model    string
-	apiCalls int64 // Counter for API calls
+	model         string
+	apiCalls      int64    // Counter for API calls
+	FallbackChain []string // Models to try in order when the primary model is rate limited
+
+	// Share one request between concurrent identical calls to this provider. The group is per provider so
+	// providers with different API transports or audit loggers never answer each other's calls.
+	SingleFlightEnabled bool
+	callGroup           singleflight.Group
+	deduplicatedCalls   int64 // Calls answered by another caller's in-flight request
+
+	cache     *ResponseCache // Ungrounded responses by model and prompt, nil when caching is disabled
+	ownsCache bool           // The cache was created by WithCache and is closed when replaced
+
+	transport http.RoundTripper // Sends API requests, nil for http.DefaultTransport
+
+	// Gemini batch API endpoint and how long CallBatch waits for a job to finish
+	batchAPIURL       string
+	batchPollInterval time.Duration
+	batchTimeout      time.Duration
+
+	// Audit logging of successful calls
+	auditLogger       AuditLogger
+	auditMu           sync.RWMutex
+	auditAgentID      string
+	auditAnalysisType string
+
+	// Token usage for the current day
+	tokensMu    sync.Mutex
+	tokensDay   string
+	tokensToday int64
+}
+
+// tokenUsage holds the token counts reported by the Google AI API
+type tokenUsage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// defaultGoogleFallbacks holds the fallback models applied to providers created via GetProvider
+var defaultGoogleFallbacks []string
+
+// SetDefaultGoogleFallbacks configures the fallback models used by providers created via GetProvider
+func SetDefaultGoogleFallbacks(models []string) {
+	defaultGoogleFallbacks = models
+}
+
+// defaultAuditLogger is the audit logger applied to providers created via GetProvider
+var defaultAuditLogger AuditLogger
+
+// SetDefaultAuditLogger configures the audit logger used by providers created via GetProvider
+func SetDefaultAuditLogger(logger AuditLogger) {
+	defaultAuditLogger = logger
 }
 
 // NewGoogleProvider creates a new Google provider
 func NewGoogleProvider(model string) *GoogleProvider {
-	return &GoogleProvider{model: model}
+	return &GoogleProvider{
+		model:               model,
+		SingleFlightEnabled: true,
+		batchAPIURL:         geminiAPIBaseURL,
+		batchPollInterval:   defaultBatchPollInterval,
+		batchTimeout:        defaultBatchTimeout,
+	}
+}
+
+// NewGoogleProviderWithTransport creates a Google provider that sends its API requests through
+// transport, such as a RecordingTransport in tests
+func NewGoogleProviderWithTransport(model string, transport http.RoundTripper) *GoogleProvider {
+	p := NewGoogleProvider(model)
+	p.transport = transport
+	return p
+}
+
+// httpClient returns the client API requests are sent with
+func (p *GoogleProvider) httpClient() *http.Client {
+	return &http.Client{Timeout: 60 * time.Second, Transport: p.transport}
+}
+
+// WithFallbacks sets the models to fall back to when the primary model is rate limited
+func (p *GoogleProvider) WithFallbacks(models ...string) *GoogleProvider {
+	p.FallbackChain = models
+	return p
+}
+
+// WithAuditLogger sets the audit logger that records every successful API call
+func (p *GoogleProvider) WithAuditLogger(logger AuditLogger) *GoogleProvider {
+	p.auditLogger = logger
+	return p
+}
+
+// SetAuditContext sets the agent and analysis type recorded with subsequent audit entries
+func (p *GoogleProvider) SetAuditContext(agentID, analysisType string) {
+	p.auditMu.Lock()
+	defer p.auditMu.Unlock()
+	p.auditAgentID = agentID
+	p.auditAnalysisType = analysisType
+}
+
+// recordAudit writes an audit entry for a successful API call
+func (p *GoogleProvider) recordAudit(promptID, model, prompt, response string, usage tokenUsage) {
+	if p.auditLogger == nil {
+		return
+	}
+
+	p.auditMu.RLock()
+	agentID, analysisType := p.auditAgentID, p.auditAnalysisType
+	p.auditMu.RUnlock()
+
+	entry := AuditEntry{
+		PromptID:     promptID,
+		Timestamp:    time.Now().UTC(),
+		Model:        model,
+		Provider:     "google",
+		PromptHash:   hashContent(prompt),
+		ResponseHash: hashContent(response),
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		AgentID:      agentID,
+		AnalysisType: analysisType,
+	}
+
+	if err := p.auditLogger.LogCall(entry); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"prompt_id": promptID,
+			"error":     err.Error(),
+		}).Error("❌ Failed to write Gemini audit log entry")
+	}
+}
+
+// recordTokens adds the call's token usage to today's total
+func (p *GoogleProvider) recordTokens(usage tokenUsage) {
+	p.tokensMu.Lock()
+	defer p.tokensMu.Unlock()
+
+	today := time.Now().Format("2006-01-02")
+	if p.tokensDay != today {
+		p.tokensDay = today
+		p.tokensToday = 0
+	}
+	p.tokensToday += int64(usage.InputTokens + usage.OutputTokens)
+}
+
+// TokensUsedToday returns the number of input and output tokens used since midnight
+func (p *GoogleProvider) TokensUsedToday() int64 {
+	p.tokensMu.Lock()
+	defer p.tokensMu.Unlock()
+
+	if p.tokensDay != time.Now().Format("2006-01-02") {
+		return 0
+	}
+	return p.tokensToday
+}
+
+// modelChain returns the primary model followed by the configured fallbacks
+func (p *GoogleProvider) modelChain() []string {
+	chain := []string{p.model}
+	for _, model := range p.FallbackChain {
+		if model != "" && model != p.model {
+			chain = append(chain, model)
+		}
+	}
+	return chain
+}
+
+// logFallback logs a switch from a rate limited model to the next model in the chain
+func (p *GoogleProvider) logFallback(fromModel, toModel string, reason error) {
+	logrus.WithFields(logrus.Fields{
+		"original_model": fromModel,
+		"target_model":   toModel,
+		"reason":         reason.Error(),
+		"timestamp":      time.Now().Format(time.RFC3339),
+	}).Warn("⚠️ Gemini model rate limited, falling back")
 }
 
 // GetAPICallCount returns the number of API calls made
@@ -47,8 +222,67 @@ func (p *GoogleProvider) GetAPICallCount() int64 {
 	return atomic.LoadInt64(&p.apiCalls)
 }
 
-// Call makes a request to the Google AI API
+// DeduplicatedCallCount returns the number of calls that shared another caller's in-flight request
+func (p *GoogleProvider) DeduplicatedCallCount() int64 {
+	return atomic.LoadInt64(&p.deduplicatedCalls)
+}
+
+// singleFlight runs call, sharing its result with concurrent callers making the same kind of request
+// with the same model and prompt when SingleFlightEnabled is set
+func (p *GoogleProvider) singleFlight(kind, model, prompt string, call func() (interface{}, error)) (interface{}, error) {
+	if !p.SingleFlightEnabled {
+		return call()
+	}
+
+	sum := sha256.Sum256([]byte(kind + "\x00" + model + "\x00" + prompt))
+	executed := false
+	result, err, _ := p.callGroup.Do(hex.EncodeToString(sum[:]), func() (interface{}, error) {
+		executed = true
+		return call()
+	})
+	if !executed {
+		atomic.AddInt64(&p.deduplicatedCalls, 1)
+		logrus.Debugf("Gemini %s call to %s shared an identical in-flight request", kind, model)
+	}
+	return result, err
+}
+
+// Call makes a request to the Google AI API, falling back through FallbackChain on rate limits
 func (p *GoogleProvider) Call(prompt string) (string, error) {
+	var cacheKey string
+	if p.cache != nil {
+		cacheKey = responseCacheKey(p.model, prompt)
+		if response, ok := p.cache.get(cacheKey); ok {
+			return response, nil
+		}
+	}
+
+	chain := p.modelChain()
+	for i, model := range chain {
+		result, err := p.singleFlight("generate", model, prompt, func() (interface{}, error) {
+			return p.callModel(model, prompt)
+		})
+		if err == nil {
+			// Only the primary model's answers are cached, since the cache is read for the primary model
+			// and a fallback model's answer would be served as its own once the rate limit has passed
+			if p.cache != nil && i == 0 {
+				p.cache.put(cacheKey, result.(string))
+			}
+			return result.(string), nil
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			return "", err
+		}
+		if i+1 < len(chain) {
+			p.logFallback(model, chain[i+1], err)
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrAllModelsExhausted, strings.Join(chain, ", "))
+}
+
+// callModel makes a request to the Google AI API using the given model
+func (p *GoogleProvider) callModel(model, prompt string) (string, error) {
 	// Generate unique prompt ID for tracking
 	promptID := generatePromptID()
 
@@ -59,7 +293,7 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 	// Log the prompt being sent to Gemini
 	logrus.WithFields(logrus.Fields{
 		"prompt_id":    promptID,
-		"model":        p.model,
+		"model":        model,
 		"call_number":  callNumber,
 		"prompt":       truncateString(prompt, 2000), // Truncate for Discord embed limits
 		"prompt_chars": len(prompt),
@@ -76,7 +310,7 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 	}
 
 	// Support for new Gemini models
-	modelName := p.model
+	modelName := model
 	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", modelName, apiKey)
 
 	payload := map[string]interface{}{
@@ -100,7 +334,7 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 	// Record start time for performance tracking
 	startTime := time.Now()
 
-	result, err := p.makeHTTPCallWithLogging(url, payload, map[string]string{
+	result, usage, err := p.makeHTTPCallWithLogging(url, payload, map[string]string{
 		"Content-Type": "application/json",
 	}, promptID, startTime)
 
@@ -108,7 +342,7 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 		// Log error response
 		logrus.WithFields(logrus.Fields{
 			"prompt_id":   promptID,
-			"model":       p.model,
+			"model":       model,
 			"call_number": callNumber,
 			"error":       err.Error(),
 			"duration_ms": time.Since(startTime).Milliseconds(),
@@ -120,7 +354,7 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 	// Log successful response
 	logrus.WithFields(logrus.Fields{
 		"prompt_id":      promptID,
-		"model":          p.model,
+		"model":          model,
 		"call_number":    callNumber,
 		"response":       truncateString(result, 2000), // Truncate for Discord embed limits
 		"response_chars": len(result),
@@ -128,14 +362,39 @@ func (p *GoogleProvider) Call(prompt string) (string, error) {
 		"timestamp":      time.Now().Format(time.RFC3339),
 	}).Info("✅ Gemini API Response")
 
+	p.recordAudit(promptID, model, prompt, result, usage)
+	p.recordTokens(usage)
+
 	// Log API call count for Gemini (keep existing behavior)
 	fmt.Printf("📊 Gemini API Call #%d completed (Prompt ID: %s)\n", callNumber, promptID)
 
 	return result, nil
 }
 
-// CallWithGrounding makes a request to the Google AI API with search grounding enabled
+// CallWithGrounding makes a request to the Google AI API with search grounding enabled,
+// falling back through FallbackChain on rate limits
 func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, error) {
+	chain := p.modelChain()
+	for i, model := range chain {
+		result, err := p.singleFlight("grounded", model, prompt, func() (interface{}, error) {
+			return p.callModelWithGrounding(model, prompt)
+		})
+		if err == nil {
+			return result.(*GroundedResponse), nil
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			return nil, err
+		}
+		if i+1 < len(chain) {
+			p.logFallback(model, chain[i+1], err)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAllModelsExhausted, strings.Join(chain, ", "))
+}
+
+// callModelWithGrounding makes a grounded request to the Google AI API using the given model
+func (p *GoogleProvider) callModelWithGrounding(model, prompt string) (*GroundedResponse, error) {
 	// Generate unique prompt ID for tracking
 	promptID := generatePromptID()
 
@@ -146,7 +405,7 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 	// Log the prompt being sent to Gemini with grounding
 	logrus.WithFields(logrus.Fields{
 		"prompt_id":    promptID,
-		"model":        p.model,
+		"model":        model,
 		"call_number":  callNumber,
 		"grounding":    true,
 		"prompt":       truncateString(prompt, 2000),
@@ -163,7 +422,7 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 		return nil, fmt.Errorf("GOOGLE_API_KEY not found")
 	}
 
-	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", p.model, apiKey)
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", model, apiKey)
 
 	payload := map[string]interface{}{
 		"contents": []map[string]interface{}{
@@ -194,7 +453,7 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 	// Record start time for performance tracking
 	startTime := time.Now()
 
-	result, err := p.makeHTTPCallWithGroundingLogging(url, payload, map[string]string{
+	result, usage, err := p.makeHTTPCallWithGroundingLogging(url, payload, map[string]string{
 		"Content-Type": "application/json",
 	}, promptID, startTime)
 
@@ -202,7 +461,7 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 		// Log error response
 		logrus.WithFields(logrus.Fields{
 			"prompt_id":   promptID,
-			"model":       p.model,
+			"model":       model,
 			"call_number": callNumber,
 			"grounding":   true,
 			"error":       err.Error(),
@@ -215,7 +474,7 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 	// Log successful response
 	logrus.WithFields(logrus.Fields{
 		"prompt_id":      promptID,
-		"model":          p.model,
+		"model":          model,
 		"call_number":    callNumber,
 		"grounding":      true,
 		"response_chars": len(result.Text),
@@ -224,6 +483,9 @@ func (p *GoogleProvider) CallWithGrounding(prompt string) (*GroundedResponse, er
 		"timestamp":      time.Now().Format(time.RFC3339),
 	}).Info("✅ Gemini API Response (grounded)")
 
+	p.recordAudit(promptID, model, prompt, result.Text, usage)
+	p.recordTokens(usage)
+
 	// Log API call count for Gemini
 	fmt.Printf("🔍 Gemini Grounded API Call #%d completed (Prompt ID: %s)\n", callNumber, promptID)
 
@@ -238,10 +500,10 @@ func (p *GoogleProvider) IsAvailable() bool {
 }
 
 // makeHTTPCallWithLogging is a helper function to make HTTP calls to the Google AI API with logging
-func (p *GoogleProvider) makeHTTPCallWithLogging(url string, payload map[string]interface{}, headers map[string]string, promptID string, startTime time.Time) (string, error) {
+func (p *GoogleProvider) makeHTTPCallWithLogging(url string, payload map[string]interface{}, headers map[string]string, promptID string, startTime time.Time) (string, tokenUsage, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", tokenUsage{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Log request details
@@ -254,23 +516,22 @@ func (p *GoogleProvider) makeHTTPCallWithLogging(url string, payload map[string]
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", tokenUsage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := p.httpClient().Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", tokenUsage{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", tokenUsage{}, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Log HTTP response details
@@ -291,10 +552,32 @@ func (p *GoogleProvider) makeHTTPCallWithLogging(url string, payload map[string]
 			"error_body":  truncateString(string(body), 1000),
 			"timestamp":   time.Now().Format(time.RFC3339),
 		}).Error("❌ Gemini HTTP Error Response")
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return "", tokenUsage{}, fmt.Errorf("%w: API request failed with status %d: %s", ErrRateLimited, resp.StatusCode, string(body))
+		}
+		return "", tokenUsage{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	text, err := p.extractResponseTextWithLogging(body, promptID)
+	return text, extractTokenUsage(body), err
+}
+
+// extractTokenUsage reads the token counts from the usageMetadata block of a Google AI API response
+func extractTokenUsage(body []byte) tokenUsage {
+	var response struct {
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return tokenUsage{}
 	}
 
-	return p.extractResponseTextWithLogging(body, promptID)
+	return tokenUsage{
+		InputTokens:  response.UsageMetadata.PromptTokenCount,
+		OutputTokens: response.UsageMetadata.CandidatesTokenCount,
+	}
 }
 
 // extractResponseTextWithLogging extracts the response text from Google AI API response with logging
@@ -343,10 +626,10 @@ func (p *GoogleProvider) extractResponseTextWithLogging(body []byte, promptID st
 }
 
 // makeHTTPCallWithGroundingLogging makes HTTP calls for grounded requests and extracts grounding metadata
-func (p *GoogleProvider) makeHTTPCallWithGroundingLogging(url string, payload map[string]interface{}, headers map[string]string, promptID string, startTime time.Time) (*GroundedResponse, error) {
+func (p *GoogleProvider) makeHTTPCallWithGroundingLogging(url string, payload map[string]interface{}, headers map[string]string, promptID string, startTime time.Time) (*GroundedResponse, tokenUsage, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, tokenUsage{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Log request details
@@ -360,23 +643,22 @@ func (p *GoogleProvider) makeHTTPCallWithGroundingLogging(url string, payload ma
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, tokenUsage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := p.httpClient().Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, tokenUsage{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, tokenUsage{}, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Log HTTP response details
@@ -399,10 +681,14 @@ func (p *GoogleProvider) makeHTTPCallWithGroundingLogging(url string, payload ma
 			"grounding":   true,
 			"timestamp":   time.Now().Format(time.RFC3339),
 		}).Error("❌ Gemini HTTP Error Response (grounded)")
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, tokenUsage{}, fmt.Errorf("%w: API request failed with status %d: %s", ErrRateLimited, resp.StatusCode, string(body))
+		}
+		return nil, tokenUsage{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	return p.extractGroundedResponseWithLogging(body, promptID)
+	result, err := p.extractGroundedResponseWithLogging(body, promptID)
+	return result, extractTokenUsage(body), err
 }
 
 // extractGroundedResponseWithLogging extracts text and grounding metadata from Gemini response
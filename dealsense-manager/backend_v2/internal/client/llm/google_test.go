@@ -0,0 +1,226 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is an http.RoundTripper calling a function
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// geminiResponse returns a generateContent response answering text
+func geminiResponse(req *http.Request, status int, text string) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"candidates": []map[string]interface{}{
+			{"content": map[string]interface{}{"parts": []map[string]string{{"text": text}}}},
+		},
+		"usageMetadata": map[string]int{"promptTokenCount": 10, "candidatesTokenCount": 5},
+	})
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestGoogleProviderSingleFlightIsPerProvider(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	release := make(chan struct{})
+	var entered sync.WaitGroup
+	entered.Add(2)
+	blockingTransport := func(calls *int64, answer string) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt64(calls, 1)
+			entered.Done()
+			<-release
+			return geminiResponse(req, http.StatusOK, answer), nil
+		})
+	}
+
+	var callsA, callsB int64
+	providerA := NewGoogleProviderWithTransport("gemini-test", blockingTransport(&callsA, "from A"))
+	providerB := NewGoogleProviderWithTransport("gemini-test", blockingTransport(&callsB, "from B"))
+
+	responses := make([]string, 2)
+	var done sync.WaitGroup
+	for i, provider := range []*GoogleProvider{providerA, providerB} {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			response, err := provider.Call("same prompt")
+			if err != nil {
+				t.Errorf("provider %d: %v", i, err)
+			}
+			responses[i] = response
+		}()
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		entered.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("identical calls to two providers were not both sent; the providers share in-flight requests")
+	}
+	close(release)
+	done.Wait()
+
+	if callsA != 1 || callsB != 1 {
+		t.Errorf("transport calls = %d, %d, want 1 each", callsA, callsB)
+	}
+	if responses[0] != "from A" || responses[1] != "from B" {
+		t.Errorf("responses = %q, want each provider's own answer", responses)
+	}
+	if providerA.DeduplicatedCallCount() != 0 || providerB.DeduplicatedCallCount() != 0 {
+		t.Error("calls to different providers were counted as deduplicated")
+	}
+}
+
+func TestGoogleProviderSharesConcurrentIdenticalCalls(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	release := make(chan struct{})
+	var calls int64
+	provider := NewGoogleProviderWithTransport("gemini-test", roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt64(&calls, 1)
+		<-release
+		return geminiResponse(req, http.StatusOK, "shared answer"), nil
+	}))
+
+	const callers = 10
+	var done sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			if response, err := provider.Call("same prompt"); err != nil || response != "shared answer" {
+				t.Errorf("Call() = %q, %v", response, err)
+			}
+		}()
+	}
+
+	// Hold the request until the other callers have had time to join it
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt64(&calls) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if calls != 1 {
+		t.Errorf("transport calls = %d, want 1", calls)
+	}
+	if provider.DeduplicatedCallCount() != callers-1 {
+		t.Errorf("DeduplicatedCallCount() = %d, want %d", provider.DeduplicatedCallCount(), callers-1)
+	}
+}
+
+func TestGoogleProviderFallbackChain(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary, second, third int64
+	calls := map[string]*int64{"gemini-primary": &primary, "gemini-second": &second, "gemini-third": &third}
+	provider := NewGoogleProviderWithTransport("gemini-primary",
+		countingTransport(calls, "third answer", "gemini-primary", "gemini-second")).
+		WithFallbacks("gemini-second", "", "gemini-primary", "gemini-third")
+
+	response, err := provider.Call("prompt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response != "third answer" {
+		t.Errorf("response = %q", response)
+	}
+	if primary != 1 || second != 1 || third != 1 {
+		t.Errorf("calls = %d, %d, %d, want each model tried once", primary, second, third)
+	}
+
+	exhausted := NewGoogleProviderWithTransport("gemini-primary", countingTransport(calls, "", "gemini-primary", "gemini-second")).
+		WithFallbacks("gemini-second")
+	if _, err := exhausted.Call("prompt"); !errors.Is(err, ErrAllModelsExhausted) {
+		t.Errorf("Call() = %v, want ErrAllModelsExhausted", err)
+	}
+}
+
+func TestGoogleProviderDoesNotFallBackOnOtherErrors(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	var primary, fallback int64
+	provider := NewGoogleProviderWithTransport("gemini-primary", roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "gemini-primary") {
+			atomic.AddInt64(&primary, 1)
+			return geminiResponse(req, http.StatusBadRequest, ""), nil
+		}
+		atomic.AddInt64(&fallback, 1)
+		return geminiResponse(req, http.StatusOK, "fallback answer"), nil
+	})).WithFallbacks("gemini-fallback")
+
+	if _, err := provider.Call("prompt"); err == nil || errors.Is(err, ErrRateLimited) {
+		t.Errorf("Call() = %v, want the request error", err)
+	}
+	if fallback != 0 {
+		t.Errorf("fallback calls = %d, want no fallback for a bad request", fallback)
+	}
+}
+
+func TestGoogleProviderWritesAuditEntries(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	path := filepath.Join(t.TempDir(), "audit", "llm.jsonl")
+	logger, err := NewFileAuditLogger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	provider := NewGoogleProviderWithTransport("gemini-test", roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return geminiResponse(req, http.StatusOK, "confidential answer"), nil
+	})).WithAuditLogger(logger)
+	provider.SetAuditContext("agent-1", "summary")
+
+	if _, err := provider.Call("confidential prompt"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "confidential") {
+		t.Error("audit log contains meeting content")
+	}
+	var entry AuditEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.PromptHash != hashContent("confidential prompt") || entry.ResponseHash != hashContent("confidential answer") {
+		t.Errorf("hashes = %s, %s", entry.PromptHash, entry.ResponseHash)
+	}
+	if entry.AgentID != "agent-1" || entry.AnalysisType != "summary" || entry.Model != "gemini-test" || entry.Provider != "google" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.InputTokens != 10 || entry.OutputTokens != 5 {
+		t.Errorf("tokens = %d, %d, want the reported usage", entry.InputTokens, entry.OutputTokens)
+	}
+}
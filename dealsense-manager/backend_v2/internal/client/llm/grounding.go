@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Default domain filters applied to grounding sources in addition to each agent's own
+var (
+	defaultGroundingBlacklist []string
+	defaultGroundingWhitelist []string
+)
+
+// SetDefaultGroundingDomains configures the grounding source domains filtered for every agent
+func SetDefaultGroundingDomains(blacklist, whitelist []string) {
+	defaultGroundingBlacklist = blacklist
+	defaultGroundingWhitelist = whitelist
+}
+
+// DefaultGroundingDomains returns the grounding source domain filters set by SetDefaultGroundingDomains
+func DefaultGroundingDomains() (blacklist, whitelist []string) {
+	return defaultGroundingBlacklist, defaultGroundingWhitelist
+}
+
+// FilterGroundingMetadata returns a copy of metadata without the grounding chunks from blacklisted domains
+// and, when a whitelist is set, without chunks from any other domain. Support chunk indices are renumbered
+// and supports left without any chunk are dropped. A domain also matches its subdomains.
+func FilterGroundingMetadata(metadata *GroundingMetadata, blacklist, whitelist []string) *GroundingMetadata {
+	if metadata == nil || (len(blacklist) == 0 && len(whitelist) == 0) {
+		return metadata
+	}
+
+	filtered := *metadata
+	filtered.GroundingChunks = nil
+	filtered.GroundingSupports = nil
+
+	// Map each kept chunk's original index to its new one
+	newIndex := make(map[int]int)
+	for i, chunk := range metadata.GroundingChunks {
+		domains := chunkDomains(chunk)
+		if matchesAnyDomain(domains, blacklist) || (len(whitelist) > 0 && !matchesAnyDomain(domains, whitelist)) {
+			continue
+		}
+		newIndex[i] = len(filtered.GroundingChunks)
+		filtered.GroundingChunks = append(filtered.GroundingChunks, chunk)
+	}
+
+	for _, support := range metadata.GroundingSupports {
+		var indices []int
+		for _, index := range support.GroundingChunkIndices {
+			if mapped, ok := newIndex[index]; ok {
+				indices = append(indices, mapped)
+			}
+		}
+		if len(indices) == 0 {
+			continue
+		}
+		support.GroundingChunkIndices = indices
+		filtered.GroundingSupports = append(filtered.GroundingSupports, support)
+	}
+
+	return &filtered
+}
+
+// chunkDomains returns the hosts a chunk may come from. Gemini often cites a redirect URI and puts the
+// source's domain in the title, so both are checked.
+func chunkDomains(chunk GroundingChunk) []string {
+	var domains []string
+	if parsed, err := url.Parse(chunk.Web.URI); err == nil && parsed.Hostname() != "" {
+		domains = append(domains, strings.ToLower(parsed.Hostname()))
+	}
+	if title := strings.ToLower(strings.TrimSpace(chunk.Web.Title)); title != "" && !strings.Contains(title, " ") {
+		domains = append(domains, title)
+	}
+	return domains
+}
+
+// matchesAnyDomain reports whether any of hosts is one of domains or a subdomain of one
+func matchesAnyDomain(hosts []string, domains []string) bool {
+	for _, host := range hosts {
+		for _, domain := range domains {
+			domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+			if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+				return true
+			}
+		}
+	}
+	return false
+}
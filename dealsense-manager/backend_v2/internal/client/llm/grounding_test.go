@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// groundingMetadata cites each chunk URI and title from its own support
+func groundingMetadata(chunks ...[2]string) *GroundingMetadata {
+	metadata := &GroundingMetadata{}
+	for i, source := range chunks {
+		var chunk GroundingChunk
+		chunk.Web.URI = source[0]
+		chunk.Web.Title = source[1]
+		metadata.GroundingChunks = append(metadata.GroundingChunks, chunk)
+
+		var support GroundingSupport
+		support.GroundingChunkIndices = []int{i}
+		metadata.GroundingSupports = append(metadata.GroundingSupports, support)
+	}
+	return metadata
+}
+
+func chunkURIs(metadata *GroundingMetadata) []string {
+	var uris []string
+	for _, chunk := range metadata.GroundingChunks {
+		uris = append(uris, chunk.Web.URI)
+	}
+	return uris
+}
+
+func TestFilterGroundingMetadataBlacklist(t *testing.T) {
+	metadata := groundingMetadata(
+		[2]string{"https://www.reddit.com/r/sales", "reddit.com"},
+		[2]string{"https://vertexaisearch.cloud.google.com/grounding-api-redirect/abc", "forum.reddit.com"},
+		[2]string{"https://acme.com/report", "acme.com"},
+	)
+
+	filtered := FilterGroundingMetadata(metadata, []string{"Reddit.com"}, nil)
+	if got := chunkURIs(filtered); !reflect.DeepEqual(got, []string{"https://acme.com/report"}) {
+		t.Errorf("chunks = %q, want subdomains and redirect titles of reddit.com removed", got)
+	}
+	if len(filtered.GroundingSupports) != 1 || !reflect.DeepEqual(filtered.GroundingSupports[0].GroundingChunkIndices, []int{0}) {
+		t.Errorf("supports = %+v, want the remaining support renumbered", filtered.GroundingSupports)
+	}
+	if len(metadata.GroundingChunks) != 3 {
+		t.Error("original metadata was modified")
+	}
+}
+
+func TestFilterGroundingMetadataWhitelist(t *testing.T) {
+	metadata := groundingMetadata(
+		[2]string{"https://investor.acme.com/report", "investor.acme.com"},
+		[2]string{"https://notacme.com/report", "notacme.com"},
+		[2]string{"https://sec.gov/filing", "sec.gov"},
+	)
+
+	filtered := FilterGroundingMetadata(metadata, nil, []string{"acme.com", ".sec.gov"})
+	want := []string{"https://investor.acme.com/report", "https://sec.gov/filing"}
+	if got := chunkURIs(filtered); !reflect.DeepEqual(got, want) {
+		t.Errorf("chunks = %q, want %q", got, want)
+	}
+	if len(filtered.GroundingSupports) != 2 || filtered.GroundingSupports[1].GroundingChunkIndices[0] != 1 {
+		t.Errorf("supports = %+v", filtered.GroundingSupports)
+	}
+}
+
+func TestFilterGroundingMetadataWithoutFilters(t *testing.T) {
+	metadata := groundingMetadata([2]string{"https://acme.com", "acme.com"})
+	if FilterGroundingMetadata(metadata, nil, nil) != metadata {
+		t.Error("metadata copied without any filter")
+	}
+	if FilterGroundingMetadata(nil, []string{"acme.com"}, nil) != nil {
+		t.Error("nil metadata not returned as is")
+	}
+}
@@ -1,6 +1,9 @@
 package llm
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // GroundedResponse represents a response with grounding information
 type GroundedResponse struct {
@@ -46,11 +49,42 @@ type GroundingCapableProvider interface {
 	CallWithGrounding(prompt string) (*GroundedResponse, error)
 }
 
+// FunctionDefinition describes a function the model may ask to call
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"` // JSON Schema of the arguments object
+}
+
+// FunctionCall is a call to one of the offered functions requested by the model
+type FunctionCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"` // JSON arguments object, as generated by the model
+}
+
+// FunctionCallingProvider extends LLMProvider with function calling
+type FunctionCallingProvider interface {
+	LLMProvider
+	CallWithFunctions(prompt string, functions []FunctionDefinition) (string, []FunctionCall, error)
+}
+
+// TokenUsageReporter is implemented by providers that track their token consumption
+type TokenUsageReporter interface {
+	TokensUsedToday() int64
+}
+
 // GetProvider returns the appropriate LLM provider based on configuration
 func GetProvider(providerType, model string) (LLMProvider, error) {
 	switch providerType {
 	case "google":
-		return NewGoogleProvider(model), nil
+		return NewGoogleProvider(model).
+			WithFallbacks(defaultGoogleFallbacks...).
+			WithAuditLogger(defaultAuditLogger).
+			WithResponseCache(defaultResponseCache), nil
+	case "anthropic":
+		return NewAnthropicGroundedProvider(model), nil
+	case "azure_openai":
+		return NewAzureOpenAIProvider(model), nil
 	default:
 		return nil, fmt.Errorf("unsupported LLM provider: %s", providerType)
 	}
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrMockExhausted is returned by a MockLLMProvider called more times than it has responses
+var ErrMockExhausted = errors.New("mock LLM provider has no more responses")
+
+// MockLLMProvider answers calls with canned responses so analysis steps can be tested without an API
+type MockLLMProvider struct {
+	// Respond answers each call when set; otherwise Responses are returned in order
+	Respond   func(prompt string) (string, error)
+	Responses []string
+	// Unavailable makes IsAvailable report false
+	Unavailable bool
+
+	mu      sync.Mutex
+	prompts []string
+}
+
+// NewMockLLMProvider creates a provider returning responses in order, one per call
+func NewMockLLMProvider(responses ...string) *MockLLMProvider {
+	return &MockLLMProvider{Responses: responses}
+}
+
+// Call records the prompt and returns the next canned response
+func (m *MockLLMProvider) Call(prompt string) (string, error) {
+	m.mu.Lock()
+	call := len(m.prompts)
+	m.prompts = append(m.prompts, prompt)
+	respond := m.Respond
+	var response string
+	exhausted := call >= len(m.Responses)
+	if !exhausted {
+		response = m.Responses[call]
+	}
+	m.mu.Unlock()
+
+	if respond != nil {
+		return respond(prompt)
+	}
+	if exhausted {
+		return "", ErrMockExhausted
+	}
+	return response, nil
+}
+
+// IsAvailable reports whether the provider can be called
+func (m *MockLLMProvider) IsAvailable() bool {
+	return !m.Unavailable
+}
+
+// Prompts returns the prompts the provider was called with, in order
+func (m *MockLLMProvider) Prompts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.prompts...)
+}
@@ -0,0 +1,34 @@
+package llm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMockLLMProviderReturnsResponsesInOrder(t *testing.T) {
+	var provider LLMProvider = NewMockLLMProvider("first", "second")
+
+	for _, want := range []string{"first", "second"} {
+		if got, err := provider.Call("prompt " + want); err != nil || got != want {
+			t.Fatalf("Call() = %q, %v, want %q", got, err, want)
+		}
+	}
+	if _, err := provider.Call("one more"); !errors.Is(err, ErrMockExhausted) {
+		t.Errorf("Call() after the responses = %v, want ErrMockExhausted", err)
+	}
+
+	prompts := provider.(*MockLLMProvider).Prompts()
+	if len(prompts) != 3 || prompts[0] != "prompt first" {
+		t.Errorf("Prompts() = %q", prompts)
+	}
+}
+
+func TestMockLLMProviderRespond(t *testing.T) {
+	provider := &MockLLMProvider{Respond: func(prompt string) (string, error) { return "echo: " + prompt, nil }}
+	if got, _ := provider.Call("hi"); got != "echo: hi" {
+		t.Errorf("Call() = %q", got)
+	}
+	if !provider.IsAvailable() {
+		t.Error("mock unavailable")
+	}
+}
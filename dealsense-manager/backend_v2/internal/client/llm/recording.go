@@ -0,0 +1,197 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecordingsDir is the directory cassettes are read from and written to
+const RecordingsDir = "recordings"
+
+// ErrCassetteNotFound is returned when replaying without a recorded cassette
+var ErrCassetteNotFound = errors.New("cassette not found")
+
+// ErrCassetteExhausted is returned when a replayed test makes more requests than were recorded
+var ErrCassetteExhausted = errors.New("no more recorded interactions in cassette")
+
+// RecordedRequest is the part of a request kept in a cassette. The API key is stripped from the URL and
+// headers are not recorded.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response kept in a cassette
+type RecordedResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordingTransport records HTTP interactions to recordings/{name}.json when RECORD=1 is set, and
+// replays them in order otherwise so provider tests run without network access or API keys
+type RecordingTransport struct {
+	path      string
+	recording bool
+	next      http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     int
+}
+
+// NewRecordingTransport creates a transport for the cassette named name, usually the test name. When
+// replaying, the cassette must exist; when recording, requests are sent with next (http.DefaultTransport
+// if nil) and the cassette is written by Save.
+func NewRecordingTransport(name string, next http.RoundTripper) (*RecordingTransport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &RecordingTransport{
+		path:      filepath.Join(RecordingsDir, filepath.Base(name)+".json"),
+		recording: os.Getenv("RECORD") == "1",
+		next:      next,
+	}
+	if t.recording {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s (run with RECORD=1 to record it)", ErrCassetteNotFound, t.path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette %s: %w", t.path, err)
+	}
+	if err := json.Unmarshal(data, &t.interactions); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", t.path, err)
+	}
+	return t, nil
+}
+
+// RoundTrip sends the request and records the interaction, or replays the next recorded interaction
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{Method: req.Method, URL: redactedURL(req.URL), Body: string(body)}
+
+	if t.recording {
+		return t.record(req, recorded)
+	}
+	return t.replay(req, recorded)
+}
+
+// record sends the request and keeps the interaction
+func (t *RecordingTransport) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        string(body),
+		},
+	}
+	t.mu.Lock()
+	t.interactions = append(t.interactions, interaction)
+	t.mu.Unlock()
+
+	return interaction.Response.httpResponse(req), nil
+}
+
+// replay returns the next recorded response, failing if the request doesn't match the one recorded
+func (t *RecordingTransport) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.replayed >= len(t.interactions) {
+		return nil, fmt.Errorf("%w: %s after %d requests", ErrCassetteExhausted, t.path, t.replayed)
+	}
+	interaction := t.interactions[t.replayed]
+	if interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+		return nil, fmt.Errorf("request %d to %s %s does not match cassette %s, which recorded %s %s", t.replayed+1,
+			recorded.Method, recorded.URL, t.path, interaction.Request.Method, interaction.Request.URL)
+	}
+	t.replayed++
+
+	return interaction.Response.httpResponse(req), nil
+}
+
+// Save writes the recorded interactions to the cassette; it does nothing when replaying
+func (t *RecordingTransport) Save() error {
+	if !t.recording {
+		return nil
+	}
+
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+	return os.WriteFile(t.path, append(data, '\n'), 0644)
+}
+
+// httpResponse builds the response returned to the client
+func (r RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+	return &http.Response{
+		StatusCode:    r.StatusCode,
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(r.Body))),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// redactedURL returns the request URL without the API key query parameter
+func redactedURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	query.Del("key")
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
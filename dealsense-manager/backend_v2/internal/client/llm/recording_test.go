@@ -0,0 +1,145 @@
+package llm
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// replayProvider creates a provider replaying the named cassette
+func replayProvider(t *testing.T, name string) *GoogleProvider {
+	t.Helper()
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	t.Setenv("RECORD", "")
+
+	transport, err := NewRecordingTransport(name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGoogleProviderWithTransport("gemini-2.5-flash", transport)
+}
+
+func TestReplaySummarySuccess(t *testing.T) {
+	provider := replayProvider(t, "summary_success")
+
+	response, err := provider.Call("Summarize this meeting transcript:\n" +
+		"[10:00:05] Maya: Legal still needs to sign off on the enterprise tier copy.\n" +
+		"[10:00:21] Sam: Then let's ship the pricing page on Friday once that's done.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(response, "ship the pricing page redesign on Friday") {
+		t.Errorf("response = %q", response)
+	}
+	if provider.TokensUsedToday() != 450 {
+		t.Errorf("TokensUsedToday() = %d, want the recorded usage of 450", provider.TokensUsedToday())
+	}
+}
+
+func TestReplayRateLimitFallsBack(t *testing.T) {
+	provider := replayProvider(t, "rate_limit_retry").WithFallbacks("gemini-2.0-flash")
+
+	response, err := provider.Call("Summarize this meeting transcript:\n" +
+		"[10:00:05] Maya: Legal still needs to sign off on the enterprise tier copy.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(response, "enterprise tier copy") {
+		t.Errorf("response = %q", response)
+	}
+	if provider.GetAPICallCount() != 2 {
+		t.Errorf("API calls = %d, want the rate limited call and the fallback", provider.GetAPICallCount())
+	}
+}
+
+func TestReplayRateLimitWithoutFallback(t *testing.T) {
+	provider := replayProvider(t, "rate_limit_retry")
+
+	_, err := provider.Call("Summarize this meeting transcript:\n" +
+		"[10:00:05] Maya: Legal still needs to sign off on the enterprise tier copy.")
+	if !errors.Is(err, ErrAllModelsExhausted) {
+		t.Errorf("Call() = %v, want ErrAllModelsExhausted", err)
+	}
+}
+
+func TestReplayGroundedCitations(t *testing.T) {
+	provider := replayProvider(t, "grounded_citations")
+
+	response, err := provider.CallWithGrounding("What was Acme Corp's revenue in fiscal 2024, and who does its CRM compete with?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := response.GroundingMetadata
+	if metadata == nil || len(metadata.GroundingChunks) != 2 || len(metadata.GroundingSupports) != 2 {
+		t.Fatalf("grounding metadata = %+v, want 2 chunks and 2 supports", metadata)
+	}
+	if len(metadata.WebSearchQueries) != 2 {
+		t.Errorf("search queries = %q", metadata.WebSearchQueries)
+	}
+
+	cited := addCitations(response.Text, metadata)
+	if !strings.Contains(cited, "fiscal 2024. [1](https://vertexaisearch.cloud.google.com/grounding-api-redirect/acme-annual-report)") {
+		t.Errorf("cited text = %q, want the first sentence cited", cited)
+	}
+	if !strings.HasSuffix(cited, "Salesforce. [2](https://vertexaisearch.cloud.google.com/grounding-api-redirect/crm-market-overview)") {
+		t.Errorf("cited text = %q, want the second sentence cited", cited)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	t.Setenv("RECORD", "")
+	if _, err := NewRecordingTransport("no_such_cassette", nil); !errors.Is(err, ErrCassetteNotFound) {
+		t.Errorf("NewRecordingTransport() = %v, want ErrCassetteNotFound", err)
+	}
+}
+
+func TestReplayRejectsUnrecordedRequests(t *testing.T) {
+	provider := replayProvider(t, "summary_success")
+
+	if _, err := provider.Call("A prompt that was never recorded"); err != nil {
+		t.Fatalf("first call = %v, want the recorded response regardless of the prompt", err)
+	}
+	if _, err := provider.Call("Another prompt"); !errors.Is(err, ErrCassetteExhausted) {
+		t.Errorf("second call = %v, want ErrCassetteExhausted", err)
+	}
+
+	mismatched := replayProvider(t, "summary_success")
+	mismatched.model = "gemini-1.5-pro"
+	if _, err := mismatched.Call("prompt"); err == nil || !strings.Contains(err.Error(), "does not match cassette") {
+		t.Errorf("call to another model = %v, want a cassette mismatch", err)
+	}
+}
+
+func TestRecordingWritesCassetteWithoutAPIKey(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("RECORD", "1")
+	t.Setenv("GOOGLE_API_KEY", "secret-key")
+
+	transport, err := NewRecordingTransport("recorded", roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return geminiResponse(req, http.StatusOK, "recorded answer"), nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewGoogleProviderWithTransport("gemini-2.5-flash", transport)
+	if response, err := provider.Call("prompt"); err != nil || response != "recorded answer" {
+		t.Fatalf("Call() = %q, %v", response, err)
+	}
+	if err := transport.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(RecordingsDir, "recorded.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Error("cassette contains the API key")
+	}
+	if !strings.Contains(string(data), "recorded answer") {
+		t.Errorf("cassette = %s, want the recorded response", data)
+	}
+}
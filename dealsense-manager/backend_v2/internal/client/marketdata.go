@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/marketdata"
+)
+
+const (
+	// marketQuoteTTL is how long a fetched quote is reused within an analysis run
+	marketQuoteTTL = 60 * time.Second
+	// maxMarketTickers bounds the number of quotes fetched per summary
+	maxMarketTickers = 10
+)
+
+// tickerPatterns find stock tickers mentioned as "$AAPL" or "NASDAQ: AAPL" style references
+var tickerPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\$([A-Z]{1,5}(?:\.[A-Z])?)\b`),
+	regexp.MustCompile(`\b(?:NASDAQ|NYSE|AMEX|ticker)[:\s]+([A-Z]{1,5}(?:\.[A-Z])?)\b`),
+}
+
+// SetMarketDataProvider sets the provider used to enrich summaries with live quotes
+func (a *AnalystAgent) SetMarketDataProvider(provider marketdata.MarketDataProvider) {
+	a.marketData = provider
+}
+
+// enrichSummaryWithMarketData appends live quotes for stocks mentioned in the meeting to the summary
+func (a *AnalystAgent) enrichSummaryWithMarketData(transcript []TranscriptEntry) {
+	if !a.config.EnableMarketDataEnrichment || a.marketData == nil {
+		return
+	}
+
+	var text strings.Builder
+	for _, entry := range transcript {
+		text.WriteString(entry.Text)
+		text.WriteString("\n")
+	}
+	text.WriteString(a.data.Summary)
+
+	tickers := extractTickers(text.String())
+	if len(tickers) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, ticker := range tickers {
+		quote, err := a.marketData.GetQuote(ticker)
+		if err != nil {
+			logrus.Warnf("Agent %s: Failed to fetch market data for %s: %v", a.agentID, ticker, err)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %.2f %s (%+.2f%%) as of %s",
+			quote.Ticker, quote.Price, quote.Currency, quote.ChangePercent(), quote.AsOf.Format("2006-01-02 15:04 MST")))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	a.data.Summary += "\n\nMarket Data at Time of Meeting:\n" + strings.Join(lines, "\n")
+	logrus.Infof("Agent %s: Added market data for %d tickers to summary", a.agentID, len(lines))
+}
+
+// extractTickers returns the distinct stock tickers referenced in the text, sorted
+func extractTickers(text string) []string {
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, pattern := range tickerPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+			ticker := match[1]
+			if !seen[ticker] {
+				seen[ticker] = true
+				tickers = append(tickers, ticker)
+			}
+		}
+	}
+
+	sort.Strings(tickers)
+	if len(tickers) > maxMarketTickers {
+		tickers = tickers[:maxMarketTickers]
+	}
+	return tickers
+}
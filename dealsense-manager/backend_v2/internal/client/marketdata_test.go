@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/marketdata"
+)
+
+// fixedQuotes is a market data provider with a fixed set of quotes
+type fixedQuotes map[string]*marketdata.MarketQuote
+
+func (q fixedQuotes) GetQuote(ticker string) (*marketdata.MarketQuote, error) {
+	if quote, ok := q[ticker]; ok {
+		return quote, nil
+	}
+	return nil, errors.New("unknown ticker")
+}
+
+func TestExtractTickers(t *testing.T) {
+	text := "We compared $AAPL with NASDAQ: MSFT, and $BRK.B came up. $AAPL again, but not $toolong or apple."
+	want := []string{"AAPL", "BRK.B", "MSFT"}
+	if got := extractTickers(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("extractTickers() = %v, want %v", got, want)
+	}
+}
+
+func TestSummaryIsEnrichedWithMarketData(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.EnableMarketDataEnrichment = true
+	analyst.SetMarketDataProvider(fixedQuotes{
+		"AAPL": {Ticker: "AAPL", Price: 110, PreviousClose: 100, Currency: "USD", AsOf: testMeetingStart},
+	})
+	analyst.data.Summary = "The team discussed their holdings."
+
+	analyst.enrichSummaryWithMarketData([]TranscriptEntry{
+		entryAt(0, "Alice", "Our position in $AAPL is up, $ZZZZ is unknown."),
+	})
+
+	want := "The team discussed their holdings.\n\nMarket Data at Time of Meeting:\n- AAPL: 110.00 USD (+10.00%) as of 2024-05-01 10:00 UTC"
+	if analyst.data.Summary != want {
+		t.Errorf("summary = %q, want %q", analyst.data.Summary, want)
+	}
+}
+
+func TestMarketDataEnrichmentDisabled(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.SetMarketDataProvider(fixedQuotes{"AAPL": {Ticker: "AAPL", AsOf: time.Now()}})
+	analyst.data.Summary = "Summary"
+
+	analyst.enrichSummaryWithMarketData([]TranscriptEntry{entryAt(0, "Alice", "We hold $AAPL.")})
+	if strings.Contains(analyst.data.Summary, "Market Data") {
+		t.Errorf("summary = %q, want no market data without EnableMarketDataEnrichment", analyst.data.Summary)
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultMeetingTypes are the meeting types classified into when the agent config sets no options
+var defaultMeetingTypes = []string{
+	"standup", "retrospective", "sales_call", "design_review", "sprint_planning", "one_on_one",
+	"interview", "product_demo", "customer_support", "all_hands", "brainstorming", "status_update",
+}
+
+// defaultMeetingTypeKeywords are the phrases the rule-based classifier looks for in each default type
+var defaultMeetingTypeKeywords = map[string][]string{
+	"standup":          {"standup", "stand-up", "yesterday", "today", "blocker", "blocked"},
+	"retrospective":    {"retrospective", "retro", "went well", "went wrong", "improve", "lessons learned"},
+	"sales_call":       {"pricing", "discount", "contract", "proposal", "budget", "decision maker", "quote"},
+	"design_review":    {"design", "mockup", "wireframe", "architecture", "ux", "prototype"},
+	"sprint_planning":  {"sprint", "backlog", "story points", "estimate", "roadmap", "capacity"},
+	"one_on_one":       {"one on one", "1:1", "career", "feedback", "growth", "personal goals"},
+	"interview":        {"interview", "candidate", "resume", "experience with", "hiring", "role"},
+	"product_demo":     {"demo", "walkthrough", "feature", "let me show", "screen share", "trial"},
+	"customer_support": {"issue", "ticket", "bug", "error", "workaround", "escalate", "not working"},
+	"all_hands":        {"all hands", "all-hands", "company", "announcement", "quarter", "everyone"},
+	"brainstorming":    {"brainstorm", "ideas", "what if", "options", "alternatives", "explore"},
+	"status_update":    {"status", "update", "progress", "on track", "milestone", "deadline"},
+}
+
+// RuleBasedClassifier categorizes a meeting by counting each type's keywords in its summary and keywords
+type RuleBasedClassifier struct {
+	types    []string
+	keywords map[string][]string
+}
+
+// NewRuleBasedClassifier creates a classifier for the given types, defaulting to the built-in types.
+// keywords replaces the built-in keyword list of the types it has an entry for; the type's name, with
+// underscores as spaces, is always one of its keywords.
+func NewRuleBasedClassifier(types []string, keywords map[string][]string) *RuleBasedClassifier {
+	if len(types) == 0 {
+		types = defaultMeetingTypes
+	}
+	merged := make(map[string][]string, len(types))
+	for _, meetingType := range types {
+		typeKeywords, ok := keywords[meetingType]
+		if !ok {
+			typeKeywords = defaultMeetingTypeKeywords[meetingType]
+		}
+		merged[meetingType] = append([]string{strings.ReplaceAll(meetingType, "_", " ")}, typeKeywords...)
+	}
+	return &RuleBasedClassifier{types: types, keywords: merged}
+}
+
+// Classify returns the type whose keywords match most often as whole words, with its share of all
+// matches as the confidence. It returns "" and 0 when no keyword matches; ties go to the type listed first.
+func (c *RuleBasedClassifier) Classify(summary string, keywords []string) (string, float64) {
+	text := strings.ToLower(summary + "\n" + strings.Join(keywords, "\n"))
+
+	best, bestHits, totalHits := "", 0, 0
+	for _, meetingType := range c.types {
+		hits := 0
+		for _, keyword := range c.keywords[meetingType] {
+			if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+				hits += len(regexp.MustCompile(`\b`+regexp.QuoteMeta(keyword)+`\b`).FindAllStringIndex(text, -1))
+			}
+		}
+		totalHits += hits
+		if hits > bestHits {
+			best, bestHits = meetingType, hits
+		}
+	}
+	if bestHits == 0 {
+		return "", 0
+	}
+	return best, float64(bestHits) / float64(totalHits)
+}
+
+// classifyMeetingType sets the meeting type from the summary and keywords, asking the LLM when LLM
+// classification is enabled and falling back to keyword matching when it is disabled or fails
+func (a *AnalystAgent) classifyMeetingType(ctx context.Context) {
+	a.dataMutex.RLock()
+	summary := a.data.Summary
+	keywords := append([]string{}, a.data.Keywords...)
+	a.dataMutex.RUnlock()
+	if summary == "" && len(keywords) == 0 {
+		return
+	}
+
+	options := a.config.MeetingTypeOptions
+	if len(options) == 0 {
+		options = defaultMeetingTypes
+	}
+
+	meetingType, confidence := "", 0.0
+	if a.config.EnableLLMClassification {
+		var err error
+		if meetingType, confidence, err = a.classifyMeetingTypeWithLLM(ctx, summary, keywords, options); err != nil {
+			logrus.Warnf("Agent %s: LLM meeting type classification failed, using keyword matching: %v", a.agentID, err)
+		}
+	}
+	if meetingType == "" {
+		meetingType, confidence = NewRuleBasedClassifier(options, a.config.MeetingTypeKeywords).Classify(summary, keywords)
+	}
+
+	a.dataMutex.Lock()
+	a.data.MeetingType = meetingType
+	a.data.MeetingTypeConfidence = confidence
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Classified meeting as %q (confidence %.2f)", a.agentID, meetingType, confidence)
+}
+
+// classifyMeetingTypeWithLLM asks the LLM which of the options the meeting is, returning an error when
+// it answers with a type that isn't one of them
+func (a *AnalystAgent) classifyMeetingTypeWithLLM(ctx context.Context, summary string, keywords, options []string) (string, float64, error) {
+	prompt := fmt.Sprintf(`Classify this meeting as exactly one of these meeting types: %s
+
+Meeting summary:
+%s
+
+Keywords: %s
+
+Rate your confidence from 0 to 1, lower when the meeting fits several types or none well.
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "meeting_type": "one of the meeting types",
+  "confidence": 0.8
+}
+`+"`"+``, strings.Join(options, ", "), summary, strings.Join(keywords, ", "))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return "", 0, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return "", 0, fmt.Errorf("no JSON in meeting type response")
+	}
+
+	var result struct {
+		MeetingType string  `json:"meeting_type"`
+		Confidence  float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return "", 0, fmt.Errorf("failed to parse meeting type JSON: %w", err)
+	}
+
+	for _, option := range options {
+		if strings.EqualFold(strings.TrimSpace(result.MeetingType), option) {
+			return option, min(max(result.Confidence, 0), 1), nil
+		}
+	}
+	return "", 0, fmt.Errorf("meeting type %q is not one of the options", result.MeetingType)
+}
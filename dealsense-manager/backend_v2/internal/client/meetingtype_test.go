@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestRuleBasedClassifier(t *testing.T) {
+	classifier := NewRuleBasedClassifier(nil, nil)
+
+	meetingType, confidence := classifier.Classify("Daily standup: yesterday the API shipped, today tests, one blocker on review", nil)
+	if meetingType != "standup" || !approx(confidence, 1) {
+		t.Errorf("Classify() = %q, %v, want standup with full confidence", meetingType, confidence)
+	}
+
+	// Three retrospective hits (its name, the keyword and "went well") against one sprint planning hit
+	meetingType, confidence = classifier.Classify("Sprint retrospective", []string{"went well"})
+	if meetingType != "retrospective" || !approx(confidence, 0.75) {
+		t.Errorf("Classify() = %q, %v, want retrospective at 0.75", meetingType, confidence)
+	}
+
+	// Keywords only match whole words
+	if meetingType, confidence := classifier.Classify("Redesigned nothing", []string{"statuses"}); meetingType != "" || confidence != 0 {
+		t.Errorf("Classify() = %q, %v, want no match", meetingType, confidence)
+	}
+}
+
+func TestRuleBasedClassifierCustomTypes(t *testing.T) {
+	classifier := NewRuleBasedClassifier(
+		[]string{"board_meeting", "standup"},
+		map[string][]string{"board_meeting": {"investors", "runway"}},
+	)
+
+	if meetingType, _ := classifier.Classify("Runway update for the investors", nil); meetingType != "board_meeting" {
+		t.Errorf("Classify() = %q, want board_meeting from its custom keywords", meetingType)
+	}
+	// The type's name is always a keyword, and ties go to the type listed first
+	if meetingType, confidence := classifier.Classify("Board meeting about a blocker", nil); meetingType != "board_meeting" || !approx(confidence, 0.5) {
+		t.Errorf("Classify() = %q, %v, want board_meeting at 0.5", meetingType, confidence)
+	}
+}
+
+func TestClassifyMeetingTypeWithLLM(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.EnableLLMClassification = true
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n{\"meeting_type\": \"Design_Review\", \"confidence\": 1.4}\n```")
+	analyst.data.Summary = "Walked through the new checkout mockups"
+
+	analyst.classifyMeetingType(context.Background())
+	analysis := analyst.GetAnalysis()
+	if analysis.MeetingType != "design_review" || analysis.MeetingTypeConfidence != 1 {
+		t.Errorf("meeting type = %q, %v, want design_review clamped to 1", analysis.MeetingType, analysis.MeetingTypeConfidence)
+	}
+}
+
+func TestClassifyMeetingTypeFallsBackToKeywords(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.EnableLLMClassification = true
+	analyst.config.MeetingTypeOptions = []string{"sales_call", "interview"}
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n{\"meeting_type\": \"standup\", \"confidence\": 0.9}\n```")
+	analyst.data.Summary = "Discussed pricing and a discount on the contract"
+
+	analyst.classifyMeetingType(context.Background())
+	analysis := analyst.GetAnalysis()
+	if analysis.MeetingType != "sales_call" || !approx(analysis.MeetingTypeConfidence, 1) {
+		t.Errorf("meeting type = %q, %v, want sales_call from keywords", analysis.MeetingType, analysis.MeetingTypeConfidence)
+	}
+}
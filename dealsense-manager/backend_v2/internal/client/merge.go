@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/migration"
+)
+
+// mergeDuplicateWindow is how close in time two entries with the same speaker and text must be to be
+// treated as the same utterance captured by both agents
+const mergeDuplicateWindow = 500 * time.Millisecond
+
+// MergeAnalysis combines the transcripts of two agents that joined the same meeting and re-runs the
+// analysis over the result. Neither agent's analysis is modified; the merged analysis is saved to its
+// own file.
+func (a *AnalystAgent) MergeAnalysis(other *AnalystAgent) (*AnalysisData, error) {
+	if other == nil || other == a {
+		return nil, errors.New("merge requires two different analyst agents")
+	}
+
+	first, second := a.transcriptCopy(), other.transcriptCopy()
+	transcript := mergeTranscripts(first, second)
+	if len(transcript) == 0 {
+		return nil, errors.New("no transcript to merge")
+	}
+
+	logrus.Infof("Merging analysis of agents %s and %s: %d + %d entries, %d after removing duplicates",
+		a.agentID, other.agentID, len(first), len(second), len(transcript))
+
+	a.dataMutex.RLock()
+	meetingURL, tenantID := a.data.MeetingURL, a.data.TenantID
+	a.dataMutex.RUnlock()
+
+	mergedID := fmt.Sprintf("merged_%s_%s", a.agentID, other.agentID)
+	participants := []string{}
+	seen := make(map[string]bool)
+	for _, entry := range transcript {
+		if !entry.IsAgent && !seen[entry.Speaker] {
+			seen[entry.Speaker] = true
+			participants = append(participants, entry.Speaker)
+		}
+	}
+
+	merged := a.newDerivedAgent(mergedID, a.config, &AnalysisData{
+		SchemaVersion: migration.CurrentSchemaVersion,
+		MeetingID:     mergedID,
+		TenantID:      tenantID,
+		MeetingURL:    meetingURL,
+		StartTime:     transcript[0].Timestamp,
+		LastUpdated:   time.Now(),
+		Transcript:    transcript,
+		KeyPoints:     []string{},
+		ActionItems:   []ActionItem{},
+		Topics:        []TopicDiscussion{},
+		Participants:  participants,
+	})
+	merged.filePath = filepath.Join(AnalysisDataDir, fmt.Sprintf("meeting_analysis_%s_%d.json", mergedID, time.Now().Unix()))
+	merged.backend = a.backend
+
+	if err := merged.runAnalysis(WindowAll); err != nil {
+		return nil, fmt.Errorf("failed to analyze merged transcript: %w", err)
+	}
+
+	return merged.GetAnalysis(), nil
+}
+
+// transcriptCopy returns a copy of the agent's transcript
+func (a *AnalystAgent) transcriptCopy() []TranscriptEntry {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return append([]TranscriptEntry{}, a.data.Transcript...)
+}
+
+// mergeTranscripts interleaves two transcripts by timestamp, dropping entries with the same speaker and
+// text as an entry less than mergeDuplicateWindow earlier
+func mergeTranscripts(first, second []TranscriptEntry) []TranscriptEntry {
+	combined := append(append(make([]TranscriptEntry, 0, len(first)+len(second)), first...), second...)
+	sort.SliceStable(combined, func(i, j int) bool {
+		return combined[i].Timestamp.Before(combined[j].Timestamp)
+	})
+
+	merged := make([]TranscriptEntry, 0, len(combined))
+	for _, entry := range combined {
+		if !isMergeDuplicate(merged, entry) {
+			merged = append(merged, entry)
+		}
+	}
+	return merged
+}
+
+// isMergeDuplicate reports whether entry repeats one of the latest merged entries within the window
+func isMergeDuplicate(merged []TranscriptEntry, entry TranscriptEntry) bool {
+	for i := len(merged) - 1; i >= 0; i-- {
+		previous := merged[i]
+		if entry.Timestamp.Sub(previous.Timestamp) >= mergeDuplicateWindow {
+			return false
+		}
+		if previous.Speaker == entry.Speaker && previous.Text == entry.Text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeTranscripts(t *testing.T) {
+	first := []TranscriptEntry{
+		entryAt(0, "Alice", "Welcome everyone"),
+		entryAt(10, "Bob", "Thanks for having me"),
+		entryAt(30, "Alice", "Let's start"),
+	}
+	second := []TranscriptEntry{
+		entryAt(0, "Alice", "Welcome everyone"), // Captured by both agents
+		{Timestamp: testMeetingStart.Add(10*time.Second + 300*time.Millisecond), Speaker: "Bob", Text: "Thanks for having me"},
+		entryAt(20, "Carol", "Sorry I'm late"),
+		entryAt(31, "Alice", "Let's start"), // Said again a second later, not a duplicate
+	}
+
+	merged := mergeTranscripts(first, second)
+	var lines []string
+	for _, entry := range merged {
+		lines = append(lines, entry.Speaker+": "+entry.Text)
+	}
+	want := []string{
+		"Alice: Welcome everyone",
+		"Bob: Thanks for having me",
+		"Carol: Sorry I'm late",
+		"Alice: Let's start",
+		"Alice: Let's start",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("merged = %q, want %q", lines, want)
+	}
+	for i := 1; i < len(merged); i++ {
+		if merged[i].Timestamp.Before(merged[i-1].Timestamp) {
+			t.Errorf("entry %d is out of order", i)
+		}
+	}
+
+	words := func(entries []TranscriptEntry) int {
+		count := 0
+		for _, entry := range entries {
+			count += len(strings.Fields(entry.Text))
+		}
+		return count
+	}
+	// Each agent captured the two duplicated utterances, 6 words in all
+	if got, want := words(merged), words(first)+words(second)-6; got != want {
+		t.Errorf("merged word count = %d, want the sum minus duplicates, %d", got, want)
+	}
+}
+
+func TestMergeAnalysisRequiresTwoAgents(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if _, err := analyst.MergeAnalysis(analyst); err == nil {
+		t.Error("agent merged with itself")
+	}
+	if _, err := analyst.MergeAnalysis(nil); err == nil {
+		t.Error("agent merged with nil")
+	}
+}
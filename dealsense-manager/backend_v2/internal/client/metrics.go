@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	metricsTranscript  = 50
+	maxVerifiedMetrics = 5
+)
+
+// MetricMention is a number stated in the meeting, such as a revenue figure, percentage or count
+type MetricMention struct {
+	Value      float64 `json:"value"`
+	Unit       string  `json:"unit,omitempty"` // Currency symbol, "%", or a unit word such as "users"
+	MetricName string  `json:"metric_name"`
+	Speaker    string  `json:"speaker"`
+	Context    string  `json:"context"`  // The statement the number appeared in
+	Verified   bool    `json:"verified"` // Confirmed by search results
+}
+
+// metricNumber matches a number with an optional leading currency symbol, thousands separators, a
+// scale suffix and a trailing percent sign or unit, e.g. "$3,000", "1.2M", "45%" or "12 million users"
+var metricNumber = regexp.MustCompile(`(?i)^([$€£¥])?\s*(-?\d+(?:,\d{3})*(?:\.\d+)?|-?\.\d+)\s*(k|m|mm|b|bn|t|thousand|million|billion|trillion)?\b\s*(%|percent\b)?\s*(.*)$`)
+
+// metricScales maps scale suffixes to multipliers
+var metricScales = map[string]float64{
+	"k": 1e3, "thousand": 1e3,
+	"m": 1e6, "mm": 1e6, "million": 1e6,
+	"b": 1e9, "bn": 1e9, "billion": 1e9,
+	"t": 1e12, "trillion": 1e12,
+}
+
+// extractMetrics finds quantitative claims in the transcript, checking the most important ones against
+// search results when the provider supports grounding
+func (a *AnalystAgent) extractMetrics(ctx context.Context) error {
+	transcript := a.getRecentTranscript(metricsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting metrics with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("metrics",
+		`Find every quantitative data point stated in this meeting transcript: revenue and cost figures, growth rates and other percentages, counts of customers, users or units, prices, and target dates or deadlines.
+
+Rules:
+- Copy each number exactly as it was said, including currency symbols, scale words and percent signs (for example "$3,000", "1.2M", "45%", "12 million")
+- Give the metric a short name describing what was measured
+- Order the metrics from most to least significant to the discussion
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "metrics": [
+    {
+      "value": "Number as stated, e.g. $1.2M",
+      "unit": "What is counted when not a currency or percentage, e.g. users",
+      "metric_name": "Q3 revenue",
+      "speaker": "Speaker name",
+      "context": "The statement the number appeared in"
+    }
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Failed to extract metrics: %v", err)
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		Metrics []struct {
+			Value      string `json:"value"`
+			Unit       string `json:"unit"`
+			MetricName string `json:"metric_name"`
+			Speaker    string `json:"speaker"`
+			Context    string `json:"context"`
+		} `json:"metrics"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse metrics JSON: %w", err)
+	}
+
+	metrics := []MetricMention{}
+	for _, raw := range result.Metrics {
+		value, unit, ok := parseMetricValue(raw.Value)
+		if !ok || strings.TrimSpace(raw.MetricName) == "" {
+			continue
+		}
+		if unit == "" {
+			unit = strings.TrimSpace(raw.Unit)
+		}
+		metrics = append(metrics, MetricMention{
+			Value:      value,
+			Unit:       unit,
+			MetricName: strings.TrimSpace(raw.MetricName),
+			Speaker:    strings.TrimSpace(raw.Speaker),
+			Context:    strings.TrimSpace(raw.Context),
+		})
+	}
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok && len(metrics) > 0 {
+		if err := a.verifyMetrics(ctx, groundingProvider, metrics[:min(len(metrics), maxVerifiedMetrics)]); err != nil {
+			logrus.Warnf("Agent %s: Failed to verify metrics: %v", a.agentID, err)
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.KeyMetrics = metrics
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Extracted %d metrics", a.agentID, len(metrics))
+	return nil
+}
+
+// verifyMetrics asks a grounded model which metrics are confirmed by search results, setting Verified
+// on those. Internal figures that can't be found publicly stay unverified.
+func (a *AnalystAgent) verifyMetrics(ctx context.Context, provider llm.GroundingCapableProvider, metrics []MetricMention) error {
+	lines := make([]string, len(metrics))
+	for i, metric := range metrics {
+		lines[i] = fmt.Sprintf("%d. %s: %s (%q)", i+1, metric.MetricName, formatMetricValue(metric), metric.Context)
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to check each of these figures mentioned in a meeting. A figure is verified only when search results confirm it; figures about private or internal matters that can't be found are not verified.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "verified": [1, 3]
+}
+`+"`"+``, strings.Join(lines, "\n"))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil || response.GroundingMetadata == nil || len(response.GroundingMetadata.GroundingChunks) == 0 {
+		return fmt.Errorf("no search results to verify metrics against")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in metric verification response")
+	}
+
+	var result struct {
+		Verified []int `json:"verified"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse metric verification JSON: %w", err)
+	}
+	for _, number := range result.Verified {
+		if number >= 1 && number <= len(metrics) {
+			metrics[number-1].Verified = true
+		}
+	}
+	return nil
+}
+
+// parseMetricValue parses a number as spoken, such as "$3,000", "1.2M" or "45%", returning its value
+// and unit
+func parseMetricValue(raw string) (float64, string, bool) {
+	match := metricNumber.FindStringSubmatch(strings.TrimSpace(raw))
+	if match == nil {
+		return 0, "", false
+	}
+	currency, number, scale, percent, rest := match[1], match[2], strings.ToLower(match[3]), match[4], strings.TrimSpace(match[5])
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0, "", false
+	}
+	if multiplier, ok := metricScales[scale]; ok {
+		value *= multiplier
+	}
+
+	switch {
+	case currency != "":
+		return value, currency, true
+	case percent != "":
+		return value, "%", true
+	default:
+		return value, rest, true
+	}
+}
+
+// formatMetricValue formats a metric's value with its unit, e.g. "$3,000", "45%" or "1,200 users"
+func formatMetricValue(metric MetricMention) string {
+	number := formatMetricNumber(metric.Value)
+	switch metric.Unit {
+	case "":
+		return number
+	case "%":
+		return number + "%"
+	case "$", "€", "£", "¥":
+		return metric.Unit + number
+	default:
+		return number + " " + metric.Unit
+	}
+}
+
+// formatMetricNumber formats a number with thousands separators and at most two decimals
+func formatMetricNumber(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign, value = "-", -value
+	}
+
+	whole, fraction := math.Modf(value)
+	digits := strconv.FormatFloat(whole, 'f', 0, 64)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	decimals := strings.TrimRight(strings.TrimPrefix(strconv.FormatFloat(fraction, 'f', 2, 64), "0"), "0")
+	return sign + grouped.String() + strings.TrimSuffix(decimals, ".")
+}
+
+// MetricsSummary formats the key metrics as a markdown table, or "" when there are none
+func (d *AnalysisData) MetricsSummary() string {
+	if len(d.KeyMetrics) == 0 {
+		return ""
+	}
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	var table strings.Builder
+	table.WriteString("| Metric | Value |\n|---|---|\n")
+	for _, metric := range d.KeyMetrics {
+		value := formatMetricValue(metric)
+		if metric.Verified {
+			value += " ✓"
+		}
+		table.WriteString(fmt.Sprintf("| %s | %s |\n", cell.Replace(metric.MetricName), cell.Replace(value)))
+	}
+	return table.String()
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestParseMetricValue(t *testing.T) {
+	tests := []struct {
+		raw   string
+		value float64
+		unit  string
+	}{
+		{"1.2M", 1.2e6, ""},
+		{"45%", 45, "%"},
+		{"$3,000", 3000, "$"},
+		{"$1.5 billion", 1.5e9, "$"},
+		{"12 percent", 12, "%"},
+		{"€250k", 250000, "€"},
+		{"1,200 users", 1200, "users"},
+		{"-3.5%", -3.5, "%"},
+		{".5", 0.5, ""},
+	}
+	for _, tt := range tests {
+		value, unit, ok := parseMetricValue(tt.raw)
+		if !ok || value != tt.value || unit != tt.unit {
+			t.Errorf("parseMetricValue(%q) = %v, %q, %v, want %v, %q", tt.raw, value, unit, ok, tt.value, tt.unit)
+		}
+	}
+
+	for _, raw := range []string{"", "next quarter", "$"} {
+		if _, _, ok := parseMetricValue(raw); ok {
+			t.Errorf("parseMetricValue(%q) parsed a value", raw)
+		}
+	}
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	tests := map[string]MetricMention{
+		"$3,000":       {Value: 3000, Unit: "$"},
+		"1,200,000":    {Value: 1.2e6},
+		"45%":          {Value: 45, Unit: "%"},
+		"1,200 users":  {Value: 1200, Unit: "users"},
+		"-2.75":        {Value: -2.75},
+		"€1,234,567.5": {Value: 1234567.5, Unit: "€"},
+	}
+	for want, metric := range tests {
+		if got := formatMetricValue(metric); got != want {
+			t.Errorf("formatMetricValue(%+v) = %q, want %q", metric, got, want)
+		}
+	}
+}
+
+func TestExtractMetrics(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Transcript = []TranscriptEntry{entryAt(0, "Maya", "Revenue hit 1.2M, up 45%, and the pilot costs $3,000")}
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"metrics": [
+		{"value": "1.2M", "metric_name": "Q3 revenue", "speaker": "Maya", "context": "Revenue hit 1.2M"},
+		{"value": "45%", "metric_name": "Revenue growth", "speaker": "Maya"},
+		{"value": "$3,000", "metric_name": "Pilot cost", "speaker": "Maya"},
+		{"value": "soon", "metric_name": "Launch"},
+		{"value": "20", "metric_name": ""}
+	]}` + "\n```")
+
+	if err := analyst.extractMetrics(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := analyst.GetAnalysis().KeyMetrics
+	if len(metrics) != 3 {
+		t.Fatalf("metrics = %+v, want the three valid ones", metrics)
+	}
+	want := []MetricMention{
+		{Value: 1.2e6, MetricName: "Q3 revenue", Speaker: "Maya", Context: "Revenue hit 1.2M"},
+		{Value: 45, Unit: "%", MetricName: "Revenue growth", Speaker: "Maya"},
+		{Value: 3000, Unit: "$", MetricName: "Pilot cost", Speaker: "Maya"},
+	}
+	for i := range want {
+		if metrics[i] != want[i] {
+			t.Errorf("metric %d = %+v, want %+v", i, metrics[i], want[i])
+		}
+	}
+
+	summary := (&AnalysisData{KeyMetrics: metrics}).MetricsSummary()
+	for _, row := range []string{"| Q3 revenue | 1,200,000 |", "| Revenue growth | 45% |", "| Pilot cost | $3,000 |"} {
+		if !strings.Contains(summary, row) {
+			t.Errorf("summary = %q, want row %q", summary, row)
+		}
+	}
+}
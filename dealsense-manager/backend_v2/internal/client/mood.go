@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// moodWindow is the span of each speaker's utterances their mood is judged from
+	moodWindow = 3 * time.Minute
+	// moodWindowStep is how far each mood window slides past the previous one
+	moodWindowStep = time.Minute
+	// maxMoodWindowsPerRun bounds the LLM calls of one analysis run; later windows are scored on the next run
+	maxMoodWindowsPerRun = 10
+)
+
+// Moods a speaker can be in
+const (
+	MoodExcited    = "excited"
+	MoodNeutral    = "neutral"
+	MoodSkeptical  = "skeptical"
+	MoodFrustrated = "frustrated"
+	MoodConfused   = "confused"
+	MoodSatisfied  = "satisfied"
+)
+
+// MoodPoint is a speaker's mood at the end of a window of their utterances
+type MoodPoint struct {
+	Timestamp  time.Time `json:"timestamp"` // End of the window
+	Mood       string    `json:"mood"`      // excited, neutral, skeptical, frustrated, confused, satisfied
+	Confidence float64   `json:"confidence"`
+}
+
+// updateSpeakerMoodTimeline judges the mood of each speaker in 3-minute windows sliding by a minute, adding
+// a point per speaker who spoke in the window. Only windows ending after the latest point are scored, so
+// nothing is re-run until the meeting has advanced a full step, and only windows the transcript has passed
+// the end of are scored so each is judged on all of its utterances.
+func (a *AnalystAgent) updateSpeakerMoodTimeline(ctx context.Context) error {
+	transcript := a.getFullTranscript()
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	a.dataMutex.RLock()
+	meetingStart := a.data.StartTime
+	var scoredUntil time.Time
+	for _, points := range a.data.SpeakerMoodTimeline {
+		if len(points) > 0 && points[len(points)-1].Timestamp.After(scoredUntil) {
+			scoredUntil = points[len(points)-1].Timestamp
+		}
+	}
+	a.dataMutex.RUnlock()
+
+	// Windows are aligned to the meeting start so they stay put when old entries leave the transcript
+	if meetingStart.IsZero() {
+		meetingStart = transcript[0].Timestamp
+	}
+	latest := transcript[len(transcript)-1].Timestamp
+	scored := 0
+	for end := meetingStart.Add(moodWindowStep); !end.After(latest) && scored < maxMoodWindowsPerRun; end = end.Add(moodWindowStep) {
+		if !end.After(scoredUntil) {
+			continue
+		}
+
+		utterances := speakerUtterancesBetween(transcript, end.Add(-moodWindow), end)
+		if len(utterances) == 0 {
+			continue
+		}
+
+		moods, err := a.judgeSpeakerMoods(ctx, utterances)
+		if err != nil {
+			return err
+		}
+		scored++
+
+		a.dataMutex.Lock()
+		if a.data.SpeakerMoodTimeline == nil {
+			a.data.SpeakerMoodTimeline = make(map[string][]MoodPoint)
+		}
+		for speaker, point := range moods {
+			point.Timestamp = end
+			a.data.SpeakerMoodTimeline[speaker] = append(a.data.SpeakerMoodTimeline[speaker], point)
+		}
+		a.dataMutex.Unlock()
+	}
+
+	if scored > 0 {
+		logrus.Infof("Agent %s: Judged speaker moods in %d windows", a.agentID, scored)
+	}
+	return nil
+}
+
+// speakerUtterancesBetween groups the participants' statements in [from, to) by speaker
+func speakerUtterancesBetween(transcript []TranscriptEntry, from, to time.Time) map[string][]TranscriptEntry {
+	utterances := make(map[string][]TranscriptEntry)
+	for _, entry := range transcript {
+		if entry.IsAgent || entry.Timestamp.Before(from) || !entry.Timestamp.Before(to) {
+			continue
+		}
+		utterances[entry.Speaker] = append(utterances[entry.Speaker], entry)
+	}
+	return utterances
+}
+
+// judgeSpeakerMoods asks the LLM for the mood of each speaker from their utterances in one window, judging
+// them with the word-list heuristic in cost saving mode or when the response can't be used. Speakers the
+// LLM leaves out are neutral.
+func (a *AnalystAgent) judgeSpeakerMoods(ctx context.Context, utterances map[string][]TranscriptEntry) (map[string]MoodPoint, error) {
+	speakers := make([]string, 0, len(utterances))
+	for speaker := range utterances {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	if a.config.CostSavingMode {
+		return heuristicMoods(utterances), nil
+	}
+
+	var text strings.Builder
+	for _, speaker := range speakers {
+		text.WriteString(fmt.Sprintf("%s:\n", speaker))
+		for _, entry := range utterances[speaker] {
+			text.WriteString(fmt.Sprintf("- %s\n", entry.Text))
+		}
+		text.WriteString("\n")
+	}
+
+	prompt := a.glossaryPrefix(text.String()) + a.languagePrefix() + fmt.Sprintf(`These are the statements each participant made in the same three minutes of a meeting. Judge the emotional state each participant was in by the end of it, from their own words only:
+- excited: enthusiastic or eager
+- neutral: matter-of-fact, no clear emotion
+- skeptical: doubtful, pushing back or questioning claims
+- frustrated: annoyed, impatient or upset
+- confused: unsure what was meant or asking for clarification
+- satisfied: content, reassured or in agreement
+
+Give your confidence in each judgement from 0 to 1; a few short statements rarely justify more than 0.6.
+
+%s
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "moods": {
+    "Participant name": {"mood": "excited/neutral/skeptical/frustrated/confused/satisfied", "confidence": 0.7}
+  }
+}
+`+"`"+``, text.String())
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	var result struct {
+		Moods map[string]MoodPoint `json:"moods"`
+	}
+	if jsonData == "" || json.Unmarshal([]byte(jsonData), &result) != nil {
+		logrus.Debugf("Agent %s: Falling back to heuristic speaker moods", a.agentID)
+		return heuristicMoods(utterances), nil
+	}
+
+	moods := make(map[string]MoodPoint, len(speakers))
+	for _, speaker := range speakers {
+		point, ok := result.Moods[speaker]
+		if !ok {
+			moods[speaker] = MoodPoint{Mood: MoodNeutral}
+			continue
+		}
+		moods[speaker] = MoodPoint{
+			Mood:       normalizeMood(point.Mood),
+			Confidence: math.Max(0, math.Min(1, point.Confidence)),
+		}
+	}
+	return moods, nil
+}
+
+// heuristicMoods judges each speaker's mood from the balance of positive and negative words, which can only
+// tell satisfied and frustrated speakers from neutral ones
+func heuristicMoods(utterances map[string][]TranscriptEntry) map[string]MoodPoint {
+	moods := make(map[string]MoodPoint, len(utterances))
+	for speaker, entries := range utterances {
+		sentiment, score := heuristicSentiment(entries)
+		mood := MoodNeutral
+		switch sentiment {
+		case "positive":
+			mood = MoodSatisfied
+		case "negative":
+			mood = MoodFrustrated
+		}
+		moods[speaker] = MoodPoint{Mood: mood, Confidence: math.Abs(score) / 2}
+	}
+	return moods
+}
+
+// normalizeMood maps the LLM's mood onto the supported set, defaulting to neutral
+func normalizeMood(mood string) string {
+	switch mood = strings.ToLower(strings.TrimSpace(mood)); mood {
+	case MoodExcited, MoodNeutral, MoodSkeptical, MoodFrustrated, MoodConfused, MoodSatisfied:
+		return mood
+	default:
+		return MoodNeutral
+	}
+}
+
+// GetSpeakerMoodAtTime returns the speaker's mood at t from the nearest point of their timeline. Between
+// two points of the same mood the confidence is interpolated linearly. Before the speaker's first point,
+// and for speakers without a timeline, the returned point has no mood.
+func (a *AnalystAgent) GetSpeakerMoodAtTime(speaker string, t time.Time) MoodPoint {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return moodAtTime(a.data.SpeakerMoodTimeline[speaker], t)
+}
+
+// moodAtTime looks up the mood at t in a timeline ordered by time
+func moodAtTime(timeline []MoodPoint, t time.Time) MoodPoint {
+	next := sort.Search(len(timeline), func(i int) bool { return timeline[i].Timestamp.After(t) })
+	switch {
+	case next == 0:
+		return MoodPoint{Timestamp: t}
+	case next == len(timeline) || timeline[next-1].Timestamp.Equal(t):
+		point := timeline[next-1]
+		point.Timestamp = t
+		return point
+	}
+
+	before, after := timeline[next-1], timeline[next]
+	progress := float64(t.Sub(before.Timestamp)) / float64(after.Timestamp.Sub(before.Timestamp))
+	if before.Mood == after.Mood {
+		return MoodPoint{
+			Timestamp:  t,
+			Mood:       before.Mood,
+			Confidence: before.Confidence + (after.Confidence-before.Confidence)*progress,
+		}
+	}
+
+	nearest := before
+	if progress > 0.5 {
+		nearest = after
+	}
+	nearest.Timestamp = t
+	return nearest
+}
+
+// mergeMoodTimelines adds the points of a timeline to the merged timelines, keeping each speaker's points
+// ordered by time and the first point at a time
+func mergeMoodTimelines(merged map[string][]MoodPoint, timelines map[string][]MoodPoint) map[string][]MoodPoint {
+	for speaker, points := range timelines {
+		if merged == nil {
+			merged = make(map[string][]MoodPoint)
+		}
+		combined := append(append([]MoodPoint(nil), merged[speaker]...), points...)
+		sort.SliceStable(combined, func(i, j int) bool { return combined[i].Timestamp.Before(combined[j].Timestamp) })
+		merged[speaker] = appendUniqueBy(nil, combined, func(point MoodPoint) string {
+			return fmt.Sprint(point.Timestamp.UnixNano())
+		})
+	}
+	return merged
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// moodAt returns a mood point the given number of minutes into the meeting
+func moodAt(minutes int, mood string, confidence float64) MoodPoint {
+	return MoodPoint{Timestamp: testMeetingStart.Add(time.Duration(minutes) * time.Minute), Mood: mood, Confidence: confidence}
+}
+
+func TestMoodAtTime(t *testing.T) {
+	timeline := []MoodPoint{
+		moodAt(1, MoodSkeptical, 0.2),
+		moodAt(3, MoodSkeptical, 0.6),
+		moodAt(5, MoodSatisfied, 0.9),
+	}
+	at := func(seconds int) time.Time { return testMeetingStart.Add(time.Duration(seconds) * time.Second) }
+
+	cases := []struct {
+		seconds    int
+		mood       string
+		confidence float64
+	}{
+		{30, "", 0},               // before the first point
+		{60, MoodSkeptical, 0.2},  // on a point
+		{120, MoodSkeptical, 0.4}, // halfway between points of the same mood
+		{210, MoodSkeptical, 0.6}, // nearer the skeptical point
+		{270, MoodSatisfied, 0.9}, // nearer the satisfied point
+		{600, MoodSatisfied, 0.9}, // after the last point
+	}
+	for _, c := range cases {
+		point := moodAtTime(timeline, at(c.seconds))
+		if point.Mood != c.mood || !approx(point.Confidence, c.confidence) || !point.Timestamp.Equal(at(c.seconds)) {
+			t.Errorf("moodAtTime(%ds) = %+v, want %s at %.1f", c.seconds, point, c.mood, c.confidence)
+		}
+	}
+}
+
+func TestMergeMoodTimelinesKeepsOrder(t *testing.T) {
+	merged := map[string][]MoodPoint{"Alice": {moodAt(1, MoodNeutral, 0.5), moodAt(3, MoodExcited, 0.7)}}
+	merged = mergeMoodTimelines(merged, map[string][]MoodPoint{
+		"Alice": {moodAt(2, MoodConfused, 0.4), moodAt(3, MoodFrustrated, 0.9)},
+		"Bob":   {moodAt(1, MoodSatisfied, 0.8)},
+	})
+
+	alice := merged["Alice"]
+	want := []string{MoodNeutral, MoodConfused, MoodExcited}
+	if len(alice) != len(want) {
+		t.Fatalf("Alice's timeline = %+v, want %v", alice, want)
+	}
+	for i, mood := range want {
+		if alice[i].Mood != mood {
+			t.Errorf("Alice's point %d = %s, want %s", i, alice[i].Mood, mood)
+		}
+	}
+	if len(merged["Bob"]) != 1 {
+		t.Errorf("Bob's timeline = %+v", merged["Bob"])
+	}
+}
+
+func TestUpdateSpeakerMoodTimeline(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.StartTime = testMeetingStart
+	mood := "```json\n" + `{"moods": {"Alice": {"mood": "Skeptical", "confidence": 1.5}}}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(mood, mood)
+	say(analyst, 0, "Alice", "I am not sure these numbers add up")
+	say(analyst, 150, "Bob", "Let me walk through them again")
+
+	if err := analyst.updateSpeakerMoodTimeline(context.Background()); err != nil {
+		t.Fatalf("updateSpeakerMoodTimeline() error = %v", err)
+	}
+
+	// The windows ending at one and two minutes hold Alice's statement; the three-minute window isn't over
+	timeline := analyst.GetAnalysis().SpeakerMoodTimeline
+	if len(timeline["Alice"]) != 2 || len(timeline["Bob"]) != 0 {
+		t.Fatalf("SpeakerMoodTimeline = %+v", timeline)
+	}
+	point := analyst.GetSpeakerMoodAtTime("Alice", testMeetingStart.Add(2*time.Minute))
+	if point.Mood != MoodSkeptical || point.Confidence != 1 {
+		t.Errorf("Alice's mood = %+v, want skeptical clamped to confidence 1", point)
+	}
+}
+
+func TestNormalizeMood(t *testing.T) {
+	if got := normalizeMood(" Excited "); got != MoodExcited {
+		t.Errorf("normalizeMood(Excited) = %q", got)
+	}
+	if got := normalizeMood("elated"); got != MoodNeutral {
+		t.Errorf("normalizeMood(elated) = %q, want neutral", got)
+	}
+}
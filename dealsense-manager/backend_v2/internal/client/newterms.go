@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	// maxNewTermsPerRun is the most new term candidates sent to the LLM in one analysis run
+	maxNewTermsPerRun = 30
+	// minNewTermLength is the length below which words aren't considered terms
+	minNewTermLength = 3
+)
+
+// Categories of new terms
+const (
+	TermCategoryTechnical = "technical"
+	TermCategoryProduct   = "product"
+	TermCategoryProcess   = "process"
+	TermCategoryPerson    = "person"
+)
+
+// englishWordsText is a lowercase US/UK English word list, one word per line, used to tell ordinary words
+// from terms the meeting introduced. Proper nouns aren't included, so names are candidates as well.
+//
+//go:embed english_words.txt
+var englishWordsText string
+
+var (
+	englishWordSet     map[string]bool
+	englishWordSetOnce sync.Once
+)
+
+// englishWords returns the embedded word list as a set, loading it on first use
+func englishWords() map[string]bool {
+	englishWordSetOnce.Do(func() {
+		englishWordSet = wordSet(strings.Fields(englishWordsText)...)
+	})
+	return englishWordSet
+}
+
+// GlossaryEntry explains a term introduced in the meeting, such as a project name or internal jargon
+type GlossaryEntry struct {
+	Definition            string    `json:"definition"`
+	FirstMentionedBy      string    `json:"first_mentioned_by"`
+	FirstMentionTimestamp time.Time `json:"first_mention_timestamp"`
+	Category              string    `json:"category"` // technical, product, process, person
+	Verified              bool      `json:"verified"` // The definition was confirmed by a web search
+}
+
+// termMention is the first mention of a candidate term
+type termMention struct {
+	term      string
+	speaker   string
+	timestamp time.Time
+}
+
+// extractNovelTerms returns the words of the transcript that aren't in the English word list, in the order
+// they were first mentioned. Stopwords, numbers and acronyms, which the acronym glossary covers, are left
+// out, as are agent statements and statements mostly written in capitals.
+func extractNovelTerms(transcript []TranscriptEntry) []termMention {
+	dictionary := englishWords()
+	seen := make(map[string]bool)
+	var mentions []termMention
+	for _, entry := range transcript {
+		if entry.IsAgent || mostlyUppercase(entry.Text) {
+			continue
+		}
+		for _, word := range termWords(entry.Text) {
+			lower := strings.ToLower(word)
+			if seen[lower] || !isNovelTerm(word, dictionary) {
+				continue
+			}
+			seen[lower] = true
+			mentions = append(mentions, termMention{term: word, speaker: entry.Speaker, timestamp: entry.Timestamp})
+		}
+	}
+	return mentions
+}
+
+// termWords splits text into words keeping their case and inner hyphens, so "follow-up" stays one word
+func termWords(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if word := strings.Trim(field, "-"); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isNovelTerm reports whether a word could be a new term: long enough, not a number or an acronym, and
+// not an English word or stopword. A hyphenated word is novel when any of its parts is.
+func isNovelTerm(word string, dictionary map[string]bool) bool {
+	if len([]rune(word)) < minNewTermLength || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+		return false
+	}
+	if match := acronymPattern.FindString(word); match == word {
+		return false
+	}
+
+	for _, part := range strings.Split(strings.ToLower(word), "-") {
+		if part == "" || dictionary[part] || defaultStopwords[part] || nonAcronyms[strings.ToUpper(part)] {
+			continue
+		}
+		if strings.IndexFunc(part, unicode.IsLetter) < 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// buildGlossary defines the new terms used in the meeting. Words outside the English word list are sent
+// to the LLM, which defines those that are meeting-specific terms from the statements they were used in;
+// the words it leaves out aren't sent again.
+// When the LLM supports grounding, the definitions are checked with a web search. Defined terms are added
+// to the knowledge base for future meetings when it can learn terms.
+func (a *AnalystAgent) buildGlossary(ctx context.Context) error {
+	transcript := a.getFullTranscript()
+
+	a.dataMutex.RLock()
+	glossary := make(map[string]GlossaryEntry, len(a.data.GlossaryOfNewTerms))
+	for term, entry := range a.data.GlossaryOfNewTerms {
+		glossary[term] = entry
+	}
+	a.dataMutex.RUnlock()
+
+	known := make(map[string]bool, len(glossary))
+	for term := range glossary {
+		known[strings.ToLower(term)] = true
+	}
+
+	var candidates []termMention
+	for _, mention := range extractNovelTerms(transcript) {
+		if lower := strings.ToLower(mention.term); !known[lower] && !a.rejectedTerms[lower] {
+			candidates = append(candidates, mention)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	if len(candidates) > maxNewTermsPerRun {
+		candidates = candidates[:maxNewTermsPerRun]
+	}
+
+	logrus.Infof("Agent %s: Defining %d new term candidates", a.agentID, len(candidates))
+
+	terms := make([]string, len(candidates))
+	for i, mention := range candidates {
+		terms[i] = mention.term
+	}
+	defined, err := a.defineTerms(ctx, terms, transcript)
+	if err != nil {
+		return err
+	}
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok && len(defined) > 0 {
+		if err := a.verifyTermDefinitions(ctx, groundingProvider, defined); err != nil {
+			logrus.Warnf("Agent %s: Failed to verify new term definitions: %v", a.agentID, err)
+		}
+	}
+
+	learned := make(map[string]string, len(defined))
+	for _, mention := range candidates {
+		entry, ok := defined[mention.term]
+		if !ok {
+			if a.rejectedTerms == nil {
+				a.rejectedTerms = make(map[string]bool)
+			}
+			a.rejectedTerms[strings.ToLower(mention.term)] = true
+			continue
+		}
+		entry.FirstMentionedBy = mention.speaker
+		entry.FirstMentionTimestamp = mention.timestamp
+		glossary[mention.term] = entry
+		learned[mention.term] = entry.Definition
+	}
+	a.learnTerms(learned)
+
+	a.dataMutex.Lock()
+	a.data.GlossaryOfNewTerms = glossary
+	a.dataMutex.Unlock()
+	return nil
+}
+
+// defineTerms asks the LLM to define the candidates that are meeting-specific terms, returning only those
+func (a *AnalystAgent) defineTerms(ctx context.Context, terms []string, transcript []TranscriptEntry) (map[string]GlossaryEntry, error) {
+	contextLines := acronymContextLines(terms, transcript)
+	prompt := a.glossaryPrefix(contextLines) + a.languagePrefix() + fmt.Sprintf(`These words from a meeting aren't ordinary English words. Identify the ones that are terms a new team member would need explained, such as project or product names, internal jargon, tools, processes and people, and define each from the statements it was used in.
+
+Categorize each term as one of:
+- technical: technologies, tools, systems and technical jargon
+- product: products, features, projects and customers
+- process: workflows, rituals and internal procedures
+- person: people mentioned by name
+
+Leave out misspellings, transcription errors, filler sounds and words the statements don't explain; don't guess.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "terms": {
+    "Term": {"definition": "What the term means in this meeting", "category": "technical/product/process/person"}
+  }
+}
+`+"`"+``, contextLines)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil, fmt.Errorf("no JSON in new terms response")
+	}
+
+	var result struct {
+		Terms map[string]struct {
+			Definition string `json:"definition"`
+			Category   string `json:"category"`
+		} `json:"terms"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse new terms JSON: %w", err)
+	}
+
+	defined := make(map[string]GlossaryEntry)
+	for _, term := range terms {
+		definition, ok := result.Terms[term]
+		if !ok || strings.TrimSpace(definition.Definition) == "" {
+			continue
+		}
+		defined[term] = GlossaryEntry{
+			Definition: strings.TrimSpace(definition.Definition),
+			Category:   normalizeTermCategory(definition.Category),
+		}
+	}
+	return defined, nil
+}
+
+// verifyTermDefinitions asks a grounded model to check the definitions with a web search, marking those it
+// confirms as verified. Internal terms usually can't be found and stay unverified.
+func (a *AnalystAgent) verifyTermDefinitions(ctx context.Context, provider llm.GroundingCapableProvider, defined map[string]GlossaryEntry) error {
+	terms := make([]string, 0, len(defined))
+	for term := range defined {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	var list strings.Builder
+	for _, term := range terms {
+		fmt.Fprintf(&list, "- %s: %s\n", term, defined[term].Definition)
+	}
+
+	prompt := fmt.Sprintf(`Use google_search to check each of these definitions of terms used in a business meeting. Mark a definition verified only when the search results confirm it; terms internal to the organization that can't be found are not verified.
+
+%s
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "verified": {"Term": true, "Other term": false}
+}
+`+"`"+``, list.String())
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil {
+		return fmt.Errorf("empty definition verification response")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in definition verification response")
+	}
+
+	var result struct {
+		Verified map[string]bool `json:"verified"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse definition verification JSON: %w", err)
+	}
+	for term, entry := range defined {
+		entry.Verified = result.Verified[term]
+		defined[term] = entry
+	}
+	return nil
+}
+
+// normalizeTermCategory maps the LLM's term category onto the supported set, defaulting to technical
+func normalizeTermCategory(category string) string {
+	switch category = strings.ToLower(strings.TrimSpace(category)); category {
+	case TermCategoryTechnical, TermCategoryProduct, TermCategoryProcess, TermCategoryPerson:
+		return category
+	default:
+		return TermCategoryTechnical
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractNovelTermsUsesWordList(t *testing.T) {
+	transcript := []TranscriptEntry{
+		entryAt(0, "Alice", "Before the launch we deploy Zephyrion to staging"),
+		entryAt(10, "Bob", "The Flumbo pipeline handles the follow-up review and the QBR in 2024"),
+		entryAt(20, "Carol", "zephyrion looks ready, and the sprint-flumbo runs Friday"),
+		{Timestamp: testMeetingStart, Speaker: "Agent", Text: "Noted the Quarvex item", IsAgent: true},
+	}
+
+	// English words, acronyms, numbers and agent statements are left out; later mentions don't count again
+	mentions := extractNovelTerms(transcript)
+	var terms []string
+	for _, mention := range mentions {
+		terms = append(terms, mention.term)
+	}
+	want := []string{"Zephyrion", "Flumbo", "sprint-flumbo", "Friday"}
+	if len(terms) != len(want) {
+		t.Fatalf("extractNovelTerms() = %v, want %v", terms, want)
+	}
+	for i := range want {
+		if terms[i] != want[i] {
+			t.Errorf("term %d = %q, want %q", i, terms[i], want[i])
+		}
+	}
+	if mentions[0].speaker != "Alice" || mentions[1].speaker != "Bob" {
+		t.Errorf("first mentions = %+v", mentions[:2])
+	}
+}
+
+func TestIsNovelTerm(t *testing.T) {
+	dictionary := englishWords()
+	cases := map[string]bool{
+		"deploy":       false, // in the word list
+		"Pipeline":     false, // in the word list, ignoring case
+		"the":          false,
+		"ab":           false, // too short
+		"QBR":          false, // acronym
+		"2024":         false,
+		"Zephyrion":    true,
+		"follow-up":    false,
+		"zephyrion-v2": true,
+	}
+	for word, want := range cases {
+		if got := isNovelTerm(word, dictionary); got != want {
+			t.Errorf("isNovelTerm(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestBuildGlossary(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	kb := learningGlossary{}
+	analyst.SetKnowledgeBase(kb)
+	mock := llm.NewMockLLMProvider("```json\n" + `{"terms": {"Zephyrion": {"definition": "The new billing service", "category": "Product"}}}` + "\n```")
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "We deploy Zephyrion before Flumbo")
+
+	if err := analyst.buildGlossary(context.Background()); err != nil {
+		t.Fatalf("buildGlossary() error = %v", err)
+	}
+	glossary := analyst.GetAnalysis().GlossaryOfNewTerms
+	entry, ok := glossary["Zephyrion"]
+	if len(glossary) != 1 || !ok || entry.Category != TermCategoryProduct || entry.FirstMentionedBy != "Alice" {
+		t.Errorf("GlossaryOfNewTerms = %+v", glossary)
+	}
+	if kb["Zephyrion"] != "The new billing service" {
+		t.Errorf("knowledge base = %v, want Zephyrion learned", kb)
+	}
+
+	// Flumbo wasn't a term, so it isn't sent again
+	if err := analyst.buildGlossary(context.Background()); err != nil {
+		t.Fatalf("second buildGlossary() error = %v", err)
+	}
+	if len(mock.Prompts()) != 1 {
+		t.Errorf("LLM was called %d times, want once", len(mock.Prompts()))
+	}
+}
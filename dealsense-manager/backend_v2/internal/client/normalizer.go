@@ -0,0 +1,73 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"joinly-manager/internal/models"
+)
+
+// TranscriptNormalizer cleans up the text of an utterance before it is added to the transcript
+type TranscriptNormalizer interface {
+	Normalize(text string) string
+}
+
+var (
+	// hesitations are always fillers, and are removed with the commas around them
+	hesitations = regexp.MustCompile(`(?i),?\s*\b(?:um+|uh+|erm?|hmm+)\b\s*,?`)
+	// discourseFillers are only fillers when set off from the sentence, since "like" and "you know" are
+	// also ordinary words ("I like it", "do you know")
+	discourseFillers = regexp.MustCompile(`(?i)(^|[,.!?])\s*(?:like|you know|i mean)\s*,`)
+	// Punctuation left behind by removed fillers
+	leadingCommas      = regexp.MustCompile(`^[\s,]+`)
+	repeatedCommas     = regexp.MustCompile(`,(?:\s*,)+`)
+	spaceBeforePunct   = regexp.MustCompile(`\s+([,.!?])`)
+	repeatedWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// sentenceEndRunes are the punctuation marks an utterance may already end with
+const sentenceEndRunes = ".!?…"
+
+// BasicNormalizer applies NFC normalization, optional filler removal, whitespace collapsing,
+// capitalization of the first letter and a closing period
+type BasicNormalizer struct {
+	RemoveFillers bool
+}
+
+// newTranscriptNormalizer creates the normalizer for cfg, or nil when normalization is disabled
+func newTranscriptNormalizer(cfg *models.NormalizerConfig) TranscriptNormalizer {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	return BasicNormalizer{RemoveFillers: cfg.RemoveFillers}
+}
+
+// Normalize returns the cleaned up text, or "" if nothing but fillers was said
+func (n BasicNormalizer) Normalize(text string) string {
+	text = norm.NFC.String(text)
+
+	if n.RemoveFillers {
+		text = hesitations.ReplaceAllString(text, " ")
+		text = discourseFillers.ReplaceAllString(text, "$1")
+		text = leadingCommas.ReplaceAllString(text, "")
+		text = repeatedCommas.ReplaceAllString(text, ",")
+		text = spaceBeforePunct.ReplaceAllString(text, "$1")
+	}
+
+	text = strings.TrimSpace(repeatedWhitespace.ReplaceAllString(text, " "))
+	if text == "" {
+		return ""
+	}
+
+	first, size := utf8.DecodeRuneInString(text)
+	text = string(unicode.ToUpper(first)) + text[size:]
+
+	if last, _ := utf8.DecodeLastRuneInString(text); !strings.ContainsRune(sentenceEndRunes, last) {
+		text = strings.TrimRight(text, ",;: ") + "."
+	}
+	return text
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+func TestBasicNormalizer(t *testing.T) {
+	tests := []struct {
+		name          string
+		removeFillers bool
+		text, want    string
+	}{
+		{"hesitations", true, "um so we uh ship on friday", "So we ship on friday."},
+		{"set-off fillers", true, "we ship friday, you know, after review", "We ship friday, after review."},
+		{"filler words kept in sentences", true, "do you know if they like it", "Do you know if they like it."},
+		{"leading filler", true, "like, we should go", "We should go."},
+		{"only fillers", true, "um, uh", ""},
+		{"fillers kept when disabled", false, "um so   we ship", "Um so we ship."},
+		{"multiple spaces", false, "  ship \t it  now ", "Ship it now."},
+		{"existing punctuation", false, "are we done?", "Are we done?"},
+		{"trailing comma", false, "and then,", "And then."},
+		{"NFC", false, "cafe\u0301 time", "Caf\u00e9 time."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (BasicNormalizer{RemoveFillers: tt.removeFillers}).Normalize(tt.text); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerDisabledKeepsOriginalText(t *testing.T) {
+	if newTranscriptNormalizer(nil) != nil || newTranscriptNormalizer(&models.NormalizerConfig{RemoveFillers: true}) != nil {
+		t.Fatal("normalizer created without being enabled")
+	}
+
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "um so   we ship")
+	if text := analyst.GetAnalysis().Transcript[0].Text; text != "um so   we ship" {
+		t.Errorf("text = %q, want it kept as recognized", text)
+	}
+}
+
+func TestNormalizerAppliedToUtterances(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.normalizer = newTranscriptNormalizer(&models.NormalizerConfig{Enabled: true, RemoveFillers: true})
+
+	say(analyst, 0, "Alice", "um, uh")
+	say(analyst, 1, "Alice", "uh we ship friday")
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != 1 || transcript[0].Text != "We ship friday." {
+		t.Errorf("transcript = %+v, want the filler-only utterance dropped and the other cleaned up", transcript)
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// npsTranscript is the number of recent transcript entries the NPS proxy is estimated from
+const npsTranscript = 100
+
+// NPSData is a Net Promoter Score estimated from how participants talk about the product and team
+type NPSData struct {
+	EstimatedScore   float64  `json:"estimated_score"` // -100 (all detractors) to 100 (all promoters)
+	PromoterPhrases  []string `json:"promoter_phrases"`
+	DetractorPhrases []string `json:"detractor_phrases"`
+	PassivePhrases   []string `json:"passive_phrases"`
+	Confidence       float64  `json:"confidence"` // 0 to 1
+}
+
+// computeNPSProxy estimates an NPS from promoter, passive and detractor language in the transcript. The
+// LLM's score is used when it gives one, otherwise the score is derived from the phrase counts the way NPS
+// is from survey answers. The estimate is replaced on each analysis run.
+func (a *AnalystAgent) computeNPSProxy(ctx context.Context) error {
+	transcript := a.getRecentTranscript(npsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Estimating NPS proxy with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("nps_proxy",
+		`Estimate a Net Promoter Score proxy from how the customer side of this meeting talks about the product, service and team.
+
+Classify the customer's language:
+- Promoter: enthusiasm, praise, positive references to the product or team, willingness to recommend or expand
+- Passive: satisfied but unenthusiastic, neutral acceptance, "it works" without commitment
+- Detractor: frustration, complaints, escalation threats, mentions of cancelling, churning or switching to a competitor
+
+Quote each phrase briefly as it was said. Ignore what the vendor side says about its own product.
+Estimate the score from -100 (every customer voice a detractor) to 100 (every customer voice a promoter), and rate your confidence from 0 to 1, lower when there is little customer language to go on.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "estimated_score": 20,
+  "promoter_phrases": ["phrase"],
+  "passive_phrases": ["phrase"],
+  "detractor_phrases": ["phrase"],
+  "confidence": 0.6
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Failed to estimate NPS proxy: %v", err)
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		EstimatedScore   *float64 `json:"estimated_score"`
+		PromoterPhrases  []string `json:"promoter_phrases"`
+		PassivePhrases   []string `json:"passive_phrases"`
+		DetractorPhrases []string `json:"detractor_phrases"`
+		Confidence       float64  `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse NPS proxy JSON: %w", err)
+	}
+
+	nps := &NPSData{
+		PromoterPhrases:  cleanPhrases(result.PromoterPhrases),
+		DetractorPhrases: cleanPhrases(result.DetractorPhrases),
+		PassivePhrases:   cleanPhrases(result.PassivePhrases),
+		Confidence:       math.Max(0, math.Min(1, result.Confidence)),
+	}
+	if result.EstimatedScore != nil {
+		nps.EstimatedScore = *result.EstimatedScore
+	} else {
+		nps.EstimatedScore = npsFromPhrases(len(nps.PromoterPhrases), len(nps.PassivePhrases), len(nps.DetractorPhrases))
+	}
+	nps.EstimatedScore = math.Max(-100, math.Min(100, nps.EstimatedScore))
+
+	a.dataMutex.Lock()
+	a.data.NPSProxy = nps
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Estimated NPS proxy %.0f (confidence %.2f)", a.agentID, nps.EstimatedScore, nps.Confidence)
+	return nil
+}
+
+// npsFromPhrases scores phrase counts like survey answers: the percentage of promoters minus the
+// percentage of detractors, or 0 when there are none
+func npsFromPhrases(promoters, passives, detractors int) float64 {
+	total := promoters + passives + detractors
+	if total == 0 {
+		return 0
+	}
+	return float64(promoters-detractors) / float64(total) * 100
+}
+
+// cleanPhrases trims the phrases and drops empty ones, returning an empty slice rather than nil
+func cleanPhrases(phrases []string) []string {
+	cleaned := []string{}
+	for _, phrase := range phrases {
+		if phrase = strings.TrimSpace(phrase); phrase != "" {
+			cleaned = append(cleaned, phrase)
+		}
+	}
+	return cleaned
+}
+
+// clone returns a deep copy of the NPS data, or nil
+func (n *NPSData) clone() *NPSData {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.PromoterPhrases = append([]string{}, n.PromoterPhrases...)
+	clone.DetractorPhrases = append([]string{}, n.DetractorPhrases...)
+	clone.PassivePhrases = append([]string{}, n.PassivePhrases...)
+	return &clone
+}
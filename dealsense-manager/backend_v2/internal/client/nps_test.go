@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestNPSFromPhrases(t *testing.T) {
+	tests := []struct {
+		promoters, passives, detractors int
+		want                            float64
+	}{
+		{0, 0, 0, 0},
+		{3, 0, 0, 100},
+		{0, 0, 3, -100},
+		{2, 1, 1, 25},
+		{0, 4, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := npsFromPhrases(tt.promoters, tt.passives, tt.detractors); got != tt.want {
+			t.Errorf("npsFromPhrases(%d, %d, %d) = %v, want %v", tt.promoters, tt.passives, tt.detractors, got, tt.want)
+		}
+	}
+}
+
+func TestComputeNPSProxyBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		score      float64
+		confidence float64
+	}{
+		{"score above 100", `{"estimated_score": 140, "confidence": 1.5}`, 100, 1},
+		{"score below -100", `{"estimated_score": -250, "confidence": -0.2}`, -100, 0},
+		{"score within bounds", `{"estimated_score": -40, "confidence": 0.7}`, -40, 0.7},
+		{"score from phrases", `{"promoter_phrases": ["love it", " "], "detractor_phrases": ["too slow"], "passive_phrases": ["fine"], "confidence": 0.4}`, 0, 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyst := newTestAnalyst(t)
+			analyst.data.Transcript = []TranscriptEntry{entryAt(0, "Customer", "We love it but it is too slow")}
+			analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + tt.response + "\n```")
+
+			if err := analyst.computeNPSProxy(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			nps := analyst.GetAnalysis().NPSProxy
+			if nps.EstimatedScore != tt.score || nps.Confidence != tt.confidence {
+				t.Errorf("score, confidence = %v, %v, want %v, %v", nps.EstimatedScore, nps.Confidence, tt.score, tt.confidence)
+			}
+		})
+	}
+}
+
+func TestCleanPhrases(t *testing.T) {
+	if got := cleanPhrases([]string{" love it ", "", "  "}); !reflect.DeepEqual(got, []string{"love it"}) {
+		t.Errorf("cleanPhrases() = %q", got)
+	}
+	if got := cleanPhrases(nil); got == nil || len(got) != 0 {
+		t.Errorf("cleanPhrases(nil) = %#v, want an empty slice", got)
+	}
+}
@@ -0,0 +1,442 @@
+package client
+
+import (
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ObfuscateParticipants returns a copy of the analysis with participant names replaced by pseudonyms such
+// as "Speaker A", for sharing outside the organization. Names are shuffled onto pseudonyms with a PRNG
+// seeded by seed, so the same seed always gives the same mapping for a meeting. Names are also replaced
+// where they appear in transcript text, summaries, key points and other analysis text. Grounded content,
+// the follow-up email draft and plugin results can't be reliably rewritten and are left out. The agent's
+// own analysis is not modified.
+func (a *AnalystAgent) ObfuscateParticipants(seed int64) *AnalysisData {
+	data := a.GetAnalysis()
+
+	var names []string
+	seen := make(map[string]bool)
+	visitParticipantNames(data, func(name *string) {
+		if *name != "" && !seen[*name] {
+			seen[*name] = true
+			names = append(names, *name)
+		}
+	})
+	sort.Strings(names)
+
+	pseudonyms := make(map[string]string, len(names))
+	for i, j := range rand.New(rand.NewSource(seed)).Perm(len(names)) {
+		pseudonyms[names[i]] = pseudonymLabel(j)
+	}
+
+	visitParticipantNames(data, func(name *string) {
+		if pseudonym, ok := pseudonyms[*name]; ok {
+			*name = pseudonym
+		}
+	})
+	if data.SpeakerEngagementMap != nil {
+		engagement := make(map[string]EngagementStats, len(data.SpeakerEngagementMap))
+		for speaker, stats := range data.SpeakerEngagementMap {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			engagement[speaker] = stats
+		}
+		data.SpeakerEngagementMap = engagement
+	}
+	if data.AttentionScores != nil {
+		scores := make(map[string]float64, len(data.AttentionScores))
+		for speaker, score := range data.AttentionScores {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			scores[speaker] = score
+		}
+		data.AttentionScores = scores
+	}
+	if data.LanguageMetrics != nil {
+		metrics := make(map[string]SpeakerLanguageMetrics, len(data.LanguageMetrics))
+		for speaker, speakerMetrics := range data.LanguageMetrics {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			metrics[speaker] = speakerMetrics
+		}
+		data.LanguageMetrics = metrics
+	}
+	if data.SpeakerPersonas != nil {
+		personas := make(map[string]SpeakerPersona, len(data.SpeakerPersonas))
+		for speaker, persona := range data.SpeakerPersonas {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			personas[speaker] = persona
+		}
+		data.SpeakerPersonas = personas
+	}
+	if data.SpeakerMoodTimeline != nil {
+		timelines := make(map[string][]MoodPoint, len(data.SpeakerMoodTimeline))
+		for speaker, points := range data.SpeakerMoodTimeline {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			timelines[speaker] = points
+		}
+		data.SpeakerMoodTimeline = timelines
+	}
+	if data.SpeakerClassification != nil {
+		classification := make(map[string]string, len(data.SpeakerClassification))
+		for speaker, class := range data.SpeakerClassification {
+			if pseudonym, ok := pseudonyms[speaker]; ok {
+				speaker = pseudonym
+			}
+			classification[speaker] = class
+		}
+		data.SpeakerClassification = classification
+	}
+
+	scrub := nameScrubber(pseudonyms)
+	visitAnalysisText(data, func(text *string) {
+		*text = scrub(*text)
+	})
+	if data.GlossaryOfNewTerms != nil {
+		glossary := make(map[string]GlossaryEntry, len(data.GlossaryOfNewTerms))
+		for term, entry := range data.GlossaryOfNewTerms {
+			if pseudonym, ok := pseudonyms[entry.FirstMentionedBy]; ok {
+				entry.FirstMentionedBy = pseudonym
+			}
+			entry.Definition = scrub(entry.Definition)
+			glossary[scrub(term)] = entry
+		}
+		data.GlossaryOfNewTerms = glossary
+	}
+
+	data.GroundedSummary = nil
+	data.GroundedKeyPoints = nil
+	data.FollowUpEmailDraft = nil
+	data.KeyContacts = nil
+	data.PluginResults = nil
+	return data
+}
+
+// visitParticipantNames calls visit with every field of data holding a participant name. Slices shared
+// with the agent's analysis are replaced by copies before they are visited.
+func visitParticipantNames(data *AnalysisData, visit func(name *string)) {
+	for i := range data.Transcript {
+		visit(&data.Transcript[i].Speaker)
+	}
+	data.Participants = append([]string(nil), data.Participants...)
+	for i := range data.Participants {
+		visit(&data.Participants[i])
+	}
+	for i := range data.ActionItems {
+		visit(&data.ActionItems[i].Assignee)
+	}
+	data.Topics = visitTopicParticipants(data.Topics, visit)
+	for i := range data.KeyQuotes {
+		visit(&data.KeyQuotes[i].Speaker)
+	}
+	for i := range data.CrosstalkEvents {
+		data.CrosstalkEvents[i].Speakers = append([]string(nil), data.CrosstalkEvents[i].Speakers...)
+		for j := range data.CrosstalkEvents[i].Speakers {
+			visit(&data.CrosstalkEvents[i].Speakers[j])
+		}
+	}
+	for i := range data.KeyMetrics {
+		visit(&data.KeyMetrics[i].Speaker)
+	}
+	data.InteractionPatterns = append([]InteractionEdge(nil), data.InteractionPatterns...)
+	for i := range data.InteractionPatterns {
+		visit(&data.InteractionPatterns[i].FromSpeaker)
+		visit(&data.InteractionPatterns[i].ToSpeaker)
+	}
+	visit(&data.CentralitySpeaker)
+	data.SpeechPatternAnomalies = append([]PatternAnomaly(nil), data.SpeechPatternAnomalies...)
+	for i := range data.SpeechPatternAnomalies {
+		visit(&data.SpeechPatternAnomalies[i].Speaker)
+	}
+	data.Objections = append([]SalesObjection(nil), data.Objections...)
+	for i := range data.Objections {
+		visit(&data.Objections[i].RaisedBy)
+		visit(&data.Objections[i].HandledBy)
+	}
+	data.EngagementHeatmap = append([]HeatmapRow(nil), data.EngagementHeatmap...)
+	for i := range data.EngagementHeatmap {
+		visit(&data.EngagementHeatmap[i].Speaker)
+	}
+	data.ImportantLinks = append([]LinkMention(nil), data.ImportantLinks...)
+	for i := range data.ImportantLinks {
+		visit(&data.ImportantLinks[i].MentionedBy)
+	}
+	data.AttachmentRefs = append([]AttachmentReference(nil), data.AttachmentRefs...)
+	for i := range data.AttachmentRefs {
+		visit(&data.AttachmentRefs[i].MentionedBy)
+	}
+	if data.TranscriptStatistics != nil {
+		visit(&data.TranscriptStatistics.LongestMonologueSpeaker)
+	}
+	data.ConversationFlow = append([]TopicTransition(nil), data.ConversationFlow...)
+	for i := range data.ConversationFlow {
+		visit(&data.ConversationFlow[i].TriggeredBySpeaker)
+	}
+	data.EthicsFlags = append([]EthicsFlag(nil), data.EthicsFlags...)
+	for i := range data.EthicsFlags {
+		visit(&data.EthicsFlags[i].Speaker)
+	}
+	for i := range data.ComplianceFlags {
+		visit(&data.ComplianceFlags[i].Speaker)
+	}
+	for i := range data.TechnicalDebtMentions {
+		visit(&data.TechnicalDebtMentions[i].MentionedBy)
+	}
+	for i := range data.SuccessMetricsTracked {
+		visit(&data.SuccessMetricsTracked[i].Owner)
+	}
+	for i := range data.BudgetDiscussions {
+		visit(&data.BudgetDiscussions[i].MentionedBy)
+	}
+	for i := range data.QuestionsAndAnswers {
+		visit(&data.QuestionsAndAnswers[i].QuestionedBy)
+		visit(&data.QuestionsAndAnswers[i].AnsweredBy)
+	}
+	for i := range data.UnansweredQuestions {
+		visit(&data.UnansweredQuestions[i].AskedBy)
+	}
+	for i := range data.Requirements {
+		visit(&data.Requirements[i].RequestedBy)
+	}
+	if data.CostEstimate != nil {
+		for i := range data.CostEstimate.ParticipantCosts {
+			visit(&data.CostEstimate.ParticipantCosts[i].Name)
+		}
+	}
+	if data.FollowUpSuggestion != nil {
+		suggestion := *data.FollowUpSuggestion
+		suggestion.SuggestedParticipants = append([]string(nil), suggestion.SuggestedParticipants...)
+		for i := range suggestion.SuggestedParticipants {
+			visit(&suggestion.SuggestedParticipants[i])
+		}
+		data.FollowUpSuggestion = &suggestion
+	}
+	if data.InsightsCard != nil {
+		data.InsightsCard = data.InsightsCard.clone()
+		if item := data.InsightsCard.TopActionItem; item != nil {
+			visit(&item.Assignee)
+		}
+	}
+	for i := range data.Snapshots {
+		snapshot := &data.Snapshots[i]
+		snapshot.ActionItems = append([]ActionItem(nil), snapshot.ActionItems...)
+		for j := range snapshot.ActionItems {
+			visit(&snapshot.ActionItems[j].Assignee)
+		}
+		snapshot.Topics = visitTopicParticipants(snapshot.Topics, visit)
+	}
+}
+
+// visitTopicParticipants returns a copy of topics with visit called on each participant, including those
+// of sub-topics
+func visitTopicParticipants(topics []TopicDiscussion, visit func(name *string)) []TopicDiscussion {
+	if topics == nil {
+		return nil
+	}
+	copied := make([]TopicDiscussion, len(topics))
+	for i, topic := range topics {
+		topic.Participants = append([]string(nil), topic.Participants...)
+		for j := range topic.Participants {
+			visit(&topic.Participants[j])
+		}
+		topic.SubTopics = visitTopicParticipants(topic.SubTopics, visit)
+		copied[i] = topic
+	}
+	return copied
+}
+
+// visitAnalysisText calls visit with every free-text field of data that may mention participants by name.
+// It must run after visitParticipantNames, which copies the shared slices it modifies.
+func visitAnalysisText(data *AnalysisData, visit func(text *string)) {
+	for i := range data.Transcript {
+		visit(&data.Transcript[i].Text)
+	}
+	visit(&data.Summary)
+	visit(&data.EmotionalArcNarrative)
+	visit(&data.TerseSummary)
+	visit(&data.TweetSummary)
+	if data.ROIReport != nil {
+		roi := *data.ROIReport
+		visit(&roi.ComparedToAsyncAlternative)
+		data.ROIReport = &roi
+	}
+	if card := data.InsightsCard; card != nil {
+		visit(&card.Headline)
+		visit(&card.MoodSummary)
+		visit(&card.NextStepsSummary)
+		for i := range card.QuickWins {
+			visit(&card.QuickWins[i])
+		}
+		if card.TopActionItem != nil {
+			visit(&card.TopActionItem.Description)
+			visit(&card.TopActionItem.RaisedInContext)
+		}
+		if card.TopRisk != nil {
+			visit(&card.TopRisk.Description)
+		}
+	}
+	for i := range data.ImportantLinks {
+		visit(&data.ImportantLinks[i].Context)
+	}
+	for i := range data.Objections {
+		visit(&data.Objections[i].ObjectionText)
+		visit(&data.Objections[i].HandlingResponse)
+	}
+	for i := range data.AttachmentRefs {
+		visit(&data.AttachmentRefs[i].Description)
+		visit(&data.AttachmentRefs[i].BlockedWork)
+	}
+	data.KeyPoints = append([]string(nil), data.KeyPoints...)
+	for i := range data.KeyPoints {
+		visit(&data.KeyPoints[i])
+	}
+	for i := range data.ActionItems {
+		visit(&data.ActionItems[i].Description)
+		visit(&data.ActionItems[i].RaisedInContext)
+	}
+	visitTopicSummaries(data.Topics, visit)
+	for i := range data.KeyQuotes {
+		visit(&data.KeyQuotes[i].Text)
+		visit(&data.KeyQuotes[i].Context)
+	}
+	for i := range data.ConflictingStatements {
+		visit(&data.ConflictingStatements[i].Explanation)
+	}
+	for i := range data.Requirements {
+		visit(&data.Requirements[i].Description)
+	}
+	for i := range data.UnansweredQuestions {
+		visit(&data.UnansweredQuestions[i].Question)
+	}
+	for i := range data.QuestionsAndAnswers {
+		visit(&data.QuestionsAndAnswers[i].Question)
+		visit(&data.QuestionsAndAnswers[i].Answer)
+	}
+	for i := range data.KeyMetrics {
+		visit(&data.KeyMetrics[i].Context)
+	}
+	for i := range data.EthicsFlags {
+		visit(&data.EthicsFlags[i].Quote)
+		visit(&data.EthicsFlags[i].ActionRecommended)
+	}
+	for i := range data.ComplianceFlags {
+		visit(&data.ComplianceFlags[i].Description)
+	}
+	for i := range data.StakeholderMap {
+		visit(&data.StakeholderMap[i].Name)
+		for j := range data.StakeholderMap[i].Context {
+			visit(&data.StakeholderMap[i].Context[j])
+		}
+	}
+	for i := range data.TechnicalDebtMentions {
+		visit(&data.TechnicalDebtMentions[i].Description)
+		visit(&data.TechnicalDebtMentions[i].ProposedFix)
+	}
+	if data.NPSProxy != nil {
+		for _, phrases := range [][]string{data.NPSProxy.PromoterPhrases, data.NPSProxy.PassivePhrases, data.NPSProxy.DetractorPhrases} {
+			for i := range phrases {
+				visit(&phrases[i])
+			}
+		}
+	}
+	for i := range data.ProductFeedback {
+		visit(&data.ProductFeedback[i].Description)
+		visit(&data.ProductFeedback[i].Verbatim)
+	}
+	for _, persona := range data.SpeakerPersonas {
+		for i := range persona.SampleQuotes {
+			visit(&persona.SampleQuotes[i])
+		}
+	}
+	if data.FollowUpSuggestion != nil {
+		visit(&data.FollowUpSuggestion.Rationale)
+	}
+	for i := range data.Snapshots {
+		snapshot := &data.Snapshots[i]
+		visit(&snapshot.Summary)
+		snapshot.KeyPoints = append([]string(nil), snapshot.KeyPoints...)
+		for j := range snapshot.KeyPoints {
+			visit(&snapshot.KeyPoints[j])
+		}
+		for j := range snapshot.ActionItems {
+			visit(&snapshot.ActionItems[j].Description)
+			visit(&snapshot.ActionItems[j].RaisedInContext)
+		}
+		visitTopicSummaries(snapshot.Topics, visit)
+	}
+}
+
+// visitTopicSummaries calls visit with the summary of each topic and sub-topic
+func visitTopicSummaries(topics []TopicDiscussion, visit func(text *string)) {
+	for i := range topics {
+		visit(&topics[i].Summary)
+		visitTopicSummaries(topics[i].SubTopics, visit)
+	}
+}
+
+// nameScrubber returns a function replacing whole-word mentions of the mapped names in text, ignoring
+// case. First names of multi-word names are replaced too when no other participant shares them.
+func nameScrubber(pseudonyms map[string]string) func(string) string {
+	replacements := make(map[string]string)
+	firstNames := make(map[string][]string)
+	for name, pseudonym := range pseudonyms {
+		// The default speaker name is also an ordinary word
+		if strings.EqualFold(name, "Participant") {
+			continue
+		}
+		replacements[strings.ToLower(name)] = pseudonym
+		if fields := strings.Fields(name); len(fields) > 1 {
+			first := strings.ToLower(fields[0])
+			firstNames[first] = append(firstNames[first], pseudonym)
+		}
+	}
+	for first, candidates := range firstNames {
+		if _, isName := replacements[first]; !isName && len(candidates) == 1 {
+			replacements[first] = candidates[0]
+		}
+	}
+	if len(replacements) == 0 {
+		return func(text string) string { return text }
+	}
+
+	// Longest first so full names win over the first names they contain
+	patterns := make([]string, 0, len(replacements))
+	for name := range replacements {
+		patterns = append(patterns, name)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for i, name := range patterns {
+		patterns[i] = regexp.QuoteMeta(name)
+	}
+	mention := regexp.MustCompile(`(?i)\b(?:` + strings.Join(patterns, "|") + `)\b`)
+
+	return func(text string) string {
+		return mention.ReplaceAllStringFunc(text, func(match string) string {
+			return replacements[strings.ToLower(match)]
+		})
+	}
+}
+
+// pseudonymLabel returns the pseudonym for index: "Speaker A" to "Speaker Z", then "Speaker AA" and so on
+func pseudonymLabel(index int) string {
+	label := ""
+	for index >= 0 {
+		label = string(rune('A'+index%26)) + label
+		index = index/26 - 1
+	}
+	return "Speaker " + label
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newObfuscationAnalyst(t *testing.T) *AnalystAgent {
+	analyst := newTestAnalyst(t)
+	analyst.data.Transcript = []TranscriptEntry{
+		entryAt(0, "Maya Patel", "Sam, can you send the deck?"),
+		entryAt(5, "Sam Ortiz", "Sure Maya, after I talk to Maya Chen"),
+		entryAt(9, "Maya Chen", "Thanks Sam Ortiz"),
+	}
+	analyst.data.Participants = []string{"Maya Patel", "Sam Ortiz", "Maya Chen"}
+	analyst.data.Summary = "Sam Ortiz will send the deck to Maya Patel."
+	analyst.data.ActionItems = []ActionItem{{ID: "1", Description: "Send the deck", Assignee: "Sam Ortiz"}}
+	analyst.data.SpeakerEngagementMap = map[string]EngagementStats{"Sam Ortiz": {Turns: 1}}
+	return analyst
+}
+
+func TestObfuscateParticipantsIsDeterministic(t *testing.T) {
+	analyst := newObfuscationAnalyst(t)
+
+	first := analyst.ObfuscateParticipants(42)
+	if second := analyst.ObfuscateParticipants(42); !reflect.DeepEqual(first.Participants, second.Participants) {
+		t.Errorf("participants = %q then %q, want the same mapping for a seed", first.Participants, second.Participants)
+	}
+
+	seen := make(map[string]bool)
+	for _, pseudonym := range first.Participants {
+		if !strings.HasPrefix(pseudonym, "Speaker ") || seen[pseudonym] {
+			t.Errorf("participants = %q, want distinct pseudonyms", first.Participants)
+		}
+		seen[pseudonym] = true
+	}
+
+	differs := false
+	for seed := int64(0); seed < 10 && !differs; seed++ {
+		differs = !reflect.DeepEqual(analyst.ObfuscateParticipants(seed).Participants, first.Participants)
+	}
+	if !differs {
+		t.Error("every seed gave the same mapping")
+	}
+}
+
+func TestObfuscateParticipantsReplacesMentions(t *testing.T) {
+	analyst := newObfuscationAnalyst(t)
+	data := analyst.ObfuscateParticipants(7)
+
+	pseudonym := make(map[string]string)
+	for i, name := range []string{"Maya Patel", "Sam Ortiz", "Maya Chen"} {
+		pseudonym[name] = data.Participants[i]
+	}
+	sam := pseudonym["Sam Ortiz"]
+
+	// "Sam" is unique and replaced, "Maya" is shared by two participants and left alone
+	if want := sam + ", can you send the deck?"; data.Transcript[0].Text != want {
+		t.Errorf("text = %q, want %q", data.Transcript[0].Text, want)
+	}
+	if want := "Sure Maya, after I talk to " + pseudonym["Maya Chen"]; data.Transcript[1].Text != want {
+		t.Errorf("text = %q, want %q", data.Transcript[1].Text, want)
+	}
+	if want := sam + " will send the deck to " + pseudonym["Maya Patel"] + "."; data.Summary != want {
+		t.Errorf("summary = %q, want %q", data.Summary, want)
+	}
+	if data.Transcript[1].Speaker != sam || data.ActionItems[0].Assignee != sam {
+		t.Errorf("speaker %q, assignee %q, want %q", data.Transcript[1].Speaker, data.ActionItems[0].Assignee, sam)
+	}
+	if _, ok := data.SpeakerEngagementMap[sam]; !ok {
+		t.Errorf("engagement = %v, want keyed by pseudonym", data.SpeakerEngagementMap)
+	}
+
+	original := analyst.GetAnalysis()
+	if original.Participants[0] != "Maya Patel" || original.ActionItems[0].Assignee != "Sam Ortiz" {
+		t.Error("agent's analysis was modified")
+	}
+}
+
+func TestPseudonymLabel(t *testing.T) {
+	tests := map[int]string{0: "Speaker A", 25: "Speaker Z", 26: "Speaker AA", 27: "Speaker AB", 52: "Speaker BA"}
+	for index, want := range tests {
+		if got := pseudonymLabel(index); got != want {
+			t.Errorf("pseudonymLabel(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
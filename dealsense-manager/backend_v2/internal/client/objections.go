@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+const (
+	// salesObjectionsTranscript is the number of recent transcript entries objections are extracted from
+	salesObjectionsTranscript = 100
+	// salesObjectionTimeout bounds posting an objection to the sales objection webhook
+	salesObjectionTimeout = 10 * time.Second
+	// salesCallMeetingType is the meeting type objections are extracted for
+	salesCallMeetingType = "sales_call"
+)
+
+// salesObjectionHTTPClient posts detected objections to the sales objection webhook
+var salesObjectionHTTPClient = &http.Client{Timeout: salesObjectionTimeout}
+
+// What a sales objection is about
+const (
+	ObjectionPrice       = "price"
+	ObjectionTiming      = "timing"
+	ObjectionCompetition = "competition"
+	ObjectionNeed        = "need"
+	ObjectionAuthority   = "authority"
+	ObjectionProduct     = "product"
+)
+
+// How a sales objection ended
+const (
+	ObjectionResolved   = "resolved"
+	ObjectionUnresolved = "unresolved"
+	ObjectionDeferred   = "deferred"
+)
+
+// SalesObjection is a concern a prospect raised against buying, and how the sales side handled it
+type SalesObjection struct {
+	ObjectionText    string    `json:"objection_text"`
+	ObjectionType    string    `json:"objection_type"` // price, timing, competition, need, authority, product
+	RaisedBy         string    `json:"raised_by"`
+	Timestamp        time.Time `json:"timestamp"`
+	Handled          bool      `json:"handled"`
+	HandledBy        string    `json:"handled_by,omitempty"`
+	HandlingResponse string    `json:"handling_response,omitempty"`
+	Outcome          string    `json:"outcome"` // resolved, unresolved, deferred
+
+	CompetitorClaimCheck string `json:"competitor_claim_check,omitempty"` // What web search found about the claim of a competition objection
+}
+
+// salesObjectionEvent is posted to the sales objection webhook for each objection detected
+type salesObjectionEvent struct {
+	AgentID   string         `json:"agent_id"`
+	MeetingID string         `json:"meeting_id"`
+	Objection SalesObjection `json:"objection"`
+}
+
+// SetSalesObjectionWebhook sets the URL each detected sales objection is posted to, "" to keep them in the
+// analysis only
+func (a *AnalystAgent) SetSalesObjectionWebhook(url string) {
+	a.salesObjectionWebhook = url
+}
+
+// extractSalesObjections finds the objections prospects raised in a sales call and how they were handled.
+// Objections accumulate across analysis runs, an objection extracted again updating its handling and
+// outcome; the claims of new competition objections are checked with a web search when the provider
+// supports grounding. Each new objection is posted to the sales objection webhook right away.
+func (a *AnalystAgent) extractSalesObjections(ctx context.Context) error {
+	if a.config.MeetingType != salesCallMeetingType {
+		return nil
+	}
+
+	transcript := a.getRecentTranscript(salesObjectionsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting sales objections from %d transcript entries", a.agentID, len(transcript))
+
+	var result struct {
+		Objections []struct {
+			ObjectionStatement int    `json:"objection_statement"`
+			Objection          string `json:"objection"`
+			Type               string `json:"type"`
+			ResponseStatement  int    `json:"response_statement"`
+			Response           string `json:"response"`
+			Outcome            string `json:"outcome"`
+		} `json:"objections"`
+	}
+	request := indexedStatementsRequest{
+		task: `This is a sales call. Identify every objection the prospect side raised against buying, and how the sales side handled it.
+
+Classify each objection as one of:
+- price: the cost, budget or pricing model
+- timing: not now, other priorities, or a timeline that doesn't fit
+- competition: a competitor or the prospect's current solution, including claims about what a competitor offers
+- need: doubt that they need the product or that it is worth it
+- authority: the speaker can't decide alone and needs approval from someone else
+- product: missing features, integrations, security or reliability concerns
+
+For each objection, give:
+- The index of the statement raising it, and the objection in one sentence
+- The index of the sales side's statement responding to it, or -1 when nobody responded
+- The response, summarized in one sentence
+- Outcome: resolved when the prospect accepted the response, deferred when both sides agreed to come back to it, unresolved otherwise`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "objections": [
+    {
+      "objection_statement": 12,
+      "objection": "The price is higher than their current vendor's.",
+      "type": "price/timing/competition/need/authority/product",
+      "response_statement": 13,
+      "response": "Offered a volume discount for a two-year contract.",
+      "outcome": "resolved/unresolved/deferred"
+    }
+  ]
+}`,
+		result: "sales objections",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return err
+	}
+
+	var found []SalesObjection
+	for _, candidate := range result.Objections {
+		raised, ok := statementAt(transcript, candidate.ObjectionStatement)
+		if !ok {
+			continue
+		}
+		objection := SalesObjection{
+			ObjectionText: strings.TrimSpace(candidate.Objection),
+			ObjectionType: normalizeObjectionType(candidate.Type),
+			RaisedBy:      raised.Speaker,
+			Timestamp:     raised.Timestamp,
+			Outcome:       normalizeObjectionOutcome(candidate.Outcome),
+		}
+		if objection.ObjectionText == "" {
+			objection.ObjectionText = raised.Text
+		}
+
+		if handling, ok := replyAt(transcript, candidate.ObjectionStatement, candidate.ResponseStatement); ok {
+			objection.Handled = true
+			objection.HandledBy = handling.Speaker
+			objection.HandlingResponse = strings.TrimSpace(candidate.Response)
+			if objection.HandlingResponse == "" {
+				objection.HandlingResponse = handling.Text
+			}
+		} else if objection.Outcome == ObjectionResolved {
+			objection.Outcome = ObjectionUnresolved
+		}
+		found = append(found, objection)
+	}
+
+	a.dataMutex.RLock()
+	var added []SalesObjection
+	for _, objection := range found {
+		if findSalesObjection(a.data.Objections, objection) < 0 {
+			added = append(added, objection)
+		}
+	}
+	meetingID := a.data.MeetingID
+	a.dataMutex.RUnlock()
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		if err := a.checkCompetitorClaims(ctx, groundingProvider, added); err != nil {
+			logrus.Warnf("Agent %s: Failed to check competitor claims in objections: %v", a.agentID, err)
+		}
+	}
+
+	a.dataMutex.Lock()
+	// New objections are added with their claim checks first, which merging the extracted ones keeps
+	for _, objection := range added {
+		a.data.Objections = mergeSalesObjection(a.data.Objections, objection)
+	}
+	for _, objection := range found {
+		a.data.Objections = mergeSalesObjection(a.data.Objections, objection)
+	}
+	total := len(a.data.Objections)
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Tracking %d sales objections, %d new", a.agentID, total, len(added))
+
+	for _, objection := range added {
+		if err := a.postSalesObjection(meetingID, objection); err != nil {
+			logrus.Warnf("Agent %s: Failed to post sales objection: %v", a.agentID, err)
+		}
+	}
+	return nil
+}
+
+// findSalesObjection returns the index of the objection raised by the same speaker at the same time, or -1
+func findSalesObjection(objections []SalesObjection, objection SalesObjection) int {
+	for i, existing := range objections {
+		if existing.RaisedBy == objection.RaisedBy && existing.Timestamp.Equal(objection.Timestamp) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeSalesObjection adds an objection, or replaces the one raised by the same speaker at the same time,
+// keeping its competitor claim check
+func mergeSalesObjection(objections []SalesObjection, objection SalesObjection) []SalesObjection {
+	if i := findSalesObjection(objections, objection); i >= 0 {
+		if objection.CompetitorClaimCheck == "" {
+			objection.CompetitorClaimCheck = objections[i].CompetitorClaimCheck
+		}
+		objections[i] = objection
+		return objections
+	}
+	return append(objections, objection)
+}
+
+// checkCompetitorClaims asks a web search about the claims of the competition objections, storing what it
+// found on each objection
+func (a *AnalystAgent) checkCompetitorClaims(ctx context.Context, provider llm.GroundingCapableProvider, objections []SalesObjection) error {
+	var lines []string
+	numbers := make(map[int]int)
+	for i, objection := range objections {
+		if objection.ObjectionType != ObjectionCompetition {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s", len(lines)+1, objection.ObjectionText))
+		numbers[len(lines)] = i
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to check the claims about competitors in these objections a prospect raised in a sales call. For each, say in one sentence whether search results confirm, contradict or can't confirm the claim, citing what they say.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "checks": [
+    {"number": 1, "check": "Confirmed: the competitor's pricing page lists a free tier."}
+  ]
+}
+`+"`"+``, strings.Join(lines, "\n"))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil || response.GroundingMetadata == nil || len(response.GroundingMetadata.GroundingChunks) == 0 {
+		return fmt.Errorf("no search results to check competitor claims against")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in competitor claim check response")
+	}
+
+	var result struct {
+		Checks []struct {
+			Number int    `json:"number"`
+			Check  string `json:"check"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse competitor claim check JSON: %w", err)
+	}
+	for _, check := range result.Checks {
+		if i, ok := numbers[check.Number]; ok {
+			objections[i].CompetitorClaimCheck = strings.TrimSpace(check.Check)
+		}
+	}
+	return nil
+}
+
+// postSalesObjection posts a newly detected objection to the sales objection webhook, queueing it for retry
+// when the post fails. Nothing is posted without a webhook.
+func (a *AnalystAgent) postSalesObjection(meetingID string, objection SalesObjection) error {
+	if a.salesObjectionWebhook == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(salesObjectionEvent{AgentID: a.agentID, MeetingID: meetingID, Objection: objection})
+	if err != nil {
+		return fmt.Errorf("failed to marshal sales objection: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), salesObjectionTimeout)
+	defer cancel()
+
+	if err := postWebhook(ctx, salesObjectionHTTPClient, a.salesObjectionWebhook, nil, payload); err != nil {
+		if !a.queueWebhookRetry("sales_objection", a.salesObjectionWebhook, payload, err) {
+			return fmt.Errorf("failed to post sales objection: %w", err)
+		}
+	}
+	return nil
+}
+
+// normalizeObjectionType maps the LLM's objection type onto the supported set, defaulting to need
+func normalizeObjectionType(objectionType string) string {
+	switch objectionType = strings.ToLower(strings.TrimSpace(objectionType)); objectionType {
+	case ObjectionPrice, ObjectionTiming, ObjectionCompetition, ObjectionNeed, ObjectionAuthority, ObjectionProduct:
+		return objectionType
+	case "cost", "budget", "pricing":
+		return ObjectionPrice
+	case "time", "timeline", "priority":
+		return ObjectionTiming
+	case "competitor", "competitors", "incumbent":
+		return ObjectionCompetition
+	case "decision maker", "approval":
+		return ObjectionAuthority
+	case "feature", "features", "integration", "security":
+		return ObjectionProduct
+	default:
+		return ObjectionNeed
+	}
+}
+
+// normalizeObjectionOutcome maps the LLM's outcome onto the supported set, defaulting to unresolved
+func normalizeObjectionOutcome(outcome string) string {
+	switch outcome = strings.ToLower(strings.TrimSpace(outcome)); outcome {
+	case ObjectionResolved, ObjectionDeferred:
+		return outcome
+	default:
+		return ObjectionUnresolved
+	}
+}
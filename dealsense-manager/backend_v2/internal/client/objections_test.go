@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestNormalizeObjectionType(t *testing.T) {
+	cases := map[string]string{
+		" Price ":        ObjectionPrice,
+		"budget":         ObjectionPrice,
+		"timeline":       ObjectionTiming,
+		"incumbent":      ObjectionCompetition,
+		"decision maker": ObjectionAuthority,
+		"integration":    ObjectionProduct,
+		"AUTHORITY":      ObjectionAuthority,
+		"vibes":          ObjectionNeed,
+	}
+	for objectionType, want := range cases {
+		if got := normalizeObjectionType(objectionType); got != want {
+			t.Errorf("normalizeObjectionType(%q) = %q, want %q", objectionType, got, want)
+		}
+	}
+}
+
+func TestExtractSalesObjections(t *testing.T) {
+	var posted []salesObjectionEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event salesObjectionEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("invalid objection event: %s", body)
+		}
+		posted = append(posted, event)
+	}))
+	defer server.Close()
+
+	analyst := newTestAnalyst(t)
+	analyst.config.MeetingType = salesCallMeetingType
+	analyst.SetSalesObjectionWebhook(server.URL)
+	response := "```json\n" + `{"objections": [
+		{"objection_statement": 0, "objection": "Too expensive compared to today", "type": "cost", "response_statement": 1, "response": "Offered a discount", "outcome": "resolved"},
+		{"objection_statement": 2, "objection": "Legal has to approve it", "type": "approval", "response_statement": -1, "outcome": "resolved"}
+	]}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(response, response)
+	say(analyst, 0, "Prospect", "This is too expensive for us right now")
+	say(analyst, 10, "Rep", "We can offer a discount for two years")
+	say(analyst, 20, "Prospect", "Our legal team has to approve it anyway")
+
+	ctx := context.Background()
+	if err := analyst.extractSalesObjections(ctx); err != nil {
+		t.Fatalf("extractSalesObjections() error = %v", err)
+	}
+	// Extracting again updates the objections without posting them again
+	if err := analyst.extractSalesObjections(ctx); err != nil {
+		t.Fatalf("second extractSalesObjections() error = %v", err)
+	}
+
+	objections := analyst.GetAnalysis().Objections
+	if len(objections) != 2 {
+		t.Fatalf("objections = %+v, want 2", objections)
+	}
+	price := objections[0]
+	if price.ObjectionType != ObjectionPrice || !price.Handled || price.HandledBy != "Rep" || price.Outcome != ObjectionResolved {
+		t.Errorf("price objection = %+v", price)
+	}
+	// Nobody responded, so the objection can't have been resolved
+	authority := objections[1]
+	if authority.ObjectionType != ObjectionAuthority || authority.Handled || authority.Outcome != ObjectionUnresolved {
+		t.Errorf("authority objection = %+v", authority)
+	}
+	if len(posted) != 2 || posted[0].Objection.RaisedBy != "Prospect" {
+		t.Errorf("posted = %+v, want each objection once", posted)
+	}
+}
+
+func TestExtractSalesObjectionsOnlyForSalesCalls(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider()
+	analyst.llmProvider = mock
+	say(analyst, 0, "Prospect", "This is too expensive for us right now")
+
+	if err := analyst.extractSalesObjections(context.Background()); err != nil {
+		t.Fatalf("extractSalesObjections() error = %v", err)
+	}
+	if prompts := mock.Prompts(); len(prompts) != 0 {
+		t.Errorf("LLM called %d times outside a sales call", len(prompts))
+	}
+}
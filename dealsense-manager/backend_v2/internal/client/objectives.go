@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/models"
+)
+
+// ObjectiveStatus is whether the meeting achieved one of its objectives
+type ObjectiveStatus struct {
+	Achieved   bool    `json:"achieved"`
+	Evidence   string  `json:"evidence,omitempty"` // Quote from the summary or topics showing the objective was or wasn't met
+	Confidence float64 `json:"confidence"`
+}
+
+// objectiveKey is the key of an objective's status: its name, or its question when it has no name
+func objectiveKey(objective models.MeetingObjective) string {
+	if name := strings.TrimSpace(objective.Name); name != "" {
+		return name
+	}
+	return strings.TrimSpace(objective.Question)
+}
+
+// objectivesStep is the analysis step checking the meeting's objectives against the summary and topics
+func (a *AnalystAgent) objectivesStep() analysisStep {
+	return analysisStep{name: "objectives", description: "evaluate meeting objectives", run: a.evaluateObjectives}
+}
+
+// evaluateObjectives asks the LLM whether the summary and topics show each of the agent's objectives was
+// achieved, storing the status of each and the share of them achieved
+func (a *AnalystAgent) evaluateObjectives(ctx context.Context) error {
+	var objectives []models.MeetingObjective
+	for _, objective := range a.config.Objectives {
+		if objectiveKey(objective) != "" {
+			objectives = append(objectives, objective)
+		}
+	}
+	if len(objectives) == 0 {
+		return nil
+	}
+
+	a.dataMutex.RLock()
+	summary := a.data.Summary
+	topics := FlattenTopics(a.data.Topics)
+	a.dataMutex.RUnlock()
+
+	if summary == "" && len(topics) == 0 {
+		return nil
+	}
+
+	var topicList strings.Builder
+	for _, topic := range topics {
+		topicList.WriteString(fmt.Sprintf("- %s: %s\n", topic.Topic, topic.Summary))
+	}
+	var questions strings.Builder
+	for i, objective := range objectives {
+		question := strings.TrimSpace(objective.Question)
+		if question == "" {
+			question = objective.Name
+		}
+		questions.WriteString(fmt.Sprintf("%d. %s\n", i+1, question))
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`A meeting had these objectives, each phrased as a yes/no question. Using only the meeting summary and discussion topics below, answer each question: yes when the summary or topics show the objective was achieved, no otherwise.
+
+For each objective, quote the sentence from the summary or topics your answer is based on, and give your confidence in the answer from 0 to 1.
+
+Objectives:
+%s
+Summary:
+%s
+
+Topics:
+%s
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "objectives": [
+    {"number": 1, "achieved": true, "evidence": "Quote from the summary or topics", "confidence": 0.8}
+  ]
+}
+`+"`"+``, questions.String(), summary, topicList.String())
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in objectives response")
+	}
+
+	var result struct {
+		Objectives []struct {
+			Number int `json:"number"`
+			ObjectiveStatus
+		} `json:"objectives"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse objectives JSON: %w", err)
+	}
+
+	statuses := make(map[string]ObjectiveStatus, len(objectives))
+	for _, objective := range objectives {
+		statuses[objectiveKey(objective)] = ObjectiveStatus{}
+	}
+	for _, answer := range result.Objectives {
+		if answer.Number < 1 || answer.Number > len(objectives) {
+			continue
+		}
+		status := answer.ObjectiveStatus
+		status.Evidence = strings.TrimSpace(status.Evidence)
+		status.Confidence = math.Max(0, math.Min(1, status.Confidence))
+		statuses[objectiveKey(objectives[answer.Number-1])] = status
+	}
+
+	rate := objectivesAchievementRate(statuses)
+	a.dataMutex.Lock()
+	a.data.ObjectiveCompletionStatus = statuses
+	a.data.ObjectivesAchievementRate = rate
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Achieved %.0f%% of %d meeting objectives", a.agentID, rate*100, len(statuses))
+	return nil
+}
+
+// objectivesAchievementRate returns the fraction of objectives achieved, or 0 when there are none
+func objectivesAchievementRate(statuses map[string]ObjectiveStatus) float64 {
+	if len(statuses) == 0 {
+		return 0
+	}
+	achieved := 0
+	for _, status := range statuses {
+		if status.Achieved {
+			achieved++
+		}
+	}
+	return float64(achieved) / float64(len(statuses))
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+func TestEvaluateObjectives(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.Objectives = []models.MeetingObjective{
+		{Name: "Budget", Question: "Was the Q3 budget approved?"},
+		{Question: "Was a launch date agreed?"},
+	}
+	analyst.data.Summary = "The team approved the Q3 budget and set the launch for June 3."
+
+	cases := []struct {
+		name     string
+		response string
+		rate     float64
+	}{
+		{"all achieved", `{"objectives": [
+			{"number": 1, "achieved": true, "evidence": "approved the Q3 budget", "confidence": 0.9},
+			{"number": 2, "achieved": true, "evidence": "set the launch for June 3", "confidence": 0.8}
+		]}`, 1},
+		// Objectives the LLM leaves out count as not achieved
+		{"one answered", `{"objectives": [{"number": 1, "achieved": true, "confidence": 0.9}, {"number": 7, "achieved": true}]}`, 0.5},
+	}
+	for _, c := range cases {
+		mock := llm.NewMockLLMProvider("```json\n" + c.response + "\n```")
+		analyst.llmProvider = mock
+		if err := analyst.evaluateObjectives(context.Background()); err != nil {
+			t.Fatalf("%s: evaluateObjectives() error = %v", c.name, err)
+		}
+
+		analysis := analyst.GetAnalysis()
+		if !approx(analysis.ObjectivesAchievementRate, c.rate) {
+			t.Errorf("%s: ObjectivesAchievementRate = %v, want %v", c.name, analysis.ObjectivesAchievementRate, c.rate)
+		}
+		statuses := analysis.ObjectiveCompletionStatus
+		if len(statuses) != 2 || !statuses["Budget"].Achieved {
+			t.Errorf("%s: ObjectiveCompletionStatus = %+v", c.name, statuses)
+		}
+		if _, ok := statuses["Was a launch date agreed?"]; !ok {
+			t.Errorf("%s: unnamed objective isn't keyed by its question: %+v", c.name, statuses)
+		}
+		if prompt := mock.Prompts()[0]; !strings.Contains(prompt, "1. Was the Q3 budget approved?\n2. Was a launch date agreed?\n") {
+			t.Errorf("%s: prompt doesn't number the objectives:\n%s", c.name, prompt)
+		}
+	}
+}
+
+func TestEvaluateObjectivesNeedsSummary(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.Objectives = []models.MeetingObjective{{Name: "Budget", Question: "Was the budget approved?"}}
+	analyst.llmProvider = llm.NewMockLLMProvider()
+
+	if err := analyst.evaluateObjectives(context.Background()); err != nil {
+		t.Fatalf("evaluateObjectives() error = %v", err)
+	}
+	if statuses := analyst.GetAnalysis().ObjectiveCompletionStatus; len(statuses) != 0 {
+		t.Errorf("ObjectiveCompletionStatus = %+v, want nothing evaluated without a summary", statuses)
+	}
+}
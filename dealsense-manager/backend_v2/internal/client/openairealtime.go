@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// realtimeDialTimeout bounds the WebSocket handshake with the Realtime API
+	realtimeDialTimeout = 15 * time.Second
+	// realtimeRetryMin and realtimeRetryMax bound the backoff between reconnection attempts
+	realtimeRetryMin = time.Second
+	realtimeRetryMax = 30 * time.Second
+	// realtimeAssistantSpeaker is the speaker of transcripts of the model's own audio responses
+	realtimeAssistantSpeaker = "Assistant"
+)
+
+// realtimeEvent is the part of an OpenAI Realtime API server event that carries transcript text
+type realtimeEvent struct {
+	Type       string `json:"type"`
+	ItemID     string `json:"item_id"`
+	Delta      string `json:"delta"`
+	Transcript string `json:"transcript"`
+	Error      *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// realtimeItem is the transcript of a conversation item accumulated from its delta events
+type realtimeItem struct {
+	speaker string
+	text    strings.Builder
+	started time.Time
+}
+
+// StartOpenAIRealtimeConsumer connects to an OpenAI Realtime API WebSocket and adds the transcripts of its
+// conversation items to the transcript. Deltas (input_audio_transcription.delta for the meeting audio,
+// response.audio_transcript.delta for the model's replies) are accumulated per item ID, and each item is
+// passed to ProcessUtterance once its completed or done event arrives, preferring the event's full
+// transcript over the accumulated deltas. Connections closed abnormally (1006) or by a server error (1011)
+// are re-established with backoff; other closes end the consumer. It blocks until ctx is cancelled or the
+// server ends the session, returning nil, or the connection fails permanently.
+func (a *AnalystAgent) StartOpenAIRealtimeConsumer(ctx context.Context, websocketURL, apiKey string) error {
+	if websocketURL == "" || apiKey == "" {
+		return fmt.Errorf("realtime WebSocket URL and API key are required")
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+apiKey)
+	header.Set("OpenAI-Beta", "realtime=v1")
+	dialer := &websocket.Dialer{HandshakeTimeout: realtimeDialTimeout, Proxy: http.ProxyFromEnvironment}
+
+	backoff := realtimeRetryMin
+	for {
+		conn, resp, err := dialer.DialContext(ctx, websocketURL, header)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// A rejected handshake won't succeed on retry
+			if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+				return fmt.Errorf("realtime API rejected the connection with status %d", resp.StatusCode)
+			}
+			logrus.Warnf("Agent %s: Failed to connect to the Realtime API, retrying in %v: %v", a.agentID, backoff, err)
+		} else {
+			logrus.Infof("Agent %s: Consuming transcript from the Realtime API at %s", a.agentID, websocketURL)
+			backoff = realtimeRetryMin
+
+			err = a.consumeRealtimeEvents(ctx, conn)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				logrus.Infof("Agent %s: Realtime API session ended", a.agentID)
+				return nil
+			}
+			if !websocket.IsCloseError(err, websocket.CloseAbnormalClosure, websocket.CloseInternalServerErr) {
+				return fmt.Errorf("realtime API connection closed: %w", err)
+			}
+			logrus.Warnf("Agent %s: Realtime API connection lost, reconnecting in %v: %v", a.agentID, backoff, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, realtimeRetryMax)
+	}
+}
+
+// consumeRealtimeEvents reads events from the connection until it fails or ctx is cancelled, returning the
+// read error. Items still accumulating deltas when the connection is lost are dropped, as their completed
+// events won't be delivered on a new connection.
+func (a *AnalystAgent) consumeRealtimeEvents(ctx context.Context, conn *websocket.Conn) error {
+	// Closing the connection unblocks the read when ctx is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+			conn.Close()
+		}
+	}()
+
+	items := make(map[string]*realtimeItem)
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var event realtimeEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			logrus.Errorf("Agent %s: Skipping Realtime API message that is not an event: %v", a.agentID, err)
+			continue
+		}
+		a.handleRealtimeEvent(items, event)
+	}
+}
+
+// handleRealtimeEvent accumulates a transcript delta, or passes a completed item's transcript to
+// ProcessUtterance. Events without transcript text are ignored.
+func (a *AnalystAgent) handleRealtimeEvent(items map[string]*realtimeItem, event realtimeEvent) {
+	switch event.Type {
+	case "conversation.item.input_audio_transcription.delta", "response.audio_transcript.delta":
+		item, ok := items[event.ItemID]
+		if !ok {
+			item = &realtimeItem{started: time.Now()}
+			if event.Type == "response.audio_transcript.delta" {
+				item.speaker = realtimeAssistantSpeaker
+			}
+			items[event.ItemID] = item
+		}
+		item.text.WriteString(event.Delta)
+
+	case "conversation.item.input_audio_transcription.completed", "response.audio_transcript.done":
+		item, ok := items[event.ItemID]
+		delete(items, event.ItemID)
+		if !ok {
+			item = &realtimeItem{started: time.Now()}
+			if event.Type == "response.audio_transcript.done" {
+				item.speaker = realtimeAssistantSpeaker
+			}
+		}
+
+		text := strings.TrimSpace(event.Transcript)
+		if text == "" {
+			text = strings.TrimSpace(item.text.String())
+		}
+		if text == "" {
+			return
+		}
+		a.ProcessUtterance([]map[string]interface{}{{
+			"speaker":   item.speaker,
+			"text":      text,
+			"timestamp": float64(item.started.UnixNano()) / float64(time.Second),
+		}})
+
+	case "error":
+		if event.Error != nil {
+			logrus.Warnf("Agent %s: Realtime API error: %s", a.agentID, event.Error.Message)
+		}
+	}
+}
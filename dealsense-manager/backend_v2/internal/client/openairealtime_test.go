@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// realtimeServer is a mock Realtime API running session on each accepted connection, returning its
+// WebSocket URL
+func realtimeServer(t *testing.T, session func(conn *websocket.Conn, attempt int)) string {
+	t.Helper()
+	var attempts atomic.Int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("OpenAI-Beta") != "realtime=v1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		session(conn, int(attempts.Add(1)))
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// endSession closes the connection the way the Realtime API does when a session ends
+func endSession(conn *websocket.Conn) {
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	conn.ReadMessage()
+}
+
+func TestRealtimeConsumerAddsCompletedItems(t *testing.T) {
+	url := realtimeServer(t, func(conn *websocket.Conn, attempt int) {
+		for _, event := range []string{
+			`{"type": "conversation.item.input_audio_transcription.delta", "item_id": "item_1", "delta": "Let's review "}`,
+			`{"type": "response.audio_transcript.delta", "item_id": "item_2", "delta": "Sure"}`,
+			`not an event`,
+			`{"type": "conversation.item.input_audio_transcription.delta", "item_id": "item_1", "delta": "the rollout plan."}`,
+			`{"type": "conversation.item.input_audio_transcription.completed", "item_id": "item_1"}`,
+			`{"type": "error", "error": {"message": "rate limited"}}`,
+			`{"type": "response.audio_transcript.done", "item_id": "item_2", "transcript": "Sure, opening the plan now."}`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		endSession(conn)
+	})
+
+	analyst := newTestAnalyst(t)
+	if err := analyst.StartOpenAIRealtimeConsumer(context.Background(), url, "secret"); err != nil {
+		t.Fatalf("StartOpenAIRealtimeConsumer() = %v", err)
+	}
+
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != 2 {
+		t.Fatalf("transcript = %+v, want 2 utterances", transcript)
+	}
+	// The accumulated deltas stand in for a completed event without a transcript
+	if transcript[0].Text != "Let's review the rollout plan." {
+		t.Errorf("first utterance = %q, want the accumulated deltas", transcript[0].Text)
+	}
+	// The done event's full transcript is preferred over the deltas
+	if transcript[1].Text != "Sure, opening the plan now." || transcript[1].Speaker != realtimeAssistantSpeaker {
+		t.Errorf("second utterance = %s: %q, want the assistant's full transcript", transcript[1].Speaker, transcript[1].Text)
+	}
+}
+
+func TestRealtimeConsumerReconnectsAfterAbnormalClose(t *testing.T) {
+	url := realtimeServer(t, func(conn *websocket.Conn, attempt int) {
+		if attempt == 1 {
+			// Dropping the connection without a close frame is an abnormal closure, losing the pending item
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "response.audio_transcript.delta", "item_id": "lost", "delta": "Never finished"}`))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "conversation.item.input_audio_transcription.completed", "item_id": "item_1", "transcript": "Back on the call."}`))
+		endSession(conn)
+	})
+
+	analyst := newTestAnalyst(t)
+	start := time.Now()
+	if err := analyst.StartOpenAIRealtimeConsumer(context.Background(), url, "secret"); err != nil {
+		t.Fatalf("StartOpenAIRealtimeConsumer() = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < realtimeRetryMin {
+		t.Errorf("reconnected after %s, want a backoff of at least %s", elapsed, realtimeRetryMin)
+	}
+
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != 1 || transcript[0].Text != "Back on the call." {
+		t.Errorf("transcript = %+v, want only the item from the second connection", transcript)
+	}
+}
+
+func TestRealtimeConsumerRejectedHandshake(t *testing.T) {
+	url := realtimeServer(t, func(conn *websocket.Conn, attempt int) {
+		t.Error("connection accepted with a bad API key")
+	})
+
+	analyst := newTestAnalyst(t)
+	err := analyst.StartOpenAIRealtimeConsumer(context.Background(), url, "wrong")
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("StartOpenAIRealtimeConsumer() = %v, want a status 401 error", err)
+	}
+}
+
+func TestRealtimeConsumerStopsOnCancel(t *testing.T) {
+	url := realtimeServer(t, func(conn *websocket.Conn, attempt int) {
+		conn.ReadMessage()
+	})
+
+	analyst := newTestAnalyst(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- analyst.StartOpenAIRealtimeConsumer(ctx, url, "secret") }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("StartOpenAIRealtimeConsumer() = %v, want nil after cancel", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("consumer didn't stop when its context was cancelled")
+	}
+}
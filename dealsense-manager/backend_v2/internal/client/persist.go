@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/storage"
+)
+
+// analysisWriter saves an agent's analysis snapshots one at a time, outside dataMutex, so slow storage
+// such as a Redis backend retrying a publish doesn't block readers of the analysis. Snapshots queued while
+// a save is running are coalesced into the newest one. The writer goroutine exits when nothing is queued.
+type analysisWriter struct {
+	mu      sync.Mutex
+	pending *pendingSave
+	running bool
+}
+
+// pendingSave is the newest queued snapshot and who is waiting for it to be saved
+type pendingSave struct {
+	doc     storage.Document
+	waiters []chan error
+	queued  bool // Also requested by a caller not waiting for the result, so failures are logged
+}
+
+// saveAnalysis saves the current analysis and waits until it is written. The caller must not hold
+// dataMutex.
+func (a *AnalystAgent) saveAnalysis() error {
+	a.dataMutex.RLock()
+	doc, err := a.analysisDocument()
+	a.dataMutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	a.enqueueSave(doc, done)
+	return <-done
+}
+
+// queueSave snapshots the analysis for the writer to save in the background, logging failures. Caller
+// must hold dataMutex.
+func (a *AnalystAgent) queueSave() {
+	doc, err := a.analysisDocument()
+	if err != nil {
+		logrus.Errorf("Failed to save analysis for agent %s: %v", a.agentID, err)
+		return
+	}
+	a.enqueueSave(doc, nil)
+}
+
+// analysisDocument serializes the analysis for saving (caller must hold dataMutex)
+func (a *AnalystAgent) analysisDocument() (storage.Document, error) {
+	data, err := json.MarshalIndent(a.data, "", "  ")
+	if err != nil {
+		return storage.Document{}, fmt.Errorf("failed to marshal analysis data: %w", err)
+	}
+	return storage.Document{
+		Path:      a.filePath,
+		TenantID:  a.data.TenantID,
+		MeetingID: a.data.MeetingID,
+		Data:      data,
+	}, nil
+}
+
+// enqueueSave hands doc to the writer, replacing a snapshot still waiting to be saved, and starts the
+// writer when it isn't running. done, when not nil, receives the result of the save.
+func (a *AnalystAgent) enqueueSave(doc storage.Document, done chan error) {
+	w := &a.writer
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.pending == nil {
+		w.pending = &pendingSave{}
+	}
+	w.pending.doc = doc
+	if done != nil {
+		w.pending.waiters = append(w.pending.waiters, done)
+	} else {
+		w.pending.queued = true
+	}
+
+	if !w.running {
+		w.running = true
+		go a.runSaves()
+	}
+}
+
+// runSaves saves queued snapshots until none is left
+func (a *AnalystAgent) runSaves() {
+	w := &a.writer
+	for {
+		w.mu.Lock()
+		save := w.pending
+		w.pending = nil
+		if save == nil {
+			w.running = false
+			w.mu.Unlock()
+			return
+		}
+		w.mu.Unlock()
+
+		err := a.persistAnalysis(save.doc)
+		if err != nil && save.queued {
+			logrus.Errorf("Failed to save analysis for agent %s: %v", a.agentID, err)
+		}
+		for _, done := range save.waiters {
+			done <- err
+		}
+	}
+}
+
+// persistAnalysis validates a serialized analysis and saves it with the storage backend
+func (a *AnalystAgent) persistAnalysis(doc storage.Document) error {
+	if err := ValidateAnalysisData(doc.Data); err != nil {
+		return err
+	}
+
+	backend := a.backend
+	if backend == nil {
+		backend = storage.FileBackend{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+	return backend.Save(ctx, doc)
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/storage"
+)
+
+// blockingBackend holds each save until release is closed and keeps the saved documents
+type blockingBackend struct {
+	release chan struct{}
+	started chan struct{}
+
+	mu    sync.Mutex
+	saved []storage.Document
+}
+
+func newBlockingBackend() *blockingBackend {
+	return &blockingBackend{release: make(chan struct{}), started: make(chan struct{}, 100)}
+}
+
+func (b *blockingBackend) Save(ctx context.Context, doc storage.Document) error {
+	b.started <- struct{}{}
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	b.mu.Lock()
+	b.saved = append(b.saved, doc)
+	b.mu.Unlock()
+	return nil
+}
+
+func TestSlowSaveDoesNotBlockReaders(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	backend := newBlockingBackend()
+	analyst.SetStorageBackend(backend)
+
+	analyst.ProcessUtterance([]map[string]interface{}{{"speaker": "Alice", "text": "Let's review the launch plan today"}})
+	select {
+	case <-backend.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("utterance was not saved")
+	}
+
+	// The save is still blocked; readers and later utterances must not wait for it
+	read := make(chan int)
+	go func() {
+		analyst.ProcessUtterance([]map[string]interface{}{{"speaker": "Bob", "text": "Sounds good, I have the numbers ready"}})
+		read <- len(analyst.GetAnalysis().Transcript)
+	}()
+	select {
+	case entries := <-read:
+		if entries != 2 {
+			t.Errorf("transcript entries = %d, want 2", entries)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetAnalysis blocked on a slow save")
+	}
+
+	close(backend.release)
+	if err := analyst.saveAnalysis(); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if len(backend.saved) < 2 {
+		t.Fatalf("saves = %d, want the first utterance and the latest analysis", len(backend.saved))
+	}
+	var latest AnalysisData
+	if err := json.Unmarshal(backend.saved[len(backend.saved)-1].Data, &latest); err != nil {
+		t.Fatal(err)
+	}
+	if len(latest.Transcript) != 2 {
+		t.Errorf("last saved transcript has %d entries, want 2", len(latest.Transcript))
+	}
+}
+
+func TestQueuedSavesAreCoalesced(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	backend := newBlockingBackend()
+	analyst.SetStorageBackend(backend)
+
+	analyst.ProcessUtterance([]map[string]interface{}{{"speaker": "Alice", "text": "First point on the agenda"}})
+	<-backend.started
+	for _, text := range []string{"Second point on the agenda", "Third point on the agenda", "Fourth point on the agenda"} {
+		analyst.ProcessUtterance([]map[string]interface{}{{"speaker": "Alice", "text": text}})
+	}
+
+	analyst.writer.mu.Lock()
+	pending := analyst.writer.pending
+	analyst.writer.mu.Unlock()
+	if pending == nil {
+		t.Fatal("no save queued behind the running one")
+	}
+	var queued AnalysisData
+	if err := json.Unmarshal(pending.doc.Data, &queued); err != nil {
+		t.Fatal(err)
+	}
+	if len(queued.Transcript) != 4 {
+		t.Errorf("queued snapshot has %d entries, want only the newest with 4", len(queued.Transcript))
+	}
+
+	close(backend.release)
+	if err := analyst.saveAnalysis(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInvalidAnalysisIsRejectedByWriter(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	backend := newBlockingBackend()
+	close(backend.release)
+	analyst.SetStorageBackend(backend)
+
+	analyst.dataMutex.Lock()
+	analyst.data.MeetingID = ""
+	analyst.dataMutex.Unlock()
+
+	err := analyst.saveAnalysis()
+	var validationErr *SchemaValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("saveAnalysis() = %v, want a schema validation error", err)
+	}
+	if len(backend.saved) != 0 {
+		t.Error("invalid analysis was saved")
+	}
+}
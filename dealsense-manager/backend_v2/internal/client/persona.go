@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// personaTranscript is the number of recent transcript entries personas are inferred from
+	personaTranscript = 200
+	// personaStatements is the most statements of one speaker sent to the LLM
+	personaStatements = 40
+	// minPersonaUtterances is the fewest utterances a speaker needs for their persona to be inferred by
+	// the LLM; speakers with fewer get a minimal low-confidence profile
+	minPersonaUtterances = 5
+	// personaSampleQuotes is the number of representative quotes kept for each speaker
+	personaSampleQuotes = 3
+)
+
+// SpeakerPersona is a profile of a participant inferred from how they speak
+type SpeakerPersona struct {
+	InferredRole       string   `json:"inferred_role"`
+	CommunicationStyle string   `json:"communication_style"` // e.g. assertive, hesitant, analytical
+	TechnicalDepth     string   `json:"technical_depth"`     // low, medium, high
+	Confidence         float64  `json:"confidence"`          // 0 to 1
+	SampleQuotes       []string `json:"sample_quotes"`
+	Utterances         int      `json:"utterances"` // Statements the persona was inferred from
+}
+
+// clone returns a copy of the persona that shares no slices with it
+func (p SpeakerPersona) clone() SpeakerPersona {
+	p.SampleQuotes = append([]string{}, p.SampleQuotes...)
+	return p
+}
+
+// inferSpeakerPersonas profiles each participant from their own statements. Speakers with fewer than
+// minPersonaUtterances utterances get a minimal profile without asking the LLM, and personas are only
+// re-inferred for speakers who said something new since the last run.
+func (a *AnalystAgent) inferSpeakerPersonas(ctx context.Context) error {
+	transcript := a.getRecentTranscript(personaTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	statements := make(map[string][]TranscriptEntry)
+	for _, entry := range transcript {
+		if !entry.IsAgent && entry.Speaker != "" && strings.TrimSpace(entry.Text) != "" {
+			statements[entry.Speaker] = append(statements[entry.Speaker], entry)
+		}
+	}
+
+	a.dataMutex.RLock()
+	previous := make(map[string]SpeakerPersona, len(a.data.SpeakerPersonas))
+	for speaker, persona := range a.data.SpeakerPersonas {
+		previous[speaker] = persona
+	}
+	a.dataMutex.RUnlock()
+
+	speakers := make([]string, 0, len(statements))
+	for speaker := range statements {
+		speakers = append(speakers, speaker)
+	}
+	sort.Strings(speakers)
+
+	logrus.Infof("Agent %s: Inferring personas of %d speakers", a.agentID, len(speakers))
+
+	personas := make(map[string]SpeakerPersona, len(speakers))
+	var errs []string
+	for _, speaker := range speakers {
+		spoken := statements[speaker]
+		if persona, ok := previous[speaker]; ok && persona.Utterances == len(spoken) {
+			personas[speaker] = persona
+			continue
+		}
+		if len(spoken) < minPersonaUtterances {
+			personas[speaker] = minimalPersona(spoken)
+			continue
+		}
+
+		persona, err := a.inferSpeakerPersona(ctx, speaker, spoken)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", speaker, err))
+			if persona, ok := previous[speaker]; ok {
+				personas[speaker] = persona
+			}
+			continue
+		}
+		personas[speaker] = persona
+	}
+
+	a.dataMutex.Lock()
+	a.data.SpeakerPersonas = personas
+	a.dataMutex.Unlock()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to infer speaker personas: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// minimalPersona profiles a speaker who said too little to infer a persona from, with a confidence
+// below 0.5 that grows with their utterances and their longest statements as sample quotes
+func minimalPersona(statements []TranscriptEntry) SpeakerPersona {
+	return SpeakerPersona{
+		InferredRole:       "unknown",
+		CommunicationStyle: "unknown",
+		TechnicalDepth:     "low",
+		Confidence:         0.1 * float64(len(statements)),
+		SampleQuotes:       longestStatements(statements, personaSampleQuotes),
+		Utterances:         len(statements),
+	}
+}
+
+// inferSpeakerPersona asks the LLM for the persona of one speaker, keeping only sample quotes the
+// speaker actually said and topping them up with their longest statements
+func (a *AnalystAgent) inferSpeakerPersona(ctx context.Context, speaker string, statements []TranscriptEntry) (SpeakerPersona, error) {
+	recent := statements
+	if len(recent) > personaStatements {
+		recent = recent[len(recent)-personaStatements:]
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Build a brief profile of %s from their statements in this meeting, for sales coaching.
+
+Infer:
+- Their likely role (e.g. economic buyer, technical evaluator, engineer, account executive, project manager)
+- Their communication style in one or two words (e.g. assertive, hesitant, analytical, collaborative)
+- Their technical depth: low, medium or high
+- Your confidence in the profile from 0 to 1, lower when the statements reveal little
+- %d representative quotes, copied EXACTLY from their statements
+
+Statements by %s:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "inferred_role": "Role",
+  "communication_style": "Style",
+  "technical_depth": "low/medium/high",
+  "confidence": 0.7,
+  "sample_quotes": ["Exact quote"]
+}
+`+"`"+``, speaker, personaSampleQuotes, speaker, a.formatTranscriptForLLM(recent))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return SpeakerPersona{}, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return SpeakerPersona{}, fmt.Errorf("no JSON in persona response")
+	}
+
+	var persona SpeakerPersona
+	if err := json.Unmarshal([]byte(jsonData), &persona); err != nil {
+		return SpeakerPersona{}, fmt.Errorf("failed to parse persona JSON: %w", err)
+	}
+
+	var quotes []string
+	for _, quote := range persona.SampleQuotes {
+		quote = strings.Trim(strings.TrimSpace(quote), `"“”`)
+		for _, statement := range statements {
+			if quote != "" && strings.Contains(statement.Text, quote) {
+				quotes = appendUnique(quotes, quote)
+				break
+			}
+		}
+		if len(quotes) == personaSampleQuotes {
+			break
+		}
+	}
+	for _, statement := range longestStatements(statements, personaSampleQuotes) {
+		if len(quotes) == personaSampleQuotes {
+			break
+		}
+		quotes = appendUnique(quotes, statement)
+	}
+
+	return SpeakerPersona{
+		InferredRole:       strings.TrimSpace(persona.InferredRole),
+		CommunicationStyle: strings.ToLower(strings.TrimSpace(persona.CommunicationStyle)),
+		TechnicalDepth:     normalizeTechnicalDepth(persona.TechnicalDepth),
+		Confidence:         min(max(persona.Confidence, 0), 1),
+		SampleQuotes:       quotes,
+		Utterances:         len(statements),
+	}, nil
+}
+
+// longestStatements returns the text of the n longest statements, longest first
+func longestStatements(statements []TranscriptEntry, n int) []string {
+	texts := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		texts = appendUnique(texts, strings.TrimSpace(statement.Text))
+	}
+	sort.SliceStable(texts, func(i, j int) bool { return len(texts[i]) > len(texts[j]) })
+	if len(texts) > n {
+		texts = texts[:n]
+	}
+	return texts
+}
+
+// normalizeTechnicalDepth maps the LLM's technical depth onto low, medium or high, defaulting to medium
+func normalizeTechnicalDepth(depth string) string {
+	switch depth = strings.ToLower(strings.TrimSpace(depth)); depth {
+	case "low", "medium", "high":
+		return depth
+	default:
+		return "medium"
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestInferSpeakerPersonas(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n" + `{
+		"inferred_role": "Engineering lead",
+		"communication_style": "Assertive",
+		"technical_depth": "HIGH",
+		"confidence": 0.8,
+		"sample_quotes": ["we shard the database by tenant", "Something never said"]
+	}` + "\n```")
+	analyst.llmProvider = mock
+	for i := 0; i < minPersonaUtterances; i++ {
+		say(analyst, i*20, "Alice", fmt.Sprintf("Point %d: we shard the database by tenant", i))
+	}
+	say(analyst, 5, "Bob", "Sounds good to me")
+	say(analyst, 25, "Bob", "Can we see the timeline?")
+
+	if err := analyst.inferSpeakerPersonas(context.Background()); err != nil {
+		t.Fatalf("inferSpeakerPersonas() error = %v", err)
+	}
+	personas := analyst.GetAnalysis().SpeakerPersonas
+
+	// Bob said too little for the LLM, getting a minimal low-confidence profile
+	bob := personas["Bob"]
+	if bob.Confidence >= 0.5 || bob.InferredRole != "unknown" || bob.Utterances != 2 {
+		t.Errorf("Bob's persona = %+v, want a minimal profile below 0.5 confidence", bob)
+	}
+	if !reflect.DeepEqual(bob.SampleQuotes, []string{"Can we see the timeline?", "Sounds good to me"}) {
+		t.Errorf("Bob's quotes = %v", bob.SampleQuotes)
+	}
+	if len(mock.Prompts()) != 1 {
+		t.Errorf("LLM was called %d times, want once for Alice", len(mock.Prompts()))
+	}
+
+	// Quotes Alice never said are replaced by the speaker's longest statements
+	alice := personas["Alice"]
+	if alice.InferredRole != "Engineering lead" || alice.CommunicationStyle != "assertive" || alice.TechnicalDepth != "high" ||
+		alice.Confidence != 0.8 || len(alice.SampleQuotes) != personaSampleQuotes || alice.SampleQuotes[0] != "we shard the database by tenant" {
+		t.Errorf("Alice's persona = %+v", alice)
+	}
+
+	// Personas of speakers with nothing new aren't re-inferred
+	if err := analyst.inferSpeakerPersonas(context.Background()); err != nil {
+		t.Fatalf("second inferSpeakerPersonas() error = %v", err)
+	}
+	if len(mock.Prompts()) != 1 {
+		t.Errorf("LLM was called again for an unchanged speaker")
+	}
+}
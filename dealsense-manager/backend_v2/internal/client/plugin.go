@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Plugin is a custom post-processing step run after the built-in analysis steps. Plugins may read and
+// modify the analysis and should store their own output under their name in AnalysisData.PluginResults.
+type Plugin interface {
+	Name() string
+	Run(ctx context.Context, data *AnalysisData) error
+	Priority() int // Lower priorities run first
+}
+
+// RegisterPlugin adds a plugin to run after each analysis, replacing any plugin with the same name
+func (a *AnalystAgent) RegisterPlugin(p Plugin) {
+	a.pluginMutex.Lock()
+	defer a.pluginMutex.Unlock()
+
+	for i, existing := range a.plugins {
+		if existing.Name() == p.Name() {
+			a.plugins[i] = p
+			return
+		}
+	}
+	a.plugins = append(a.plugins, p)
+}
+
+// UnregisterPlugin removes the plugin with the given name, if registered
+func (a *AnalystAgent) UnregisterPlugin(name string) {
+	a.pluginMutex.Lock()
+	defer a.pluginMutex.Unlock()
+
+	for i, existing := range a.plugins {
+		if existing.Name() == name {
+			a.plugins = append(a.plugins[:i], a.plugins[i+1:]...)
+			return
+		}
+	}
+}
+
+// runPlugins runs the registered plugins in priority order. A failing or panicking plugin is logged and
+// does not stop the plugins after it. Each plugin gets the default step timeout through its context.
+func (a *AnalystAgent) runPlugins() {
+	a.pluginMutex.RLock()
+	plugins := make([]Plugin, len(a.plugins))
+	copy(plugins, a.plugins)
+	a.pluginMutex.RUnlock()
+
+	if len(plugins) == 0 {
+		return
+	}
+
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Priority() < plugins[j].Priority()
+	})
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	if a.data.PluginResults == nil {
+		a.data.PluginResults = make(map[string]json.RawMessage)
+	}
+
+	for _, plugin := range plugins {
+		if err := a.runPlugin(plugin); err != nil {
+			logrus.Errorf("Plugin %s failed for agent %s: %v", plugin.Name(), a.agentID, err)
+		}
+	}
+}
+
+// runPlugin runs a single plugin, converting a panic into an error
+func (a *AnalystAgent) runPlugin(plugin Plugin) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugin panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultStepTimeout)
+	defer cancel()
+
+	return plugin.Run(ctx, a.data)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// testPlugin appends its name to the shared order and then runs fn, if set
+type testPlugin struct {
+	name     string
+	priority int
+	order    *[]string
+	fn       func(data *AnalysisData) error
+}
+
+func (p *testPlugin) Name() string  { return p.name }
+func (p *testPlugin) Priority() int { return p.priority }
+func (p *testPlugin) Run(ctx context.Context, data *AnalysisData) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("no step timeout")
+	}
+	*p.order = append(*p.order, p.name)
+	if p.fn != nil {
+		return p.fn(data)
+	}
+	return nil
+}
+
+func TestRunPluginsInPriorityOrder(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	var order []string
+	analyst.RegisterPlugin(&testPlugin{name: "late", priority: 10, order: &order})
+	analyst.RegisterPlugin(&testPlugin{name: "panics", priority: 1, order: &order, fn: func(*AnalysisData) error { panic("boom") }})
+	analyst.RegisterPlugin(&testPlugin{name: "fails", priority: 2, order: &order, fn: func(*AnalysisData) error { return errors.New("failed") }})
+	analyst.RegisterPlugin(&testPlugin{name: "early", priority: 0, order: &order, fn: func(data *AnalysisData) error {
+		data.PluginResults["early"] = json.RawMessage(`{"ok":true}`)
+		return nil
+	}})
+
+	analyst.runPlugins()
+
+	// Failing and panicking plugins don't stop the ones after them
+	want := []string{"early", "panics", "fails", "late"}
+	if len(order) != len(want) {
+		t.Fatalf("plugins ran in order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("plugins ran in order %v, want %v", order, want)
+		}
+	}
+	if result := string(analyst.GetAnalysis().PluginResults["early"]); result != `{"ok":true}` {
+		t.Errorf("plugin result = %s", result)
+	}
+}
+
+func TestRegisterPluginReplacesAndUnregisters(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	var order []string
+	analyst.RegisterPlugin(&testPlugin{name: "a", order: &order})
+	analyst.RegisterPlugin(&testPlugin{name: "b", order: &order})
+	analyst.RegisterPlugin(&testPlugin{name: "a", priority: 5, order: &order})
+	analyst.UnregisterPlugin("b")
+	analyst.UnregisterPlugin("missing")
+
+	analyst.runPlugins()
+	if len(order) != 1 || order[0] != "a" {
+		t.Errorf("plugins ran = %v, want only the replacement a", order)
+	}
+}
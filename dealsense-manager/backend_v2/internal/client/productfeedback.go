@@ -0,0 +1,212 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// productFeedbackTranscript is the number of recent transcript entries product feedback is extracted from
+	productFeedbackTranscript = 100
+	// productFeedbackExportTimeout bounds posting the feedback to the product feedback webhook
+	productFeedbackExportTimeout = 10 * time.Second
+)
+
+// productFeedbackHTTPClient posts extracted feedback to the product feedback webhook
+var productFeedbackHTTPClient = &http.Client{Timeout: productFeedbackExportTimeout}
+
+// FeedbackItem is a feature request, bug report, complaint or praise a customer voiced about the product
+type FeedbackItem struct {
+	Type            string `json:"type"` // feature_request, bug_report, complaint, praise
+	Description     string `json:"description"`
+	CustomerSpeaker string `json:"customer_speaker"`
+	Priority        string `json:"priority"` // high, medium, low
+	Verbatim        string `json:"verbatim"` // Exact words from the transcript
+	ProductArea     string `json:"product_area,omitempty"`
+}
+
+// SetProductFeedbackWebhook sets the URL extracted product feedback is posted to, "" to keep it in the analysis only
+func (a *AnalystAgent) SetProductFeedbackWebhook(url string) {
+	a.productFeedbackWebhook = url
+}
+
+// extractProductFeedback collects the customer's feature requests, bug reports, complaints and praise,
+// keeping only items whose verbatim quote appears in the transcript, then posts them to the product
+// feedback webhook when they changed since the last post. The items are replaced on each analysis run.
+func (a *AnalystAgent) extractProductFeedback(ctx context.Context) error {
+	transcript := a.getRecentTranscript(productFeedbackTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting product feedback with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("product_feedback",
+		`Extract product feedback the customer side of this meeting gave, for the product team to act on.
+
+Classify each item as one of:
+- feature_request: a capability the customer wants added or changed
+- bug_report: something that doesn't work as expected
+- complaint: frustration with the product, pricing, performance or support
+- praise: something the customer likes and wants kept
+
+For each item, give:
+- A one-sentence description the product team can act on
+- The customer who said it, exactly as named in the transcript
+- Priority (high/medium/low) from how strongly and how often the customer raised it
+- The verbatim quote, copied EXACTLY from a single transcript line without paraphrasing
+- The product area it concerns (e.g. billing, reporting, integrations, mobile app), inferred from the content
+
+Ignore what the vendor side says about its own product.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "product_feedback": [
+    {
+      "type": "feature_request/bug_report/complaint/praise",
+      "description": "What the customer needs",
+      "customer_speaker": "Speaker name",
+      "priority": "high/medium/low",
+      "verbatim": "Exact words from the transcript",
+      "product_area": "Product area"
+    }
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Failed to extract product feedback: %v", err)
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		ProductFeedback []FeedbackItem `json:"product_feedback"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse product feedback JSON: %w", err)
+	}
+
+	items := verifyFeedbackItems(result.ProductFeedback, transcript)
+
+	a.dataMutex.Lock()
+	a.data.ProductFeedback = items
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Kept %d of %d product feedback items found verbatim in the transcript",
+		a.agentID, len(items), len(result.ProductFeedback))
+
+	if err := a.exportProductFeedback(items); err != nil {
+		logrus.Warnf("Agent %s: Failed to export product feedback: %v", a.agentID, err)
+	}
+	return nil
+}
+
+// verifyFeedbackItems keeps only items whose verbatim quote appears in a transcript entry, taking the
+// customer speaker from that entry and normalizing the type and priority
+func verifyFeedbackItems(candidates []FeedbackItem, transcript []TranscriptEntry) []FeedbackItem {
+	items := []FeedbackItem{}
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		verbatim := strings.Trim(strings.TrimSpace(candidate.Verbatim), `"“”`)
+		if verbatim == "" || seen[verbatim] {
+			continue
+		}
+
+		for _, entry := range transcript {
+			if entry.IsAgent || !strings.Contains(entry.Text, verbatim) {
+				continue
+			}
+
+			seen[verbatim] = true
+			items = append(items, FeedbackItem{
+				Type:            normalizeFeedbackType(candidate.Type),
+				Description:     strings.TrimSpace(candidate.Description),
+				CustomerSpeaker: entry.Speaker,
+				Priority:        normalizePriority(candidate.Priority),
+				Verbatim:        verbatim,
+				ProductArea:     strings.TrimSpace(candidate.ProductArea),
+			})
+			break
+		}
+	}
+
+	return items
+}
+
+// normalizeFeedbackType maps the LLM's feedback type onto the supported set, defaulting to complaint
+func normalizeFeedbackType(feedbackType string) string {
+	switch feedbackType = strings.ToLower(strings.TrimSpace(feedbackType)); feedbackType {
+	case "feature_request", "bug_report", "complaint", "praise":
+		return feedbackType
+	case "feature request", "feature-request", "feature":
+		return "feature_request"
+	case "bug report", "bug-report", "bug":
+		return "bug_report"
+	default:
+		return "complaint"
+	}
+}
+
+// normalizePriority maps the LLM's priority onto high, medium or low, defaulting to medium
+func normalizePriority(priority string) string {
+	switch priority = strings.ToLower(strings.TrimSpace(priority)); priority {
+	case "high", "medium", "low":
+		return priority
+	default:
+		return "medium"
+	}
+}
+
+// exportProductFeedback posts the items as a JSON array to the product feedback webhook. Nothing is posted
+// without a webhook, without items or when the items match the last ones posted.
+func (a *AnalystAgent) exportProductFeedback(items []FeedbackItem) error {
+	if a.productFeedbackWebhook == "" || len(items) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal product feedback: %w", err)
+	}
+
+	a.productFeedbackMutex.Lock()
+	defer a.productFeedbackMutex.Unlock()
+	if bytes.Equal(payload, a.lastProductFeedbackExport) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), productFeedbackExportTimeout)
+	defer cancel()
+
+	if err := postWebhook(ctx, productFeedbackHTTPClient, a.productFeedbackWebhook, nil, payload); err != nil {
+		if !a.queueWebhookRetry("product_feedback", a.productFeedbackWebhook, payload, err) {
+			return fmt.Errorf("failed to post product feedback: %w", err)
+		}
+		// The queue delivers these items, so they aren't posted again with the next analysis
+		a.lastProductFeedbackExport = payload
+		return nil
+	}
+
+	a.lastProductFeedbackExport = payload
+	logrus.Infof("Agent %s: Exported %d product feedback items", a.agentID, len(items))
+	return nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestVerifyFeedbackItems(t *testing.T) {
+	transcript := []TranscriptEntry{
+		entryAt(0, "Carol", "The export to Excel keeps crashing on big reports"),
+		{Timestamp: testMeetingStart, Speaker: "Agent", Text: "I love the new dashboard", IsAgent: true},
+		entryAt(20, "Dave", "I love the new dashboard, honestly"),
+	}
+	candidates := []FeedbackItem{
+		{Type: "Bug", Verbatim: `"keeps crashing on big reports"`, CustomerSpeaker: "Someone", Priority: "urgent", ProductArea: " reporting "},
+		{Type: "praise", Verbatim: "I love the new dashboard", Priority: "LOW"},
+		{Type: "feature request", Verbatim: "please add dark mode"},
+		{Type: "complaint", Verbatim: "keeps crashing on big reports"},
+	}
+
+	items := verifyFeedbackItems(candidates, transcript)
+	if len(items) != 2 {
+		t.Fatalf("verifyFeedbackItems() = %+v, want the two quotes found in the transcript", items)
+	}
+	// The speaker comes from the transcript line, not the LLM
+	bug := items[0]
+	if bug.Type != "bug_report" || bug.CustomerSpeaker != "Carol" || bug.Priority != "medium" ||
+		bug.Verbatim != "keeps crashing on big reports" || bug.ProductArea != "reporting" {
+		t.Errorf("bug = %+v", bug)
+	}
+	// The agent's own words don't count as customer feedback
+	if praise := items[1]; praise.Type != "praise" || praise.CustomerSpeaker != "Dave" || praise.Priority != "low" {
+		t.Errorf("praise = %+v", praise)
+	}
+}
+
+func TestExtractProductFeedbackExportsChanges(t *testing.T) {
+	var posts [][]FeedbackItem
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var items []FeedbackItem
+		if err := json.Unmarshal(body, &items); err != nil {
+			t.Errorf("invalid product feedback: %s", body)
+		}
+		posts = append(posts, items)
+	}))
+	defer server.Close()
+
+	analyst := newTestAnalyst(t)
+	analyst.SetProductFeedbackWebhook(server.URL)
+	response := "```json\n" + `{"product_feedback": [
+		{"type": "feature_request", "description": "Add SSO", "customer_speaker": "Carol", "priority": "high", "verbatim": "we really need SSO"}
+	]}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(response, response)
+	say(analyst, 0, "Carol", "Honestly we really need SSO before rollout")
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := analyst.extractProductFeedback(ctx); err != nil {
+			t.Fatalf("extractProductFeedback() error = %v", err)
+		}
+	}
+
+	if items := analyst.GetAnalysis().ProductFeedback; len(items) != 1 || items[0].Priority != "high" {
+		t.Errorf("ProductFeedback = %+v", items)
+	}
+	// Unchanged feedback isn't posted again
+	if len(posts) != 1 || len(posts[0]) != 1 || posts[0][0].Description != "Add SSO" {
+		t.Errorf("posts = %+v, want the feedback posted once", posts)
+	}
+}
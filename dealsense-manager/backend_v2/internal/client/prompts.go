@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+const promptTemplateExt = ".tmpl"
+
+// ErrPromptNotFound is returned for an analysis type without a prompt
+var ErrPromptNotFound = errors.New("no prompt for analysis type")
+
+// PromptTemplateData is passed to prompt template files
+type PromptTemplateData struct {
+	Transcript         string
+	CustomInstructions string
+}
+
+// PromptTemplate is the prompt an analysis type currently uses
+type PromptTemplate struct {
+	AnalysisType string `json:"analysis_type"`
+	Source       string `json:"source"` // file path, or "default" for the built-in prompt
+	Template     string `json:"template"`
+}
+
+// promptFile is a parsed prompt template file
+type promptFile struct {
+	path     string
+	text     string
+	template *template.Template
+}
+
+// loadPromptTemplates parses every {analysis type}.tmpl file in dir, failing on the first file that
+// can't be read or parsed
+func loadPromptTemplates(dir string) (map[string]*promptFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+promptTemplateExt))
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt template directory %s: %w", dir, err)
+	}
+
+	templates := make(map[string]*promptFile, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", path, err)
+		}
+
+		analysisType := strings.TrimSuffix(filepath.Base(path), promptTemplateExt)
+		tmpl, err := template.New(analysisType).Option("missingkey=error").Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", path, err)
+		}
+		templates[analysisType] = &promptFile{path: path, text: string(raw), template: tmpl}
+	}
+
+	return templates, nil
+}
+
+// loadPromptTemplateDir loads the agent's prompt template files, keeping the built-in prompts if any
+// file is invalid
+func (a *AnalystAgent) loadPromptTemplateDir() {
+	if a.config.PromptTemplateDir == "" {
+		return
+	}
+
+	templates, err := loadPromptTemplates(a.config.PromptTemplateDir)
+	if err != nil {
+		logrus.Errorf("Agent %s: using built-in prompts: %v", a.agentID, err)
+		return
+	}
+
+	a.promptTemplates = templates
+	logrus.Infof("Agent %s: Loaded %d prompt templates from %s", a.agentID, len(templates), a.config.PromptTemplateDir)
+}
+
+// renderPromptTemplate executes the prompt template file for analysisType, returning false when there
+// is none or it fails
+func (a *AnalystAgent) renderPromptTemplate(analysisType, transcript string) (string, bool) {
+	file, ok := a.promptTemplates[analysisType]
+	if !ok {
+		return "", false
+	}
+
+	data := PromptTemplateData{Transcript: transcript}
+	if a.config.CustomPrompt != nil {
+		data.CustomInstructions = *a.config.CustomPrompt
+	}
+
+	var buf bytes.Buffer
+	if err := file.template.Execute(&buf, data); err != nil {
+		logrus.Warnf("Agent %s: prompt template %s failed, using built-in prompt: %v", a.agentID, file.path, err)
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// rememberDefaultPrompt records the built-in prompt for analysisType so it can be inspected
+func (a *AnalystAgent) rememberDefaultPrompt(analysisType, defaultPrompt string) {
+	a.promptMutex.Lock()
+	defer a.promptMutex.Unlock()
+
+	if a.defaultPrompts == nil {
+		a.defaultPrompts = make(map[string]string)
+	}
+	a.defaultPrompts[analysisType] = defaultPrompt
+}
+
+// GetPromptTemplate returns the prompt analysisType currently uses. Built-in prompts are known once
+// their analysis step has run.
+func (a *AnalystAgent) GetPromptTemplate(analysisType string) (*PromptTemplate, error) {
+	if file, ok := a.promptTemplates[analysisType]; ok {
+		return &PromptTemplate{AnalysisType: analysisType, Source: file.path, Template: file.text}, nil
+	}
+
+	a.promptMutex.RLock()
+	defer a.promptMutex.RUnlock()
+
+	defaultPrompt, ok := a.defaultPrompts[analysisType]
+	if !ok {
+		return nil, ErrPromptNotFound
+	}
+	return &PromptTemplate{AnalysisType: analysisType, Source: "default", Template: defaultPrompt}, nil
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// writePromptTemplate writes a template file for an analysis type into dir
+func writePromptTemplate(t *testing.T, dir, analysisType, text string) string {
+	t.Helper()
+	path := filepath.Join(dir, analysisType+promptTemplateExt)
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPromptTemplateOverridesDefault(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	dir := t.TempDir()
+	path := writePromptTemplate(t, dir, "summary", "Summarize for the board. {{.CustomInstructions}}\n{{.Transcript}}")
+	instructions := "Mention revenue."
+	analyst.config.CustomPrompt = &instructions
+	analyst.config.PromptTemplateDir = dir
+	analyst.loadPromptTemplateDir()
+	// Types without a file turn the custom prompt into step instructions
+	mock := llm.NewMockLLMProvider("List the topics discussed.")
+	analyst.llmProvider = mock
+
+	prompt := analyst.buildAnalysisPrompt("summary", "Default summary prompt:\n%s", "[10:00] Alice: Revenue is up")
+	if !strings.HasSuffix(prompt, "Summarize for the board. Mention revenue.\n[10:00] Alice: Revenue is up") || strings.Contains(prompt, "Default summary prompt") {
+		t.Errorf("prompt = %q, want the rendered template", prompt)
+	}
+	if len(mock.Prompts()) != 0 {
+		t.Error("the template file's custom instructions were sent to the LLM")
+	}
+	if prompt := analyst.buildAnalysisPrompt("topics", "Default topics prompt:\n%s", "transcript"); strings.Contains(prompt, "board") {
+		t.Errorf("topics prompt = %q, want no template", prompt)
+	}
+
+	active, err := analyst.GetPromptTemplate("summary")
+	if err != nil || active.Source != path || !strings.HasPrefix(active.Template, "Summarize for the board.") {
+		t.Errorf("GetPromptTemplate(summary) = %+v, %v, want the file", active, err)
+	}
+	active, err = analyst.GetPromptTemplate("topics")
+	if err != nil || active.Source != "default" || active.Template != "Default topics prompt:\n%s" {
+		t.Errorf("GetPromptTemplate(topics) = %+v, %v, want the built-in prompt", active, err)
+	}
+	if _, err := analyst.GetPromptTemplate("key_points"); !errors.Is(err, ErrPromptNotFound) {
+		t.Errorf("GetPromptTemplate(key_points) error = %v, want ErrPromptNotFound", err)
+	}
+}
+
+func TestLoadPromptTemplatesSyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "summary", "{{.Transcript}}")
+	path := writePromptTemplate(t, dir, "topics", "List the topics: {{.Transcript")
+
+	_, err := loadPromptTemplates(dir)
+	if err == nil || !strings.Contains(err.Error(), "invalid prompt template "+path) {
+		t.Fatalf("loadPromptTemplates() error = %v, want one naming %s", err, path)
+	}
+
+	// An agent keeps all built-in prompts when any file is invalid
+	analyst := newTestAnalyst(t)
+	analyst.config.PromptTemplateDir = dir
+	analyst.loadPromptTemplateDir()
+	if len(analyst.promptTemplates) != 0 {
+		t.Errorf("loaded %d templates from an invalid directory", len(analyst.promptTemplates))
+	}
+}
+
+func TestPromptTemplateExecutionErrorFallsBack(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "summary", "{{.Attendees}}")
+	analyst.config.PromptTemplateDir = dir
+	analyst.loadPromptTemplateDir()
+
+	if prompt := analyst.buildAnalysisPrompt("summary", "Default summary prompt:\n%s", "transcript"); !strings.HasSuffix(prompt, "Default summary prompt:\ntranscript") {
+		t.Errorf("prompt = %q, want the built-in prompt", prompt)
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/sirupsen/logrus"
+)
+
+// versionedPromptTypes are the analysis types whose prompts make up the prompt version, in hashing order
+var versionedPromptTypes = []string{"summary", "key_points", "action_items", "topics", "sentiment_keywords"}
+
+// defaultPromptTemplates are the built-in prompts of the versioned analysis types
+var defaultPromptTemplates = map[string]string{
+	"summary":            summaryPromptTemplate,
+	"key_points":         keyPointsPromptTemplate,
+	"action_items":       actionItemsPromptTemplate,
+	"topics":             topicsPromptTemplate,
+	"sentiment_keywords": sentimentKeywordsPromptTemplate,
+}
+
+// PromptVersion identifies the prompts an analysis was produced with
+type PromptVersion struct {
+	Version string            `json:"prompt_version"` // SHA-256 of the versioned prompt templates concatenated
+	Hashes  map[string]string `json:"prompt_hashes"`  // SHA-256 of each versioned prompt template
+}
+
+// ComputePromptVersion hashes the template of each versioned analysis type, and all of them concatenated
+// in a fixed order as the version. Types missing from templates are hashed as empty templates.
+func ComputePromptVersion(templates map[string]string) PromptVersion {
+	version := PromptVersion{Hashes: make(map[string]string, len(versionedPromptTypes))}
+	combined := sha256.New()
+	for _, analysisType := range versionedPromptTypes {
+		text := templates[analysisType]
+		sum := sha256.Sum256([]byte(text))
+		version.Hashes[analysisType] = hex.EncodeToString(sum[:])
+		combined.Write([]byte(text))
+		// Separate the templates so text moving from one template to the next changes the version
+		combined.Write([]byte{0})
+	}
+	version.Version = hex.EncodeToString(combined.Sum(nil))
+	return version
+}
+
+// DefaultPromptVersion returns the version of the built-in prompts
+func DefaultPromptVersion() PromptVersion {
+	return ComputePromptVersion(defaultPromptTemplates)
+}
+
+// promptVersion returns the version of the prompts the agent uses: its template files, falling back to
+// the built-in prompts
+func (a *AnalystAgent) promptVersion() PromptVersion {
+	templates := make(map[string]string, len(versionedPromptTypes))
+	for _, analysisType := range versionedPromptTypes {
+		if file, ok := a.promptTemplates[analysisType]; ok {
+			templates[analysisType] = file.text
+		} else {
+			templates[analysisType] = defaultPromptTemplates[analysisType]
+		}
+	}
+	return ComputePromptVersion(templates)
+}
+
+// recordPromptVersion stores the version of the agent's prompts in the analysis. The caller must hold
+// dataMutex.
+func (a *AnalystAgent) recordPromptVersion() {
+	version := a.promptVersion()
+	a.data.PromptVersion = version.Version
+	a.data.PromptHashes = version.Hashes
+}
+
+// skipUnchangedPromptSteps makes the next analysis keep the results of the versioned steps whose prompt
+// is unchanged since the loaded analysis was produced, warning when the prompt version differs
+func (a *AnalystAgent) skipUnchangedPromptSteps() {
+	current := a.promptVersion()
+
+	a.dataMutex.RLock()
+	previousVersion := a.data.PromptVersion
+	previousHashes := a.data.PromptHashes
+	a.dataMutex.RUnlock()
+
+	if previousVersion == "" {
+		return
+	}
+	if previousVersion != current.Version {
+		logrus.Warnf("Agent %s: Replaying an analysis produced with prompt version %s using prompt version %s",
+			a.agentID, previousVersion, current.Version)
+	}
+
+	a.skipSteps = make(map[string]bool)
+	for _, analysisType := range versionedPromptTypes {
+		if previousHashes[analysisType] == current.Hashes[analysisType] {
+			a.skipSteps[analysisType] = true
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import "testing"
+
+func TestPromptVersionChangesWithEachTemplate(t *testing.T) {
+	base := DefaultPromptVersion()
+	if base.Version != DefaultPromptVersion().Version {
+		t.Fatal("version differs between identical templates")
+	}
+
+	for _, analysisType := range versionedPromptTypes {
+		templates := make(map[string]string, len(defaultPromptTemplates))
+		for name, text := range defaultPromptTemplates {
+			templates[name] = text
+		}
+		templates[analysisType] += " "
+
+		changed := ComputePromptVersion(templates)
+		if changed.Version == base.Version {
+			t.Errorf("changing the %s template didn't change the version", analysisType)
+		}
+		for _, other := range versionedPromptTypes {
+			if (changed.Hashes[other] != base.Hashes[other]) != (other == analysisType) {
+				t.Errorf("changing the %s template changed the %s hash: %v", analysisType, other, changed.Hashes[other] != base.Hashes[other])
+			}
+		}
+	}
+}
+
+func TestPromptVersionSeparatesTemplates(t *testing.T) {
+	// Moving text from one template to the next keeps the concatenation but not the version
+	first := ComputePromptVersion(map[string]string{"summary": "ab", "key_points": "c"})
+	second := ComputePromptVersion(map[string]string{"summary": "a", "key_points": "bc"})
+	if first.Version == second.Version {
+		t.Error("version unchanged when text moves between templates")
+	}
+}
+
+func TestSkipUnchangedPromptSteps(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	version := DefaultPromptVersion()
+	analyst.data.PromptVersion = "older"
+	analyst.data.PromptHashes = map[string]string{"summary": version.Hashes["summary"], "topics": "changed"}
+
+	analyst.skipUnchangedPromptSteps()
+	if !analyst.skipSteps["summary"] || analyst.skipSteps["topics"] || analyst.skipSteps["key_points"] {
+		t.Errorf("skipped steps = %v, want only the unchanged summary", analyst.skipSteps)
+	}
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// qaTranscript is the number of recent transcript entries question-answer pairs are extracted from
+const qaTranscript = 50
+
+// How fully a question was answered
+const (
+	AnswerComplete  = "complete"
+	AnswerPartial   = "partial"
+	AnswerDeflected = "deflected"
+)
+
+// QAPair is a question asked in the meeting with the answer it got, if any
+type QAPair struct {
+	Question          string    `json:"question"`
+	QuestionedBy      string    `json:"questioned_by"`
+	QuestionTimestamp time.Time `json:"question_timestamp"`
+	Answer            string    `json:"answer,omitempty"`
+	AnsweredBy        string    `json:"answered_by,omitempty"`
+	AnswerTimestamp   time.Time `json:"answer_timestamp"`
+	Answered          bool      `json:"answered"`
+	AnswerQuality     string    `json:"answer_quality,omitempty"` // complete, partial, deflected; empty when unanswered
+}
+
+// extractQAPairs finds the questions asked in the recent transcript and the statements answering them.
+// Rhetorical questions, which expect no answer, are left out. A question extracted again replaces its
+// earlier pair, so questions answered later in the meeting are updated; unanswered questions are then
+// linked to UnansweredQuestions.
+func (a *AnalystAgent) extractQAPairs(ctx context.Context) error {
+	transcript := a.getRecentTranscript(qaTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	asked := false
+	for _, entry := range transcript {
+		if strings.Contains(entry.Text, "?") {
+			asked = true
+			break
+		}
+	}
+	if !asked {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting questions and answers from %d transcript entries", a.agentID, len(transcript))
+
+	var result struct {
+		Questions []struct {
+			QuestionStatement int    `json:"question_statement"`
+			Question          string `json:"question"`
+			AnswerStatement   int    `json:"answer_statement"`
+			Answer            string `json:"answer"`
+			AnswerQuality     string `json:"answer_quality"`
+			Rhetorical        bool   `json:"rhetorical"`
+		} `json:"questions"`
+	}
+	request := indexedStatementsRequest{
+		task: `Identify the question-answer sequences in this meeting transcript: each genuine question a participant asked, and the statement that answered it.
+
+For each question, give:
+- The index of the statement asking it, and the question rephrased to stand on its own
+- The index of the statement answering it, or -1 when nobody answered it
+- The answer, summarized in one sentence
+- Answer quality: complete when the answer fully addresses the question, partial when it addresses only part of it or is vague, deflected when the answerer avoided the question, postponed it or redirected it
+- Whether the question is rhetorical: asked for effect with no answer expected, such as "who doesn't want faster builds?", or a figure of speech such as "you know what I mean?"`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "questions": [
+    {
+      "question_statement": 4,
+      "question": "When will the migration be finished?",
+      "answer_statement": 5,
+      "answer": "The migration finishes at the end of the month.",
+      "answer_quality": "complete/partial/deflected",
+      "rhetorical": false
+    }
+  ]
+}`,
+		result: "questions and answers",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return err
+	}
+
+	var found []QAPair
+	for _, candidate := range result.Questions {
+		question, ok := statementAt(transcript, candidate.QuestionStatement)
+		if candidate.Rhetorical || !ok {
+			continue
+		}
+		pair := QAPair{
+			Question:          strings.TrimSpace(candidate.Question),
+			QuestionedBy:      question.Speaker,
+			QuestionTimestamp: question.Timestamp,
+		}
+		if pair.Question == "" {
+			pair.Question = question.Text
+		}
+
+		if answer, ok := replyAt(transcript, candidate.QuestionStatement, candidate.AnswerStatement); ok {
+			pair.Answer = strings.TrimSpace(candidate.Answer)
+			if pair.Answer == "" {
+				pair.Answer = answer.Text
+			}
+			pair.AnsweredBy = answer.Speaker
+			pair.AnswerTimestamp = answer.Timestamp
+			pair.Answered = true
+			pair.AnswerQuality = normalizeAnswerQuality(candidate.AnswerQuality)
+		}
+		found = append(found, pair)
+	}
+
+	a.dataMutex.Lock()
+	for _, pair := range found {
+		a.data.QuestionsAndAnswers = mergeQAPair(a.data.QuestionsAndAnswers, pair)
+	}
+	a.linkUnansweredQuestions()
+	total := len(a.data.QuestionsAndAnswers)
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Tracking %d questions asked in the meeting", a.agentID, total)
+	return nil
+}
+
+// mergeQAPair adds a pair to the meeting's, or replaces the pair of the same question. An answered pair is
+// never replaced by an unanswered one, as a later run may no longer see the answer.
+func mergeQAPair(pairs []QAPair, pair QAPair) []QAPair {
+	for i, existing := range pairs {
+		if existing.QuestionedBy == pair.QuestionedBy && existing.QuestionTimestamp.Equal(pair.QuestionTimestamp) {
+			if pair.Answered || !existing.Answered {
+				pairs[i] = pair
+			}
+			return pairs
+		}
+	}
+	return append(pairs, pair)
+}
+
+// linkUnansweredQuestions replaces the unanswered questions raised by question-answer extraction with
+// those of the current pairs, keeping the questions of ambiguous requirements. Caller must hold dataMutex.
+func (a *AnalystAgent) linkUnansweredQuestions() {
+	questions := []UnansweredQuestion{}
+	for _, question := range a.data.UnansweredQuestions {
+		if question.RequirementIndex >= 0 {
+			questions = append(questions, question)
+		}
+	}
+	for i, pair := range a.data.QuestionsAndAnswers {
+		if !pair.Answered {
+			questions = append(questions, UnansweredQuestion{
+				Question:         pair.Question,
+				RequirementIndex: -1,
+				QAPairIndex:      i,
+				AskedBy:          pair.QuestionedBy,
+			})
+		}
+	}
+	a.data.UnansweredQuestions = questions
+}
+
+// normalizeAnswerQuality maps the LLM's answer quality onto the supported set, defaulting to partial
+func normalizeAnswerQuality(quality string) string {
+	switch quality = strings.ToLower(strings.TrimSpace(quality)); quality {
+	case AnswerComplete, AnswerPartial, AnswerDeflected:
+		return quality
+	default:
+		return AnswerPartial
+	}
+}
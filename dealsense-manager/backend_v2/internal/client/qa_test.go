@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractQAPairs(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"questions": [
+		{"question_statement": 0, "question": "Who doesn't want faster builds?", "answer_statement": -1, "rhetorical": true},
+		{"question_statement": 1, "question": "When does the migration finish?", "answer_statement": 2, "answer": "End of the month.", "answer_quality": "Complete"},
+		{"question_statement": 3, "question": "Who owns the rollback plan?", "answer_statement": -1},
+		{"question_statement": 9, "question": "Out of range?", "answer_statement": -1}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "Who doesn't want faster builds?")
+	say(analyst, 10, "Bob", "When does the migration finish?")
+	say(analyst, 20, "Carol", "End of the month at the latest")
+	say(analyst, 30, "Bob", "And who owns the rollback plan?")
+
+	if err := analyst.extractQAPairs(context.Background()); err != nil {
+		t.Fatalf("extractQAPairs() error = %v", err)
+	}
+
+	// The rhetorical question and the one at no statement are left out
+	analysis := analyst.GetAnalysis()
+	pairs := analysis.QuestionsAndAnswers
+	if len(pairs) != 2 {
+		t.Fatalf("QuestionsAndAnswers = %+v, want 2 pairs", pairs)
+	}
+	answered := pairs[0]
+	if answered.QuestionedBy != "Bob" || !answered.Answered || answered.AnsweredBy != "Carol" ||
+		answered.Answer != "End of the month." || answered.AnswerQuality != AnswerComplete {
+		t.Errorf("answered pair = %+v", answered)
+	}
+	if pairs[1].Answered || pairs[1].AnswerQuality != "" {
+		t.Errorf("unanswered pair = %+v", pairs[1])
+	}
+	unanswered := analysis.UnansweredQuestions
+	if len(unanswered) != 1 || unanswered[0].Question != "Who owns the rollback plan?" || unanswered[0].QAPairIndex != 1 {
+		t.Errorf("UnansweredQuestions = %+v", unanswered)
+	}
+}
+
+func TestExtractQAPairsWithoutQuestions(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	say(analyst, 0, "Alice", "The migration finishes this month")
+
+	if err := analyst.extractQAPairs(context.Background()); err != nil {
+		t.Fatalf("extractQAPairs() error = %v", err)
+	}
+	if pairs := analyst.GetAnalysis().QuestionsAndAnswers; len(pairs) != 0 {
+		t.Errorf("QuestionsAndAnswers = %+v, want none", pairs)
+	}
+}
+
+func TestMergeQAPairKeepsAnswer(t *testing.T) {
+	question := entryAt(0, "Bob", "When does the migration finish?")
+	answered := QAPair{QuestionedBy: "Bob", QuestionTimestamp: question.Timestamp, Answered: true, Answer: "End of the month."}
+	unanswered := QAPair{QuestionedBy: "Bob", QuestionTimestamp: question.Timestamp}
+
+	pairs := mergeQAPair(nil, unanswered)
+	pairs = mergeQAPair(pairs, answered)
+	pairs = mergeQAPair(pairs, unanswered)
+	if len(pairs) != 1 || !pairs[0].Answered {
+		t.Errorf("pairs = %+v, want the answered pair", pairs)
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// minSummaryChars is the shortest summary accepted
+	minSummaryChars = 100
+	// minKeyPoints is the fewest key points accepted
+	minKeyPoints = 3
+	// minLanguageCheckWords is the number of words a response needs before its language is checked
+	minLanguageCheckWords = 20
+	// refusalPrefixChars is how much of the response and of each text value is searched for a refusal
+	refusalPrefixChars = 200
+	// minTextValueWords is the fewest words a JSON string value needs to be checked, skipping enum values
+	// such as priorities that are always English
+	minTextValueWords = 3
+)
+
+// Quality check failures
+const (
+	QualityTooShort         = "too_short"
+	QualityRefusal          = "refusal"
+	QualityInvalidJSON      = "invalid_json"
+	QualityLanguageMismatch = "language_mismatch"
+)
+
+// refusalPatterns are phrases an LLM opens with when it declines or can't do the task
+var refusalPatterns = []string{"i cannot", "i can't", "i can not", "i'm unable", "i am unable", "as an ai", "i'm sorry, but", "i apologize, but"}
+
+// QualityCheckResult records whether an LLM response was good enough to store
+type QualityCheckResult struct {
+	Passed    bool      `json:"passed"`
+	Failures  []string  `json:"failures,omitempty"` // too_short, refusal, invalid_json, language_mismatch
+	Language  string    `json:"language,omitempty"` // Language detected in the response text
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ErrLowQualityResponse is returned by an analysis step whose LLM response failed quality scoring
+type ErrLowQualityResponse struct {
+	AnalysisType string
+	Failures     []string
+}
+
+func (e *ErrLowQualityResponse) Error() string {
+	return fmt.Sprintf("%s response failed quality checks: %s", e.AnalysisType, strings.Join(e.Failures, ", "))
+}
+
+// QualityScorer rejects LLM responses that are refusals, malformed, too short for their analysis type or
+// written in the wrong language
+type QualityScorer struct {
+	languageDetector *LanguageDetector
+}
+
+// NewQualityScorer creates a quality scorer
+func NewQualityScorer() *QualityScorer {
+	return &QualityScorer{languageDetector: NewLanguageDetector()}
+}
+
+// Score checks a response for the analysis type. jsonData is the JSON extracted from the response, and
+// language the ISO 639-1 code the response should be written in, "" to skip the language check.
+func (s *QualityScorer) Score(analysisType, response, jsonData, language string) QualityCheckResult {
+	result := QualityCheckResult{CheckedAt: time.Now()}
+
+	var fields map[string]any
+	if jsonData == "" || json.Unmarshal([]byte(jsonData), &fields) != nil {
+		result.Failures = append(result.Failures, QualityInvalidJSON)
+	}
+
+	var texts []string
+	collectStrings(fields, &texts)
+
+	if isRefusal(strings.TrimSpace(response)) {
+		result.Failures = append(result.Failures, QualityRefusal)
+	} else {
+		for _, text := range texts {
+			if isRefusal(text) {
+				result.Failures = append(result.Failures, QualityRefusal)
+				break
+			}
+		}
+	}
+
+	switch analysisType {
+	case "summary":
+		if summary, _ := fields["summary"].(string); fields != nil && len(strings.TrimSpace(summary)) < minSummaryChars {
+			result.Failures = append(result.Failures, QualityTooShort)
+		}
+	case "key_points":
+		if points, _ := fields["key_points"].([]any); fields != nil && len(points) < minKeyPoints {
+			result.Failures = append(result.Failures, QualityTooShort)
+		}
+	}
+
+	if joined := strings.Join(texts, " "); len(strings.Fields(joined)) >= minLanguageCheckWords {
+		result.Language = s.languageDetector.DetectText(joined)
+		if language != "" && result.Language != "" && !strings.EqualFold(result.Language, language) {
+			result.Failures = append(result.Failures, QualityLanguageMismatch)
+		}
+	}
+
+	result.Passed = len(result.Failures) == 0
+	return result
+}
+
+// isRefusal reports whether text opens with a refusal pattern
+func isRefusal(text string) bool {
+	if len(text) > refusalPrefixChars {
+		text = text[:refusalPrefixChars]
+	}
+	text = strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+	for _, pattern := range refusalPatterns {
+		if strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectStrings appends the string values of at least minTextValueWords words in the decoded JSON to texts
+func collectStrings(value any, texts *[]string) {
+	switch v := value.(type) {
+	case string:
+		if v = strings.TrimSpace(v); len(strings.Fields(v)) >= minTextValueWords {
+			*texts = append(*texts, v)
+		}
+	case []any:
+		for _, item := range v {
+			collectStrings(item, texts)
+		}
+	case map[string]any:
+		for _, item := range v {
+			collectStrings(item, texts)
+		}
+	}
+}
+
+// checkResponseQuality scores an LLM response for the analysis type against the response language,
+// records the result in QualityChecks and returns ErrLowQualityResponse when it fails, so the step is
+// retried from the dead letter queue instead of storing the response
+func (a *AnalystAgent) checkResponseQuality(analysisType, response string) error {
+	a.dataMutex.RLock()
+	language := a.data.ResponseLanguage
+	if language == "" {
+		language = a.data.DetectedLanguage
+	}
+	a.dataMutex.RUnlock()
+
+	result := a.quality.Score(analysisType, response, extractJSON(response).data, language)
+
+	a.dataMutex.Lock()
+	if a.data.QualityChecks == nil {
+		a.data.QualityChecks = make(map[string]QualityCheckResult)
+	}
+	a.data.QualityChecks[analysisType] = result
+	a.dataMutex.Unlock()
+
+	if result.Passed {
+		return nil
+	}
+	logrus.Warnf("Agent %s: Rejected %s response that failed quality checks: %s",
+		a.agentID, analysisType, strings.Join(result.Failures, ", "))
+	return &ErrLowQualityResponse{AnalysisType: analysisType, Failures: result.Failures}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// longSummary is an English summary long enough to pass the length and language checks
+const longSummary = "The team reviewed the launch plan for the new billing dashboard and agreed to move the release to June so that the finance team can finish testing the invoice exports."
+
+func TestQualityScorer(t *testing.T) {
+	scorer := NewQualityScorer()
+	spanish := "El equipo revisó el plan de lanzamiento del nuevo panel de facturación y acordó mover la publicación a junio para que el equipo de finanzas pueda terminar las pruebas."
+
+	cases := []struct {
+		name, analysisType, response, jsonData, language string
+		want                                             []string
+	}{
+		{"good summary", "summary", "", `{"summary": "` + longSummary + `"}`, "en", nil},
+		{"short summary", "summary", "", `{"summary": "Launch moved."}`, "", []string{QualityTooShort}},
+		{"two key points", "key_points", "", `{"key_points": ["Launch moved", "Budget approved"]}`, "", []string{QualityTooShort}},
+		{"refusal text", "summary", "I'm sorry, but I cannot summarize this transcript.", "", "", []string{QualityInvalidJSON, QualityRefusal}},
+		{"refusal in a value", "topics", "", `{"topics": [{"summary": "As an AI I can't tell what they discussed"}]}`, "", []string{QualityRefusal}},
+		{"malformed JSON", "action_items", "", `{"action_items": [`, "", []string{QualityInvalidJSON}},
+		{"wrong language", "summary", "", `{"summary": "` + spanish + `"}`, "en", []string{QualityLanguageMismatch}},
+		// Other analysis types have no minimum length
+		{"short topics", "topics", "", `{"topics": []}`, "en", nil},
+	}
+	for _, c := range cases {
+		result := scorer.Score(c.analysisType, c.response, c.jsonData, c.language)
+		if !reflect.DeepEqual(result.Failures, c.want) || result.Passed != (len(c.want) == 0) {
+			t.Errorf("%s: Score() = %+v, want failures %v", c.name, result, c.want)
+		}
+	}
+}
+
+func TestIsRefusal(t *testing.T) {
+	cases := map[string]bool{
+		"I’m unable to help with that.":          true,
+		"As an AI language model, I think":       true,
+		"The customer said I cannot wait":        true,
+		strings.Repeat("fine ", 50) + "I cannot": false,
+		"We agreed on the plan":                  false,
+	}
+	for text, want := range cases {
+		if got := isRefusal(text); got != want {
+			t.Errorf("isRefusal(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestLowQualitySummaryIsNotStored(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("I cannot summarize this meeting.")
+	say(analyst, 0, "Alice", "We moved the launch to June")
+	analyst.data.Summary = "Earlier summary"
+
+	err := analyst.generateSummary(context.Background())
+	var qualityErr *ErrLowQualityResponse
+	if !errors.As(err, &qualityErr) || qualityErr.AnalysisType != "summary" {
+		t.Fatalf("generateSummary() error = %v, want ErrLowQualityResponse", err)
+	}
+
+	analysis := analyst.GetAnalysis()
+	if analysis.Summary != "Earlier summary" {
+		t.Errorf("summary = %q, want the earlier one kept", analysis.Summary)
+	}
+	check, ok := analysis.QualityChecks["summary"]
+	if !ok || check.Passed || !reflect.DeepEqual(check.Failures, []string{QualityInvalidJSON, QualityRefusal}) {
+		t.Errorf("quality check = %+v, want the failures recorded", check)
+	}
+}
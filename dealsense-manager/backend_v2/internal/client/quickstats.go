@@ -0,0 +1,61 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+// QuickStats summarizes the analysis in a few counters, so dashboards listing many meetings don't have to
+// load the full analysis with its transcript
+type QuickStats struct {
+	SummaryWordCount        int       `json:"summary_word_count"`
+	KeyPointCount           int       `json:"key_point_count"`
+	ActionItemCount         int       `json:"action_item_count"`
+	HighPriorityActionItems int       `json:"high_priority_action_items"`
+	TopicCount              int       `json:"topic_count"`
+	Sentiment               string    `json:"sentiment"`
+	DurationMinutes         float64   `json:"duration_minutes"`
+	ParticipantCount        int       `json:"participant_count"`
+	LastUpdated             time.Time `json:"last_updated"`
+	AnalysisConfidence      float64   `json:"analysis_confidence"` // Mean confidence of the core analysis results, from 0 to 1
+	ImportantLinksCount     int       `json:"important_links_count"`
+}
+
+// updateQuickStats recomputes the quick stats from the current analysis. It is cheap enough to run after
+// every utterance, which keeps the duration and participant count live between analysis runs. Caller must
+// hold dataMutex.
+func (a *AnalystAgent) updateQuickStats() {
+	highPriority := 0
+	for _, item := range a.data.ActionItems {
+		if strings.EqualFold(item.Priority, "high") {
+			highPriority++
+		}
+	}
+
+	a.data.QuickStats = &QuickStats{
+		SummaryWordCount:        len(strings.Fields(a.data.Summary)),
+		KeyPointCount:           len(a.data.KeyPoints),
+		ActionItemCount:         len(a.data.ActionItems),
+		HighPriorityActionItems: highPriority,
+		TopicCount:              len(a.data.Topics),
+		Sentiment:               a.data.Sentiment,
+		DurationMinutes:         a.data.DurationMinutes,
+		ParticipantCount:        len(a.data.Participants),
+		LastUpdated:             a.data.LastUpdated,
+		ImportantLinksCount:     len(a.data.ImportantLinks),
+		AnalysisConfidence: (a.data.SummaryConfidence + a.data.KeyPointsConfidence + a.data.ActionItemsConfidence +
+			a.data.TopicsConfidence + a.data.SentimentConfidence) / 5,
+	}
+}
+
+// GetQuickStats returns the quick stats of the analysis, or nil before the first utterance
+func (a *AnalystAgent) GetQuickStats() *QuickStats {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	if a.data.QuickStats == nil {
+		return nil
+	}
+	stats := *a.data.QuickStats
+	return &stats
+}
@@ -0,0 +1,52 @@
+package client
+
+import "testing"
+
+func TestQuickStatsAfterSingleUtterance(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	if analyst.GetQuickStats() != nil {
+		t.Fatal("quick stats before the first utterance")
+	}
+
+	analyst.data.Summary = "The team reviewed the launch plan"
+	analyst.data.KeyPoints = []string{"Launch Friday"}
+	analyst.data.ActionItems = []ActionItem{{Priority: "High"}, {Priority: "low"}}
+	analyst.data.Topics = []TopicDiscussion{{Topic: "Launch"}}
+	analyst.data.Sentiment = "positive"
+	analyst.data.ImportantLinks = []LinkMention{{URL: "https://example.com"}}
+	analyst.data.SummaryConfidence = 0.5
+	analyst.data.KeyPointsConfidence = 0.5
+	analyst.data.ActionItemsConfidence = 0.5
+	analyst.data.TopicsConfidence = 0.5
+	analyst.data.SentimentConfidence = 1
+
+	say(analyst, 90, "Alice", "Let's get started with the review")
+
+	stats := analyst.GetQuickStats()
+	if stats == nil {
+		t.Fatal("no quick stats after an utterance")
+	}
+	want := QuickStats{
+		SummaryWordCount:        6,
+		KeyPointCount:           1,
+		ActionItemCount:         2,
+		HighPriorityActionItems: 1,
+		TopicCount:              1,
+		Sentiment:               "positive",
+		DurationMinutes:         stats.DurationMinutes,
+		ParticipantCount:        1,
+		LastUpdated:             stats.LastUpdated,
+		AnalysisConfidence:      0.6,
+		ImportantLinksCount:     1,
+	}
+	if !approx(stats.AnalysisConfidence, 0.6) {
+		t.Errorf("confidence = %v, want 0.6", stats.AnalysisConfidence)
+	}
+	stats.AnalysisConfidence = 0.6
+	if *stats != want {
+		t.Errorf("stats = %+v, want %+v", *stats, want)
+	}
+	if stats.LastUpdated.IsZero() || stats.DurationMinutes <= 0 {
+		t.Errorf("last updated %v, duration %v, want both set by the utterance", stats.LastUpdated, stats.DurationMinutes)
+	}
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxKeyQuotes        = 10
+	minQuoteWords       = 3
+	maxQuoteWords       = 30
+	keyQuotesTranscript = 50
+)
+
+// Quote is a verbatim statement from the meeting worth highlighting
+type Quote struct {
+	Text      string    `json:"text"`
+	Speaker   string    `json:"speaker"`
+	Timestamp time.Time `json:"timestamp"`
+	Context   string    `json:"context"`  // The sentence before and after the quote
+	Category  string    `json:"category"` // decision, commitment, concern, insight
+}
+
+// sentenceBoundary splits text after sentence-ending punctuation
+var sentenceBoundary = regexp.MustCompile(`[^.!?]+[.!?]*`)
+
+// extractKeyQuotes identifies impactful verbatim statements from the transcript
+func (a *AnalystAgent) extractKeyQuotes(ctx context.Context) error {
+	transcript := a.getRecentTranscript(keyQuotesTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting key quotes with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("key_quotes",
+		`Find the most impactful verbatim quotes in this meeting transcript. Look for statements that capture:
+- Decisions that were made
+- Commitments someone made
+- Concerns or risks that were raised
+- Insights that changed the direction of the discussion
+
+Rules:
+- Copy the quote EXACTLY as it appears in the transcript; do not paraphrase, fix grammar or join separate statements
+- Each quote must be between 3 and 30 words
+- Return at most 10 quotes, most impactful first
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "quotes": [
+    {
+      "text": "Exact words from the transcript",
+      "speaker": "Speaker name",
+      "category": "decision/commitment/concern/insight"
+    }
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Failed to extract key quotes: %v", err)
+		return err
+	}
+
+	if response != "" {
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
+			var result struct {
+				Quotes []Quote `json:"quotes"`
+			}
+			if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+				logrus.Warnf("Failed to parse key quotes JSON: %v", err)
+				return err
+			}
+
+			quotes := verifyQuotes(result.Quotes, transcript)
+			a.data.KeyQuotes = quotes
+			logrus.Infof("Agent %s: Kept %d of %d key quotes found verbatim in the transcript",
+				a.agentID, len(quotes), len(result.Quotes))
+		}
+	}
+	return nil
+}
+
+// verifyQuotes keeps only quotes that appear verbatim in the transcript, filling in the speaker,
+// timestamp and surrounding context from the transcript entry they were found in
+func verifyQuotes(candidates []Quote, transcript []TranscriptEntry) []Quote {
+	quotes := []Quote{}
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		if len(quotes) >= maxKeyQuotes {
+			break
+		}
+
+		text := strings.Trim(strings.TrimSpace(candidate.Text), `"“”`)
+		words := len(strings.Fields(text))
+		if words < minQuoteWords || words > maxQuoteWords || seen[text] {
+			continue
+		}
+
+		for i, entry := range transcript {
+			if !strings.Contains(entry.Text, text) {
+				continue
+			}
+
+			seen[text] = true
+			quotes = append(quotes, Quote{
+				Text:      text,
+				Speaker:   entry.Speaker,
+				Timestamp: entry.Timestamp,
+				Context:   quoteContext(transcript, i, text),
+				Category:  normalizeQuoteCategory(candidate.Category),
+			})
+			break
+		}
+	}
+
+	return quotes
+}
+
+// quoteContext returns the sentence before and after the quote, looking into neighbouring entries when
+// the quote starts or ends its own entry
+func quoteContext(transcript []TranscriptEntry, index int, quote string) string {
+	text := transcript[index].Text
+	quoteStart := strings.Index(text, quote)
+	quoteEnd := quoteStart + len(quote)
+	bounds := sentenceBoundary.FindAllStringIndex(text, -1)
+
+	// Find the sentences containing the first and last characters of the quote
+	first, last := 0, len(bounds)-1
+	for i, bound := range bounds {
+		if bound[0] <= quoteStart && quoteStart < bound[1] {
+			first = i
+		}
+		if bound[0] < quoteEnd && quoteEnd <= bound[1] {
+			last = i
+		}
+	}
+
+	var before, after string
+	if first > 0 {
+		before = strings.TrimSpace(text[bounds[first-1][0]:bounds[first-1][1]])
+	} else if index > 0 {
+		if previous := splitSentences(transcript[index-1].Text); len(previous) > 0 {
+			before = previous[len(previous)-1]
+		}
+	}
+
+	if last+1 < len(bounds) {
+		after = strings.TrimSpace(text[bounds[last+1][0]:bounds[last+1][1]])
+	} else if index+1 < len(transcript) {
+		if next := splitSentences(transcript[index+1].Text); len(next) > 0 {
+			after = next[0]
+		}
+	}
+
+	switch {
+	case before != "" && after != "":
+		return before + " … " + after
+	case before != "":
+		return before
+	default:
+		return after
+	}
+}
+
+// splitSentences splits text into trimmed sentences
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, sentence := range sentenceBoundary.FindAllString(text, -1) {
+		if sentence = strings.TrimSpace(sentence); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// normalizeQuoteCategory maps the LLM's category onto the supported set, defaulting to insight
+func normalizeQuoteCategory(category string) string {
+	switch category = strings.ToLower(strings.TrimSpace(category)); category {
+	case "decision", "commitment", "concern", "insight":
+		return category
+	default:
+		return "insight"
+	}
+}
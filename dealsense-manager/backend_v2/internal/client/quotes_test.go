@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractKeyQuotesAreVerbatim(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"quotes": [
+		{"text": "\"We ship on Friday, no matter what.\"", "speaker": "Bob", "category": "Decision"},
+		{"text": "We will ship on Friday whatever happens", "speaker": "Alice", "category": "decision"},
+		{"text": "Agreed", "speaker": "Bob", "category": "commitment"},
+		{"text": "I'm worried the tests won't pass", "speaker": "Carol", "category": "mood"}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "Review is done. We ship on Friday, no matter what. Marketing is ready.")
+	say(analyst, 10, "Carol", "Honestly, I'm worried the tests won't pass in time")
+
+	if err := analyst.extractKeyQuotes(context.Background()); err != nil {
+		t.Fatalf("extractKeyQuotes() error = %v", err)
+	}
+
+	// Paraphrases and quotes under three words are dropped
+	quotes := analyst.GetAnalysis().KeyQuotes
+	if len(quotes) != 2 {
+		t.Fatalf("KeyQuotes = %+v, want 2 verbatim quotes", quotes)
+	}
+	transcript := analyst.GetAnalysis().Transcript
+	for _, quote := range quotes {
+		found := false
+		for _, entry := range transcript {
+			if entry.Speaker == quote.Speaker && strings.Contains(entry.Text, quote.Text) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("quote %q by %s isn't in the transcript", quote.Text, quote.Speaker)
+		}
+	}
+
+	// The speaker and context come from the transcript, not the LLM
+	decision := quotes[0]
+	if decision.Text != "We ship on Friday, no matter what." || decision.Speaker != "Alice" || decision.Category != "decision" {
+		t.Errorf("decision quote = %+v", decision)
+	}
+	if decision.Context != "Review is done. … Marketing is ready." {
+		t.Errorf("decision context = %q", decision.Context)
+	}
+	if quotes[1].Category != "insight" || quotes[1].Context != "Marketing is ready." {
+		t.Errorf("concern quote = %+v", quotes[1])
+	}
+}
+
+func TestVerifyQuotesLimit(t *testing.T) {
+	transcript := []TranscriptEntry{entryAt(0, "Alice", strings.Repeat("we need more time ", 12))}
+	var candidates []Quote
+	for i := 0; i < 12; i++ {
+		candidates = append(candidates, Quote{Text: strings.TrimSpace(strings.Repeat("we need more time ", i%4+1))})
+	}
+	candidates = append(candidates, Quote{Text: strings.TrimSpace(strings.Repeat("we need more time ", 8))})
+
+	// Duplicates count once, and over-long quotes are dropped
+	quotes := verifyQuotes(candidates, transcript)
+	if len(quotes) != 4 {
+		t.Errorf("verifyQuotes() kept %d quotes, want the 4 distinct ones", len(quotes))
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import "math"
+
+// Reading levels suggested for readers of an analysis section
+const (
+	ReadingLevelElementary = "elementary"  // Below US grade 9
+	ReadingLevelHighSchool = "high_school" // US grades 9 to 12
+	ReadingLevelCollege    = "college"     // Above US grade 12
+)
+
+// Sections of the analysis readability is scored for
+const (
+	readabilitySummary    = "summary"
+	readabilityKeyPoints  = "key_points_combined"
+	readabilityTranscript = "full_transcript"
+)
+
+// ReadabilityScore describes how hard a text is to read, with the grade formulas giving the US school
+// grade needed to understand it
+type ReadabilityScore struct {
+	FleschKincaidGrade      float64 `json:"flesch_kincaid_grade"`
+	GunningFogIndex         float64 `json:"gunning_fog_index"`
+	SmogIndex               float64 `json:"smog_index"`
+	AverageSyllablesPerWord float64 `json:"average_syllables_per_word"`
+	AverageWordsPerSentence float64 `json:"average_words_per_sentence"`
+	SuggestedReadingLevel   string  `json:"suggested_reading_level"` // elementary, high_school, college
+}
+
+// gunningFogIndex applies the Gunning fog formula, counting words of three syllables or more as complex
+func (s textStatistics) gunningFogIndex() float64 {
+	if s.words == 0 || s.sentences == 0 {
+		return 0
+	}
+	return 0.4 * (float64(s.words)/float64(s.sentences) + 100*float64(s.polysyllables)/float64(s.words))
+}
+
+// smogIndex applies the SMOG grade formula, normalized to a 30 sentence sample
+func (s textStatistics) smogIndex() float64 {
+	if s.sentences == 0 {
+		return 0
+	}
+	return 1.0430*math.Sqrt(float64(s.polysyllables)*30/float64(s.sentences)) + 3.1291
+}
+
+// readabilityScore scores text statistics, returning false for text without words
+func (s textStatistics) readabilityScore() (ReadabilityScore, bool) {
+	if s.words == 0 || s.sentences == 0 {
+		return ReadabilityScore{}, false
+	}
+
+	grade := s.fleschKincaidGrade()
+	return ReadabilityScore{
+		FleschKincaidGrade:      grade,
+		GunningFogIndex:         s.gunningFogIndex(),
+		SmogIndex:               s.smogIndex(),
+		AverageSyllablesPerWord: float64(s.syllables) / float64(s.words),
+		AverageWordsPerSentence: float64(s.words) / float64(s.sentences),
+		SuggestedReadingLevel:   readingLevel(grade),
+	}, true
+}
+
+// readingLevel maps a US school grade to the reading level of its readers
+func readingLevel(grade float64) string {
+	switch {
+	case grade < 9:
+		return ReadingLevelElementary
+	case grade <= 12:
+		return ReadingLevelHighSchool
+	default:
+		return ReadingLevelCollege
+	}
+}
+
+// computeReadabilityScores scores the summary, the key points and the transcript, leaving out sections
+// without text. Each key point and transcript entry counts as at least one sentence, as neither reliably
+// ends with punctuation.
+func computeReadabilityScores(summary string, keyPoints []string, transcript []TranscriptEntry) map[string]ReadabilityScore {
+	var keyPointStats, transcriptStats textStatistics
+	for _, point := range keyPoints {
+		keyPointStats.add(measureText(point))
+	}
+	for _, entry := range transcript {
+		transcriptStats.add(measureText(entry.Text))
+	}
+
+	sections := map[string]textStatistics{
+		readabilitySummary:    measureText(summary),
+		readabilityKeyPoints:  keyPointStats,
+		readabilityTranscript: transcriptStats,
+	}
+
+	scores := make(map[string]ReadabilityScore, len(sections))
+	for section, stats := range sections {
+		if score, ok := stats.readabilityScore(); ok {
+			scores[section] = score
+		}
+	}
+	return scores
+}
@@ -0,0 +1,52 @@
+package client
+
+import "testing"
+
+func TestReadabilityScoresOfKnownGrades(t *testing.T) {
+	// Six one-syllable words in one sentence score below the first grade
+	simple, ok := measureText("The cat sat on the mat.").readabilityScore()
+	if !ok {
+		t.Fatal("readabilityScore() reported no words")
+	}
+	if !approx(simple.FleschKincaidGrade, -1.45) || !approx(simple.GunningFogIndex, 2.4) || !approx(simple.SmogIndex, 3.1291) ||
+		simple.AverageSyllablesPerWord != 1 || simple.AverageWordsPerSentence != 6 || simple.SuggestedReadingLevel != ReadingLevelElementary {
+		t.Errorf("simple text score = %+v", simple)
+	}
+
+	academic, _ := measureText("Comprehensive organizational transformation necessitates extraordinarily sophisticated " +
+		"interdisciplinary collaboration between institutional stakeholders.").readabilityScore()
+	if academic.FleschKincaidGrade <= 12 || academic.GunningFogIndex <= 12 || academic.SuggestedReadingLevel != ReadingLevelCollege {
+		t.Errorf("academic text score = %+v, want above grade 12", academic)
+	}
+}
+
+func TestReadingLevel(t *testing.T) {
+	cases := map[float64]string{
+		3:    ReadingLevelElementary,
+		8.9:  ReadingLevelElementary,
+		9:    ReadingLevelHighSchool,
+		12:   ReadingLevelHighSchool,
+		12.1: ReadingLevelCollege,
+	}
+	for grade, want := range cases {
+		if got := readingLevel(grade); got != want {
+			t.Errorf("readingLevel(%v) = %q, want %q", grade, got, want)
+		}
+	}
+}
+
+func TestComputeReadabilityScoresSkipsEmptySections(t *testing.T) {
+	transcript := []TranscriptEntry{entryAt(0, "Alice", "ship it"), entryAt(10, "Bob", "sounds good")}
+	scores := computeReadabilityScores("", []string{"Launch moves to June", "Alice owns the checklist"}, transcript)
+
+	if _, ok := scores[readabilitySummary]; ok {
+		t.Error("empty summary was scored")
+	}
+	// Key points and transcript entries without punctuation still count as a sentence each
+	if score := scores[readabilityKeyPoints]; score.AverageWordsPerSentence != 4 {
+		t.Errorf("key points score = %+v, want 4 words per sentence", score)
+	}
+	if score := scores[readabilityTranscript]; score.AverageWordsPerSentence != 2 {
+		t.Errorf("transcript score = %+v, want 2 words per sentence", score)
+	}
+}
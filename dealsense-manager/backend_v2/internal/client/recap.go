@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RecapFormat is the audience a meeting recap is written for
+type RecapFormat string
+
+const (
+	RecapExecutive   RecapFormat = "executive"   // A few sentences on outcomes and decisions for leadership
+	RecapEngineering RecapFormat = "engineering" // A Jira-style issue description
+	RecapSales       RecapFormat = "sales"       // A CRM activity note
+	RecapCustom      RecapFormat = "custom"      // Written to a caller-supplied template
+)
+
+const (
+	// DefaultRecapWords is the recap length used when none is requested
+	DefaultRecapWords = 150
+	// maxRecapWords is the longest recap that can be requested
+	maxRecapWords = 1000
+	// recapWordTolerance is how far over the word limit the LLM may go before its recap is cut
+	recapWordTolerance = 0.1
+	// recapCacheSize is the number of recaps kept per agent
+	recapCacheSize = 5
+)
+
+var (
+	// ErrInvalidRecapFormat is returned for a format other than executive, engineering, sales or custom
+	ErrInvalidRecapFormat = errors.New("format must be executive, engineering, sales or custom")
+	// ErrInvalidRecapTemplate is returned when a custom recap has no template or an unsafe one
+	ErrInvalidRecapTemplate = errors.New("custom recaps need a template of at most 5000 characters")
+	// ErrInvalidRecapLength is returned for a word limit outside 1 to 1000
+	ErrInvalidRecapLength = fmt.Errorf("max words must be between 1 and %d", maxRecapWords)
+	// ErrNoTranscript is returned when there is nothing to recap yet
+	ErrNoTranscript = errors.New("no transcript to recap")
+)
+
+// recapInstructions describes what each built-in format should contain
+var recapInstructions = map[RecapFormat]string{
+	RecapExecutive: "Write an executive recap of this meeting in three sentences for senior leadership: the purpose of " +
+		"the meeting, the key outcome or decision, and the most important next step. Leave out discussion details.",
+	RecapEngineering: "Write a recap of this meeting as a Jira issue description for the engineering team. Use a short " +
+		"Background paragraph, then Requirements and Acceptance criteria as bullet lists, then any open technical " +
+		"questions. Keep technical details such as systems, versions and figures exactly as stated.",
+	RecapSales: "Write a recap of this meeting as a CRM activity note for the account team. Cover the customer's needs " +
+		"and pain points, objections raised, budget and timeline signals, the decision makers mentioned and the agreed " +
+		"next steps with owners.",
+}
+
+// recapCacheEntry is a generated recap and the transcript length it was generated from
+type recapCacheEntry struct {
+	key        string
+	recap      string
+	statements int
+}
+
+// GenerateRecap writes a recap of the meeting in the given format, cut to maxWords words when the LLM
+// overruns it by more than 10%. A maxWords of 0 uses DefaultRecapWords. Custom recaps follow
+// customTemplate, which describes the recap wanted. The last five recaps are cached until the
+// transcript grows, and recaps are not stored in the analysis.
+func (a *AnalystAgent) GenerateRecap(ctx context.Context, format RecapFormat, maxWords int, customTemplate string) (string, error) {
+	if maxWords == 0 {
+		maxWords = DefaultRecapWords
+	}
+	if maxWords < 0 || maxWords > maxRecapWords {
+		return "", ErrInvalidRecapLength
+	}
+
+	instructions, ok := recapInstructions[format]
+	switch {
+	case format == RecapCustom:
+		customTemplate = strings.TrimSpace(customTemplate)
+		if customTemplate == "" || !a.isSafeInstruction(customTemplate) {
+			return "", ErrInvalidRecapTemplate
+		}
+		instructions = "Write a recap of this meeting following this template:\n" + customTemplate
+	case !ok:
+		return "", ErrInvalidRecapFormat
+	}
+
+	a.dataMutex.RLock()
+	transcript := append([]TranscriptEntry(nil), a.data.Transcript...)
+	a.dataMutex.RUnlock()
+	if len(transcript) == 0 {
+		return "", ErrNoTranscript
+	}
+
+	key := fmt.Sprintf("%s|%d|%s", format, maxWords, customTemplate)
+	if recap, ok := a.cachedRecap(key, len(transcript)); ok {
+		return recap, nil
+	}
+
+	instructions += fmt.Sprintf(" Use at most %d words.", maxWords)
+	text := a.formatTranscriptForLLM(transcript)
+	prompt := a.buildAnalysisPrompt("recap_"+string(format), instructions+`
+
+Respond with the recap only, without a title or preamble.
+
+Transcript:
+%s`, text)
+	// Custom prompts and templates may replace the recap prompt, so its requirements are restated
+	if !strings.Contains(prompt, instructions) {
+		prompt += "\n\n" + instructions
+	}
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate recap: %w", err)
+	}
+	recap := limitWords(strings.TrimSpace(response), maxWords)
+
+	a.recapMutex.Lock()
+	a.recapCache = append(a.recapCache, recapCacheEntry{key: key, recap: recap, statements: len(transcript)})
+	if len(a.recapCache) > recapCacheSize {
+		a.recapCache = a.recapCache[len(a.recapCache)-recapCacheSize:]
+	}
+	a.recapMutex.Unlock()
+
+	logrus.Infof("Agent %s: Generated %s recap of %d words", a.agentID, format, len(strings.Fields(recap)))
+	return recap, nil
+}
+
+// cachedRecap returns the cached recap for key if it was generated from the current transcript
+func (a *AnalystAgent) cachedRecap(key string, statements int) (string, bool) {
+	a.recapMutex.Lock()
+	defer a.recapMutex.Unlock()
+
+	for i := len(a.recapCache) - 1; i >= 0; i-- {
+		entry := a.recapCache[i]
+		if entry.key != key {
+			continue
+		}
+		if entry.statements != statements {
+			a.recapCache = append(a.recapCache[:i], a.recapCache[i+1:]...)
+			return "", false
+		}
+		return entry.recap, true
+	}
+	return "", false
+}
+
+// recapWord matches a word of a recap, keeping its position so formatting is preserved when cutting
+var recapWord = regexp.MustCompile(`\S+`)
+
+// limitWords cuts text to maxWords words when it is more than recapWordTolerance over, ending at the last
+// complete sentence when one ends in the second half of the kept text
+func limitWords(text string, maxWords int) string {
+	words := recapWord.FindAllStringIndex(text, -1)
+	if float64(len(words)) <= float64(maxWords)*(1+recapWordTolerance) {
+		return text
+	}
+
+	cut := text[:words[maxWords-1][1]]
+	for i := maxWords - 1; i >= maxWords/2; i-- {
+		word := text[words[i][0]:words[i][1]]
+		if strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?") {
+			return text[:words[i][1]]
+		}
+	}
+	return strings.TrimRight(cut, ",;:") + "…"
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestLimitWords(t *testing.T) {
+	cases := []struct {
+		text     string
+		maxWords int
+		want     string
+	}{
+		// Up to 10% over the limit is kept
+		{"one two three four five six seven eight nine ten eleven", 10, "one two three four five six seven eight nine ten eleven"},
+		// Cut at the last sentence ending in the second half
+		{"One two three four five six. Seven eight nine ten eleven twelve", 10, "One two three four five six."},
+		// Without one, cut mid-sentence with an ellipsis and no dangling punctuation
+		{"One. Two three four five six seven eight nine ten, eleven twelve", 10, "One. Two three four five six seven eight nine ten…"},
+		{"A **bold** point,\n- next item here and more words", 3, "A **bold** point…"},
+	}
+	for _, c := range cases {
+		if got := limitWords(c.text, c.maxWords); got != c.want {
+			t.Errorf("limitWords(%q, %d) = %q, want %q", c.text, c.maxWords, got, c.want)
+		}
+	}
+}
+
+func TestGenerateRecapValidation(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	ctx := context.Background()
+
+	if _, err := analyst.GenerateRecap(ctx, RecapExecutive, maxRecapWords+1, ""); !errors.Is(err, ErrInvalidRecapLength) {
+		t.Errorf("too long: error = %v, want ErrInvalidRecapLength", err)
+	}
+	if _, err := analyst.GenerateRecap(ctx, "haiku", 0, ""); !errors.Is(err, ErrInvalidRecapFormat) {
+		t.Errorf("unknown format: error = %v, want ErrInvalidRecapFormat", err)
+	}
+	if _, err := analyst.GenerateRecap(ctx, RecapCustom, 0, "  "); !errors.Is(err, ErrInvalidRecapTemplate) {
+		t.Errorf("empty template: error = %v, want ErrInvalidRecapTemplate", err)
+	}
+	if _, err := analyst.GenerateRecap(ctx, RecapSales, 0, ""); !errors.Is(err, ErrNoTranscript) {
+		t.Errorf("no transcript: error = %v, want ErrNoTranscript", err)
+	}
+}
+
+func TestGenerateRecapMaxWords(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	long := strings.Repeat("word ", 40) + "end."
+	mock := llm.NewMockLLMProvider(long, "A short recap.")
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "We agreed to ship the beta on Friday")
+
+	recap, err := analyst.GenerateRecap(context.Background(), RecapEngineering, 20, "")
+	if err != nil {
+		t.Fatalf("GenerateRecap() error = %v", err)
+	}
+	if words := len(strings.Fields(recap)); words != 20 || !strings.HasSuffix(recap, "…") {
+		t.Errorf("recap = %q (%d words), want 20 words cut with an ellipsis", recap, words)
+	}
+	if prompt := mock.Prompts()[0]; !strings.Contains(prompt, "Jira issue description") || !strings.Contains(prompt, "Use at most 20 words.") {
+		t.Errorf("prompt = %q, want the engineering instructions and word limit", prompt)
+	}
+
+	// The same request is cached until the transcript grows
+	if cached, _ := analyst.GenerateRecap(context.Background(), RecapEngineering, 20, ""); cached != recap {
+		t.Errorf("cached recap = %q, want %q", cached, recap)
+	}
+	say(analyst, 10, "Bob", "And the docs the week after")
+	if recap, err := analyst.GenerateRecap(context.Background(), RecapEngineering, 20, ""); err != nil || recap != "A short recap." {
+		t.Errorf("regenerated recap = %q, %v", recap, err)
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Recording platforms with a known timestamp link format
+const (
+	RecordingPlatformZoom   = "zoom"
+	RecordingPlatformTeams  = "teams"
+	RecordingPlatformMeet   = "meet"
+	RecordingPlatformCustom = "custom"
+)
+
+var (
+	// ErrNoRecording is returned when generating a deep link for a meeting without a recording URL
+	ErrNoRecording = errors.New("no recording URL set for meeting")
+	// ErrInvalidRecordingPlatform is returned for a recording platform without a known link format
+	ErrInvalidRecordingPlatform = errors.New("recording platform must be zoom, teams, meet or custom")
+	// ErrInvalidRecordingURL is returned when a recording URL isn't an absolute web URL
+	ErrInvalidRecordingURL = errors.New("recording URL must be an absolute http or https URL")
+)
+
+// GenerateDeepLink returns a link to the moment entry was spoken in the meeting recording. The offset
+// is the time from the start of the meeting to the entry.
+func (d *AnalysisData) GenerateDeepLink(entry TranscriptEntry) (string, error) {
+	if d.RecordingURL == "" {
+		return "", ErrNoRecording
+	}
+
+	link, err := url.Parse(d.RecordingURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid recording URL: %w", err)
+	}
+
+	offset := int(entry.Timestamp.Sub(d.StartTime).Seconds())
+	if offset < 0 {
+		return "", fmt.Errorf("transcript entry at %s is before the meeting started", entry.Timestamp.Format("15:04:05"))
+	}
+	seconds := strconv.Itoa(offset)
+
+	query := link.Query()
+	switch d.RecordingPlatform {
+	case RecordingPlatformZoom, "":
+		query.Set("t", seconds)
+	case RecordingPlatformTeams:
+		// Microsoft Stream takes the start time in seconds as st
+		query.Set("st", seconds)
+	case RecordingPlatformMeet:
+		// Meet recordings are Google Drive videos, which take a duration like 90s
+		query.Set("t", seconds+"s")
+	case RecordingPlatformCustom:
+		// W3C media fragment, supported by browsers playing a video file directly
+		link.Fragment = "t=" + seconds
+		return link.String(), nil
+	default:
+		return "", ErrInvalidRecordingPlatform
+	}
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// EnrichTranscriptWithDeepLinks returns a copy of the transcript with each entry's DeepLinkURL set, or
+// left empty when a link can't be generated
+func (d *AnalysisData) EnrichTranscriptWithDeepLinks() []TranscriptEntry {
+	entries := make([]TranscriptEntry, len(d.Transcript))
+	for i, entry := range d.Transcript {
+		entry.DeepLinkURL, _ = d.GenerateDeepLink(entry)
+		entries[i] = entry
+	}
+	return entries
+}
+
+// SetRecording records where the meeting recording can be watched so transcript entries can link to it
+func (a *AnalystAgent) SetRecording(recordingURL, platform string) error {
+	switch platform {
+	case RecordingPlatformZoom, RecordingPlatformTeams, RecordingPlatformMeet, RecordingPlatformCustom:
+	default:
+		return ErrInvalidRecordingPlatform
+	}
+
+	parsed, err := url.Parse(recordingURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidRecordingURL
+	}
+
+	a.dataMutex.Lock()
+	a.data.RecordingURL = recordingURL
+	a.data.RecordingPlatform = platform
+	a.dataMutex.Unlock()
+
+	return a.saveAnalysis()
+}
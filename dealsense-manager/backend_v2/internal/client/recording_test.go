@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateDeepLink(t *testing.T) {
+	entry := entryAt(95, "Alice", "Let's look at the numbers")
+
+	tests := []struct {
+		platform string
+		url      string
+		want     string
+	}{
+		{RecordingPlatformZoom, "https://zoom.us/rec/play/abc?pwd=x", "https://zoom.us/rec/play/abc?pwd=x&t=95"},
+		{"", "https://zoom.us/rec/play/abc", "https://zoom.us/rec/play/abc?t=95"},
+		{RecordingPlatformTeams, "https://contoso.sharepoint.com/stream.aspx?id=1", "https://contoso.sharepoint.com/stream.aspx?id=1&st=95"},
+		{RecordingPlatformMeet, "https://drive.google.com/file/d/abc/view", "https://drive.google.com/file/d/abc/view?t=95s"},
+		{RecordingPlatformCustom, "https://cdn.example.com/meeting.mp4", "https://cdn.example.com/meeting.mp4#t=95"},
+	}
+	for _, tt := range tests {
+		data := &AnalysisData{StartTime: testMeetingStart, RecordingURL: tt.url, RecordingPlatform: tt.platform}
+		got, err := data.GenerateDeepLink(entry)
+		if err != nil {
+			t.Errorf("%s: %v", tt.platform, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s link = %s, want %s", tt.platform, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateDeepLinkErrors(t *testing.T) {
+	entry := entryAt(10, "Alice", "Hello")
+
+	if _, err := (&AnalysisData{StartTime: testMeetingStart}).GenerateDeepLink(entry); !errors.Is(err, ErrNoRecording) {
+		t.Errorf("without a recording: %v, want ErrNoRecording", err)
+	}
+	unknown := &AnalysisData{StartTime: testMeetingStart, RecordingURL: "https://example.com/v", RecordingPlatform: "webex"}
+	if _, err := unknown.GenerateDeepLink(entry); !errors.Is(err, ErrInvalidRecordingPlatform) {
+		t.Errorf("unknown platform: %v, want ErrInvalidRecordingPlatform", err)
+	}
+	early := &AnalysisData{StartTime: testMeetingStart, RecordingURL: "https://example.com/v"}
+	if _, err := early.GenerateDeepLink(entryAt(-5, "Alice", "Before")); err == nil {
+		t.Error("link generated for an entry before the meeting started")
+	}
+}
+
+func TestSetRecording(t *testing.T) {
+	analyst := newTestAnalyst(t)
+
+	if err := analyst.SetRecording("https://zoom.us/rec/play/abc", "slack"); !errors.Is(err, ErrInvalidRecordingPlatform) {
+		t.Errorf("unknown platform: %v", err)
+	}
+	if err := analyst.SetRecording("zoom.us/rec/play/abc", RecordingPlatformZoom); !errors.Is(err, ErrInvalidRecordingURL) {
+		t.Errorf("relative URL: %v", err)
+	}
+	if err := analyst.SetRecording("https://zoom.us/rec/play/abc", RecordingPlatformZoom); err != nil {
+		t.Fatal(err)
+	}
+	if data := analyst.GetAnalysis(); data.RecordingURL != "https://zoom.us/rec/play/abc" || data.RecordingPlatform != RecordingPlatformZoom {
+		t.Errorf("recording = %q on %q", data.RecordingURL, data.RecordingPlatform)
+	}
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MeetingRecordsPath is the file each tenant's all-time meeting records are kept in
+const MeetingRecordsPath = "data/records.json"
+
+// Metrics of a finalized meeting that records are kept for
+const (
+	RecordDurationMinutes  = "duration_minutes"
+	RecordParticipantCount = "participant_count"
+	RecordActionItemCount  = "action_item_count"
+)
+
+// meetingRecordsMutex serializes reading and rewriting the records, which all agents share
+var meetingRecordsMutex sync.Mutex
+
+// RecordEvent is an all-time record of the tenant's meetings that this meeting broke
+type RecordEvent struct {
+	Metric                  string    `json:"metric"` // duration_minutes, participant_count, action_item_count
+	Value                   float64   `json:"value"`
+	PreviousRecord          float64   `json:"previous_record"`
+	PreviousRecordMeetingID string    `json:"previous_record_meeting_id"`
+	BrokenAt                time.Time `json:"broken_at"`
+}
+
+// meetingRecord is the highest value of a metric across a tenant's meetings and the meeting that set it
+type meetingRecord struct {
+	Value     float64   `json:"value"`
+	MeetingID string    `json:"meeting_id"`
+	SetAt     time.Time `json:"set_at"`
+}
+
+// meetingRecords holds each tenant's records keyed by tenant ID, then by metric
+type meetingRecords map[string]map[string]meetingRecord
+
+// updateMeetingRecords compares the finalized meeting against its tenant's all-time records, storing the
+// records it broke in RecordBreakers and announcing each at info level, which the Discord hook forwards to
+// the info webhook. The first meeting of a tenant sets the records without breaking any.
+func (a *AnalystAgent) updateMeetingRecords() error {
+	a.dataMutex.RLock()
+	meetingID, tenantID := a.data.MeetingID, a.data.TenantID
+	values := meetingRecordValues(a.data)
+	a.dataMutex.RUnlock()
+
+	meetingRecordsMutex.Lock()
+	defer meetingRecordsMutex.Unlock()
+
+	records, err := loadMeetingRecords(MeetingRecordsPath)
+	if err != nil {
+		return err
+	}
+
+	broken, changed := compareMeetingRecords(records, tenantID, meetingID, values, time.Now())
+	if changed {
+		if err := saveMeetingRecords(MeetingRecordsPath, records); err != nil {
+			return err
+		}
+	}
+
+	a.dataMutex.Lock()
+	a.data.RecordBreakers = broken
+	a.dataMutex.Unlock()
+
+	for _, event := range broken {
+		logrus.WithFields(logrus.Fields{
+			"agent_id":                   a.agentID,
+			"meeting_id":                 meetingID,
+			"value":                      event.Value,
+			"previous_record":            event.PreviousRecord,
+			"previous_record_meeting_id": event.PreviousRecordMeetingID,
+		}).Infof("🏆 Meeting record broken: %s", event.Metric)
+	}
+	return nil
+}
+
+// meetingRecordValues returns the meeting's value of each metric records are kept for. Decisions aren't
+// counted as action items.
+func meetingRecordValues(data *AnalysisData) map[string]float64 {
+	actionItems := 0
+	for _, item := range data.ActionItems {
+		if item.Type != "decision" {
+			actionItems++
+		}
+	}
+	return map[string]float64{
+		RecordDurationMinutes:  data.DurationMinutes,
+		RecordParticipantCount: float64(len(data.Participants)),
+		RecordActionItemCount:  float64(actionItems),
+	}
+}
+
+// compareMeetingRecords updates the tenant's records with the meeting's values that exceed them, returning
+// the records broken, ordered by metric, and whether the records changed. Metrics without a record yet are
+// recorded without counting as broken, and a meeting doesn't break its own record.
+func compareMeetingRecords(records meetingRecords, tenantID, meetingID string, values map[string]float64, now time.Time) ([]RecordEvent, bool) {
+	tenant := records[tenantID]
+	if tenant == nil {
+		tenant = make(map[string]meetingRecord)
+		records[tenantID] = tenant
+	}
+
+	var broken []RecordEvent
+	changed := false
+	for _, metric := range []string{RecordDurationMinutes, RecordParticipantCount, RecordActionItemCount} {
+		value := values[metric]
+		if value <= 0 {
+			continue
+		}
+
+		previous, ok := tenant[metric]
+		switch {
+		case !ok:
+			// The first meeting with the metric sets its record
+		case previous.MeetingID == meetingID || value <= previous.Value:
+			continue
+		default:
+			broken = append(broken, RecordEvent{
+				Metric:                  metric,
+				Value:                   value,
+				PreviousRecord:          previous.Value,
+				PreviousRecordMeetingID: previous.MeetingID,
+				BrokenAt:                now,
+			})
+		}
+		tenant[metric] = meetingRecord{Value: value, MeetingID: meetingID, SetAt: now}
+		changed = true
+	}
+	return broken, changed
+}
+
+// loadMeetingRecords reads the meeting records, returning no records when the file doesn't exist yet
+func loadMeetingRecords(path string) (meetingRecords, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return meetingRecords{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read meeting records: %w", err)
+	}
+
+	records := meetingRecords{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse meeting records: %w", err)
+	}
+	return records, nil
+}
+
+// saveMeetingRecords writes the meeting records to a temporary file and renames it over path, so readers
+// never see a partly written file
+func saveMeetingRecords(path string, records meetingRecords) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal meeting records: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create meeting records directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write meeting records: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace meeting records: %w", err)
+	}
+	return nil
+}
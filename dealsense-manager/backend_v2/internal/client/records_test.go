@@ -0,0 +1,113 @@
+package client
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareMeetingRecords(t *testing.T) {
+	records := meetingRecords{}
+	now := testMeetingStart
+
+	// The first meeting sets the records without breaking any
+	broken, changed := compareMeetingRecords(records, "acme", "m1", map[string]float64{
+		RecordDurationMinutes: 60, RecordParticipantCount: 5, RecordActionItemCount: 0,
+	}, now)
+	if len(broken) != 0 || !changed {
+		t.Fatalf("first meeting: broken = %+v, changed = %v", broken, changed)
+	}
+	if _, ok := records["acme"][RecordActionItemCount]; ok {
+		t.Error("a zero value set a record")
+	}
+
+	later := now.Add(time.Hour)
+	broken, changed = compareMeetingRecords(records, "acme", "m2", map[string]float64{
+		RecordDurationMinutes: 90, RecordParticipantCount: 5, RecordActionItemCount: 3,
+	}, later)
+	want := []RecordEvent{{Metric: RecordDurationMinutes, Value: 90, PreviousRecord: 60, PreviousRecordMeetingID: "m1", BrokenAt: later}}
+	if !reflect.DeepEqual(broken, want) || !changed {
+		t.Errorf("second meeting: broken = %+v, want %+v", broken, want)
+	}
+	// Equalling a record doesn't break it, and the first action items set that record
+	if record := records["acme"][RecordParticipantCount]; record.MeetingID != "m1" {
+		t.Errorf("participant record = %+v, want m1's kept", record)
+	}
+	if record := records["acme"][RecordActionItemCount]; record.MeetingID != "m2" || record.Value != 3 {
+		t.Errorf("action item record = %+v", record)
+	}
+
+	// A meeting finalized again doesn't break its own records
+	if broken, changed := compareMeetingRecords(records, "acme", "m2", map[string]float64{RecordDurationMinutes: 120}, later); len(broken) != 0 || changed {
+		t.Errorf("same meeting: broken = %+v, changed = %v", broken, changed)
+	}
+	// Tenants have their own records
+	if broken, _ := compareMeetingRecords(records, "globex", "g1", map[string]float64{RecordDurationMinutes: 200}, later); len(broken) != 0 {
+		t.Errorf("other tenant: broken = %+v", broken)
+	}
+}
+
+func TestSaveMeetingRecordsReplacesFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	records := meetingRecords{"acme": {RecordDurationMinutes: {Value: 60, MeetingID: "m1", SetAt: testMeetingStart}}}
+	if err := saveMeetingRecords(MeetingRecordsPath, records); err != nil {
+		t.Fatalf("saveMeetingRecords() error = %v", err)
+	}
+	records["acme"][RecordDurationMinutes] = meetingRecord{Value: 90, MeetingID: "m2", SetAt: testMeetingStart}
+	if err := saveMeetingRecords(MeetingRecordsPath, records); err != nil {
+		t.Fatalf("second saveMeetingRecords() error = %v", err)
+	}
+
+	if _, err := os.Stat(MeetingRecordsPath + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+	loaded, err := loadMeetingRecords(MeetingRecordsPath)
+	if err != nil || !reflect.DeepEqual(loaded, records) {
+		t.Errorf("loadMeetingRecords() = %+v, %v, want %+v", loaded, err, records)
+	}
+}
+
+func TestUpdateMeetingRecords(t *testing.T) {
+	first := newTestAnalyst(t)
+	first.data.MeetingID, first.data.TenantID = "m1", "acme"
+	first.data.DurationMinutes = 30
+	first.data.Participants = []string{"Alice", "Bob"}
+	if err := first.updateMeetingRecords(); err != nil {
+		t.Fatalf("updateMeetingRecords() error = %v", err)
+	}
+
+	second := NewAnalystAgent("second-agent", first.config, nil)
+	second.data.MeetingID, second.data.TenantID = "m2", "acme"
+	second.data.DurationMinutes = 45
+	second.data.Participants = []string{"Alice"}
+	// Decisions don't count as action items
+	second.data.ActionItems = []ActionItem{{Description: "Ship it", Type: "decision"}}
+	if err := second.updateMeetingRecords(); err != nil {
+		t.Fatalf("second updateMeetingRecords() error = %v", err)
+	}
+
+	broken := second.GetAnalysis().RecordBreakers
+	if len(broken) != 1 || broken[0].Metric != RecordDurationMinutes || broken[0].PreviousRecordMeetingID != "m1" {
+		t.Errorf("RecordBreakers = %+v, want the duration record", broken)
+	}
+}
+
+func TestUpdateMeetingRecordsKeepsCorruptFile(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.DurationMinutes = 30
+	if err := os.MkdirAll("data", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(MeetingRecordsPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := analyst.updateMeetingRecords(); err == nil || !strings.Contains(err.Error(), "failed to parse meeting records") {
+		t.Errorf("updateMeetingRecords() error = %v, want a parse error", err)
+	}
+	if data, _ := os.ReadFile(MeetingRecordsPath); string(data) != "{not json" {
+		t.Errorf("records file = %q, want it untouched", data)
+	}
+}
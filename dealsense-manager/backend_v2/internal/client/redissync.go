@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/storage"
+)
+
+const (
+	subscriberBaseBackoff = time.Second
+	subscriberMaxBackoff  = 30 * time.Second
+)
+
+// RedisSubscriber receives the analysis updates published by storage.RedisPublisher for every meeting
+type RedisSubscriber struct {
+	client   *redis.Client
+	OnUpdate func(data *AnalysisData)
+}
+
+// NewRedisSubscriber creates a subscriber that calls onUpdate with each analysis update
+func NewRedisSubscriber(client *redis.Client, onUpdate func(data *AnalysisData)) *RedisSubscriber {
+	return &RedisSubscriber{client: client, OnUpdate: onUpdate}
+}
+
+// Run listens for analysis updates until ctx is cancelled, resubscribing with exponential backoff when the
+// connection to Redis is lost
+func (s *RedisSubscriber) Run(ctx context.Context) {
+	backoff := subscriberBaseBackoff
+	for {
+		received, err := s.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = subscriberBaseBackoff
+		}
+		logrus.Warnf("Analysis update subscription lost, resubscribing in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, subscriberMaxBackoff)
+	}
+}
+
+// listen subscribes to the analysis channels and handles messages until receiving fails, reporting
+// whether any message was received
+func (s *RedisSubscriber) listen(ctx context.Context) (bool, error) {
+	pubsub := s.client.PSubscribe(ctx, storage.AnalysisChannelPattern)
+	defer pubsub.Close()
+	// ReceiveMessage keeps blocking on the connection after ctx is cancelled, so close it to return
+	stop := context.AfterFunc(ctx, func() { pubsub.Close() })
+	defer stop()
+
+	received := false
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		var data AnalysisData
+		if err := json.Unmarshal([]byte(msg.Payload), &data); err != nil {
+			logrus.Warnf("Ignoring malformed analysis update on %s: %v", msg.Channel, err)
+			continue
+		}
+		s.OnUpdate(&data)
+	}
+}
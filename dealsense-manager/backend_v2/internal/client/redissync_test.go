@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"joinly-manager/internal/storage"
+)
+
+func TestRedisSubscriberReceivesUpdates(t *testing.T) {
+	server := miniredis.RunT(t)
+	redisClient, err := storage.NewRedisClient("redis://" + server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer redisClient.Close()
+
+	updates := make(chan *AnalysisData, 1)
+	subscriber := NewRedisSubscriber(redisClient, func(data *AnalysisData) { updates <- data })
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		subscriber.Run(ctx)
+		close(stopped)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for server.PubSubNumPat() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber never subscribed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Malformed updates are skipped without dropping the subscription
+	server.Publish(storage.AnalysisChannel("acme", "m1"), "{not json")
+	server.Publish(storage.AnalysisChannel("acme", "m1"), `{"meeting_id": "m1", "tenant_id": "acme", "summary": "Rollout planned"}`)
+
+	select {
+	case data := <-updates:
+		if data.MeetingID != "m1" || data.Summary != "Rollout planned" {
+			t.Errorf("update = %+v", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no analysis update received")
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't return after cancellation")
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/models"
+)
+
+// ReplayAnalystAgent re-runs analysis over a previously saved meeting analysis file
+type ReplayAnalystAgent struct {
+	*AnalystAgent
+	sourcePath string
+}
+
+// NewReplayAnalystAgent loads the saved analysis at sourcePath; results are written to outputPath
+// so the original file is left untouched
+func NewReplayAnalystAgent(sourcePath, outputPath string, config models.AgentConfig) (*ReplayAnalystAgent, error) {
+	raw, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript file: %w", err)
+	}
+
+	data, err := migration.MigrateAnalysisData[AnalysisData](raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transcript file: %w", err)
+	}
+
+	agentID := data.MeetingID
+	if agentID == "" {
+		agentID = strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+	}
+
+	llmProvider, err := llm.GetProvider(string(config.LLMProvider), config.LLMModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
+	}
+
+	analyst := newAnalystAgent(agentID, config, llmProvider, newStepProviders(agentID, config.StepProviders), data)
+	analyst.filePath = outputPath
+	analyst.loadPromptTemplateDir()
+
+	return &ReplayAnalystAgent{AnalystAgent: analyst, sourcePath: sourcePath}, nil
+}
+
+// Run analyzes the complete saved transcript and returns the new analysis. Summary, key point, action
+// item, topic and sentiment results are kept when their prompt is unchanged since the saved analysis.
+func (r *ReplayAnalystAgent) Run(ctx context.Context) (*AnalysisData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Replaying analysis for %s", r.sourcePath)
+	r.skipUnchangedPromptSteps()
+
+	if err := r.runAnalysis(WindowAll); err != nil {
+		return nil, err
+	}
+
+	return r.GetAnalysis(), nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+func TestNewReplayAnalystAgentLoadsSavedAnalysis(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "standup.json")
+	// A v0 file with a null list is migrated when loaded
+	saved := `{"start_time": "2024-05-01T10:00:00Z", "summary": "Old summary", "action_items": null,
+		"transcript": [{"speaker": "Alice", "text": "Let's review the rollout plan", "timestamp": "2024-05-01T10:00:00Z"}]}`
+	if err := os.WriteFile(source, []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "standup.replay.json")
+
+	replay, err := NewReplayAnalystAgent(source, output, models.AgentConfig{LLMProvider: "google", LLMModel: "gemini-2.5-flash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without a meeting ID the agent is named after the file
+	if replay.agentID != "standup" || replay.filePath != output {
+		t.Errorf("agent %q writing to %q", replay.agentID, replay.filePath)
+	}
+	data := replay.GetAnalysis()
+	if len(data.Transcript) != 1 || data.Summary != "Old summary" || data.ActionItems == nil {
+		t.Errorf("loaded analysis = %+v", data)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := replay.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	if raw, _ := os.ReadFile(source); string(raw) != saved {
+		t.Error("source file was modified")
+	}
+}
+
+func TestNewReplayAnalystAgentErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewReplayAnalystAgent(filepath.Join(dir, "missing.json"), "", models.AgentConfig{}); err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("missing file error = %v", err)
+	}
+
+	source := filepath.Join(dir, "m1.json")
+	if err := os.WriteFile(source, []byte(`{"meeting_id": "m1"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReplayAnalystAgent(source, "", models.AgentConfig{LLMProvider: "unknown"}); err == nil || !strings.Contains(err.Error(), "unsupported LLM provider") {
+		t.Errorf("unsupported provider error = %v", err)
+	}
+}
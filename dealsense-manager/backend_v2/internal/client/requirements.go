@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// requirementsTranscript is the number of recent transcript entries searched for requirements
+const requirementsTranscript = 50
+
+// Requirement is a product or engineering requirement derived from the meeting
+type Requirement struct {
+	Description        string   `json:"description"`
+	Type               string   `json:"type"`     // functional, non-functional, constraint
+	Priority           string   `json:"priority"` // high, medium, low
+	RequestedBy        string   `json:"requested_by,omitempty"`
+	AcceptanceCriteria []string `json:"acceptance_criteria,omitempty"`
+	Ambiguous          bool     `json:"ambiguous"` // The requirement needs clarification before it can be built
+}
+
+// UnansweredQuestion is an open question left by the meeting, raised by an ambiguous requirement or asked
+// in the meeting without getting an answer
+type UnansweredQuestion struct {
+	Question         string `json:"question"`
+	RequirementIndex int    `json:"requirement_index"` // Index of the ambiguous requirement in AnalysisData.Requirements, -1 for questions asked in the meeting
+	QAPairIndex      int    `json:"qa_pair_index"`     // Index of the question in AnalysisData.QuestionsAndAnswers, -1 for ambiguous requirements
+	AskedBy          string `json:"asked_by,omitempty"`
+}
+
+// extractRequirements derives feature requirements from user stories, acceptance criteria and constraints
+// discussed in the meeting, raising an unanswered question for each ambiguous requirement
+func (a *AnalystAgent) extractRequirements(ctx context.Context) error {
+	transcript := a.getRecentTranscript(requirementsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting requirements with %d transcript entries", a.agentID, len(transcript))
+
+	prompt := a.buildAnalysisPrompt("requirements",
+		`Extract product and engineering requirements from this meeting transcript. Focus on:
+- User stories: who needs what and why
+- Acceptance criteria: the conditions that tell us a requirement is met
+- Constraints: technical, legal, budget or timeline limits the solution must respect
+
+Classify each requirement as:
+- functional: something the product must do
+- non-functional: a quality the product must have, such as performance, security or reliability
+- constraint: a limit on how the solution can be built
+
+Only include statements that describe what the product must do or satisfy. Exclude status updates, opinions, small talk and action items that are not requirements.
+Mark a requirement as ambiguous when it cannot be built without further clarification, and give the question that would resolve it.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "requirements": [
+    {
+      "description": "What is required",
+      "type": "functional/non-functional/constraint",
+      "priority": "high/medium/low",
+      "requested_by": "Speaker who asked for it",
+      "acceptance_criteria": ["Criterion"],
+      "ambiguous": false,
+      "clarifying_question": "Question to resolve the ambiguity, only when ambiguous"
+    }
+  ]
+}
+`+"`"+``,
+		a.formatTranscriptForLLM(transcript))
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		logrus.Warnf("Failed to extract requirements: %v", err)
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		Requirements []struct {
+			Requirement
+			ClarifyingQuestion string `json:"clarifying_question"`
+		} `json:"requirements"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse requirements JSON: %w", err)
+	}
+
+	requirements := []Requirement{}
+	questions := []UnansweredQuestion{}
+	for _, candidate := range result.Requirements {
+		requirement, ok := normalizeRequirement(candidate.Requirement)
+		if !ok {
+			continue
+		}
+
+		if requirement.Ambiguous {
+			question := strings.TrimSpace(candidate.ClarifyingQuestion)
+			if question == "" {
+				question = fmt.Sprintf("What exactly is needed for: %s?", requirement.Description)
+			}
+			questions = append(questions, UnansweredQuestion{Question: question, RequirementIndex: len(requirements), QAPairIndex: -1})
+		}
+		requirements = append(requirements, requirement)
+	}
+
+	// Questions left unanswered in the meeting stay; only those of ambiguous requirements are replaced
+	ambiguous := len(questions)
+	for _, question := range a.data.UnansweredQuestions {
+		if question.RequirementIndex < 0 {
+			questions = append(questions, question)
+		}
+	}
+	a.data.Requirements = requirements
+	a.data.UnansweredQuestions = questions
+	logrus.Infof("Agent %s: Extracted %d requirements (%d ambiguous)", a.agentID, len(requirements), ambiguous)
+	return nil
+}
+
+// normalizeRequirement validates a requirement returned by the LLM, reporting false for statements that
+// are not requirements
+func normalizeRequirement(requirement Requirement) (Requirement, bool) {
+	requirement.Description = strings.TrimSpace(requirement.Description)
+	if requirement.Description == "" {
+		return requirement, false
+	}
+
+	switch requirement.Type = strings.ToLower(strings.TrimSpace(requirement.Type)); requirement.Type {
+	case "functional", "non-functional", "constraint":
+	case "nonfunctional", "non functional":
+		requirement.Type = "non-functional"
+	default:
+		return requirement, false
+	}
+
+	switch requirement.Priority = strings.ToLower(strings.TrimSpace(requirement.Priority)); requirement.Priority {
+	case "high", "medium", "low":
+	default:
+		requirement.Priority = "medium"
+	}
+
+	requirement.RequestedBy = strings.TrimSpace(requirement.RequestedBy)
+	return requirement, true
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractRequirementsExcludesNonRequirements(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"requirements": [
+		{"description": "Export reports as CSV", "type": "Functional", "priority": "high", "requested_by": "Dana", "acceptance_criteria": ["Includes every column"]},
+		{"description": "The demo went well", "type": "status update", "priority": "low"},
+		{"description": "", "type": "functional"},
+		{"description": "Pages load quickly", "type": "non functional", "priority": "urgent", "ambiguous": true, "clarifying_question": "What load time counts as quick?"},
+		{"description": "Must run on-premises", "type": "constraint", "priority": "medium", "ambiguous": true}
+	]}` + "\n```")
+	say(analyst, 0, "Dana", "We need CSV export and pages must load quickly")
+	analyst.data.UnansweredQuestions = []UnansweredQuestion{{Question: "Who owns QA?", RequirementIndex: -1, QAPairIndex: 0}}
+
+	if err := analyst.extractRequirements(context.Background()); err != nil {
+		t.Fatalf("extractRequirements() error = %v", err)
+	}
+
+	analysis := analyst.GetAnalysis()
+	requirements := analysis.Requirements
+	if len(requirements) != 3 {
+		t.Fatalf("Requirements = %+v, want the 3 requirements", requirements)
+	}
+	if requirements[0].Type != "functional" || requirements[0].RequestedBy != "Dana" {
+		t.Errorf("CSV requirement = %+v", requirements[0])
+	}
+	if requirements[1].Type != "non-functional" || requirements[1].Priority != "medium" {
+		t.Errorf("load time requirement = %+v", requirements[1])
+	}
+
+	// Each ambiguous requirement raises a question; questions asked in the meeting are kept
+	want := []UnansweredQuestion{
+		{Question: "What load time counts as quick?", RequirementIndex: 1, QAPairIndex: -1},
+		{Question: "What exactly is needed for: Must run on-premises?", RequirementIndex: 2, QAPairIndex: -1},
+		{Question: "Who owns QA?", RequirementIndex: -1, QAPairIndex: 0},
+	}
+	if got := analysis.UnansweredQuestions; len(got) != len(want) {
+		t.Fatalf("UnansweredQuestions = %+v, want %+v", got, want)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("question %d = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/models"
+)
+
+// retentionCheckInterval is the number of transcript appends between retention policy checks
+const retentionCheckInterval = 100
+
+// overflowFilePath returns the sidecar file that pruned transcript entries are appended to
+func (a *AnalystAgent) overflowFilePath() string {
+	return strings.TrimSuffix(a.filePath, ".json") + "_overflow.jsonl"
+}
+
+// applyRetentionPolicy prunes middle transcript entries once the policy's limit is exceeded, queueing them
+// for flushOverflow to write to the overflow file (caller must hold dataMutex)
+func (a *AnalystAgent) applyRetentionPolicy() {
+	policy := a.config.TranscriptRetention
+	if policy == nil || policy.MaxEntries <= 0 {
+		return
+	}
+
+	start, end := pruneRange(len(a.data.Transcript), policy)
+	if start >= end {
+		return
+	}
+
+	pruned := end - start
+	a.pendingOverflow = append(a.pendingOverflow, a.data.Transcript[start:end]...)
+	a.data.Transcript = append(a.data.Transcript[:start], a.data.Transcript[end:]...)
+	a.shiftConflicts(start, end)
+	a.data.PrunedEntryCount += pruned
+	logrus.Infof("Agent %s: Pruned %d transcript entries to %s", a.agentID, pruned, a.overflowFilePath())
+}
+
+// flushOverflow writes the pruned transcript entries to the overflow file. The entries are taken under
+// dataMutex but written after releasing it, so slow disks don't block the analysis; entries that fail to
+// be written are put back for the next flush rather than lost. The caller must not hold dataMutex.
+func (a *AnalystAgent) flushOverflow() {
+	a.overflowMutex.Lock()
+	defer a.overflowMutex.Unlock()
+
+	a.dataMutex.Lock()
+	entries := a.pendingOverflow
+	a.pendingOverflow = nil
+	a.dataMutex.Unlock()
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := a.writeOverflow(entries); err != nil {
+		logrus.Errorf("Agent %s: Failed to write %d pruned transcript entries to the overflow file, will retry: %v", a.agentID, len(entries), err)
+		a.dataMutex.Lock()
+		a.pendingOverflow = append(entries, a.pendingOverflow...)
+		a.dataMutex.Unlock()
+	}
+}
+
+// pruneRange returns the range of transcript entries to prune so that at most MaxEntries remain. The
+// oldest unprotected entries are pruned first and the first and last windows are never touched.
+func pruneRange(count int, policy *models.TranscriptRetentionPolicy) (int, int) {
+	excess := count - policy.MaxEntries
+	if excess <= 0 {
+		return 0, 0
+	}
+
+	start := max(policy.RetainFirstN, 0)
+	protectedEnd := count - max(policy.RetainLastN, 0)
+	if start >= protectedEnd {
+		return 0, 0
+	}
+
+	return start, min(start+excess, protectedEnd)
+}
+
+// writeOverflow appends entries to the overflow file as newline-delimited JSON
+func (a *AnalystAgent) writeOverflow(entries []TranscriptEntry) error {
+	file, err := os.OpenFile(a.overflowFilePath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open overflow file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write overflow entry: %w", err)
+		}
+	}
+
+	return file.Sync()
+}
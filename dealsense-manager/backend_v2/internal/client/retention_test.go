@@ -0,0 +1,106 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+func TestPruneRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		policy     models.TranscriptRetentionPolicy
+		start, end int
+	}{
+		{"within limit", 10, models.TranscriptRetentionPolicy{MaxEntries: 10}, 0, 0},
+		{"oldest first", 15, models.TranscriptRetentionPolicy{MaxEntries: 10}, 0, 5},
+		{"after first window", 15, models.TranscriptRetentionPolicy{MaxEntries: 10, RetainFirstN: 3}, 3, 8},
+		{"stops at last window", 15, models.TranscriptRetentionPolicy{MaxEntries: 2, RetainFirstN: 3, RetainLastN: 4}, 3, 11},
+		{"windows cover everything", 15, models.TranscriptRetentionPolicy{MaxEntries: 5, RetainFirstN: 8, RetainLastN: 8}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := pruneRange(tt.count, &tt.policy)
+			if start != tt.start || end != tt.end {
+				t.Errorf("pruneRange() = %d, %d, want %d, %d", start, end, tt.start, tt.end)
+			}
+			if start < end && (start < tt.policy.RetainFirstN || end > tt.count-tt.policy.RetainLastN) {
+				t.Errorf("range %d-%d prunes a protected window", start, end)
+			}
+		})
+	}
+}
+
+func TestRetentionKeepsProtectedWindows(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.TranscriptRetention = &models.TranscriptRetentionPolicy{MaxEntries: 10, RetainFirstN: 3, RetainLastN: 4}
+
+	analyst.dataMutex.Lock()
+	for i := 0; i < 20; i++ {
+		analyst.data.Transcript = append(analyst.data.Transcript, entryAt(i, "Alice", fmt.Sprintf("entry %d", i)))
+	}
+	analyst.applyRetentionPolicy()
+	analyst.dataMutex.Unlock()
+	analyst.flushOverflow()
+
+	data := analyst.GetAnalysis()
+	if len(data.Transcript) != 10 || data.PrunedEntryCount != 10 {
+		t.Fatalf("%d entries kept, %d pruned, want 10 and 10", len(data.Transcript), data.PrunedEntryCount)
+	}
+	for i, want := range []string{"entry 0", "entry 1", "entry 2"} {
+		if data.Transcript[i].Text != want {
+			t.Errorf("entry %d = %q, want the opening window kept", i, data.Transcript[i].Text)
+		}
+	}
+	for i, want := range []string{"entry 16", "entry 17", "entry 18", "entry 19"} {
+		if got := data.Transcript[6+i].Text; got != want {
+			t.Errorf("entry %d = %q, want the latest window kept", 6+i, got)
+		}
+	}
+
+	file, err := os.Open(analyst.overflowFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var overflow []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry TranscriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		overflow = append(overflow, entry.Text)
+	}
+	if len(overflow) != 10 || overflow[0] != "entry 3" || overflow[9] != "entry 12" {
+		t.Errorf("overflow = %q, want entries 3 to 12", overflow)
+	}
+}
+
+func TestFlushOverflowKeepsEntriesWhenWriteFails(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	filePath := analyst.filePath
+	analyst.filePath = filepath.Join(t.TempDir(), "missing", "meeting_analysis.json")
+	analyst.pendingOverflow = []TranscriptEntry{entryAt(0, "Alice", "entry 0"), entryAt(1, "Bob", "entry 1")}
+
+	analyst.flushOverflow()
+	if len(analyst.pendingOverflow) != 2 {
+		t.Fatalf("%d entries pending after a failed write, want 2 kept for the next flush", len(analyst.pendingOverflow))
+	}
+
+	analyst.filePath = filePath
+	analyst.flushOverflow()
+	if len(analyst.pendingOverflow) != 0 {
+		t.Errorf("%d entries still pending after a successful write", len(analyst.pendingOverflow))
+	}
+	if data, err := os.ReadFile(analyst.overflowFilePath()); err != nil || bytes.Count(data, []byte("\n")) != 2 {
+		t.Errorf("overflow file = %q, %v, want the two entries", data, err)
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// roiWorthItCostPerOutcome is the highest cost per outcome of a meeting that was worth it
+	roiWorthItCostPerOutcome = 100.0
+	// roiBorderlineCostPerOutcome is the highest cost per outcome of a borderline meeting; costlier ones
+	// were a waste
+	roiBorderlineCostPerOutcome = 250.0
+)
+
+// ROI classifications of a meeting
+const (
+	ROIWorthIt    = "worth_it"
+	ROIBorderline = "borderline"
+	ROIWaste      = "waste"
+)
+
+// MeetingROI weighs what the meeting cost its participants against what it produced
+type MeetingROI struct {
+	TotalPersonHours           float64 `json:"total_person_hours"`            // Meeting duration times the number of participants
+	EstimatedCostUSD           float64 `json:"estimated_cost_usd"`            // Each participant's hourly rate for the whole meeting
+	OutcomesGenerated          int     `json:"outcomes_generated"`            // Action items, decisions and key points
+	CostPerOutcomeUSD          float64 `json:"cost_per_outcome_usd"`          // 0 when there were no outcomes
+	ComparedToAsyncAlternative string  `json:"compared_to_async_alternative"` // Whether an email, Slack thread or document review would have done
+	ROIClassification          string  `json:"roi_classification"`            // worth_it, borderline, waste
+}
+
+// SetROIReport sets whether the meeting's return on the time invested is reported when it is finalized
+func (a *AnalystAgent) SetROIReport(enabled bool) {
+	a.roiReport = enabled
+}
+
+// updateROIReport computes the meeting's return on the time invested and asks the LLM whether an
+// asynchronous alternative would have done. The report is kept without the comparison when the LLM call
+// fails.
+func (a *AnalystAgent) updateROIReport(ctx context.Context) error {
+	a.dataMutex.Lock()
+	roi := computeMeetingROI(a.data, a.config.ParticipantHourlyRates, a.defaultHourlyRate)
+	a.data.ROIReport = roi
+	summary := a.data.ExecutiveSummary
+	if summary == "" {
+		summary = a.data.Summary
+	}
+	duration := a.data.DurationMinutes
+	a.dataMutex.Unlock()
+
+	if summary == "" {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`This meeting took %.0f minutes (%.1f person-hours of its participants' time, about $%.0f) and produced %d outcomes (action items, decisions and key points), $%.0f per outcome.
+
+Could this discussion have happened asynchronously instead? In one sentence, either say "This discussion could have been an email instead", "This discussion could have been a Slack thread instead" or "This discussion could have been a document review instead", followed by why, or explain why it needed a live meeting.
+
+Meeting summary:
+%s`, duration, roi.TotalPersonHours, roi.EstimatedCostUSD, roi.OutcomesGenerated, roi.CostPerOutcomeUSD, summary)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	a.dataMutex.Lock()
+	if a.data.ROIReport != nil {
+		a.data.ROIReport.ComparedToAsyncAlternative = strings.TrimSpace(response)
+	}
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Meeting ROI is %s at $%.2f per outcome", a.agentID, roi.ROIClassification, roi.CostPerOutcomeUSD)
+	return nil
+}
+
+// computeMeetingROI prices every participant's attendance for the whole meeting at their hourly rate, looked
+// up by name ignoring case, or defaultRate, and divides the cost by the meeting's outcomes. Decisions are
+// action items of the decision type, so outcomes are the action items and key points. Meetings without
+// outcomes are a waste; the others are classified by their cost per outcome.
+func computeMeetingROI(data *AnalysisData, rates map[string]float64, defaultRate float64) *MeetingROI {
+	ratesByName := make(map[string]float64, len(rates))
+	for name, rate := range rates {
+		ratesByName[strings.ToLower(strings.TrimSpace(name))] = rate
+	}
+
+	participants := data.Participants
+	if len(participants) == 0 {
+		for speaker := range SpeakingSeconds(data.Transcript) {
+			participants = append(participants, speaker)
+		}
+	}
+
+	hours := data.DurationMinutes / 60
+	roi := &MeetingROI{
+		TotalPersonHours:  hours * float64(len(participants)),
+		OutcomesGenerated: len(data.ActionItems) + len(data.KeyPoints),
+	}
+	for _, participant := range participants {
+		rate, ok := ratesByName[strings.ToLower(participant)]
+		if !ok {
+			rate = defaultRate
+		}
+		roi.EstimatedCostUSD += rate * hours
+	}
+
+	if roi.OutcomesGenerated == 0 {
+		roi.ROIClassification = ROIWaste
+		return roi
+	}
+	roi.CostPerOutcomeUSD = roi.EstimatedCostUSD / float64(roi.OutcomesGenerated)
+	switch {
+	case roi.CostPerOutcomeUSD <= roiWorthItCostPerOutcome:
+		roi.ROIClassification = ROIWorthIt
+	case roi.CostPerOutcomeUSD <= roiBorderlineCostPerOutcome:
+		roi.ROIClassification = ROIBorderline
+	default:
+		roi.ROIClassification = ROIWaste
+	}
+	return roi
+}
@@ -0,0 +1,52 @@
+package client
+
+import "testing"
+
+func TestComputeMeetingROI(t *testing.T) {
+	tests := []struct {
+		name           string
+		actionItems    int
+		keyPoints      int
+		costPerOutcome float64
+		classification string
+	}{
+		{"worth it", 2, 1, 100, ROIWorthIt},
+		{"borderline", 1, 1, 150, ROIBorderline},
+		{"waste", 1, 0, 300, ROIWaste},
+		{"no outcomes", 0, 0, 0, ROIWaste},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &AnalysisData{
+				DurationMinutes: 60,
+				Participants:    []string{"Alice", "Bob", "Carol"},
+				ActionItems:     make([]ActionItem, tt.actionItems),
+				KeyPoints:       make([]string, tt.keyPoints),
+			}
+			// Alice at $150 an hour and the others at $75 cost $300 for the hour
+			roi := computeMeetingROI(data, map[string]float64{"ALICE": 150}, 75)
+
+			if roi.TotalPersonHours != 3 || roi.EstimatedCostUSD != 300 {
+				t.Errorf("person hours, cost = %v, %v, want 3, 300", roi.TotalPersonHours, roi.EstimatedCostUSD)
+			}
+			if roi.OutcomesGenerated != tt.actionItems+tt.keyPoints || !approx(roi.CostPerOutcomeUSD, tt.costPerOutcome) {
+				t.Errorf("%d outcomes at %v, want %v", roi.OutcomesGenerated, roi.CostPerOutcomeUSD, tt.costPerOutcome)
+			}
+			if roi.ROIClassification != tt.classification {
+				t.Errorf("classification = %q, want %q", roi.ROIClassification, tt.classification)
+			}
+		})
+	}
+}
+
+func TestComputeMeetingROIFallsBackToSpeakers(t *testing.T) {
+	data := &AnalysisData{
+		DurationMinutes: 30,
+		Transcript:      []TranscriptEntry{{Speaker: "Alice", Text: "Hello"}, {Speaker: "Bob", Text: "Hi"}},
+		KeyPoints:       []string{"Agreed on scope"},
+	}
+	roi := computeMeetingROI(data, nil, 100)
+	if roi.TotalPersonHours != 1 || roi.CostPerOutcomeUSD != 100 {
+		t.Errorf("roi = %+v, want the two speakers counted", roi)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed analysis_data_schema.json
+var analysisDataSchemaJSON []byte
+
+const analysisDataSchemaURL = "analysis_data_schema.json"
+
+var (
+	analysisDataSchema     *jsonschema.Schema
+	analysisDataSchemaErr  error
+	analysisDataSchemaOnce sync.Once
+)
+
+// SchemaValidationError lists every way an analysis document violates the analysis data schema
+type SchemaValidationError struct {
+	Violations []string
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("analysis data failed schema validation: %s", strings.Join(e.Violations, "; "))
+}
+
+// compiledAnalysisDataSchema compiles the embedded schema the first time it is needed
+func compiledAnalysisDataSchema() (*jsonschema.Schema, error) {
+	analysisDataSchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.AssertFormat = true
+		if err := compiler.AddResource(analysisDataSchemaURL, bytes.NewReader(analysisDataSchemaJSON)); err != nil {
+			analysisDataSchemaErr = fmt.Errorf("failed to load analysis data schema: %w", err)
+			return
+		}
+		analysisDataSchema, analysisDataSchemaErr = compiler.Compile(analysisDataSchemaURL)
+	})
+	return analysisDataSchema, analysisDataSchemaErr
+}
+
+// ValidateAnalysisData checks an encoded analysis document against the analysis data schema, returning a
+// *SchemaValidationError listing all violations when it doesn't conform
+func ValidateAnalysisData(data []byte) error {
+	schema, err := compiledAnalysisDataSchema()
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return &SchemaValidationError{Violations: []string{fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+
+	err = schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+
+	// Report the leaf errors, which describe each individual violation
+	var violations []string
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == "" || strings.HasPrefix(unit.Error, "doesn't validate with") {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s", location, unit.Error))
+	}
+	if len(violations) == 0 {
+		violations = []string{validationErr.Error()}
+	}
+	return &SchemaValidationError{Violations: violations}
+}
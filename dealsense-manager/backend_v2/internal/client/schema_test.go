@@ -0,0 +1,45 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAnalysisDataAcceptsAgentOutput(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+
+	raw, err := json.Marshal(analyst.GetAnalysis())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateAnalysisData(raw); err != nil {
+		t.Errorf("ValidateAnalysisData() = %v, want nil", err)
+	}
+}
+
+func TestValidateAnalysisDataReportsViolations(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"missing meeting id", `{"schema_version": 1, "start_time": "2024-05-01T10:00:00Z", "last_updated": "2024-05-01T10:00:00Z", "transcript": []}`, "meeting_id"},
+		{"bad timestamp", `{"schema_version": 1, "meeting_id": "m1", "start_time": "yesterday", "last_updated": "2024-05-01T10:00:00Z", "transcript": []}`, "/start_time"},
+		{"invalid JSON", `{"meeting_id": `, "invalid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAnalysisData([]byte(tt.doc))
+			var validationErr *SchemaValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateAnalysisData() = %v, want a *SchemaValidationError", err)
+			}
+			if !strings.Contains(validationErr.Error(), tt.want) {
+				t.Errorf("violations %q don't mention %q", validationErr.Violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// sentimentWindow is the span of meeting time covered by each point on the sentiment timeline
+const sentimentWindow = 5 * time.Minute
+
+// sparklineLevels are the characters used to draw the sentiment timeline, from most negative to most positive
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// SentimentPoint is the sentiment of one window of the meeting
+type SentimentPoint struct {
+	Timestamp  time.Time `json:"timestamp"` // Start of the window
+	Sentiment  string    `json:"sentiment"` // positive, negative, neutral
+	Score      float64   `json:"score"`     // -1 (negative) to 1 (positive)
+	WindowSize int       `json:"window_size"`
+}
+
+// Word lists for the heuristic scorer used in cost saving mode
+var (
+	positiveWords = wordSet("good", "great", "excellent", "agree", "agreed", "love", "happy", "glad", "perfect",
+		"awesome", "nice", "thanks", "thank", "success", "successful", "excited", "progress", "win", "yes",
+		"helpful", "improved", "improvement", "resolved", "done", "amazing", "fantastic", "pleased")
+	negativeWords = wordSet("bad", "problem", "problems", "issue", "issues", "concern", "concerned", "worried",
+		"worry", "disagree", "fail", "failed", "failure", "delay", "delayed", "blocked", "blocker", "risk",
+		"unfortunately", "wrong", "broken", "angry", "frustrated", "difficult", "no", "not", "late", "missed")
+)
+
+// wordSet builds a lookup set from the given words
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// updateSentimentTimeline scores each 5-minute window of the transcript. Points for windows that were
+// already complete in the previous timeline are reused so each window is only scored once.
+func (a *AnalystAgent) updateSentimentTimeline(ctx context.Context, transcript []TranscriptEntry) {
+	windows := splitSentimentWindows(transcript)
+
+	previous := make(map[time.Time]SentimentPoint, len(a.data.SentimentTimeline))
+	for _, point := range a.data.SentimentTimeline {
+		previous[point.Timestamp] = point
+	}
+
+	timeline := make([]SentimentPoint, 0, len(windows))
+	for i, window := range windows {
+		start := window[0].Timestamp.Truncate(time.Second)
+		if point, ok := previous[start]; ok && point.WindowSize == len(window) && i < len(windows)-1 {
+			timeline = append(timeline, point)
+			continue
+		}
+
+		sentiment, score := a.scoreSentimentWindow(ctx, window)
+		timeline = append(timeline, SentimentPoint{
+			Timestamp:  start,
+			Sentiment:  sentiment,
+			Score:      score,
+			WindowSize: len(window),
+		})
+	}
+
+	a.data.SentimentTimeline = timeline
+}
+
+// splitSentimentWindows groups transcript entries into consecutive windows of sentimentWindow length
+func splitSentimentWindows(transcript []TranscriptEntry) [][]TranscriptEntry {
+	var windows [][]TranscriptEntry
+	var windowStart time.Time
+	for _, entry := range transcript {
+		if len(windows) == 0 || entry.Timestamp.Sub(windowStart) >= sentimentWindow {
+			windowStart = entry.Timestamp
+			windows = append(windows, []TranscriptEntry{})
+		}
+		windows[len(windows)-1] = append(windows[len(windows)-1], entry)
+	}
+	return windows
+}
+
+// scoreSentimentWindow scores a window with the LLM, or with the word-list heuristic in cost saving mode
+// or when the LLM call fails
+func (a *AnalystAgent) scoreSentimentWindow(ctx context.Context, window []TranscriptEntry) (string, float64) {
+	if a.config.CostSavingMode {
+		return heuristicSentiment(window)
+	}
+
+	text := a.formatTranscriptForLLM(window)
+	prompt := fmt.Sprintf(`Rate the sentiment of this short excerpt from a meeting transcript.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "sentiment": "positive/negative/neutral",
+  "score": 0.4
+}
+`+"`"+`
+
+The score ranges from -1 (very negative) to 1 (very positive).`, text)
+
+	response, err := a.callLLM(ctx, a.glossaryPrefix(text)+a.languagePrefix()+prompt)
+	if err == nil {
+		if jsonData := a.extractJSONFromResponse(ctx, response); jsonData != "" {
+			var result struct {
+				Sentiment string  `json:"sentiment"`
+				Score     float64 `json:"score"`
+			}
+			if err = json.Unmarshal([]byte(jsonData), &result); err == nil {
+				return sentimentLabel(result.Score), math.Max(-1, math.Min(1, result.Score))
+			}
+		}
+	}
+
+	logrus.Debugf("Agent %s: Falling back to heuristic sentiment for window: %v", a.agentID, err)
+	return heuristicSentiment(window)
+}
+
+// heuristicSentiment scores a window by counting positive and negative words
+func heuristicSentiment(window []TranscriptEntry) (string, float64) {
+	positive, negative := 0, 0
+	for _, entry := range window {
+		for _, word := range strings.FieldsFunc(strings.ToLower(entry.Text), func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r == '\'')
+		}) {
+			if positiveWords[word] {
+				positive++
+			} else if negativeWords[word] {
+				negative++
+			}
+		}
+	}
+
+	if positive+negative == 0 {
+		return "neutral", 0
+	}
+
+	score := float64(positive-negative) / float64(positive+negative)
+	return sentimentLabel(score), score
+}
+
+// sentimentLabel converts a score into a sentiment label
+func sentimentLabel(score float64) string {
+	switch {
+	case score > 0.2:
+		return "positive"
+	case score < -0.2:
+		return "negative"
+	default:
+		return "neutral"
+	}
+}
+
+// VisualizeSentimentTimeline returns a sparkline of the sentiment timeline, one character per window
+func (a *AnalystAgent) VisualizeSentimentTimeline() string {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	return sentimentSparkline(a.data.SentimentTimeline)
+}
+
+// sentimentSparkline maps each point's score from [-1, 1] onto the sparkline levels
+func sentimentSparkline(points []SentimentPoint) string {
+	var sparkline strings.Builder
+	for _, point := range points {
+		score := math.Max(-1, math.Min(1, point.Score))
+		level := int(math.Round((score + 1) / 2 * float64(len(sparklineLevels)-1)))
+		sparkline.WriteRune(sparklineLevels[level])
+	}
+	return sparkline.String()
+}
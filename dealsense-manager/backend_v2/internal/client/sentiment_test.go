@@ -0,0 +1,39 @@
+package client
+
+import "testing"
+
+func TestSentimentSparklineDeclining(t *testing.T) {
+	var points []SentimentPoint
+	for _, score := range []float64{1, 0.5, 0, -0.5, -1} {
+		points = append(points, SentimentPoint{Score: score})
+	}
+
+	sparkline := sentimentSparkline(points)
+	if sparkline != "█▆▅▃▁" {
+		t.Errorf("sparkline = %q, want █▆▅▃▁", sparkline)
+	}
+	levels := []rune(sparkline)
+	for i := 1; i < len(levels); i++ {
+		if levels[i] > levels[i-1] {
+			t.Errorf("sparkline %q rises at %d for a declining score", sparkline, i)
+		}
+	}
+}
+
+func TestSentimentSparklineClampsScores(t *testing.T) {
+	if sparkline := sentimentSparkline([]SentimentPoint{{Score: 3}, {Score: -3}}); sparkline != "█▁" {
+		t.Errorf("sparkline = %q, want out-of-range scores clamped", sparkline)
+	}
+	if sparkline := sentimentSparkline(nil); sparkline != "" {
+		t.Errorf("sparkline = %q, want empty without points", sparkline)
+	}
+}
+
+func TestSentimentLabel(t *testing.T) {
+	tests := map[float64]string{0.5: "positive", 0.2: "neutral", 0: "neutral", -0.2: "neutral", -0.21: "negative"}
+	for score, want := range tests {
+		if got := sentimentLabel(score); got != want {
+			t.Errorf("sentimentLabel(%v) = %q, want %q", score, got, want)
+		}
+	}
+}
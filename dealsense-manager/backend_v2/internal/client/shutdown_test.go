@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/shutdown"
+)
+
+func TestSIGTERMFinalizesAnalysisToValidJSON(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+	say(analyst, 30, "Bob", "The rollout starts on Monday.")
+
+	sm := shutdown.NewShutdownManager(5 * time.Second)
+	analyst.RegisterShutdown(sm)
+
+	// Keep SIGTERM from killing the test binary before the manager listens for it
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, syscall.SIGTERM)
+	defer signal.Stop(guard)
+
+	received := make(chan os.Signal, 1)
+	go func() { received <- sm.WaitForSignal() }()
+
+	var sig os.Signal
+	deadline := time.After(5 * time.Second)
+	for sig == nil {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+			t.Fatalf("failed to send SIGTERM: %v", err)
+		}
+		select {
+		case sig = <-received:
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("shutdown manager never received SIGTERM")
+		}
+	}
+	if sig != syscall.SIGTERM {
+		t.Fatalf("received %v, want SIGTERM", sig)
+	}
+
+	if !sm.Shutdown() {
+		t.Fatal("shutdown timed out")
+	}
+	if !analyst.IsFinalized() {
+		t.Fatal("analysis was not finalized on shutdown")
+	}
+
+	raw, err := os.ReadFile(analyst.filePath)
+	if err != nil {
+		t.Fatalf("analysis file not written: %v", err)
+	}
+	var saved AnalysisData
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		t.Fatalf("analysis file is not valid JSON: %v", err)
+	}
+	if len(saved.Transcript) != 2 {
+		t.Errorf("saved %d transcript entries, want 2", len(saved.Transcript))
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(analyst.filePath), "*.tmp")); len(leftovers) > 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MeetingIndexPath is the file finalized meetings are indexed in for finding similar meetings
+	MeetingIndexPath = "data/analysis_index.json"
+	// maxSimilarMeetings is the number of most similar past meetings linked from an analysis
+	maxSimilarMeetings = 5
+	// minSimilarityScore is the lowest similarity at which a past meeting counts as related
+	minSimilarityScore = 0.1
+)
+
+// meetingIndexMutex serializes reading and rewriting the index, which all agents share
+var meetingIndexMutex sync.Mutex
+
+// MeetingReference links to a past meeting that discussed similar topics
+type MeetingReference struct {
+	MeetingID       string    `json:"meeting_id"`
+	MeetingURL      string    `json:"meeting_url"`
+	SimilarityScore float64   `json:"similarity_score"` // Cosine similarity of the meetings' TF-IDF vectors, from 0 to 1
+	CommonTopics    []string  `json:"common_topics,omitempty"`
+	StartTime       time.Time `json:"start_time"`
+}
+
+// meetingIndexEntry is a finalized meeting's bag of words from its keywords and topics
+type meetingIndexEntry struct {
+	MeetingID  string         `json:"meeting_id"`
+	TenantID   string         `json:"tenant_id,omitempty"`
+	MeetingURL string         `json:"meeting_url"`
+	StartTime  time.Time      `json:"start_time"`
+	Topics     []string       `json:"topics,omitempty"`
+	Terms      map[string]int `json:"terms"`
+}
+
+// updateSimilarMeetings links the analysis to the most similar past meetings of the same tenant, then adds
+// the meeting to the index so later meetings can find it. Meetings are compared by the TF-IDF vectors of
+// the words in their keywords, topic names and topic summaries, with document frequencies taken across
+// the index.
+func (a *AnalystAgent) updateSimilarMeetings() error {
+	a.dataMutex.RLock()
+	entry := newMeetingIndexEntry(a.data)
+	a.dataMutex.RUnlock()
+
+	if len(entry.Terms) == 0 {
+		return nil
+	}
+
+	meetingIndexMutex.Lock()
+	defer meetingIndexMutex.Unlock()
+
+	index, err := loadMeetingIndex(MeetingIndexPath)
+	if err != nil {
+		return err
+	}
+
+	// Drop this meeting's previous entry so it isn't matched against itself
+	kept := index[:0]
+	for _, past := range index {
+		if past.MeetingID != entry.MeetingID {
+			kept = append(kept, past)
+		}
+	}
+	index = append(kept, entry)
+
+	var candidates []meetingIndexEntry
+	for _, past := range index[:len(index)-1] {
+		if past.TenantID == entry.TenantID {
+			candidates = append(candidates, past)
+		}
+	}
+	similar := findSimilarMeetings(entry, candidates, index)
+
+	a.dataMutex.Lock()
+	a.data.SimilarMeetings = similar
+	a.dataMutex.Unlock()
+
+	logrus.Infof("Agent %s: Found %d similar meetings among %d indexed", a.agentID, len(similar), len(candidates))
+	return saveMeetingIndex(MeetingIndexPath, index)
+}
+
+// newMeetingIndexEntry builds the index entry of an analysis
+func newMeetingIndexEntry(data *AnalysisData) meetingIndexEntry {
+	entry := meetingIndexEntry{
+		MeetingID:  data.MeetingID,
+		TenantID:   data.TenantID,
+		MeetingURL: data.MeetingURL,
+		StartTime:  data.StartTime,
+		Terms:      make(map[string]int),
+	}
+
+	addTerms := func(text string) {
+		for _, word := range tokenizeWords(text) {
+			if !defaultStopwords[word] {
+				entry.Terms[word]++
+			}
+		}
+	}
+	for _, keyword := range data.Keywords {
+		addTerms(keyword)
+	}
+	for _, topic := range data.Topics {
+		entry.Topics = append(entry.Topics, topic.Topic)
+		addTerms(topic.Topic)
+		addTerms(topic.Summary)
+	}
+	return entry
+}
+
+// findSimilarMeetings scores each candidate against the meeting, returning the most similar first. The
+// corpus the document frequencies are counted over includes the meeting itself.
+func findSimilarMeetings(meeting meetingIndexEntry, candidates, corpus []meetingIndexEntry) []MeetingReference {
+	documentFrequency := make(map[string]int)
+	for _, entry := range corpus {
+		for term := range entry.Terms {
+			documentFrequency[term]++
+		}
+	}
+	// Smoothed IDF, so terms every meeting shares still count towards similarity
+	idf := func(term string) float64 {
+		return math.Log(float64(1+len(corpus))/float64(1+documentFrequency[term])) + 1
+	}
+
+	vector := tfidfVector(meeting.Terms, idf)
+	var references []MeetingReference
+	for _, candidate := range candidates {
+		score := cosineSimilarity(vector, tfidfVector(candidate.Terms, idf))
+		if score < minSimilarityScore {
+			continue
+		}
+		references = append(references, MeetingReference{
+			MeetingID:       candidate.MeetingID,
+			MeetingURL:      candidate.MeetingURL,
+			SimilarityScore: score,
+			CommonTopics:    commonTopics(meeting.Topics, candidate.Topics),
+			StartTime:       candidate.StartTime,
+		})
+	}
+
+	sort.SliceStable(references, func(i, j int) bool {
+		return references[i].SimilarityScore > references[j].SimilarityScore
+	})
+	if len(references) > maxSimilarMeetings {
+		references = references[:maxSimilarMeetings]
+	}
+	return references
+}
+
+// tfidfVector weights each term count by the term's inverse document frequency
+func tfidfVector(terms map[string]int, idf func(string) float64) map[string]float64 {
+	vector := make(map[string]float64, len(terms))
+	for term, count := range terms {
+		vector[term] = float64(count) * idf(term)
+	}
+	return vector
+}
+
+// cosineSimilarity returns the cosine of the angle between two sparse vectors, 0 when either is empty
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		dot += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// commonTopics returns the topics of a that b also discussed, comparing names ignoring case
+func commonTopics(a, b []string) []string {
+	names := make(map[string]bool, len(b))
+	for _, topic := range b {
+		names[strings.ToLower(strings.TrimSpace(topic))] = true
+	}
+
+	var common []string
+	for _, topic := range a {
+		if names[strings.ToLower(strings.TrimSpace(topic))] {
+			common = append(common, topic)
+		}
+	}
+	return common
+}
+
+// loadMeetingIndex reads the meeting index, returning an empty index when the file doesn't exist yet
+func loadMeetingIndex(path string) ([]meetingIndexEntry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read meeting index: %w", err)
+	}
+
+	var index []meetingIndexEntry
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse meeting index: %w", err)
+	}
+	return index, nil
+}
+
+// saveMeetingIndex writes the meeting index to a temporary file and renames it over path
+func saveMeetingIndex(path string, index []meetingIndexEntry) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal meeting index: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create meeting index directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write meeting index: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace meeting index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// indexedMeeting returns the index entry of a meeting that discussed the given topics
+func indexedMeeting(id string, topics ...TopicDiscussion) meetingIndexEntry {
+	return newMeetingIndexEntry(&AnalysisData{MeetingID: id, Topics: topics})
+}
+
+func TestFindSimilarMeetings(t *testing.T) {
+	pricing := TopicDiscussion{Topic: "Pricing tiers", Summary: "Enterprise discount for annual contracts"}
+	hiring := TopicDiscussion{Topic: "Hiring plan", Summary: "Two backend engineers next quarter"}
+	offsite := TopicDiscussion{Topic: "Team offsite", Summary: "Venue booking and travel dates"}
+
+	meeting := indexedMeeting("current", pricing, hiring)
+	same := indexedMeeting("same", pricing, hiring)
+	partial := indexedMeeting("partial", pricing, offsite)
+	unrelated := indexedMeeting("unrelated", offsite)
+	candidates := []meetingIndexEntry{unrelated, partial, same}
+	corpus := append([]meetingIndexEntry{meeting}, candidates...)
+
+	similar := findSimilarMeetings(meeting, candidates, corpus)
+	if len(similar) != 2 {
+		t.Fatalf("findSimilarMeetings() = %+v, want the meetings sharing topics", similar)
+	}
+	if similar[0].MeetingID != "same" || similar[0].SimilarityScore <= 0.8 {
+		t.Errorf("most similar = %s scoring %.2f, want same above 0.8", similar[0].MeetingID, similar[0].SimilarityScore)
+	}
+	if similar[1].MeetingID != "partial" || similar[1].SimilarityScore >= similar[0].SimilarityScore {
+		t.Errorf("second = %s scoring %.2f", similar[1].MeetingID, similar[1].SimilarityScore)
+	}
+	if !reflect.DeepEqual(similar[1].CommonTopics, []string{"Pricing tiers"}) {
+		t.Errorf("CommonTopics = %v, want [Pricing tiers]", similar[1].CommonTopics)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	a := map[string]float64{"pricing": 1, "discount": 2}
+	if got := cosineSimilarity(a, a); !approx(got, 1) {
+		t.Errorf("cosineSimilarity(a, a) = %v, want 1", got)
+	}
+	if got := cosineSimilarity(a, map[string]float64{"venue": 1}); got != 0 {
+		t.Errorf("cosineSimilarity() of disjoint vectors = %v, want 0", got)
+	}
+	if got := cosineSimilarity(a, nil); got != 0 {
+		t.Errorf("cosineSimilarity() with an empty vector = %v, want 0", got)
+	}
+}
+
+func TestCommonTopicsIgnoresCase(t *testing.T) {
+	got := commonTopics([]string{"Pricing", "Hiring"}, []string{" pricing ", "Offsite"})
+	if !reflect.DeepEqual(got, []string{"Pricing"}) {
+		t.Errorf("commonTopics() = %v, want [Pricing]", got)
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Classifications of speakers in meetings between the team and its customers
+const (
+	SpeakerInternal = "internal"
+	SpeakerExternal = "external"
+	SpeakerUnknown  = "unknown"
+)
+
+// speakerEmailPattern finds an email address in a speaker identifier such as "jane@acme.com" or
+// "Jane Doe <jane@acme.com>", capturing its domain
+var speakerEmailPattern = regexp.MustCompile(`[\w.+-]+@([\w-]+(?:\.[\w-]+)+)`)
+
+// classifySpeaker returns internal for the speakers listed in internalSpeakers and those whose email domain
+// is, or is a subdomain of, one of internalDomains. Speakers with an email at another domain are external
+// when internal domains are configured; all other speakers are unknown.
+func classifySpeaker(speaker string, internalSpeakers, internalDomains []string) string {
+	for _, name := range internalSpeakers {
+		if speaker == name {
+			return SpeakerInternal
+		}
+	}
+
+	match := speakerEmailPattern.FindStringSubmatch(speaker)
+	if match == nil || len(internalDomains) == 0 {
+		return SpeakerUnknown
+	}
+	domain := strings.ToLower(match[1])
+	for _, internal := range internalDomains {
+		internal = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(internal), "@"))
+		if internal != "" && (domain == internal || strings.HasSuffix(domain, "."+internal)) {
+			return SpeakerInternal
+		}
+	}
+	return SpeakerExternal
+}
+
+// updateSpeakerClassification classifies every participant with the agent's internal speakers and domains.
+// Caller must hold dataMutex.
+func (a *AnalystAgent) updateSpeakerClassification() {
+	if len(a.data.Participants) == 0 {
+		return
+	}
+
+	classification := make(map[string]string, len(a.data.Participants))
+	for _, speaker := range a.data.Participants {
+		classification[speaker] = classifySpeaker(speaker, a.config.InternalSpeakers, a.config.InternalDomains)
+	}
+	a.data.SpeakerClassification = classification
+}
+
+// GetInternalTranscript returns the statements of participants classified as internal
+func (a *AnalystAgent) GetInternalTranscript() []TranscriptEntry {
+	return a.transcriptBySpeakerClass(SpeakerInternal)
+}
+
+// GetExternalTranscript returns the statements of participants classified as external, such as customers
+func (a *AnalystAgent) GetExternalTranscript() []TranscriptEntry {
+	return a.transcriptBySpeakerClass(SpeakerExternal)
+}
+
+// transcriptBySpeakerClass returns the participants' statements whose speaker has the given classification
+func (a *AnalystAgent) transcriptBySpeakerClass(class string) []TranscriptEntry {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	entries := []TranscriptEntry{}
+	for _, entry := range a.data.Transcript {
+		if !entry.IsAgent && a.data.SpeakerClassification[entry.Speaker] == class {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// speakerPerspective labels a summary of a speaker's statements by their classification, or returns "" for
+// speakers of unknown classification
+func speakerPerspective(class string) string {
+	switch class {
+	case SpeakerExternal:
+		return "Customer perspective"
+	case SpeakerInternal:
+		return "Internal perspective"
+	default:
+		return ""
+	}
+}
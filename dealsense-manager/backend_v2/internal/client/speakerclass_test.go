@@ -0,0 +1,45 @@
+package client
+
+import "testing"
+
+func TestClassifySpeaker(t *testing.T) {
+	internalSpeakers := []string{"Alice"}
+	internalDomains := []string{"@acme.com"}
+	cases := []struct {
+		speaker string
+		domains []string
+		want    string
+	}{
+		{"Alice", internalDomains, SpeakerInternal},
+		{"bob@acme.com", internalDomains, SpeakerInternal},
+		{"Carol Diaz <carol@eu.acme.com>", internalDomains, SpeakerInternal},
+		{"dan@customer.io", internalDomains, SpeakerExternal},
+		{"dan@notacme.com", internalDomains, SpeakerExternal},
+		// Speakers without an email, or without configured domains, can't be placed
+		{"Erin", internalDomains, SpeakerUnknown},
+		{"dan@customer.io", nil, SpeakerUnknown},
+	}
+	for _, c := range cases {
+		if got := classifySpeaker(c.speaker, internalSpeakers, c.domains); got != c.want {
+			t.Errorf("classifySpeaker(%q, %v) = %q, want %q", c.speaker, c.domains, got, c.want)
+		}
+	}
+}
+
+func TestTranscriptBySpeakerClass(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.InternalDomains = []string{"acme.com"}
+	say(analyst, 0, "alice@acme.com", "Welcome to the demo everyone")
+	say(analyst, 10, "dan@customer.io", "Thanks, glad to be here")
+	say(analyst, 20, "Guest", "Hello from the guest line")
+
+	if got := analyst.GetInternalTranscript(); len(got) != 1 || got[0].Speaker != "alice@acme.com" {
+		t.Errorf("GetInternalTranscript() = %+v", got)
+	}
+	if got := analyst.GetExternalTranscript(); len(got) != 1 || got[0].Speaker != "dan@customer.io" {
+		t.Errorf("GetExternalTranscript() = %+v", got)
+	}
+	if class := analyst.GetAnalysis().SpeakerClassification["Guest"]; class != SpeakerUnknown {
+		t.Errorf("Guest classified as %q, want %q", class, SpeakerUnknown)
+	}
+}
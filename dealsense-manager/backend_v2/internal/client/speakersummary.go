@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// speakerSummaryTTL is how long a speaker summary is reused before it is regenerated
+const speakerSummaryTTL = 10 * time.Minute
+
+// ErrSpeakerNotFound is returned when the transcript has no statements from the requested speaker
+var ErrSpeakerNotFound = errors.New("speaker not found in transcript")
+
+// speakerSummaryCacheEntry is a generated speaker summary and the statements it covers
+type speakerSummaryCacheEntry struct {
+	summary     string
+	statements  int
+	generatedAt time.Time
+}
+
+// GenerateSpeakerSummary summarizes what one speaker said during the meeting, matching the name ignoring
+// case. Summaries of external speakers are labeled as the customer perspective and those of internal ones
+// as the internal perspective. Summaries are cached for ten minutes, or until the speaker says something
+// new, and are not stored in the analysis.
+func (a *AnalystAgent) GenerateSpeakerSummary(ctx context.Context, speaker string) (string, error) {
+	speaker = strings.TrimSpace(speaker)
+
+	a.dataMutex.RLock()
+	var statements []TranscriptEntry
+	for _, entry := range a.data.Transcript {
+		if strings.EqualFold(entry.Speaker, speaker) {
+			statements = append(statements, entry)
+		}
+	}
+	perspective := ""
+	if len(statements) > 0 {
+		perspective = speakerPerspective(a.data.SpeakerClassification[statements[0].Speaker])
+	}
+	a.dataMutex.RUnlock()
+
+	if len(statements) == 0 {
+		return "", ErrSpeakerNotFound
+	}
+
+	key := strings.ToLower(speaker)
+	a.speakerSummaryMutex.Lock()
+	cached, ok := a.speakerSummaryCache[key]
+	a.speakerSummaryMutex.Unlock()
+	if ok && cached.statements == len(statements) && time.Since(cached.generatedAt) < speakerSummaryTTL {
+		return labelSpeakerSummary(perspective, cached.summary), nil
+	}
+
+	text := a.formatTranscriptForLLM(statements)
+	prompt := a.glossaryPrefix(text) + a.languagePrefix() + fmt.Sprintf(`Summarize what %s said during this meeting, based only on their statements below. Cover the positions they took, the questions and concerns they raised, the commitments they made and any figures they gave. Write two or three short paragraphs of plain text without headings.
+
+Statements by %s:
+%s`, statements[0].Speaker, statements[0].Speaker, text)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize speaker: %w", err)
+	}
+	summary := strings.TrimSpace(response)
+
+	a.speakerSummaryMutex.Lock()
+	if a.speakerSummaryCache == nil {
+		a.speakerSummaryCache = make(map[string]speakerSummaryCacheEntry)
+	}
+	a.speakerSummaryCache[key] = speakerSummaryCacheEntry{summary: summary, statements: len(statements), generatedAt: time.Now()}
+	a.speakerSummaryMutex.Unlock()
+
+	logrus.Infof("Agent %s: Summarized %d statements by %s", a.agentID, len(statements), statements[0].Speaker)
+	return labelSpeakerSummary(perspective, summary), nil
+}
+
+// labelSpeakerSummary heads a speaker summary with the speaker's perspective, when it is known
+func labelSpeakerSummary(perspective, summary string) string {
+	if perspective == "" {
+		return summary
+	}
+	return perspective + "\n\n" + summary
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestSpeakerSummaryCache(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("First summary.", "Second summary.", "Third summary.")
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "We should ship the beta on Friday")
+	say(analyst, 10, "Bob", "Marketing needs two more days")
+	ctx := context.Background()
+
+	summary, err := analyst.GenerateSpeakerSummary(ctx, " alice ")
+	if err != nil || !strings.HasSuffix(summary, "First summary.") {
+		t.Fatalf("GenerateSpeakerSummary() = %q, %v", summary, err)
+	}
+	prompt := mock.Prompts()[0]
+	if !strings.Contains(prompt, "ship the beta") || strings.Contains(prompt, "Marketing") {
+		t.Errorf("prompt = %q, want only Alice's statements", prompt)
+	}
+
+	// Hit: nothing new was said
+	if summary, _ := analyst.GenerateSpeakerSummary(ctx, "Alice"); !strings.HasSuffix(summary, "First summary.") {
+		t.Errorf("cached summary = %q", summary)
+	}
+	// Another speaker saying something doesn't invalidate it
+	say(analyst, 20, "Bob", "Or we cut the launch email")
+	if summary, _ := analyst.GenerateSpeakerSummary(ctx, "Alice"); !strings.HasSuffix(summary, "First summary.") {
+		t.Errorf("cached summary = %q", summary)
+	}
+	if calls := len(mock.Prompts()); calls != 1 {
+		t.Fatalf("LLM called %d times, want 1", calls)
+	}
+
+	// Miss: the speaker said something new
+	say(analyst, 30, "Alice", "Fine, Monday it is then")
+	if summary, _ := analyst.GenerateSpeakerSummary(ctx, "Alice"); !strings.HasSuffix(summary, "Second summary.") {
+		t.Errorf("summary after a new statement = %q", summary)
+	}
+
+	// Miss: the cached summary expired
+	analyst.speakerSummaryMutex.Lock()
+	entry := analyst.speakerSummaryCache["alice"]
+	entry.generatedAt = time.Now().Add(-speakerSummaryTTL)
+	analyst.speakerSummaryCache["alice"] = entry
+	analyst.speakerSummaryMutex.Unlock()
+	if summary, _ := analyst.GenerateSpeakerSummary(ctx, "Alice"); !strings.HasSuffix(summary, "Third summary.") {
+		t.Errorf("summary after expiry = %q", summary)
+	}
+
+	if summary := analyst.GetAnalysis().Summary; summary != "" {
+		t.Errorf("speaker summary stored in the analysis: %q", summary)
+	}
+}
+
+func TestSpeakerSummaryUnknownSpeaker(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	say(analyst, 0, "Alice", "We should ship the beta on Friday")
+
+	if _, err := analyst.GenerateSpeakerSummary(context.Background(), "Carol"); !errors.Is(err, ErrSpeakerNotFound) {
+		t.Errorf("GenerateSpeakerSummary() error = %v, want ErrSpeakerNotFound", err)
+	}
+}
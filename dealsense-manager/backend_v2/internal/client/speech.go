@@ -0,0 +1,103 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultMinSpeechConfidence is the lowest transcription confidence of segments kept in the transcript
+const DefaultMinSpeechConfidence = 0.4
+
+// defaultNoisePatterns match transcription placeholders and segments made up only of punctuation
+var defaultNoisePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\[[^\]]*\]$`),
+	regexp.MustCompile(`^[[:punct:][:space:]]*$`),
+}
+
+// SpeechActivityDetector filters out utterances that contain no real speech
+type SpeechActivityDetector struct {
+	NoisePatterns []*regexp.Regexp // Segment text matching any pattern is treated as noise
+	MinConfidence float64          // Segments with a lower "confidence" value are ignored
+	MinWords      int              // Utterances with fewer remaining words are rejected
+}
+
+// NewSpeechActivityDetector creates a detector with the default noise patterns and confidence threshold
+func NewSpeechActivityDetector() *SpeechActivityDetector {
+	return &SpeechActivityDetector{
+		NoisePatterns: defaultNoisePatterns,
+		MinConfidence: DefaultMinSpeechConfidence,
+		MinWords:      2,
+	}
+}
+
+// newSpeechActivityDetector creates a detector dropping segments below minConfidence and, besides the
+// default noise patterns, those matching noisePatterns, ignoring case. Invalid patterns are logged and
+// skipped.
+func newSpeechActivityDetector(minConfidence float64, noisePatterns []string) *SpeechActivityDetector {
+	detector := NewSpeechActivityDetector()
+	detector.MinConfidence = minConfidence
+	detector.NoisePatterns = append([]*regexp.Regexp(nil), defaultNoisePatterns...)
+	for _, pattern := range noisePatterns {
+		compiled, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid speech noise pattern %q: %v", pattern, err)
+			continue
+		}
+		detector.NoisePatterns = append(detector.NoisePatterns, compiled)
+	}
+	return detector
+}
+
+// SetSpeechFilter sets the confidence threshold and extra noise patterns that utterance segments are
+// filtered with before they are added to the transcript
+func (a *AnalystAgent) SetSpeechFilter(minConfidence float64, noisePatterns []string) {
+	detector := newSpeechActivityDetector(minConfidence, noisePatterns)
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+	a.speechDetector = detector
+}
+
+// IsValidUtterance reports whether the segments contain enough confident, non-noise speech to analyze
+func (d *SpeechActivityDetector) IsValidUtterance(segments []map[string]interface{}) bool {
+	words := 0
+	for _, segment := range d.SpeechSegments(segments) {
+		text, _ := segment["text"].(string)
+		words += len(strings.Fields(text))
+	}
+
+	return words >= d.MinWords
+}
+
+// SpeechSegments returns the segments holding speech, leaving out empty, noise and low-confidence ones
+func (d *SpeechActivityDetector) SpeechSegments(segments []map[string]interface{}) []map[string]interface{} {
+	var speech []map[string]interface{}
+	for _, segment := range segments {
+		text, _ := segment["text"].(string)
+		text = strings.TrimSpace(text)
+		if text == "" || d.isNoise(text) {
+			continue
+		}
+
+		// Segments without a confidence score are accepted as-is
+		if confidence, ok := segment["confidence"].(float64); ok && confidence < d.MinConfidence {
+			continue
+		}
+
+		speech = append(speech, segment)
+	}
+	return speech
+}
+
+// isNoise reports whether the text matches one of the noise patterns
+func (d *SpeechActivityDetector) isNoise(text string) bool {
+	lower := strings.ToLower(text)
+	for _, pattern := range d.NoisePatterns {
+		if pattern.MatchString(lower) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestIsValidUtterance(t *testing.T) {
+	detector := &SpeechActivityDetector{NoisePatterns: defaultNoisePatterns, MinConfidence: 0.5, MinWords: 2}
+	tests := []struct {
+		name     string
+		segments []map[string]interface{}
+		want     bool
+	}{
+		{"speech", []map[string]interface{}{{"text": "Ship it Friday"}}, true},
+		{"single word", []map[string]interface{}{{"text": "Yes"}}, false},
+		{"empty", []map[string]interface{}{{"text": "   "}}, false},
+		{"inaudible placeholder", []map[string]interface{}{{"text": "[inaudible]"}}, false},
+		{"background noise placeholder", []map[string]interface{}{{"text": "[Background Noise]"}}, false},
+		{"punctuation", []map[string]interface{}{{"text": "?"}}, false},
+		{"low confidence", []map[string]interface{}{{"text": "maybe we ship", "confidence": 0.3}}, false},
+		{"confident", []map[string]interface{}{{"text": "maybe we ship", "confidence": 0.9}}, true},
+		{"words across segments", []map[string]interface{}{{"text": "Ship"}, {"text": "it"}}, true},
+		{"noise does not count", []map[string]interface{}{{"text": "[inaudible]"}, {"text": "yes"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detector.IsValidUtterance(tt.segments); got != tt.want {
+				t.Errorf("IsValidUtterance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidUtteranceCustomNoisePatterns(t *testing.T) {
+	detector := &SpeechActivityDetector{
+		NoisePatterns: append([]*regexp.Regexp{regexp.MustCompile(`^(um+|uh+) (um+|uh+)$`)}, defaultNoisePatterns...),
+		MinWords:      2,
+	}
+	if detector.IsValidUtterance([]map[string]interface{}{{"text": "Umm uh"}}) {
+		t.Error("utterance matching a custom noise pattern accepted")
+	}
+	if !detector.IsValidUtterance([]map[string]interface{}{{"text": "Umm, the roadmap"}}) {
+		t.Error("speech rejected by a custom noise pattern")
+	}
+}
+
+func TestProcessUtteranceCountsDroppedNoise(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.ProcessUtterance([]map[string]interface{}{{"speaker": "Alice", "text": "[background noise]"}})
+
+	data := analyst.GetAnalysis()
+	if len(data.Transcript) != 0 {
+		t.Errorf("transcript = %v, want the noise dropped", data.Transcript)
+	}
+	if data.NoisySegmentsDropped != 1 {
+		t.Errorf("NoisySegmentsDropped = %d, want 1", data.NoisySegmentsDropped)
+	}
+}
+
+func TestSpeechSegmentsFiltersEachSegment(t *testing.T) {
+	detector := NewSpeechActivityDetector()
+	segments := []map[string]interface{}{
+		{"text": "Let's start with the roadmap", "confidence": 0.92},
+		{"text": "[inaudible]"},
+		{"text": "mumbled words here", "confidence": 0.1},
+		{"text": "..."},
+		{"text": "and then pricing"},
+	}
+
+	speech := detector.SpeechSegments(segments)
+	if len(speech) != 2 {
+		t.Fatalf("SpeechSegments() kept %d segments, want 2: %v", len(speech), speech)
+	}
+	if speech[0]["text"] != "Let's start with the roadmap" || speech[1]["text"] != "and then pricing" {
+		t.Errorf("SpeechSegments() = %v", speech)
+	}
+}
+
+func TestDefaultConfidenceThresholdRejectsLowConfidence(t *testing.T) {
+	detector := NewSpeechActivityDetector()
+	if detector.MinConfidence <= 0 {
+		t.Fatalf("MinConfidence = %v, want a positive default", detector.MinConfidence)
+	}
+	if detector.IsValidUtterance([]map[string]interface{}{{"text": "probably not what was said", "confidence": 0.05}}) {
+		t.Error("low-confidence utterance accepted")
+	}
+}
+
+func TestConfiguredNoisePatterns(t *testing.T) {
+	detector := newSpeechActivityDetector(0.8, []string{`^(um+|uh+)$`, `(`})
+	if len(detector.NoisePatterns) != len(defaultNoisePatterns)+1 {
+		t.Fatalf("noise patterns = %d, want the defaults and the valid configured one", len(detector.NoisePatterns))
+	}
+
+	speech := detector.SpeechSegments([]map[string]interface{}{
+		{"text": "UMM"},
+		{"text": "[music]"},
+		{"text": "somewhat sure", "confidence": 0.7},
+		{"text": "quite sure of this", "confidence": 0.9},
+	})
+	if len(speech) != 1 || speech[0]["text"] != "quite sure of this" {
+		t.Errorf("SpeechSegments() = %v, want only the confident speech", speech)
+	}
+}
+
+func TestProcessUtteranceDropsNoiseSegments(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.ProcessUtterance([]map[string]interface{}{
+		{"speaker": "Alice", "text": "We should ship on Friday", "confidence": 0.95},
+		{"speaker": "Alice", "text": "[inaudible]"},
+		{"speaker": "Alice", "text": "or maybe", "confidence": 0.2},
+		{"speaker": "Alice", "text": "after the review"},
+	})
+
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != 1 {
+		t.Fatalf("transcript entries = %d, want 1", len(transcript))
+	}
+	if want := "We should ship on Friday after the review"; transcript[0].Text != want {
+		t.Errorf("transcript text = %q, want %q", transcript[0].Text, want)
+	}
+}
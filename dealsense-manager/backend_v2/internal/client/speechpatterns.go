@@ -0,0 +1,205 @@
+package client
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// speechBaselineSamples is the number of utterances a speaker's baseline needs before anomalies are judged
+	// against it
+	speechBaselineSamples = 3
+	// formalityWindow is the number of a speaker's most recent formality scores averaged as their baseline
+	formalityWindow = 10
+	// minFormalityWords is the length from which an utterance's formality is scored, as shorter ones are noisy
+	minFormalityWords = 8
+	// formalityShift is the change in the share of Latinate words from a speaker's average that is flagged
+	formalityShift = 0.2
+	// monologueFactor is how many times a speaker's average utterance length a monologue is flagged at
+	monologueFactor = 3.0
+	// pauseFactor is how many times a speaker's average response latency a pause is flagged at
+	pauseFactor = 3.0
+	// minUnusualPause is the shortest pause flagged, however quickly the speaker usually responds
+	minUnusualPause = 10 * time.Second
+	// excessiveHedges is the number of hedging phrases in one utterance that is flagged
+	excessiveHedges = 2
+)
+
+// Kinds of unusual speaking behavior
+const (
+	AnomalyFormalityShift   = "sudden_formality_shift"
+	AnomalyLongMonologue    = "unusually_long_monologue"
+	AnomalyUnusualPause     = "unusual_pause"
+	AnomalyExcessiveHedging = "excessive_hedging"
+)
+
+// latinateWordsText is a lowercase list of common Latinate words, one per line, whose share of an
+// utterance scores its formality
+//
+//go:embed latinate_words.txt
+var latinateWordsText string
+
+var (
+	latinateWordSet     map[string]bool
+	latinateWordSetOnce sync.Once
+)
+
+// latinateWords returns the embedded Latinate word list as a set, loading it on first use
+func latinateWords() map[string]bool {
+	latinateWordSetOnce.Do(func() {
+		latinateWordSet = wordSet(strings.Fields(latinateWordsText)...)
+	})
+	return latinateWordSet
+}
+
+// hedgingPhrases signal a speaker avoiding commitment
+var hedgingPhrases = regexp.MustCompile(`(?i)\b(i think maybe|i guess|possibly|perhaps we could|perhaps|maybe|sort of|kind of|i'm not sure|not entirely sure|it might be|might be able to|more or less|to some extent)\b`)
+
+// PatternAnomaly is a moment a participant spoke unlike they usually do, which can indicate stress or
+// evasion
+type PatternAnomaly struct {
+	Speaker     string    `json:"speaker"`
+	Timestamp   time.Time `json:"timestamp"`
+	AnomalyType string    `json:"anomaly_type"` // sudden_formality_shift, unusually_long_monologue, unusual_pause, excessive_hedging
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"` // low, medium, high
+}
+
+// speechBaseline holds the running averages a speaker's utterances are compared against
+type speechBaseline struct {
+	utterances        int
+	averageWords      float64
+	formalityScores   []float64 // Most recent formality scores, oldest first
+	responses         int
+	averageLatencySec float64
+}
+
+// detectSpeechPatternAnomalies compares the newest transcript entry with its speaker's earlier utterances,
+// flagging shifts in formality, monologues, long pauses before responding and heavy hedging, then adds the
+// entry to the speaker's baseline. Caller must hold dataMutex.
+func (a *AnalystAgent) detectSpeechPatternAnomalies() {
+	count := len(a.data.Transcript)
+	if count == 0 {
+		return
+	}
+	current := a.data.Transcript[count-1]
+	if current.IsAgent {
+		return
+	}
+	if a.speechBaselines == nil {
+		a.speechBaselines = make(map[string]*speechBaseline)
+	}
+	baseline := a.speechBaselines[current.Speaker]
+	if baseline == nil {
+		baseline = &speechBaseline{}
+		a.speechBaselines[current.Speaker] = baseline
+	}
+
+	var previous *TranscriptEntry
+	if count > 1 && a.data.Transcript[count-2].Speaker != current.Speaker {
+		previous = &a.data.Transcript[count-2]
+	}
+	anomalies := speechPatternAnomalies(baseline, current, previous)
+	a.data.SpeechPatternAnomalies = append(a.data.SpeechPatternAnomalies, anomalies...)
+}
+
+// speechPatternAnomalies returns the anomalies of an utterance against the speaker's baseline, which is
+// then updated with it. previous is the utterance the speaker responded to, or nil when they continued
+// their own turn.
+func speechPatternAnomalies(baseline *speechBaseline, current TranscriptEntry, previous *TranscriptEntry) []PatternAnomaly {
+	var anomalies []PatternAnomaly
+	flag := func(anomalyType, severity, description string) {
+		anomalies = append(anomalies, PatternAnomaly{
+			Speaker:     current.Speaker,
+			Timestamp:   current.Timestamp,
+			AnomalyType: anomalyType,
+			Description: description,
+			Severity:    severity,
+		})
+	}
+
+	words := strings.Fields(current.Text)
+	if baseline.utterances >= speechBaselineSamples && baseline.averageWords > 0 {
+		if ratio := float64(len(words)) / baseline.averageWords; ratio > monologueFactor {
+			flag(AnomalyLongMonologue, anomalySeverity(ratio/monologueFactor),
+				fmt.Sprintf("Spoke %d words, %.1f times their average of %.0f", len(words), ratio, baseline.averageWords))
+		}
+	}
+	baseline.averageWords = (baseline.averageWords*float64(baseline.utterances) + float64(len(words))) / float64(baseline.utterances+1)
+	baseline.utterances++
+
+	if len(words) >= minFormalityWords {
+		score := formalityScore(words)
+		if len(baseline.formalityScores) >= speechBaselineSamples {
+			var average float64
+			for _, previousScore := range baseline.formalityScores {
+				average += previousScore
+			}
+			average /= float64(len(baseline.formalityScores))
+			if shift := score - average; math.Abs(shift) >= formalityShift {
+				direction := "formal"
+				if shift < 0 {
+					direction = "informal"
+				}
+				flag(AnomalyFormalityShift, anomalySeverity(math.Abs(shift)/formalityShift),
+					fmt.Sprintf("Switched to %s language: %.0f%% Latinate words against their average of %.0f%%", direction, score*100, average*100))
+			}
+		}
+		baseline.formalityScores = append(baseline.formalityScores, score)
+		if len(baseline.formalityScores) > formalityWindow {
+			baseline.formalityScores = baseline.formalityScores[1:]
+		}
+	}
+
+	if previous != nil {
+		latency := responseLatency(*previous, current)
+		if baseline.responses >= speechBaselineSamples && latency >= minUnusualPause.Seconds() &&
+			latency > baseline.averageLatencySec*pauseFactor {
+			ratio := latency / max(baseline.averageLatencySec*pauseFactor, minUnusualPause.Seconds())
+			flag(AnomalyUnusualPause, anomalySeverity(ratio),
+				fmt.Sprintf("Paused %.0f seconds before responding, against their average of %.0f seconds", latency, baseline.averageLatencySec))
+		}
+		baseline.averageLatencySec = (baseline.averageLatencySec*float64(baseline.responses) + latency) / float64(baseline.responses+1)
+		baseline.responses++
+	}
+
+	if hedges := hedgingPhrases.FindAllString(current.Text, -1); len(hedges) >= excessiveHedges {
+		flag(AnomalyExcessiveHedging, anomalySeverity(float64(len(hedges))/excessiveHedges),
+			fmt.Sprintf("Hedged %d times: %s", len(hedges), strings.ToLower(strings.Join(hedges, ", "))))
+	}
+	return anomalies
+}
+
+// formalityScore returns the share of words that are Latinate, matching plural and past tense forms of
+// the listed words
+func formalityScore(words []string) float64 {
+	latinate := latinateWords()
+	matched := 0
+	for _, word := range words {
+		word = strings.ToLower(strings.Trim(word, ".,;:!?\"'()"))
+		for _, form := range []string{word, strings.TrimSuffix(word, "s"), strings.TrimSuffix(word, "d"), strings.TrimSuffix(word, "ed")} {
+			if latinate[form] {
+				matched++
+				break
+			}
+		}
+	}
+	return float64(matched) / float64(len(words))
+}
+
+// anomalySeverity grades how far past its threshold an anomaly is, given as the multiple of the threshold
+func anomalySeverity(multiple float64) string {
+	switch {
+	case multiple >= 2:
+		return "high"
+	case multiple >= 1.5:
+		return "medium"
+	default:
+		return "low"
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import "testing"
+
+// casualTurn is an eight-word utterance without Latinate words
+const casualTurn = "yeah we can look at that one later"
+
+// withBaseline feeds three casual utterances into a fresh baseline
+func withBaseline() *speechBaseline {
+	baseline := &speechBaseline{}
+	for i := 0; i < speechBaselineSamples; i++ {
+		speechPatternAnomalies(baseline, entryAt(i*10, "Bob", casualTurn), nil)
+	}
+	return baseline
+}
+
+func anomalyTypes(anomalies []PatternAnomaly) map[string]string {
+	types := make(map[string]string)
+	for _, anomaly := range anomalies {
+		types[anomaly.AnomalyType] = anomaly.Severity
+	}
+	return types
+}
+
+func TestFormalityShift(t *testing.T) {
+	baseline := withBaseline()
+	formal := "we will subsequently facilitate, implement and demonstrate sufficient progress"
+	types := anomalyTypes(speechPatternAnomalies(baseline, entryAt(60, "Bob", formal), nil))
+	if severity, ok := types[AnomalyFormalityShift]; !ok || severity != "high" {
+		t.Errorf("anomalies = %v, want a high formality shift", types)
+	}
+}
+
+func TestLongMonologue(t *testing.T) {
+	baseline := withBaseline()
+	long := casualTurn + " " + casualTurn + " " + casualTurn + " and then we are done"
+	types := anomalyTypes(speechPatternAnomalies(baseline, entryAt(60, "Bob", long), nil))
+	if severity, ok := types[AnomalyLongMonologue]; !ok || severity != "low" {
+		t.Errorf("anomalies = %v, want a low monologue", types)
+	}
+}
+
+func TestUnusualPause(t *testing.T) {
+	baseline := &speechBaseline{}
+	// "Can you confirm that please" takes 2s to say, so a reply 3s after it started comes after 1s
+	for i := 0; i < speechBaselineSamples; i++ {
+		question := entryAt(i*20, "Alice", "Can you confirm that please")
+		if anomalies := speechPatternAnomalies(baseline, entryAt(i*20+3, "Bob", "Sure, that works"), &question); len(anomalies) > 0 {
+			t.Fatalf("baseline reply flagged: %+v", anomalies)
+		}
+	}
+
+	question := entryAt(100, "Alice", "Can you confirm the price")
+	types := anomalyTypes(speechPatternAnomalies(baseline, entryAt(122, "Bob", "Sure, that works"), &question))
+	if severity, ok := types[AnomalyUnusualPause]; !ok || severity != "high" {
+		t.Errorf("anomalies = %v, want a high unusual pause", types)
+	}
+}
+
+func TestShortPauseNotFlagged(t *testing.T) {
+	// A quick responder pausing 8s tops their average threefold but stays under the minimum
+	baseline := &speechBaseline{responses: speechBaselineSamples, averageLatencySec: 1}
+	question := entryAt(0, "Alice", "Can you confirm that please")
+	if anomalies := speechPatternAnomalies(baseline, entryAt(10, "Bob", "Sure, that works"), &question); len(anomalies) > 0 {
+		t.Errorf("anomalies = %+v, want none", anomalies)
+	}
+}
+
+func TestExcessiveHedging(t *testing.T) {
+	// Hedging is judged without a baseline
+	anomalies := speechPatternAnomalies(&speechBaseline{}, entryAt(0, "Bob", "I guess maybe we could sort of try it"), nil)
+	if len(anomalies) != 1 || anomalies[0].AnomalyType != AnomalyExcessiveHedging || anomalies[0].Severity != "medium" {
+		t.Fatalf("anomalies = %+v, want one medium hedging anomaly", anomalies)
+	}
+	if anomalies[0].Description != "Hedged 3 times: i guess, maybe, sort of" {
+		t.Errorf("description = %q", anomalies[0].Description)
+	}
+}
+
+func TestNoAnomaliesWithoutBaseline(t *testing.T) {
+	formal := "we will subsequently facilitate, implement and demonstrate sufficient progress"
+	if anomalies := speechPatternAnomalies(&speechBaseline{}, entryAt(0, "Bob", formal), nil); len(anomalies) > 0 {
+		t.Errorf("anomalies = %+v, want none before the baseline is built", anomalies)
+	}
+}
+
+func TestSpeechAnomaliesSkipAgent(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.dataMutex.Lock()
+	analyst.data.Transcript = append(analyst.data.Transcript, TranscriptEntry{
+		Timestamp: testMeetingStart, Speaker: "Agent", Text: "I guess maybe it is sort of late", IsAgent: true,
+	})
+	analyst.detectSpeechPatternAnomalies()
+	analyst.dataMutex.Unlock()
+	say(analyst, 10, "Bob", "I guess maybe we could sort of try it")
+
+	anomalies := analyst.GetAnalysis().SpeechPatternAnomalies
+	if len(anomalies) != 1 || anomalies[0].Speaker != "Bob" {
+		t.Errorf("anomalies = %+v, want only Bob's hedging", anomalies)
+	}
+}
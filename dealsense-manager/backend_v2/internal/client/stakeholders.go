@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// stakeholdersTranscript is the number of recent transcript entries stakeholders are found in
+	stakeholdersTranscript = 50
+	// maxStakeholderContexts is the number of most recent statements kept for each stakeholder
+	maxStakeholderContexts = 3
+)
+
+// Relationships of an external stakeholder to the organization
+const (
+	StakeholderClient    = "client"
+	StakeholderVendor    = "vendor"
+	StakeholderRegulator = "regulator"
+	StakeholderPartner   = "partner"
+	StakeholderInvestor  = "investor"
+)
+
+// Stakeholder is an external organization or person referenced in the meeting
+type Stakeholder struct {
+	Name                string   `json:"name"`
+	Type                string   `json:"type"` // client, vendor, regulator, partner, investor
+	MentionCount        int      `json:"mention_count"`
+	Sentiment           string   `json:"sentiment"`                       // positive, neutral, negative
+	Context             []string `json:"context,omitempty"`               // Most recent statements mentioning the stakeholder, oldest first
+	ActionItemsAssigned []string `json:"action_items_assigned,omitempty"` // IDs of action items assigned to the stakeholder
+}
+
+// extractStakeholders finds the external organizations and people referenced in the recent transcript and
+// classifies their relationship to the organization. Stakeholders accumulate across analysis runs, taking
+// the type and sentiment of their latest mention; internal speakers are never added. Mention counts,
+// contexts and assigned action items are then recounted over the whole transcript.
+func (a *AnalystAgent) extractStakeholders(ctx context.Context) error {
+	transcript := a.getRecentTranscript(stakeholdersTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting stakeholders from %d transcript entries", a.agentID, len(transcript))
+
+	text := a.formatTranscriptForLLM(transcript)
+	prompt := a.glossaryPrefix(text) + a.languagePrefix() + fmt.Sprintf(`Identify the external stakeholders referenced in this meeting: organizations (ORG) and people (PERSON) outside the participants' own organization, such as clients, vendors, regulators, partners and investors.
+
+For each stakeholder, give:
+- Its name as said in the meeting, without titles or honorifics
+- Type: client when they buy from the organization, vendor when they supply it, regulator for government bodies and auditors, partner for resellers, integrators and other collaborators, investor for shareholders, funds and board members
+- Sentiment: how the participants talked about them, positive, neutral or negative
+
+Leave out the meeting participants and their colleagues, and products or technologies that aren't organizations.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "stakeholders": [
+    {"name": "Acme Corp", "type": "client/vendor/regulator/partner/investor", "sentiment": "positive/neutral/negative"}
+  ]
+}
+`+"`"+``, text)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		Stakeholders []Stakeholder `json:"stakeholders"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse stakeholders JSON: %w", err)
+	}
+
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	for _, stakeholder := range result.Stakeholders {
+		stakeholder.Name = strings.TrimSpace(stakeholder.Name)
+		if stakeholder.Name == "" || a.isInternalStakeholder(stakeholder.Name) {
+			continue
+		}
+		stakeholder.Type = normalizeStakeholderType(stakeholder.Type)
+		stakeholder.Sentiment = normalizeStakeholderSentiment(stakeholder.Sentiment)
+		a.data.StakeholderMap = mergeStakeholder(a.data.StakeholderMap, stakeholder)
+	}
+	a.refreshStakeholderMentions()
+
+	logrus.Infof("Agent %s: Tracking %d stakeholders", a.agentID, len(a.data.StakeholderMap))
+	return nil
+}
+
+// isInternalStakeholder reports whether name is an internal speaker, by the agent's internal speakers and
+// domains or the classification of a participant with that name. Caller must hold dataMutex.
+func (a *AnalystAgent) isInternalStakeholder(name string) bool {
+	if classifySpeaker(name, a.config.InternalSpeakers, a.config.InternalDomains) == SpeakerInternal {
+		return true
+	}
+	for _, speaker := range a.config.InternalSpeakers {
+		if strings.EqualFold(speaker, name) {
+			return true
+		}
+	}
+	for speaker, class := range a.data.SpeakerClassification {
+		if class == SpeakerInternal && strings.EqualFold(speaker, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeStakeholder adds a stakeholder to the map, or updates the type and sentiment of the one with the
+// same name
+func mergeStakeholder(stakeholders []Stakeholder, stakeholder Stakeholder) []Stakeholder {
+	for i, existing := range stakeholders {
+		if strings.EqualFold(existing.Name, stakeholder.Name) {
+			stakeholders[i].Type = stakeholder.Type
+			stakeholders[i].Sentiment = stakeholder.Sentiment
+			return stakeholders
+		}
+	}
+	return append(stakeholders, Stakeholder{Name: stakeholder.Name, Type: stakeholder.Type, Sentiment: stakeholder.Sentiment})
+}
+
+// refreshStakeholderMentions recounts each stakeholder's mentions in the participants' statements, keeping
+// the most recent statements as context, and collects the action items assigned to them. Caller must hold
+// dataMutex.
+func (a *AnalystAgent) refreshStakeholderMentions() {
+	for i := range a.data.StakeholderMap {
+		stakeholder := &a.data.StakeholderMap[i]
+		mention := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(stakeholder.Name) + `\b`)
+
+		stakeholder.MentionCount = 0
+		stakeholder.Context = nil
+		for _, entry := range a.data.Transcript {
+			if entry.IsAgent {
+				continue
+			}
+			count := len(mention.FindAllStringIndex(entry.Text, -1))
+			if count == 0 {
+				continue
+			}
+			stakeholder.MentionCount += count
+			stakeholder.Context = append(stakeholder.Context, entry.Text)
+			if len(stakeholder.Context) > maxStakeholderContexts {
+				stakeholder.Context = stakeholder.Context[1:]
+			}
+		}
+
+		stakeholder.ActionItemsAssigned = nil
+		for _, item := range a.data.ActionItems {
+			if item.Assignee != "" && mention.MatchString(item.Assignee) {
+				stakeholder.ActionItemsAssigned = append(stakeholder.ActionItemsAssigned, item.ID)
+			}
+		}
+	}
+}
+
+// normalizeStakeholderSentiment maps the LLM's sentiment onto positive, neutral or negative, defaulting to
+// neutral
+func normalizeStakeholderSentiment(sentiment string) string {
+	switch sentiment = strings.ToLower(strings.TrimSpace(sentiment)); sentiment {
+	case "positive", "negative":
+		return sentiment
+	default:
+		return "neutral"
+	}
+}
+
+// normalizeStakeholderType maps the LLM's relationship type onto the supported set, defaulting to partner
+// for stakeholders of unclear relationship
+func normalizeStakeholderType(stakeholderType string) string {
+	switch stakeholderType = strings.ToLower(strings.TrimSpace(stakeholderType)); stakeholderType {
+	case StakeholderClient, StakeholderVendor, StakeholderRegulator, StakeholderPartner, StakeholderInvestor:
+		return stakeholderType
+	case "customer":
+		return StakeholderClient
+	case "supplier":
+		return StakeholderVendor
+	default:
+		return StakeholderPartner
+	}
+}
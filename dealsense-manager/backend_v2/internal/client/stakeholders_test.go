@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestExtractStakeholders(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.InternalSpeakers = []string{"Alice"}
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"stakeholders": [
+		{"name": "Acme Corp", "type": "Customer", "sentiment": "Positive"},
+		{"name": "alice", "type": "partner", "sentiment": "neutral"},
+		{"name": "Globex", "type": "supplier", "sentiment": "angry"}
+	]}` + "\n```")
+	say(analyst, 0, "Alice", "Acme Corp renewed and Acme Corp wants more seats")
+	say(analyst, 10, "Bob", "Globex is late with the hardware again")
+	analyst.dataMutex.Lock()
+	analyst.data.ActionItems = []ActionItem{{ID: "ai-1", Assignee: "Acme Corp procurement"}}
+	analyst.dataMutex.Unlock()
+
+	if err := analyst.extractStakeholders(context.Background()); err != nil {
+		t.Fatalf("extractStakeholders() error = %v", err)
+	}
+
+	// The internal speaker is left out, however the LLM cased the name
+	stakeholders := analyst.GetAnalysis().StakeholderMap
+	if len(stakeholders) != 2 {
+		t.Fatalf("StakeholderMap = %+v, want Acme Corp and Globex", stakeholders)
+	}
+	acme, globex := stakeholders[0], stakeholders[1]
+	if acme.Type != StakeholderClient || acme.Sentiment != "positive" || acme.MentionCount != 2 ||
+		!reflect.DeepEqual(acme.ActionItemsAssigned, []string{"ai-1"}) {
+		t.Errorf("Acme Corp = %+v", acme)
+	}
+	if globex.Type != StakeholderVendor || globex.Sentiment != "neutral" || globex.MentionCount != 1 {
+		t.Errorf("Globex = %+v", globex)
+	}
+}
+
+func TestIsInternalStakeholderUsesDomains(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.InternalDomains = []string{"acme.com"}
+	say(analyst, 0, "carol@acme.com", "Joining from the sales team")
+
+	analyst.dataMutex.RLock()
+	defer analyst.dataMutex.RUnlock()
+	if !analyst.isInternalStakeholder("bob@acme.com") || !analyst.isInternalStakeholder("CAROL@ACME.COM") {
+		t.Error("speakers at the internal domain aren't internal")
+	}
+	if analyst.isInternalStakeholder("Globex") {
+		t.Error("Globex is internal")
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// indexedSection is a list shown in an indexed statements prompt, each line prefixed with its index in
+// square brackets
+type indexedSection struct {
+	title string
+	text  string
+}
+
+// indexedStatementsRequest asks the LLM about transcript statements, with a response referring to them
+// by their index
+type indexedStatementsRequest struct {
+	task     string           // What to identify in the statements
+	sections []indexedSection // Indexed lists following the task, usually the transcript alone
+	indexed  string           // What the sections list, "statement" when empty
+	format   string           // Example of the JSON response
+	notes    string           // Instructions following the response format, if any
+	result   string           // What the response holds, for parse errors
+}
+
+// transcriptSection lists the whole transcript, prefixed with each entry's index
+func transcriptSection(transcript []TranscriptEntry) indexedSection {
+	return indexedSection{title: "Transcript", text: formatIndexedTranscript(transcript, [2]int{0, len(transcript)})}
+}
+
+// askAboutStatements sends an indexed statements request and decodes the JSON response into result,
+// reporting false when the response held no JSON. Custom prompts are not applied here as the response
+// must reference statements by index.
+func (a *AnalystAgent) askAboutStatements(ctx context.Context, request indexedStatementsRequest, result interface{}) (bool, error) {
+	indexed := request.indexed
+	if indexed == "" {
+		indexed = "statement"
+	}
+
+	texts := make([]string, len(request.sections))
+	var prompt strings.Builder
+	prompt.WriteString(request.task)
+	prompt.WriteString("\n\nEach " + indexed + " is prefixed with its index in square brackets.\n\n")
+	for i, section := range request.sections {
+		texts[i] = section.text
+		prompt.WriteString(section.title + ":\n" + section.text + "\n")
+	}
+	prompt.WriteString("Provide your response in the following JSON format within a code block:\n```json\n" + request.format + "\n```")
+	prompt.WriteString(request.notes)
+
+	response, err := a.callLLM(ctx, a.glossaryPrefix(texts...)+a.languagePrefix()+prompt.String())
+	if err != nil {
+		return false, err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(jsonData), result); err != nil {
+		return false, fmt.Errorf("failed to parse %s JSON: %w", request.result, err)
+	}
+	return true, nil
+}
+
+// formatIndexedTranscript formats the entries in window prefixed with their transcript index
+func formatIndexedTranscript(transcript []TranscriptEntry, window [2]int) string {
+	var result strings.Builder
+	for i := window[0]; i < window[1]; i++ {
+		result.WriteString(fmt.Sprintf("[%d] %s: %s\n", i, transcript[i].Speaker, transcript[i].Text))
+	}
+	return result.String()
+}
+
+// statementAt returns the statement an LLM response refers to by index, reporting false when the index
+// is outside the transcript
+func statementAt(transcript []TranscriptEntry, index int) (TranscriptEntry, bool) {
+	if index < 0 || index >= len(transcript) {
+		return TranscriptEntry{}, false
+	}
+	return transcript[index], true
+}
+
+// replyAt returns the statement an LLM response gives as the reply to the statement at index, reporting
+// false unless it is a later statement by someone else
+func replyAt(transcript []TranscriptEntry, index, reply int) (TranscriptEntry, bool) {
+	if reply <= index || reply >= len(transcript) || transcript[reply].Speaker == transcript[index].Speaker {
+		return TranscriptEntry{}, false
+	}
+	return transcript[reply], true
+}
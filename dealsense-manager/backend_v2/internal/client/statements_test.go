@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// indexedTranscript returns a transcript of statements by the given speakers
+func indexedTranscript(speakers ...string) []TranscriptEntry {
+	start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	transcript := make([]TranscriptEntry, len(speakers))
+	for i, speaker := range speakers {
+		transcript[i] = TranscriptEntry{Speaker: speaker, Text: "Statement " + string(rune('A'+i)), Timestamp: start.Add(time.Duration(i) * time.Minute)}
+	}
+	return transcript
+}
+
+func TestAskAboutStatementsPrompt(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	mock := llm.NewMockLLMProvider("```json\n{\"items\": [{\"statement\": 1}]}\n```")
+	analyst.llmProvider = mock
+
+	var result struct {
+		Items []struct {
+			Statement int `json:"statement"`
+		} `json:"items"`
+	}
+	request := indexedStatementsRequest{
+		task:     "Find the items.",
+		sections: []indexedSection{{title: "Items", text: "[0] Ship it\n"}, transcriptSection(indexedTranscript("Alice", "Bob"))},
+		indexed:  "item and each statement",
+		format:   `{"items": [{"statement": 0}]}`,
+		notes:    "\n\nUse -1 for items not raised.",
+		result:   "items",
+	}
+	ok, err := analyst.askAboutStatements(context.Background(), request, &result)
+	if err != nil || !ok {
+		t.Fatalf("askAboutStatements() = %v, %v", ok, err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Statement != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	prompt := mock.Prompts()[0]
+	for _, want := range []string{
+		"Find the items.\n\nEach item and each statement is prefixed with its index in square brackets.",
+		"Items:\n[0] Ship it\n",
+		"Transcript:\n[0] Alice: Statement A\n[1] Bob: Statement B\n",
+		"```json\n{\"items\": [{\"statement\": 0}]}\n```\n\nUse -1 for items not raised.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestAskAboutStatementsWithoutJSON(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	// The response without JSON is asked to be reformatted as JSON once
+	analyst.llmProvider = llm.NewMockLLMProvider("Nothing found.", "Nothing found.", "```json\n{\"items\": \"not a list\"}\n```")
+
+	var result struct {
+		Items []int `json:"items"`
+	}
+	request := indexedStatementsRequest{task: "Find the items.", format: `{"items": []}`, result: "items"}
+	if ok, err := analyst.askAboutStatements(context.Background(), request, &result); ok || err != nil {
+		t.Errorf("response without JSON: askAboutStatements() = %v, %v, want false, nil", ok, err)
+	}
+	_, err := analyst.askAboutStatements(context.Background(), request, &result)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse items JSON") {
+		t.Errorf("invalid JSON: askAboutStatements() error = %v", err)
+	}
+}
+
+func TestStatementIndexes(t *testing.T) {
+	transcript := indexedTranscript("Alice", "Bob", "Alice")
+
+	for index, want := range map[int]bool{-1: false, 0: true, 2: true, 3: false} {
+		if _, ok := statementAt(transcript, index); ok != want {
+			t.Errorf("statementAt(%d) ok = %v, want %v", index, ok, want)
+		}
+	}
+
+	tests := []struct {
+		index, reply int
+		want         bool
+	}{
+		{0, 1, true},  // A later statement by someone else
+		{0, 2, false}, // The same speaker
+		{1, 0, false}, // An earlier statement
+		{1, 1, false},
+		{1, 3, false}, // Outside the transcript
+		{0, -1, false},
+	}
+	for _, tt := range tests {
+		if _, ok := replyAt(transcript, tt.index, tt.reply); ok != tt.want {
+			t.Errorf("replyAt(%d, %d) ok = %v, want %v", tt.index, tt.reply, ok, tt.want)
+		}
+	}
+}
+
+func TestEthicsFlagsIgnoreUnknownStatements(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Transcript = indexedTranscript("Alice", "Bob")
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"flags": [
+		{"statement": 1, "category": "harassment", "severity": "medium", "quote": "Statement B"},
+		{"statement": 7, "category": "harassment", "severity": "medium", "quote": "Made up"}
+	]}` + "\n```")
+
+	if err := analyst.detectEthicsIssues(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	flags := analyst.GetAnalysis().EthicsFlags
+	if len(flags) != 1 || flags[0].Speaker != "Bob" {
+		t.Errorf("flags = %+v, want only Bob's statement", flags)
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+// stepProviderSteps are the analysis steps that can have an LLM provider of their own
+var stepProviderSteps = map[string]bool{
+	"summary":            true,
+	"key_points":         true,
+	"action_items":       true,
+	"topics":             true,
+	"sentiment_keywords": true,
+}
+
+// newStepProviders creates the LLM providers of the steps configured with one, keyed by step. Steps sharing
+// a provider and model share one instance. Unknown steps and providers that can't be created are logged
+// and left out, so those steps use the agent's provider.
+func newStepProviders(agentID string, configs map[string]models.LLMProviderConfig) map[string]llm.LLMProvider {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	providers := make(map[string]llm.LLMProvider, len(configs))
+	created := make(map[models.LLMProviderConfig]llm.LLMProvider)
+	for step, config := range configs {
+		if !stepProviderSteps[step] {
+			logrus.Warnf("Agent %s: Ignoring LLM provider of unknown analysis step %q", agentID, step)
+			continue
+		}
+		provider, ok := created[config]
+		if !ok {
+			var err error
+			provider, err = llm.GetProvider(string(config.Provider), config.Model)
+			if err != nil {
+				logrus.Errorf("Failed to get LLM provider of %s step for analyst %s, using the agent's: %v", step, agentID, err)
+				continue
+			}
+			created[config] = provider
+		}
+		providers[step] = provider
+		logrus.Infof("Agent %s: %s step uses %s model %s", agentID, step, config.Provider, config.Model)
+	}
+	return providers
+}
+
+// stepProvider returns the LLM provider configured for an analysis step, or the agent's provider when the
+// step has none
+func (a *AnalystAgent) stepProvider(step string) llm.LLMProvider {
+	if provider, ok := a.stepProviders[step]; ok {
+		return provider
+	}
+	return a.llmProvider
+}
+
+// callStepLLM makes an LLM call with the provider of an analysis step, giving up when ctx is done
+func (a *AnalystAgent) callStepLLM(ctx context.Context, step, prompt string) (string, error) {
+	provider := a.stepProvider(step)
+	if provider == nil || !provider.IsAvailable() {
+		return "", fmt.Errorf("LLM provider not available")
+	}
+	prompt = a.withSystemPrefix(prompt)
+	// Steps with a provider of their own aren't batched with the agent's provider
+	if _, ok := a.stepProviders[step]; !ok {
+		if response, ok := a.takeBatchedResponse(prompt); ok {
+			return response, nil
+		}
+	}
+
+	return callWithContext(ctx, func() (string, error) {
+		return provider.Call(prompt)
+	})
+}
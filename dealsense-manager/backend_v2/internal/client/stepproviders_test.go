@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+	"joinly-manager/internal/models"
+)
+
+func TestNewStepProviders(t *testing.T) {
+	fast := models.LLMProviderConfig{Provider: models.LLMProviderGoogle, Model: "gemini-2.5-flash"}
+	precise := models.LLMProviderConfig{Provider: models.LLMProviderAnthropic, Model: "claude-sonnet-4"}
+	providers := newStepProviders("test-agent", map[string]models.LLMProviderConfig{
+		"summary":      fast,
+		"key_points":   fast,
+		"action_items": precise,
+		"translation":  fast,
+		"topics":       {Provider: models.LLMProviderOllama, Model: "llama3"},
+	})
+
+	// Unknown steps and providers that can't be created fall back to the agent's provider
+	if len(providers) != 3 {
+		t.Fatalf("newStepProviders() = %v, want summary, key_points and action_items", providers)
+	}
+	if providers["summary"] != providers["key_points"] {
+		t.Error("steps with the same provider and model don't share an instance")
+	}
+	if providers["summary"] == providers["action_items"] {
+		t.Error("steps with different providers share an instance")
+	}
+}
+
+func TestCallStepLLMUsesStepProvider(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	agentProvider := llm.NewMockLLMProvider("agent")
+	summaryProvider := llm.NewMockLLMProvider("summary")
+	analyst.llmProvider = agentProvider
+	analyst.stepProviders = map[string]llm.LLMProvider{"summary": summaryProvider}
+
+	ctx := context.Background()
+	if response, err := analyst.callStepLLM(ctx, "summary", "Summarize."); err != nil || response != "summary" {
+		t.Errorf("summary step = %q, %v, want the step's provider", response, err)
+	}
+	if response, err := analyst.callStepLLM(ctx, "topics", "Find the topics."); err != nil || response != "agent" {
+		t.Errorf("topics step = %q, %v, want the agent's provider", response, err)
+	}
+	if len(agentProvider.Prompts()) != 1 || len(summaryProvider.Prompts()) != 1 {
+		t.Errorf("calls = %d agent, %d summary, want one each", len(agentProvider.Prompts()), len(summaryProvider.Prompts()))
+	}
+}
@@ -0,0 +1,22 @@
+package client
+
+import "joinly-manager/internal/event"
+
+const (
+	// maxTranscriptStreams bounds the number of concurrent transcript streams per agent
+	maxTranscriptStreams = 20
+	// transcriptStreamBuffer is the number of updates buffered for each stream before dropping
+	transcriptStreamBuffer = 64
+)
+
+// TranscriptUpdate is published to transcript streams whenever a new entry is recorded
+type TranscriptUpdate struct {
+	Entry     TranscriptEntry `json:"entry"`
+	WordCount int             `json:"word_count"`
+}
+
+// SubscribeTranscript starts streaming new transcript entries. It returns event.ErrTooManySubscribers if
+// the agent already has its maximum number of streams. Events are TranscriptUpdate values.
+func (a *AnalystAgent) SubscribeTranscript() (*event.Subscription, error) {
+	return a.transcriptBus.Subscribe()
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/event"
+)
+
+func TestSubscribeTranscriptStreamsNewEntries(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	sub, err := analyst.SubscribeTranscript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	say(analyst, 0, "Alice", "Let's review the rollout plan.")
+
+	select {
+	case ev := <-sub.Events():
+		update, ok := ev.(TranscriptUpdate)
+		if !ok || update.Entry.Speaker != "Alice" || update.WordCount != 5 {
+			t.Errorf("event = %#v, want Alice's entry with 5 words", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no transcript update received")
+	}
+}
+
+func TestSubscribeTranscriptLimitsStreams(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	for i := 0; i < maxTranscriptStreams; i++ {
+		sub, err := analyst.SubscribeTranscript()
+		if err != nil {
+			t.Fatalf("stream %d: %v", i, err)
+		}
+		defer sub.Close()
+	}
+	if _, err := analyst.SubscribeTranscript(); !errors.Is(err, event.ErrTooManySubscribers) {
+		t.Errorf("SubscribeTranscript() error = %v, want ErrTooManySubscribers", err)
+	}
+}
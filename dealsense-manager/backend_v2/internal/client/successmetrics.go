@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// successMetricsTranscript is the number of recent transcript entries success metrics are extracted from
+const successMetricsTranscript = 50
+
+// Progress of a success metric towards its target
+const (
+	MetricOnTrack  = "on_track"
+	MetricAtRisk   = "at_risk"
+	MetricOffTrack = "off_track"
+)
+
+// SuccessMetric is a goal, KPI or OKR key result discussed in the meeting with its progress towards the target
+type SuccessMetric struct {
+	MetricName   string `json:"metric_name"`
+	CurrentValue string `json:"current_value,omitempty"` // As stated, e.g. "$1.2M" or "38%"
+	TargetValue  string `json:"target_value,omitempty"`
+	Status       string `json:"status"` // on_track, at_risk, off_track
+	Owner        string `json:"owner,omitempty"`
+	Deadline     string `json:"deadline,omitempty"` // As stated, e.g. "end of Q3"
+	Verified     bool   `json:"verified"`           // The current value was confirmed by a web search
+}
+
+// extractSuccessMetrics tracks the targets, KPIs and OKRs discussed in the recent transcript. A metric
+// mentioned again updates the values given in the new mention, and each metric that goes off track raises
+// an error-level alert. Current values are checked with a web search when the LLM supports grounding.
+func (a *AnalystAgent) extractSuccessMetrics(ctx context.Context) error {
+	transcript := a.getRecentTranscript(successMetricsTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Tracking success metrics in %d transcript entries", a.agentID, len(transcript))
+
+	text := a.formatTranscriptForLLM(transcript)
+	prompt := a.glossaryPrefix(text) + a.languagePrefix() + fmt.Sprintf(`Find every success metric discussed in this meeting: OKRs and key results, KPIs, quotas, and other goals with a numerical target, such as "we want to hit 40%% activation by Q3" or "churn is at 6%%, the goal is under 4%%".
+
+Listen for numerical targets, percentages, currency amounts, growth rates and goal language such as "target", "goal", "objective", "key result", "KPI", "quota", "hit", "reach" and "by the end of".
+
+For each metric, give:
+- A short name for what is measured
+- The current value and the target value, copied exactly as said including currency symbols, scale words and percent signs (for example "$1.2M", "45%%", "3,000 users"); leave a value empty when it wasn't mentioned
+- Status: on_track when the metric is expected to meet its target, at_risk when the participants are unsure it will, off_track when it is expected to miss
+- The participant who owns the metric, if one was named
+- The deadline as stated, if any
+
+Only report metrics with a target or a reported value; leave out numbers that aren't goals or measures of progress.
+
+Transcript:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "metrics": [
+    {
+      "metric_name": "Weekly active users",
+      "current_value": "12,000",
+      "target_value": "15,000",
+      "status": "on_track/at_risk/off_track",
+      "owner": "Participant name",
+      "deadline": "End of Q3"
+    }
+  ]
+}
+`+"`"+``, text)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return nil
+	}
+
+	var result struct {
+		Metrics []SuccessMetric `json:"metrics"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse success metrics JSON: %w", err)
+	}
+
+	var found []SuccessMetric
+	for _, metric := range result.Metrics {
+		metric.MetricName = strings.TrimSpace(metric.MetricName)
+		metric.CurrentValue = strings.TrimSpace(metric.CurrentValue)
+		metric.TargetValue = strings.TrimSpace(metric.TargetValue)
+		if metric.MetricName == "" || (metric.CurrentValue == "" && metric.TargetValue == "") {
+			continue
+		}
+		metric.Status = normalizeMetricStatus(metric.Status)
+		metric.Owner = strings.TrimSpace(metric.Owner)
+		metric.Deadline = strings.TrimSpace(metric.Deadline)
+		metric.Verified = false
+		found = append(found, metric)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	if groundingProvider, ok := a.llmProvider.(llm.GroundingCapableProvider); ok {
+		if err := a.verifySuccessMetrics(ctx, groundingProvider, found); err != nil {
+			logrus.Warnf("Agent %s: Failed to verify success metrics: %v", a.agentID, err)
+		}
+	}
+
+	a.dataMutex.Lock()
+	var offTrack []SuccessMetric
+	for _, metric := range found {
+		previous, ok := mergeSuccessMetric(&a.data.SuccessMetricsTracked, metric)
+		if metric.Status == MetricOffTrack && (!ok || previous.Status != MetricOffTrack) {
+			offTrack = append(offTrack, metric)
+		}
+	}
+	meetingID := a.data.MeetingID
+	a.dataMutex.Unlock()
+
+	for _, metric := range offTrack {
+		a.alertOffTrackMetric(meetingID, metric)
+	}
+	logrus.Infof("Agent %s: Tracked %d success metrics", a.agentID, len(found))
+	return nil
+}
+
+// mergeSuccessMetric adds a metric to the tracked ones, or updates the values of the tracked metric with the
+// same name from those the new mention gives. It returns the tracked metric before the update, if there was
+// one.
+func mergeSuccessMetric(tracked *[]SuccessMetric, metric SuccessMetric) (SuccessMetric, bool) {
+	for i, existing := range *tracked {
+		if !strings.EqualFold(existing.MetricName, metric.MetricName) {
+			continue
+		}
+		updated := existing
+		if metric.CurrentValue != "" {
+			updated.CurrentValue = metric.CurrentValue
+			updated.Verified = metric.Verified
+		}
+		if metric.TargetValue != "" {
+			updated.TargetValue = metric.TargetValue
+		}
+		if metric.Owner != "" {
+			updated.Owner = metric.Owner
+		}
+		if metric.Deadline != "" {
+			updated.Deadline = metric.Deadline
+		}
+		updated.Status = metric.Status
+		(*tracked)[i] = updated
+		return existing, true
+	}
+	*tracked = append(*tracked, metric)
+	return SuccessMetric{}, false
+}
+
+// verifySuccessMetrics asks a grounded model which reported current values are confirmed by search results,
+// setting Verified on those. Internal figures that can't be found publicly stay unverified.
+func (a *AnalystAgent) verifySuccessMetrics(ctx context.Context, provider llm.GroundingCapableProvider, metrics []SuccessMetric) error {
+	var lines []string
+	numbers := make(map[int]int)
+	for i, metric := range metrics {
+		if metric.CurrentValue == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s: %s", len(lines)+1, metric.MetricName, metric.CurrentValue))
+		numbers[len(lines)] = i
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Use google_search to check each of these values reported in a meeting. A value is verified only when search results confirm it; figures about private or internal matters that can't be found are not verified.
+
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "verified": [1, 3]
+}
+`+"`"+``, strings.Join(lines, "\n"))
+
+	response, err := a.callLLMWithGrounding(ctx, provider, prompt)
+	if err != nil {
+		return err
+	}
+	if response == nil || response.GroundingMetadata == nil || len(response.GroundingMetadata.GroundingChunks) == 0 {
+		return fmt.Errorf("no search results to verify success metrics against")
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response.Text)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in success metric verification response")
+	}
+
+	var result struct {
+		Verified []int `json:"verified"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse success metric verification JSON: %w", err)
+	}
+	for _, number := range result.Verified {
+		if i, ok := numbers[number]; ok {
+			metrics[i].Verified = true
+		}
+	}
+	return nil
+}
+
+// alertOffTrackMetric logs a metric that went off track at error level, which the Discord hook forwards to
+// the error webhook with the metric's details as embed fields
+func (a *AnalystAgent) alertOffTrackMetric(meetingID string, metric SuccessMetric) {
+	logrus.WithFields(logrus.Fields{
+		"agent_id":   a.agentID,
+		"meeting_id": meetingID,
+		"current":    metric.CurrentValue,
+		"target":     metric.TargetValue,
+		"owner":      metric.Owner,
+		"deadline":   metric.Deadline,
+	}).Errorf("📉 Success metric off track: %s", metric.MetricName)
+}
+
+// normalizeMetricStatus maps the LLM's metric status onto the supported set, defaulting to on_track so
+// metrics of unclear progress don't raise alerts
+func normalizeMetricStatus(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status = strings.NewReplacer(" ", "_", "-", "_").Replace(status); status {
+	case MetricOnTrack, MetricAtRisk, MetricOffTrack:
+		return status
+	default:
+		return MetricOnTrack
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// captureLogs records the entries logged through the standard logger until the test ends
+func captureLogs(t *testing.T) *logtest.Hook {
+	t.Helper()
+	hook := &logtest.Hook{}
+	previous := logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(hook)
+	t.Cleanup(func() { logrus.StandardLogger().ReplaceHooks(previous) })
+	return hook
+}
+
+func TestExtractSuccessMetricsNumericFormats(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider("```json\n" + `{"metrics": [
+		{"metric_name": "ARR", "current_value": " $1.2M ", "target_value": "$1.5M", "status": "On Track", "owner": "Dana", "deadline": "end of Q3"},
+		{"metric_name": "Activation rate", "current_value": "38.5%", "target_value": "45%", "status": "at-risk"},
+		{"metric_name": "Weekly active users", "current_value": "3,000 users", "target_value": "10k users", "status": "off_track"},
+		{"metric_name": "EMEA pipeline", "target_value": "€250k", "status": "unclear"},
+		{"metric_name": "Deploy frequency", "current_value": "2x per week"},
+		{"metric_name": "Team morale", "status": "off_track"}
+	]}` + "\n```")
+	say(analyst, 0, "Dana", "ARR is at 1.2 million and we want 1.5 by end of Q3")
+
+	if err := analyst.extractSuccessMetrics(context.Background()); err != nil {
+		t.Fatalf("extractSuccessMetrics() error = %v", err)
+	}
+
+	want := []SuccessMetric{
+		{MetricName: "ARR", CurrentValue: "$1.2M", TargetValue: "$1.5M", Status: MetricOnTrack, Owner: "Dana", Deadline: "end of Q3"},
+		{MetricName: "Activation rate", CurrentValue: "38.5%", TargetValue: "45%", Status: MetricAtRisk},
+		{MetricName: "Weekly active users", CurrentValue: "3,000 users", TargetValue: "10k users", Status: MetricOffTrack},
+		{MetricName: "EMEA pipeline", TargetValue: "€250k", Status: MetricOnTrack},
+		{MetricName: "Deploy frequency", CurrentValue: "2x per week", Status: MetricOnTrack},
+	}
+	metrics := analyst.GetAnalysis().SuccessMetricsTracked
+	if len(metrics) != len(want) {
+		t.Fatalf("metrics = %+v, want %d without the one lacking values", metrics, len(want))
+	}
+	for i := range want {
+		if metrics[i] != want[i] {
+			t.Errorf("metric %d = %+v, want %+v", i, metrics[i], want[i])
+		}
+	}
+}
+
+func TestOffTrackMetricAlertsOnce(t *testing.T) {
+	logs := captureLogs(t)
+	analyst := newTestAnalyst(t)
+	offTrack := "```json\n" + `{"metrics": [{"metric_name": "Churn", "current_value": "6%", "target_value": "under 4%", "status": "off track"}]}` + "\n```"
+	// The second mention only changes the current value, so the metric stays off track
+	stillOffTrack := "```json\n" + `{"metrics": [{"metric_name": "churn", "current_value": "7%", "status": "off_track"}]}` + "\n```"
+	analyst.llmProvider = llm.NewMockLLMProvider(offTrack, stillOffTrack)
+	say(analyst, 0, "Dana", "Churn is at six percent, the goal is under four")
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := analyst.extractSuccessMetrics(ctx); err != nil {
+			t.Fatalf("extractSuccessMetrics() error = %v", err)
+		}
+	}
+
+	alerts := 0
+	for _, entry := range logs.AllEntries() {
+		if entry.Level == logrus.ErrorLevel && entry.Message == "📉 Success metric off track: Churn" {
+			alerts++
+		}
+	}
+	if alerts != 1 {
+		t.Errorf("logged %d off-track alerts, want 1", alerts)
+	}
+
+	metrics := analyst.GetAnalysis().SuccessMetricsTracked
+	if len(metrics) != 1 || metrics[0].CurrentValue != "7%" || metrics[0].TargetValue != "under 4%" {
+		t.Errorf("metrics = %+v, want the current value updated and the target kept", metrics)
+	}
+}
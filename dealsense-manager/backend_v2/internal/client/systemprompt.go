@@ -0,0 +1,42 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SetSystemPromptPrefix sets the operator's instructions placed before every prompt, ahead of the agent's
+// own SystemPromptPrefix and any custom prompt
+func (a *AnalystAgent) SetSystemPromptPrefix(prefix string) {
+	a.systemPromptPrefix = strings.TrimSpace(prefix)
+}
+
+// systemPrefix returns the operator and agent system prompt prefixes followed by a blank line, or "" when
+// neither is set. A prefix that fails isSafeInstruction is dropped.
+func (a *AnalystAgent) systemPrefix() string {
+	var parts []string
+	for _, prefix := range []string{a.systemPromptPrefix, strings.TrimSpace(a.config.SystemPromptPrefix)} {
+		if prefix == "" {
+			continue
+		}
+		if !a.isSafeInstruction(prefix) {
+			logrus.Errorf("Agent %s: Ignoring system prompt prefix with a potentially harmful pattern", a.agentID)
+			continue
+		}
+		parts = append(parts, prefix)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n") + "\n\n"
+}
+
+// withSystemPrefix puts the system prompt prefix before prompt, unless prompt already starts with it
+func (a *AnalystAgent) withSystemPrefix(prompt string) string {
+	prefix := a.systemPrefix()
+	if prefix == "" || strings.HasPrefix(prompt, prefix) {
+		return prompt
+	}
+	return prefix + prompt
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// teamsGraphBaseURL is the Microsoft Graph API the Teams transcript stream is read from
+var teamsGraphBaseURL = "https://graph.microsoft.com/v1.0"
+
+const (
+	// teamsSilenceGap is the pause between words that ends an utterance
+	teamsSilenceGap = 500 * time.Millisecond
+	// teamsReconnectMin and teamsReconnectMax bound the backoff between stream reconnection attempts
+	teamsReconnectMin = time.Second
+	teamsReconnectMax = 30 * time.Second
+)
+
+// TokenRefresher returns a new Microsoft Graph access token when the current one has expired
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// NewTeamsTokenRefresher creates a TokenRefresher that requests Graph tokens for an Azure AD app with the
+// client credentials flow
+func NewTeamsTokenRefresher(tenantID, clientID, clientSecret string) TokenRefresher {
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     "https://login.microsoftonline.com/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token",
+		Scopes:       []string{"https://graph.microsoft.com/.default"},
+	}
+	return func(ctx context.Context) (string, error) {
+		token, err := config.Token(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get Teams access token: %w", err)
+		}
+		return token.AccessToken, nil
+	}
+}
+
+// errTeamsUnauthorized is returned when Graph rejects the access token
+var errTeamsUnauthorized = errors.New("teams access token rejected")
+
+// teamsTranscriptEvent is one server-sent event of the Teams transcript stream
+type teamsTranscriptEvent struct {
+	Transcript struct {
+		Speaker struct {
+			DisplayName string `json:"displayName"`
+		} `json:"speaker"`
+		Words []teamsWord `json:"words"`
+	} `json:"transcript"`
+	receivedAt time.Time
+}
+
+// teamsWord is a recognized word with its timing in the call, when Graph provides it
+type teamsWord struct {
+	Word          string    `json:"word"`
+	StartDateTime time.Time `json:"startDateTime"`
+	EndDateTime   time.Time `json:"endDateTime"`
+}
+
+// teamsUtterance collects consecutive words from one speaker
+type teamsUtterance struct {
+	speaker string
+	words   []string
+	start   time.Time
+	lastEnd time.Time
+}
+
+// SetTeamsTokenRefresher sets the callback used to replace an expired Teams access token
+func (a *AnalystAgent) SetTeamsTokenRefresher(refresher TokenRefresher) {
+	a.teamsTokens = refresher
+}
+
+// StartTeamsTranscriptStream reads the live transcript of a Teams call from the Graph API's server-sent
+// events, batching words into utterances that end when the speaker changes or pauses for more than 500 ms.
+// An empty or rejected access token is replaced using the TokenRefresher, and dropped connections are
+// reopened with backoff. It blocks until ctx is cancelled or the stream fails permanently.
+func (a *AnalystAgent) StartTeamsTranscriptStream(ctx context.Context, callID, accessToken string) error {
+	events := make(chan teamsTranscriptEvent)
+	streamErr := make(chan error, 1)
+	go func() {
+		defer close(events)
+		streamErr <- a.readTeamsStream(ctx, callID, accessToken, events)
+	}()
+
+	logrus.Infof("Agent %s: Streaming Teams transcript for call %s", a.agentID, callID)
+
+	var pending *teamsUtterance
+	flush := func() {
+		if pending != nil {
+			a.ProcessUtterance([]map[string]interface{}{{
+				"speaker":   pending.speaker,
+				"text":      strings.Join(pending.words, " "),
+				"timestamp": float64(pending.start.Unix()),
+			}})
+			pending = nil
+		}
+	}
+
+	// Ends the pending utterance when no words arrive for the silence gap
+	silence := time.NewTimer(teamsSilenceGap)
+	silence.Stop()
+	defer silence.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				flush()
+				return <-streamErr
+			}
+
+			speaker := strings.TrimSpace(event.Transcript.Speaker.DisplayName)
+			if speaker == "" {
+				speaker = "Participant"
+			}
+			for _, word := range event.Transcript.Words {
+				text := strings.TrimSpace(word.Word)
+				if text == "" {
+					continue
+				}
+				start, end := word.StartDateTime, word.EndDateTime
+				if start.IsZero() {
+					start = event.receivedAt
+				}
+				if end.Before(start) {
+					end = start
+				}
+
+				if pending != nil && (pending.speaker != speaker || start.Sub(pending.lastEnd) > teamsSilenceGap) {
+					flush()
+				}
+				if pending == nil {
+					pending = &teamsUtterance{speaker: speaker, start: start}
+				}
+				pending.words = append(pending.words, text)
+				pending.lastEnd = end
+			}
+			silence.Reset(teamsSilenceGap)
+		case <-silence.C:
+			flush()
+		}
+	}
+}
+
+// readTeamsStream connects to the call's transcript stream and sends its events until ctx is cancelled,
+// reconnecting when the connection drops. It returns nil once ctx is cancelled.
+func (a *AnalystAgent) readTeamsStream(ctx context.Context, callID, accessToken string, events chan<- teamsTranscriptEvent) error {
+	backoff := teamsReconnectMin
+	refreshed := false
+	for {
+		var err error
+		if accessToken == "" {
+			if accessToken, err = a.refreshTeamsToken(ctx); err != nil {
+				return err
+			}
+			refreshed = true
+		}
+
+		received, err := a.readTeamsEvents(ctx, callID, accessToken, events)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if received {
+			backoff = teamsReconnectMin
+			refreshed = false
+		}
+
+		var status *teamsStatusError
+		switch {
+		case errors.Is(err, errTeamsUnauthorized) && refreshed:
+			return fmt.Errorf("%w after refreshing it", errTeamsUnauthorized)
+		case errors.Is(err, errTeamsUnauthorized):
+			logrus.Infof("Agent %s: Teams access token expired, refreshing", a.agentID)
+			accessToken = ""
+			continue
+		case errors.As(err, &status):
+			return err
+		case err != nil:
+			logrus.Warnf("Agent %s: Teams transcript stream failed, reconnecting in %s: %v", a.agentID, backoff, err)
+		default:
+			logrus.Infof("Agent %s: Teams transcript stream closed, reconnecting in %s", a.agentID, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, teamsReconnectMax)
+	}
+}
+
+// teamsStatusError is returned for error responses that reconnecting won't fix
+type teamsStatusError struct {
+	StatusCode int
+}
+
+func (e *teamsStatusError) Error() string {
+	return fmt.Sprintf("teams transcript stream returned status %d", e.StatusCode)
+}
+
+// refreshTeamsToken gets a new access token from the TokenRefresher
+func (a *AnalystAgent) refreshTeamsToken(ctx context.Context) (string, error) {
+	if a.teamsTokens == nil {
+		return "", fmt.Errorf("no token refresher is set to replace the Teams access token")
+	}
+	token, err := a.teamsTokens(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("token refresher returned an empty Teams access token")
+	}
+	return token, nil
+}
+
+// readTeamsEvents reads server-sent events from one connection to the transcript stream until it closes,
+// reporting whether any events were received
+func (a *AnalystAgent) readTeamsEvents(ctx context.Context, callID, accessToken string, events chan<- teamsTranscriptEvent) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, teamsGraphBaseURL+"/communications/calls/"+url.PathEscape(callID)+"/transcripts", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "text/event-stream")
+
+	// No client timeout, as the stream stays open for the whole call
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return false, errTeamsUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return false, fmt.Errorf("teams transcript stream returned status %d", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return false, &teamsStatusError{StatusCode: resp.StatusCode}
+	}
+
+	received := false
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+			continue
+		}
+		if line != "" || len(data) == 0 {
+			// Comments, event names and ids don't affect the transcript
+			continue
+		}
+
+		var event teamsTranscriptEvent
+		err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event)
+		data = nil
+		if err != nil {
+			logrus.Warnf("Agent %s: Skipping malformed Teams transcript event: %v", a.agentID, err)
+			continue
+		}
+		event.receivedAt = time.Now()
+
+		select {
+		case events <- event:
+			received = true
+		case <-ctx.Done():
+			return received, nil
+		}
+	}
+	return received, scanner.Err()
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// teamsEvent encodes a server-sent transcript event of words spoken by one speaker
+func teamsEvent(speaker string, words ...teamsWord) string {
+	var event teamsTranscriptEvent
+	event.Transcript.Speaker.DisplayName = speaker
+	event.Transcript.Words = words
+	raw, _ := json.Marshal(event)
+	return "data: " + string(raw) + "\n\n"
+}
+
+// wordAt returns a word spoken from start to end milliseconds into the meeting
+func wordAt(word string, start, end int) teamsWord {
+	return teamsWord{
+		Word:          word,
+		StartDateTime: testMeetingStart.Add(time.Duration(start) * time.Millisecond),
+		EndDateTime:   testMeetingStart.Add(time.Duration(end) * time.Millisecond),
+	}
+}
+
+// useTeamsServer points the Graph API at a mock server for the duration of the test
+func useTeamsServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	previous := teamsGraphBaseURL
+	teamsGraphBaseURL = server.URL
+	t.Cleanup(func() {
+		teamsGraphBaseURL = previous
+		server.Close()
+	})
+}
+
+func TestTeamsStreamBatchesWordsIntoUtterances(t *testing.T) {
+	var requests atomic.Int32
+	useTeamsServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/communications/calls/call-1/transcripts" {
+			http.NotFound(w, r)
+			return
+		}
+		// The first token has expired, forcing a refresh
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, teamsEvent("Alice", wordAt("hello", 0, 300), wordAt("there", 300, 600)))
+		fmt.Fprint(w, "data: {not json\n\n")
+		fmt.Fprint(w, teamsEvent("Alice", wordAt("everyone", 700, 1000)))
+		fmt.Fprint(w, teamsEvent("Bob", wordAt("thanks", 1100, 1400), wordAt("Alice", 1400, 1700)))
+		fmt.Fprint(w, teamsEvent("Bob", wordAt("see", 3000, 3200), wordAt("you", 3200, 3400)))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	analyst := newTestAnalyst(t)
+	analyst.SetTeamsTokenRefresher(func(ctx context.Context) (string, error) { return "fresh", nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- analyst.StartTeamsTranscriptStream(ctx, "call-1", "expired") }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(analyst.GetAnalysis().Transcript) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("StartTeamsTranscriptStream() = %v", err)
+	}
+
+	want := []TranscriptEntry{
+		entryAt(0, "Alice", "hello there everyone"),
+		entryAt(1, "Bob", "thanks Alice"),
+		entryAt(3, "Bob", "see you"),
+	}
+	transcript := analyst.GetAnalysis().Transcript
+	if len(transcript) != len(want) {
+		t.Fatalf("transcript = %+v, want %d utterances", transcript, len(want))
+	}
+	for i, entry := range transcript {
+		if entry.Speaker != want[i].Speaker || entry.Text != want[i].Text || !entry.Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("utterance %d = %s at %s: %q, want %s at %s: %q", i, entry.Speaker, entry.Timestamp, entry.Text, want[i].Speaker, want[i].Timestamp, want[i].Text)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Errorf("%d requests, want one rejected and one after refreshing the token", requests.Load())
+	}
+}
+
+func TestTeamsStreamFailsOnPermanentErrors(t *testing.T) {
+	useTeamsServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	analyst := newTestAnalyst(t)
+	err := analyst.StartTeamsTranscriptStream(context.Background(), "call-1", "token")
+	var status *teamsStatusError
+	if !errors.As(err, &status) || status.StatusCode != http.StatusForbidden {
+		t.Errorf("StartTeamsTranscriptStream() = %v, want a status 403 error", err)
+	}
+}
+
+func TestTeamsStreamFailsWhenRefreshedTokenIsRejected(t *testing.T) {
+	useTeamsServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	analyst := newTestAnalyst(t)
+	analyst.SetTeamsTokenRefresher(func(ctx context.Context) (string, error) { return "also-bad", nil })
+	err := analyst.StartTeamsTranscriptStream(context.Background(), "call-1", "")
+	if !errors.Is(err, errTeamsUnauthorized) {
+		t.Errorf("StartTeamsTranscriptStream() = %v, want errTeamsUnauthorized", err)
+	}
+}
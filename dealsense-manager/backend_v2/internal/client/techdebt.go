@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// techDebtTranscript is the number of recent transcript entries technical debt is extracted from
+const techDebtTranscript = 50
+
+// TechDebtItem is technical debt an engineer mentioned, for follow-up in a retrospective
+type TechDebtItem struct {
+	Description string    `json:"description"`
+	Component   string    `json:"component,omitempty"` // System or module the debt is in, inferred from context
+	Severity    string    `json:"severity"`            // low, medium, high
+	MentionedBy string    `json:"mentioned_by"`
+	ProposedFix string    `json:"proposed_fix,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// techDebtSignals matches the phrases engineers use when talking about technical debt. Transcripts without
+// any are not sent to the LLM.
+var techDebtSignals = regexp.MustCompile(`(?i)\b(?:hack(?:s|y|ed)?|workarounds?|work around|refactor(?:s|ed|ing)?|tech(?:nical)? debt|brittle|fragile|legacy|kludge|band-?aid|duct tape|quick fix|spaghetti|rewrite|deprecated|flaky|cut corners)\b`)
+
+// Phrases that decide the severity of a debt item the LLM didn't rate
+var (
+	highTechDebtSignals = regexp.MustCompile(`(?i)\b(?:outages?|incidents?|data loss|security|vulnerab\w*|breaks? (?:in )?prod\w*|keeps? (?:breaking|failing)|blocking|blocker|on fire|every release)\b`)
+	lowTechDebtSignals  = regexp.MustCompile(`(?i)\b(?:eventually|someday|some day|at some point|nice to have|cosmetic|clean ?up|cleanup|minor|when we have time)\b`)
+)
+
+// extractTechnicalDebt records technical debt mentioned in the recent transcript, such as hacks,
+// workarounds and components that need refactoring. Items accumulate across analysis runs.
+func (a *AnalystAgent) extractTechnicalDebt(ctx context.Context) error {
+	transcript := a.getRecentTranscript(techDebtTranscript)
+	if len(transcript) == 0 {
+		return nil
+	}
+
+	mentioned := false
+	for _, entry := range transcript {
+		if techDebtSignals.MatchString(entry.Text) {
+			mentioned = true
+			break
+		}
+	}
+	if !mentioned {
+		return nil
+	}
+
+	logrus.Infof("Agent %s: Extracting technical debt from %d transcript entries", a.agentID, len(transcript))
+
+	var result struct {
+		TechnicalDebt []struct {
+			Statement int `json:"statement"`
+			TechDebtItem
+		} `json:"technical_debt"`
+	}
+	request := indexedStatementsRequest{
+		task: `Identify technical debt the participants of this engineering meeting mentioned: hacks, workarounds, code or systems that need refactoring or rewriting, brittle or legacy components, and shortcuts taken to ship.
+
+Listen for phrases such as "this is a hack", "workaround", "we need to refactor", "technical debt", "brittle", "legacy" and "quick fix", but only report debt the speaker actually describes, not passing uses of these words.
+
+For each item, give:
+- The index of the statement that mentions it
+- A one-sentence description of the debt
+- The component, service or module it is in, inferred from the discussion
+- Severity: high when it causes incidents, blocks work or is a security risk; medium when it slows development or makes changes risky; low when it is cosmetic or can wait
+- The fix proposed in the meeting, if any`,
+		sections: []indexedSection{transcriptSection(transcript)},
+		format: `{
+  "technical_debt": [
+    {
+      "statement": 12,
+      "description": "What the debt is",
+      "component": "Component name",
+      "severity": "low/medium/high",
+      "proposed_fix": "Fix proposed in the meeting, or empty"
+    }
+  ]
+}`,
+		result: "technical debt",
+	}
+	if ok, err := a.askAboutStatements(ctx, request, &result); !ok || err != nil {
+		return err
+	}
+
+	var found []TechDebtItem
+	for _, candidate := range result.TechnicalDebt {
+		item := candidate.TechDebtItem
+		item.Description = strings.TrimSpace(item.Description)
+		statement, ok := statementAt(transcript, candidate.Statement)
+		if !ok || item.Description == "" {
+			continue
+		}
+		item.Component = strings.TrimSpace(item.Component)
+		item.ProposedFix = strings.TrimSpace(item.ProposedFix)
+		item.Severity = techDebtSeverity(item.Severity, statement.Text)
+		item.MentionedBy = statement.Speaker
+		item.Timestamp = statement.Timestamp
+		found = append(found, item)
+	}
+
+	a.dataMutex.Lock()
+	seen := make(map[string]bool)
+	for _, item := range a.data.TechnicalDebtMentions {
+		seen[techDebtKey(item)] = true
+	}
+	added := 0
+	for _, item := range found {
+		if key := techDebtKey(item); !seen[key] {
+			seen[key] = true
+			a.data.TechnicalDebtMentions = append(a.data.TechnicalDebtMentions, item)
+			added++
+		}
+	}
+	a.dataMutex.Unlock()
+
+	if added > 0 {
+		logrus.Infof("Agent %s: Found %d new technical debt items", a.agentID, added)
+	}
+	return nil
+}
+
+// techDebtSeverity returns the LLM's severity when it is low, medium or high, and otherwise rates the
+// statement from its wording: high when it mentions incidents, security or blocked work, low when the
+// debt can wait, and medium otherwise
+func techDebtSeverity(severity, statement string) string {
+	switch severity = strings.ToLower(strings.TrimSpace(severity)); severity {
+	case "low", "medium", "high":
+		return severity
+	}
+
+	switch {
+	case highTechDebtSignals.MatchString(statement):
+		return "high"
+	case lowTechDebtSignals.MatchString(statement):
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+// techDebtKey identifies the statement and component an item is for, so debt is recorded once however
+// often its statement is analyzed
+func techDebtKey(item TechDebtItem) string {
+	return fmt.Sprintf("%s|%d|%s", item.MentionedBy, item.Timestamp.UnixNano(), strings.ToLower(item.Component))
+}
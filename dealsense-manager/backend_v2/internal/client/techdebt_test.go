@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+func TestTechDebtSeverity(t *testing.T) {
+	cases := []struct {
+		severity, statement, want string
+	}{
+		{"HIGH", "We should clean up the styles someday", "high"},
+		{" low ", "This hack caused two outages", "low"},
+		{"", "The auth hack caused an outage last week", "high"},
+		{"critical", "The legacy importer is a security risk", "high"},
+		{"", "The retry workaround keeps breaking in prod", "high"},
+		{"", "We should refactor the settings page someday", "low"},
+		{"", "The CSS is a bit of a kludge, purely cosmetic", "low"},
+		{"", "The billing module needs a refactor", "medium"},
+	}
+	for _, c := range cases {
+		if got := techDebtSeverity(c.severity, c.statement); got != c.want {
+			t.Errorf("techDebtSeverity(%q, %q) = %q, want %q", c.severity, c.statement, got, c.want)
+		}
+	}
+}
+
+func TestExtractTechnicalDebt(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	response := "```json\n" + `{"technical_debt": [
+		{"statement": 0, "description": "Auth token refresh is a hack", "component": "auth", "severity": "", "proposed_fix": "Move to the SDK refresh"},
+		{"statement": 1, "description": "Billing needs a refactor", "component": "billing", "severity": "medium"},
+		{"statement": 7, "description": "Out of range", "severity": "low"}
+	]}` + "\n```"
+	mock := llm.NewMockLLMProvider(response, response)
+	analyst.llmProvider = mock
+	say(analyst, 0, "Alice", "The auth refresh is a hack and caused an outage")
+	say(analyst, 10, "Bob", "Billing also needs a refactor at some point")
+
+	ctx := context.Background()
+	if err := analyst.extractTechnicalDebt(ctx); err != nil {
+		t.Fatalf("extractTechnicalDebt() error = %v", err)
+	}
+	// Debt already recorded for a statement isn't added again
+	if err := analyst.extractTechnicalDebt(ctx); err != nil {
+		t.Fatalf("second extractTechnicalDebt() error = %v", err)
+	}
+
+	items := analyst.GetAnalysis().TechnicalDebtMentions
+	if len(items) != 2 {
+		t.Fatalf("TechnicalDebtMentions = %+v, want 2 items", items)
+	}
+	if items[0].Severity != "high" || items[0].MentionedBy != "Alice" || items[0].ProposedFix != "Move to the SDK refresh" {
+		t.Errorf("auth item = %+v", items[0])
+	}
+	if items[1].Severity != "medium" || items[1].MentionedBy != "Bob" {
+		t.Errorf("billing item = %+v", items[1])
+	}
+}
+
+func TestExtractTechnicalDebtNeedsSignals(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.llmProvider = llm.NewMockLLMProvider()
+	say(analyst, 0, "Alice", "The launch went smoothly this week")
+
+	if err := analyst.extractTechnicalDebt(context.Background()); err != nil {
+		t.Fatalf("extractTechnicalDebt() error = %v", err)
+	}
+	if items := analyst.GetAnalysis().TechnicalDebtMentions; len(items) != 0 {
+		t.Errorf("TechnicalDebtMentions = %+v, want none", items)
+	}
+}
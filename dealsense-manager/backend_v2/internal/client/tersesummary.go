@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// terseSummaryLimit is the most characters of the terse summary, which must fit a push notification
+	terseSummaryLimit = 140
+	// tweetSummaryLimit is the most characters of the Twitter/X-style summary
+	tweetSummaryLimit = 280
+)
+
+// CharLimitExceededError is returned when the LLM writes a summary longer than its character limit
+type CharLimitExceededError struct {
+	Field  string
+	Length int
+	Limit  int
+}
+
+func (e *CharLimitExceededError) Error() string {
+	return fmt.Sprintf("%s has %d characters, exceeding the limit of %d", e.Field, e.Length, e.Limit)
+}
+
+// terseSummariesStep is the analysis step writing the notification-sized summaries, run after the summary
+func (a *AnalystAgent) terseSummariesStep() analysisStep {
+	return analysisStep{name: "terse_summaries", description: "generate terse summaries", run: a.generateTerseSummaries}
+}
+
+// generateTerseSummaries writes a one-sentence summary of the decisions for push notifications and a
+// Twitter/X-style post from the full summary. Summaries over their character limit aren't stored and a
+// CharLimitExceededError is returned.
+func (a *AnalystAgent) generateTerseSummaries(ctx context.Context) error {
+	a.dataMutex.RLock()
+	summary := a.data.Summary
+	a.dataMutex.RUnlock()
+
+	if summary == "" {
+		return nil
+	}
+
+	prompt := a.languagePrefix() + fmt.Sprintf(`Write two ultra-brief summaries of this meeting:
+
+1. In one sentence of 20–30 words, describe what was decided in this meeting. It must not exceed %d characters.
+2. In exactly 280 characters or fewer, write a Twitter/X-style post summarizing this meeting.
+
+Meeting summary:
+%s
+
+Provide your response in the following JSON format within a code block:
+`+"`"+`json
+{
+  "terse_summary": "One sentence on what was decided.",
+  "tweet_summary": "Post summarizing the meeting."
+}
+`+"`"+``, terseSummaryLimit, summary)
+
+	response, err := a.callLLM(ctx, prompt)
+	if err != nil {
+		return err
+	}
+
+	jsonData := a.extractJSONFromResponse(ctx, response)
+	if jsonData == "" {
+		return fmt.Errorf("no JSON in terse summaries response")
+	}
+
+	var result struct {
+		TerseSummary string `json:"terse_summary"`
+		TweetSummary string `json:"tweet_summary"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return fmt.Errorf("failed to parse terse summaries JSON: %w", err)
+	}
+
+	terse, terseErr := checkCharLimit("terse summary", result.TerseSummary, terseSummaryLimit)
+	tweet, tweetErr := checkCharLimit("tweet summary", result.TweetSummary, tweetSummaryLimit)
+
+	a.dataMutex.Lock()
+	if terseErr == nil && terse != "" {
+		a.data.TerseSummary = terse
+	}
+	if tweetErr == nil && tweet != "" {
+		a.data.TweetSummary = tweet
+	}
+	a.dataMutex.Unlock()
+
+	if terseErr != nil {
+		return terseErr
+	}
+	if tweetErr != nil {
+		return tweetErr
+	}
+	logrus.Infof("Agent %s: Generated terse summaries (%d and %d characters)", a.agentID,
+		utf8.RuneCountInString(terse), utf8.RuneCountInString(tweet))
+	return nil
+}
+
+// checkCharLimit trims text and returns it, or a CharLimitExceededError when it has more characters than
+// the limit
+func checkCharLimit(field, text string, limit int) (string, error) {
+	text = strings.TrimSpace(text)
+	if length := utf8.RuneCountInString(text); length > limit {
+		return "", &CharLimitExceededError{Field: field, Length: length, Limit: limit}
+	}
+	return text, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client/llm"
+)
+
+// terseSummariesResponse returns an LLM response with the given terse and tweet summaries
+func terseSummariesResponse(terse, tweet string) string {
+	return "```json\n{\"terse_summary\": \"" + terse + "\", \"tweet_summary\": \"" + tweet + "\"}\n```"
+}
+
+func TestGenerateTerseSummaries(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Summary = "The team agreed to ship the beta on Friday."
+	analyst.llmProvider = llm.NewMockLLMProvider(terseSummariesResponse(" Beta ships Friday. ", "Beta ships Friday after sign-off."))
+
+	if err := analyst.generateTerseSummaries(context.Background()); err != nil {
+		t.Fatalf("generateTerseSummaries() error = %v", err)
+	}
+	analysis := analyst.GetAnalysis()
+	if analysis.TerseSummary != "Beta ships Friday." || analysis.TweetSummary != "Beta ships Friday after sign-off." {
+		t.Errorf("summaries = %q, %q", analysis.TerseSummary, analysis.TweetSummary)
+	}
+}
+
+func TestGenerateTerseSummariesCharLimit(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Summary = "The team agreed to ship the beta on Friday."
+	// Counted in characters, so 141 accented letters are over the limit though 140 would fit
+	analyst.llmProvider = llm.NewMockLLMProvider(terseSummariesResponse(strings.Repeat("é", 141), strings.Repeat("é", 280)))
+
+	err := analyst.generateTerseSummaries(context.Background())
+	var limitErr *CharLimitExceededError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("generateTerseSummaries() error = %v, want CharLimitExceededError", err)
+	}
+	if limitErr.Field != "terse summary" || limitErr.Length != 141 || limitErr.Limit != terseSummaryLimit {
+		t.Errorf("error = %+v", limitErr)
+	}
+
+	// The over-limit summary isn't stored; the one within its limit is
+	analysis := analyst.GetAnalysis()
+	if analysis.TerseSummary != "" || analysis.TweetSummary != strings.Repeat("é", 280) {
+		t.Errorf("summaries = %q, %q", analysis.TerseSummary, analysis.TweetSummary)
+	}
+}
+
+func TestCheckCharLimit(t *testing.T) {
+	if text, err := checkCharLimit("terse summary", "  Ship it.  ", 10); err != nil || text != "Ship it." {
+		t.Errorf("checkCharLimit() = %q, %v", text, err)
+	}
+	if _, err := checkCharLimit("terse summary", "Ship it now", 10); err == nil {
+		t.Error("checkCharLimit() over the limit returned no error")
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"sort"
+	"time"
+)
+
+// GetTranscriptSlice returns the transcript entries with timestamps in [from, to). Entries are appended as
+// they are spoken, so the transcript is searched as if sorted by timestamp.
+func (a *AnalystAgent) GetTranscriptSlice(from, to time.Time) []TranscriptEntry {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	transcript := a.data.Transcript
+	start := sort.Search(len(transcript), func(i int) bool {
+		return !transcript[i].Timestamp.Before(from)
+	})
+	end := sort.Search(len(transcript), func(i int) bool {
+		return !transcript[i].Timestamp.Before(to)
+	})
+	if start >= end {
+		return []TranscriptEntry{}
+	}
+
+	result := make([]TranscriptEntry, end-start)
+	copy(result, transcript[start:end])
+	return result
+}
+
+// GetTranscriptPage returns up to limit transcript entries starting at offset, along with the total
+// number of entries
+func (a *AnalystAgent) GetTranscriptPage(offset, limit int) ([]TranscriptEntry, int) {
+	a.dataMutex.RLock()
+	defer a.dataMutex.RUnlock()
+
+	total := len(a.data.Transcript)
+	if offset < 0 || limit <= 0 || offset >= total {
+		return []TranscriptEntry{}, total
+	}
+
+	end := min(offset+limit, total)
+	result := make([]TranscriptEntry, end-offset)
+	copy(result, a.data.Transcript[offset:end])
+	return result, total
+}
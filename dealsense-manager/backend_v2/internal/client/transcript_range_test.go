@@ -0,0 +1,73 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTranscriptSlice(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Transcript = []TranscriptEntry{
+		entryAt(0, "Alice", "first"),
+		entryAt(60, "Bob", "second"),
+		entryAt(60, "Carol", "third"),
+		entryAt(120, "Alice", "fourth"),
+	}
+	at := func(seconds int) time.Time { return testMeetingStart.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"whole meeting", at(0), at(121), []string{"first", "second", "third", "fourth"}},
+		{"from is inclusive", at(60), at(61), []string{"second", "third"}},
+		{"to is exclusive", at(0), at(60), []string{"first"}},
+		{"empty range", at(60), at(60), nil},
+		{"reversed range", at(120), at(0), nil},
+		{"before the meeting", at(-60), at(-1), nil},
+		{"after the meeting", at(121), at(600), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slice := analyst.GetTranscriptSlice(tt.from, tt.to)
+			if slice == nil {
+				t.Fatal("slice is nil, want an empty slice")
+			}
+			var texts []string
+			for _, entry := range slice {
+				texts = append(texts, entry.Text)
+			}
+			if len(texts) != len(tt.want) {
+				t.Fatalf("slice = %q, want %q", texts, tt.want)
+			}
+			for i := range texts {
+				if texts[i] != tt.want[i] {
+					t.Errorf("slice = %q, want %q", texts, tt.want)
+				}
+			}
+		})
+	}
+
+	slice := analyst.GetTranscriptSlice(at(0), at(1))
+	slice[0].Text = "changed"
+	if analyst.data.Transcript[0].Text != "first" {
+		t.Error("slice shares the transcript's entries")
+	}
+}
+
+func TestGetTranscriptPage(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.Transcript = []TranscriptEntry{entryAt(0, "A", "1"), entryAt(1, "B", "2"), entryAt(2, "C", "3")}
+
+	page, total := analyst.GetTranscriptPage(1, 5)
+	if total != 3 || len(page) != 2 || page[0].Text != "2" {
+		t.Errorf("page = %+v, total = %d", page, total)
+	}
+	if page, _ := analyst.GetTranscriptPage(3, 5); len(page) != 0 {
+		t.Errorf("page past the end = %+v", page)
+	}
+	if page, _ := analyst.GetTranscriptPage(0, 0); len(page) != 0 {
+		t.Errorf("page without a limit = %+v", page)
+	}
+}
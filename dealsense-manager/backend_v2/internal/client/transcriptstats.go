@@ -0,0 +1,70 @@
+package client
+
+import "sort"
+
+// TranscriptStats describes the vocabulary, pacing and turn lengths of the whole transcript
+type TranscriptStats struct {
+	UniqueWordCount             int     `json:"unique_word_count"`
+	LexicalDiversity            float64 `json:"lexical_diversity"`              // Unique words divided by total words
+	AveragePauseDurationSeconds float64 `json:"average_pause_duration_seconds"` // Between one utterance ending and the next starting
+	LongestMonologueSpeaker     string  `json:"longest_monologue_speaker"`
+	LongestMonologueWords       int     `json:"longest_monologue_words"` // Words in the longest run of utterances by one speaker
+	ShortestUtteranceWords      int     `json:"shortest_utterance_words"`
+	MedianUtteranceLengthWords  float64 `json:"median_utterance_length_words"`
+}
+
+// ComputeTranscriptStatistics computes the statistics of a transcript in a single pass, returning nil when
+// it has no words. Consecutive utterances by the same speaker form one monologue. Pauses are estimated like
+// response latencies, as entries only record when speech started; utterances without words are left out of
+// the utterance lengths.
+func ComputeTranscriptStatistics(entries []TranscriptEntry) *TranscriptStats {
+	stats := &TranscriptStats{}
+	vocabulary := make(map[string]bool)
+	var lengths []int
+	totalWords, pauses := 0, 0
+	totalPause := 0.0
+	monologueSpeaker, monologueWords := "", 0
+
+	for i, entry := range entries {
+		words := languageWords(entry.Text)
+		for _, word := range words {
+			vocabulary[word] = true
+		}
+		totalWords += len(words)
+		if len(words) > 0 {
+			lengths = append(lengths, len(words))
+		}
+
+		if i > 0 {
+			totalPause += responseLatency(entries[i-1], entry)
+			pauses++
+		}
+
+		if i == 0 || entry.Speaker != monologueSpeaker {
+			monologueSpeaker, monologueWords = entry.Speaker, 0
+		}
+		monologueWords += len(words)
+		if monologueWords > stats.LongestMonologueWords {
+			stats.LongestMonologueSpeaker, stats.LongestMonologueWords = monologueSpeaker, monologueWords
+		}
+	}
+
+	if totalWords == 0 {
+		return nil
+	}
+
+	stats.UniqueWordCount = len(vocabulary)
+	stats.LexicalDiversity = float64(len(vocabulary)) / float64(totalWords)
+	if pauses > 0 {
+		stats.AveragePauseDurationSeconds = totalPause / float64(pauses)
+	}
+
+	sort.Ints(lengths)
+	stats.ShortestUtteranceWords = lengths[0]
+	if middle := len(lengths) / 2; len(lengths)%2 == 1 {
+		stats.MedianUtteranceLengthWords = float64(lengths[middle])
+	} else {
+		stats.MedianUtteranceLengthWords = float64(lengths[middle-1]+lengths[middle]) / 2
+	}
+	return stats
+}
@@ -0,0 +1,42 @@
+package client
+
+import "testing"
+
+func TestComputeTranscriptStatistics(t *testing.T) {
+	transcript := []TranscriptEntry{
+		entryAt(0, "Alice", "We ship the release on Friday"),
+		entryAt(5, "Alice", "The notes are ready too"),
+		entryAt(20, "Bob", "Great news"),
+		entryAt(30, "Carol", "..."),
+		entryAt(40, "Bob", "Ship it"),
+	}
+
+	stats := ComputeTranscriptStatistics(transcript)
+	if stats == nil {
+		t.Fatal("ComputeTranscriptStatistics() = nil")
+	}
+	// 15 words, of which "ship" and "the" repeat
+	if stats.UniqueWordCount != 13 || !approx(stats.LexicalDiversity, 13.0/15) {
+		t.Errorf("vocabulary = %d unique, %v diversity, want 13 and 13/15", stats.UniqueWordCount, stats.LexicalDiversity)
+	}
+	// Pauses of 2.6s, 13s, 9.2s and 9.6s after each utterance's estimated end
+	if !approx(stats.AveragePauseDurationSeconds, 8.6) {
+		t.Errorf("AveragePauseDurationSeconds = %v, want 8.6", stats.AveragePauseDurationSeconds)
+	}
+	if stats.LongestMonologueSpeaker != "Alice" || stats.LongestMonologueWords != 11 {
+		t.Errorf("longest monologue = %s with %d words, want Alice with 11", stats.LongestMonologueSpeaker, stats.LongestMonologueWords)
+	}
+	// The utterance without words is left out of the lengths 2, 2, 5 and 6
+	if stats.ShortestUtteranceWords != 2 || stats.MedianUtteranceLengthWords != 3.5 {
+		t.Errorf("utterance lengths = shortest %d, median %v, want 2 and 3.5", stats.ShortestUtteranceWords, stats.MedianUtteranceLengthWords)
+	}
+}
+
+func TestComputeTranscriptStatisticsWithoutWords(t *testing.T) {
+	if stats := ComputeTranscriptStatistics([]TranscriptEntry{entryAt(0, "Alice", "...")}); stats != nil {
+		t.Errorf("ComputeTranscriptStatistics() = %+v, want nil", stats)
+	}
+	if stats := ComputeTranscriptStatistics(nil); stats != nil {
+		t.Errorf("ComputeTranscriptStatistics(nil) = %+v, want nil", stats)
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/models"
+)
+
+const (
+	// defaultAnalysisInterval and defaultAnalysisUtterances trigger an analysis every 5 minutes or 20 utterances
+	defaultAnalysisInterval   = 5 * time.Minute
+	defaultAnalysisUtterances = 20
+
+	// Utterance rates, per minute, above which analysis speeds up and below which it slows down
+	busyUtteranceRate  = 30.0
+	quietUtteranceRate = 5.0
+
+	// Trigger bounds used when the agent config leaves them unset
+	defaultMinTriggerInterval   = 2 * time.Minute
+	defaultMaxTriggerInterval   = 10 * time.Minute
+	defaultMinTriggerUtterances = 10
+	defaultMaxTriggerUtterances = 50
+
+	// utteranceRateAlpha weights the newest utterance gap in the moving average
+	utteranceRateAlpha = 0.2
+	// maxInstantUtteranceRate caps the rate of utterances arriving together, which would otherwise be infinite
+	maxInstantUtteranceRate = 120.0
+)
+
+// AdaptiveTrigger decides how often analysis runs from how fast utterances arrive: busy stretches are
+// analyzed more often and slow ones less often. It is guarded by the analyst's dataMutex.
+type AdaptiveTrigger struct {
+	agentID                 string
+	utterancesPerLastMinute float64 // Exponential moving average of the utterance rate
+	lastUtterance           time.Time
+	interval                time.Duration // Parameters last returned by Thresholds, to log changes
+	utterances              int
+}
+
+// NewAdaptiveTrigger creates an adaptive trigger for the given agent
+func NewAdaptiveTrigger(agentID string) *AdaptiveTrigger {
+	return &AdaptiveTrigger{
+		agentID:    agentID,
+		interval:   defaultAnalysisInterval,
+		utterances: defaultAnalysisUtterances,
+	}
+}
+
+// RecordUtterance updates the moving average of the utterance rate with the gap since the previous utterance
+func (t *AdaptiveTrigger) RecordUtterance(at time.Time) {
+	previous := t.lastUtterance
+	t.lastUtterance = at
+	if previous.IsZero() {
+		return
+	}
+
+	rate := maxInstantUtteranceRate
+	if gap := at.Sub(previous); gap > 0 {
+		rate = min(time.Minute.Seconds()/gap.Seconds(), maxInstantUtteranceRate)
+	}
+	if t.utterancesPerLastMinute == 0 {
+		t.utterancesPerLastMinute = rate
+		return
+	}
+	t.utterancesPerLastMinute = utteranceRateAlpha*rate + (1-utteranceRateAlpha)*t.utterancesPerLastMinute
+}
+
+// UtterancesPerMinute returns the moving average of the utterance rate, 0 before two utterances arrived
+func (t *AdaptiveTrigger) UtterancesPerMinute() float64 {
+	return t.utterancesPerLastMinute
+}
+
+// Thresholds returns how long and how many utterances to wait between analyses at the current utterance
+// rate, within the config's bounds
+func (t *AdaptiveTrigger) Thresholds(config models.AgentConfig) (time.Duration, int) {
+	minInterval := durationOrDefault(config.MinIntervalSec, defaultMinTriggerInterval)
+	maxInterval := durationOrDefault(config.MaxIntervalSec, defaultMaxTriggerInterval)
+	minUtterances := intOrDefault(config.MinUtterances, defaultMinTriggerUtterances)
+	maxUtterances := intOrDefault(config.MaxUtterances, defaultMaxTriggerUtterances)
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	if maxUtterances < minUtterances {
+		maxUtterances = minUtterances
+	}
+
+	interval, utterances := defaultAnalysisInterval, defaultAnalysisUtterances
+	switch rate := t.utterancesPerLastMinute; {
+	case rate == 0:
+		// Not enough utterances yet to tell how busy the meeting is
+	case rate > busyUtteranceRate:
+		interval, utterances = minInterval, minUtterances
+	case rate < quietUtteranceRate:
+		interval, utterances = maxInterval, maxUtterances
+	}
+	interval = min(max(interval, minInterval), maxInterval)
+	utterances = min(max(utterances, minUtterances), maxUtterances)
+
+	if interval != t.interval || utterances != t.utterances {
+		logrus.Debugf("Agent %s: Analysis trigger changed to every %s or %d utterances at %.1f utterances per minute",
+			t.agentID, interval, utterances, t.utterancesPerLastMinute)
+		t.interval, t.utterances = interval, utterances
+	}
+	return interval, utterances
+}
+
+// durationOrDefault converts seconds to a duration, using fallback when seconds isn't positive
+func durationOrDefault(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// intOrDefault returns value, or fallback when value isn't positive
+func intOrDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
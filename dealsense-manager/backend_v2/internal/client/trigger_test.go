@@ -0,0 +1,71 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/models"
+)
+
+func TestAdaptiveTriggerEMAConverges(t *testing.T) {
+	trigger := NewAdaptiveTrigger("test-agent")
+	at := testMeetingStart
+
+	trigger.RecordUtterance(at)
+	if trigger.UtterancesPerMinute() != 0 {
+		t.Fatal("rate measured from a single utterance")
+	}
+	at = at.Add(6 * time.Second)
+	trigger.RecordUtterance(at)
+	if rate := trigger.UtterancesPerMinute(); rate != 10 {
+		t.Fatalf("rate = %v, want the first gap's rate of 10", rate)
+	}
+
+	// Utterances every 2 seconds, 30 a minute
+	for i := 0; i < 40; i++ {
+		at = at.Add(2 * time.Second)
+		trigger.RecordUtterance(at)
+	}
+	if want := 30 - 20*math.Pow(0.8, 40); !approx(trigger.UtterancesPerMinute(), want) {
+		t.Errorf("rate = %v, want %v", trigger.UtterancesPerMinute(), want)
+	}
+
+	// Utterances arriving together count at the capped rate
+	trigger.RecordUtterance(at)
+	if rate := trigger.UtterancesPerMinute(); rate > maxInstantUtteranceRate || !approx(rate, 0.2*maxInstantUtteranceRate+0.8*(30-20*math.Pow(0.8, 40))) {
+		t.Errorf("rate = %v after simultaneous utterances", rate)
+	}
+}
+
+func TestAdaptiveTriggerThresholds(t *testing.T) {
+	config := models.AgentConfig{MinIntervalSec: 60, MaxIntervalSec: 900, MinUtterances: 5, MaxUtterances: 80}
+	tests := []struct {
+		name       string
+		rate       float64
+		config     models.AgentConfig
+		interval   time.Duration
+		utterances int
+	}{
+		{"no rate yet", 0, config, defaultAnalysisInterval, defaultAnalysisUtterances},
+		{"busy", 45, config, time.Minute, 5},
+		{"quiet", 2, config, 15 * time.Minute, 80},
+		{"normal", 10, config, defaultAnalysisInterval, defaultAnalysisUtterances},
+		{"busy with default bounds", 45, models.AgentConfig{}, defaultMinTriggerInterval, defaultMinTriggerUtterances},
+		{"quiet with default bounds", 2, models.AgentConfig{}, defaultMaxTriggerInterval, defaultMaxTriggerUtterances},
+		// The default thresholds are clamped into narrow bounds
+		{"clamped up", 10, models.AgentConfig{MinIntervalSec: 600, MinUtterances: 30}, 10 * time.Minute, 30},
+		{"clamped down", 10, models.AgentConfig{MinIntervalSec: 30, MaxIntervalSec: 120, MinUtterances: 2, MaxUtterances: 8}, 2 * time.Minute, 8},
+		{"maximum below minimum", 2, models.AgentConfig{MinIntervalSec: 300, MaxIntervalSec: 60, MinUtterances: 40, MaxUtterances: 10}, 5 * time.Minute, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := NewAdaptiveTrigger("test-agent")
+			trigger.utterancesPerLastMinute = tt.rate
+			interval, utterances := trigger.Thresholds(tt.config)
+			if interval != tt.interval || utterances != tt.utterances {
+				t.Errorf("Thresholds() = %s, %d, want %s, %d", interval, utterances, tt.interval, tt.utterances)
+			}
+		})
+	}
+}
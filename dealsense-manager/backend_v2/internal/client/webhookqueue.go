@@ -0,0 +1,313 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookQueueDir is the directory failed webhook deliveries are persisted in until they are delivered
+	WebhookQueueDir = "data/webhook_queue"
+	// WebhookQueuePollInterval is how often the queue looks for deliveries due for a retry
+	WebhookQueuePollInterval = 30 * time.Second
+	// MaxWebhookAttempts is the number of failed deliveries after which a webhook is dead-lettered
+	MaxWebhookAttempts = 10
+
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = time.Hour
+	webhookTimeout     = 10 * time.Second
+
+	// webhookDeadLetterFile collects deliveries that were given up on, one JSON object per line
+	webhookDeadLetterFile = "dead_letters.jsonl"
+)
+
+// WebhookDelivery is a webhook post that failed and is waiting to be retried
+type WebhookDelivery struct {
+	ID          string            `json:"id"`
+	AgentID     string            `json:"agent_id"`
+	Source      string            `json:"source"` // What posted the webhook, e.g. product_feedback or keyword_alert
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Payload     json.RawMessage   `json:"payload"`
+	Attempts    int               `json:"attempts"`
+	LastError   string            `json:"last_error"`
+	CreatedAt   time.Time         `json:"created_at"`
+	NextRetryAt time.Time         `json:"next_retry_at"`
+}
+
+// WebhookRetryQueue retries failed webhook deliveries with exponential backoff. Each pending delivery is
+// kept in its own file in the queue directory, so deliveries survive restarts. Deliveries that fail
+// MaxWebhookAttempts times are appended to a dead letter file and logged as errors, which posts them to
+// the Discord error webhook.
+type WebhookRetryQueue struct {
+	mu         sync.Mutex
+	dir        string
+	deliveries map[string]*WebhookDelivery
+	httpClient *http.Client
+}
+
+// NewWebhookRetryQueue creates a queue persisted in dir, loading the deliveries pending from before a restart
+func NewWebhookRetryQueue(dir string) (*WebhookRetryQueue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create webhook queue directory: %w", err)
+	}
+
+	q := &WebhookRetryQueue{
+		dir:        dir,
+		deliveries: make(map[string]*WebhookDelivery),
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook queue: %w", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read queued webhook %s: %w", filepath.Base(path), err)
+		}
+		var delivery WebhookDelivery
+		if err := json.Unmarshal(data, &delivery); err != nil {
+			logrus.Warnf("Skipping unreadable queued webhook %s: %v", filepath.Base(path), err)
+			continue
+		}
+		q.deliveries[delivery.ID] = &delivery
+	}
+	if len(q.deliveries) > 0 {
+		logrus.Infof("Loaded %d queued webhook deliveries", len(q.deliveries))
+	}
+	return q, nil
+}
+
+// Enqueue persists a failed delivery for retry after its first backoff
+func (q *WebhookRetryQueue) Enqueue(delivery WebhookDelivery) error {
+	if delivery.ID == "" {
+		delivery.ID = fmt.Sprintf("whk_%s", uuid.New().String()[:8])
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	if delivery.Attempts == 0 {
+		delivery.Attempts = 1
+	}
+	delivery.NextRetryAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.persist(&delivery); err != nil {
+		return err
+	}
+	q.deliveries[delivery.ID] = &delivery
+	return nil
+}
+
+// List returns a copy of the pending deliveries, soonest retry first
+func (q *WebhookRetryQueue) List() []WebhookDelivery {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	deliveries := make([]WebhookDelivery, 0, len(q.deliveries))
+	for _, delivery := range q.deliveries {
+		deliveries = append(deliveries, *delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].NextRetryAt.Before(deliveries[j].NextRetryAt)
+	})
+	return deliveries
+}
+
+// Start retries due deliveries every interval until the context is cancelled
+func (q *WebhookRetryQueue) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				q.retryDue(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// retryDue retries every delivery whose backoff has elapsed
+func (q *WebhookRetryQueue) retryDue(ctx context.Context, now time.Time) {
+	q.mu.Lock()
+	var due []WebhookDelivery
+	for _, delivery := range q.deliveries {
+		if !now.Before(delivery.NextRetryAt) {
+			due = append(due, *delivery)
+		}
+	}
+	q.mu.Unlock()
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		q.attempt(ctx, delivery)
+	}
+}
+
+// attempt retries a delivery, removing it once delivered, rescheduling it with a longer backoff when it
+// fails, and dead-lettering it once it has failed MaxWebhookAttempts times
+func (q *WebhookRetryQueue) attempt(ctx context.Context, delivery WebhookDelivery) {
+	err := postWebhook(ctx, q.httpClient, delivery.URL, delivery.Headers, delivery.Payload)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err == nil {
+		logrus.Infof("Delivered %s webhook for agent %s after %d attempts", delivery.Source, delivery.AgentID, delivery.Attempts+1)
+		q.remove(delivery.ID)
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= MaxWebhookAttempts {
+		if deadErr := q.deadLetter(delivery); deadErr != nil {
+			logrus.Warnf("Failed to dead-letter %s webhook %s: %v", delivery.Source, delivery.ID, deadErr)
+		}
+		q.remove(delivery.ID)
+		logrus.WithFields(logrus.Fields{
+			"agent_id":   delivery.AgentID,
+			"source":     delivery.Source,
+			"delivery":   delivery.ID,
+			"last_error": delivery.LastError,
+		}).Errorf("Giving up on %s webhook for agent %s after %d attempts", delivery.Source, delivery.AgentID, delivery.Attempts)
+		return
+	}
+
+	delivery.NextRetryAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+	if persistErr := q.persist(&delivery); persistErr != nil {
+		logrus.Warnf("Failed to update queued %s webhook %s: %v", delivery.Source, delivery.ID, persistErr)
+	}
+	q.deliveries[delivery.ID] = &delivery
+	logrus.Debugf("Retry %d of %s webhook for agent %s failed: %v", delivery.Attempts, delivery.Source, delivery.AgentID, err)
+}
+
+// persist writes a delivery to its file, replacing the previous version atomically (caller must hold mu)
+func (q *WebhookRetryQueue) persist(delivery *WebhookDelivery) error {
+	data, err := json.MarshalIndent(delivery, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued webhook: %w", err)
+	}
+
+	path := q.path(delivery.ID)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write queued webhook: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace queued webhook: %w", err)
+	}
+	return nil
+}
+
+// remove deletes a delivery from the queue and its file (caller must hold mu)
+func (q *WebhookRetryQueue) remove(id string) {
+	delete(q.deliveries, id)
+	if err := os.Remove(q.path(id)); err != nil && !os.IsNotExist(err) {
+		logrus.Warnf("Failed to delete queued webhook %s: %v", id, err)
+	}
+}
+
+// deadLetter appends a delivery that was given up on to the dead letter file (caller must hold mu)
+func (q *WebhookRetryQueue) deadLetter(delivery WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(q.dir, webhookDeadLetterFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return nil
+}
+
+// path returns the file a delivery is persisted in
+func (q *WebhookRetryQueue) path(id string) string {
+	return filepath.Join(q.dir, filepath.Base(id)+".json")
+}
+
+// webhookBackoff returns the delay before the next retry after the given number of failed attempts
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
+
+// postWebhook posts a JSON payload to a webhook, treating any status outside 2xx as a failure
+func postWebhook(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SetWebhookRetryQueue sets the queue failed webhook deliveries are retried from; without one they are dropped
+func (a *AnalystAgent) SetWebhookRetryQueue(queue *WebhookRetryQueue) {
+	a.webhookQueue = queue
+}
+
+// queueWebhookRetry queues a webhook post that failed for retry, returning whether it was queued
+func (a *AnalystAgent) queueWebhookRetry(source, url string, payload []byte, postErr error) bool {
+	if a.webhookQueue == nil {
+		return false
+	}
+
+	err := a.webhookQueue.Enqueue(WebhookDelivery{
+		AgentID:   a.agentID,
+		Source:    source,
+		URL:       url,
+		Payload:   payload,
+		LastError: postErr.Error(),
+	})
+	if err != nil {
+		logrus.Warnf("Agent %s: Failed to queue %s webhook for retry: %v", a.agentID, source, err)
+		return false
+	}
+	logrus.Infof("Agent %s: Queued %s webhook for retry: %v", a.agentID, source, postErr)
+	return true
+}
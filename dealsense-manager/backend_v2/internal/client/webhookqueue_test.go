@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookServer answers webhook posts with status and counts them
+func webhookServer(t *testing.T, status int) (*httptest.Server, *int64) {
+	t.Helper()
+	var posts int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		atomic.AddInt64(&posts, 1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &posts
+}
+
+func TestWebhookQueuePersistsAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+	queue, err := NewWebhookRetryQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Enqueue(WebhookDelivery{AgentID: "agent-1", Source: "keyword_alert", URL: "https://hooks.example.com",
+		Payload: json.RawMessage(`{"keyword":"pricing"}`)}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewWebhookRetryQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveries := reloaded.List()
+	if len(deliveries) != 1 {
+		t.Fatalf("reloaded %d deliveries, want 1", len(deliveries))
+	}
+	delivery := deliveries[0]
+	var payload map[string]string
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil || payload["keyword"] != "pricing" {
+		t.Errorf("payload = %s, want the queued payload", delivery.Payload)
+	}
+	if delivery.Source != "keyword_alert" || delivery.Attempts != 1 {
+		t.Errorf("delivery = %+v", delivery)
+	}
+	if wait := time.Until(delivery.NextRetryAt); wait <= 0 || wait > webhookBaseBackoff {
+		t.Errorf("next retry in %v, want the first backoff", wait)
+	}
+}
+
+func TestWebhookQueueSkipsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	queue, err := NewWebhookRetryQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queue.List()) != 0 {
+		t.Error("unreadable delivery loaded")
+	}
+}
+
+func TestWebhookQueueRemovesDeliveredWebhooks(t *testing.T) {
+	server, posts := webhookServer(t, http.StatusOK)
+	dir := t.TempDir()
+	queue, err := NewWebhookRetryQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Enqueue(WebhookDelivery{ID: "whk_1", URL: server.URL, Payload: json.RawMessage(`{}`)}); err != nil {
+		t.Fatal(err)
+	}
+
+	queue.retryDue(context.Background(), time.Now())
+	if *posts != 0 {
+		t.Fatal("delivery retried before its backoff elapsed")
+	}
+
+	queue.retryDue(context.Background(), time.Now().Add(time.Hour))
+	if *posts != 1 {
+		t.Fatalf("posts = %d, want 1", *posts)
+	}
+	if len(queue.List()) != 0 {
+		t.Error("delivered webhook left in the queue")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "whk_1.json")); !os.IsNotExist(err) {
+		t.Error("delivered webhook's file not deleted")
+	}
+}
+
+func TestWebhookQueueDeadLettersAfterMaxAttempts(t *testing.T) {
+	server, _ := webhookServer(t, http.StatusInternalServerError)
+	dir := t.TempDir()
+	queue, err := NewWebhookRetryQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Enqueue(WebhookDelivery{ID: "whk_1", URL: server.URL, Payload: json.RawMessage(`{}`),
+		Attempts: MaxWebhookAttempts - 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	queue.retryDue(context.Background(), time.Now().Add(24*time.Hour))
+	deliveries := queue.List()
+	if len(deliveries) != 1 || deliveries[0].Attempts != MaxWebhookAttempts-1 || deliveries[0].LastError != "webhook returned status 500" {
+		t.Fatalf("queue = %+v, want the failed delivery rescheduled", deliveries)
+	}
+
+	queue.retryDue(context.Background(), time.Now().Add(24*time.Hour))
+	if len(queue.List()) != 0 {
+		t.Error("delivery still queued after its last attempt")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, webhookDeadLetterFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"id":"whk_1"`) {
+		t.Errorf("dead letters = %s, want the delivery", data)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 4: 4 * time.Minute, 20: webhookMaxBackoff}
+	for attempts, want := range tests {
+		if got := webhookBackoff(attempts); got != want {
+			t.Errorf("webhookBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// whisperNoSpeechThreshold is the no_speech_prob above which Whisper most likely transcribed silence
+const whisperNoSpeechThreshold = 0.5
+
+// ErrInvalidWhisperTranscript is returned for payloads that aren't a Whisper transcription
+var ErrInvalidWhisperTranscript = errors.New("invalid Whisper transcript payload")
+
+// whisperSegment is a segment of a Whisper verbose_json transcription. Speaker isn't part of Whisper's
+// output but is added by diarizing wrappers such as WhisperX.
+type whisperSegment struct {
+	ID           int     `json:"id"`
+	Seek         int     `json:"seek"`
+	Start        float64 `json:"start"` // Seconds from the start of the audio
+	End          float64 `json:"end"`
+	Text         string  `json:"text"`
+	NoSpeechProb float64 `json:"no_speech_prob"`
+	Speaker      string  `json:"speaker,omitempty"`
+}
+
+// ProcessWhisperTranscript adds the segments of a Whisper transcription to the transcript, one utterance
+// per segment. Segment start offsets are taken to be relative to the meeting start, and segments Whisper
+// considers likely silence are dropped.
+func (a *AnalystAgent) ProcessWhisperTranscript(payload []byte) error {
+	var body struct {
+		Segments []whisperSegment `json:"segments"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWhisperTranscript, err)
+	}
+	if len(body.Segments) == 0 {
+		return fmt.Errorf("%w: missing segments", ErrInvalidWhisperTranscript)
+	}
+
+	a.dataMutex.RLock()
+	startTime := a.data.StartTime
+	a.dataMutex.RUnlock()
+
+	silent := 0
+	for _, segment := range body.Segments {
+		if segment.NoSpeechProb > whisperNoSpeechThreshold {
+			silent++
+			continue
+		}
+
+		speaker := strings.TrimSpace(segment.Speaker)
+		if speaker == "" {
+			speaker = "Participant"
+		}
+		timestamp := whisperTimestamp(startTime, segment.Start)
+
+		a.ProcessUtterance([]map[string]interface{}{{
+			"speaker":   speaker,
+			"text":      strings.TrimSpace(segment.Text),
+			"timestamp": float64(timestamp.Unix()),
+		}})
+	}
+
+	if silent > 0 {
+		a.dataMutex.Lock()
+		a.data.NoisySegmentsDropped += silent
+		a.dataMutex.Unlock()
+		logrus.Debugf("Agent %s: Dropped %d silent Whisper segments", a.agentID, silent)
+	}
+	return nil
+}
+
+// whisperTimestamp converts a Whisper offset in seconds to the time it was spoken. ProcessUtterance keeps
+// whole seconds, so the offset is rounded rather than truncated.
+func whisperTimestamp(startTime time.Time, offsetSeconds float64) time.Time {
+	offset := time.Duration(offsetSeconds * float64(time.Second))
+	return startTime.Add(offset).Round(time.Second)
+}
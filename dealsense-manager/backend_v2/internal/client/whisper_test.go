@@ -0,0 +1,62 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProcessWhisperTranscript(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.data.StartTime = testMeetingStart
+
+	payload := []byte(`{"text": "...", "segments": [
+		{"id": 0, "start": 0.0, "end": 4.2, "text": " Welcome to the review.", "no_speech_prob": 0.01, "speaker": "Alice"},
+		{"id": 1, "start": 4.2, "end": 9.0, "text": " Thanks for watching!", "no_speech_prob": 0.92},
+		{"id": 2, "start": 65.4, "end": 70.0, "text": " Next up, pricing.", "no_speech_prob": 0.5, "speaker": "Bob"},
+		{"id": 3, "start": 3725.6, "end": 3730.0, "text": " Let's wrap up.", "no_speech_prob": 0.2}
+	]}`)
+	if err := analyst.ProcessWhisperTranscript(payload); err != nil {
+		t.Fatal(err)
+	}
+
+	data := analyst.GetAnalysis()
+	if len(data.Transcript) != 3 || data.NoisySegmentsDropped != 1 {
+		t.Fatalf("transcript = %+v with %d dropped, want the likely silent segment dropped", data.Transcript, data.NoisySegmentsDropped)
+	}
+	want := []struct {
+		speaker string
+		text    string
+		offset  time.Duration
+	}{
+		{"Alice", "Welcome to the review.", 0},
+		{"Bob", "Next up, pricing.", 65 * time.Second},
+		{"Participant", "Let's wrap up.", time.Hour + 2*time.Minute + 6*time.Second},
+	}
+	for i, entry := range data.Transcript {
+		if entry.Speaker != want[i].speaker || entry.Text != want[i].text {
+			t.Errorf("entry %d = %s: %q, want %s: %q", i, entry.Speaker, entry.Text, want[i].speaker, want[i].text)
+		}
+		if offset := entry.Timestamp.Sub(testMeetingStart); offset != want[i].offset {
+			t.Errorf("entry %d at %s, want %s", i, offset, want[i].offset)
+		}
+	}
+}
+
+func TestWhisperTimestamp(t *testing.T) {
+	tests := map[float64]time.Duration{0: 0, 1.49: time.Second, 1.5: 2 * time.Second, 3599.7: time.Hour}
+	for offset, want := range tests {
+		if got := whisperTimestamp(testMeetingStart, offset).Sub(testMeetingStart); got != want {
+			t.Errorf("whisperTimestamp(%v) = +%s, want +%s", offset, got, want)
+		}
+	}
+}
+
+func TestProcessWhisperTranscriptInvalidPayloads(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	for _, payload := range []string{`not json`, `{"text": "hello"}`, `{"segments": []}`} {
+		if err := analyst.ProcessWhisperTranscript([]byte(payload)); !errors.Is(err, ErrInvalidWhisperTranscript) {
+			t.Errorf("%s: %v, want ErrInvalidWhisperTranscript", payload, err)
+		}
+	}
+}
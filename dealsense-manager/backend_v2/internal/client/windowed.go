@@ -0,0 +1,421 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/migration"
+	"joinly-manager/internal/storage"
+)
+
+// maxConcurrentWindows is the number of transcript windows analyzed at the same time per agent
+const maxConcurrentWindows = 4
+
+// discardBackend drops saved documents, so window analyses aren't written to disk
+type discardBackend struct{}
+
+func (discardBackend) Save(context.Context, storage.Document) error { return nil }
+
+// analysisWindows splits the transcript into the windows of the windowed analysis config, or returns the
+// whole transcript as a single window when windowed analysis is disabled or the transcript fits in one
+func (a *AnalystAgent) analysisWindows(transcript []TranscriptEntry) [][]TranscriptEntry {
+	windowed := a.config.WindowedAnalysis
+	if windowed == nil || windowed.WindowSizeEntries <= 0 || len(transcript) <= windowed.WindowSizeEntries {
+		return [][]TranscriptEntry{transcript}
+	}
+
+	size := windowed.WindowSizeEntries
+	overlap := min(max(windowed.WindowOverlapEntries, 0), size/2)
+
+	var windows [][]TranscriptEntry
+	for start := 0; ; start += size - overlap {
+		end := min(start+size, len(transcript))
+		windows = append(windows, transcript[start:end])
+		if end == len(transcript) {
+			return windows
+		}
+	}
+}
+
+// windowKey identifies a window by its first entry and length, so complete windows are recognized after
+// entries before them are moved out by the retention policy
+func windowKey(window []TranscriptEntry) string {
+	first := window[0]
+	return fmt.Sprintf("%d|%s|%d", first.Timestamp.UnixNano(), first.Speaker, len(window))
+}
+
+// runWindowedSteps runs every analysis step on each window concurrently and merges the results into the
+// analysis, returning the names of the windows that failed. Results of complete windows are reused by
+// later runs, so only the windows new entries fall into are analyzed again. Failed windows aren't queued
+// for retry; they are analyzed again on the next run.
+func (a *AnalystAgent) runWindowedSteps(windows [][]TranscriptEntry) []string {
+	size := a.config.WindowedAnalysis.WindowSizeEntries
+	logrus.Infof("Agent %s: Analyzing %d transcript windows of up to %d entries", a.agentID, len(windows), size)
+	stepsStarted := time.Now()
+
+	results := make([]*AnalysisData, len(windows))
+	errs := make([]error, len(windows))
+	semaphore := make(chan struct{}, maxConcurrentWindows)
+	var wg sync.WaitGroup
+
+	for i, window := range windows {
+		key := windowKey(window)
+		if cached, ok := a.windowResults[key]; ok {
+			results[i] = cached
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, window []TranscriptEntry) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			windowAgent := a.newWindowAgent(window)
+			errs[i] = windowAgent.runAnalysis(WindowAll)
+			results[i] = windowAgent.GetAnalysis()
+		}(i, window)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, window := range windows {
+		switch {
+		case errs[i] != nil:
+			logrus.Errorf("Failed to analyze transcript window %d for agent %s: %v", i+1, a.agentID, errs[i])
+			failed = append(failed, fmt.Sprintf("window %d", i+1))
+		case len(window) == size:
+			if a.windowResults == nil {
+				a.windowResults = make(map[string]*AnalysisData)
+			}
+			a.windowResults[windowKey(window)] = results[i]
+		}
+	}
+
+	merged := MergeAnalysisResults(results)
+
+	a.dataMutex.Lock()
+	a.data.Summary = merged.Summary
+	a.data.KeyPoints = merged.KeyPoints
+	a.data.ActionItems = deduplicateActionItems(a.data.ActionItems, mergeActionItems(a.data.ActionItems, merged.ActionItems))
+	a.data.Topics = merged.Topics
+	a.data.Sentiment = merged.Sentiment
+	a.data.Keywords = merged.Keywords
+	if a.config.EnableKeyQuotes {
+		a.data.KeyQuotes = merged.KeyQuotes
+	}
+	if a.config.EnableCompetitiveIntel {
+		a.data.CompetitiveIntelligence = merged.CompetitiveIntelligence
+	}
+	if a.config.EnableRequirementExtraction {
+		a.data.Requirements = merged.Requirements
+		a.data.UnansweredQuestions = merged.UnansweredQuestions
+	}
+	if a.config.EnableMetricExtraction {
+		a.data.KeyMetrics = merged.KeyMetrics
+	}
+	if a.config.EnableLearningResources {
+		a.data.BookRecommendations = merged.BookRecommendations
+	}
+	if a.config.EnableComplianceDetection {
+		a.data.ComplianceFlags = merged.ComplianceFlags
+	}
+	if a.config.EnableNPSProxy {
+		a.data.NPSProxy = merged.NPSProxy
+	}
+	if a.config.EnableProductFeedback {
+		a.data.ProductFeedback = merged.ProductFeedback
+	}
+	if a.config.EnablePersonaInference {
+		a.data.SpeakerPersonas = merged.SpeakerPersonas
+	}
+	if a.config.EnableAcronymGlossary {
+		a.data.AcronymGlossary = merged.AcronymGlossary
+	}
+	if a.config.EnableNewTermsGlossary {
+		a.data.GlossaryOfNewTerms = merged.GlossaryOfNewTerms
+	}
+	if a.config.EnableTechDebtExtraction {
+		a.data.TechnicalDebtMentions = merged.TechnicalDebtMentions
+	}
+	if a.config.EnableMetricsTracking {
+		a.data.SuccessMetricsTracked = merged.SuccessMetricsTracked
+	}
+	if a.config.EnableSpeakerMoodTimeline {
+		a.data.SpeakerMoodTimeline = merged.SpeakerMoodTimeline
+	}
+	if a.config.EnableBudgetExtraction {
+		a.data.BudgetDiscussions = merged.BudgetDiscussions
+	}
+	if a.config.EnableStakeholderMap {
+		// Mentions are recounted over the whole transcript rather than summed across windows
+		a.data.StakeholderMap = merged.StakeholderMap
+		a.refreshStakeholderMentions()
+	}
+	if a.config.EnableQAExtraction {
+		a.data.QuestionsAndAnswers = merged.QuestionsAndAnswers
+		a.linkUnansweredQuestions()
+	}
+	var newObjections []SalesObjection
+	if a.config.MeetingType == salesCallMeetingType {
+		for _, objection := range merged.Objections {
+			if findSalesObjection(a.data.Objections, objection) < 0 {
+				newObjections = append(newObjections, objection)
+			}
+		}
+		a.data.Objections = merged.Objections
+	}
+	meetingID := a.data.MeetingID
+	a.data.TimeoutCount += merged.TimeoutCount
+	a.dataMutex.Unlock()
+
+	for _, objection := range newObjections {
+		if err := a.postSalesObjection(meetingID, objection); err != nil {
+			logrus.Warnf("Agent %s: Failed to post sales objection: %v", a.agentID, err)
+		}
+	}
+
+	mergedSteps := []analysisStep{a.executiveSummaryStep(), a.terseSummariesStep()}
+	if len(a.config.Objectives) > 0 {
+		mergedSteps = append(mergedSteps, a.objectivesStep())
+	}
+	if a.config.EnableAttachmentTracking {
+		mergedSteps = append(mergedSteps, a.attachmentsStep())
+	}
+	if a.config.EnableEthicsDetection {
+		mergedSteps = append(mergedSteps, a.ethicsStep())
+	}
+	if a.config.EnableConversationFlow {
+		mergedSteps = append(mergedSteps, a.conversationFlowStep())
+	}
+	for _, step := range mergedSteps {
+		a.analysisStepLabel.Store(step.name)
+		if err := a.runStep(step); err != nil {
+			logrus.Errorf("Failed to %s for agent %s: %v", step.description, a.agentID, err)
+			failed = append(failed, step.name)
+		}
+	}
+
+	logrus.Infof("Agent %s: Windowed analysis steps took %dms", a.agentID, time.Since(stepsStarted).Milliseconds())
+	return failed
+}
+
+// newWindowAgent creates an analyst for a single transcript window that shares this agent's LLM provider,
+// prompts and glossary but saves nothing
+func (a *AnalystAgent) newWindowAgent(window []TranscriptEntry) *AnalystAgent {
+	config := a.config
+	config.WindowedAnalysis = nil
+	config.ABTest = nil
+	// Transitions are classified and objectives evaluated once across the merged summary and topics, so
+	// those spanning windows aren't missed
+	config.EnableConversationFlow = false
+	config.Objectives = nil
+
+	a.dataMutex.RLock()
+	meetingID, tenantID, meetingURL := a.data.MeetingID, a.data.TenantID, a.data.MeetingURL
+	corrections := append([]Correction(nil), a.data.Corrections...)
+	a.dataMutex.RUnlock()
+
+	participants := []string{}
+	seen := make(map[string]bool)
+	for _, entry := range window {
+		if !entry.IsAgent && !seen[entry.Speaker] {
+			seen[entry.Speaker] = true
+			participants = append(participants, entry.Speaker)
+		}
+	}
+
+	windowAgent := a.newDerivedAgent(a.agentID, config, &AnalysisData{
+		SchemaVersion: migration.CurrentSchemaVersion,
+		MeetingID:     meetingID,
+		TenantID:      tenantID,
+		MeetingURL:    meetingURL,
+		StartTime:     window[0].Timestamp,
+		LastUpdated:   time.Now(),
+		Transcript:    append([]TranscriptEntry{}, window...),
+		KeyPoints:     []string{},
+		ActionItems:   []ActionItem{},
+		Topics:        []TopicDiscussion{},
+		Participants:  participants,
+		Corrections:   corrections,
+	})
+	windowAgent.backend = discardBackend{}
+	windowAgent.windowAgent = true
+	return windowAgent
+}
+
+// MergeAnalysisResults combines analyses of consecutive transcript windows into one: summaries are
+// concatenated, action items, key points and other extracted items found in more than one window are kept
+// once, topics with the same name are merged and the most common sentiment wins. Single-valued results such
+// as the NPS proxy come from the last window that has one. Conflicting statements refer to their window's
+// transcript, so they aren't merged.
+func MergeAnalysisResults(results []*AnalysisData) *AnalysisData {
+	merged := &AnalysisData{
+		KeyPoints:   []string{},
+		ActionItems: []ActionItem{},
+		Topics:      []TopicDiscussion{},
+	}
+
+	var summaries []string
+	sentiments := make(map[string]int)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if merged.MeetingID == "" {
+			merged.MeetingID, merged.TenantID, merged.MeetingURL = result.MeetingID, result.TenantID, result.MeetingURL
+		}
+
+		if summary := strings.TrimSpace(result.Summary); summary != "" {
+			summaries = append(summaries, summary)
+		}
+		for _, point := range result.KeyPoints {
+			if !containsNearDuplicate(merged.KeyPoints, point) {
+				merged.KeyPoints = append(merged.KeyPoints, point)
+			}
+		}
+		merged.ActionItems = deduplicateActionItems(merged.ActionItems, result.ActionItems)
+		merged.Topics = mergeTopicLists(merged.Topics, result.Topics)
+		if result.Sentiment != "" {
+			sentiments[result.Sentiment]++
+			if sentiments[result.Sentiment] >= sentiments[merged.Sentiment] {
+				merged.Sentiment = result.Sentiment
+			}
+		}
+		merged.Keywords = appendUniqueBy(merged.Keywords, result.Keywords, func(keyword string) string { return keyword })
+
+		merged.KeyQuotes = appendUniqueBy(merged.KeyQuotes, result.KeyQuotes, func(quote Quote) string { return quote.Text })
+		offset := len(merged.Requirements)
+		merged.Requirements = append(merged.Requirements, result.Requirements...)
+		for _, question := range result.UnansweredQuestions {
+			// Questions asked in the meeting are linked again once the pairs are merged
+			if question.RequirementIndex >= 0 {
+				question.RequirementIndex += offset
+				merged.UnansweredQuestions = append(merged.UnansweredQuestions, question)
+			}
+		}
+		merged.KeyMetrics = appendUniqueBy(merged.KeyMetrics, result.KeyMetrics, func(metric MetricMention) string {
+			return fmt.Sprintf("%s|%g|%s", metric.MetricName, metric.Value, metric.Unit)
+		})
+		merged.BookRecommendations = appendUniqueBy(merged.BookRecommendations, result.BookRecommendations, func(book BookRecommendation) string { return book.Title })
+		merged.ComplianceFlags = appendUniqueBy(merged.ComplianceFlags, result.ComplianceFlags, func(flag ComplianceFlag) string { return flag.Description })
+		merged.ProductFeedback = appendUniqueBy(merged.ProductFeedback, result.ProductFeedback, func(item FeedbackItem) string { return item.Verbatim })
+		merged.TechnicalDebtMentions = appendUniqueBy(merged.TechnicalDebtMentions, result.TechnicalDebtMentions, techDebtKey)
+		// Later windows update the values of metrics mentioned again
+		for _, metric := range result.SuccessMetricsTracked {
+			mergeSuccessMetric(&merged.SuccessMetricsTracked, metric)
+		}
+		merged.SpeakerMoodTimeline = mergeMoodTimelines(merged.SpeakerMoodTimeline, result.SpeakerMoodTimeline)
+		for _, item := range result.BudgetDiscussions {
+			mergeBudgetItem(&merged.BudgetDiscussions, item)
+		}
+		for _, pair := range result.QuestionsAndAnswers {
+			merged.QuestionsAndAnswers = mergeQAPair(merged.QuestionsAndAnswers, pair)
+		}
+		for _, objection := range result.Objections {
+			merged.Objections = mergeSalesObjection(merged.Objections, objection)
+		}
+		for _, stakeholder := range result.StakeholderMap {
+			merged.StakeholderMap = mergeStakeholder(merged.StakeholderMap, stakeholder)
+		}
+		if result.CompetitiveIntelligence != nil {
+			merged.CompetitiveIntelligence = result.CompetitiveIntelligence
+		}
+		if result.NPSProxy != nil {
+			merged.NPSProxy = result.NPSProxy.clone()
+		}
+		// Each speaker keeps the persona inferred from the window they spoke most in
+		for speaker, persona := range result.SpeakerPersonas {
+			if merged.SpeakerPersonas == nil {
+				merged.SpeakerPersonas = make(map[string]SpeakerPersona)
+			}
+			if current, ok := merged.SpeakerPersonas[speaker]; !ok || persona.Utterances > current.Utterances {
+				merged.SpeakerPersonas[speaker] = persona.clone()
+			}
+		}
+		// An acronym keeps the expansion from the first window that resolved it
+		for acronym, expansion := range result.AcronymGlossary {
+			if merged.AcronymGlossary == nil {
+				merged.AcronymGlossary = make(map[string]string)
+			}
+			if _, ok := merged.AcronymGlossary[acronym]; !ok {
+				merged.AcronymGlossary[acronym] = expansion
+			}
+		}
+		// Windows are in transcript order, so the first window defining a term has its first mention
+		for term, entry := range result.GlossaryOfNewTerms {
+			if merged.GlossaryOfNewTerms == nil {
+				merged.GlossaryOfNewTerms = make(map[string]GlossaryEntry)
+			}
+			if _, ok := merged.GlossaryOfNewTerms[term]; !ok {
+				merged.GlossaryOfNewTerms[term] = entry
+			}
+		}
+		merged.TimeoutCount += result.TimeoutCount
+	}
+
+	merged.Summary = strings.Join(summaries, "\n\n")
+	return merged
+}
+
+// containsNearDuplicate reports whether a key point differs from one in points by at most
+// actionItemDuplicateRatio, the way duplicate action items are recognized
+func containsNearDuplicate(points []string, point string) bool {
+	for _, existing := range points {
+		if isDuplicateActionItem(existing, point) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUniqueBy appends the items with a non-empty key not already in list, ignoring case and spacing
+func appendUniqueBy[T any](list, items []T, key func(T) string) []T {
+	seen := make(map[string]bool, len(list)+len(items))
+	normalize := func(item T) string { return strings.Join(strings.Fields(strings.ToLower(key(item))), " ") }
+	for _, existing := range list {
+		seen[normalize(existing)] = true
+	}
+	for _, item := range items {
+		if k := normalize(item); k != "" && !seen[k] {
+			seen[k] = true
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// mergeTopicLists adds topics to the list, merging each into the topic of the same name if there is one:
+// the earliest start time and longest duration are kept, and participants and sub-topics are combined
+func mergeTopicLists(topics, additions []TopicDiscussion) []TopicDiscussion {
+	for _, addition := range additions {
+		match := -1
+		for i := range topics {
+			if strings.EqualFold(strings.TrimSpace(topics[i].Topic), strings.TrimSpace(addition.Topic)) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			topics = append(topics, copyTopics([]TopicDiscussion{addition}, maxTopicDepth)...)
+			continue
+		}
+
+		topic := &topics[match]
+		if addition.StartTime != "" && (topic.StartTime == "" || addition.StartTime < topic.StartTime) {
+			topic.StartTime = addition.StartTime
+		}
+		topic.Duration = max(topic.Duration, addition.Duration)
+		if topic.Summary == "" {
+			topic.Summary = addition.Summary
+		}
+		topic.Participants = appendUniqueBy(append([]string(nil), topic.Participants...), addition.Participants, func(name string) string { return name })
+		topic.SubTopics = mergeTopicLists(topic.SubTopics, addition.SubTopics)
+	}
+	return topics
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/models"
+)
+
+// numberedTranscript returns count entries a second apart, each naming its position
+func numberedTranscript(count int) []TranscriptEntry {
+	transcript := make([]TranscriptEntry, count)
+	for i := range transcript {
+		transcript[i] = entryAt(i, "Alice", fmt.Sprintf("statement %d", i))
+	}
+	return transcript
+}
+
+// windowBounds returns the first and last statement of each window
+func windowBounds(windows [][]TranscriptEntry) [][2]string {
+	bounds := make([][2]string, len(windows))
+	for i, window := range windows {
+		bounds[i] = [2]string{window[0].Text, window[len(window)-1].Text}
+	}
+	return bounds
+}
+
+func TestAnalysisWindows(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	transcript := numberedTranscript(10)
+
+	if windows := analyst.analysisWindows(transcript); len(windows) != 1 || len(windows[0]) != 10 {
+		t.Errorf("windows without config = %v, want the whole transcript", windowBounds(windows))
+	}
+
+	analyst.config.WindowedAnalysis = &models.WindowedAnalysisConfig{WindowSizeEntries: 4, WindowOverlapEntries: 1}
+	want := [][2]string{
+		{"statement 0", "statement 3"},
+		{"statement 3", "statement 6"},
+		{"statement 6", "statement 9"},
+	}
+	if got := windowBounds(analyst.analysisWindows(transcript)); !reflect.DeepEqual(got, want) {
+		t.Errorf("windows = %v, want %v", got, want)
+	}
+
+	// Overlap is capped at half the window
+	analyst.config.WindowedAnalysis = &models.WindowedAnalysisConfig{WindowSizeEntries: 4, WindowOverlapEntries: 3}
+	want = [][2]string{
+		{"statement 0", "statement 3"},
+		{"statement 2", "statement 5"},
+		{"statement 4", "statement 7"},
+		{"statement 6", "statement 9"},
+	}
+	if got := windowBounds(analyst.analysisWindows(transcript)); !reflect.DeepEqual(got, want) {
+		t.Errorf("windows = %v, want %v", got, want)
+	}
+}
+
+func TestMergeAnalysisResultsDeduplicatesOverlap(t *testing.T) {
+	// The overlapping statement gives both windows the same action item and key point
+	first := &AnalysisData{
+		MeetingID: "m1",
+		Summary:   "Reviewed the launch.",
+		KeyPoints: []string{"Launch moves to June", "Budget approved"},
+		ActionItems: []ActionItem{
+			{Description: "Update the launch checklist", Assignee: "Alice"},
+			{Description: "Email the press kit", Assignee: "Bob"},
+		},
+		Topics:    []TopicDiscussion{{Topic: "Launch", StartTime: "10:05", Duration: 10, Participants: []string{"Alice"}}},
+		Sentiment: "positive",
+		Keywords:  []string{"launch"},
+	}
+	second := &AnalysisData{
+		MeetingID: "m1",
+		Summary:   "Planned the hiring.",
+		KeyPoints: []string{"Launch moves to June.", "Two hires approved"},
+		ActionItems: []ActionItem{
+			{Description: "Update the launch checklist.", Assignee: "Alice"},
+			{Description: "Post the job ads", Assignee: "Carol"},
+		},
+		Topics: []TopicDiscussion{
+			{Topic: "launch", StartTime: "10:00", Duration: 5, Participants: []string{"Bob"}},
+			{Topic: "Hiring", StartTime: "10:30"},
+		},
+		Sentiment: "neutral",
+		Keywords:  []string{"Launch", "hiring"},
+	}
+
+	merged := MergeAnalysisResults([]*AnalysisData{first, nil, second})
+
+	if merged.Summary != "Reviewed the launch.\n\nPlanned the hiring." || merged.MeetingID != "m1" {
+		t.Errorf("summary = %q, meeting = %q", merged.Summary, merged.MeetingID)
+	}
+	if len(merged.ActionItems) != 3 {
+		t.Errorf("action items = %+v, want 3 without the overlap doubled", merged.ActionItems)
+	}
+	if want := []string{"Launch moves to June", "Budget approved", "Two hires approved"}; !reflect.DeepEqual(merged.KeyPoints, want) {
+		t.Errorf("key points = %v, want %v", merged.KeyPoints, want)
+	}
+	if len(merged.Topics) != 2 {
+		t.Fatalf("topics = %+v, want the launch topics merged", merged.Topics)
+	}
+	launch := merged.Topics[0]
+	if launch.StartTime != "10:00" || launch.Duration != 10 || !reflect.DeepEqual(launch.Participants, []string{"Alice", "Bob"}) {
+		t.Errorf("launch topic = %+v", launch)
+	}
+	// Ties go to the later window's sentiment
+	if merged.Sentiment != "neutral" || !reflect.DeepEqual(merged.Keywords, []string{"launch", "hiring"}) {
+		t.Errorf("sentiment = %q, keywords = %v", merged.Sentiment, merged.Keywords)
+	}
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxWordCloudWords caps the number of words kept in the word cloud data
+	maxWordCloudWords = 100
+	// Word cloud SVG canvas size and font size range
+	wordCloudWidth       = 800
+	wordCloudHeight      = 600
+	wordCloudMinFontSize = 12
+	wordCloudMaxFontSize = 64
+)
+
+// WordFrequency is a word and how often it was said in the meeting
+type WordFrequency struct {
+	Word      string `json:"word"`
+	Frequency int    `json:"frequency"`
+}
+
+// defaultStopwords are common English words excluded from the word cloud. Contractions are listed
+// without apostrophes since tokenization strips them.
+var defaultStopwords = wordSet("a", "about", "above", "after", "again", "all", "also", "am", "an", "and", "any",
+	"are", "as", "at", "be", "because", "been", "before", "being", "below", "between", "both", "but", "by",
+	"can", "cant", "could", "couldnt", "did", "didnt", "do", "does", "doesnt", "doing", "dont", "down",
+	"during", "each", "even", "few", "for", "from", "further", "get", "got", "had", "has", "have", "having",
+	"he", "her", "here", "hers", "him", "his", "how", "i", "id", "if", "ill", "im", "in", "into", "is", "isnt",
+	"it", "its", "ive", "just", "kind", "know", "like", "lets", "me", "mean", "more", "most", "my", "no", "nor",
+	"not", "now", "of", "off", "oh", "ok", "okay", "on", "once", "one", "only", "or", "other", "our", "ours",
+	"out", "over", "own", "really", "right", "same", "say", "see", "she", "should", "so", "some", "such",
+	"than", "that", "thats", "the", "their", "them", "then", "there", "theres", "these", "they", "theyre",
+	"thing", "things", "think", "this", "those", "through", "to", "too", "um", "uh", "under", "until", "up",
+	"us", "very", "was", "wasnt", "we", "well", "were", "weve", "what", "whats", "when", "where", "which",
+	"while", "who", "why", "will", "with", "would", "yeah", "yes", "you", "youre", "your", "yours")
+
+// stopwords returns the default stopwords combined with any configured for the agent
+func (a *AnalystAgent) stopwords() map[string]bool {
+	if len(a.config.WordCloudStopwords) == 0 {
+		return defaultStopwords
+	}
+
+	stopwords := make(map[string]bool, len(defaultStopwords)+len(a.config.WordCloudStopwords))
+	for word := range defaultStopwords {
+		stopwords[word] = true
+	}
+	for _, word := range a.config.WordCloudStopwords {
+		stopwords[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+	return stopwords
+}
+
+// computeWordFrequencies counts how often each non-stopword is said in the transcript, most frequent first
+func computeWordFrequencies(transcript []TranscriptEntry, stopwords map[string]bool) []WordFrequency {
+	counts := make(map[string]int)
+	for _, entry := range transcript {
+		for _, word := range tokenizeWords(entry.Text) {
+			if !stopwords[word] {
+				counts[word]++
+			}
+		}
+	}
+
+	frequencies := make([]WordFrequency, 0, len(counts))
+	for word, count := range counts {
+		frequencies = append(frequencies, WordFrequency{Word: word, Frequency: count})
+	}
+
+	sort.Slice(frequencies, func(i, j int) bool {
+		if frequencies[i].Frequency != frequencies[j].Frequency {
+			return frequencies[i].Frequency > frequencies[j].Frequency
+		}
+		return frequencies[i].Word < frequencies[j].Word
+	})
+
+	if len(frequencies) > maxWordCloudWords {
+		frequencies = frequencies[:maxWordCloudWords]
+	}
+	return frequencies
+}
+
+// tokenizeWords lowercases text and splits it into words, stripping punctuation and possessives. Single
+// characters and numbers are dropped.
+func tokenizeWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		// Fold possessives into the base word before dropping apostrophes
+		word := strings.TrimSuffix(strings.TrimSuffix(field, "'s"), "’s")
+		word = strings.NewReplacer("'", "", "’", "").Replace(word)
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// wordBox is the area occupied by a placed word in the word cloud
+type wordBox struct {
+	x, y, width, height float64
+}
+
+// overlaps reports whether two boxes intersect
+func (b wordBox) overlaps(other wordBox) bool {
+	return b.x < other.x+other.width && other.x < b.x+b.width &&
+		b.y < other.y+other.height && other.y < b.y+b.height
+}
+
+// RenderWordCloudSVG lays the words out on a spiral from the centre of the canvas, larger words first, and
+// returns the SVG document. Words that don't fit are left out.
+func RenderWordCloudSVG(words []WordFrequency) string {
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		wordCloudWidth, wordCloudHeight, wordCloudWidth, wordCloudHeight))
+	svg.WriteString("\n")
+
+	if len(words) > 0 {
+		maxFrequency := float64(words[0].Frequency)
+		minFrequency := float64(words[len(words)-1].Frequency)
+
+		var placed []wordBox
+		for i, word := range words {
+			fontSize := float64(wordCloudMaxFontSize)
+			if maxFrequency > minFrequency {
+				scale := (float64(word.Frequency) - minFrequency) / (maxFrequency - minFrequency)
+				fontSize = wordCloudMinFontSize + scale*(wordCloudMaxFontSize-wordCloudMinFontSize)
+			}
+
+			// Approximate text metrics, glyphs average around 0.6em wide
+			width := fontSize * 0.6 * float64(len([]rune(word.Word)))
+			height := fontSize
+
+			box, ok := placeWord(width, height, placed)
+			if !ok {
+				continue
+			}
+			placed = append(placed, box)
+
+			svg.WriteString(fmt.Sprintf(`  <text x="%.1f" y="%.1f" font-family="sans-serif" font-size="%.1f" fill="%s">%s</text>`,
+				box.x, box.y+height*0.8, fontSize, wordCloudColor(i), html.EscapeString(word.Word)))
+			svg.WriteString("\n")
+		}
+	}
+
+	svg.WriteString("</svg>\n")
+	return svg.String()
+}
+
+// placeWord finds the first position on an Archimedean spiral where the word fits without overlapping
+func placeWord(width, height float64, placed []wordBox) (wordBox, bool) {
+	centerX, centerY := wordCloudWidth/2.0, wordCloudHeight/2.0
+	maxRadius := math.Hypot(centerX, centerY)
+
+	for angle := 0.0; ; angle += 0.1 {
+		radius := 2 * angle
+		if radius > maxRadius {
+			return wordBox{}, false
+		}
+
+		box := wordBox{
+			x:      centerX + radius*math.Cos(angle) - width/2,
+			y:      centerY + radius*math.Sin(angle) - height/2,
+			width:  width,
+			height: height,
+		}
+		if box.x < 0 || box.y < 0 || box.x+box.width > wordCloudWidth || box.y+box.height > wordCloudHeight {
+			continue
+		}
+
+		fits := true
+		for _, other := range placed {
+			if box.overlaps(other) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return box, true
+		}
+	}
+}
+
+// wordCloudColors is the palette cycled through for word cloud words
+var wordCloudColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#17becf"}
+
+// wordCloudColor returns the palette colour for the i-th word
+func wordCloudColor(i int) string {
+	return wordCloudColors[i%len(wordCloudColors)]
+}
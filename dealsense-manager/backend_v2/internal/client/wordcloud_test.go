@@ -0,0 +1,60 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeWordFrequencies(t *testing.T) {
+	transcript := []TranscriptEntry{
+		{Speaker: "Alice", Text: "The launch is Friday. Launch day!"},
+		{Speaker: "Bob", Text: "Friday's launch needs the budget, and the budget's approved."},
+		{Speaker: "Carol", Text: "Um, I think 2 people approved it"},
+	}
+
+	got := computeWordFrequencies(transcript, defaultStopwords)
+	want := []WordFrequency{
+		{Word: "launch", Frequency: 3},
+		{Word: "approved", Frequency: 2},
+		{Word: "budget", Frequency: 2},
+		{Word: "friday", Frequency: 2},
+		{Word: "day", Frequency: 1},
+		{Word: "needs", Frequency: 1},
+		{Word: "people", Frequency: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frequencies = %+v, want %+v", got, want)
+	}
+}
+
+func TestWordCloudCustomStopwords(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	analyst.config.WordCloudStopwords = []string{" Launch "}
+
+	got := computeWordFrequencies([]TranscriptEntry{{Text: "launch budget launch"}}, analyst.stopwords())
+	if !reflect.DeepEqual(got, []WordFrequency{{Word: "budget", Frequency: 1}}) {
+		t.Errorf("frequencies = %+v, want the configured stopword excluded", got)
+	}
+	if !analyst.stopwords()["the"] {
+		t.Error("default stopwords dropped when custom ones are configured")
+	}
+}
+
+func TestTokenizeWords(t *testing.T) {
+	got := tokenizeWords("Sam’s team can't ship Q3's 42 builds - a B2B release")
+	want := []string{"sam", "team", "cant", "ship", "q3", "builds", "b2b", "release"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenizeWords() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderWordCloudSVG(t *testing.T) {
+	svg := RenderWordCloudSVG([]WordFrequency{{Word: "launch", Frequency: 3}, {Word: "<budget>", Frequency: 1}})
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, ">launch<") {
+		t.Errorf("svg = %s", svg)
+	}
+	if strings.Contains(svg, "<budget>") {
+		t.Error("word not escaped in SVG")
+	}
+}
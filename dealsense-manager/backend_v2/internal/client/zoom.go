@@ -0,0 +1,129 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// zoomRefineSearch is the number of recent transcript entries searched for the entry a refined caption updates
+const zoomRefineSearch = 20
+
+// ErrInvalidZoomCaption is returned for Zoom webhook payloads that don't hold a caption
+var ErrInvalidZoomCaption = errors.New("invalid Zoom caption payload")
+
+// zoomCaptionObject is the caption object of a Zoom closed-caption webhook
+type zoomCaptionObject struct {
+	Participant struct {
+		UserID   string `json:"user_id"`
+		UserName string `json:"user_name"`
+	} `json:"participant"`
+	ClosedCaptionID string `json:"closed_caption_id"`
+	Text            string `json:"text"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// zoomCaption identifies the transcript entry added for a Zoom caption, so later parts of the same caption
+// replace its text instead of adding new entries
+type zoomCaption struct {
+	id        string
+	speaker   string
+	timestamp time.Time
+}
+
+// ProcessZoomCaption adds a caption from a Zoom closed-caption webhook to the transcript. Zoom sends a
+// caption again with the same closed_caption_id as recognition refines it; those updates replace the text
+// of the caption's entry.
+func (a *AnalystAgent) ProcessZoomCaption(payload []byte) error {
+	caption, err := parseZoomCaption(payload)
+	if err != nil {
+		return err
+	}
+
+	speaker := strings.TrimSpace(caption.Participant.UserName)
+	if speaker == "" {
+		speaker = "Participant"
+	}
+	timestamp := time.Now()
+	if caption.Timestamp != "" {
+		if timestamp, err = time.Parse(time.RFC3339Nano, caption.Timestamp); err != nil {
+			return fmt.Errorf("%w: timestamp %q is not RFC3339", ErrInvalidZoomCaption, caption.Timestamp)
+		}
+	}
+	// ProcessUtterance keeps whole seconds
+	timestamp = time.Unix(timestamp.Unix(), 0)
+
+	a.zoomMutex.Lock()
+	defer a.zoomMutex.Unlock()
+
+	if caption.ClosedCaptionID != "" && a.refineZoomCaption(caption.ClosedCaptionID, caption.Text) {
+		return nil
+	}
+
+	a.ProcessUtterance([]map[string]interface{}{{
+		"speaker":   speaker,
+		"text":      caption.Text,
+		"timestamp": float64(timestamp.Unix()),
+	}})
+
+	a.dataMutex.Lock()
+	a.lastZoomCaption = &zoomCaption{id: caption.ClosedCaptionID, speaker: speaker, timestamp: timestamp}
+	a.dataMutex.Unlock()
+	return nil
+}
+
+// refineZoomCaption replaces the text of the entry added for the latest caption when id matches it,
+// reporting false when there is no such entry
+func (a *AnalystAgent) refineZoomCaption(id, text string) bool {
+	a.dataMutex.Lock()
+	defer a.dataMutex.Unlock()
+
+	last := a.lastZoomCaption
+	if last == nil || last.id != id {
+		return false
+	}
+
+	for i := len(a.data.Transcript) - 1; i >= 0 && i >= len(a.data.Transcript)-zoomRefineSearch; i-- {
+		entry := &a.data.Transcript[i]
+		if entry.Speaker != last.speaker || !entry.Timestamp.Equal(last.timestamp) {
+			continue
+		}
+
+		if a.normalizer != nil {
+			text = a.normalizer.Normalize(text)
+		}
+		if text != "" && text != entry.Text {
+			a.data.WordCount += len(strings.Fields(text)) - len(strings.Fields(entry.Text))
+			entry.Text = text
+			a.data.LastUpdated = time.Now()
+			a.queueSave()
+		}
+		return true
+	}
+	return false
+}
+
+// parseZoomCaption extracts the caption object from a Zoom webhook body, which holds it either at the top
+// level or inside the event envelope's payload
+func parseZoomCaption(payload []byte) (*zoomCaptionObject, error) {
+	var body struct {
+		Object  *zoomCaptionObject `json:"object"`
+		Payload struct {
+			Object *zoomCaptionObject `json:"object"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidZoomCaption, err)
+	}
+
+	caption := body.Object
+	if caption == nil {
+		caption = body.Payload.Object
+	}
+	if caption == nil || strings.TrimSpace(caption.Text) == "" {
+		return nil, fmt.Errorf("%w: missing object.text", ErrInvalidZoomCaption)
+	}
+	return caption, nil
+}
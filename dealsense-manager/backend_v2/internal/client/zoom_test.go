@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func zoomPayload(id, speaker, text, timestamp string) []byte {
+	return []byte(`{"event": "meeting.closed_caption", "payload": {"object": {"participant": {"user_name": "` + speaker +
+		`"}, "closed_caption_id": "` + id + `", "text": "` + text + `", "timestamp": "` + timestamp + `"}}}`)
+}
+
+func TestZoomCaptionRefinements(t *testing.T) {
+	analyst := newTestAnalyst(t)
+
+	for _, payload := range [][]byte{
+		zoomPayload("cc-1", "Alice", "we should", "2024-05-01T10:00:00.250Z"),
+		zoomPayload("cc-1", "Alice", "we should ship", "2024-05-01T10:00:01Z"),
+		zoomPayload("cc-1", "Alice", "we should ship on Friday", "2024-05-01T10:00:02Z"),
+		zoomPayload("cc-2", "Bob", "Agreed, Friday works", "2024-05-01T10:00:05Z"),
+		// A late refinement of an earlier caption is added as a new entry
+		zoomPayload("cc-1", "Alice", "we should ship on Friday morning", "2024-05-01T10:00:06Z"),
+	} {
+		if err := analyst.ProcessZoomCaption(payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := analyst.GetAnalysis()
+	var texts []string
+	for _, entry := range data.Transcript {
+		texts = append(texts, entry.Speaker+": "+entry.Text)
+	}
+	want := []string{"Alice: we should ship on Friday", "Bob: Agreed, Friday works", "Alice: we should ship on Friday morning"}
+	if len(texts) != len(want) {
+		t.Fatalf("transcript = %q, want %q", texts, want)
+	}
+	for i := range want {
+		if texts[i] != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, texts[i], want[i])
+		}
+	}
+	if !data.Transcript[0].Timestamp.Equal(testMeetingStart) {
+		t.Errorf("first entry at %v, want the first part's time in whole seconds", data.Transcript[0].Timestamp)
+	}
+	if data.WordCount != 5+3+6 {
+		t.Errorf("word count = %d, want refined text counted once", data.WordCount)
+	}
+}
+
+func TestZoomCaptionTopLevelObject(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	payload := []byte(`{"object": {"participant": {"user_name": " "}, "text": "Hello everyone", "timestamp": "2024-05-01T10:00:00Z"}}`)
+	if err := analyst.ProcessZoomCaption(payload); err != nil {
+		t.Fatal(err)
+	}
+	if transcript := analyst.GetAnalysis().Transcript; len(transcript) != 1 || transcript[0].Speaker != "Participant" {
+		t.Errorf("transcript = %+v, want an unnamed participant", transcript)
+	}
+}
+
+func TestZoomCaptionInvalidPayloads(t *testing.T) {
+	analyst := newTestAnalyst(t)
+	for _, payload := range []string{
+		`not json`,
+		`{"event": "meeting.started"}`,
+		`{"object": {"text": "  "}}`,
+		`{"object": {"text": "Hello", "timestamp": "yesterday"}}`,
+	} {
+		if err := analyst.ProcessZoomCaption([]byte(payload)); !errors.Is(err, ErrInvalidZoomCaption) {
+			t.Errorf("%s: %v, want ErrInvalidZoomCaption", payload, err)
+		}
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -21,6 +23,15 @@ type Config struct {
 	Logging  LoggingConfig  `yaml:"logging"`
 	Joinly   JoinlyConfig   `yaml:"joinly"`
 	Database DatabaseConfig `yaml:"database"`
+	LLM      LLMConfig      `yaml:"llm"`
+	Analysis AnalysisConfig `yaml:"analysis"`
+	Email    EmailConfig    `yaml:"email"`
+	Calendar CalendarConfig `yaml:"calendar"`
+	GitHub   GitHubConfig   `yaml:"github"`
+	Teams    TeamsConfig    `yaml:"teams"`
+	Kafka    KafkaConfig    `yaml:"kafka"`
+	Realtime RealtimeConfig `yaml:"realtime"`
+	Archive  ArchiveConfig  `yaml:"archive"`
 }
 
 // ServerConfig represents the server configuration
@@ -30,6 +41,11 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `yaml:"read_timeout"`
 	WriteTimeout time.Duration `yaml:"write_timeout"`
 	CORS         CORSConfig    `yaml:"cors"`
+	APISecret    string        `yaml:"api_secret"` // Bearer token required by the REST API, disabled when empty
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // Time allowed for analyst agents to finalize on shutdown
+
+	ReportFlushInterval time.Duration `yaml:"report_flush_interval"` // Least time between flushes of a streamed report, 0 to flush every section
 }
 
 // CORSConfig represents CORS configuration
@@ -44,6 +60,7 @@ type LoggingConfig struct {
 	Level   string               `yaml:"level"`
 	Format  string               `yaml:"format"`
 	Discord DiscordWebhookConfig `yaml:"discord"`
+	File    FileLogConfig        `yaml:"file"`
 }
 
 // DiscordWebhookConfig holds the configuration for Discord webhooks
@@ -56,14 +73,32 @@ type DiscordWebhookConfig struct {
 	Enabled       bool   `yaml:"enabled"`
 	GeminiEnabled bool   `yaml:"gemini_enabled"`
 	Username      string `yaml:"username"`
+
+	EmbedFieldFilter FieldFilterConfig `yaml:"embed_field_filter"`
+
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"` // How often webhooks are checked, every 5 minutes when 0
+}
+
+// FieldFilterConfig controls which log fields are shown in Discord embeds. With both lists empty every
+// field is shown.
+type FieldFilterConfig struct {
+	AllowList []string `yaml:"allow_list"` // Only show these fields
+	DenyList  []string `yaml:"deny_list"`  // Never show these fields
+	MaxFields int      `yaml:"max_fields"` // Show at most this many fields, unlimited when 0
 }
 
 // DiscordHook is a logrus hook for sending logs to Discord webhooks
 type DiscordHook struct {
 	config     DiscordWebhookConfig
 	httpClient *http.Client
+	capture    *CapturedMessages // Set in test mode, where messages are captured instead of sent
+	demoted    sync.Map          // Webhook URLs that failed repeated health checks and are skipped
 }
 
+// DiscordContentField is the log field posted as the Discord message's content, shown above the embed,
+// rather than as an embed field
+const DiscordContentField = "discord_content"
+
 // DiscordMessage represents the payload sent to Discord webhooks
 type DiscordMessage struct {
 	Username  string         `json:"username,omitempty"`
@@ -143,8 +178,15 @@ func (hook *DiscordHook) Fire(entry *logrus.Entry) error {
 	if webhook == "" {
 		return nil // No webhook configured for this level
 	}
+	if _, demoted := hook.demoted.Load(webhook); demoted {
+		return nil
+	}
 
 	message := hook.createDiscordMessage(entry)
+	if hook.capture != nil {
+		hook.capture.add(entry.Level, message)
+		return nil
+	}
 	return hook.sendToDiscord(webhook, message)
 }
 
@@ -189,12 +231,8 @@ func (hook *DiscordHook) createDiscordMessage(entry *logrus.Entry) DiscordMessag
 
 	// Add fields for any additional data
 	if len(entry.Data) > 0 {
-		for key, value := range entry.Data {
-			// Skip internal logrus fields
-			if key == "level" || key == "msg" || key == "time" {
-				continue
-			}
-
+		for _, key := range hook.embedFieldKeys(entry.Data) {
+			value := entry.Data[key]
 			fieldValue := fmt.Sprintf("%v", value)
 			// Truncate long values
 			if len(fieldValue) > 1024 {
@@ -209,12 +247,50 @@ func (hook *DiscordHook) createDiscordMessage(entry *logrus.Entry) DiscordMessag
 		}
 	}
 
+	content, _ := entry.Data[DiscordContentField].(string)
 	return DiscordMessage{
 		Username: hook.config.Username,
+		Content:  content,
 		Embeds:   []DiscordEmbed{embed},
 	}
 }
 
+// embedFieldKeys returns the names of the entry fields to show in an embed, sorted alphabetically
+func (hook *DiscordHook) embedFieldKeys(data logrus.Fields) []string {
+	filter := hook.config.EmbedFieldFilter
+	allow := fieldNameSet(filter.AllowList)
+	deny := fieldNameSet(filter.DenyList)
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		// Skip internal logrus fields
+		if key == "level" || key == "msg" || key == "time" || key == DiscordContentField {
+			continue
+		}
+
+		name := strings.ToLower(key)
+		if (len(allow) > 0 && !allow[name]) || deny[name] {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if filter.MaxFields > 0 && len(keys) > filter.MaxFields {
+		keys = keys[:filter.MaxFields]
+	}
+	return keys
+}
+
+// fieldNameSet returns the lowercased field names as a set
+func fieldNameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
+
 // getColorForLevel returns the Discord embed color for the given log level
 func (hook *DiscordHook) getColorForLevel(level logrus.Level) int {
 	switch level {
@@ -280,10 +356,120 @@ type JoinlyConfig struct {
 	MaxAgents      int           `yaml:"max_agents"`
 }
 
+// LLMConfig represents LLM provider configuration
+type LLMConfig struct {
+	GeminiFallbackModels []string      `yaml:"gemini_fallback_models"`
+	GeminiCacheTTL       time.Duration `yaml:"gemini_cache_ttl"` // How long identical Gemini prompts are answered from cache, 0 to disable
+	AuditLogPath         string        `yaml:"audit_log_path"`
+
+	// Grounding source domains filtered for every agent
+	GroundingBlacklistDomains []string `yaml:"grounding_blacklist_domains"`
+	GroundingWhitelistDomains []string `yaml:"grounding_whitelist_domains"`
+}
+
+// AnalysisConfig represents meeting analysis configuration
+type AnalysisConfig struct {
+	MaxDLQRetries int    `yaml:"max_dlq_retries"`
+	RedisURL      string `yaml:"redis_url"` // Analysis updates are published to Redis when set
+
+	DefaultHourlyRateUSD float64 `yaml:"default_hourly_rate_usd"` // Assumed for participants without a rate when estimating meeting cost
+	KnowledgeBasePath    string  `yaml:"knowledge_base_path"`     // JSON file of internal terms explained in analysis prompts
+	SystemPromptPrefix   string  `yaml:"system_prompt_prefix"`    // Operator instructions placed before every analysis prompt
+
+	ProductFeedbackWebhookURL string `yaml:"product_feedback_webhook_url"` // Extracted product feedback is posted here when set
+	SalesObjectionWebhookURL  string `yaml:"sales_objection_webhook_url"`  // Each objection detected in a sales call is posted here when set
+	CRMWebhookURL             string `yaml:"crm_webhook_url"`              // Key contacts of finalized meetings are posted here when set
+	HRNotificationEmail       string `yaml:"hr_notification_email"`        // High-severity ethics flags are emailed here when set
+	ExternalNLPAPIKey         string `yaml:"external_nlp_api_key"`         // Bearer token for agents' external NLP services
+
+	DailyBudgetUSD        float64 `yaml:"daily_budget_usd"`        // Estimated LLM spend allowed per day across all agents, 0 for no budget
+	OutputTokenMultiplier float64 `yaml:"output_token_multiplier"` // Estimated response tokens per prompt token, 0 for the default of 0.4
+	HardBudgetStop        bool    `yaml:"hard_budget_stop"`        // Skip analyses that would exceed the daily budget instead of only logging them
+
+	EnableROIReport bool `yaml:"enable_roi_report"` // Report each finalized meeting's cost per outcome and whether it could have been async
+
+	MetricsTrackingEnabled bool `yaml:"metrics_tracking_enabled"` // Track success metrics for new agents that don't enable it themselves
+
+	SpeechMinConfidence float64  `yaml:"speech_min_confidence"` // Utterance segments with a lower transcription confidence are dropped
+	SpeechNoisePatterns []string `yaml:"speech_noise_patterns"` // Regular expressions of segment text dropped as noise, besides placeholders like [inaudible]
+}
+
+// EmailConfig represents mail provider configuration for post-meeting digest emails
+type EmailConfig struct {
+	Provider           string `yaml:"provider"` // "smtp" or "sendgrid"
+	SMTPHost           string `yaml:"smtp_host"`
+	SMTPPort           int    `yaml:"smtp_port"`
+	SMTPUser           string `yaml:"smtp_user"`
+	SMTPPass           string `yaml:"smtp_pass"`
+	SMTPFrom           string `yaml:"smtp_from"`
+	SendGridAPIKey     string `yaml:"sendgrid_api_key"`
+	SendGridFrom       string `yaml:"sendgrid_from_email"`
+	SendGridTemplateID string `yaml:"sendgrid_template_id"`
+	Disabled           bool   `yaml:"disabled"`
+}
+
+// CalendarConfig represents Google Calendar configuration for drafting follow-up meetings
+type CalendarConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	AccessToken string `yaml:"access_token"` // OAuth access token with the calendar.events scope
+
+	// OAuth client credentials and refreshable token files, used instead of AccessToken when both are set
+	CredentialsFile string `yaml:"credentials_file"`
+	TokenFile       string `yaml:"token_file"`
+
+	CalendarID string `yaml:"calendar_id"`
+}
+
+// GitHubConfig represents the GitHub repository technical debt from meetings is filed in as issues
+type GitHubConfig struct {
+	Token      string `yaml:"token"`      // Token allowed to create issues in the repository
+	Repository string `yaml:"repository"` // owner/name, exporting is disabled when empty
+	APIURL     string `yaml:"api_url"`    // REST API of GitHub Enterprise Server, github.com when empty
+}
+
+// TeamsConfig represents the Azure AD app used to read Microsoft Teams transcripts from the Graph API
+type TeamsConfig struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	TenantID     string `yaml:"tenant_id"`
+}
+
+// KafkaConfig represents the Kafka topic analyst agents consume transcript segments from
+type KafkaConfig struct {
+	Brokers    []string `yaml:"brokers"`
+	Topic      string   `yaml:"topic"`
+	GroupID    string   `yaml:"group_id"` // Prefix of each agent's consumer group, suffixed with the agent ID
+	TLSEnabled bool     `yaml:"tls_enabled"`
+}
+
+// RealtimeConfig represents the OpenAI Realtime API WebSocket analyst agents consume transcripts from
+type RealtimeConfig struct {
+	URL    string `yaml:"url"`
+	APIKey string `yaml:"api_key"`
+}
+
+// ArchiveConfig holds the credentials finalized analyses are archived to S3 with. Google Cloud Storage
+// archives use Application Default Credentials.
+type ArchiveConfig struct {
+	S3Region          string `yaml:"s3_region"`
+	S3AccessKeyID     string `yaml:"s3_access_key_id"`
+	S3SecretAccessKey string `yaml:"s3_secret_access_key"`
+	S3SessionToken    string `yaml:"s3_session_token"`
+	S3Endpoint        string `yaml:"s3_endpoint"` // S3-compatible service to use instead of AWS, with path-style URLs
+}
+
 // DatabaseConfig represents database configuration (for future use)
 type DatabaseConfig struct {
-	Type string `yaml:"type"`
-	URL  string `yaml:"url"`
+	Type      string          `yaml:"type"`
+	URL       string          `yaml:"url"`
+	Retention RetentionPolicy `yaml:"retention"`
+}
+
+// RetentionPolicy controls how long saved analysis files are kept
+type RetentionPolicy struct {
+	MaxAgeDays      int  `yaml:"max_age_days"`     // Files not modified for this long are deleted
+	MaxStorageMB    int  `yaml:"max_storage_mb"`   // Oldest files are deleted while the directory is larger than this
+	VerifyIntegrity bool `yaml:"verify_integrity"` // Files that don't match their stored checksum are kept instead
 }
 
 // DefaultConfig returns the default configuration
@@ -294,6 +480,8 @@ func DefaultConfig() *Config {
 			Port:         8001,
 			ReadTimeout:  30 * time.Second,
 			WriteTimeout: 30 * time.Second,
+
+			ShutdownTimeout: 2 * time.Minute,
 			CORS: CORSConfig{
 				AllowedOrigins: []string{"http://localhost:3000"},
 				AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
@@ -307,6 +495,14 @@ func DefaultConfig() *Config {
 				Enabled:  false,
 				Username: "Joinly Bot",
 			},
+			File: FileLogConfig{
+				Enabled:    false,
+				FilePath:   "logs/dealsense.log",
+				MaxSizeMB:  100,
+				MaxBackups: 5,
+				MaxAgeDays: 30,
+				Compress:   true,
+			},
 		},
 		Joinly: JoinlyConfig{
 			DefaultURL:     "http://135.235.237.143:8000/mcp/",
@@ -316,6 +512,26 @@ func DefaultConfig() *Config {
 		Database: DatabaseConfig{
 			Type: "memory",
 			URL:  "",
+			Retention: RetentionPolicy{
+				MaxAgeDays:      90,
+				MaxStorageMB:    1000,
+				VerifyIntegrity: true,
+			},
+		},
+		Analysis: AnalysisConfig{
+			MaxDLQRetries:        5,
+			DefaultHourlyRateUSD: 75,
+			SpeechMinConfidence:  0.4,
+		},
+		Email: EmailConfig{
+			Provider: "smtp",
+			SMTPPort: 587,
+		},
+		Calendar: CalendarConfig{
+			CalendarID: "primary",
+		},
+		Archive: ArchiveConfig{
+			S3Region: "us-east-1",
 		},
 	}
 }
@@ -355,6 +571,22 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	if apiSecret := os.Getenv("API_SECRET"); apiSecret != "" {
+		cfg.Server.APISecret = apiSecret
+	}
+
+	if shutdownTimeout := os.Getenv("SHUTDOWN_TIMEOUT"); shutdownTimeout != "" {
+		if st, err := time.ParseDuration(shutdownTimeout); err == nil {
+			cfg.Server.ShutdownTimeout = st
+		}
+	}
+
+	if flushInterval := os.Getenv("REPORT_FLUSH_INTERVAL"); flushInterval != "" {
+		if fi, err := time.ParseDuration(flushInterval); err == nil {
+			cfg.Server.ReportFlushInterval = fi
+		}
+	}
+
 	if level := os.Getenv("LOG_LEVEL"); level != "" {
 		cfg.Logging.Level = level
 	}
@@ -392,10 +624,61 @@ func LoadConfig() (*Config, error) {
 		cfg.Logging.Discord.GeminiWebhook = geminiWebhook
 	}
 
+	if interval := os.Getenv("WEBHOOK_HEALTH_CHECK_INTERVAL"); interval != "" {
+		if hci, err := time.ParseDuration(interval); err == nil {
+			cfg.Logging.Discord.HealthCheckInterval = hci
+		}
+	}
+
 	if username := os.Getenv("DISCORD_BOT_USERNAME"); username != "" {
 		cfg.Logging.Discord.Username = username
 	}
 
+	if maxFields := os.Getenv("DISCORD_MAX_EMBED_FIELDS"); maxFields != "" {
+		if mf, err := strconv.Atoi(maxFields); err == nil {
+			cfg.Logging.Discord.EmbedFieldFilter.MaxFields = mf
+		}
+	}
+
+	if allowFields := os.Getenv("DISCORD_EMBED_ALLOW_FIELDS"); allowFields != "" {
+		cfg.Logging.Discord.EmbedFieldFilter.AllowList = splitCommaList(allowFields)
+	}
+
+	if denyFields := os.Getenv("DISCORD_EMBED_DENY_FIELDS"); denyFields != "" {
+		cfg.Logging.Discord.EmbedFieldFilter.DenyList = splitCommaList(denyFields)
+	}
+
+	// File logging configuration
+	if os.Getenv("LOG_FILE_ENABLED") == "true" {
+		cfg.Logging.File.Enabled = true
+	}
+
+	if filePath := os.Getenv("LOG_FILE_PATH"); filePath != "" {
+		cfg.Logging.File.FilePath = filePath
+	}
+
+	if maxSize := os.Getenv("LOG_MAX_SIZE_MB"); maxSize != "" {
+		if ms, err := strconv.Atoi(maxSize); err == nil {
+			cfg.Logging.File.MaxSizeMB = ms
+		}
+	}
+
+	if maxBackups := os.Getenv("LOG_MAX_BACKUPS"); maxBackups != "" {
+		if mb, err := strconv.Atoi(maxBackups); err == nil {
+			cfg.Logging.File.MaxBackups = mb
+		}
+	}
+
+	if maxAge := os.Getenv("LOG_MAX_AGE_DAYS"); maxAge != "" {
+		if ma, err := strconv.Atoi(maxAge); err == nil {
+			cfg.Logging.File.MaxAgeDays = ma
+		}
+	}
+
+	if compress := os.Getenv("LOG_COMPRESS"); compress != "" {
+		cfg.Logging.File.Compress = compress == "true"
+	}
+
 	if url := os.Getenv("JOINLY_URL"); url != "" {
 		cfg.Joinly.DefaultURL = url
 	}
@@ -406,9 +689,283 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	if fallbackModels := os.Getenv("GEMINI_FALLBACK_MODELS"); fallbackModels != "" {
+		cfg.LLM.GeminiFallbackModels = splitCommaList(fallbackModels)
+	}
+
+	if cacheTTL := os.Getenv("GEMINI_CACHE_TTL"); cacheTTL != "" {
+		if ttl, err := time.ParseDuration(cacheTTL); err == nil {
+			cfg.LLM.GeminiCacheTTL = ttl
+		}
+	}
+
+	if blacklist := os.Getenv("GROUNDING_BLACKLIST_DOMAINS"); blacklist != "" {
+		cfg.LLM.GroundingBlacklistDomains = splitCommaList(blacklist)
+	}
+
+	if whitelist := os.Getenv("GROUNDING_WHITELIST_DOMAINS"); whitelist != "" {
+		cfg.LLM.GroundingWhitelistDomains = splitCommaList(whitelist)
+	}
+
+	if auditLogPath := os.Getenv("AUDIT_LOG_PATH"); auditLogPath != "" {
+		cfg.LLM.AuditLogPath = auditLogPath
+	}
+
+	if maxRetries := os.Getenv("MAX_DLQ_RETRIES"); maxRetries != "" {
+		if mr, err := strconv.Atoi(maxRetries); err == nil {
+			cfg.Analysis.MaxDLQRetries = mr
+		}
+	}
+
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		cfg.Analysis.RedisURL = redisURL
+	}
+
+	if hourlyRate := os.Getenv("DEFAULT_HOURLY_RATE_USD"); hourlyRate != "" {
+		if rate, err := strconv.ParseFloat(hourlyRate, 64); err == nil && rate > 0 {
+			cfg.Analysis.DefaultHourlyRateUSD = rate
+		}
+	}
+
+	if knowledgeBasePath := os.Getenv("KNOWLEDGE_BASE_PATH"); knowledgeBasePath != "" {
+		cfg.Analysis.KnowledgeBasePath = knowledgeBasePath
+	}
+
+	if systemPromptPrefix := os.Getenv("SYSTEM_PROMPT_PREFIX"); systemPromptPrefix != "" {
+		cfg.Analysis.SystemPromptPrefix = systemPromptPrefix
+	}
+
+	if productFeedbackWebhookURL := os.Getenv("PRODUCT_FEEDBACK_WEBHOOK_URL"); productFeedbackWebhookURL != "" {
+		cfg.Analysis.ProductFeedbackWebhookURL = productFeedbackWebhookURL
+	}
+
+	if salesObjectionWebhookURL := os.Getenv("SALES_OBJECTION_WEBHOOK_URL"); salesObjectionWebhookURL != "" {
+		cfg.Analysis.SalesObjectionWebhookURL = salesObjectionWebhookURL
+	}
+
+	if crmWebhookURL := os.Getenv("CRM_WEBHOOK_URL"); crmWebhookURL != "" {
+		cfg.Analysis.CRMWebhookURL = crmWebhookURL
+	}
+
+	if hrNotificationEmail := os.Getenv("HR_NOTIFICATION_EMAIL"); hrNotificationEmail != "" {
+		cfg.Analysis.HRNotificationEmail = hrNotificationEmail
+	}
+
+	if externalNLPAPIKey := os.Getenv("EXTERNAL_NLP_API_KEY"); externalNLPAPIKey != "" {
+		cfg.Analysis.ExternalNLPAPIKey = externalNLPAPIKey
+	}
+
+	if dailyBudget := os.Getenv("LLM_DAILY_BUDGET_USD"); dailyBudget != "" {
+		if budget, err := strconv.ParseFloat(dailyBudget, 64); err == nil && budget >= 0 {
+			cfg.Analysis.DailyBudgetUSD = budget
+		}
+	}
+
+	if outputMultiplier := os.Getenv("LLM_OUTPUT_TOKEN_MULTIPLIER"); outputMultiplier != "" {
+		if multiplier, err := strconv.ParseFloat(outputMultiplier, 64); err == nil && multiplier > 0 {
+			cfg.Analysis.OutputTokenMultiplier = multiplier
+		}
+	}
+
+	if hardStop := os.Getenv("LLM_HARD_BUDGET_STOP"); hardStop != "" {
+		cfg.Analysis.HardBudgetStop = hardStop == "true"
+	}
+
+	if roiReport := os.Getenv("ENABLE_ROI_REPORT"); roiReport != "" {
+		cfg.Analysis.EnableROIReport = roiReport == "true"
+	}
+
+	if metricsTracking := os.Getenv("METRICS_TRACKING_ENABLED"); metricsTracking != "" {
+		cfg.Analysis.MetricsTrackingEnabled = metricsTracking == "true"
+	}
+
+	if minConfidence := os.Getenv("SPEECH_MIN_CONFIDENCE"); minConfidence != "" {
+		if confidence, err := strconv.ParseFloat(minConfidence, 64); err == nil {
+			cfg.Analysis.SpeechMinConfidence = confidence
+		}
+	}
+
+	if noisePatterns := os.Getenv("SPEECH_NOISE_PATTERNS"); noisePatterns != "" {
+		cfg.Analysis.SpeechNoisePatterns = splitCommaList(noisePatterns)
+	}
+
+	if maxAgeDays := os.Getenv("ANALYSIS_MAX_AGE_DAYS"); maxAgeDays != "" {
+		if days, err := strconv.Atoi(maxAgeDays); err == nil {
+			cfg.Database.Retention.MaxAgeDays = days
+		}
+	}
+
+	if maxStorageMB := os.Getenv("ANALYSIS_MAX_STORAGE_MB"); maxStorageMB != "" {
+		if mb, err := strconv.Atoi(maxStorageMB); err == nil {
+			cfg.Database.Retention.MaxStorageMB = mb
+		}
+	}
+
+	if verifyIntegrity := os.Getenv("ANALYSIS_VERIFY_INTEGRITY"); verifyIntegrity != "" {
+		cfg.Database.Retention.VerifyIntegrity = verifyIntegrity == "true"
+	}
+
+	// Mail provider configuration for post-meeting digests
+	if mailerProvider := os.Getenv("MAILER_PROVIDER"); mailerProvider != "" {
+		switch mailerProvider {
+		case "smtp", "sendgrid":
+			cfg.Email.Provider = mailerProvider
+		default:
+			return nil, fmt.Errorf("invalid MAILER_PROVIDER %q: must be smtp or sendgrid", mailerProvider)
+		}
+	}
+
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		cfg.Email.SMTPHost = smtpHost
+	}
+
+	if smtpPort := os.Getenv("SMTP_PORT"); smtpPort != "" {
+		if p, err := strconv.Atoi(smtpPort); err == nil {
+			cfg.Email.SMTPPort = p
+		}
+	}
+
+	if smtpUser := os.Getenv("SMTP_USER"); smtpUser != "" {
+		cfg.Email.SMTPUser = smtpUser
+	}
+
+	if smtpPass := os.Getenv("SMTP_PASS"); smtpPass != "" {
+		cfg.Email.SMTPPass = smtpPass
+	}
+
+	if smtpFrom := os.Getenv("SMTP_FROM"); smtpFrom != "" {
+		cfg.Email.SMTPFrom = smtpFrom
+	}
+
+	if sendGridAPIKey := os.Getenv("SENDGRID_API_KEY"); sendGridAPIKey != "" {
+		cfg.Email.SendGridAPIKey = sendGridAPIKey
+	}
+
+	if sendGridFrom := os.Getenv("SENDGRID_FROM_EMAIL"); sendGridFrom != "" {
+		cfg.Email.SendGridFrom = sendGridFrom
+	}
+
+	if sendGridTemplateID := os.Getenv("SENDGRID_TEMPLATE_ID"); sendGridTemplateID != "" {
+		cfg.Email.SendGridTemplateID = sendGridTemplateID
+	}
+
+	if os.Getenv("DISABLE_EMAIL") == "true" {
+		cfg.Email.Disabled = true
+	}
+
+	// Google Calendar configuration for follow-up meeting drafts
+	if os.Getenv("GOOGLE_CALENDAR_ENABLED") == "true" {
+		cfg.Calendar.Enabled = true
+	}
+
+	if accessToken := os.Getenv("GOOGLE_CALENDAR_ACCESS_TOKEN"); accessToken != "" {
+		cfg.Calendar.AccessToken = accessToken
+	}
+
+	if credentialsFile := os.Getenv("GOOGLE_CALENDAR_CREDENTIALS_FILE"); credentialsFile != "" {
+		cfg.Calendar.CredentialsFile = credentialsFile
+	}
+
+	if tokenFile := os.Getenv("GOOGLE_CALENDAR_TOKEN_FILE"); tokenFile != "" {
+		cfg.Calendar.TokenFile = tokenFile
+	}
+
+	if calendarID := os.Getenv("GOOGLE_CALENDAR_ID"); calendarID != "" {
+		cfg.Calendar.CalendarID = calendarID
+	}
+
+	// GitHub repository technical debt is filed in
+	if githubToken := os.Getenv("GITHUB_TOKEN"); githubToken != "" {
+		cfg.GitHub.Token = githubToken
+	}
+
+	if githubRepository := os.Getenv("GITHUB_REPOSITORY"); githubRepository != "" {
+		cfg.GitHub.Repository = githubRepository
+	}
+
+	if githubAPIURL := os.Getenv("GITHUB_API_URL"); githubAPIURL != "" {
+		cfg.GitHub.APIURL = githubAPIURL
+	}
+
+	// Microsoft Teams app credentials for live transcript streaming
+	if teamsClientID := os.Getenv("TEAMS_CLIENT_ID"); teamsClientID != "" {
+		cfg.Teams.ClientID = teamsClientID
+	}
+
+	if teamsClientSecret := os.Getenv("TEAMS_CLIENT_SECRET"); teamsClientSecret != "" {
+		cfg.Teams.ClientSecret = teamsClientSecret
+	}
+
+	if teamsTenantID := os.Getenv("TEAMS_TENANT_ID"); teamsTenantID != "" {
+		cfg.Teams.TenantID = teamsTenantID
+	}
+
+	// Kafka topic of ASR transcript segments
+	if kafkaBrokers := os.Getenv("KAFKA_BROKERS"); kafkaBrokers != "" {
+		cfg.Kafka.Brokers = nil
+		for _, broker := range strings.Split(kafkaBrokers, ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				cfg.Kafka.Brokers = append(cfg.Kafka.Brokers, broker)
+			}
+		}
+	}
+
+	if kafkaTopic := os.Getenv("KAFKA_TOPIC"); kafkaTopic != "" {
+		cfg.Kafka.Topic = kafkaTopic
+	}
+
+	if kafkaGroupID := os.Getenv("KAFKA_GROUP_ID"); kafkaGroupID != "" {
+		cfg.Kafka.GroupID = kafkaGroupID
+	}
+
+	if kafkaTLS := os.Getenv("KAFKA_TLS_ENABLED"); kafkaTLS != "" {
+		cfg.Kafka.TLSEnabled = kafkaTLS == "true"
+	}
+
+	// OpenAI Realtime API transcript stream
+	if realtimeURL := os.Getenv("OPENAI_REALTIME_URL"); realtimeURL != "" {
+		cfg.Realtime.URL = realtimeURL
+	}
+
+	if openAIAPIKey := os.Getenv("OPENAI_API_KEY"); openAIAPIKey != "" {
+		cfg.Realtime.APIKey = openAIAPIKey
+	}
+
+	// AWS credentials for archiving finalized analyses to S3
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		cfg.Archive.S3Region = region
+	}
+
+	if accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID"); accessKeyID != "" {
+		cfg.Archive.S3AccessKeyID = accessKeyID
+	}
+
+	if secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY"); secretAccessKey != "" {
+		cfg.Archive.S3SecretAccessKey = secretAccessKey
+	}
+
+	if sessionToken := os.Getenv("AWS_SESSION_TOKEN"); sessionToken != "" {
+		cfg.Archive.S3SessionToken = sessionToken
+	}
+
+	if endpoint := os.Getenv("S3_ENDPOINT"); endpoint != "" {
+		cfg.Archive.S3Endpoint = endpoint
+	}
+
 	return cfg, nil
 }
 
+// splitCommaList splits a comma-separated list, trimming whitespace and dropping empty values
+func splitCommaList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // SetupLogging configures the logging system
 func SetupLogging(cfg *LoggingConfig) error {
 	// Set log level
@@ -435,8 +992,15 @@ func SetupLogging(cfg *LoggingConfig) error {
 	if cfg.Discord.Enabled {
 		discordHook := NewDiscordHook(cfg.Discord)
 		logrus.AddHook(discordHook)
+		defaultWebhookHealth = NewWebhookHealthChecker(discordHook)
 		logrus.Info("Discord webhook logging enabled")
 	}
 
+	// Setup rotating log file if enabled
+	if cfg.File.Enabled {
+		logrus.AddHook(NewFileLogHook(cfg.File))
+		logrus.Infof("File logging enabled at %s", cfg.File.FilePath)
+	}
+
 	return nil
 }
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSpeechFilterFromEnv(t *testing.T) {
+	t.Setenv("SPEECH_MIN_CONFIDENCE", "0.65")
+	t.Setenv("SPEECH_NOISE_PATTERNS", "^um$,^music$")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Analysis.SpeechMinConfidence != 0.65 {
+		t.Errorf("SpeechMinConfidence = %v, want 0.65", cfg.Analysis.SpeechMinConfidence)
+	}
+	if len(cfg.Analysis.SpeechNoisePatterns) != 2 {
+		t.Errorf("SpeechNoisePatterns = %v", cfg.Analysis.SpeechNoisePatterns)
+	}
+}
+
+func TestMetricsTrackingFromEnv(t *testing.T) {
+	t.Setenv("METRICS_TRACKING_ENABLED", "true")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Analysis.MetricsTrackingEnabled {
+		t.Error("MetricsTrackingEnabled = false, want METRICS_TRACKING_ENABLED applied")
+	}
+}
+
+func TestEmbedFieldFilter(t *testing.T) {
+	data := logrus.Fields{"agent_id": "a1", "Meeting_ID": "m1", "tokens": 12, "cost": 0.1, "msg": "internal"}
+	tests := []struct {
+		name   string
+		filter FieldFilterConfig
+		want   []string
+	}{
+		{"all fields", FieldFilterConfig{}, []string{"Meeting_ID", "agent_id", "cost", "tokens"}},
+		{"allow list", FieldFilterConfig{AllowList: []string{"meeting_id", "cost"}}, []string{"Meeting_ID", "cost"}},
+		{"deny list", FieldFilterConfig{DenyList: []string{"TOKENS"}}, []string{"Meeting_ID", "agent_id", "cost"}},
+		{"max fields", FieldFilterConfig{MaxFields: 2}, []string{"Meeting_ID", "agent_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := NewDiscordHook(DiscordWebhookConfig{EmbedFieldFilter: tt.filter})
+			got := hook.embedFieldKeys(data)
+			if len(got) != len(tt.want) {
+				t.Fatalf("embedFieldKeys() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("embedFieldKeys() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CapturedMessages records the messages a DiscordHook in test mode would have sent. Fields may be read
+// directly once logging has finished; Reset is safe to call concurrently with the hook.
+type CapturedMessages struct {
+	mu      sync.Mutex
+	All     []DiscordMessage
+	ByLevel map[logrus.Level][]DiscordMessage
+}
+
+// NewDiscordHookForTesting returns a hook that fires for every level and captures its messages instead
+// of sending them
+func NewDiscordHookForTesting() (*DiscordHook, *CapturedMessages) {
+	capture := &CapturedMessages{}
+	hook := NewDiscordHook(DiscordWebhookConfig{
+		Enabled:      true,
+		Username:     "DealSense Test",
+		InfoWebhook:  "test://info",
+		WarnWebhook:  "test://warn",
+		ErrorWebhook: "test://error",
+		DebugWebhook: "test://debug",
+	})
+	return hook.WithTestMode(capture), capture
+}
+
+// WithTestMode makes the hook store messages in capture instead of posting them to Discord
+func (hook *DiscordHook) WithTestMode(capture *CapturedMessages) *DiscordHook {
+	hook.capture = capture
+	return hook
+}
+
+// Reset discards all captured messages
+func (c *CapturedMessages) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.All = nil
+	c.ByLevel = nil
+}
+
+// add records a message sent for a log entry at level
+func (c *CapturedMessages) add(level logrus.Level, message DiscordMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ByLevel == nil {
+		c.ByLevel = make(map[logrus.Level][]DiscordMessage)
+	}
+	c.All = append(c.All, message)
+	c.ByLevel[level] = append(c.ByLevel[level], message)
+}
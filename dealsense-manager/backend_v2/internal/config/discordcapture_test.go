@@ -0,0 +1,41 @@
+package config
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestDiscordHookTestModeCapturesMessages(t *testing.T) {
+	hook, captured := NewDiscordHookForTesting()
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+	logger.AddHook(hook)
+	logger.SetOutput(io.Discard)
+
+	logger.WithFields(logrus.Fields{"agent_id": "a1", DiscordContentField: "Summary text"}).Info("Finalized analysis")
+	logger.Warn("Slow response")
+	logger.Error("Save failed")
+	logger.Debug("Details")
+
+	if len(captured.All) != 4 {
+		t.Fatalf("captured %d messages, want 4", len(captured.All))
+	}
+	info := captured.ByLevel[logrus.InfoLevel]
+	if len(info) != 1 || info[0].Content != "Summary text" || info[0].Embeds[0].Description != "Finalized analysis" {
+		t.Errorf("info messages = %+v", info)
+	}
+	// The content field is posted as the message content, not as an embed field
+	if fields := info[0].Embeds[0].Fields; len(fields) != 1 || fields[0].Name != "Agent_id" {
+		t.Errorf("embed fields = %+v, want only agent_id", fields)
+	}
+	if len(captured.ByLevel[logrus.ErrorLevel]) != 1 || len(captured.ByLevel[logrus.WarnLevel]) != 1 {
+		t.Errorf("messages by level = %v", captured.ByLevel)
+	}
+
+	captured.Reset()
+	if len(captured.All) != 0 || len(captured.ByLevel) != 0 {
+		t.Error("Reset() kept captured messages")
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileLogConfig holds the configuration for writing logs to a rotating file
+type FileLogConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	FilePath   string `yaml:"file_path"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`  // Size at which the file is rotated
+	MaxBackups int    `yaml:"max_backups"`  // Rotated files to keep, 0 keeps all
+	MaxAgeDays int    `yaml:"max_age_days"` // Days to keep rotated files, 0 keeps them forever
+	Compress   bool   `yaml:"compress"`     // Gzip rotated files
+}
+
+// FileLogHook is a logrus hook that writes JSON log lines to a rotating file
+type FileLogHook struct {
+	writer    io.WriteCloser
+	formatter logrus.Formatter
+	mu        sync.Mutex
+}
+
+// NewFileLogHook creates a file log hook, the file is opened on the first write
+func NewFileLogHook(config FileLogConfig) *FileLogHook {
+	return &FileLogHook{
+		writer: &lumberjack.Logger{
+			Filename:   config.FilePath,
+			MaxSize:    config.MaxSizeMB,
+			MaxBackups: config.MaxBackups,
+			MaxAge:     config.MaxAgeDays,
+			Compress:   config.Compress,
+		},
+		// Files always get JSON so they can be parsed regardless of the console format
+		formatter: &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339,
+		},
+	}
+}
+
+// Levels returns the levels this hook should fire for
+func (hook *FileLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes the log entry to the file
+func (hook *FileLogHook) Fire(entry *logrus.Entry) error {
+	line, err := hook.formatter.Format(entry)
+	if err != nil {
+		return fmt.Errorf("failed to format log entry: %w", err)
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+
+	if _, err := hook.writer.Write(line); err != nil {
+		return fmt.Errorf("failed to write log file: %w", err)
+	}
+	return nil
+}
+
+// Close closes the log file
+func (hook *FileLogHook) Close() error {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+
+	return hook.writer.Close()
+}
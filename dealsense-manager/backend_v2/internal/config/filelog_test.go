@@ -0,0 +1,73 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFileLogHookRotates(t *testing.T) {
+	dir := t.TempDir()
+	hook := NewFileLogHook(FileLogConfig{Enabled: true, FilePath: filepath.Join(dir, "manager.log"), MaxSizeMB: 1, MaxBackups: 2})
+	defer hook.Close()
+
+	logger := logrus.New()
+	message := strings.Repeat("x", 10*1024)
+	// 250 entries of 10 KB fill more than two 1 MB files
+	for i := 0; i < 250; i++ {
+		entry := &logrus.Entry{Logger: logger, Level: logrus.InfoLevel, Message: message, Data: logrus.Fields{"n": i}}
+		if err := hook.Fire(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hook.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "manager*.log"))
+	if len(files) < 2 {
+		t.Fatalf("log files = %v, want the log rotated after 1 MB", files)
+	}
+	for _, file := range files {
+		if info, _ := os.Stat(file); info.Size() > 1024*1024 {
+			t.Errorf("%s is %d bytes, over the 1 MB limit", file, info.Size())
+		}
+	}
+
+	// Every line is a JSON log entry
+	current, err := os.Open(filepath.Join(dir, "manager.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer current.Close()
+	scanner := bufio.NewScanner(current)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("log line is not JSON: %v", err)
+		}
+		if line["level"] == nil || line["time"] == nil {
+			t.Fatalf("log line = %v, want level and time", line)
+		}
+	}
+}
+
+func TestFileLogConfigFromEnv(t *testing.T) {
+	t.Setenv("LOG_FILE_ENABLED", "true")
+	t.Setenv("LOG_FILE_PATH", "/var/log/manager.log")
+	t.Setenv("LOG_MAX_SIZE_MB", "50")
+	t.Setenv("LOG_MAX_BACKUPS", "4")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := cfg.Logging.File
+	if !file.Enabled || file.FilePath != "/var/log/manager.log" || file.MaxSizeMB != 50 || file.MaxBackups != 4 {
+		t.Errorf("file logging = %+v", file)
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Severities of configuration problems
+const (
+	SeverityError   = "error"   // The server can't run correctly and must not start
+	SeverityWarning = "warning" // The server can start, but the setting won't work as intended
+)
+
+// discordWebhookPattern matches the URLs Discord issues for webhooks
+var discordWebhookPattern = regexp.MustCompile(`^https://(?:(?:canary|ptb)\.)?discord(?:app)?\.com/api/webhooks/\d+/[\w-]+$`)
+
+// ConfigValidationError is a setting that is known to be wrong
+type ConfigValidationError struct {
+	Field    string      `json:"field"`
+	Value    interface{} `json:"value"`
+	Message  string      `json:"message"`
+	Severity string      `json:"severity"` // error, warning
+}
+
+// Error describes the problem with the field and its value
+func (e ConfigValidationError) Error() string {
+	return fmt.Sprintf("%s (%v): %s", e.Field, e.Value, e.Message)
+}
+
+// ValidateConfig checks cfg for known misconfigurations, returning one entry per problem. Errors should
+// stop the server from starting; warnings only need to be logged.
+func ValidateConfig(cfg *Config) []ConfigValidationError {
+	var problems []ConfigValidationError
+	add := func(field string, value interface{}, severity, message string) {
+		problems = append(problems, ConfigValidationError{Field: field, Value: value, Message: message, Severity: severity})
+	}
+
+	if cfg.Server.Port < 1 || cfg.Server.Port > 65535 {
+		add("Server.Port", cfg.Server.Port, SeverityError, "must be between 1 and 65535")
+	}
+	if cfg.Joinly.MaxAgents <= 0 {
+		add("Joinly.MaxAgents", cfg.Joinly.MaxAgents, SeverityError, "must be greater than 0")
+	}
+	if _, err := logrus.ParseLevel(cfg.Logging.Level); err != nil {
+		add("Logging.Level", cfg.Logging.Level, SeverityError, "must be one of panic, fatal, error, warn, info, debug or trace")
+	}
+
+	for _, timeout := range []struct {
+		field string
+		value time.Duration
+	}{
+		{"Server.ReadTimeout", cfg.Server.ReadTimeout},
+		{"Server.WriteTimeout", cfg.Server.WriteTimeout},
+		{"Server.ShutdownTimeout", cfg.Server.ShutdownTimeout},
+		{"Joinly.DefaultTimeout", cfg.Joinly.DefaultTimeout},
+	} {
+		if timeout.value <= 0 {
+			add(timeout.field, timeout.value, SeverityError, "must be greater than 0")
+		}
+	}
+	if cfg.Server.ReportFlushInterval < 0 {
+		add("Server.ReportFlushInterval", cfg.Server.ReportFlushInterval, SeverityError, "must not be negative")
+	}
+
+	// Webhooks of disabled Discord logging are never called, so bad ones only need fixing before it is enabled
+	discord := cfg.Logging.Discord
+	severity := SeverityError
+	if !discord.Enabled {
+		severity = SeverityWarning
+	}
+	for _, webhook := range []struct {
+		field string
+		url   string
+	}{
+		{"Logging.Discord.InfoWebhook", discord.InfoWebhook},
+		{"Logging.Discord.WarnWebhook", discord.WarnWebhook},
+		{"Logging.Discord.ErrorWebhook", discord.ErrorWebhook},
+		{"Logging.Discord.DebugWebhook", discord.DebugWebhook},
+		{"Logging.Discord.GeminiWebhook", discord.GeminiWebhook},
+	} {
+		if webhook.url != "" && !discordWebhookPattern.MatchString(webhook.url) {
+			add(webhook.field, webhook.url, severity, "must be a Discord webhook URL of the form https://discord.com/api/webhooks/{id}/{token}")
+		}
+	}
+	if discord.HealthCheckInterval < 0 {
+		add("Logging.Discord.HealthCheckInterval", discord.HealthCheckInterval, SeverityWarning, "is negative; the default of 5 minutes is used")
+	}
+
+	if confidence := cfg.Analysis.SpeechMinConfidence; confidence < 0 || confidence > 1 {
+		add("Analysis.SpeechMinConfidence", confidence, SeverityWarning, "must be between 0 and 1")
+	}
+	for _, pattern := range cfg.Analysis.SpeechNoisePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			add("Analysis.SpeechNoisePatterns", pattern, SeverityWarning, "is not a valid regular expression and is ignored")
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateConfigDefaults(t *testing.T) {
+	if problems := ValidateConfig(DefaultConfig()); len(problems) != 0 {
+		t.Errorf("ValidateConfig() of the defaults = %v, want none", problems)
+	}
+}
+
+func TestValidateConfigReportsProblems(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Server.Port = 70000
+	cfg.Joinly.MaxAgents = 0
+	cfg.Logging.Level = "verbose"
+	cfg.Server.ReadTimeout = 0
+	cfg.Joinly.DefaultTimeout = -time.Second
+	cfg.Logging.Discord.Enabled = true
+	cfg.Logging.Discord.InfoWebhook = "https://example.com/hook"
+	cfg.Logging.Discord.ErrorWebhook = "https://discord.com/api/webhooks/123/abc-DEF_1"
+
+	severities := make(map[string]string)
+	for _, problem := range ValidateConfig(cfg) {
+		severities[problem.Field] = problem.Severity
+	}
+	for _, field := range []string{"Server.Port", "Joinly.MaxAgents", "Logging.Level", "Server.ReadTimeout", "Joinly.DefaultTimeout", "Logging.Discord.InfoWebhook"} {
+		if severities[field] != SeverityError {
+			t.Errorf("%s severity = %q, want %q", field, severities[field], SeverityError)
+		}
+	}
+	if len(severities) != 6 {
+		t.Errorf("problems = %v, want only the six misconfigured fields", severities)
+	}
+}
+
+func TestValidateConfigDisabledDiscordWebhookIsWarning(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Logging.Discord.Enabled = false
+	cfg.Logging.Discord.WarnWebhook = "not a url"
+
+	problems := ValidateConfig(cfg)
+	if len(problems) != 1 || problems[0].Field != "Logging.Discord.WarnWebhook" || problems[0].Severity != SeverityWarning {
+		t.Errorf("ValidateConfig() = %v, want a warning for the webhook", problems)
+	}
+}
+
+func TestValidateSpeechFilter(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Analysis.SpeechMinConfidence = 1.5
+	cfg.Analysis.SpeechNoisePatterns = []string{`^um$`, `(`}
+
+	fields := make(map[string]int)
+	for _, problem := range ValidateConfig(cfg) {
+		fields[problem.Field]++
+	}
+	if fields["Analysis.SpeechMinConfidence"] != 1 {
+		t.Error("out-of-range confidence threshold not reported")
+	}
+	if fields["Analysis.SpeechNoisePatterns"] != 1 {
+		t.Errorf("invalid noise patterns reported %d times, want 1", fields["Analysis.SpeechNoisePatterns"])
+	}
+}
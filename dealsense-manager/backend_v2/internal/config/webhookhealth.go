@@ -0,0 +1,247 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultWebhookHealthCheckInterval is how often Discord webhooks are checked when no interval is set
+	DefaultWebhookHealthCheckInterval = 5 * time.Minute
+	// webhookDemotionFailures is the number of consecutive failed checks after which a webhook is demoted
+	webhookDemotionFailures = 3
+)
+
+// WebhookStatus is the result of the latest health checks of one Discord webhook
+type WebhookStatus struct {
+	Healthy             bool      `json:"healthy"`
+	Demoted             bool      `json:"demoted"` // Logs aren't sent to the webhook until it passes a check again
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastCheckedAt       time.Time `json:"last_checked_at"`
+}
+
+// WebhookHealthStatus is the status of each configured webhook, keyed by its name: info, warn, error,
+// debug or gemini. Webhook URLs contain a token and are never exposed.
+type WebhookHealthStatus map[string]WebhookStatus
+
+// WebhookHealthChecker periodically checks that the Discord webhooks of a hook still exist, demoting
+// webhooks that fail repeatedly so logs aren't silently lost to them
+type WebhookHealthChecker struct {
+	hook       *DiscordHook
+	httpClient *http.Client
+
+	mu     sync.RWMutex
+	status WebhookHealthStatus
+}
+
+// defaultWebhookHealth checks the webhooks of the Discord hook installed by SetupLogging
+var defaultWebhookHealth *WebhookHealthChecker
+
+// DefaultWebhookHealthChecker returns the checker for the Discord hook installed by SetupLogging, or nil
+// when Discord logging is disabled
+func DefaultWebhookHealthChecker() *WebhookHealthChecker {
+	return defaultWebhookHealth
+}
+
+// NewWebhookHealthChecker creates a health checker for the webhooks of hook
+func NewWebhookHealthChecker(hook *DiscordHook) *WebhookHealthChecker {
+	return &WebhookHealthChecker{
+		hook:       hook,
+		httpClient: hook.httpClient,
+		status:     make(WebhookHealthStatus),
+	}
+}
+
+// Start checks every webhook immediately and then every interval until the context is cancelled
+func (c *WebhookHealthChecker) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWebhookHealthCheckInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			c.CheckAll(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Status returns a copy of the latest status of each webhook
+func (c *WebhookHealthChecker) Status() WebhookHealthStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status := make(WebhookHealthStatus, len(c.status))
+	for name, webhook := range c.status {
+		status[name] = webhook
+	}
+	return status
+}
+
+// CheckAll checks each configured webhook once. A webhook failing webhookDemotionFailures checks in a row
+// is demoted and the remaining healthy webhooks are alerted; a demoted webhook that passes a check is
+// restored.
+func (c *WebhookHealthChecker) CheckAll(ctx context.Context) {
+	webhooks := c.hook.webhooksByName()
+
+	// Webhooks shared by several levels are checked once
+	results := make(map[string]error)
+	for _, url := range webhooks {
+		if _, checked := results[url]; !checked {
+			results[url] = c.check(ctx, url)
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	var demoted, restored []string
+	c.mu.Lock()
+	for name, url := range webhooks {
+		status := c.status[name]
+		status.LastCheckedAt = now
+		if err := results[url]; err != nil {
+			status.Healthy = false
+			status.ConsecutiveFailures++
+			status.LastError = err.Error()
+			if status.ConsecutiveFailures >= webhookDemotionFailures && !status.Demoted {
+				status.Demoted = true
+				demoted = append(demoted, name)
+			}
+		} else {
+			if status.Demoted {
+				restored = append(restored, name)
+			}
+			status = WebhookStatus{Healthy: true, LastCheckedAt: now}
+		}
+		c.status[name] = status
+	}
+	c.mu.Unlock()
+
+	for url, err := range results {
+		if err != nil && c.isDemoted(url) {
+			c.hook.demoted.Store(url, true)
+		} else {
+			c.hook.demoted.Delete(url)
+		}
+	}
+
+	if len(restored) > 0 {
+		sort.Strings(restored)
+		logrus.Infof("Restored Discord webhooks that are healthy again: %s", strings.Join(restored, ", "))
+	}
+	if len(demoted) > 0 {
+		sort.Strings(demoted)
+		c.alertDemoted(demoted)
+	}
+}
+
+// isDemoted reports whether every webhook name using url is demoted
+func (c *WebhookHealthChecker) isDemoted(url string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for name, webhook := range c.hook.webhooksByName() {
+		if webhook == url && !c.status[name].Demoted {
+			return false
+		}
+	}
+	return true
+}
+
+// check posts an empty message to the webhook. Discord rejects empty messages with 400 Bad Request after
+// validating the webhook, so only other error statuses mean the webhook is broken.
+func (c *WebhookHealthChecker) check(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(`{"content": ""}`))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// alertDemoted tells the remaining healthy webhooks that the named webhooks were demoted
+func (c *WebhookHealthChecker) alertDemoted(names []string) {
+	text := fmt.Sprintf("Demoted Discord webhooks after %d failed health checks in a row: %s. They receive no logs until they recover.",
+		webhookDemotionFailures, strings.Join(names, ", "))
+
+	// The warning below reaches the warn webhook through the hook, so it isn't alerted directly
+	warnURL := c.hook.config.WarnWebhook
+	if _, demoted := c.hook.demoted.Load(warnURL); demoted || !c.hook.config.Enabled {
+		warnURL = ""
+	}
+
+	message := DiscordMessage{
+		Username: c.hook.config.Username,
+		Embeds: []DiscordEmbed{{
+			Title:       "⚠️ Webhook demoted",
+			Description: text,
+			Color:       c.hook.getColorForLevel(logrus.WarnLevel),
+			Timestamp:   time.Now().Format(time.RFC3339),
+		}},
+	}
+	alerted := make(map[string]bool)
+	for _, url := range c.hook.webhooksByName() {
+		if _, demoted := c.hook.demoted.Load(url); demoted || url == warnURL || alerted[url] {
+			continue
+		}
+		alerted[url] = true
+		if c.hook.capture != nil {
+			c.hook.capture.add(logrus.WarnLevel, message)
+			continue
+		}
+		if err := c.hook.sendToDiscord(url, message); err != nil {
+			logrus.Debugf("Failed to alert Discord webhook of demotion: %v", err)
+		}
+	}
+
+	logrus.Warn(text)
+}
+
+// webhooksByName returns the configured webhook URLs keyed by name
+func (hook *DiscordHook) webhooksByName() map[string]string {
+	webhooks := make(map[string]string)
+	for name, url := range map[string]string{
+		"info":   hook.config.InfoWebhook,
+		"warn":   hook.config.WarnWebhook,
+		"error":  hook.config.ErrorWebhook,
+		"debug":  hook.config.DebugWebhook,
+		"gemini": hook.config.GeminiWebhook,
+	} {
+		if name == "gemini" && !hook.config.GeminiEnabled {
+			continue
+		}
+		if url != "" {
+			webhooks[name] = url
+		}
+	}
+	return webhooks
+}
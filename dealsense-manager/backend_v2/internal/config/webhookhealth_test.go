@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// discordServer is a mock Discord accepting webhooks under any path except /broken while broken is set.
+// Empty messages are answered with 400 like Discord does; other messages are recorded by path.
+type discordServer struct {
+	*httptest.Server
+	broken atomic.Bool
+
+	mu       sync.Mutex
+	checks   map[string]int
+	messages map[string][]DiscordMessage
+}
+
+func newDiscordServer(t *testing.T) *discordServer {
+	t.Helper()
+	s := &discordServer{checks: make(map[string]int), messages: make(map[string][]DiscordMessage)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.URL.Path == "/broken" && s.broken.Load() {
+			http.Error(w, "Unknown Webhook", http.StatusNotFound)
+			return
+		}
+		var message DiscordMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		if message.Content == "" && len(message.Embeds) == 0 {
+			s.checks[r.URL.Path]++
+			http.Error(w, "Cannot send an empty message", http.StatusBadRequest)
+			return
+		}
+		s.messages[r.URL.Path] = append(s.messages[r.URL.Path], message)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *discordServer) received(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages[path])
+}
+
+func TestWebhookIsDemotedAfterRepeatedFailuresAndRestored(t *testing.T) {
+	server := newDiscordServer(t)
+	server.broken.Store(true)
+	hook := NewDiscordHook(DiscordWebhookConfig{
+		Enabled:      true,
+		InfoWebhook:  server.URL + "/info",
+		WarnWebhook:  server.URL + "/warn",
+		ErrorWebhook: server.URL + "/broken",
+	})
+	checker := NewWebhookHealthChecker(hook)
+	ctx := context.Background()
+
+	for i := 1; i < webhookDemotionFailures; i++ {
+		checker.CheckAll(ctx)
+	}
+	status := checker.Status()["error"]
+	if status.Healthy || status.Demoted || status.ConsecutiveFailures != webhookDemotionFailures-1 {
+		t.Fatalf("status after %d failures = %+v, want unhealthy but not demoted", webhookDemotionFailures-1, status)
+	}
+	if !checker.Status()["info"].Healthy {
+		t.Error("a webhook answering 400 to the empty check should be healthy")
+	}
+
+	checker.CheckAll(ctx)
+	status = checker.Status()["error"]
+	if !status.Demoted || status.LastError == "" {
+		t.Fatalf("status after %d failures = %+v, want demoted with the error", webhookDemotionFailures, status)
+	}
+	// The warn webhook gets the demotion warning through the hook, so only the info webhook is alerted
+	if server.received("/info") != 1 || server.received("/warn") != 0 {
+		t.Errorf("alerts sent to info %d, warn %d times, want info once", server.received("/info"), server.received("/warn"))
+	}
+
+	// Logs aren't sent to a demoted webhook
+	server.broken.Store(false)
+	entry := &logrus.Entry{Logger: logrus.New(), Level: logrus.ErrorLevel, Message: "Something failed"}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+	if server.received("/broken") != 0 {
+		t.Error("error log was sent to the demoted webhook")
+	}
+
+	checker.CheckAll(ctx)
+	if status := checker.Status()["error"]; !status.Healthy || status.Demoted || status.ConsecutiveFailures != 0 {
+		t.Errorf("status after passing a check = %+v, want restored", status)
+	}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+	if server.received("/broken") != 1 {
+		t.Error("error log wasn't sent to the restored webhook")
+	}
+}
+
+func TestSharedWebhookIsCheckedOnce(t *testing.T) {
+	server := newDiscordServer(t)
+	hook := NewDiscordHook(DiscordWebhookConfig{
+		Enabled:       true,
+		InfoWebhook:   server.URL + "/shared",
+		ErrorWebhook:  server.URL + "/shared",
+		GeminiWebhook: server.URL + "/gemini", // Not checked while Gemini logging is off
+	})
+	checker := NewWebhookHealthChecker(hook)
+	checker.CheckAll(context.Background())
+
+	if server.checks["/shared"] != 1 || server.checks["/gemini"] != 0 {
+		t.Errorf("checks = %v, want the shared webhook checked once", server.checks)
+	}
+	if status := checker.Status(); len(status) != 2 || !status["info"].Healthy || !status["error"].Healthy {
+		t.Errorf("status = %+v, want info and error healthy", status)
+	}
+}
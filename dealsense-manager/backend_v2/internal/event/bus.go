@@ -0,0 +1,88 @@
+package event
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTooManySubscribers is returned when a bus already has its maximum number of subscribers
+var ErrTooManySubscribers = errors.New("too many subscribers")
+
+// Bus fans published events out to a bounded set of subscribers. Publishing never blocks: events are
+// dropped for subscribers whose buffer is full.
+type Bus struct {
+	mu             sync.Mutex
+	subscribers    map[*Subscription]struct{}
+	maxSubscribers int
+	bufferSize     int
+}
+
+// Subscription receives events published on a bus until it is closed
+type Subscription struct {
+	bus    *Bus
+	events chan interface{}
+	once   sync.Once
+}
+
+// NewBus creates a bus allowing up to maxSubscribers subscribers, each buffering bufferSize events
+func NewBus(maxSubscribers, bufferSize int) *Bus {
+	return &Bus{
+		subscribers:    make(map[*Subscription]struct{}),
+		maxSubscribers: maxSubscribers,
+		bufferSize:     bufferSize,
+	}
+}
+
+// Subscribe registers a new subscriber
+func (b *Bus) Subscribe() (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers) >= b.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		events: make(chan interface{}, b.bufferSize),
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Publish sends the event to every subscriber
+func (b *Bus) Publish(event interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// Slow subscriber, drop the event rather than stall the publisher
+		}
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *Bus) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers)
+}
+
+// Events returns the channel events are delivered on. It is closed when the subscription is closed.
+func (s *Subscription) Events() <-chan interface{} {
+	return s.events
+}
+
+// Close unregisters the subscription, it is safe to call more than once
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		delete(s.bus.subscribers, s)
+		close(s.events)
+		s.bus.mu.Unlock()
+	})
+}
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBusLimitsSubscribers(t *testing.T) {
+	bus := NewBus(2, 1)
+	first, err := bus.Subscribe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bus.Subscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bus.Subscribe(); !errors.Is(err, ErrTooManySubscribers) {
+		t.Fatalf("Subscribe() = %v, want ErrTooManySubscribers", err)
+	}
+
+	first.Close()
+	if bus.SubscriberCount() != 1 {
+		t.Errorf("SubscriberCount() = %d, want 1", bus.SubscriberCount())
+	}
+	if _, err := bus.Subscribe(); err != nil {
+		t.Errorf("Subscribe() after a close = %v", err)
+	}
+}
+
+func TestBusPublishDropsEventsForFullSubscribers(t *testing.T) {
+	bus := NewBus(2, 1)
+	slow, _ := bus.Subscribe()
+	fast, _ := bus.Subscribe()
+
+	bus.Publish("first")
+	if got := <-fast.Events(); got != "first" {
+		t.Fatalf("event = %v", got)
+	}
+	// slow hasn't read "first", so "second" must be dropped for it without blocking
+	bus.Publish("second")
+
+	if got := <-slow.Events(); got != "first" {
+		t.Errorf("slow subscriber event = %v, want first", got)
+	}
+	select {
+	case got := <-slow.Events():
+		t.Errorf("slow subscriber received %v, want it dropped", got)
+	default:
+	}
+	if got := <-fast.Events(); got != "second" {
+		t.Errorf("fast subscriber event = %v, want second", got)
+	}
+}
+
+func TestSubscriptionCloseIsIdempotent(t *testing.T) {
+	bus := NewBus(1, 1)
+	sub, _ := bus.Subscribe()
+
+	sub.Close()
+	sub.Close()
+
+	if _, ok := <-sub.Events(); ok {
+		t.Error("events channel not closed")
+	}
+	if bus.SubscriberCount() != 0 {
+		t.Errorf("SubscriberCount() = %d, want 0", bus.SubscriberCount())
+	}
+	// Publishing after a close must not send on the closed channel
+	bus.Publish("event")
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"joinly-manager/internal/client"
+)
+
+// ExportActionItemsCSV writes the action items as RFC 4180 CSV with a header row, one row per item, for
+// tracking them in a spreadsheet
+func ExportActionItemsCSV(data *client.AnalysisData, w io.Writer) error {
+	if data == nil {
+		data = &client.AnalysisData{}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"ID", "Description", "Assignee", "Priority", "Type", "Status", "CreatedAt", "MeetingID", "MeetingURL"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, item := range data.ActionItems {
+		record := []string{
+			item.ID, item.Description, item.Assignee, item.Priority, item.Type, item.Status,
+			csvTime(item.CreatedAt), data.MeetingID, data.MeetingURL,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write action item %s: %w", item.ID, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportTranscriptCSV writes the transcript as RFC 4180 CSV with a header row, one row per entry
+func ExportTranscriptCSV(data *client.AnalysisData, w io.Writer) error {
+	if data == nil {
+		data = &client.AnalysisData{}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Timestamp", "Speaker", "Text", "IsAgent", "WordCount"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for i, entry := range data.Transcript {
+		record := []string{
+			csvTime(entry.Timestamp), entry.Speaker, entry.Text, strconv.FormatBool(entry.IsAgent),
+			strconv.Itoa(len(strings.Fields(entry.Text))),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write transcript entry %d: %w", i, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvTime formats a time as RFC 3339, which spreadsheets parse as a date, or "" when it is unset
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client"
+)
+
+func TestExportActionItemsCSV(t *testing.T) {
+	data := &client.AnalysisData{
+		MeetingID:  "m1",
+		MeetingURL: "https://meet.example.com/m1",
+		ActionItems: []client.ActionItem{
+			{ID: "a1", Description: "Send the \"final\" pricing, today\nplease", Assignee: "Bob", Priority: "high", Type: "task", Status: "pending", CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+			{ID: "a2", Description: "Book a room"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := ExportActionItemsCSV(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"ID", "Description", "Assignee", "Priority", "Type", "Status", "CreatedAt", "MeetingID", "MeetingURL"},
+		{"a1", "Send the \"final\" pricing, today\nplease", "Bob", "high", "task", "pending", "2024-05-01T10:00:00Z", "m1", "https://meet.example.com/m1"},
+		{"a2", "Book a room", "", "", "", "", "", "m1", "https://meet.example.com/m1"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+}
+
+func TestExportTranscriptCSV(t *testing.T) {
+	data := &client.AnalysisData{Transcript: []client.TranscriptEntry{
+		{Timestamp: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Speaker: "Alice", Text: "Let's get  started, everyone"},
+		{Timestamp: time.Date(2024, 5, 1, 10, 0, 5, 0, time.UTC), Speaker: "Analyst", Text: "Noted.", IsAgent: true},
+	}}
+
+	var out bytes.Buffer
+	if err := ExportTranscriptCSV(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"Timestamp", "Speaker", "Text", "IsAgent", "WordCount"},
+		{"2024-05-01T10:00:00Z", "Alice", "Let's get  started, everyone", "false", "4"},
+		{"2024-05-01T10:00:05Z", "Analyst", "Noted.", "true", "1"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+}
+
+func TestExportCSVWithoutData(t *testing.T) {
+	var out bytes.Buffer
+	if err := ExportActionItemsCSV(nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if records, _ := csv.NewReader(&out).ReadAll(); len(records) != 1 {
+		t.Errorf("records = %q, want only the header", records)
+	}
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"joinly-manager/internal/client"
+)
+
+const (
+	// DefaultGitHubAPIURL is the GitHub REST API, replaced for GitHub Enterprise Server
+	DefaultGitHubAPIURL = "https://api.github.com"
+
+	// TechnicalDebtLabel labels the issues filed for technical debt mentioned in a meeting
+	TechnicalDebtLabel = "technical-debt"
+
+	// maxIssueTitleLength keeps issue titles readable in GitHub's issue list
+	maxIssueTitleLength = 80
+)
+
+// GitHubExporter files meeting follow-ups as issues in a GitHub repository
+type GitHubExporter struct {
+	apiURL     string
+	repository string // owner/name
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHubExporter creates an exporter filing issues in repository, "owner/name", authenticated with a
+// token allowed to create issues there. apiURL defaults to DefaultGitHubAPIURL when empty.
+func NewGitHubExporter(apiURL, repository, token string) *GitHubExporter {
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	return &GitHubExporter{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		repository: repository,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// gitHubIssue is the body of a create issue request
+type gitHubIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+}
+
+// ExportTechnicalDebt files an issue labelled TechnicalDebtLabel for each technical debt mention in the
+// analysis, and returns how many were filed before any failure
+func (e *GitHubExporter) ExportTechnicalDebt(ctx context.Context, data *client.AnalysisData) (int, error) {
+	if data == nil {
+		return 0, nil
+	}
+
+	for i, item := range data.TechnicalDebtMentions {
+		issue := gitHubIssue{
+			Title:  techDebtIssueTitle(item),
+			Body:   techDebtIssueBody(item, data),
+			Labels: []string{TechnicalDebtLabel},
+		}
+		if err := e.createIssue(ctx, issue); err != nil {
+			return i, err
+		}
+	}
+	return len(data.TechnicalDebtMentions), nil
+}
+
+// createIssue creates the issue in the repository
+func (e *GitHubExporter) createIssue(ctx context.Context, issue gitHubIssue) error {
+	payload, err := json.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GitHub issue: %w", err)
+	}
+
+	endpoint := e.apiURL + "/repos/" + e.repository + "/issues"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+e.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub issue %q: %w", issue.Title, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GitHub returned status %d for issue %q: %s", resp.StatusCode, issue.Title, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// techDebtIssueTitle names the issue after the component and the debt, shortened to maxIssueTitleLength
+func techDebtIssueTitle(item client.TechDebtItem) string {
+	title := strings.Join(strings.Fields(item.Description), " ")
+	if item.Component != "" {
+		title = item.Component + ": " + title
+	}
+
+	runes := []rune(title)
+	if len(runes) > maxIssueTitleLength {
+		title = strings.TrimSpace(string(runes[:maxIssueTitleLength-1])) + "…"
+	}
+	return title
+}
+
+// techDebtIssueBody describes the debt in Markdown with the meeting it was mentioned in
+func techDebtIssueBody(item client.TechDebtItem, data *client.AnalysisData) string {
+	var b strings.Builder
+	b.WriteString(item.Description + "\n\n")
+	if item.Component != "" {
+		fmt.Fprintf(&b, "- **Component:** %s\n", item.Component)
+	}
+	if item.Severity != "" {
+		fmt.Fprintf(&b, "- **Severity:** %s\n", item.Severity)
+	}
+	if item.MentionedBy != "" {
+		fmt.Fprintf(&b, "- **Mentioned by:** %s\n", item.MentionedBy)
+	}
+	if item.ProposedFix != "" {
+		fmt.Fprintf(&b, "- **Proposed fix:** %s\n", item.ProposedFix)
+	}
+	if !item.Timestamp.IsZero() {
+		fmt.Fprintf(&b, "- **Mentioned at:** %s\n", item.Timestamp.UTC().Format(time.RFC3339))
+	}
+	if data.MeetingURL != "" {
+		fmt.Fprintf(&b, "- **Meeting:** %s\n", data.MeetingURL)
+	} else if data.MeetingID != "" {
+		fmt.Fprintf(&b, "- **Meeting:** %s\n", data.MeetingID)
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"joinly-manager/internal/client"
+)
+
+func TestGitHubExporterExportTechnicalDebt(t *testing.T) {
+	var issues []gitHubIssue
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/platform/issues" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer gh-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var issue gitHubIssue
+		if err := json.NewDecoder(r.Body).Decode(&issue); err != nil {
+			t.Error(err)
+		}
+		issues = append(issues, issue)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	data := &client.AnalysisData{
+		MeetingURL: "https://meet.example.com/retro",
+		TechnicalDebtMentions: []client.TechDebtItem{
+			{Description: "The billing retry is a hack that keeps failing", Component: "billing", Severity: "high", MentionedBy: "Alice", ProposedFix: "Move retries to the queue"},
+			{Description: "Clean up the legacy CSS someday", Severity: "low"},
+		},
+	}
+	exported, err := NewGitHubExporter(server.URL+"/", "acme/platform", "gh-token").ExportTechnicalDebt(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exported != 2 || len(issues) != 2 {
+		t.Fatalf("exported %d issues, server got %d, want 2", exported, len(issues))
+	}
+
+	first := issues[0]
+	if first.Title != "billing: The billing retry is a hack that keeps failing" {
+		t.Errorf("title = %q", first.Title)
+	}
+	if len(first.Labels) != 1 || first.Labels[0] != TechnicalDebtLabel {
+		t.Errorf("labels = %q, want only %q", first.Labels, TechnicalDebtLabel)
+	}
+	for _, want := range []string{"**Severity:** high", "**Mentioned by:** Alice", "**Proposed fix:** Move retries to the queue", "https://meet.example.com/retro"} {
+		if !strings.Contains(first.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, first.Body)
+		}
+	}
+	if strings.Contains(issues[1].Body, "Component") {
+		t.Errorf("body of an item without a component = %q", issues[1].Body)
+	}
+}
+
+func TestGitHubExporterStopsOnError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	data := &client.AnalysisData{TechnicalDebtMentions: []client.TechDebtItem{{Description: "one"}, {Description: "two"}}}
+	exported, err := NewGitHubExporter(server.URL, "acme/platform", "gh-token").ExportTechnicalDebt(context.Background(), data)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("error = %v, want the 404", err)
+	}
+	if exported != 0 || requests != 1 {
+		t.Errorf("exported = %d after %d requests, want 0 after 1", exported, requests)
+	}
+}
+
+func TestTechDebtIssueTitleTruncates(t *testing.T) {
+	title := techDebtIssueTitle(client.TechDebtItem{Description: strings.Repeat("brittle ", 20), Component: "search"})
+	if n := len([]rune(title)); n > maxIssueTitleLength || !strings.HasPrefix(title, "search: brittle") || !strings.HasSuffix(title, "…") {
+		t.Errorf("title = %q (%d runes)", title, n)
+	}
+}
@@ -0,0 +1,177 @@
+package export
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"joinly-manager/internal/client"
+)
+
+// ExportMarkdownWithDiagrams renders the analysis as Markdown with Mermaid diagrams: a gantt chart of the
+// topic timeline, a pie chart of speaking time and a graph of action item dependencies. Diagrams without
+// data are left out.
+func ExportMarkdownWithDiagrams(data *client.AnalysisData) string {
+	if data == nil {
+		data = &client.AnalysisData{}
+	}
+
+	var md strings.Builder
+	title := "Meeting Analysis"
+	if data.MeetingID != "" {
+		title += ": " + data.MeetingID
+	}
+	md.WriteString("# " + title + "\n\n")
+	if !data.StartTime.IsZero() {
+		fmt.Fprintf(&md, "_%s, %.0f minutes_\n\n", data.StartTime.Format("2006-01-02 15:04"), data.DurationMinutes)
+	}
+	if data.Summary != "" {
+		md.WriteString("## Summary\n\n" + data.Summary + "\n\n")
+	}
+
+	if gantt := topicGantt(data.Topics); gantt != "" {
+		md.WriteString("## Topic Timeline\n\n" + mermaidBlock(gantt))
+	}
+	if pie := speakingTimePie(data.Transcript); pie != "" {
+		md.WriteString("## Speaking Time\n\n" + mermaidBlock(pie))
+	}
+
+	if len(data.ActionItems) > 0 {
+		md.WriteString("## Action Items\n\n")
+		for _, item := range data.ActionItems {
+			line := "- " + item.Description
+			if item.Assignee != "" {
+				line += " (" + item.Assignee + ")"
+			}
+			md.WriteString(line + "\n")
+		}
+		md.WriteString("\n")
+		if graph := actionItemGraph(data.ActionItems); graph != "" {
+			md.WriteString("### Dependencies\n\n" + mermaidBlock(graph))
+		}
+	}
+
+	return strings.TrimRight(md.String(), "\n") + "\n"
+}
+
+// mermaidBlock wraps a diagram in a fenced mermaid code block
+func mermaidBlock(diagram string) string {
+	return "```mermaid\n" + diagram + "```\n\n"
+}
+
+// topicGantt charts each topic from its start time for its duration, skipping topics whose start time
+// is not HH:MM or HH:MM:SS
+func topicGantt(topics []client.TopicDiscussion) string {
+	var tasks strings.Builder
+	for i, topic := range topics {
+		start, ok := parseClock(topic.StartTime)
+		if !ok {
+			continue
+		}
+		minutes := max(1, int(math.Round(topic.Duration)))
+		fmt.Fprintf(&tasks, "    %s :topic%d, %s, %dm\n", mermaidText(topic.Topic), i+1, start.Format("15:04"), minutes)
+	}
+	if tasks.Len() == 0 {
+		return ""
+	}
+	return "gantt\n    title Topics\n    dateFormat HH:mm\n    axisFormat %H:%M\n    section Discussion\n" + tasks.String()
+}
+
+// parseClock parses a topic start time in HH:MM or HH:MM:SS format
+func parseClock(value string) (time.Time, bool) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// speakingTimePie charts the minutes each participant spoke, longest first
+func speakingTimePie(transcript []client.TranscriptEntry) string {
+	seconds := client.SpeakingSeconds(transcript)
+	speakers := make([]string, 0, len(seconds))
+	for speaker, spoken := range seconds {
+		if spoken > 0 {
+			speakers = append(speakers, speaker)
+		}
+	}
+	if len(speakers) == 0 {
+		return ""
+	}
+	sort.Slice(speakers, func(i, j int) bool {
+		if seconds[speakers[i]] != seconds[speakers[j]] {
+			return seconds[speakers[i]] > seconds[speakers[j]]
+		}
+		return speakers[i] < speakers[j]
+	})
+
+	var pie strings.Builder
+	pie.WriteString("pie title Speaking time (minutes)\n")
+	for _, speaker := range speakers {
+		fmt.Fprintf(&pie, "    \"%s\" : %.2f\n", mermaidText(speaker), seconds[speaker]/60)
+	}
+	return pie.String()
+}
+
+// actionItemGraph links each action item to the items it depends on. Only items that take part in a
+// dependency are drawn; dependencies on unknown IDs are drawn with the ID as their label.
+func actionItemGraph(items []client.ActionItem) string {
+	nodes := make(map[string]string)
+	labels := make(map[string]string)
+	for i, item := range items {
+		if item.ID != "" {
+			nodes[item.ID] = fmt.Sprintf("item%d", i+1)
+			labels[item.ID] = item.Description
+		}
+	}
+
+	var edges, declared strings.Builder
+	drawn := make(map[string]bool)
+	node := func(id string) string {
+		name, ok := nodes[id]
+		if !ok {
+			name = fmt.Sprintf("dep%d", len(nodes)+1)
+			nodes[id] = name
+			labels[id] = id
+		}
+		if !drawn[name] {
+			drawn[name] = true
+			fmt.Fprintf(&declared, "    %s[\"%s\"]\n", name, mermaidText(labels[id]))
+		}
+		return name
+	}
+
+	for i, item := range items {
+		for _, dependency := range item.DependsOn {
+			if dependency == "" || dependency == item.ID {
+				continue
+			}
+			from := node(dependency)
+			var to string
+			if item.ID != "" {
+				to = node(item.ID)
+			} else {
+				to = fmt.Sprintf("item%d", i+1)
+				if !drawn[to] {
+					drawn[to] = true
+					fmt.Fprintf(&declared, "    %s[\"%s\"]\n", to, mermaidText(item.Description))
+				}
+			}
+			fmt.Fprintf(&edges, "    %s --> %s\n", from, to)
+		}
+	}
+	if edges.Len() == 0 {
+		return ""
+	}
+	return "graph LR\n" + declared.String() + edges.String()
+}
+
+// mermaidText makes text safe to use as a Mermaid label by collapsing whitespace and replacing
+// characters Mermaid treats as syntax
+func mermaidText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.NewReplacer(`"`, "'", ":", " -", ";", ",", "#", "", "`", "'").Replace(text)
+}
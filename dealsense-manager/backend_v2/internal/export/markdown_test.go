@@ -0,0 +1,76 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/client"
+)
+
+func TestExportMarkdownWithDiagrams(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	data := &client.AnalysisData{
+		MeetingID:       "m1",
+		StartTime:       start,
+		DurationMinutes: 30,
+		Summary:         "The team planned the launch.",
+		Topics: []client.TopicDiscussion{
+			{Topic: "Launch: timeline", StartTime: "10:00", Duration: 12.4},
+			{Topic: "Unparseable", StartTime: "early"},
+		},
+		Transcript: []client.TranscriptEntry{
+			{Timestamp: start, Speaker: "Alice", Text: "Let's plan the launch for Friday."},
+			{Timestamp: start.Add(2 * time.Minute), Speaker: "Bob", Text: "Friday works."},
+			{Timestamp: start.Add(3 * time.Minute), Speaker: "Alice", Text: "Great."},
+		},
+		ActionItems: []client.ActionItem{
+			{ID: "a1", Description: "Write the \"release\" notes", Assignee: "Bob"},
+			{ID: "a2", Description: "Publish the release", DependsOn: []string{"a1", "legal-review"}},
+		},
+	}
+
+	md := ExportMarkdownWithDiagrams(data)
+	for _, want := range []string{
+		"# Meeting Analysis: m1\n",
+		"_2024-05-01 10:00, 30 minutes_",
+		"## Summary\n\nThe team planned the launch.",
+		"```mermaid\ngantt\n",
+		"    Launch - timeline :topic1, 10:00, 12m\n",
+		"```mermaid\npie title Speaking time (minutes)\n",
+		"    \"Alice\" : ",
+		"- Write the \"release\" notes (Bob)\n",
+		"```mermaid\ngraph LR\n",
+		"    item1[\"Write the 'release' notes\"]\n",
+		"    item1 --> item2\n",
+		"[\"legal-review\"]",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Unparseable") {
+		t.Error("topic without a clock start time was charted")
+	}
+	if strings.Count(md, "```mermaid") != 3 {
+		t.Errorf("want 3 diagrams:\n%s", md)
+	}
+}
+
+func TestExportMarkdownLeavesOutEmptyDiagrams(t *testing.T) {
+	md := ExportMarkdownWithDiagrams(&client.AnalysisData{
+		ActionItems: []client.ActionItem{{ID: "a1", Description: "No dependencies"}},
+	})
+	if strings.Contains(md, "mermaid") {
+		t.Errorf("diagrams rendered without data:\n%s", md)
+	}
+	if md != "# Meeting Analysis\n\n## Action Items\n\n- No dependencies\n" {
+		t.Errorf("markdown = %q", md)
+	}
+}
+
+func TestMermaidText(t *testing.T) {
+	if got := mermaidText("Q3 #goals: \"ship\";\n`now`"); got != "Q3 goals - 'ship', 'now'" {
+		t.Errorf("mermaidText() = %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+// Package i18n translates the fixed strings in generated reports, such as section headings
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultLocale is used for locales without a translation file and keys missing from one
+const DefaultLocale = "en"
+
+//go:embed *.json
+var translationFiles embed.FS
+
+// translations maps locale to key to translated string, loaded from the embedded files at startup
+var translations = mustLoadTranslations()
+
+func mustLoadTranslations() map[string]map[string]string {
+	files, err := translationFiles.ReadDir(".")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read translations: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		content, err := translationFiles.ReadFile(file.Name())
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read %s: %v", file.Name(), err))
+		}
+		var entries map[string]string
+		if err := json.Unmarshal(content, &entries); err != nil {
+			panic(fmt.Sprintf("i18n: failed to parse %s: %v", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), path.Ext(file.Name()))] = entries
+	}
+	return loaded
+}
+
+// Translate returns the string for key in locale, which may be a bare language code ("es") or include a
+// region ("es-MX", "pt_BR"). It falls back to English, then to the key itself.
+func Translate(locale, key string) string {
+	language, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "_", "-")), "-")
+	if translated, ok := translations[language][key]; ok {
+		return translated
+	}
+	if translated, ok := translations[DefaultLocale][key]; ok {
+		return translated
+	}
+	return key
+}
@@ -0,0 +1,34 @@
+package i18n
+
+import "testing"
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		locale, key, want string
+	}{
+		{"en", "key_points", "Key Points"},
+		{"es", "key_points", translations["es"]["key_points"]},
+		{"es-MX", "key_points", translations["es"]["key_points"]},
+		{"pt_BR", "key_points", "Key Points"}, // No Portuguese translation
+		{"", "summary", "Summary"},
+		{"de", "no_such_key", "no_such_key"},
+	}
+	for _, tt := range tests {
+		if got := Translate(tt.locale, tt.key); got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+	if Translate("ja", "key_points") == "Key Points" {
+		t.Error("Japanese key points heading is untranslated")
+	}
+}
+
+func TestEveryLocaleTranslatesEveryKey(t *testing.T) {
+	for locale, entries := range translations {
+		for key := range translations[DefaultLocale] {
+			if entries[key] == "" {
+				t.Errorf("%s has no translation for %q", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package knowledge resolves internal codenames, acronyms and terminology so analysis prompts can explain
+// them to the LLM
+package knowledge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxTermWords is the longest multi-word term looked up, in words
+const maxTermWords = 3
+
+// KnowledgeBase explains internal terms
+type KnowledgeBase interface {
+	// Lookup returns the explanation of term, reporting false when the term is unknown
+	Lookup(term string) (string, bool)
+}
+
+// Writer is a knowledge base that learns new terms
+type Writer interface {
+	// Add stores the explanation of term, replacing any existing one
+	Add(term, explanation string) error
+}
+
+// FileKnowledgeBase is a knowledge base loaded from a JSON object mapping terms to their explanations.
+// Terms are matched ignoring case.
+type FileKnowledgeBase struct {
+	path    string
+	mu      sync.RWMutex
+	entries map[string]string
+}
+
+// NewFileKnowledgeBase loads a knowledge base from the JSON file at path
+func NewFileKnowledgeBase(path string) (*FileKnowledgeBase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read knowledge base: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse knowledge base %s: %w", path, err)
+	}
+
+	entries := make(map[string]string, len(raw))
+	for term, explanation := range raw {
+		term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+		if explanation = strings.TrimSpace(explanation); term != "" && explanation != "" {
+			entries[term] = explanation
+		}
+	}
+	return &FileKnowledgeBase{path: path, entries: entries}, nil
+}
+
+// Lookup returns the explanation of term
+func (kb *FileKnowledgeBase) Lookup(term string) (string, bool) {
+	kb.mu.RLock()
+	defer kb.mu.RUnlock()
+
+	explanation, ok := kb.entries[strings.ToLower(term)]
+	return explanation, ok
+}
+
+// Add stores the explanation of term and writes the knowledge base back to its file
+func (kb *FileKnowledgeBase) Add(term, explanation string) error {
+	term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+	explanation = strings.TrimSpace(explanation)
+	if term == "" || explanation == "" {
+		return fmt.Errorf("knowledge base terms need a name and an explanation")
+	}
+
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	kb.entries[term] = explanation
+	data, err := json.MarshalIndent(kb.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal knowledge base: %w", err)
+	}
+	if err := os.WriteFile(kb.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write knowledge base: %w", err)
+	}
+	return nil
+}
+
+// CachedKnowledgeBase wraps a knowledge base and remembers the result of every lookup, including misses
+type CachedKnowledgeBase struct {
+	kb      KnowledgeBase
+	lookups sync.Map // term -> cachedLookup
+}
+
+type cachedLookup struct {
+	explanation string
+	found       bool
+}
+
+// NewCachedKnowledgeBase creates a caching wrapper around kb
+func NewCachedKnowledgeBase(kb KnowledgeBase) *CachedKnowledgeBase {
+	return &CachedKnowledgeBase{kb: kb}
+}
+
+// Lookup returns the cached result for term, looking it up in the wrapped knowledge base the first time
+func (c *CachedKnowledgeBase) Lookup(term string) (string, bool) {
+	if cached, ok := c.lookups.Load(term); ok {
+		lookup := cached.(cachedLookup)
+		return lookup.explanation, lookup.found
+	}
+
+	explanation, found := c.kb.Lookup(term)
+	c.lookups.Store(term, cachedLookup{explanation: explanation, found: found})
+	return explanation, found
+}
+
+// Add stores the explanation of term in the wrapped knowledge base, failing when it can't learn terms
+func (c *CachedKnowledgeBase) Add(term, explanation string) error {
+	writer, ok := c.kb.(Writer)
+	if !ok {
+		return fmt.Errorf("knowledge base is read-only")
+	}
+	if err := writer.Add(term, explanation); err != nil {
+		return err
+	}
+
+	// Drop cached lookups of the term in any case so the next lookup sees the new explanation
+	c.lookups.Range(func(key, _ interface{}) bool {
+		if strings.EqualFold(key.(string), term) {
+			c.lookups.Delete(key)
+		}
+		return true
+	})
+	return nil
+}
+
+// Entry is a term found in a text with its explanation
+type Entry struct {
+	Term        string // As first written in the text
+	Explanation string
+	Mentions    int
+}
+
+// word matches a term word, which may contain inner dots, dashes, ampersands and slashes as in "K8s",
+// "Q3-OKR" or "R&D"
+var word = regexp.MustCompile(`[\p{L}\p{N}]+(?:[.&/_-][\p{L}\p{N}]+)*`)
+
+// FindTerms returns up to limit knowledge base entries mentioned in text, most mentioned first. Phrases of
+// up to three words are looked up, preferring the longest match at each position.
+func FindTerms(kb KnowledgeBase, text string, limit int) []Entry {
+	words := word.FindAllString(text, -1)
+
+	found := make(map[string]*Entry)
+	var order []string
+	for i := 0; i < len(words); {
+		matched := 1
+		for n := min(maxTermWords, len(words)-i); n >= 1; n-- {
+			term := strings.Join(words[i:i+n], " ")
+			explanation, ok := kb.Lookup(term)
+			if !ok {
+				continue
+			}
+
+			key := strings.ToLower(term)
+			if entry, seen := found[key]; seen {
+				entry.Mentions++
+			} else {
+				found[key] = &Entry{Term: term, Explanation: explanation, Mentions: 1}
+				order = append(order, key)
+			}
+			matched = n
+			break
+		}
+		i += matched
+	}
+
+	entries := make([]Entry, 0, len(order))
+	for _, key := range order {
+		entries = append(entries, *found[key])
+	}
+	// Ties keep the order terms first appeared in
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Mentions > entries[j].Mentions })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
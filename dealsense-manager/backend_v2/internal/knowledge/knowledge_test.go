@@ -0,0 +1,115 @@
+package knowledge
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// countingKB is a knowledge base that counts its lookups
+type countingKB struct {
+	entries map[string]string
+	lookups int
+}
+
+func (kb *countingKB) Lookup(term string) (string, bool) {
+	kb.lookups++
+	explanation, ok := kb.entries[term]
+	return explanation, ok
+}
+
+func writeKnowledgeBase(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kb.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindTerms(t *testing.T) {
+	kb, err := NewFileKnowledgeBase(writeKnowledgeBase(t, `{
+		"ARR": "Annual recurring revenue",
+		"Project  Falcon": "The billing rewrite",
+		"Falcon": "The old billing service",
+		"K8s": "Kubernetes",
+		"R&D": "Research and development",
+		"QBR": "Quarterly business review",
+		"SLA": "Service level agreement",
+		"blank": "  "
+	}`))
+	if err != nil {
+		t.Fatalf("NewFileKnowledgeBase() error = %v", err)
+	}
+
+	text := "ARR grew. Project Falcon moves to k8s, so arr is up again. R&D owns Falcon. The QBR covers the SLA and ARR. blank"
+	entries := FindTerms(kb, text, 5)
+	want := []Entry{
+		{Term: "ARR", Explanation: "Annual recurring revenue", Mentions: 3},
+		// The longest phrase wins, so "Project Falcon" doesn't count as a Falcon mention
+		{Term: "Project Falcon", Explanation: "The billing rewrite", Mentions: 1},
+		{Term: "k8s", Explanation: "Kubernetes", Mentions: 1},
+		{Term: "R&D", Explanation: "Research and development", Mentions: 1},
+		{Term: "Falcon", Explanation: "The old billing service", Mentions: 1},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("FindTerms() = %+v, want %+v", entries, want)
+	}
+}
+
+func TestFileKnowledgeBaseAdd(t *testing.T) {
+	path := writeKnowledgeBase(t, `{"ARR": "Annual recurring revenue"}`)
+	kb, err := NewFileKnowledgeBase(path)
+	if err != nil {
+		t.Fatalf("NewFileKnowledgeBase() error = %v", err)
+	}
+	if err := kb.Add("  NRR ", "Net revenue retention"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := kb.Add("CAC", " "); err == nil {
+		t.Error("Add() accepted a term without an explanation")
+	}
+
+	reloaded, err := NewFileKnowledgeBase(path)
+	if err != nil {
+		t.Fatalf("reloading error = %v", err)
+	}
+	if explanation, ok := reloaded.Lookup("nrr"); !ok || explanation != "Net revenue retention" {
+		t.Errorf("Lookup(nrr) = %q, %v, want the added term saved", explanation, ok)
+	}
+}
+
+func TestCachedKnowledgeBase(t *testing.T) {
+	kb := &countingKB{entries: map[string]string{"ARR": "Annual recurring revenue"}}
+	cached := NewCachedKnowledgeBase(kb)
+
+	for i := 0; i < 3; i++ {
+		cached.Lookup("ARR")
+		cached.Lookup("unknown")
+	}
+	if kb.lookups != 2 {
+		t.Errorf("wrapped knowledge base looked up %d times, want each term once including misses", kb.lookups)
+	}
+	if err := cached.Add("NRR", "Net revenue retention"); err == nil {
+		t.Error("Add() succeeded on a read-only knowledge base")
+	}
+}
+
+func TestCachedKnowledgeBaseAddDropsCachedMiss(t *testing.T) {
+	kb, err := NewFileKnowledgeBase(writeKnowledgeBase(t, `{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := NewCachedKnowledgeBase(kb)
+	if _, ok := cached.Lookup("NRR"); ok {
+		t.Fatal("Lookup(NRR) found an unknown term")
+	}
+
+	if err := cached.Add("nrr", "Net revenue retention"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if explanation, ok := cached.Lookup("NRR"); !ok || explanation != "Net revenue retention" {
+		t.Errorf("Lookup(NRR) = %q, %v, want the new explanation", explanation, ok)
+	}
+}
@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Mailer sends meeting digest emails
+type Mailer interface {
+	SendDigest(to []string, subject string, htmlBody string, plainBody string) error
+}
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a new SMTP mailer. Authentication is skipped when username is empty.
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// SendDigest sends a multipart email with both plain text and HTML bodies
+func (m *SMTPMailer) SendDigest(to []string, subject string, htmlBody string, plainBody string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	message, err := m.buildMessage(to, subject, htmlBody, plainBody)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", m.host, m.port)
+	if err := smtp.SendMail(addr, auth, m.from, to, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// buildMessage assembles the MIME message with a multipart/alternative body
+func (m *SMTPMailer) buildMessage(to []string, subject, htmlBody, plainBody string) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", plainBody},
+		{"text/html; charset=UTF-8", htmlBody},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+		if _, err := partWriter.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish email body: %w", err)
+	}
+
+	var message bytes.Buffer
+	message.WriteString(fmt.Sprintf("From: %s\r\n", m.from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
+	message.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n", writer.Boundary()))
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package mailer
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// smtpServer is a mock SMTP server accepting one message, which it sends on the returned channel along
+// with its recipients
+func smtpServer(t *testing.T) (host string, port int, received <-chan smtpMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		var message smtpMessage
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				message.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				message.to = append(message.to, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(line, "."))
+				}
+				message.data = data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				messages <- message
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, portText, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ = strconv.Atoi(portText)
+	return host, port, messages
+}
+
+// smtpMessage is a message received by the mock SMTP server
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func TestSMTPSendDigest(t *testing.T) {
+	host, port, received := smtpServer(t)
+	mailer := NewSMTPMailer(host, port, "", "", "digest@example.com")
+
+	err := mailer.SendDigest([]string{"alice@example.com", "bob@example.com"}, "Résumé of the meeting", "<p>Hello</p>", "Hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := <-received
+	if message.from != "digest@example.com" || len(message.to) != 2 {
+		t.Errorf("envelope from %q to %v", message.from, message.to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(message.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject")); subject != "Résumé of the meeting" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if to := parsed.Header.Get("To"); to != "alice@example.com, bob@example.com" {
+		t.Errorf("To = %q", to)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", parsed.Header.Get("Content-Type"), err)
+	}
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", "Hello"},
+		{"text/html; charset=UTF-8", "<p>Hello</p>"},
+	} {
+		part, err := parts.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Type") != want.contentType || string(body) != want.body {
+			t.Errorf("part %q = %q, want %q = %q", part.Header.Get("Content-Type"), body, want.contentType, want.body)
+		}
+	}
+}
+
+func TestSendDigestWithoutRecipients(t *testing.T) {
+	if err := NewSMTPMailer("localhost", 25, "", "", "digest@example.com").SendDigest(nil, "Subject", "", ""); err == nil {
+		t.Error("SendDigest() without recipients = nil, want an error")
+	}
+}
@@ -0,0 +1,150 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// sendGridAPIURL is the SendGrid v3 mail send endpoint
+const sendGridAPIURL = "https://api.sendgrid.com/v3/mail/send"
+
+// TemplateMailer is implemented by mailers that can render digests from a template hosted by the provider
+type TemplateMailer interface {
+	Mailer
+	// UsesTemplate reports whether a template is configured
+	UsesTemplate() bool
+	// SendTemplate sends the configured template rendered with data
+	SendTemplate(to []string, subject string, data interface{}) error
+}
+
+// SendGridMailer sends emails through the SendGrid v3 API
+type SendGridMailer struct {
+	apiKey     string
+	from       string
+	templateID string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewSendGridMailer creates a new SendGrid mailer. Digests are sent as plain content when templateID is empty.
+func NewSendGridMailer(apiKey, from, templateID string) *SendGridMailer {
+	return &SendGridMailer{
+		apiKey:     apiKey,
+		from:       from,
+		templateID: templateID,
+		apiURL:     sendGridAPIURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// sendGridAddress is an email address in a SendGrid request
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+// sendGridPersonalization addresses a message and carries its template data
+type sendGridPersonalization struct {
+	To                  []sendGridAddress `json:"to"`
+	Subject             string            `json:"subject,omitempty"`
+	DynamicTemplateData interface{}       `json:"dynamic_template_data,omitempty"`
+}
+
+// sendGridContent is a message body of a single MIME type
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridRequest is the body of a SendGrid mail send request
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject,omitempty"`
+	Content          []sendGridContent         `json:"content,omitempty"`
+	TemplateID       string                    `json:"template_id,omitempty"`
+}
+
+// UsesTemplate reports whether a dynamic template is configured
+func (m *SendGridMailer) UsesTemplate() bool {
+	return m.templateID != ""
+}
+
+// SendDigest sends the digest with plain text and HTML content
+func (m *SendGridMailer) SendDigest(to []string, subject string, htmlBody string, plainBody string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	// SendGrid requires text/plain to come before text/html
+	content := []sendGridContent{{Type: "text/plain", Value: plainBody}}
+	if htmlBody != "" {
+		content = append(content, sendGridContent{Type: "text/html", Value: htmlBody})
+	}
+
+	return m.send(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: sendGridAddresses(to)}},
+		From:             sendGridAddress{Email: m.from},
+		Subject:          subject,
+		Content:          content,
+	})
+}
+
+// SendTemplate sends the configured dynamic template with data as its template data
+func (m *SendGridMailer) SendTemplate(to []string, subject string, data interface{}) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	if m.templateID == "" {
+		return fmt.Errorf("no SendGrid template configured")
+	}
+
+	return m.send(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{
+			To:                  sendGridAddresses(to),
+			Subject:             subject,
+			DynamicTemplateData: data,
+		}},
+		From:       sendGridAddress{Email: m.from},
+		TemplateID: m.templateID,
+	})
+}
+
+// send posts the request to the SendGrid API
+func (m *SendGridMailer) send(request sendGridRequest) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SendGrid request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", m.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create SendGrid request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("SendGrid request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// sendGridAddresses converts plain addresses to SendGrid addresses
+func sendGridAddresses(emails []string) []sendGridAddress {
+	addresses := make([]sendGridAddress, len(emails))
+	for i, email := range emails {
+		addresses[i] = sendGridAddress{Email: email}
+	}
+	return addresses
+}
@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sendGridServer is a mock SendGrid API recording the last request, answering with status
+func sendGridServer(t *testing.T, status int, request *sendGridRequest) *SendGridMailer {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(request)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"errors": [{"message": "rejected"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	mailer := NewSendGridMailer("key", "digest@example.com", "")
+	mailer.apiURL = server.URL
+	return mailer
+}
+
+func TestSendGridSendDigest(t *testing.T) {
+	var request sendGridRequest
+	mailer := sendGridServer(t, http.StatusAccepted, &request)
+
+	if err := mailer.SendDigest([]string{"alice@example.com"}, "Digest", "<p>Hello</p>", "Hello"); err != nil {
+		t.Fatal(err)
+	}
+	if request.From.Email != "digest@example.com" || request.Subject != "Digest" || request.TemplateID != "" {
+		t.Errorf("request = %+v", request)
+	}
+	if len(request.Personalizations) != 1 || request.Personalizations[0].To[0].Email != "alice@example.com" {
+		t.Errorf("personalizations = %+v", request.Personalizations)
+	}
+	// text/plain must come before text/html
+	if len(request.Content) != 2 || request.Content[0].Type != "text/plain" || request.Content[1].Type != "text/html" {
+		t.Errorf("content = %+v", request.Content)
+	}
+}
+
+func TestSendGridSendTemplate(t *testing.T) {
+	var request sendGridRequest
+	mailer := sendGridServer(t, http.StatusAccepted, &request)
+	if mailer.UsesTemplate() {
+		t.Error("UsesTemplate() without a template ID = true")
+	}
+	if err := mailer.SendTemplate([]string{"alice@example.com"}, "Digest", nil); err == nil {
+		t.Error("SendTemplate() without a template ID = nil, want an error")
+	}
+
+	mailer.templateID = "d-123"
+	if err := mailer.SendTemplate([]string{"alice@example.com"}, "Digest", map[string]string{"summary": "Launch Friday"}); err != nil {
+		t.Fatal(err)
+	}
+	if request.TemplateID != "d-123" || len(request.Content) != 0 {
+		t.Errorf("request = %+v, want the template without content", request)
+	}
+	personalization := request.Personalizations[0]
+	data, _ := personalization.DynamicTemplateData.(map[string]interface{})
+	if personalization.Subject != "Digest" || data["summary"] != "Launch Friday" {
+		t.Errorf("personalization = %+v", personalization)
+	}
+}
+
+func TestSendGridErrorStatus(t *testing.T) {
+	var request sendGridRequest
+	mailer := sendGridServer(t, http.StatusBadRequest, &request)
+
+	err := mailer.SendDigest([]string{"alice@example.com"}, "Digest", "", "Hello")
+	if err == nil || !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("SendDigest() = %v, want the status and SendGrid's message", err)
+	}
+}
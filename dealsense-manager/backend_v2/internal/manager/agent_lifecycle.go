@@ -2,6 +2,7 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -27,6 +28,10 @@ func (m *AgentManager) CreateAgent(config models.AgentConfig) (*models.Agent, er
 		return nil, fmt.Errorf("maximum number of agents (%d) reached", m.config.Joinly.MaxAgents)
 	}
 
+	if m.config.Analysis.MetricsTrackingEnabled {
+		config.EnableMetricsTracking = true
+	}
+
 	agentID := fmt.Sprintf("agent_%s", uuid.New().String()[:8])
 	now := time.Now()
 
@@ -39,7 +44,9 @@ func (m *AgentManager) CreateAgent(config models.AgentConfig) (*models.Agent, er
 	}
 
 	m.agents[agentID] = agent
+	m.logMu.Lock()
 	m.logBuffers[agentID] = make([]models.LogEntry, 0, m.logBufferSize)
+	m.logMu.Unlock()
 
 	// Update meeting info
 	meetingURL := config.MeetingURL
@@ -52,7 +59,7 @@ func (m *AgentManager) CreateAgent(config models.AgentConfig) (*models.Agent, er
 	m.meetings[meetingURL].AgentIDs = append(m.meetings[meetingURL].AgentIDs, agentID)
 	m.meetings[meetingURL].AgentCount++
 
-	m.addLogEntryUnsafe(agentID, models.LogEntry{
+	m.appendLogEntry(agentID, models.LogEntry{
 		Timestamp: time.Now(),
 		Level:     "info",
 		Message:   fmt.Sprintf("Agent created for meeting: %s", meetingURL),
@@ -73,11 +80,13 @@ func (m *AgentManager) DeleteAgent(agentID string) error {
 		return fmt.Errorf("agent not found")
 	}
 
-	// Stop if running
+	// Stop if running, otherwise make sure the analysis is finalized before it is dropped
 	if agent.Status == models.AgentStatusRunning {
 		if err := m.stopAgent(agentID); err != nil {
 			logrus.Errorf("Failed to stop agent %s during deletion: %v", agentID, err)
 		}
+	} else {
+		m.finalizeAnalyst(agentID)
 	}
 
 	// Update meeting info
@@ -102,7 +111,12 @@ func (m *AgentManager) DeleteAgent(agentID string) error {
 	delete(m.agents, agentID)
 	delete(m.clients, agentID)
 	delete(m.analysts, agentID) // Clean up analyst agent if exists
+	if m.shutdown != nil {
+		m.shutdown.Unregister(agentID)
+	}
+	m.logMu.Lock()
 	delete(m.logBuffers, agentID)
+	m.logMu.Unlock()
 
 	logrus.Infof("Deleted agent %s", agentID)
 	return nil
@@ -135,6 +149,53 @@ func (m *AgentManager) StartAgent(agentID string) error {
 	// Create analyst agent if in analyst mode
 	if agent.Config.ConversationMode == models.ConversationModeAnalyst {
 		analystAgent := client.NewAnalystAgent(agentID, agent.Config, joinlyClient)
+		analystAgent.SetDeadLetterQueue(m.dlq)
+		analystAgent.SetABTestRecorder(m.abTests)
+		analystAgent.SetStorageBackend(m.backend)
+		analystAgent.SetDefaultHourlyRate(m.config.Analysis.DefaultHourlyRateUSD)
+		analystAgent.SetSystemPromptPrefix(m.config.Analysis.SystemPromptPrefix)
+		analystAgent.SetProductFeedbackWebhook(m.config.Analysis.ProductFeedbackWebhookURL)
+		analystAgent.SetSalesObjectionWebhook(m.config.Analysis.SalesObjectionWebhookURL)
+		analystAgent.SetCRMWebhook(m.config.Analysis.CRMWebhookURL)
+		analystAgent.SetHRNotificationEmail(m.config.Analysis.HRNotificationEmail)
+		analystAgent.SetROIReport(m.config.Analysis.EnableROIReport)
+		analystAgent.SetSpeechFilter(m.config.Analysis.SpeechMinConfidence, m.config.Analysis.SpeechNoisePatterns)
+		analystAgent.SetArchiveStore(m.archives)
+		analystAgent.SetCostEstimator(m.costEstimator, m.config.Analysis.HardBudgetStop)
+		analystAgent.SetKafkaTLS(m.config.Kafka.TLSEnabled)
+		analystAgent.SetWebhookRetryQueue(m.webhookQueue)
+		if agent.Config.ExternalNLPURL != "" {
+			analystAgent.SetExternalNLPProvider(client.NewExternalNLPProvider(agent.Config.ExternalNLPURL, m.config.Analysis.ExternalNLPAPIKey))
+		}
+		if m.knowledge != nil {
+			analystAgent.SetKnowledgeBase(m.knowledge)
+		}
+		analystAgent.SetConfigChangedCallback(func(config models.AgentConfig) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if agent, exists := m.agents[agentID]; exists {
+				agent.Config = config
+				m.addLogEntry(agentID, "info", "Analyst prompt refined from action item feedback")
+			}
+		})
+		analystAgent.SetFinalizedCallback(func(data *client.AnalysisData) {
+			if m.templates.Add(*data) {
+				logrus.Infof("Updated the %s meeting template from agent %s", data.MeetingType, agentID)
+			}
+			m.exportTechnicalDebt(agentID, data)
+		})
+		if m.mailer != nil {
+			analystAgent.SetMailer(m.mailer)
+		}
+		if m.calendar != nil {
+			analystAgent.SetCalendar(m.calendar)
+		}
+		if m.teamsTokens != nil {
+			analystAgent.SetTeamsTokenRefresher(m.teamsTokens)
+		}
+		if m.shutdown != nil {
+			analystAgent.RegisterShutdown(m.shutdown)
+		}
 		m.analysts[agentID] = analystAgent
 		m.addLogEntry(agentID, "info", "Analyst agent created for meeting analysis")
 	}
@@ -159,6 +220,36 @@ func (m *AgentManager) StartAgent(agentID string) error {
 	agentCtx, agentCancel := context.WithCancel(m.ctx)
 	m.agentContexts[agentID] = agentCancel
 
+	// Consume the agent's transcript segments from Kafka, in a consumer group of its own
+	if analyst, exists := m.analysts[agentID]; exists && len(m.config.Kafka.Brokers) > 0 && m.config.Kafka.Topic != "" {
+		groupID := agentID
+		if m.config.Kafka.GroupID != "" {
+			groupID = m.config.Kafka.GroupID + "-" + agentID
+		}
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			if err := analyst.StartKafkaConsumer(agentCtx, m.config.Kafka.Brokers, m.config.Kafka.Topic, groupID); err != nil {
+				m.mu.Lock()
+				m.addLogEntry(agentID, "error", fmt.Sprintf("Kafka transcript consumer stopped: %v", err))
+				m.mu.Unlock()
+			}
+		}()
+	}
+
+	// Consume transcripts from the OpenAI Realtime API
+	if analyst, exists := m.analysts[agentID]; exists && m.config.Realtime.URL != "" && m.config.Realtime.APIKey != "" {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			if err := analyst.StartOpenAIRealtimeConsumer(agentCtx, m.config.Realtime.URL, m.config.Realtime.APIKey); err != nil {
+				m.mu.Lock()
+				m.addLogEntry(agentID, "error", fmt.Sprintf("Realtime transcript consumer stopped: %v", err))
+				m.mu.Unlock()
+			}
+		}()
+	}
+
 	// Start client in a goroutine
 	m.wg.Add(1)
 	go func() {
@@ -271,10 +362,52 @@ func (m *AgentManager) stopAgent(agentID string) error {
 	agent.Status = models.AgentStatusStopped
 	m.updateAgentStatusUnsafe(agentID, models.AgentStatusStopped)
 
+	m.finalizeAnalyst(agentID)
+
 	logrus.Infof("Agent %s stopped successfully", agentID)
 	return nil
 }
 
+// finalizeAnalyst runs the final analysis and sends the digest without blocking the caller (caller must hold mu)
+func (m *AgentManager) finalizeAnalyst(agentID string) {
+	analyst := m.analysts[agentID]
+	if analyst == nil || analyst.IsFinalized() {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		if _, err := analyst.Finalize(ctx); err != nil && !errors.Is(err, client.ErrAlreadyFinalized) {
+			logrus.Errorf("Failed to finalize analysis for agent %s: %v", agentID, err)
+		}
+	}()
+}
+
+// UpdateAgentConfig applies a config update to an agent. Running analyst agents pick up the change from
+// their next analysis run; other agents use it the next time they are started.
+func (m *AgentManager) UpdateAgentConfig(agentID string, update models.AgentConfigUpdate) (*models.Agent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	agent, exists := m.agents[agentID]
+	if !exists {
+		return nil, fmt.Errorf("agent not found")
+	}
+
+	update.Apply(&agent.Config)
+	if analyst := m.analysts[agentID]; analyst != nil {
+		analyst.UpdateConfig(agent.Config)
+	}
+
+	m.addLogEntry(agentID, "info", "Agent configuration updated")
+	logrus.Infof("Updated configuration for agent %s", agentID)
+
+	agentCopy := *agent
+	agentCopy.Logs = m.recentLogs(agentID)
+	return &agentCopy, nil
+}
+
 // GetAgent gets an agent by ID
 func (m *AgentManager) GetAgent(agentID string) (*models.Agent, bool) {
 	m.mu.RLock()
@@ -287,8 +420,7 @@ func (m *AgentManager) GetAgent(agentID string) (*models.Agent, bool) {
 
 	// Return a copy to prevent external modifications
 	agentCopy := *agent
-	agentCopy.Logs = make([]models.LogEntry, len(agent.Logs))
-	copy(agentCopy.Logs, agent.Logs)
+	agentCopy.Logs = m.recentLogs(agentID)
 
 	return &agentCopy, true
 }
@@ -302,8 +434,7 @@ func (m *AgentManager) ListAgents() []*models.Agent {
 	for _, agent := range m.agents {
 		// Return copies to prevent external modifications
 		agentCopy := *agent
-		agentCopy.Logs = make([]models.LogEntry, len(agent.Logs))
-		copy(agentCopy.Logs, agent.Logs)
+		agentCopy.Logs = m.recentLogs(agent.ID)
 		agents = append(agents, &agentCopy)
 	}
 
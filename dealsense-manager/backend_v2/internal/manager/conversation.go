@@ -18,7 +18,7 @@ func (m *AgentManager) handleUtterance(agentID string, segments []map[string]int
 	// Cancel any existing utterance processing task for this agent
 	m.mu.Lock()
 	if cancelFunc, exists := m.utteranceTasks[agentID]; exists {
-		m.addLogEntryUnsafe(agentID, models.LogEntry{
+		m.appendLogEntry(agentID, models.LogEntry{
 			Timestamp: time.Now(),
 			Level:     "debug",
 			Message:   "Cancelling previous utterance processing task",
@@ -7,10 +7,13 @@ import (
 	"joinly-manager/internal/models"
 )
 
+// agentLogLimit is how many of an agent's latest log entries are returned with the agent
+const agentLogLimit = 100
+
 // GetAgentLogs gets logs for an agent with pagination support
 func (m *AgentManager) GetAgentLogs(agentID string, lines int) ([]models.LogEntry, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
 
 	logs, exists := m.logBuffers[agentID]
 	if !exists {
@@ -43,22 +46,26 @@ func (m *AgentManager) GetAgentLogs(agentID string, lines int) ([]models.LogEntr
 	return result, nil
 }
 
-// addLogEntry adds a log entry for an agent
+// addLogEntry adds a log entry for an agent. It only takes logMu, so it is safe to call with or without mu held.
 func (m *AgentManager) addLogEntry(agentID, level, message string) {
-	entry := models.LogEntry{
+	m.appendLogEntry(agentID, models.LogEntry{
 		Timestamp: time.Now(),
 		Level:     level,
 		Message:   message,
-	}
-
-	m.addLogEntryUnsafe(agentID, entry)
+	})
 
 	// Note: Logs are now fetched via polling API, not WebSocket to avoid conflicts
 }
 
-// addLogEntryUnsafe adds a log entry without acquiring mutex (caller must hold mutex)
-func (m *AgentManager) addLogEntryUnsafe(agentID string, entry models.LogEntry) {
-	logs := m.logBuffers[agentID]
+// appendLogEntry adds entry to the agent's log buffer, dropping it if the agent was deleted
+func (m *AgentManager) appendLogEntry(agentID string, entry models.LogEntry) {
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
+
+	logs, exists := m.logBuffers[agentID]
+	if !exists {
+		return
+	}
 	logs = append(logs, entry)
 
 	// Keep only the last logBufferSize entries
@@ -67,14 +74,16 @@ func (m *AgentManager) addLogEntryUnsafe(agentID string, entry models.LogEntry)
 	}
 
 	m.logBuffers[agentID] = logs
+}
 
-	// Also update the agent logs (keep last 100)
-	agent := m.agents[agentID]
-	if agent != nil {
-		agent.Logs = append(agent.Logs, entry)
-		if len(agent.Logs) > 100 {
-			agent.Logs = agent.Logs[len(agent.Logs)-100:]
-		}
+// recentLogs returns a copy of the agent's last agentLogLimit log entries, for the copies of agents handed out
+func (m *AgentManager) recentLogs(agentID string) []models.LogEntry {
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
+
+	logs := m.logBuffers[agentID]
+	if len(logs) > agentLogLimit {
+		logs = logs[len(logs)-agentLogLimit:]
 	}
+	return append([]models.LogEntry{}, logs...)
 }
-
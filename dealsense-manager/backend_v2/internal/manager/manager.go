@@ -8,9 +8,17 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"joinly-manager/internal/calendar"
 	"joinly-manager/internal/client"
+	"joinly-manager/internal/client/llm"
 	"joinly-manager/internal/config"
+	"joinly-manager/internal/export"
+	"joinly-manager/internal/knowledge"
+	"joinly-manager/internal/mailer"
 	"joinly-manager/internal/models"
+	"joinly-manager/internal/shutdown"
+	"joinly-manager/internal/storage"
+	"joinly-manager/internal/templates"
 	"joinly-manager/internal/websocket"
 )
 
@@ -30,9 +38,23 @@ type AgentManager struct {
 	wg                  sync.WaitGroup
 	agentContexts       map[string]context.CancelFunc
 	logBuffers          map[string][]models.LogEntry
+	logMu               sync.Mutex // Guards logBuffers, so clients can log while mu is held elsewhere
 	logBufferSize       int
 	utteranceTasks      map[string]context.CancelFunc // Track active utterance processing tasks
 	conversationHistory map[string][]models.ConversationEntry
+	dlq                 *client.DeadLetterQueue    // Failed analysis steps awaiting retry
+	abTests             *client.ABTestRecorder     // Action item prompt A/B test samples from every analyst
+	backend             storage.Backend            // Saves analyses and publishes them to Redis, nil to write files directly
+	mailer              mailer.Mailer              // Sends post-meeting digests, nil when email is disabled
+	calendar            calendar.EventCreator      // Drafts follow-up meetings, nil when the calendar is disabled
+	github              *export.GitHubExporter     // Files technical debt as GitHub issues, nil when unconfigured
+	teamsTokens         client.TokenRefresher      // Gets Teams access tokens for transcript streams, nil when Teams is unconfigured
+	knowledge           knowledge.KnowledgeBase    // Explains internal terms in analysis prompts, nil when no knowledge base is configured
+	archives            *storage.ObjectStore       // Uploads finalized analyses to S3 or Google Cloud Storage
+	costEstimator       *llm.PromptCostEstimator   // Projects analyses' LLM cost against the daily budget shared by every analyst
+	templates           *templates.TemplateLearner // Learns a meeting template per meeting type from finalized analyses
+	webhookQueue        *client.WebhookRetryQueue  // Retries failed webhook deliveries across restarts, nil when it can't be loaded
+	shutdown            *shutdown.ShutdownManager
 }
 
 // NewAgentManager creates a new agent manager
@@ -55,9 +77,132 @@ func NewAgentManager(cfg *config.Config) *AgentManager {
 		logBufferSize:       1000,
 		utteranceTasks:      make(map[string]context.CancelFunc),
 		conversationHistory: make(map[string][]models.ConversationEntry),
+		dlq:                 client.NewDeadLetterQueue(client.DefaultDLQCapacity, cfg.Analysis.MaxDLQRetries),
+		abTests:             client.NewABTestRecorder(),
+		mailer:              newMailer(&cfg.Email),
+		calendar:            newCalendar(&cfg.Calendar),
+		github:              newGitHubExporter(&cfg.GitHub),
+		teamsTokens:         newTeamsTokenRefresher(&cfg.Teams),
+		knowledge:           newKnowledgeBase(&cfg.Analysis),
+		archives:            storage.NewObjectStore(&cfg.Archive),
+		costEstimator:       llm.NewPromptCostEstimator(cfg.Analysis.DailyBudgetUSD, cfg.Analysis.OutputTokenMultiplier),
+		templates:           templates.NewTemplateLearner(),
+		webhookQueue:        newWebhookRetryQueue(),
+		backend:             newStorageBackend(&cfg.Analysis),
 	}
 }
 
+// newCalendar creates the calendar follow-up meetings are drafted on, or nil if it is disabled or unconfigured
+func newCalendar(cfg *config.CalendarConfig) calendar.EventCreator {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.CredentialsFile != "" && cfg.TokenFile != "" {
+		tokens, err := calendar.NewFileTokenProvider(cfg.CredentialsFile, cfg.TokenFile)
+		if err != nil {
+			logrus.Errorf("Failed to set up Google Calendar credentials: %v", err)
+			return nil
+		}
+		return calendar.NewGoogleCalendar(tokens, cfg.CalendarID)
+	}
+
+	if cfg.AccessToken == "" {
+		return nil
+	}
+	return calendar.NewGoogleCalendar(calendar.NewStaticTokenProvider(cfg.AccessToken), cfg.CalendarID)
+}
+
+// newGitHubExporter creates the exporter technical debt is filed with, or nil if GitHub is unconfigured
+func newGitHubExporter(cfg *config.GitHubConfig) *export.GitHubExporter {
+	if cfg.Token == "" || cfg.Repository == "" {
+		return nil
+	}
+	return export.NewGitHubExporter(cfg.APIURL, cfg.Repository, cfg.Token)
+}
+
+// exportTechnicalDebt files the technical debt mentioned in a finalized meeting as GitHub issues
+func (m *AgentManager) exportTechnicalDebt(agentID string, data *client.AnalysisData) {
+	if m.github == nil || len(data.TechnicalDebtMentions) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	exported, err := m.github.ExportTechnicalDebt(ctx, data)
+	if err != nil {
+		logrus.Errorf("Failed to export technical debt from agent %s to GitHub after %d issues: %v", agentID, exported, err)
+		return
+	}
+	logrus.Infof("Exported %d technical debt mentions from agent %s to GitHub", exported, agentID)
+}
+
+// newTeamsTokenRefresher creates the refresher for Teams access tokens, or nil if the Teams app is unconfigured
+func newTeamsTokenRefresher(cfg *config.TeamsConfig) client.TokenRefresher {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.TenantID == "" {
+		return nil
+	}
+	return client.NewTeamsTokenRefresher(cfg.TenantID, cfg.ClientID, cfg.ClientSecret)
+}
+
+// newKnowledgeBase loads the knowledge base of internal terms, or returns nil if none is configured or it
+// can't be loaded
+func newKnowledgeBase(cfg *config.AnalysisConfig) knowledge.KnowledgeBase {
+	if cfg.KnowledgeBasePath == "" {
+		return nil
+	}
+
+	kb, err := knowledge.NewFileKnowledgeBase(cfg.KnowledgeBasePath)
+	if err != nil {
+		logrus.Errorf("Analysis prompts will not include a glossary: %v", err)
+		return nil
+	}
+	return knowledge.NewCachedKnowledgeBase(kb)
+}
+
+// newWebhookRetryQueue loads the queue of failed webhook deliveries, or returns nil if it can't be loaded
+func newWebhookRetryQueue() *client.WebhookRetryQueue {
+	queue, err := client.NewWebhookRetryQueue(client.WebhookQueueDir)
+	if err != nil {
+		logrus.Errorf("Failed webhook deliveries will not be retried: %v", err)
+		return nil
+	}
+	return queue
+}
+
+// newStorageBackend creates the backend that publishes analysis updates to Redis, or nil if Redis is unconfigured
+func newStorageBackend(cfg *config.AnalysisConfig) storage.Backend {
+	if cfg.RedisURL == "" {
+		return nil
+	}
+
+	redisClient, err := storage.NewRedisClient(cfg.RedisURL)
+	if err != nil {
+		logrus.Errorf("Analysis updates will not be published: %v", err)
+		return nil
+	}
+	return storage.NewRedisPublisher(storage.FileBackend{}, redisClient)
+}
+
+// newMailer creates the configured mailer for post-meeting digests, or nil if email is disabled or unconfigured
+func newMailer(cfg *config.EmailConfig) mailer.Mailer {
+	if cfg.Disabled {
+		return nil
+	}
+
+	if cfg.Provider == "sendgrid" {
+		if cfg.SendGridAPIKey == "" {
+			return nil
+		}
+		return mailer.NewSendGridMailer(cfg.SendGridAPIKey, cfg.SendGridFrom, cfg.SendGridTemplateID)
+	}
+
+	if cfg.SMTPHost == "" {
+		return nil
+	}
+	return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.SMTPFrom)
+}
+
 // Start starts the agent manager
 func (m *AgentManager) Start() error {
 	m.mu.Lock()
@@ -74,6 +219,22 @@ func (m *AgentManager) Start() error {
 	// Start WebSocket hub
 	m.wsHub.Start()
 
+	// Start retrying failed analysis steps
+	m.dlq.Start(m.ctx, 15*time.Second, m.retryFailedStep)
+
+	// Retry failed webhook deliveries, including those queued before a restart
+	if m.webhookQueue != nil {
+		m.webhookQueue.Start(m.ctx, client.WebhookQueuePollInterval)
+	}
+
+	// Delete old analysis files every hour
+	storage.NewRetentionEnforcer(client.AnalysisDataDir, m.config.Database.Retention).Start(m.ctx, time.Hour)
+
+	// Demote Discord webhooks that stop accepting logs
+	if healthChecker := config.DefaultWebhookHealthChecker(); healthChecker != nil {
+		healthChecker.Start(m.ctx, m.config.Logging.Discord.HealthCheckInterval)
+	}
+
 	logrus.Info("Agent manager started successfully")
 	return nil
 }
@@ -110,6 +271,14 @@ func (m *AgentManager) Stop() error {
 	return nil
 }
 
+// SetShutdownManager sets the shutdown manager that analyst agents register with to finalize on shutdown
+func (m *AgentManager) SetShutdownManager(sm *shutdown.ShutdownManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.shutdown = sm
+}
+
 // GetAnalystAgent gets an analyst agent by ID
 func (m *AgentManager) GetAnalystAgent(agentID string) *client.AnalystAgent {
 	m.mu.RLock()
@@ -117,3 +286,33 @@ func (m *AgentManager) GetAnalystAgent(agentID string) *client.AnalystAgent {
 
 	return m.analysts[agentID]
 }
+
+// WebhookRetryQueue returns the queue of failed webhook deliveries, or nil when it couldn't be loaded
+func (m *AgentManager) WebhookRetryQueue() *client.WebhookRetryQueue {
+	return m.webhookQueue
+}
+
+// DeadLetterQueue returns the queue of failed analysis steps
+func (m *AgentManager) DeadLetterQueue() *client.DeadLetterQueue {
+	return m.dlq
+}
+
+// ABTestResults returns the aggregate results of action item prompt A/B tests
+func (m *AgentManager) ABTestResults() client.ABTestResults {
+	return m.abTests.Results()
+}
+
+// MeetingTemplates returns the learner of meeting templates from finalized analyses
+func (m *AgentManager) MeetingTemplates() *templates.TemplateLearner {
+	return m.templates
+}
+
+// retryFailedStep re-runs a failed analysis step on its analyst agent
+func (m *AgentManager) retryFailedStep(step *client.FailedAnalysisStep) error {
+	analyst := m.GetAnalystAgent(step.AgentID)
+	if analyst == nil {
+		return fmt.Errorf("analyst agent %s no longer exists", step.AgentID)
+	}
+
+	return analyst.RetryStep(step.StepName, step.TranscriptSnapshot)
+}
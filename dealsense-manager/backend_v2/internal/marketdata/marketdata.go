@@ -0,0 +1,154 @@
+package marketdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarketQuote is a live price quote for a ticker
+type MarketQuote struct {
+	Ticker        string    `json:"ticker"`
+	Price         float64   `json:"price"`
+	PreviousClose float64   `json:"previous_close"`
+	Currency      string    `json:"currency"`
+	AsOf          time.Time `json:"as_of"`
+}
+
+// ChangePercent returns the change from the previous close as a percentage
+func (q *MarketQuote) ChangePercent() float64 {
+	if q.PreviousClose == 0 {
+		return 0
+	}
+	return (q.Price - q.PreviousClose) / q.PreviousClose * 100
+}
+
+// MarketDataProvider fetches live market quotes
+type MarketDataProvider interface {
+	GetQuote(ticker string) (*MarketQuote, error)
+}
+
+// YahooFinanceProvider fetches quotes from the Yahoo Finance chart API
+type YahooFinanceProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewYahooFinanceProvider creates a new Yahoo Finance provider
+func NewYahooFinanceProvider() *YahooFinanceProvider {
+	return &YahooFinanceProvider{
+		baseURL:    "https://query1.finance.yahoo.com/v8/finance/chart/",
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetQuote returns the latest regular market price for the ticker
+func (p *YahooFinanceProvider) GetQuote(ticker string) (*MarketQuote, error) {
+	requestURL := p.baseURL + url.PathEscape(strings.ToUpper(ticker)) + "?interval=1d&range=1d"
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	// Yahoo rejects requests without a browser-like user agent
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quote for %s: %w", ticker, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quote response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("quote request for %s failed with status %d", ticker, resp.StatusCode)
+	}
+
+	var result struct {
+		Chart struct {
+			Result []struct {
+				Meta struct {
+					Symbol             string  `json:"symbol"`
+					Currency           string  `json:"currency"`
+					RegularMarketPrice float64 `json:"regularMarketPrice"`
+					ChartPreviousClose float64 `json:"chartPreviousClose"`
+					RegularMarketTime  int64   `json:"regularMarketTime"`
+				} `json:"meta"`
+			} `json:"result"`
+			Error *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"chart"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quote response: %w", err)
+	}
+	if result.Chart.Error != nil {
+		return nil, fmt.Errorf("quote request for %s failed: %s", ticker, result.Chart.Error.Description)
+	}
+	if len(result.Chart.Result) == 0 {
+		return nil, fmt.Errorf("no quote found for %s", ticker)
+	}
+
+	meta := result.Chart.Result[0].Meta
+	return &MarketQuote{
+		Ticker:        meta.Symbol,
+		Price:         meta.RegularMarketPrice,
+		PreviousClose: meta.ChartPreviousClose,
+		Currency:      meta.Currency,
+		AsOf:          time.Unix(meta.RegularMarketTime, 0).UTC(),
+	}, nil
+}
+
+// cachedQuote is a quote along with when it was fetched
+type cachedQuote struct {
+	quote     *MarketQuote
+	fetchedAt time.Time
+}
+
+// CachedProvider wraps a provider and reuses quotes for each ticker until they are older than ttl
+type CachedProvider struct {
+	provider MarketDataProvider
+	ttl      time.Duration
+	mu       sync.Mutex
+	quotes   map[string]cachedQuote
+}
+
+// NewCachedProvider creates a caching wrapper around provider
+func NewCachedProvider(provider MarketDataProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		ttl:      ttl,
+		quotes:   make(map[string]cachedQuote),
+	}
+}
+
+// GetQuote returns a cached quote if it is fresh, otherwise fetches a new one
+func (c *CachedProvider) GetQuote(ticker string) (*MarketQuote, error) {
+	key := strings.ToUpper(ticker)
+
+	c.mu.Lock()
+	cached, ok := c.quotes[key]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < c.ttl {
+		return cached.quote, nil
+	}
+
+	quote, err := c.provider.GetQuote(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.quotes[key] = cachedQuote{quote: quote, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return quote, nil
+}
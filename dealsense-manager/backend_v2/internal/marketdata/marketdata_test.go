@@ -0,0 +1,113 @@
+package marketdata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chartServer is a mock Yahoo Finance chart API answering every request with body
+func chartServer(t *testing.T, status int, body string) *YahooFinanceProvider {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" || r.URL.Query().Get("range") != "1d" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewYahooFinanceProvider()
+	provider.baseURL = server.URL + "/v8/finance/chart/"
+	return provider
+}
+
+func TestYahooFinanceGetQuote(t *testing.T) {
+	provider := chartServer(t, http.StatusOK, `{"chart": {"result": [{"meta": {
+		"symbol": "AAPL", "currency": "USD", "regularMarketPrice": 110, "chartPreviousClose": 100, "regularMarketTime": 1714557600
+	}}], "error": null}}`)
+
+	quote, err := provider.GetQuote("aapl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Ticker != "AAPL" || quote.Price != 110 || quote.Currency != "USD" {
+		t.Errorf("quote = %+v", quote)
+	}
+	if !quote.AsOf.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("AsOf = %s", quote.AsOf)
+	}
+	if change := quote.ChangePercent(); change != 10 {
+		t.Errorf("ChangePercent() = %v, want 10", change)
+	}
+}
+
+func TestYahooFinanceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"chart error", http.StatusOK, `{"chart": {"result": null, "error": {"description": "No data found, symbol may be delisted"}}}`, "delisted"},
+		{"no result", http.StatusOK, `{"chart": {"result": []}}`, "no quote found"},
+		{"bad status", http.StatusTooManyRequests, `Too Many Requests`, "status 429"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chartServer(t, tt.status, tt.body).GetQuote("ZZZZ")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("GetQuote() = %v, want an error mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangePercentWithoutPreviousClose(t *testing.T) {
+	if change := (&MarketQuote{Price: 10}).ChangePercent(); change != 0 {
+		t.Errorf("ChangePercent() = %v, want 0", change)
+	}
+}
+
+// countingProvider returns a quote for every ticker, counting the calls
+type countingProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingProvider) GetQuote(ticker string) (*MarketQuote, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &MarketQuote{Ticker: ticker, Price: float64(p.calls)}, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	provider := &countingProvider{}
+	cached := NewCachedProvider(provider, time.Minute)
+
+	first, _ := cached.GetQuote("aapl")
+	second, _ := cached.GetQuote("AAPL")
+	if provider.calls != 1 || first != second {
+		t.Errorf("%d fetches, want the quote cached across ticker case", provider.calls)
+	}
+
+	expired := NewCachedProvider(provider, 0)
+	expired.GetQuote("MSFT")
+	expired.GetQuote("MSFT")
+	if provider.calls != 3 {
+		t.Errorf("%d fetches, want expired quotes fetched again", provider.calls)
+	}
+
+	// Failed fetches aren't cached
+	provider.err = errors.New("unavailable")
+	if _, err := NewCachedProvider(provider, time.Minute).GetQuote("GOOG"); err == nil {
+		t.Error("GetQuote() = nil error, want the provider's error")
+	}
+}
@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentSchemaVersion is the schema version written by this build
+const CurrentSchemaVersion = 2
+
+// migrationFunc upgrades a decoded analysis document by exactly one schema version
+type migrationFunc func(doc map[string]interface{}) error
+
+// migrations is indexed by the version it upgrades from
+var migrations = []migrationFunc{
+	0: v0ToV1,
+	1: v1ToV2,
+}
+
+// Migrate upgrades a saved analysis document to CurrentSchemaVersion. It works on raw JSON so it can
+// be used without importing the analysis types; documents already at the current version are returned unchanged.
+func Migrate(data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse analysis data: %w", err)
+	}
+
+	version := SchemaVersion(doc)
+	if version == CurrentSchemaVersion {
+		return data, nil
+	}
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("analysis data schema version %d is newer than supported version %d", version, CurrentSchemaVersion)
+	}
+
+	for ; version < CurrentSchemaVersion; version++ {
+		if err := migrations[version](doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate analysis data from v%d to v%d: %w", version, version+1, err)
+		}
+		doc["schema_version"] = version + 1
+	}
+
+	return json.Marshal(doc)
+}
+
+// MigrateAnalysisData decodes a saved analysis document into T, upgrading it to CurrentSchemaVersion first.
+// T is the analysis type, client.AnalysisData, which can't be named here because the client package
+// imports this one.
+func MigrateAnalysisData[T any](data []byte) (*T, error) {
+	migrated, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var analysis T
+	if err := json.Unmarshal(migrated, &analysis); err != nil {
+		return nil, fmt.Errorf("failed to parse analysis data: %w", err)
+	}
+	return &analysis, nil
+}
+
+// SchemaVersion returns the schema version of a decoded document; documents without one are version 0
+func SchemaVersion(doc map[string]interface{}) int {
+	version, ok := doc["schema_version"].(float64)
+	if !ok {
+		return 0
+	}
+	return int(version)
+}
+
+// v0ToV1 replaces null lists, written by early versions for meetings with no results, with empty lists
+func v0ToV1(doc map[string]interface{}) error {
+	for _, field := range []string{"transcript", "key_points", "action_items", "topics", "participants", "keywords"} {
+		if value, ok := doc[field]; !ok || value == nil {
+			doc[field] = []interface{}{}
+		}
+	}
+	return nil
+}
+
+// v1ToV2 converts numeric topic start times, written before start_time became a string, into
+// "HH:MM" strings, treating the number as minutes into the meeting
+func v1ToV2(doc map[string]interface{}) error {
+	topics, ok := doc["topics"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	for _, item := range topics {
+		topic, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected topic entry %v", item)
+		}
+		if minutes, ok := topic["start_time"].(float64); ok {
+			total := int(minutes)
+			topic["start_time"] = fmt.Sprintf("%02d:%02d", total/60, total%60)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestV0ToV1ReplacesNullLists(t *testing.T) {
+	doc := map[string]interface{}{"transcript": nil, "key_points": []interface{}{"Launch"}}
+	if err := v0ToV1(doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []string{"transcript", "action_items", "topics", "participants", "keywords"} {
+		if list, ok := doc[field].([]interface{}); !ok || len(list) != 0 {
+			t.Errorf("%s = %v, want an empty list", field, doc[field])
+		}
+	}
+	if !reflect.DeepEqual(doc["key_points"], []interface{}{"Launch"}) {
+		t.Errorf("key_points = %v, want it unchanged", doc["key_points"])
+	}
+}
+
+func TestV1ToV2FormatsTopicStartTimes(t *testing.T) {
+	doc := map[string]interface{}{"topics": []interface{}{
+		map[string]interface{}{"name": "Budget", "start_time": float64(75)},
+		map[string]interface{}{"name": "Hiring", "start_time": "00:05"},
+	}}
+	if err := v1ToV2(doc); err != nil {
+		t.Fatal(err)
+	}
+
+	topics := doc["topics"].([]interface{})
+	if got := topics[0].(map[string]interface{})["start_time"]; got != "01:15" {
+		t.Errorf("numeric start_time = %v, want 01:15", got)
+	}
+	if got := topics[1].(map[string]interface{})["start_time"]; got != "00:05" {
+		t.Errorf("string start_time = %v, want it unchanged", got)
+	}
+
+	if err := v1ToV2(map[string]interface{}{"topics": []interface{}{"Budget"}}); err == nil {
+		t.Error("topic that isn't an object accepted")
+	}
+}
+
+func TestMigrateUpgradesToCurrentVersion(t *testing.T) {
+	migrated, err := Migrate([]byte(`{"meeting_id": "m1", "topics": [{"name": "Budget", "start_time": 30}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if SchemaVersion(doc) != CurrentSchemaVersion {
+		t.Errorf("schema version = %d, want %d", SchemaVersion(doc), CurrentSchemaVersion)
+	}
+	if got := doc["topics"].([]interface{})[0].(map[string]interface{})["start_time"]; got != "00:30" {
+		t.Errorf("start_time = %v, want 00:30", got)
+	}
+}
+
+func TestMigrateCurrentVersionIsNoOp(t *testing.T) {
+	data := []byte(`{"schema_version": 2, "meeting_id": "m1", "transcript": null}`)
+	migrated, err := Migrate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(migrated, data) {
+		t.Errorf("Migrate changed a current document: %s", migrated)
+	}
+}
+
+func TestMigrateRejectsNewerVersions(t *testing.T) {
+	_, err := Migrate([]byte(`{"schema_version": 99}`))
+	if err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("Migrate() = %v, want a newer version error", err)
+	}
+}
+
+func TestMigrateAnalysisData(t *testing.T) {
+	type analysis struct {
+		SchemaVersion int      `json:"schema_version"`
+		MeetingID     string   `json:"meeting_id"`
+		KeyPoints     []string `json:"key_points"`
+	}
+
+	got, err := MigrateAnalysisData[analysis]([]byte(`{"meeting_id": "m1", "key_points": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SchemaVersion != CurrentSchemaVersion || got.MeetingID != "m1" || got.KeyPoints == nil {
+		t.Errorf("MigrateAnalysisData() = %+v", got)
+	}
+
+	if _, err := MigrateAnalysisData[analysis]([]byte(`not json`)); err == nil {
+		t.Error("invalid JSON accepted")
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -20,10 +21,11 @@ const (
 type LLMProvider string
 
 const (
-	LLMProviderOpenAI    LLMProvider = "openai"
-	LLMProviderAnthropic LLMProvider = "anthropic"
-	LLMProviderGoogle    LLMProvider = "google"
-	LLMProviderOllama    LLMProvider = "ollama"
+	LLMProviderOpenAI      LLMProvider = "openai"
+	LLMProviderAnthropic   LLMProvider = "anthropic"
+	LLMProviderGoogle      LLMProvider = "google"
+	LLMProviderOllama      LLMProvider = "ollama"
+	LLMProviderAzureOpenAI LLMProvider = "azure_openai"
 )
 
 // TTSProvider represents the TTS provider type
@@ -60,6 +62,327 @@ type ConversationEntry struct {
 	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
 }
 
+// AgendaItem is a planned item on a meeting agenda
+type AgendaItem struct {
+	Title           string `json:"title" yaml:"title"`
+	DurationMinutes int    `json:"duration_minutes,omitempty" yaml:"duration_minutes,omitempty"`
+}
+
+// MeetingObjective is a goal the meeting is expected to achieve, such as each participant sharing their
+// blockers in a standup
+type MeetingObjective struct {
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"` // Key of the objective's status; the question when unset
+	Question string `json:"question" yaml:"question"`             // Yes/no question, e.g. "Did everyone share their blockers?"
+}
+
+// KeywordAlert posts a Discord embed as soon as an utterance matches Pattern. After an alert fires it
+// stays quiet for Cooldown (1 minute when unset), so a topic discussed at length doesn't flood the channel.
+type KeywordAlert struct {
+	Pattern    string        `json:"pattern" yaml:"pattern"` // Regular expression, e.g. (?i)\b(cancel|churn)\b
+	WebhookURL string        `json:"webhook_url" yaml:"webhook_url"`
+	Color      int           `json:"color,omitempty" yaml:"color,omitempty"`     // Embed color as 0xRRGGBB
+	Message    string        `json:"message,omitempty" yaml:"message,omitempty"` // Embed title, defaulting to the pattern
+	Cooldown   time.Duration `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+// AgentConfigUpdate holds the agent settings that can be changed while an agent is running. Nil fields
+// are left unchanged.
+type AgentConfigUpdate struct {
+	CustomPrompt                *string                   `json:"custom_prompt,omitempty"`
+	PostMeetingEmailRecipients  *[]string                 `json:"post_meeting_email_recipients,omitempty"`
+	ForceResponseLanguage       *string                   `json:"force_response_language,omitempty"`
+	ReportLocale                *string                   `json:"report_locale,omitempty"`
+	WebhookSecret               *string                   `json:"webhook_secret,omitempty"`
+	EnableKeyQuotes             *bool                     `json:"enable_key_quotes,omitempty"`
+	EnableConflictDetection     *bool                     `json:"enable_conflict_detection,omitempty"`
+	EnableCompetitiveIntel      *bool                     `json:"enable_competitive_intel,omitempty"`
+	EnableRequirementExtraction *bool                     `json:"enable_requirement_extraction,omitempty"`
+	EnableMetricExtraction      *bool                     `json:"enable_metric_extraction,omitempty"`
+	EnableLearningResources     *bool                     `json:"enable_learning_resources,omitempty"`
+	EnableComplianceDetection   *bool                     `json:"enable_compliance_detection,omitempty"`
+	ComplianceFramework         *string                   `json:"compliance_framework,omitempty"`
+	EnableNPSProxy              *bool                     `json:"enable_nps_proxy,omitempty"`
+	EnableProductFeedback       *bool                     `json:"enable_product_feedback,omitempty"`
+	EnablePersonaInference      *bool                     `json:"enable_persona_inference,omitempty"`
+	EnableAcronymGlossary       *bool                     `json:"enable_acronym_glossary,omitempty"`
+	EnableConversationFlow      *bool                     `json:"enable_conversation_flow,omitempty"`
+	EnableNewTermsGlossary      *bool                     `json:"enable_new_terms_glossary,omitempty"`
+	EnableTechDebtExtraction    *bool                     `json:"enable_tech_debt_extraction,omitempty"`
+	EnableMetricsTracking       *bool                     `json:"enable_metrics_tracking,omitempty"`
+	EnableSpeakerMoodTimeline   *bool                     `json:"enable_speaker_mood_timeline,omitempty"`
+	EnableBudgetExtraction      *bool                     `json:"enable_budget_extraction,omitempty"`
+	EnableStakeholderMap        *bool                     `json:"enable_stakeholder_map,omitempty"`
+	EnableQAExtraction          *bool                     `json:"enable_qa_extraction,omitempty"`
+	EnableFollowUpSuggestion    *bool                     `json:"enable_follow_up_suggestion,omitempty"`
+	EnableFollowUpEmailDraft    *bool                     `json:"enable_follow_up_email_draft,omitempty"`
+	EnableEmotionalArc          *bool                     `json:"enable_emotional_arc,omitempty"`
+	EnableIcebreakerDetection   *bool                     `json:"enable_icebreaker_detection,omitempty"`
+	IcebreakerIndicators        *[]string                 `json:"icebreaker_indicators,omitempty"`
+	MeetingType                 *string                   `json:"meeting_type,omitempty"`
+	EnableKeyContacts           *bool                     `json:"enable_key_contacts,omitempty"`
+	EnableAttachmentTracking    *bool                     `json:"enable_attachment_tracking,omitempty"`
+	EnableEthicsDetection       *bool                     `json:"enable_ethics_detection,omitempty"`
+	EnableBatchCalls            *bool                     `json:"enable_batch_calls,omitempty"`
+	CostSavingMode              *bool                     `json:"cost_saving_mode,omitempty"`
+	EnableMarketDataEnrichment  *bool                     `json:"enable_market_data_enrichment,omitempty"`
+	EnableQueryOptimization     *bool                     `json:"enable_query_optimization,omitempty"`
+	StepTimeouts                *map[string]time.Duration `json:"step_timeouts,omitempty"`
+	ABTest                      *ABTestConfig             `json:"ab_test,omitempty"`
+	Normalizer                  *NormalizerConfig         `json:"normalizer,omitempty"`
+	Agenda                      *[]AgendaItem             `json:"agenda,omitempty"`
+	Objectives                  *[]MeetingObjective       `json:"objectives,omitempty"`
+	KeywordAlerts               *[]KeywordAlert           `json:"keyword_alerts,omitempty"`
+	WordCloudStopwords          *[]string                 `json:"word_cloud_stopwords,omitempty"`
+	InternalSpeakers            *[]string                 `json:"internal_speakers,omitempty"`
+	InternalDomains             *[]string                 `json:"internal_domains,omitempty"`
+	ParticipantHourlyRates      *map[string]float64       `json:"participant_hourly_rates,omitempty"`
+	Archival                    *ArchivalPolicy           `json:"archival,omitempty"`
+	WindowedAnalysis            *WindowedAnalysisConfig   `json:"windowed_analysis,omitempty"`
+	HealthThresholds            *HealthThresholds         `json:"health_thresholds,omitempty"`
+	MeetingTypeOptions          *[]string                 `json:"meeting_type_options,omitempty"`
+	EnableLLMClassification     *bool                     `json:"enable_llm_classification,omitempty"`
+	MeetingTypeKeywords         *map[string][]string      `json:"meeting_type_keywords,omitempty"`
+	AdaptiveTrigger             *bool                     `json:"adaptive_trigger,omitempty"`
+	MinIntervalSec              *int                      `json:"min_interval_sec,omitempty"`
+	MaxIntervalSec              *int                      `json:"max_interval_sec,omitempty"`
+	MinUtterances               *int                      `json:"min_utterances,omitempty"`
+	MaxUtterances               *int                      `json:"max_utterances,omitempty"`
+}
+
+// Apply copies the set fields of the update onto config
+func (u AgentConfigUpdate) Apply(config *AgentConfig) {
+	if u.CustomPrompt != nil {
+		config.CustomPrompt = u.CustomPrompt
+	}
+	if u.PostMeetingEmailRecipients != nil {
+		config.PostMeetingEmailRecipients = *u.PostMeetingEmailRecipients
+	}
+	if u.ForceResponseLanguage != nil {
+		config.ForceResponseLanguage = *u.ForceResponseLanguage
+	}
+	if u.ReportLocale != nil {
+		config.ReportLocale = *u.ReportLocale
+	}
+	if u.WebhookSecret != nil {
+		config.WebhookSecret = *u.WebhookSecret
+	}
+	if u.EnableKeyQuotes != nil {
+		config.EnableKeyQuotes = *u.EnableKeyQuotes
+	}
+	if u.EnableConflictDetection != nil {
+		config.EnableConflictDetection = *u.EnableConflictDetection
+	}
+	if u.EnableCompetitiveIntel != nil {
+		config.EnableCompetitiveIntel = *u.EnableCompetitiveIntel
+	}
+	if u.EnableRequirementExtraction != nil {
+		config.EnableRequirementExtraction = *u.EnableRequirementExtraction
+	}
+	if u.EnableMetricExtraction != nil {
+		config.EnableMetricExtraction = *u.EnableMetricExtraction
+	}
+	if u.EnableLearningResources != nil {
+		config.EnableLearningResources = *u.EnableLearningResources
+	}
+	if u.EnableComplianceDetection != nil {
+		config.EnableComplianceDetection = *u.EnableComplianceDetection
+	}
+	if u.ComplianceFramework != nil {
+		config.ComplianceFramework = *u.ComplianceFramework
+	}
+	if u.EnableNPSProxy != nil {
+		config.EnableNPSProxy = *u.EnableNPSProxy
+	}
+	if u.EnableProductFeedback != nil {
+		config.EnableProductFeedback = *u.EnableProductFeedback
+	}
+	if u.EnablePersonaInference != nil {
+		config.EnablePersonaInference = *u.EnablePersonaInference
+	}
+	if u.EnableAcronymGlossary != nil {
+		config.EnableAcronymGlossary = *u.EnableAcronymGlossary
+	}
+	if u.EnableConversationFlow != nil {
+		config.EnableConversationFlow = *u.EnableConversationFlow
+	}
+	if u.EnableNewTermsGlossary != nil {
+		config.EnableNewTermsGlossary = *u.EnableNewTermsGlossary
+	}
+	if u.EnableTechDebtExtraction != nil {
+		config.EnableTechDebtExtraction = *u.EnableTechDebtExtraction
+	}
+	if u.EnableMetricsTracking != nil {
+		config.EnableMetricsTracking = *u.EnableMetricsTracking
+	}
+	if u.EnableSpeakerMoodTimeline != nil {
+		config.EnableSpeakerMoodTimeline = *u.EnableSpeakerMoodTimeline
+	}
+	if u.EnableBudgetExtraction != nil {
+		config.EnableBudgetExtraction = *u.EnableBudgetExtraction
+	}
+	if u.EnableStakeholderMap != nil {
+		config.EnableStakeholderMap = *u.EnableStakeholderMap
+	}
+	if u.EnableQAExtraction != nil {
+		config.EnableQAExtraction = *u.EnableQAExtraction
+	}
+	if u.EnableFollowUpSuggestion != nil {
+		config.EnableFollowUpSuggestion = *u.EnableFollowUpSuggestion
+	}
+	if u.EnableFollowUpEmailDraft != nil {
+		config.EnableFollowUpEmailDraft = *u.EnableFollowUpEmailDraft
+	}
+	if u.EnableEmotionalArc != nil {
+		config.EnableEmotionalArc = *u.EnableEmotionalArc
+	}
+	if u.EnableIcebreakerDetection != nil {
+		config.EnableIcebreakerDetection = *u.EnableIcebreakerDetection
+	}
+	if u.IcebreakerIndicators != nil {
+		config.IcebreakerIndicators = *u.IcebreakerIndicators
+	}
+	if u.MeetingType != nil {
+		config.MeetingType = *u.MeetingType
+	}
+	if u.EnableKeyContacts != nil {
+		config.EnableKeyContacts = *u.EnableKeyContacts
+	}
+	if u.EnableAttachmentTracking != nil {
+		config.EnableAttachmentTracking = *u.EnableAttachmentTracking
+	}
+	if u.EnableEthicsDetection != nil {
+		config.EnableEthicsDetection = *u.EnableEthicsDetection
+	}
+	if u.EnableBatchCalls != nil {
+		config.EnableBatchCalls = *u.EnableBatchCalls
+	}
+	if u.CostSavingMode != nil {
+		config.CostSavingMode = *u.CostSavingMode
+	}
+	if u.EnableMarketDataEnrichment != nil {
+		config.EnableMarketDataEnrichment = *u.EnableMarketDataEnrichment
+	}
+	if u.EnableQueryOptimization != nil {
+		config.EnableQueryOptimization = *u.EnableQueryOptimization
+	}
+	if u.StepTimeouts != nil {
+		config.StepTimeouts = *u.StepTimeouts
+	}
+	if u.ABTest != nil {
+		config.ABTest = u.ABTest
+	}
+	if u.Normalizer != nil {
+		config.Normalizer = u.Normalizer
+	}
+	if u.Archival != nil {
+		config.Archival = u.Archival
+	}
+	if u.WindowedAnalysis != nil {
+		config.WindowedAnalysis = u.WindowedAnalysis
+	}
+	if u.HealthThresholds != nil {
+		config.HealthThresholds = u.HealthThresholds
+	}
+	if u.MeetingTypeOptions != nil {
+		config.MeetingTypeOptions = *u.MeetingTypeOptions
+	}
+	if u.EnableLLMClassification != nil {
+		config.EnableLLMClassification = *u.EnableLLMClassification
+	}
+	if u.MeetingTypeKeywords != nil {
+		config.MeetingTypeKeywords = *u.MeetingTypeKeywords
+	}
+	if u.AdaptiveTrigger != nil {
+		config.AdaptiveTrigger = *u.AdaptiveTrigger
+	}
+	if u.MinIntervalSec != nil {
+		config.MinIntervalSec = *u.MinIntervalSec
+	}
+	if u.MaxIntervalSec != nil {
+		config.MaxIntervalSec = *u.MaxIntervalSec
+	}
+	if u.MinUtterances != nil {
+		config.MinUtterances = *u.MinUtterances
+	}
+	if u.MaxUtterances != nil {
+		config.MaxUtterances = *u.MaxUtterances
+	}
+	if u.Agenda != nil {
+		config.Agenda = *u.Agenda
+	}
+	if u.Objectives != nil {
+		config.Objectives = *u.Objectives
+	}
+	if u.KeywordAlerts != nil {
+		config.KeywordAlerts = *u.KeywordAlerts
+	}
+	if u.WordCloudStopwords != nil {
+		config.WordCloudStopwords = *u.WordCloudStopwords
+	}
+	if u.InternalSpeakers != nil {
+		config.InternalSpeakers = *u.InternalSpeakers
+	}
+	if u.InternalDomains != nil {
+		config.InternalDomains = *u.InternalDomains
+	}
+	if u.ParticipantHourlyRates != nil {
+		config.ParticipantHourlyRates = *u.ParticipantHourlyRates
+	}
+}
+
+// TranscriptRetentionPolicy controls pruning of in-memory transcript entries. Pruned entries are taken
+// from the middle of the transcript so the opening and most recent windows are always kept.
+type TranscriptRetentionPolicy struct {
+	MaxEntries   int `json:"max_entries" yaml:"max_entries"`       // 0 means unlimited
+	RetainFirstN int `json:"retain_first_n" yaml:"retain_first_n"` // Opening entries that are never pruned
+	RetainLastN  int `json:"retain_last_n" yaml:"retain_last_n"`   // Most recent entries that are never pruned
+}
+
+// NormalizerConfig controls clean-up of raw speech recognition text before it is added to the transcript
+type NormalizerConfig struct {
+	Enabled       bool `json:"enabled" yaml:"enabled"`
+	RemoveFillers bool `json:"remove_fillers" yaml:"remove_fillers"` // Drop hesitations ("um", "uh") and set-off fillers (", you know,")
+}
+
+// ArchivalPolicy controls archiving of finalized analyses for backup
+type ArchivalPolicy struct {
+	ArchiveOnFinalize  bool   `json:"archive_on_finalize" yaml:"archive_on_finalize"`
+	ArchiveDestination string `json:"archive_destination" yaml:"archive_destination"` // s3://bucket/prefix or gs://bucket/prefix
+	ArchiveFormat      string `json:"archive_format" yaml:"archive_format"`           // json or json_gz; defaults to json
+}
+
+// WindowedAnalysisConfig splits transcripts longer than one window into windows of WindowSizeEntries
+// entries, each sharing WindowOverlapEntries entries with the previous one
+type WindowedAnalysisConfig struct {
+	WindowSizeEntries    int `json:"window_size_entries" yaml:"window_size_entries"`
+	WindowOverlapEntries int `json:"window_overlap_entries" yaml:"window_overlap_entries"` // At most half the window size
+}
+
+// HealthThresholds are the limits beyond which a finalized meeting is flagged as unhealthy; unset limits
+// use the defaults given for each
+type HealthThresholds struct {
+	MaxDurationMinutes   float64 `json:"max_duration_minutes,omitempty" yaml:"max_duration_minutes,omitempty"`     // 60
+	MaxParticipants      int     `json:"max_participants,omitempty" yaml:"max_participants,omitempty"`             // 8
+	DominantSpeakerShare float64 `json:"dominant_speaker_share,omitempty" yaml:"dominant_speaker_share,omitempty"` // Share of speaking time, 0.6
+	MaxCrosstalkRate     float64 `json:"max_crosstalk_rate,omitempty" yaml:"max_crosstalk_rate,omitempty"`         // Events per minute, 0.5
+	MinAttentionScore    float64 `json:"min_attention_score,omitempty" yaml:"min_attention_score,omitempty"`       // Average attention score, 40
+}
+
+// ABTestConfig splits analysis runs between two action item prompt templates so their results can be
+// compared. Variants are Go templates with the same fields as prompt template files.
+type ABTestConfig struct {
+	Enabled    bool    `json:"enabled" yaml:"enabled"`
+	VariantA   string  `json:"variant_a" yaml:"variant_a"`
+	VariantB   string  `json:"variant_b" yaml:"variant_b"`
+	SplitRatio float64 `json:"split_ratio" yaml:"split_ratio"` // Share of runs using variant A; 0 means 0.5
+}
+
+// LLMProviderConfig selects the LLM provider and model of one analysis step
+type LLMProviderConfig struct {
+	Provider LLMProvider `json:"provider" yaml:"provider"`
+	Model    string      `json:"model" yaml:"model"`
+}
+
 // AgentConfig represents the configuration for an agent
 type AgentConfig struct {
 	Name             string           `json:"name" yaml:"name"`
@@ -74,6 +397,230 @@ type AgentConfig struct {
 	AutoJoin         bool             `json:"auto_join" yaml:"auto_join"`
 	ConversationMode ConversationMode `json:"conversation_mode" yaml:"conversation_mode"` // Mode of conversation: conversational or analyst
 
+	// Tenant the agent's meetings belong to, used to benchmark meetings against the same tenant's history
+	TenantID string `json:"tenant_id,omitempty" yaml:"tenant_id,omitempty"`
+
+	// Email addresses that receive the analysis digest when the meeting ends (analyst mode)
+	PostMeetingEmailRecipients []string `json:"post_meeting_email_recipients,omitempty" yaml:"post_meeting_email_recipients,omitempty"`
+
+	// ISO 639-1 code of the language analysis is written in, overriding transcript language detection
+	ForceResponseLanguage string `json:"force_response_language,omitempty" yaml:"force_response_language,omitempty"`
+
+	// Locale of the markdown report's headings (en, es, fr, de, ja); defaults to the detected transcript language
+	ReportLocale string `json:"report_locale,omitempty" yaml:"report_locale,omitempty"`
+
+	// Secret transcript payloads posted to the agent must be signed with (X-Webhook-Signature); unsigned posts are accepted when empty.
+	// It is never returned by the API.
+	WebhookSecret string `json:"webhook_secret,omitempty" yaml:"webhook_secret,omitempty"`
+
+	// Extract verbatim key quotes as an additional analysis step (analyst mode)
+	EnableKeyQuotes bool `json:"enable_key_quotes,omitempty" yaml:"enable_key_quotes,omitempty"`
+
+	// Detect statements that contradict earlier ones in meetings over 50 entries (analyst mode)
+	EnableConflictDetection bool `json:"enable_conflict_detection,omitempty" yaml:"enable_conflict_detection,omitempty"`
+
+	// Extract competitor mentions, pricing and feature comparisons for sales meetings (analyst mode)
+	EnableCompetitiveIntel bool `json:"enable_competitive_intel,omitempty" yaml:"enable_competitive_intel,omitempty"`
+
+	// Derive feature requirements and open questions for product and engineering meetings (analyst mode)
+	EnableRequirementExtraction bool `json:"enable_requirement_extraction,omitempty" yaml:"enable_requirement_extraction,omitempty"`
+
+	// Extract figures, percentages and counts stated in the meeting, verifying the top 5 with search (analyst mode)
+	EnableMetricExtraction bool `json:"enable_metric_extraction,omitempty" yaml:"enable_metric_extraction,omitempty"`
+
+	// Recommend books for learning gaps when the meeting is about learning or growth (analyst mode)
+	EnableLearningResources bool `json:"enable_learning_resources,omitempty" yaml:"enable_learning_resources,omitempty"`
+
+	// Flag statements that may breach HIPAA, SOX, GDPR or PCI DSS, alerting the error webhook on high-severity flags (analyst mode)
+	EnableComplianceDetection bool `json:"enable_compliance_detection,omitempty" yaml:"enable_compliance_detection,omitempty"`
+
+	// Instructions placed before every analysis prompt, after the operator's SYSTEM_PROMPT_PREFIX and before
+	// any custom prompt or template (analyst mode)
+	SystemPromptPrefix string `json:"system_prompt_prefix,omitempty" yaml:"system_prompt_prefix,omitempty"`
+
+	// Comma-separated regulations checked by compliance detection (HIPAA, SOX, GDPR, PCI); all of them when empty
+	ComplianceFramework string `json:"compliance_framework,omitempty" yaml:"compliance_framework,omitempty"`
+
+	// Estimate a Net Promoter Score from promoter and detractor language in the transcript (analyst mode)
+	EnableNPSProxy bool `json:"enable_nps_proxy,omitempty" yaml:"enable_nps_proxy,omitempty"`
+
+	// Planned type of the meeting, such as sales_call, which runs the analysis specific to that type:
+	// sales calls have the objections prospects raise extracted and posted to SALES_OBJECTION_WEBHOOK_URL
+	// when set (analyst mode)
+	MeetingType string `json:"meeting_type,omitempty" yaml:"meeting_type,omitempty"`
+
+	// Extract the customer-side contacts of the meeting and their buying roles when it is finalized, posting
+	// them to CRM_WEBHOOK_URL when set (analyst mode)
+	EnableKeyContacts bool `json:"enable_key_contacts,omitempty" yaml:"enable_key_contacts,omitempty"`
+
+	// Track the files and documents participants share or promise, adding an action item to share those
+	// someone is blocked on (analyst mode)
+	EnableAttachmentTracking bool `json:"enable_attachment_tracking,omitempty" yaml:"enable_attachment_tracking,omitempty"`
+
+	// Flag discriminatory, harassing, confidentiality-breaching or inappropriate language for HR, alerting the
+	// error webhook and emailing HR_NOTIFICATION_EMAIL on high-severity flags (analyst mode)
+	EnableEthicsDetection bool `json:"enable_ethics_detection,omitempty" yaml:"enable_ethics_detection,omitempty"`
+
+	// Extract customer feature requests, bug reports, complaints and praise with verbatim quotes, posting
+	// them to PRODUCT_FEEDBACK_WEBHOOK_URL when set (analyst mode)
+	EnableProductFeedback bool `json:"enable_product_feedback,omitempty" yaml:"enable_product_feedback,omitempty"`
+
+	// Profile each participant's likely role, communication style and technical depth from their own
+	// statements, for sales coaching (analyst mode)
+	EnablePersonaInference bool `json:"enable_persona_inference,omitempty" yaml:"enable_persona_inference,omitempty"`
+
+	// Expand the acronyms used in the meeting from context, searching for those the context doesn't explain
+	// and adding the expansions to the knowledge base for future meetings (analyst mode)
+	EnableAcronymGlossary bool `json:"enable_acronym_glossary,omitempty" yaml:"enable_acronym_glossary,omitempty"`
+
+	// Classify each transition between consecutive discussion topics as natural, abrupt or tangential, to
+	// show how structured the meeting was (analyst mode)
+	EnableConversationFlow bool `json:"enable_conversation_flow,omitempty" yaml:"enable_conversation_flow,omitempty"`
+
+	// Define the project names, jargon and other terms introduced in the meeting that aren't ordinary English
+	// words, verifying the definitions with a web search and adding them to the knowledge base for future
+	// meetings (analyst mode)
+	EnableNewTermsGlossary bool `json:"enable_new_terms_glossary,omitempty" yaml:"enable_new_terms_glossary,omitempty"`
+
+	// Record the technical debt engineers mention, such as hacks, workarounds and components needing a
+	// refactor, for engineering retrospectives (analyst mode)
+	EnableTechDebtExtraction bool `json:"enable_tech_debt_extraction,omitempty" yaml:"enable_tech_debt_extraction,omitempty"`
+
+	// Track the targets, KPIs and OKRs discussed in the meeting with their progress, verifying reported values
+	// with a web search and alerting when a metric is off track (analyst mode)
+	EnableMetricsTracking bool `json:"enable_metrics_tracking,omitempty" yaml:"enable_metrics_tracking,omitempty"`
+
+	// Judge each participant's mood (excited, neutral, skeptical, frustrated, confused, satisfied) in
+	// 3-minute windows sliding by a minute, to show when their tone shifted (analyst mode)
+	EnableSpeakerMoodTimeline bool `json:"enable_speaker_mood_timeline,omitempty" yaml:"enable_speaker_mood_timeline,omitempty"`
+
+	// Extract budget categories whose budgeted and actual amounts were compared, with their variance, for
+	// finance meetings (analyst mode)
+	EnableBudgetExtraction bool `json:"enable_budget_extraction,omitempty" yaml:"enable_budget_extraction,omitempty"`
+
+	// Map the external clients, vendors, regulators, partners and investors referenced in the meeting, leaving
+	// out internal speakers (analyst mode)
+	EnableStakeholderMap bool `json:"enable_stakeholder_map,omitempty" yaml:"enable_stakeholder_map,omitempty"`
+
+	// Extract the questions asked in the meeting with the answers they got and how fully they were answered,
+	// listing unanswered ones with the open questions (analyst mode)
+	EnableQAExtraction bool `json:"enable_qa_extraction,omitempty" yaml:"enable_qa_extraction,omitempty"`
+
+	// Recommend a follow-up meeting when the meeting is finalized, drafting it on Google Calendar if enabled
+	EnableFollowUpSuggestion bool `json:"enable_follow_up_suggestion,omitempty" yaml:"enable_follow_up_suggestion,omitempty"`
+
+	// Draft a follow-up email to the participants when the meeting is finalized
+	EnableFollowUpEmailDraft bool `json:"enable_follow_up_email_draft,omitempty" yaml:"enable_follow_up_email_draft,omitempty"`
+
+	// Narrate the meeting's emotional journey from its sentiment, moods and crosstalk when it is finalized
+	EnableEmotionalArc bool `json:"enable_emotional_arc,omitempty" yaml:"enable_emotional_arc,omitempty"`
+
+	// Detect the social opener of the meeting when it is finalized by matching IcebreakerIndicators, its
+	// greetings, personal topics and laughter markers, against the first five minutes; built-in indicators
+	// are used when unset
+	EnableIcebreakerDetection bool     `json:"enable_icebreaker_detection,omitempty" yaml:"enable_icebreaker_detection,omitempty"`
+	IcebreakerIndicators      []string `json:"icebreaker_indicators,omitempty" yaml:"icebreaker_indicators,omitempty"`
+
+	// Prefer local heuristics over extra LLM calls where analysis allows it
+	CostSavingMode bool `json:"cost_saving_mode,omitempty" yaml:"cost_saving_mode,omitempty"`
+
+	// Append live quotes for stocks mentioned in the meeting to grounded summaries
+	EnableMarketDataEnrichment bool `json:"enable_market_data_enrichment,omitempty" yaml:"enable_market_data_enrichment,omitempty"`
+
+	// Send the ungrounded core analysis prompts as one batch job. Gemini batch jobs are asynchronous and
+	// can take minutes, so this suits replays and finalization more than live analysis.
+	EnableBatchCalls bool `json:"enable_batch_calls,omitempty" yaml:"enable_batch_calls,omitempty"`
+
+	// Ask the LLM for targeted fact-checking search queries before each grounded call (one extra call per step)
+	EnableQueryOptimization bool `json:"enable_query_optimization,omitempty" yaml:"enable_query_optimization,omitempty"`
+
+	// Domains whose grounding sources are dropped, added to GROUNDING_BLACKLIST_DOMAINS
+	GroundingSourceBlacklist []string `json:"grounding_source_blacklist,omitempty" yaml:"grounding_source_blacklist,omitempty"`
+
+	// When set, only grounding sources from these domains are kept, replacing GROUNDING_WHITELIST_DOMAINS
+	GroundingSourceWhitelist []string `json:"grounding_source_whitelist,omitempty" yaml:"grounding_source_whitelist,omitempty"`
+
+	// Timeout for each analysis step keyed by step name (summary, executive_summary, terse_summaries, key_points, action_items, topics,
+	// sentiment_keywords, objectives, key_quotes, conflicts, competitive_intel, requirements, metrics,
+	// learning_resources, compliance, ethics, nps_proxy, product_feedback, speaker_personas, acronym_glossary,
+	// conversation_flow, new_terms, tech_debt, success_metrics, speaker_mood, budget, stakeholders, qa_pairs, sales_objections, attachments, external_nlp, follow_up, email_draft, emotional_arc, key_contacts, roi_report, meeting_type); steps without one use 30 seconds
+	StepTimeouts map[string]time.Duration `json:"step_timeouts,omitempty" yaml:"step_timeouts,omitempty"`
+
+	// Directory of {analysis type}.tmpl text/template files replacing the built-in analysis prompts, loaded
+	// when the analyst starts; types without a file keep the built-in prompt
+	PromptTemplateDir string `json:"prompt_template_dir,omitempty" yaml:"prompt_template_dir,omitempty"`
+
+	// Base URL of an external NLP service producing the summary, key points, action items, topics and
+	// sentiment instead of the LLM, authenticated with EXTERNAL_NLP_API_KEY; read when the agent starts
+	ExternalNLPURL string `json:"external_nlp_url,omitempty" yaml:"external_nlp_url,omitempty"`
+
+	// Planned agenda the analysis checks coverage of and deviations from (analyst mode)
+	Agenda []AgendaItem `json:"agenda,omitempty" yaml:"agenda,omitempty"`
+
+	// Objectives checked against the summary and topics after each analysis (analyst mode)
+	Objectives []MeetingObjective `json:"objectives,omitempty" yaml:"objectives,omitempty"`
+
+	// Regular expressions that post a Discord alert as soon as an utterance matches them (analyst mode)
+	KeywordAlerts []KeywordAlert `json:"keyword_alerts,omitempty" yaml:"keyword_alerts,omitempty"`
+
+	// Words excluded from the word cloud in addition to the built-in English stopwords
+	WordCloudStopwords []string `json:"word_cloud_stopwords,omitempty" yaml:"word_cloud_stopwords,omitempty"`
+
+	// Names of the participants on the team, matched exactly, for telling them apart from customers in
+	// mixed meetings (analyst mode)
+	InternalSpeakers []string `json:"internal_speakers,omitempty" yaml:"internal_speakers,omitempty"`
+
+	// Email domains of the team; participants identified by an email at another domain are external
+	InternalDomains []string `json:"internal_domains,omitempty" yaml:"internal_domains,omitempty"`
+
+	// Hourly rates in USD by participant name, used to estimate the meeting's cost when it is finalized;
+	// participants without one use DEFAULT_HOURLY_RATE_USD
+	ParticipantHourlyRates map[string]float64 `json:"participant_hourly_rates,omitempty" yaml:"participant_hourly_rates,omitempty"`
+
+	// Estimated analysis backlog in minutes that logs a capacity warning; defaults to 5
+	CapacityWarnThreshold float64 `json:"capacity_warn_threshold_minutes,omitempty" yaml:"capacity_warn_threshold_minutes,omitempty"`
+
+	// Analyze every 5 minutes or 20 utterances by default; with the adaptive trigger, analyze every MinIntervalSec
+	// seconds or MinUtterances utterances (defaults 120 and 10) above 30 utterances per minute, and every
+	// MaxIntervalSec seconds or MaxUtterances utterances (defaults 600 and 50) below 5 (analyst mode)
+	AdaptiveTrigger bool `json:"adaptive_trigger,omitempty" yaml:"adaptive_trigger,omitempty"`
+	MinIntervalSec  int  `json:"min_interval_sec,omitempty" yaml:"min_interval_sec,omitempty"`
+	MaxIntervalSec  int  `json:"max_interval_sec,omitempty" yaml:"max_interval_sec,omitempty"`
+	MinUtterances   int  `json:"min_utterances,omitempty" yaml:"min_utterances,omitempty"`
+	MaxUtterances   int  `json:"max_utterances,omitempty" yaml:"max_utterances,omitempty"`
+
+	// Limits how many transcript entries are kept in memory during long meetings (analyst mode)
+	TranscriptRetention *TranscriptRetentionPolicy `json:"transcript_retention,omitempty" yaml:"transcript_retention,omitempty"`
+
+	// Capitalizes and punctuates utterances, optionally removing filler words (analyst mode)
+	Normalizer *NormalizerConfig `json:"normalizer,omitempty" yaml:"normalizer,omitempty"`
+
+	// Compares two action item prompts across analysis runs (analyst mode)
+	ABTest *ABTestConfig `json:"ab_test,omitempty" yaml:"ab_test,omitempty"`
+
+	// Uploads the analysis to S3 or Google Cloud Storage when the meeting is finalized (analyst mode)
+	Archival *ArchivalPolicy `json:"archival,omitempty" yaml:"archival,omitempty"`
+
+	// Analyzes long transcripts in overlapping windows run concurrently, merging their results (analyst mode)
+	WindowedAnalysis *WindowedAnalysisConfig `json:"windowed_analysis,omitempty" yaml:"windowed_analysis,omitempty"`
+
+	// Limits the finalized meeting's health indicators flag, such as its duration and the share of speaking
+	// time of one participant (analyst mode)
+	HealthThresholds *HealthThresholds `json:"health_thresholds,omitempty" yaml:"health_thresholds,omitempty"`
+
+	// Meeting types the finalized meeting is classified into, defaulting to the 12 built-in types (standup,
+	// retrospective, sales_call, ...). The LLM classifies from the summary and keywords when
+	// EnableLLMClassification is set; otherwise, or when it fails, the keywords of each type are counted.
+	// MeetingTypeKeywords replaces the built-in keywords of the types it lists (analyst mode)
+	MeetingTypeOptions      []string            `json:"meeting_type_options,omitempty" yaml:"meeting_type_options,omitempty"`
+	EnableLLMClassification bool                `json:"enable_llm_classification,omitempty" yaml:"enable_llm_classification,omitempty"`
+	MeetingTypeKeywords     map[string][]string `json:"meeting_type_keywords,omitempty" yaml:"meeting_type_keywords,omitempty"`
+
+	// LLM provider and model of the core analysis steps (summary, key_points, action_items, topics,
+	// sentiment_keywords) that shouldn't use LLMProvider and LLMModel, such as a grounded provider for the
+	// summary; read when the agent starts (analyst mode)
+	StepProviders map[string]LLMProviderConfig `json:"step_providers,omitempty" yaml:"step_providers,omitempty"`
+
 	// Transcription Controller Parameters
 	UtteranceTailSeconds *float64 `json:"utterance_tail_seconds,omitempty" yaml:"utterance_tail_seconds,omitempty"`
 	NoSpeechEventDelay   *float64 `json:"no_speech_event_delay,omitempty" yaml:"no_speech_event_delay,omitempty"`
@@ -96,6 +643,15 @@ type Agent struct {
 	Logs        []LogEntry  `json:"logs" yaml:"logs"`
 }
 
+// MarshalJSON encodes the agent without its webhook secret, which is only accepted as input when creating or
+// updating the agent
+func (a Agent) MarshalJSON() ([]byte, error) {
+	type agent Agent
+	redacted := agent(a)
+	redacted.Config.WebhookSecret = ""
+	return json.Marshal(redacted)
+}
+
 // LogEntry represents a log entry for an agent
 type LogEntry struct {
 	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
@@ -0,0 +1,80 @@
+// Package wordcount is an example analysis plugin. It shows how to implement client.Plugin to add
+// custom post-processing to an analyst agent without modifying the client package:
+//
+//	analyst.RegisterPlugin(wordcount.NewWordDensityPlugin())
+package wordcount
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"joinly-manager/internal/client"
+)
+
+// WordDensityPlugin reports how many words each participant spoke and their share of the meeting
+type WordDensityPlugin struct{}
+
+// SpeakerDensity is the word count and share of the meeting for one speaker
+type SpeakerDensity struct {
+	Words int     `json:"words"`
+	Share float64 `json:"share"` // Fraction of all words spoken, from 0 to 1
+}
+
+// WordDensity is the plugin's output, stored under "word_density" in AnalysisData.PluginResults
+type WordDensity struct {
+	TotalWords     int                       `json:"total_words"`
+	WordsPerMinute float64                   `json:"words_per_minute"`
+	Speakers       map[string]SpeakerDensity `json:"speakers"`
+}
+
+// NewWordDensityPlugin creates a new word density plugin
+func NewWordDensityPlugin() *WordDensityPlugin {
+	return &WordDensityPlugin{}
+}
+
+// Name returns the key the plugin's output is stored under
+func (p *WordDensityPlugin) Name() string {
+	return "word_density"
+}
+
+// Priority returns the plugin's position in the run order
+func (p *WordDensityPlugin) Priority() int {
+	return 100
+}
+
+// Run counts the words spoken by each participant and stores the result in data.PluginResults
+func (p *WordDensityPlugin) Run(ctx context.Context, data *client.AnalysisData) error {
+	counts := make(map[string]int)
+	total := 0
+	for _, entry := range data.Transcript {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		words := len(strings.Fields(entry.Text))
+		counts[entry.Speaker] += words
+		total += words
+	}
+
+	result := WordDensity{
+		TotalWords: total,
+		Speakers:   make(map[string]SpeakerDensity, len(counts)),
+	}
+	if data.DurationMinutes > 0 {
+		result.WordsPerMinute = float64(total) / data.DurationMinutes
+	}
+	for speaker, words := range counts {
+		density := SpeakerDensity{Words: words}
+		if total > 0 {
+			density.Share = float64(words) / float64(total)
+		}
+		result.Speakers[speaker] = density
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	data.PluginResults[p.Name()] = raw
+	return nil
+}
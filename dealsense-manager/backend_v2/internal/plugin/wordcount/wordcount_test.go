@@ -0,0 +1,66 @@
+package wordcount
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"joinly-manager/internal/client"
+)
+
+func TestWordDensityPlugin(t *testing.T) {
+	data := &client.AnalysisData{
+		DurationMinutes: 2,
+		Transcript: []client.TranscriptEntry{
+			{Speaker: "Alice", Text: "Let's review the rollout plan"},
+			{Speaker: "Bob", Text: "Sounds good"},
+			{Speaker: "Alice", Text: "Great, thanks"},
+		},
+		PluginResults: map[string]json.RawMessage{},
+	}
+
+	plugin := NewWordDensityPlugin()
+	if err := plugin.Run(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	var result WordDensity
+	if err := json.Unmarshal(data.PluginResults[plugin.Name()], &result); err != nil {
+		t.Fatalf("plugin result not stored: %v", err)
+	}
+	if result.TotalWords != 9 || result.WordsPerMinute != 4.5 {
+		t.Errorf("total %d words at %.1f per minute, want 9 at 4.5", result.TotalWords, result.WordsPerMinute)
+	}
+	if alice := result.Speakers["Alice"]; alice.Words != 7 || math.Abs(alice.Share-7.0/9) > 1e-9 {
+		t.Errorf("Alice = %+v", alice)
+	}
+	if bob := result.Speakers["Bob"]; bob.Words != 2 {
+		t.Errorf("Bob = %+v", bob)
+	}
+}
+
+func TestWordDensityPluginEmptyMeeting(t *testing.T) {
+	data := &client.AnalysisData{PluginResults: map[string]json.RawMessage{}}
+	if err := NewWordDensityPlugin().Run(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data.PluginResults["word_density"]); got != `{"total_words":0,"words_per_minute":0,"speakers":{}}` {
+		t.Errorf("result = %s", got)
+	}
+}
+
+func TestWordDensityPluginStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data := &client.AnalysisData{
+		Transcript:    []client.TranscriptEntry{{Speaker: "Alice", Text: "Hello"}},
+		PluginResults: map[string]json.RawMessage{},
+	}
+	if err := NewWordDensityPlugin().Run(ctx, data); err == nil {
+		t.Error("Run() error = nil, want the context error")
+	}
+	if _, ok := data.PluginResults["word_density"]; ok {
+		t.Error("result stored after cancellation")
+	}
+}
@@ -0,0 +1,103 @@
+package shutdown
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HookFunc is run when the process shuts down; it should return once ctx is done
+type HookFunc func(ctx context.Context) error
+
+// ShutdownManager waits for a termination signal and then runs every registered hook concurrently,
+// giving them up to the configured timeout to finish
+type ShutdownManager struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	hooks   map[string]HookFunc
+	signals chan os.Signal
+}
+
+// NewShutdownManager creates a shutdown manager that allows hooks up to timeout to complete
+func NewShutdownManager(timeout time.Duration) *ShutdownManager {
+	return &ShutdownManager{
+		timeout: timeout,
+		hooks:   make(map[string]HookFunc),
+		signals: make(chan os.Signal, 1),
+	}
+}
+
+// Register adds a hook under name, replacing any hook already registered with that name
+func (sm *ShutdownManager) Register(name string, hook HookFunc) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.hooks[name] = hook
+}
+
+// Unregister removes the hook registered under name
+func (sm *ShutdownManager) Unregister(name string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	delete(sm.hooks, name)
+}
+
+// WaitForSignal blocks until the process receives SIGINT or SIGTERM and returns the signal
+func (sm *ShutdownManager) WaitForSignal() os.Signal {
+	signal.Notify(sm.signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sm.signals)
+
+	return <-sm.signals
+}
+
+// Shutdown runs all registered hooks concurrently and waits for them to finish or for the timeout
+// to expire. It returns false if the timeout expired before every hook finished.
+func (sm *ShutdownManager) Shutdown() bool {
+	sm.mu.Lock()
+	hooks := make(map[string]HookFunc, len(sm.hooks))
+	for name, hook := range sm.hooks {
+		hooks[name] = hook
+	}
+	sm.mu.Unlock()
+
+	if len(hooks) == 0 {
+		return true
+	}
+
+	logrus.Infof("Running %d shutdown hooks (timeout %s)", len(hooks), sm.timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sm.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for name, hook := range hooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := hook(ctx); err != nil {
+				logrus.Errorf("Shutdown hook %s failed: %v", name, err)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logrus.Info("All shutdown hooks completed")
+		return true
+	case <-ctx.Done():
+		logrus.Warnf("Shutdown timed out after %s with hooks still running", sm.timeout)
+		return false
+	}
+}
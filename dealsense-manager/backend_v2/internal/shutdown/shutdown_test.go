@@ -0,0 +1,67 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWaitForSignalReturnsSignal(t *testing.T) {
+	sm := NewShutdownManager(time.Second)
+	sm.signals <- syscall.SIGTERM
+	if sig := sm.WaitForSignal(); sig != syscall.SIGTERM {
+		t.Errorf("WaitForSignal = %v, want SIGTERM", sig)
+	}
+}
+
+func TestShutdownRunsEveryHook(t *testing.T) {
+	sm := NewShutdownManager(time.Second)
+	var ran atomic.Int32
+	for _, name := range []string{"a", "b", "c"} {
+		sm.Register(name, func(ctx context.Context) error {
+			ran.Add(1)
+			return nil
+		})
+	}
+	// A failing hook doesn't stop the others
+	sm.Register("failing", func(ctx context.Context) error { return errors.New("boom") })
+	sm.Register("removed", func(ctx context.Context) error {
+		t.Error("unregistered hook ran")
+		return nil
+	})
+	sm.Unregister("removed")
+
+	if !sm.Shutdown() {
+		t.Fatal("Shutdown timed out")
+	}
+	if ran.Load() != 3 {
+		t.Errorf("%d hooks ran, want 3", ran.Load())
+	}
+}
+
+func TestShutdownTimesOut(t *testing.T) {
+	sm := NewShutdownManager(50 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	sm.Register("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	if sm.Shutdown() {
+		t.Fatal("Shutdown reported success with a hook still running")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %s, want about the 50ms timeout", elapsed)
+	}
+}
+
+func TestShutdownWithoutHooks(t *testing.T) {
+	if !NewShutdownManager(time.Millisecond).Shutdown() {
+		t.Error("Shutdown without hooks should succeed")
+	}
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Document is a serialized analysis and the meeting it belongs to
+type Document struct {
+	Path      string // File the analysis is stored in
+	TenantID  string
+	MeetingID string
+	Data      []byte
+}
+
+// Backend persists analysis documents
+type Backend interface {
+	Save(ctx context.Context, doc Document) error
+}
+
+// FileBackend writes each document to its path, replacing the previous version atomically
+type FileBackend struct{}
+
+// Save writes the document to a temporary file and renames it over doc.Path, then stores its checksum
+func (FileBackend) Save(ctx context.Context, doc Document) error {
+	tmpPath := doc.Path + ".tmp"
+	if err := os.WriteFile(tmpPath, doc.Data, 0644); err != nil {
+		return fmt.Errorf("failed to write analysis file: %w", err)
+	}
+	if err := os.Rename(tmpPath, doc.Path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace analysis file: %w", err)
+	}
+	return WriteChecksum(doc.Path, doc.Data)
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+func TestFileBackendReplacesDocument(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analysis.json")
+
+	for _, data := range []string{`{"version":1}`, `{"version":2}`} {
+		if err := (FileBackend{}).Save(context.Background(), Document{Path: path, Data: []byte(data)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := readFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != `{"version":2}` {
+		t.Errorf("saved = %q, want the latest version", saved)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("directory has %d files, want the document and its checksum with the temporary file renamed away", len(entries))
+	}
+	if err := VerifyChecksum(path); err != nil {
+		t.Errorf("VerifyChecksum() = %v for the saved document", err)
+	}
+}
+
+func TestFileBackendMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "analysis.json")
+	if err := (FileBackend{}).Save(context.Background(), Document{Path: path, Data: []byte("{}")}); err == nil {
+		t.Error("Save() succeeded in a missing directory")
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when an analysis file changed since its checksum was stored
+var ErrChecksumMismatch = errors.New("analysis file does not match its stored checksum")
+
+// ChecksumPath returns the sidecar file holding the SHA-256 checksum of the analysis file at path
+func ChecksumPath(path string) string {
+	return path + ".sha256"
+}
+
+// WriteChecksum stores the hex SHA-256 of data, the content of the analysis file at path, in its sidecar
+func WriteChecksum(path string, data []byte) error {
+	if err := os.WriteFile(ChecksumPath(path), []byte(sha256Hex(data)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write analysis checksum: %w", err)
+	}
+	return nil
+}
+
+// VerifyChecksum checks the analysis file at path against its stored checksum. Files saved before checksums
+// were stored have none and pass.
+func VerifyChecksum(path string) error {
+	stored, err := os.ReadFile(ChecksumPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read analysis checksum: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read analysis file: %w", err)
+	}
+
+	if strings.TrimSpace(string(stored)) != sha256Hex(data) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meeting_analysis_a_1.json")
+	if err := os.WriteFile(path, []byte(`{"summary": "Launch"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(path); err != nil {
+		t.Errorf("VerifyChecksum() = %v, want files without a stored checksum to pass", err)
+	}
+
+	if err := WriteChecksum(path, []byte(`{"summary": "Launch"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(path); err != nil {
+		t.Errorf("VerifyChecksum() = %v for an unchanged file", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"summary": "Edited"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(path); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("VerifyChecksum() = %v, want ErrChecksumMismatch for a changed file", err)
+	}
+}
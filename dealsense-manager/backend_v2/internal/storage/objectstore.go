@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"joinly-manager/internal/config"
+)
+
+// gcsScope is the OAuth scope needed to upload objects to Google Cloud Storage
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// ObjectStore opens backends that upload documents to S3 or Google Cloud Storage. S3 requests are signed
+// with the configured AWS keys and GCS requests use Application Default Credentials.
+type ObjectStore struct {
+	cfg        config.ArchiveConfig
+	httpClient *http.Client
+
+	gcsOnce   sync.Once
+	gcsTokens oauth2.TokenSource
+	gcsErr    error
+}
+
+// NewObjectStore creates an object store with the given credentials
+func NewObjectStore(cfg *config.ArchiveConfig) *ObjectStore {
+	return &ObjectStore{
+		cfg:        *cfg,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Backend returns a backend uploading each document to the destination, an s3://bucket/prefix or
+// gs://bucket/prefix URL. The document's path is used as the object name under the prefix.
+func (s *ObjectStore) Backend(destination string) (Backend, error) {
+	parsed, err := url.Parse(destination)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid archive destination %q: must be an s3:// or gs:// URL", destination)
+	}
+	prefix := strings.Trim(parsed.Path, "/")
+
+	switch parsed.Scheme {
+	case "s3":
+		if s.cfg.S3AccessKeyID == "" || s.cfg.S3SecretAccessKey == "" {
+			return nil, fmt.Errorf("no AWS credentials are configured to archive to %s", destination)
+		}
+		return &s3Backend{store: s, bucket: parsed.Host, prefix: prefix}, nil
+	case "gs":
+		return &gcsBackend{store: s, bucket: parsed.Host, prefix: prefix}, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive destination %q: must be an s3:// or gs:// URL", destination)
+	}
+}
+
+// objectName joins the destination prefix and the document path
+func objectName(prefix, path string) string {
+	path = strings.TrimLeft(path, "/")
+	if prefix == "" {
+		return path
+	}
+	return prefix + "/" + path
+}
+
+// contentType returns the content type of an archived analysis from its name
+func contentType(name string) string {
+	if strings.HasSuffix(name, ".gz") {
+		return "application/gzip"
+	}
+	return "application/json"
+}
+
+// upload sends a prepared request, failing on an error status
+func (s *ObjectStore) upload(req *http.Request) error {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("upload to %s returned status %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// s3Backend uploads documents to an S3 bucket, or an S3-compatible service when an endpoint is configured
+type s3Backend struct {
+	store  *ObjectStore
+	bucket string
+	prefix string
+}
+
+// Save uploads the document with a SigV4-signed PUT request
+func (b *s3Backend) Save(ctx context.Context, doc Document) error {
+	cfg := b.store.cfg
+	key := objectName(b.prefix, doc.Path)
+
+	target := &url.URL{Scheme: "https", Host: b.bucket + ".s3." + cfg.S3Region + ".amazonaws.com"}
+	canonicalURI := "/" + awsURIEncode(key, false)
+	if cfg.S3Endpoint != "" {
+		endpoint, err := url.Parse(cfg.S3Endpoint)
+		if err != nil || endpoint.Host == "" {
+			return fmt.Errorf("invalid S3 endpoint %q", cfg.S3Endpoint)
+		}
+		// Path-style addressing, which S3-compatible services support
+		target = &url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host}
+		canonicalURI = "/" + awsURIEncode(b.bucket, true) + canonicalURI
+	}
+	target.RawPath = canonicalURI
+	target.Path, _ = url.PathUnescape(canonicalURI)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(doc.Data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType(key))
+	signS3Request(req, canonicalURI, doc.Data, cfg, time.Now().UTC())
+
+	return b.store.upload(req)
+}
+
+// signS3Request adds AWS Signature Version 4 headers to an S3 request
+func signS3Request(req *http.Request, canonicalURI string, payload []byte, cfg config.ArchiveConfig, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if cfg.S3SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cfg.S3SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{req.Method, canonicalURI, "", canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+	scope := date + "/" + cfg.S3Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+cfg.S3SecretAccessKey), date)
+	for _, part := range []string{cfg.S3Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cfg.S3AccessKeyID, scope, signedHeaders, signature))
+}
+
+// awsURIEncode percent-encodes everything except unreserved characters, and slashes unless encodeSlash
+func awsURIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// gcsBackend uploads documents to a Google Cloud Storage bucket
+type gcsBackend struct {
+	store  *ObjectStore
+	bucket string
+	prefix string
+}
+
+// Save uploads the document with the JSON API's simple upload
+func (b *gcsBackend) Save(ctx context.Context, doc Document) error {
+	tokens, err := b.store.gcsTokenSource()
+	if err != nil {
+		return err
+	}
+	token, err := tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get Google Cloud Storage token: %w", err)
+	}
+
+	name := objectName(b.prefix, doc.Path)
+	endpoint := "https://storage.googleapis.com/upload/storage/v1/b/" + url.PathEscape(b.bucket) +
+		"/o?uploadType=media&name=" + url.QueryEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(doc.Data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType(name))
+	token.SetAuthHeader(req)
+
+	return b.store.upload(req)
+}
+
+// gcsTokenSource loads Application Default Credentials the first time they are needed
+func (s *ObjectStore) gcsTokenSource() (oauth2.TokenSource, error) {
+	s.gcsOnce.Do(func() {
+		s.gcsTokens, s.gcsErr = google.DefaultTokenSource(context.Background(), gcsScope)
+		if s.gcsErr != nil {
+			s.gcsErr = fmt.Errorf("no Google Cloud credentials to archive with: %w", s.gcsErr)
+		}
+	})
+	return s.gcsTokens, s.gcsErr
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/config"
+)
+
+func TestObjectStoreBackendDestinations(t *testing.T) {
+	store := NewObjectStore(&config.ArchiveConfig{S3AccessKeyID: "key", S3SecretAccessKey: "secret"})
+
+	for _, destination := range []string{"s3://bucket/prefix", "gs://bucket"} {
+		if _, err := store.Backend(destination); err != nil {
+			t.Errorf("Backend(%q) = %v", destination, err)
+		}
+	}
+	for _, destination := range []string{"ftp://bucket/prefix", "s3:///prefix", "bucket/prefix"} {
+		if _, err := store.Backend(destination); err == nil {
+			t.Errorf("Backend(%q) accepted an invalid destination", destination)
+		}
+	}
+
+	if _, err := NewObjectStore(&config.ArchiveConfig{}).Backend("s3://bucket"); err == nil {
+		t.Error("S3 backend created without credentials")
+	}
+}
+
+func TestS3BackendUploadsToEndpoint(t *testing.T) {
+	var method, path, contentType, authorization, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.EscapedPath(), string(data)
+		contentType, authorization = r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	store := NewObjectStore(&config.ArchiveConfig{
+		S3AccessKeyID:     "AKIDEXAMPLE",
+		S3SecretAccessKey: "secret",
+		S3Region:          "us-east-1",
+		S3Endpoint:        server.URL,
+	})
+	backend, err := store.Backend("s3://archive/meetings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Save(context.Background(), Document{Path: "acme/meeting 1.json.gz", Data: []byte("data")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPut || path != "/archive/meetings/acme/meeting%201.json.gz" {
+		t.Errorf("request = %s %s", method, path)
+	}
+	if contentType != "application/gzip" || body != "data" {
+		t.Errorf("content type, body = %q, %q", contentType, body)
+	}
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Errorf("authorization = %q, want a SigV4 signature", authorization)
+	}
+}
+
+func TestS3BackendReportsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	store := NewObjectStore(&config.ArchiveConfig{S3AccessKeyID: "key", S3SecretAccessKey: "secret", S3Region: "us-east-1", S3Endpoint: server.URL})
+	backend, err := store.Backend("s3://archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = backend.Save(context.Background(), Document{Path: "analysis.json", Data: []byte("{}")})
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Save() = %v, want the error status", err)
+	}
+}
+
+func TestSignS3RequestIsDeterministic(t *testing.T) {
+	cfg := config.ArchiveConfig{S3AccessKeyID: "key", S3SecretAccessKey: "secret", S3Region: "eu-west-1"}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	sign := func(secret string) string {
+		req, _ := http.NewRequest(http.MethodPut, "https://bucket.s3.eu-west-1.amazonaws.com/a.json", nil)
+		cfg.S3SecretAccessKey = secret
+		signS3Request(req, "/a.json", []byte("{}"), cfg, now)
+		return req.Header.Get("Authorization")
+	}
+
+	if sign("secret") != sign("secret") {
+		t.Error("signature differs for identical requests")
+	}
+	if sign("secret") == sign("other") {
+		t.Error("signature doesn't depend on the secret key")
+	}
+	if !strings.Contains(sign("secret"), "Credential=key/20240501/eu-west-1/s3/aws4_request") {
+		t.Errorf("authorization = %q, want the credential scope", sign("secret"))
+	}
+}
+
+func TestAWSURIEncode(t *testing.T) {
+	if got := awsURIEncode("a b/c~d", false); got != "a%20b/c~d" {
+		t.Errorf("awsURIEncode = %q", got)
+	}
+	if got := awsURIEncode("a/b", true); got != "a%2Fb" {
+		t.Errorf("awsURIEncode with slashes = %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AnalysisChannelPattern matches every analysis update channel
+	AnalysisChannelPattern = "analysis:*"
+
+	publishAttempts    = 3
+	publishBaseBackoff = 100 * time.Millisecond
+)
+
+// AnalysisChannel is the Redis channel updates to a meeting's analysis are published on
+func AnalysisChannel(tenantID, meetingID string) string {
+	return fmt.Sprintf("analysis:%s:%s", tenantID, meetingID)
+}
+
+// NewRedisClient creates a Redis client from a redis:// or rediss:// URL
+func NewRedisClient(redisURL string) (*redis.Client, error) {
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	return redis.NewClient(options), nil
+}
+
+// RedisPublisher saves documents with the wrapped backend, then publishes them to the meeting's
+// analysis channel so other services can react to updates
+type RedisPublisher struct {
+	next   Backend
+	client *redis.Client
+}
+
+// NewRedisPublisher wraps next, publishing each saved document with client
+func NewRedisPublisher(next Backend, client *redis.Client) *RedisPublisher {
+	return &RedisPublisher{next: next, client: client}
+}
+
+// Save saves the document and publishes it. Publishing is retried with exponential backoff; a failure
+// is logged rather than returned since the analysis itself was saved.
+func (p *RedisPublisher) Save(ctx context.Context, doc Document) error {
+	if err := p.next.Save(ctx, doc); err != nil {
+		return err
+	}
+
+	channel := AnalysisChannel(doc.TenantID, doc.MeetingID)
+	err := p.client.Publish(ctx, channel, doc.Data).Err()
+	for attempt := 1; err != nil && attempt < publishAttempts && ctx.Err() == nil; attempt++ {
+		time.Sleep(publishBaseBackoff << (attempt - 1))
+		err = p.client.Publish(ctx, channel, doc.Data).Err()
+	}
+	if err != nil {
+		logrus.Warnf("Failed to publish analysis update to %s: %v", channel, err)
+	}
+	return nil
+}
+
+// Close closes the Redis connection
+func (p *RedisPublisher) Close() error {
+	return p.client.Close()
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// failingBackend fails every save
+type failingBackend struct{}
+
+func (failingBackend) Save(ctx context.Context, doc Document) error {
+	return errors.New("disk full")
+}
+
+func TestRedisPublisherPublishesSavedDocuments(t *testing.T) {
+	server := miniredis.RunT(t)
+	client, err := NewRedisClient("redis://" + server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publisher := NewRedisPublisher(FileBackend{}, client)
+	defer publisher.Close()
+
+	ctx := context.Background()
+	sub := client.Subscribe(ctx, AnalysisChannel("acme", "meeting-1"))
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := Document{
+		Path:      filepath.Join(t.TempDir(), "analysis.json"),
+		TenantID:  "acme",
+		MeetingID: "meeting-1",
+		Data:      []byte(`{"meeting_id":"meeting-1"}`),
+	}
+	if err := publisher.Save(ctx, doc); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		if msg.Channel != "analysis:acme:meeting-1" {
+			t.Errorf("channel = %q", msg.Channel)
+		}
+		if msg.Payload != string(doc.Data) {
+			t.Errorf("payload = %q, want the saved document", msg.Payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no analysis update was published")
+	}
+}
+
+func TestRedisPublisherSavesWhenPublishFails(t *testing.T) {
+	server := miniredis.RunT(t)
+	client, err := NewRedisClient("redis://" + server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publisher := NewRedisPublisher(FileBackend{}, client)
+	defer publisher.Close()
+	server.SetError("LOADING Redis is loading the dataset in memory")
+
+	doc := Document{Path: filepath.Join(t.TempDir(), "analysis.json"), TenantID: "acme", MeetingID: "meeting-1", Data: []byte("{}")}
+	if err := publisher.Save(context.Background(), doc); err != nil {
+		t.Fatalf("Save() = %v, want publish failures to be logged only", err)
+	}
+	if _, err := readFile(doc.Path); err != nil {
+		t.Errorf("analysis was not saved: %v", err)
+	}
+}
+
+func TestRedisPublisherDoesNotPublishFailedSaves(t *testing.T) {
+	server := miniredis.RunT(t)
+	client, err := NewRedisClient("redis://" + server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publisher := NewRedisPublisher(failingBackend{}, client)
+	defer publisher.Close()
+
+	ctx := context.Background()
+	sub := client.Subscribe(ctx, AnalysisChannel("acme", "meeting-1"))
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := publisher.Save(ctx, Document{TenantID: "acme", MeetingID: "meeting-1", Data: []byte("{}")}); err == nil {
+		t.Fatal("Save() succeeded with a failing backend")
+	}
+	select {
+	case msg := <-sub.Channel():
+		t.Errorf("published %q for a failed save", msg.Payload)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNewRedisClientRejectsInvalidURL(t *testing.T) {
+	if _, err := NewRedisClient("http://localhost:6379"); err == nil {
+		t.Error("NewRedisClient accepted a non-Redis URL")
+	}
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"joinly-manager/internal/config"
+)
+
+// RetentionEnforcer deletes old analysis files so the analysis directory doesn't grow without bound
+type RetentionEnforcer struct {
+	dir    string
+	policy config.RetentionPolicy
+}
+
+// NewRetentionEnforcer creates a retention enforcer for the files in dir
+func NewRetentionEnforcer(dir string, policy config.RetentionPolicy) *RetentionEnforcer {
+	return &RetentionEnforcer{dir: dir, policy: policy}
+}
+
+// Start enforces the policy every interval until the context is cancelled
+func (e *RetentionEnforcer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.Enforce(time.Now()); err != nil {
+					logrus.Errorf("Failed to enforce analysis retention policy: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// analysisFilePattern matches the analysis files saved by analyst agents. Only these and their sidecars are
+// deleted; the other files in the directory, like the meeting index, are left alone.
+const analysisFilePattern = "meeting_analysis_*.json"
+
+// overflowSuffix replaces ".json" in the name of an analysis file's transcript overflow sidecar
+const overflowSuffix = "_overflow.jsonl"
+
+// retainedFile is an analysis file considered for deletion, with its sidecars
+type retainedFile struct {
+	path    string   // The analysis file
+	paths   []string // The analysis file and its sidecars present in the directory
+	size    int64    // Total size of paths
+	modTime time.Time
+}
+
+// analysisFileOf returns the analysis file name is, or is a sidecar of, and whether there is one
+func analysisFileOf(name string) (string, bool) {
+	analysisName := name
+	if base, ok := strings.CutSuffix(name, overflowSuffix); ok {
+		analysisName = base + ".json"
+	} else if base, ok := strings.CutSuffix(name, ".sha256"); ok {
+		analysisName = base
+	}
+	matched, _ := filepath.Match(analysisFilePattern, analysisName)
+	return analysisName, matched
+}
+
+// Enforce deletes analysis files last modified more than MaxAgeDays before now, then deletes the oldest
+// remaining ones until the directory is within MaxStorageMB. A limit of zero or less disables that check.
+// Each analysis file is deleted with its overflow and checksum sidecars.
+func (e *RetentionEnforcer) Enforce(now time.Time) error {
+	entries, err := os.ReadDir(e.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list analysis directory: %w", err)
+	}
+
+	byName := make(map[string]*retainedFile)
+	var totalSize int64
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		analysisName, ok := analysisFileOf(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		file := byName[analysisName]
+		if file == nil {
+			file = &retainedFile{path: filepath.Join(e.dir, analysisName)}
+			byName[analysisName] = file
+		}
+		file.paths = append(file.paths, filepath.Join(e.dir, entry.Name()))
+		file.size += info.Size()
+		if info.ModTime().After(file.modTime) {
+			file.modTime = info.ModTime()
+		}
+		totalSize += info.Size()
+	}
+
+	files := make([]*retainedFile, 0, len(byName))
+	for _, file := range byName {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	if e.policy.MaxAgeDays > 0 {
+		cutoff := now.AddDate(0, 0, -e.policy.MaxAgeDays)
+		remaining := files[:0]
+		for _, file := range files {
+			if file.modTime.Before(cutoff) && e.delete(file, "older than %d days", e.policy.MaxAgeDays) {
+				totalSize -= file.size
+				continue
+			}
+			remaining = append(remaining, file)
+		}
+		files = remaining
+	}
+
+	if e.policy.MaxStorageMB > 0 {
+		limit := int64(e.policy.MaxStorageMB) * 1024 * 1024
+		for _, file := range files {
+			if totalSize <= limit {
+				break
+			}
+			if e.delete(file, "analysis storage over %d MB", e.policy.MaxStorageMB) {
+				totalSize -= file.size
+			}
+		}
+	}
+
+	return nil
+}
+
+// delete removes an analysis file and its sidecars, logging why, and reports whether it was deleted. With
+// VerifyIntegrity, files that no longer match their stored checksum are kept for inspection.
+func (e *RetentionEnforcer) delete(file *retainedFile, reason string, args ...interface{}) bool {
+	if e.policy.VerifyIntegrity {
+		// Orphaned sidecars have no analysis file left to verify
+		if err := VerifyChecksum(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf("Keeping analysis file %s that failed integrity verification: %v", file.path, err)
+			return false
+		}
+	}
+
+	for _, path := range file.paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Failed to delete analysis file %s: %v", path, err)
+			return false
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"file":          file.path,
+		"size_bytes":    file.size,
+		"last_modified": file.modTime,
+	}).Infof("Deleted analysis file: %s", fmt.Sprintf(reason, args...))
+	return true
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"joinly-manager/internal/config"
+)
+
+// writeAged writes a file of size bytes last modified age before now
+func writeAged(t *testing.T, dir, name string, size int, now time.Time, age time.Duration) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := now.Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// remaining lists the files left in dir
+func remaining(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRetentionDeletesFilesOlderThanMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	day := 24 * time.Hour
+	writeAged(t, dir, "meeting_analysis_old.json", 10, now, 31*day)
+	writeAged(t, dir, "meeting_analysis_recent.json", 10, now, 29*day)
+
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxAgeDays: 30})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := remaining(t, dir); len(got) != 1 || got[0] != "meeting_analysis_recent.json" {
+		t.Errorf("remaining files = %v, want [meeting_analysis_recent.json]", got)
+	}
+}
+
+func TestRetentionDeletesOldestFilesOverMaxStorage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	half := 512 * 1024
+	writeAged(t, dir, "meeting_analysis_a.json", half, now, 4*time.Hour)
+	writeAged(t, dir, "meeting_analysis_b.json", half, now, time.Hour)
+	writeAged(t, dir, "meeting_analysis_c.json", half, now, 3*time.Hour)
+	writeAged(t, dir, "meeting_analysis_d.json", half, now, 2*time.Hour)
+
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxStorageMB: 1})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	got := remaining(t, dir)
+	if len(got) != 2 || got[0] != "meeting_analysis_b.json" || got[1] != "meeting_analysis_d.json" {
+		t.Errorf("remaining files = %v, want the two newest [b d]", got)
+	}
+}
+
+func TestRetentionAppliesMaxAgeBeforeMaxStorage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	day := 24 * time.Hour
+	size := 400 * 1024
+	writeAged(t, dir, "meeting_analysis_expired.json", size, now, 10*day)
+	writeAged(t, dir, "meeting_analysis_older.json", size, now, 2*day)
+	writeAged(t, dir, "meeting_analysis_newer.json", size, now, day)
+
+	// Deleting the expired file brings the directory within the limit, so nothing else goes
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxAgeDays: 7, MaxStorageMB: 1})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	got := remaining(t, dir)
+	if len(got) != 2 || got[0] != "meeting_analysis_newer.json" || got[1] != "meeting_analysis_older.json" {
+		t.Errorf("remaining files = %v, want [newer older]", got)
+	}
+}
+
+func TestRetentionDisabledLimitsKeepFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeAged(t, dir, "meeting_analysis_ancient.json", 2*1024*1024, now, 1000*24*time.Hour)
+
+	if err := NewRetentionEnforcer(dir, config.RetentionPolicy{}).Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+	if got := remaining(t, dir); len(got) != 1 {
+		t.Errorf("remaining files = %v, want nothing deleted", got)
+	}
+}
+
+func TestRetentionIgnoresMissingDirectory(t *testing.T) {
+	enforcer := NewRetentionEnforcer(filepath.Join(t.TempDir(), "missing"), config.RetentionPolicy{MaxAgeDays: 1})
+	if err := enforcer.Enforce(time.Now()); err != nil {
+		t.Errorf("Enforce() = %v, want nil for a missing directory", err)
+	}
+}
+
+func TestRetentionOnlyDeletesAnalysisFilesAndSidecars(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	age := 10 * 24 * time.Hour
+	writeAged(t, dir, "meeting_analysis_a_1.json", 10, now, age)
+	writeAged(t, dir, "meeting_analysis_a_1_overflow.jsonl", 10, now, age)
+	writeAged(t, dir, "meeting_analysis_a_1.json.sha256", 10, now, age)
+	writeAged(t, dir, "meeting_index.json", 10, now, age)
+	writeAged(t, dir, "notes.txt", 10, now, age)
+
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxAgeDays: 7})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	got := remaining(t, dir)
+	if len(got) != 2 || got[0] != "meeting_index.json" || got[1] != "notes.txt" {
+		t.Errorf("remaining files = %v, want only the files that aren't analyses", got)
+	}
+}
+
+func TestRetentionCountsSidecarsTowardsStorage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	half := 512 * 1024
+	writeAged(t, dir, "meeting_analysis_old.json", 1024, now, 2*time.Hour)
+	writeAged(t, dir, "meeting_analysis_old_overflow.jsonl", half, now, 2*time.Hour)
+	writeAged(t, dir, "meeting_analysis_new.json", half, now, time.Hour)
+
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxStorageMB: 1})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := remaining(t, dir); len(got) != 1 || got[0] != "meeting_analysis_new.json" {
+		t.Errorf("remaining files = %v, want the old analysis deleted with its overflow", got)
+	}
+}
+
+func TestRetentionVerifiesChecksumsBeforeDeleting(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	age := 10 * 24 * time.Hour
+	for _, name := range []string{"meeting_analysis_intact.json", "meeting_analysis_tampered.json"} {
+		path := filepath.Join(dir, name)
+		if err := (FileBackend{}).Save(context.Background(), Document{Path: path, Data: []byte(`{"summary": "Launch"}`)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAged(t, dir, "meeting_analysis_tampered.json", 10, now, age)
+	for _, name := range []string{"meeting_analysis_intact.json", "meeting_analysis_intact.json.sha256", "meeting_analysis_tampered.json.sha256"} {
+		if err := os.Chtimes(filepath.Join(dir, name), now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	enforcer := NewRetentionEnforcer(dir, config.RetentionPolicy{MaxAgeDays: 7, VerifyIntegrity: true})
+	if err := enforcer.Enforce(now); err != nil {
+		t.Fatal(err)
+	}
+
+	got := remaining(t, dir)
+	if len(got) != 2 || got[0] != "meeting_analysis_tampered.json" || got[1] != "meeting_analysis_tampered.json.sha256" {
+		t.Errorf("remaining files = %v, want only the file failing verification kept", got)
+	}
+}
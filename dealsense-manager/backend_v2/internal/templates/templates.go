@@ -0,0 +1,281 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"joinly-manager/internal/client"
+)
+
+const (
+	// MinMeetingsForTemplate is the number of finalized meetings of a type needed before its template is learned
+	MinMeetingsForTemplate = 10
+	// minItemFrequency is the share of meetings an agenda item, action type or role must appear in to be
+	// part of the template
+	minItemFrequency = 0.6
+	// maxLearnedMeetings is the number of most recent meetings of each type templates are learned from
+	maxLearnedMeetings = 50
+)
+
+// ErrNotEnoughMeetings is returned when learning a template from fewer than MinMeetingsForTemplate meetings
+var ErrNotEnoughMeetings = errors.New("not enough meetings to learn a template")
+
+// MeetingTemplate is a reusable plan for meetings of one type, learned from past meetings of that type
+type MeetingTemplate struct {
+	MeetingType                 string       `json:"meeting_type"`
+	Name                        string       `json:"name"`
+	SuggestedAgenda             []AgendaItem `json:"suggested_agenda"`
+	TypicalDuration             float64      `json:"typical_duration_minutes"` // Average meeting length
+	TypicalParticipantCount     int          `json:"typical_participant_count"`
+	CommonActionTypes           []string     `json:"common_action_types"`
+	RecommendedParticipantRoles []string     `json:"recommended_participant_roles"`
+	MeetingsAnalyzed            int          `json:"meetings_analyzed"`
+}
+
+// AgendaItem is a topic discussed in most meetings of a type
+type AgendaItem struct {
+	Title           string  `json:"title"`
+	DurationMinutes float64 `json:"duration_minutes"` // Average time spent on the topic
+	Frequency       float64 `json:"frequency"`        // Share of the meetings the topic came up in, 0.6 to 1
+}
+
+// TemplateLearner collects finalized meetings by meeting type and learns a template for each type once
+// enough meetings of it have been seen
+type TemplateLearner struct {
+	mu        sync.RWMutex
+	meetings  map[string][]client.AnalysisData // Most recent maxLearnedMeetings meetings of each type, trimmed to what templates use
+	templates map[string]MeetingTemplate
+}
+
+// NewTemplateLearner creates a learner without any meetings
+func NewTemplateLearner() *TemplateLearner {
+	return &TemplateLearner{
+		meetings:  make(map[string][]client.AnalysisData),
+		templates: make(map[string]MeetingTemplate),
+	}
+}
+
+// Add records a finalized meeting and relearns the template of its type, returning whether a template
+// was learned. Meetings without a meeting type are ignored.
+func (l *TemplateLearner) Add(data client.AnalysisData) bool {
+	if data.MeetingType == "" {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	meetings := append(l.meetings[data.MeetingType], client.AnalysisData{
+		MeetingType:     data.MeetingType,
+		ActionItems:     data.ActionItems,
+		Topics:          data.Topics,
+		Participants:    data.Participants,
+		DurationMinutes: data.DurationMinutes,
+		SpeakerPersonas: data.SpeakerPersonas,
+	})
+	if len(meetings) > maxLearnedMeetings {
+		meetings = meetings[len(meetings)-maxLearnedMeetings:]
+	}
+	l.meetings[data.MeetingType] = meetings
+
+	template, err := l.Learn(meetings)
+	if err != nil {
+		return false
+	}
+	l.templates[data.MeetingType] = template
+	return true
+}
+
+// Learn builds a template from meetings of the same type, named after the type of the first meeting.
+// Agenda items, action types and participant roles are only included when they appear in at least 60%
+// of the meetings.
+func (l *TemplateLearner) Learn(meetings []client.AnalysisData) (MeetingTemplate, error) {
+	if len(meetings) < MinMeetingsForTemplate {
+		return MeetingTemplate{}, fmt.Errorf("%w: %d of %d", ErrNotEnoughMeetings, len(meetings), MinMeetingsForTemplate)
+	}
+
+	meetingType := meetings[0].MeetingType
+	var totalDuration float64
+	participantCounts := make([]int, 0, len(meetings))
+	actionTypes := newOccurrences()
+	roles := newOccurrences()
+	agenda := newOccurrences()
+	for _, meeting := range meetings {
+		totalDuration += meeting.DurationMinutes
+		participantCounts = append(participantCounts, len(meeting.Participants))
+
+		for _, item := range meeting.ActionItems {
+			actionTypes.add(item.Type, 0, 0)
+		}
+		for _, persona := range meeting.SpeakerPersonas {
+			if !strings.EqualFold(persona.InferredRole, "unknown") {
+				roles.add(persona.InferredRole, 0, 0)
+			}
+		}
+		for i, topic := range meeting.Topics {
+			agenda.add(topic.Topic, topic.Duration, float64(i)/float64(len(meeting.Topics)))
+		}
+
+		actionTypes.endMeeting()
+		roles.endMeeting()
+		agenda.endMeeting()
+	}
+
+	template := MeetingTemplate{
+		MeetingType:                 meetingType,
+		Name:                        templateName(meetingType),
+		SuggestedAgenda:             []AgendaItem{},
+		TypicalDuration:             totalDuration / float64(len(meetings)),
+		TypicalParticipantCount:     median(participantCounts),
+		CommonActionTypes:           []string{},
+		RecommendedParticipantRoles: []string{},
+		MeetingsAnalyzed:            len(meetings),
+	}
+	for _, item := range actionTypes.common(len(meetings)) {
+		template.CommonActionTypes = append(template.CommonActionTypes, item.key)
+	}
+	for _, item := range roles.common(len(meetings)) {
+		template.RecommendedParticipantRoles = append(template.RecommendedParticipantRoles, item.name())
+	}
+
+	// Agenda items follow the order topics are usually discussed in
+	items := agenda.common(len(meetings))
+	sort.SliceStable(items, func(i, j int) bool { return items[i].averagePosition() < items[j].averagePosition() })
+	for _, item := range items {
+		template.SuggestedAgenda = append(template.SuggestedAgenda, AgendaItem{
+			Title:           item.name(),
+			DurationMinutes: item.totalDuration / float64(item.mentions),
+			Frequency:       float64(item.meetings) / float64(len(meetings)),
+		})
+	}
+	return template, nil
+}
+
+// Templates returns the learned templates ordered by meeting type
+func (l *TemplateLearner) Templates() []MeetingTemplate {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	templates := make([]MeetingTemplate, 0, len(l.templates))
+	for _, template := range l.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].MeetingType < templates[j].MeetingType })
+	return templates
+}
+
+// Template returns the learned template of a meeting type
+func (l *TemplateLearner) Template(meetingType string) (MeetingTemplate, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	template, ok := l.templates[meetingType]
+	return template, ok
+}
+
+// occurrence counts how often one normalized value appears across meetings
+type occurrence struct {
+	key           string
+	spellings     map[string]int // Original spellings of the value and how often each was used
+	meetings      int            // Meetings the value appeared in
+	mentions      int            // Appearances across all meetings
+	totalDuration float64
+	totalPosition float64 // Sum of each meeting's first relative position of the value, 0 to 1
+}
+
+// name returns the most used spelling of the value, the first alphabetically on ties
+func (o *occurrence) name() string {
+	best := ""
+	for spelling, count := range o.spellings {
+		if best == "" || count > o.spellings[best] || (count == o.spellings[best] && spelling < best) {
+			best = spelling
+		}
+	}
+	return best
+}
+
+// averagePosition returns where in a meeting the value usually first comes up, from 0 to 1
+func (o *occurrence) averagePosition() float64 {
+	return o.totalPosition / float64(o.meetings)
+}
+
+// occurrences counts values across meetings, each value counting once per meeting towards its frequency
+type occurrences struct {
+	byKey   map[string]*occurrence
+	order   []string        // Keys in the order first seen
+	current map[string]bool // Keys seen in the meeting being counted
+}
+
+func newOccurrences() *occurrences {
+	return &occurrences{byKey: make(map[string]*occurrence), current: make(map[string]bool)}
+}
+
+// add counts a value of the meeting being counted, with the time spent on it and its relative position
+func (o *occurrences) add(value string, duration, position float64) {
+	key := normalize(value)
+	if key == "" {
+		return
+	}
+
+	item, ok := o.byKey[key]
+	if !ok {
+		item = &occurrence{key: key, spellings: make(map[string]int)}
+		o.byKey[key] = item
+		o.order = append(o.order, key)
+	}
+	item.spellings[strings.TrimSpace(value)]++
+	item.mentions++
+	item.totalDuration += duration
+	if !o.current[key] {
+		o.current[key] = true
+		item.meetings++
+		item.totalPosition += position
+	}
+}
+
+// endMeeting finishes counting the current meeting
+func (o *occurrences) endMeeting() {
+	o.current = make(map[string]bool)
+}
+
+// common returns the values appearing in at least minItemFrequency of the meetings, most frequent first
+func (o *occurrences) common(meetings int) []*occurrence {
+	var items []*occurrence
+	for _, key := range o.order {
+		if item := o.byKey[key]; float64(item.meetings)/float64(meetings) >= minItemFrequency {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].meetings > items[j].meetings })
+	return items
+}
+
+// normalize lowercases a value and reduces it to words separated by single spaces, so differently
+// punctuated or capitalized spellings of a topic are counted together
+func normalize(value string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}), " ")
+}
+
+// templateName turns a meeting type such as "sprint_planning" into a name such as "Sprint Planning"
+func templateName(meetingType string) string {
+	words := strings.Fields(strings.ReplaceAll(meetingType, "_", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// median returns the middle value, the lower of the two middle values for an even count
+func median(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"joinly-manager/internal/client"
+)
+
+// standups returns ten standups: updates come up in all of them, blockers in six and demos in five
+func standups() []client.AnalysisData {
+	meetings := make([]client.AnalysisData, 10)
+	for i := range meetings {
+		topics := []client.TopicDiscussion{{Topic: "Team updates", Duration: 10}}
+		if i < 6 {
+			topics = append(topics, client.TopicDiscussion{Topic: "blockers!", Duration: 4})
+		}
+		if i < 5 {
+			// Demos come first, so only their frequency keeps them off the agenda
+			topics = append([]client.TopicDiscussion{{Topic: "Demo"}}, topics...)
+		}
+
+		actionType := "task"
+		if i < 7 {
+			actionType = "decision"
+		}
+		personas := map[string]client.SpeakerPersona{"Alice": {InferredRole: "Engineer"}, "Bob": {InferredRole: "unknown"}}
+		if i < 4 {
+			personas["Carol"] = client.SpeakerPersona{InferredRole: "Manager"}
+		}
+
+		meetings[i] = client.AnalysisData{
+			MeetingType:     "daily_standup",
+			Topics:          topics,
+			ActionItems:     []client.ActionItem{{Description: "Item", Type: actionType}},
+			Participants:    make([]string, 3+i%2),
+			DurationMinutes: float64(10 + i),
+			SpeakerPersonas: personas,
+		}
+	}
+	// Updates are spelled differently in one meeting
+	meetings[9].Topics[0].Topic = "team updates."
+	return meetings
+}
+
+func TestLearnKeepsItemsInMostMeetings(t *testing.T) {
+	meetings := standups()
+	template, err := NewTemplateLearner().Learn(meetings)
+	if err != nil {
+		t.Fatalf("Learn() error = %v", err)
+	}
+
+	if template.Name != "Daily Standup" || template.MeetingsAnalyzed != 10 || template.TypicalDuration != 14.5 || template.TypicalParticipantCount != 3 {
+		t.Errorf("template = %+v", template)
+	}
+	wantAgenda := []AgendaItem{
+		{Title: "Team updates", DurationMinutes: 10, Frequency: 1},
+		{Title: "blockers!", DurationMinutes: 4, Frequency: 0.6},
+	}
+	if !reflect.DeepEqual(template.SuggestedAgenda, wantAgenda) {
+		t.Errorf("agenda = %+v, want %+v", template.SuggestedAgenda, wantAgenda)
+	}
+	if !reflect.DeepEqual(template.CommonActionTypes, []string{"decision"}) {
+		t.Errorf("action types = %v, want decision only", template.CommonActionTypes)
+	}
+	if !reflect.DeepEqual(template.RecommendedParticipantRoles, []string{"Engineer"}) {
+		t.Errorf("roles = %v, want Engineer only", template.RecommendedParticipantRoles)
+	}
+
+	// Every item of the template appears in at least 60% of the meetings
+	for _, item := range template.SuggestedAgenda {
+		count := 0
+		for _, meeting := range meetings {
+			for _, topic := range meeting.Topics {
+				if normalize(topic.Topic) == normalize(item.Title) {
+					count++
+					break
+				}
+			}
+		}
+		if float64(count)/float64(len(meetings)) < minItemFrequency {
+			t.Errorf("agenda item %q appears in %d of %d meetings", item.Title, count, len(meetings))
+		}
+	}
+}
+
+func TestLearnNeedsEnoughMeetings(t *testing.T) {
+	if _, err := NewTemplateLearner().Learn(standups()[:9]); !errors.Is(err, ErrNotEnoughMeetings) {
+		t.Errorf("Learn() error = %v, want ErrNotEnoughMeetings", err)
+	}
+}
+
+func TestTemplateLearnerAdd(t *testing.T) {
+	learner := NewTemplateLearner()
+	if learner.Add(client.AnalysisData{}) {
+		t.Error("Add() learned a template for a meeting without a type")
+	}
+
+	for i, meeting := range standups() {
+		if learned := learner.Add(meeting); learned != (i == 9) {
+			t.Errorf("Add() of meeting %d = %v", i+1, learned)
+		}
+	}
+	template, ok := learner.Template("daily_standup")
+	if !ok || template.MeetingsAnalyzed != 10 {
+		t.Errorf("Template() = %+v, %v", template, ok)
+	}
+	if templates := learner.Templates(); len(templates) != 1 || templates[0].MeetingType != "daily_standup" {
+		t.Errorf("Templates() = %+v", templates)
+	}
+}
@@ -0,0 +1,134 @@
+// Package webhook verifies the signatures of payloads posted by external transcript sources
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the request header carrying a payload's signature
+const SignatureHeader = "X-Webhook-Signature"
+
+const signaturePrefix = "sha256="
+
+// SignatureVerificationError is returned when a payload's signature is missing or doesn't match
+type SignatureVerificationError struct {
+	Reason string `json:"reason"`
+}
+
+func (e *SignatureVerificationError) Error() string {
+	return "webhook signature verification failed: " + e.Reason
+}
+
+// MarshalJSON encodes the error as the body of a rejected request: the message under "error" like other
+// API errors, with a "type" clients can match on and the reason
+func (e *SignatureVerificationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Error  string `json:"error"`
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}{e.Error(), "SignatureVerificationError", e.Reason})
+}
+
+// Sign returns the signature header value for payload: "sha256=" followed by the hex HMAC-SHA256 of the
+// payload keyed with secret
+func Sign(secret string, payload []byte) string {
+	return signaturePrefix + hex.EncodeToString(payloadMAC(secret, payload))
+}
+
+// VerifyWebhookSignature checks that header is the signature of payload under secret, comparing in
+// constant time so the expected signature can't be discovered from response times
+func VerifyWebhookSignature(secret string, payload []byte, header string) error {
+	if header == "" {
+		return &SignatureVerificationError{Reason: "missing " + SignatureHeader + " header"}
+	}
+
+	encoded, ok := strings.CutPrefix(header, signaturePrefix)
+	if !ok {
+		return &SignatureVerificationError{Reason: "signature must start with " + signaturePrefix}
+	}
+	signature, err := hex.DecodeString(encoded)
+	if err != nil {
+		return &SignatureVerificationError{Reason: "signature is not hex encoded"}
+	}
+
+	if subtle.ConstantTimeCompare(signature, payloadMAC(secret, payload)) != 1 {
+		return &SignatureVerificationError{Reason: "signature does not match payload"}
+	}
+	return nil
+}
+
+// ZoomSignatureHeader and ZoomTimestampHeader carry the signature of Zoom webhook requests
+const (
+	ZoomSignatureHeader = "x-zm-signature"
+	ZoomTimestampHeader = "x-zm-request-timestamp"
+)
+
+const zoomSignaturePrefix = "v0="
+
+// ZoomTimestampTolerance is how far a Zoom request's timestamp may be from now. The timestamp is signed with
+// the payload, so rejecting old ones stops captured requests from being replayed later.
+const ZoomTimestampTolerance = 5 * time.Minute
+
+// VerifyZoomSignature checks a Zoom webhook signature: "v0=" followed by the hex HMAC-SHA256 of
+// "v0:{timestamp}:{payload}" keyed with the app's secret token. timestamp is in Unix seconds and must be
+// within ZoomTimestampTolerance of now.
+func VerifyZoomSignature(secret string, payload []byte, timestamp, header string) error {
+	if header == "" || timestamp == "" {
+		return &SignatureVerificationError{Reason: "missing " + ZoomSignatureHeader + " or " + ZoomTimestampHeader + " header"}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return &SignatureVerificationError{Reason: "timestamp is not in Unix seconds"}
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > ZoomTimestampTolerance || age < -ZoomTimestampTolerance {
+		return &SignatureVerificationError{Reason: "timestamp is more than " + ZoomTimestampTolerance.String() + " from now"}
+	}
+
+	encoded, ok := strings.CutPrefix(header, zoomSignaturePrefix)
+	if !ok {
+		return &SignatureVerificationError{Reason: "signature must start with " + zoomSignaturePrefix}
+	}
+	signature, err := hex.DecodeString(encoded)
+	if err != nil {
+		return &SignatureVerificationError{Reason: "signature is not hex encoded"}
+	}
+
+	message := append([]byte("v0:"+timestamp+":"), payload...)
+	if subtle.ConstantTimeCompare(signature, payloadMAC(secret, message)) != 1 {
+		return &SignatureVerificationError{Reason: "signature does not match payload"}
+	}
+	return nil
+}
+
+// ZoomURLValidationEvent is the event Zoom posts to check that a webhook endpoint belongs to the app
+const ZoomURLValidationEvent = "endpoint.url_validation"
+
+// ZoomURLValidation is the answer to Zoom's endpoint.url_validation challenge
+type ZoomURLValidation struct {
+	PlainToken     string `json:"plainToken"`
+	EncryptedToken string `json:"encryptedToken"`
+}
+
+// ValidateZoomURL answers the challenge for plainToken: the token with its hex HMAC-SHA256 keyed with the
+// app's secret token
+func ValidateZoomURL(secret, plainToken string) ZoomURLValidation {
+	return ZoomURLValidation{
+		PlainToken:     plainToken,
+		EncryptedToken: hex.EncodeToString(payloadMAC(secret, []byte(plainToken))),
+	}
+}
+
+// payloadMAC computes the HMAC-SHA256 of payload keyed with secret
+func payloadMAC(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"speaker": "Alice", "text": "Hello"}`)
+	signature := Sign("secret", payload)
+	if !strings.HasPrefix(signature, "sha256=") {
+		t.Fatalf("Sign() = %q, want a sha256= prefix", signature)
+	}
+	if err := VerifyWebhookSignature("secret", payload, signature); err != nil {
+		t.Errorf("VerifyWebhookSignature() = %v for a valid signature", err)
+	}
+
+	tests := []struct {
+		name, secret, header, want string
+		payload                    []byte
+	}{
+		{"missing", "secret", "", "missing", payload},
+		{"wrong prefix", "secret", strings.Replace(signature, "sha256=", "sha1=", 1), "must start with", payload},
+		{"not hex", "secret", "sha256=zz", "not hex", payload},
+		{"wrong secret", "other", signature, "does not match", payload},
+		{"tampered payload", "secret", signature, "does not match", []byte(`{"speaker": "Mallory"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.payload, tt.header)
+			var verificationErr *SignatureVerificationError
+			if !errors.As(err, &verificationErr) || !strings.Contains(verificationErr.Reason, tt.want) {
+				t.Errorf("VerifyWebhookSignature() = %v, want a reason containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignatureVerificationErrorJSON(t *testing.T) {
+	raw, err := json.Marshal(&SignatureVerificationError{Reason: "signature does not match payload"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]string
+	json.Unmarshal(raw, &body)
+	if body["type"] != "SignatureVerificationError" || body["reason"] != "signature does not match payload" || !strings.Contains(body["error"], "verification failed") {
+		t.Errorf("body = %s", raw)
+	}
+}
+
+// zoomSignature returns the signature Zoom sends for payload at timestamp
+func zoomSignature(secret, timestamp string, payload []byte) string {
+	// Zoom signs "v0:{timestamp}:{payload}"
+	return "v0=" + strings.TrimPrefix(Sign(secret, append([]byte("v0:"+timestamp+":"), payload...)), "sha256=")
+}
+
+func TestVerifyZoomSignature(t *testing.T) {
+	payload := []byte(`{"event": "meeting.started"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := zoomSignature("token", now, payload)
+
+	if err := VerifyZoomSignature("token", payload, now, signature); err != nil {
+		t.Errorf("VerifyZoomSignature() = %v for a valid signature", err)
+	}
+
+	stale := strconv.FormatInt(time.Now().Add(-2*ZoomTimestampTolerance).Unix(), 10)
+	tests := []struct {
+		name, timestamp, header, want string
+	}{
+		{"another timestamp", strconv.FormatInt(time.Now().Unix()+1, 10), signature, "does not match"},
+		{"missing timestamp", "", signature, "missing"},
+		{"timestamp not in seconds", "yesterday", zoomSignature("token", "yesterday", payload), "Unix seconds"},
+		{"stale timestamp", stale, zoomSignature("token", stale, payload), "from now"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyZoomSignature("token", payload, tt.timestamp, tt.header)
+			var verificationErr *SignatureVerificationError
+			if !errors.As(err, &verificationErr) || !strings.Contains(verificationErr.Reason, tt.want) {
+				t.Errorf("VerifyZoomSignature() = %v, want a reason containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateZoomURL(t *testing.T) {
+	validation := ValidateZoomURL("token", "plain")
+	if validation.PlainToken != "plain" || "sha256="+validation.EncryptedToken != Sign("token", []byte("plain")) {
+		t.Errorf("ValidateZoomURL() = %+v", validation)
+	}
+}